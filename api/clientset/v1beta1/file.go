@@ -0,0 +1,571 @@
+package v1beta1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/fsnotify/fsnotify"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/yaml"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const topologyFileExt = ".yaml"
+
+// FileClientset reads topologies from YAML files instead of the K8s API, so that meshnet can
+// run without a cluster. The topology name of namespace ns is the file <dir>/<ns>/<name>.yaml,
+// and its status is written back to it. The resource version of a topology is a hash of its
+// file, so that writes of a topology that has been changed since it was read, by meshnet or by
+// hand, fail with a conflict. The other resources aren't read from files: the node statuses
+// are kept in memory, and there are none of the others and they can't be created.
+type FileClientset struct {
+	dir string
+	// serializes the writes of the files
+	mu           sync.Mutex
+	nodeStatuses map[string]*topologyv1.NodeTopologyStatus
+}
+
+// NewFileClientset returns a clientset reading the topologies of the directory dir
+func NewFileClientset(dir string) (*FileClientset, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	return &FileClientset{dir: dir, nodeStatuses: make(map[string]*topologyv1.NodeTopologyStatus)}, nil
+}
+
+func (c *FileClientset) Topology(namespace string) TopologyInterface {
+	return &FileTopologyClient{c: c, ns: namespace}
+}
+
+func (c *FileClientset) ChaosProfile(namespace string) ChaosProfileInterface {
+	return fileChaosProfiles{}
+}
+
+func (c *FileClientset) LinkProfile(namespace string) LinkProfileInterface {
+	return fileLinkProfiles{}
+}
+
+func (c *FileClientset) NodeTopologyStatus() NodeTopologyStatusInterface {
+	return fileNodeStatuses{c: c}
+}
+
+func (c *FileClientset) TopologyTemplate(namespace string) TopologyTemplateInterface {
+	return fileTemplates{}
+}
+
+func (c *FileClientset) GlobalConfig(ctx context.Context) (*topologyv1.MeshnetConfig, error) {
+	return nil, nil
+}
+
+func (c *FileClientset) NamespaceQuota(ctx context.Context, namespace string) (*topologyv1.MeshnetNamespaceQuota, error) {
+	return nil, nil
+}
+
+// FileTopologyClient implements TopologyInterface for a namespace of a FileClientset, all
+// namespaces if empty
+type FileTopologyClient struct {
+	c  *FileClientset
+	ns string
+}
+
+var topologyResource = gvr.GroupResource()
+
+func (t *FileTopologyClient) path(name string) string {
+	return filepath.Join(t.c.dir, t.ns, name+topologyFileExt)
+}
+
+// readTopologyFile reads the topology of the file path of namespace ns
+func readTopologyFile(path, ns string) (*unstructured.Unstructured, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	// integers are decoded as int64, as by the API client, rather than float64
+	if err := utiljson.Unmarshal(doc, &obj.Object); err != nil || obj.Object == nil {
+		return nil, fmt.Errorf("%s is not a topology", path)
+	}
+	if obj.GetAPIVersion() == "" {
+		obj.SetAPIVersion(topologyv1.SchemeGroupVersion.String())
+	}
+	if obj.GetKind() == "" {
+		obj.SetKind("Topology")
+	}
+	// the file is where the topology is
+	obj.SetName(strings.TrimSuffix(filepath.Base(path), topologyFileExt))
+	obj.SetNamespace(ns)
+	sum := sha256.Sum256(data)
+	obj.SetResourceVersion(hex.EncodeToString(sum[:8]))
+	return obj, nil
+}
+
+func (t *FileTopologyClient) read(name string) (*unstructured.Unstructured, error) {
+	obj, err := readTopologyFile(t.path(name), t.ns)
+	if os.IsNotExist(err) {
+		return nil, apierrors.NewNotFound(topologyResource, name)
+	}
+	return obj, err
+}
+
+// write replaces the file of the topology obj, through a temporary file so that readers never
+// see it partially written
+func (t *FileTopologyClient) write(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	obj = obj.DeepCopy()
+	obj.SetNamespace("")
+	obj.SetResourceVersion("")
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	path := t.path(obj.GetName())
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+obj.GetName())
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	return t.read(obj.GetName())
+}
+
+// namespaces returns the namespaces of the client, the directories of the clientset if it has
+// none
+func (t *FileTopologyClient) namespaces() ([]string, error) {
+	if t.ns != "" {
+		return []string{t.ns}, nil
+	}
+	entries, err := ioutil.ReadDir(t.c.dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			result = append(result, e.Name())
+		}
+	}
+	return result, nil
+}
+
+func (t *FileTopologyClient) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyList, error) {
+	namespaces, err := t.namespaces()
+	if err != nil {
+		return nil, err
+	}
+	result := &topologyv1.TopologyList{}
+	for _, ns := range namespaces {
+		paths, err := filepath.Glob(filepath.Join(t.c.dir, ns, "*"+topologyFileExt))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			obj, err := readTopologyFile(path, ns)
+			if err != nil {
+				return nil, err
+			}
+			topology, err := toTopology(obj)
+			if err != nil {
+				return nil, err
+			}
+			result.Items = append(result.Items, *topology)
+		}
+	}
+	return result, nil
+}
+
+func (t *FileTopologyClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.Topology, error) {
+	obj, err := t.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return toTopology(obj)
+}
+
+func (t *FileTopologyClient) Create(ctx context.Context, topology *topologyv1.Topology) (*topologyv1.Topology, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(topology)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	if _, err := os.Stat(t.path(obj.GetName())); err == nil {
+		return nil, apierrors.NewAlreadyExists(topologyResource, obj.GetName())
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path(obj.GetName())), 0755); err != nil {
+		return nil, err
+	}
+	if obj, err = t.write(obj); err != nil {
+		return nil, err
+	}
+	return toTopology(obj)
+}
+
+func (t *FileTopologyClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	if err := os.Remove(t.path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return apierrors.NewNotFound(topologyResource, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *FileTopologyClient) Unstructured(ctx context.Context, name string, opts metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return t.read(name)
+}
+
+// Update updates everything but the status of a topology, as the API server does
+func (t *FileTopologyClient) Update(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error) {
+	return t.update(obj, func(current *unstructured.Unstructured) *unstructured.Unstructured {
+		updated := obj.DeepCopy()
+		if status, ok := current.Object["status"]; ok {
+			updated.Object["status"] = runtime.DeepCopyJSONValue(status)
+		} else {
+			delete(updated.Object, "status")
+		}
+		return updated
+	})
+}
+
+// UpdateStatus writes the status of a topology back to its file
+func (t *FileTopologyClient) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error) {
+	return t.update(obj, func(current *unstructured.Unstructured) *unstructured.Unstructured {
+		updated := current.DeepCopy()
+		if status, ok := obj.Object["status"]; ok {
+			updated.Object["status"] = runtime.DeepCopyJSONValue(status)
+		} else {
+			delete(updated.Object, "status")
+		}
+		return updated
+	})
+}
+
+// update writes the object built by merge from the current one, if obj isn't stale
+func (t *FileTopologyClient) update(obj *unstructured.Unstructured, merge func(current *unstructured.Unstructured) *unstructured.Unstructured) (*topologyv1.Topology, error) {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	current, err := t.read(obj.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if obj.GetResourceVersion() != current.GetResourceVersion() {
+		return nil, apierrors.NewConflict(topologyResource, obj.GetName(),
+			fmt.Errorf("the file has been modified, resource version %s, expected %s", obj.GetResourceVersion(), current.GetResourceVersion()))
+	}
+	updated, err := t.write(merge(current))
+	if err != nil {
+		return nil, err
+	}
+	return toTopology(updated)
+}
+
+func (t *FileTopologyClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	current, err := t.read(name)
+	if err != nil {
+		return nil, err
+	}
+	current.SetResourceVersion("")
+	doc, err := json.Marshal(current.Object)
+	if err != nil {
+		return nil, err
+	}
+	switch pt {
+	case types.JSONPatchType:
+		var patch jsonpatch.Patch
+		if patch, err = jsonpatch.DecodePatch(data); err == nil {
+			doc, err = patch.Apply(doc)
+		}
+	case types.MergePatchType:
+		doc, err = jsonpatch.MergePatch(doc, data)
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported patch type %s", pt))
+	}
+	if err != nil {
+		// a failed test operation is rejected as invalid by the API server
+		return nil, apierrors.NewInvalid(topologyv1.SchemeGroupVersion.WithKind("Topology").GroupKind(), name, nil)
+	}
+	patched := &unstructured.Unstructured{}
+	if err := patched.UnmarshalJSON(doc); err != nil {
+		return nil, err
+	}
+	return t.write(patched)
+}
+
+// Watch watches the files of the topologies with fsnotify. Like a watch of the API server
+// without a resource version, it starts with an Added event for each existing topology. Files
+// that can't be parsed, e.g. while they are being edited, are skipped until they can.
+func (t *FileTopologyClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// the directory of the clientset is watched for the namespaces created later
+	if err := w.Add(t.c.dir); err != nil {
+		w.Close()
+		return nil, err
+	}
+	namespaces, err := t.namespaces()
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+	// topologies last sent by path
+	known := make(map[string]*topologyv1.Topology)
+	var initial []watch.Event
+	for _, ns := range namespaces {
+		dir := filepath.Join(t.c.dir, ns)
+		if err := w.Add(dir); err != nil {
+			if os.IsNotExist(err) && t.ns != "" {
+				continue
+			}
+			w.Close()
+			return nil, err
+		}
+		paths, _ := filepath.Glob(filepath.Join(dir, "*"+topologyFileExt))
+		sort.Strings(paths)
+		for _, path := range paths {
+			if topology, err := readTopology(path, ns); err == nil {
+				known[path] = topology
+				initial = append(initial, watch.Event{Type: watch.Added, Object: topology})
+			}
+		}
+	}
+
+	ch := make(chan watch.Event)
+	pw := watch.NewProxyWatcher(ch)
+	send := func(e watch.Event) bool {
+		select {
+		case ch <- e:
+			return true
+		case <-pw.StopChan():
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(ch)
+		defer w.Close()
+		for _, e := range initial {
+			if !send(e) {
+				return
+			}
+		}
+		for {
+			var e fsnotify.Event
+			select {
+			case <-pw.StopChan():
+				return
+			case <-ctx.Done():
+				return
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				send(watch.Event{Type: watch.Error, Object: &apierrors.NewInternalError(err).ErrStatus})
+				continue
+			case e = <-w.Events:
+			}
+
+			dir, base := filepath.Split(e.Name)
+			if strings.HasPrefix(base, ".") {
+				continue
+			}
+			if filepath.Clean(dir) == filepath.Clean(t.c.dir) {
+				// a new namespace, whose files may have been written before it's watched
+				if info, err := os.Stat(e.Name); err == nil && info.IsDir() && e.Op&fsnotify.Create != 0 && (t.ns == "" || base == t.ns) {
+					w.Add(e.Name)
+					paths, _ := filepath.Glob(filepath.Join(e.Name, "*"+topologyFileExt))
+					sort.Strings(paths)
+					for _, path := range paths {
+						if event, ok := fileEvent(known, path, base); ok && !send(event) {
+							return
+						}
+					}
+				}
+				continue
+			}
+			if !strings.HasSuffix(base, topologyFileExt) {
+				continue
+			}
+			ns := filepath.Base(dir)
+			if t.ns != "" && ns != t.ns {
+				continue
+			}
+			event, ok := fileEvent(known, e.Name, ns)
+			if ok && !send(event) {
+				return
+			}
+		}
+	}()
+	return pw, nil
+}
+
+// fileEvent returns the event of a change of the topology file path, if any, and records
+// what has been sent in known
+func fileEvent(known map[string]*topologyv1.Topology, path, ns string) (watch.Event, bool) {
+	last := known[path]
+	topology, err := readTopology(path, ns)
+	if os.IsNotExist(err) {
+		if last == nil {
+			return watch.Event{}, false
+		}
+		delete(known, path)
+		return watch.Event{Type: watch.Deleted, Object: last}, true
+	}
+	if err != nil || (last != nil && last.ResourceVersion == topology.ResourceVersion) {
+		return watch.Event{}, false
+	}
+	known[path] = topology
+	if last == nil {
+		return watch.Event{Type: watch.Added, Object: topology}, true
+	}
+	return watch.Event{Type: watch.Modified, Object: topology}, true
+}
+
+func readTopology(path, ns string) (*topologyv1.Topology, error) {
+	obj, err := readTopologyFile(path, ns)
+	if err != nil {
+		return nil, err
+	}
+	return toTopology(obj)
+}
+
+func fileResource(resource string) schema.GroupResource {
+	return schema.GroupResource{Group: gvr.Group, Resource: resource}
+}
+
+// errFileMode is returned by the writes of the resources that can't be read from files
+func errFileMode(resource string) error {
+	return apierrors.NewMethodNotSupported(fileResource(resource), "writing without K8s")
+}
+
+type fileChaosProfiles struct{}
+
+func (fileChaosProfiles) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.ChaosProfileList, error) {
+	return &topologyv1.ChaosProfileList{}, nil
+}
+
+func (fileChaosProfiles) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.ChaosProfile, error) {
+	return nil, apierrors.NewNotFound(fileResource("chaosprofiles"), name)
+}
+
+func (fileChaosProfiles) Create(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error) {
+	return nil, errFileMode("chaosprofiles")
+}
+
+func (fileChaosProfiles) Update(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error) {
+	return nil, errFileMode("chaosprofiles")
+}
+
+func (fileChaosProfiles) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return apierrors.NewNotFound(fileResource("chaosprofiles"), name)
+}
+
+type fileLinkProfiles struct{}
+
+func (fileLinkProfiles) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.LinkProfileList, error) {
+	return &topologyv1.LinkProfileList{}, nil
+}
+
+func (fileLinkProfiles) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.LinkProfile, error) {
+	return nil, apierrors.NewNotFound(fileResource("linkprofiles"), name)
+}
+
+func (fileLinkProfiles) Create(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error) {
+	return nil, errFileMode("linkprofiles")
+}
+
+func (fileLinkProfiles) Update(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error) {
+	return nil, errFileMode("linkprofiles")
+}
+
+func (fileLinkProfiles) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return apierrors.NewNotFound(fileResource("linkprofiles"), name)
+}
+
+// fileNodeStatuses keeps the node statuses in memory
+type fileNodeStatuses struct {
+	c *FileClientset
+}
+
+func (f fileNodeStatuses) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.NodeTopologyStatusList, error) {
+	f.c.mu.Lock()
+	defer f.c.mu.Unlock()
+	result := &topologyv1.NodeTopologyStatusList{}
+	var names []string
+	for name := range f.c.nodeStatuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.Items = append(result.Items, *f.c.nodeStatuses[name].DeepCopy())
+	}
+	return result, nil
+}
+
+func (f fileNodeStatuses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.NodeTopologyStatus, error) {
+	f.c.mu.Lock()
+	defer f.c.mu.Unlock()
+	if status, ok := f.c.nodeStatuses[name]; ok {
+		return status.DeepCopy(), nil
+	}
+	return nil, apierrors.NewNotFound(fileResource("nodetopologystatuses"), name)
+}
+
+func (f fileNodeStatuses) Apply(ctx context.Context, status *topologyv1.NodeTopologyStatus, fieldManager string) (*topologyv1.NodeTopologyStatus, error) {
+	f.c.mu.Lock()
+	defer f.c.mu.Unlock()
+	f.c.nodeStatuses[status.Name] = status.DeepCopy()
+	return status.DeepCopy(), nil
+}
+
+type fileTemplates struct{}
+
+func (fileTemplates) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyTemplateList, error) {
+	return &topologyv1.TopologyTemplateList{}, nil
+}
+
+func (fileTemplates) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.TopologyTemplate, error) {
+	return nil, apierrors.NewNotFound(fileResource("topologytemplates"), name)
+}
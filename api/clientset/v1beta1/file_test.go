@@ -0,0 +1,154 @@
+package v1beta1
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const r1 = `apiVersion: networkop.co.uk/v1beta1
+kind: Topology
+metadata:
+  name: r1
+spec:
+  links:
+  - uid: 1
+    peer_pod: r2
+    local_intf: eth1
+    peer_intf: eth1
+`
+
+func TestFileTopologyClient(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "topologies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "lab"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "lab", "r1.yaml")
+	if err := ioutil.WriteFile(path, []byte(r1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewFileClientset(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := c.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Namespace != "lab" || len(list.Items[0].Spec.Links) != 1 {
+		t.Fatalf("List() = %+v, want r1 of namespace lab", list.Items)
+	}
+	if _, err := c.Topology("lab").Get(ctx, "r2", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get(r2) = %v, want NotFound", err)
+	}
+
+	obj, err := c.Topology("lab").Unstructured(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// integers are read as the API client reads them
+	links, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+	if uid, ok, _ := unstructured.NestedInt64(links[0].(map[string]interface{}), "uid"); !ok || uid == 0 {
+		t.Errorf("uid of the link of r1 = %v, want an int64", links[0].(map[string]interface{})["uid"])
+	}
+	stale := obj.DeepCopy()
+	if err := unstructured.SetNestedField(obj.Object, "10.0.0.1", "status", "src_ip"); err != nil {
+		t.Fatal(err)
+	}
+	// the spec isn't changed by a status update
+	unstructured.RemoveNestedField(obj.Object, "spec")
+	if _, err := c.Topology("lab").UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("UpdateStatus() = %v", err)
+	}
+	if _, err := c.Topology("lab").UpdateStatus(ctx, stale, metav1.UpdateOptions{}); !apierrors.IsConflict(err) {
+		t.Errorf("UpdateStatus() of a stale topology = %v, want a conflict", err)
+	}
+	got, err := c.Topology("lab").Get(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.SrcIp != "10.0.0.1" || len(got.Spec.Links) != 1 {
+		t.Errorf("r1 after UpdateStatus() = %+v, want its src_ip and links", got)
+	}
+
+	if _, err := c.ChaosProfile("lab").Create(ctx, &topologyv1.ChaosProfile{}); err == nil {
+		t.Errorf("ChaosProfile().Create() = nil, want an error")
+	}
+}
+
+func TestFileTopologyClientWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "topologies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "lab"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "lab", "r1.yaml")
+	if err := ioutil.WriteFile(path, []byte(r1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewFileClientset(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := c.Topology("").Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	next := func(want watch.EventType, name string) {
+		t.Helper()
+		select {
+		case e := <-w.ResultChan():
+			if topology, ok := e.Object.(*topologyv1.Topology); !ok || e.Type != want || topology.Name != name {
+				t.Fatalf("event = %s %v, want %s of %s", e.Type, e.Object, want, name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %s event of %s", want, name)
+		}
+	}
+
+	next(watch.Added, "r1")
+	if err := os.Mkdir(filepath.Join(dir, "other"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Topology("other").Create(ctx, &topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: "r2"}}); err != nil {
+		t.Fatal(err)
+	}
+	next(watch.Added, "r2")
+	obj, err := c.Topology("lab").Unstructured(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unstructured.SetNestedField(obj.Object, "10.0.0.1", "status", "src_ip"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Topology("lab").UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	next(watch.Modified, "r1")
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	next(watch.Deleted, "r1")
+}
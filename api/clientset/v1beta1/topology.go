@@ -16,12 +16,15 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -38,12 +41,58 @@ type TopologyInterface interface {
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 	Unstructured(ctx context.Context, name string, opts metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error)
+	// Update replaces the spec and metadata of a topology, its status is left as it is
 	Update(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error)
+	// UpdateStatus replaces the status of a topology through the status subresource, so
+	// that it doesn't conflict with concurrent updates of the spec
+	UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error)
+}
+
+// ChaosProfileInterface provides access to the ChaosProfile CRD.
+type ChaosProfileInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.ChaosProfileList, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.ChaosProfile, error)
+	Create(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error)
+	Update(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// LinkProfileInterface provides access to the LinkProfile CRD.
+type LinkProfileInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.LinkProfileList, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.LinkProfile, error)
+	Create(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error)
+	Update(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// NodeTopologyStatusInterface provides access to the cluster-scoped NodeTopologyStatus CRD.
+type NodeTopologyStatusInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.NodeTopologyStatusList, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.NodeTopologyStatus, error)
+	// Apply creates or updates status with a server-side apply by fieldManager, which
+	// takes over the fields of status from other managers
+	Apply(ctx context.Context, status *topologyv1.NodeTopologyStatus, fieldManager string) (*topologyv1.NodeTopologyStatus, error)
+}
+
+// TopologyTemplateInterface provides access to the TopologyTemplate CRD.
+type TopologyTemplateInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyTemplateList, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.TopologyTemplate, error)
 }
 
 // Interface is the clientset interface for topology.
 type Interface interface {
 	Topology(namespace string) TopologyInterface
+	ChaosProfile(namespace string) ChaosProfileInterface
+	LinkProfile(namespace string) LinkProfileInterface
+	NodeTopologyStatus() NodeTopologyStatusInterface
+	TopologyTemplate(namespace string) TopologyTemplateInterface
+	// GlobalConfig returns the cluster-wide link defaults, or nil if there are none.
+	GlobalConfig(ctx context.Context) (*topologyv1.MeshnetConfig, error)
+	// NamespaceQuota returns the quota of a namespace, or nil if it has none.
+	NamespaceQuota(ctx context.Context, namespace string) (*topologyv1.MeshnetNamespaceQuota, error)
 }
 
 // Clientset is a client for the topology crds.
@@ -88,6 +137,65 @@ func (c *Clientset) Topology(namespace string) TopologyInterface {
 	}
 }
 
+func (c *Clientset) ChaosProfile(namespace string) ChaosProfileInterface {
+	return &chaosProfileClient{
+		restClient: c.restClient,
+		ns:         namespace,
+	}
+}
+
+func (c *Clientset) LinkProfile(namespace string) LinkProfileInterface {
+	return &linkProfileClient{
+		restClient: c.restClient,
+		ns:         namespace,
+	}
+}
+
+func (c *Clientset) NodeTopologyStatus() NodeTopologyStatusInterface {
+	return &nodeTopologyStatusClient{restClient: c.restClient}
+}
+
+func (c *Clientset) TopologyTemplate(namespace string) TopologyTemplateInterface {
+	return &topologyTemplateClient{
+		restClient: c.restClient,
+		ns:         namespace,
+	}
+}
+
+func (c *Clientset) GlobalConfig(ctx context.Context) (*topologyv1.MeshnetConfig, error) {
+	result := topologyv1.MeshnetConfig{}
+	err := c.restClient.
+		Get().
+		Resource("meshnetconfigs").
+		Name(topologyv1.MeshnetConfigName).
+		Do(ctx).
+		Into(&result)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Clientset) NamespaceQuota(ctx context.Context, namespace string) (*topologyv1.MeshnetNamespaceQuota, error) {
+	result := topologyv1.MeshnetNamespaceQuota{}
+	err := c.restClient.
+		Get().
+		Resource("meshnetnamespacequotas").
+		Name(namespace).
+		Do(ctx).
+		Into(&result)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 type topologyClient struct {
 	dInterface dynamic.NamespaceableResourceInterface
 	restClient rest.Interface
@@ -156,12 +264,24 @@ func (t *topologyClient) Delete(ctx context.Context, name string, opts metav1.De
 }
 
 func (t *topologyClient) Update(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error) {
-	result := topologyv1.Topology{}
-	obj, err := t.dInterface.Namespace(t.ns).UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+	obj, err := t.dInterface.Namespace(t.ns).Update(ctx, obj, opts)
 	if err != nil {
 		return nil, err
 	}
-	err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &result)
+	return toTopology(obj)
+}
+
+func (t *topologyClient) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error) {
+	obj, err := t.dInterface.Namespace(t.ns).UpdateStatus(ctx, obj, opts)
+	if err != nil {
+		return nil, err
+	}
+	return toTopology(obj)
+}
+
+func toTopology(obj *unstructured.Unstructured) (*topologyv1.Topology, error) {
+	result := topologyv1.Topology{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to type assert return to topology")
 	}
@@ -172,6 +292,233 @@ func (t *topologyClient) Unstructured(ctx context.Context, name string, opts met
 	return t.dInterface.Namespace(t.ns).Get(ctx, name, opts, subresources...)
 }
 
+func (t *topologyClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return t.dInterface.Namespace(t.ns).Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+type chaosProfileClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+func (c *chaosProfileClient) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.ChaosProfileList, error) {
+	result := topologyv1.ChaosProfileList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("chaosprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *chaosProfileClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.ChaosProfile, error) {
+	result := topologyv1.ChaosProfile{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("chaosprofiles").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *chaosProfileClient) Create(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error) {
+	result := topologyv1.ChaosProfile{}
+	err := c.restClient.
+		Post().
+		Namespace(c.ns).
+		Resource("chaosprofiles").
+		Body(profile).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *chaosProfileClient) Update(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error) {
+	result := topologyv1.ChaosProfile{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource("chaosprofiles").
+		Name(profile.Name).
+		Body(profile).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *chaosProfileClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.
+		Delete().
+		Namespace(c.ns).
+		Resource("chaosprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Name(name).
+		Do(ctx).
+		Error()
+}
+
+type linkProfileClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+func (c *linkProfileClient) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.LinkProfileList, error) {
+	result := topologyv1.LinkProfileList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("linkprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *linkProfileClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.LinkProfile, error) {
+	result := topologyv1.LinkProfile{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("linkprofiles").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *linkProfileClient) Create(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error) {
+	result := topologyv1.LinkProfile{}
+	err := c.restClient.
+		Post().
+		Namespace(c.ns).
+		Resource("linkprofiles").
+		Body(profile).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *linkProfileClient) Update(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error) {
+	result := topologyv1.LinkProfile{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource("linkprofiles").
+		Name(profile.Name).
+		Body(profile).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *linkProfileClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.restClient.
+		Delete().
+		Namespace(c.ns).
+		Resource("linkprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Name(name).
+		Do(ctx).
+		Error()
+}
+
+type nodeTopologyStatusClient struct {
+	restClient rest.Interface
+}
+
+func (c *nodeTopologyStatusClient) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.NodeTopologyStatusList, error) {
+	result := topologyv1.NodeTopologyStatusList{}
+	err := c.restClient.
+		Get().
+		Resource("nodetopologystatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *nodeTopologyStatusClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.NodeTopologyStatus, error) {
+	result := topologyv1.NodeTopologyStatus{}
+	err := c.restClient.
+		Get().
+		Resource("nodetopologystatuses").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *nodeTopologyStatusClient) Apply(ctx context.Context, status *topologyv1.NodeTopologyStatus, fieldManager string) (*topologyv1.NodeTopologyStatus, error) {
+	obj := status.DeepCopy()
+	obj.APIVersion = topologyv1.SchemeGroupVersion.String()
+	obj.Kind = "NodeTopologyStatus"
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	force := true
+	result := topologyv1.NodeTopologyStatus{}
+	err = c.restClient.
+		Patch(types.ApplyPatchType).
+		Resource("nodetopologystatuses").
+		Name(obj.Name).
+		VersionedParams(&metav1.PatchOptions{FieldManager: fieldManager, Force: &force}, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+type topologyTemplateClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+func (c *topologyTemplateClient) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyTemplateList, error) {
+	result := topologyv1.TopologyTemplateList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("topologytemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
+func (c *topologyTemplateClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.TopologyTemplate, error) {
+	result := topologyv1.TopologyTemplate{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("topologytemplates").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
 func init() {
 	topologyv1.AddToScheme(scheme.Scheme)
 }
@@ -0,0 +1,86 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MeshnetConfigName is the name of the MeshnetConfig object holding the defaults
+	MeshnetConfigName = "default"
+
+	TunnelVxlan    = "vxlan"
+	TunnelVxlanGPE = "vxlan-gpe"
+)
+
+// Validate checks the values of the defaults
+func (s *MeshnetConfigSpec) Validate() error {
+	switch s.DefaultTunnelType {
+	case "", TunnelVxlan, TunnelVxlanGPE:
+	default:
+		return fmt.Errorf("unsupported tunnel type %q", s.DefaultTunnelType)
+	}
+	if s.DefaultLatencyMs < 0 {
+		return fmt.Errorf("latency must not be negative, got %d", s.DefaultLatencyMs)
+	}
+	if _, err := s.DialTimeout(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DialTimeout returns the gRPC dial timeout, zero if it isn't set
+func (s *MeshnetConfigSpec) DialTimeout() (time.Duration, error) {
+	if s.DefaultGRPCDialTimeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.DefaultGRPCDialTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gRPC dial timeout: %s", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("gRPC dial timeout must be positive, got %s", d)
+	}
+	return d, nil
+}
+
+// Validate checks the values of a link profile
+func (s *LinkProfileSpec) Validate() error {
+	switch s.TunnelType {
+	case "", TunnelVxlan, TunnelVxlanGPE:
+	default:
+		return fmt.Errorf("unsupported tunnel type %q", s.TunnelType)
+	}
+	if s.MTU != 0 && (s.MTU < 68 || s.MTU > 65535) {
+		return fmt.Errorf("MTU must be between 68 and 65535, got %d", s.MTU)
+	}
+	for _, imp := range []Impairment{s.EgressImpairment, s.IngressImpairment} {
+		if imp.LatencyMs < 0 || imp.JitterMs < 0 {
+			return fmt.Errorf("latency and jitter must not be negative")
+		}
+		for _, pct := range []float32{imp.LossPercent, imp.DuplicatePercent, imp.CorruptPercent, imp.EcnThresholdPercent,
+			imp.ReorderPercent, imp.ReorderCorrelationPercent} {
+			if pct < 0 || pct > 100 {
+				return fmt.Errorf("percentages must be between 0 and 100, got %v", pct)
+			}
+		}
+		if imp.ReorderPercent > 0 && imp.LatencyMs == 0 {
+			return fmt.Errorf("reordering packets requires a latency")
+		}
+	}
+	return nil
+}
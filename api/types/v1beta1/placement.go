@@ -0,0 +1,36 @@
+package v1beta1
+
+// AntiAffinityGroups returns the anti-affinity groups of topologies that pod is a member of.
+// A group is made of a topology and the pods in its anti_affinity list, which must all run
+// on different nodes.
+func AntiAffinityGroups(topologies []Topology, pod string) [][]string {
+	var groups [][]string
+	for _, t := range topologies {
+		if t.Spec.Placement == nil || len(t.Spec.Placement.AntiAffinity) == 0 {
+			continue
+		}
+		group := append([]string{t.Name}, t.Spec.Placement.AntiAffinity...)
+		for _, member := range group {
+			if member == pod {
+				groups = append(groups, group)
+				break
+			}
+		}
+	}
+	return groups
+}
+
+// AntiAffinePods returns the pods that must not run on the same node as pod
+func AntiAffinePods(topologies []Topology, pod string) []string {
+	seen := map[string]bool{pod: true}
+	var result []string
+	for _, group := range AntiAffinityGroups(topologies, pod) {
+		for _, member := range group {
+			if !seen[member] {
+				seen[member] = true
+				result = append(result, member)
+			}
+		}
+	}
+	return result
+}
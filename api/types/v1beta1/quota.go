@@ -0,0 +1,55 @@
+package v1beta1
+
+import "fmt"
+
+// CheckTopology checks that topology can be created or updated in a namespace with quota,
+// whose topologies are existing. A topology that doesn't add wires is accepted even if the
+// namespace is already above its max_total_wires, e.g. after the quota was lowered.
+func CheckTopology(quota NamespaceQuotaSpec, existing []Topology, topology *Topology) error {
+	if max := quota.MaxLinksPerTopology; max > 0 && int64(len(topology.Spec.Links)) > max {
+		return fmt.Errorf("topology %s has %d links, the quota of namespace %s allows %d per topology",
+			topology.Name, len(topology.Spec.Links), topology.Namespace, max)
+	}
+	others := []Topology{*topology}
+	for _, t := range existing {
+		if t.Name != topology.Name {
+			others = append(others, t)
+		}
+	}
+	if max := quota.MaxTopologies; max > 0 {
+		if count := int64(len(others)); count > max {
+			return fmt.Errorf("namespace %s would have %d topologies, its quota allows %d", topology.Namespace, count, max)
+		}
+	}
+	if max := quota.MaxTotalWires; max > 0 {
+		if before, after := Wires(existing), Wires(others); after > max && after > before {
+			return fmt.Errorf("namespace %s would have %d wires, its quota allows %d", topology.Namespace, after, max)
+		}
+	}
+	return nil
+}
+
+// WireKey identifies the wire of link uid between pod and peer, whichever end it's seen from
+func WireKey(pod, peer string, uid int) string {
+	if peer < pod {
+		pod, peer = peer, pod
+	}
+	// pod names can't contain underscores, which makes the key a valid ConfigMap key too
+	return fmt.Sprintf("%s_%s_%d", pod, peer, uid)
+}
+
+// WireKeys returns the keys of the wires of topologies, the two ends of a link having the same
+func WireKeys(topologies []Topology) map[string]bool {
+	wires := make(map[string]bool)
+	for _, t := range topologies {
+		for _, l := range t.Spec.Links {
+			wires[WireKey(t.Name, l.PeerPod, l.UID)] = true
+		}
+	}
+	return wires
+}
+
+// Wires returns the number of wires of topologies, counting the two ends of a link once
+func Wires(topologies []Topology) int64 {
+	return int64(len(WireKeys(topologies)))
+}
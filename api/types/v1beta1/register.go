@@ -34,6 +34,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Topology{},
 		&TopologyList{},
+		&MeshnetConfig{},
+		&MeshnetConfigList{},
+		&ChaosProfile{},
+		&ChaosProfileList{},
+		&LinkProfile{},
+		&LinkProfileList{},
+		&NodeTopologyStatus{},
+		&NodeTopologyStatusList{},
+		&TopologyTemplate{},
+		&TopologyTemplateList{},
+		&MeshnetNamespaceQuota{},
+		&MeshnetNamespaceQuotaList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
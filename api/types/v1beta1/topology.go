@@ -22,6 +22,44 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 type TopologySpec struct {
 	metav1.TypeMeta `json:",inline"`
 	Links           []Link `json:"links"`
+	// Constraints on the nodes the pod is scheduled on
+	Placement *Placement `json:"placement,omitempty"`
+	// Selects the pod of the topology by its labels, instead of by the name of the topology
+	PodSelector *metav1.LabelSelector `json:"pod_selector,omitempty"`
+	// Other topologies whose links are included in this one
+	Refs []TopologyRef `json:"refs,omitempty"`
+	// DSCP classes the traffic sent out of the links is marked with
+	TrafficClassPolicy []TrafficClass `json:"traffic_class_policy,omitempty"`
+}
+
+// TrafficClass marks the traffic sent out of the links selected by LinkSelector with DSCP
+type TrafficClass struct {
+	LinkSelector TrafficClassSelector `json:"link_selector"`
+	DSCP         int                  `json:"dscp"`
+}
+
+// TrafficClassSelector selects the links of a topology whose UID is in UIDRange
+type TrafficClassSelector struct {
+	UIDRange UIDRange `json:"uid_range"`
+}
+
+// UIDRange is an inclusive range of link UIDs
+type UIDRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// TopologyRef references a topology whose links are included in another one
+type TopologyRef struct {
+	Name string `json:"name"`
+	// Namespace of the referenced topology, the one of the referencing topology when empty
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Placement constrains the nodes of the pods of a topology
+type Placement struct {
+	// Pods that must each run on a different node, and on a different node than this pod
+	AntiAffinity []string `json:"anti_affinity,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -32,6 +70,44 @@ type TopologyStatus struct {
   Skipped []string `json:"skipped"`
   SrcIp string     `json:"src_ip"`
   NetNs string     `json:"net_ns"`
+  ContainerID string `json:"container_id,omitempty"`
+  WiresUp int64    `json:"wires_up"`
+  WiresTotal int64 `json:"wires_total"`
+  WireAudit []WireAudit `json:"wire_audit,omitempty"`
+  // Set while a canary deployment is in progress
+  CanaryFraction *float64 `json:"canary_fraction,omitempty"`
+  CanaryStarted string `json:"canary_started,omitempty"`
+  // Node IP the pod has last been started on and how many times it has moved to another node
+  LastSrcIp string `json:"last_src_ip,omitempty"`
+  Migrations int64 `json:"migrations,omitempty"`
+  // Set while the wires of the pod are being set up by a transaction
+  WireTransaction *WireTransaction `json:"wire_transaction,omitempty"`
+  // RFC3339 time the pod has last been set alive at
+  AliveAt string `json:"alive_at,omitempty"`
+  // Last convergence measured for the namespace and the UIDs of its critical path
+  LastConvergenceMs int64 `json:"last_convergence_ms,omitempty"`
+  LastConvergenceCriticalPath []int64 `json:"last_convergence_critical_path,omitempty"`
+  // EVACUATING while the node of the pod is being evacuated, until the pod runs on another node
+  State string `json:"state,omitempty"`
+  // UIDs of the links torn down by the reconciler after the pod was deleted, until set up again
+  InactiveLinks []int64 `json:"inactive_links,omitempty"`
+}
+
+// WireTransaction records the wires set up so far by a transaction, so that they can be
+// removed if the daemon restarts before it's done
+type WireTransaction struct {
+	ID      string  `json:"id"`
+	NodeIP  string  `json:"node_ip"`
+	Created []int64 `json:"created,omitempty"`
+}
+
+// WireAudit records how and when a link was set up
+type WireAudit struct {
+	LinkUID    int64  `json:"link_uid"`
+	HowCreated string `json:"how_created"`
+	CreatedAt  string `json:"created_at"`
+	NodeIP     string `json:"node_ip"`
+	WireType   string `json:"wire_type"`
 }
 
 type Link struct {
@@ -41,6 +117,74 @@ type Link struct {
 	PeerIP    string `json:"peer_ip"`
 	PeerPod   string `json:"peer_pod"`
 	UID       int    `json:"uid"`
+	VxlanGPE  bool   `json:"vxlan_gpe,omitempty"`
+	// MPLS label pushed on egress and popped on ingress, 0 for none
+	MPLSLabel uint32 `json:"mpls_label,omitempty"`
+	// Destination prefix routed over all the links of the pod sharing it
+	ECMPGroup string `json:"ecmp_group,omitempty"`
+	// PCI address of an SR-IOV VF used instead of a macvlan when PeerPod is localhost
+	SRIOVVFPCIAddr string `json:"sriov_vf_pci_addr,omitempty"`
+	// MAC addresses of the two ends, PeerMAC enables ARP suppression on VXLAN links
+	LocalMAC string `json:"local_mac,omitempty"`
+	PeerMAC  string `json:"peer_mac,omitempty"`
+	// MTU of the two ends, the interfaces' default when 0
+	MTU int `json:"mtu,omitempty"`
+	// LinkProfile in the same namespace whose values are used for the fields left unset
+	ProfileRef LinkProfileRef `json:"profile_ref,omitempty"`
+	// Secret in the same namespace with the keys encrypting the link
+	SecureLink SecureLink `json:"secure_link,omitempty"`
+	// SRv6 path to the peer, used instead of VXLAN when it's on another node
+	SRv6 SRv6Link `json:"srv6,omitempty"`
+	// Pods of the broadcast domain of the link its end floods frames to, which makes the
+	// domain a fanout from this pod instead of a shared bus
+	FanoutPeers []string `json:"fanout_peers,omitempty"`
+	// "best_effort" floods to the fanout peers that are running, "fail_on_any_error" only
+	// once all of them are
+	FanoutMode string `json:"fanout_mode,omitempty"`
+
+	// Impairments applied to traffic leaving and entering LocalIntf
+	EgressImpairment  Impairment `json:"egress_impairment,omitempty"`
+	IngressImpairment Impairment `json:"ingress_impairment,omitempty"`
+	// Priority queues of the traffic leaving LocalIntf by DSCP class, and their bandwidth
+	EgressQoSMap          []DSCPQueue     `json:"egress_qos_map,omitempty"`
+	EgressQueueAllocation QueueAllocation `json:"egress_queue_allocation,omitempty"`
+}
+
+// DSCPQueue queues the packets of a DSCP class with Priority, from 0, the highest, to 7
+type DSCPQueue struct {
+	DSCP     uint32 `json:"dscp"`
+	Priority uint32 `json:"priority"`
+}
+
+// QueueAllocation shares RateMbps, 1000 when 0, between the egress queues of a link
+type QueueAllocation struct {
+	RateMbps int64            `json:"rate_mbps,omitempty"`
+	Queues   []QueueBandwidth `json:"queues,omitempty"`
+}
+
+// QueueBandwidth is the percentage of the rate the queue of Priority is guaranteed, the queues
+// without one share the rest
+type QueueBandwidth struct {
+	Priority uint32  `json:"priority"`
+	Percent  float32 `json:"percent"`
+}
+
+// Impairment is a set of netem parameters, zero values mean no impairment
+type Impairment struct {
+	LatencyMs        int64   `json:"latency_ms,omitempty"`
+	JitterMs         int64   `json:"jitter_ms,omitempty"`
+	LossPercent      float32 `json:"loss_percent,omitempty"`
+	DuplicatePercent float32 `json:"duplicate_percent,omitempty"`
+	CorruptPercent   float32 `json:"corrupt_percent,omitempty"`
+	// Mark congested packets with ECN, the codel target being EcnThresholdPercent of its
+	// interval, 5% when 0
+	EcnEnabled          bool    `json:"ecn_enabled,omitempty"`
+	EcnThresholdPercent float32 `json:"ecn_threshold_percent,omitempty"`
+	// Send ReorderPercent of the packets, correlated by ReorderCorrelationPercent, without
+	// the delay of LatencyMs, only every ReorderGap-th packet when set
+	ReorderPercent            float32 `json:"reorder_percent,omitempty"`
+	ReorderCorrelationPercent float32 `json:"reorder_correlation_percent,omitempty"`
+	ReorderGap                uint32  `json:"reorder_gap,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -59,3 +203,196 @@ type TopologyList struct {
 
 	Items []Topology `json:"items"`
 }
+
+// MeshnetConfig holds the cluster-wide defaults of the links that don't set them.
+// It's cluster-scoped and only the object called MeshnetConfigName is used.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MeshnetConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MeshnetConfigSpec `json:"spec"`
+}
+
+type MeshnetConfigSpec struct {
+	// vxlan or vxlan-gpe
+	DefaultTunnelType string `json:"default_tunnel_type,omitempty"`
+	DefaultLatencyMs  int64  `json:"default_latency_ms,omitempty"`
+	// Timeout of the gRPC dials between daemons, e.g. 5s
+	DefaultGRPCDialTimeout string `json:"default_grpc_dial_timeout,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MeshnetConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MeshnetConfig `json:"items"`
+}
+
+// ChaosProfile impairs the wires between the pods matching Selector and the pods matching
+// PeerSelector, or all the wires of the pods matching Selector if PeerSelector is empty.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ChaosProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ChaosProfileSpec `json:"spec"`
+}
+
+type ChaosProfileSpec struct {
+	// Labels of the pods whose wires are impaired
+	Selector map[string]string `json:"selector"`
+	// Labels of the pods at the other end of the wires
+	PeerSelector map[string]string `json:"peer_selector,omitempty"`
+	// Impairment of the traffic leaving either end of the wires
+	Impairment Impairment `json:"impairment"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ChaosProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ChaosProfile `json:"items"`
+}
+
+// SecureLink encrypts a link to a pod on another node with WireGuard, using keys kept in a
+// Secret rather than in the topology. The Secret holds the base64 private_key of the local
+// end, the peer_public_key of the peer end and an optional preshared_key. Both ends of the
+// link need one.
+type SecureLink struct {
+	SecretRef SecretRef `json:"secret_ref,omitempty"`
+}
+
+// SRv6Link carries the traffic of a link between nodes over SRv6. Both ends of the link need
+// one, each with the segments to the other end, the last one being the peer's LocalSID.
+type SRv6Link struct {
+	// IPv6 segments the traffic sent to the peer is encapsulated with
+	Segments []string `json:"segments,omitempty"`
+	// IPv6 SID of the node terminating the traffic of the peer with End.DX4 or End.DX6
+	LocalSID string `json:"local_sid,omitempty"`
+}
+
+// SecretRef names a Secret
+type SecretRef struct {
+	Name string `json:"name"`
+}
+
+// LinkProfileRef names a LinkProfile
+type LinkProfileRef struct {
+	Name string `json:"name"`
+}
+
+// LinkProfile holds link properties shared by the links of the namespace that reference it
+// with profile_ref. The links' own values take precedence over the profile's.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type LinkProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LinkProfileSpec `json:"spec"`
+}
+
+type LinkProfileSpec struct {
+	EgressImpairment  Impairment `json:"egress_impairment,omitempty"`
+	IngressImpairment Impairment `json:"ingress_impairment,omitempty"`
+	MTU int `json:"mtu,omitempty"`
+	// vxlan or vxlan-gpe
+	TunnelType string `json:"tunnel_type,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type LinkProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LinkProfile `json:"items"`
+}
+
+// NodeTopologyStatus summarizes the topologies and wires of a node. It's cluster-scoped,
+// named after the node and written by the node's daemon.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeTopologyStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status NodeInventory `json:"status"`
+}
+
+type NodeInventory struct {
+	NodeIP        string `json:"node_ip"`
+	DaemonVersion string `json:"daemon_version,omitempty"`
+	// Wires of the pods of the node that are up, in total and by wire type
+	ActiveWires int64            `json:"active_wires"`
+	WiresByType map[string]int64 `json:"wires_by_type,omitempty"`
+	// Namespaces of the topologies of the pods of the node
+	Namespaces []string `json:"namespaces,omitempty"`
+	// When the daemon last wrote the object
+	LastHeartbeat metav1.Time `json:"last_heartbeat"`
+	// Most frequent errors of the recent wire set ups, most frequent first
+	TopErrors []string `json:"top_errors,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeTopologyStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeTopologyStatus `json:"items"`
+}
+
+// TopologyTemplate holds topologies whose names and the peer pods, interface names and IPs of
+// whose links are Go templates, e.g. {{ .podPrefix }}-spine-1, executed with the parameters
+// of each instantiation of the template.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TopologyTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TopologyTemplateSpec `json:"spec"`
+}
+
+type TopologyTemplateSpec struct {
+	Topologies []TemplateTopology `json:"topologies"`
+}
+
+// TemplateTopology is a topology of a template
+type TemplateTopology struct {
+	Name  string `json:"name"`
+	Links []Link `json:"links,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TopologyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TopologyTemplate `json:"items"`
+}
+
+// MeshnetNamespaceQuota limits the topologies, links and wires of a namespace. It's
+// cluster-scoped and named after the namespace it applies to.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MeshnetNamespaceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespaceQuotaSpec `json:"spec"`
+}
+
+// NamespaceQuotaSpec holds the limits of a namespace, 0 meaning no limit
+type NamespaceQuotaSpec struct {
+	MaxTopologies       int64 `json:"max_topologies,omitempty"`
+	MaxLinksPerTopology int64 `json:"max_links_per_topology,omitempty"`
+	// Wires of the namespace, a link between two of its pods being a single wire
+	MaxTotalWires int64 `json:"max_total_wires,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MeshnetNamespaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MeshnetNamespaceQuota `json:"items"`
+}
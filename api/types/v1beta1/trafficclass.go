@@ -0,0 +1,36 @@
+package v1beta1
+
+import "fmt"
+
+// MaxDSCP is the largest DSCP class
+const MaxDSCP = 63
+
+// ValidateTrafficClassPolicy checks that the DSCP classes of policy are valid and that no
+// link is selected by more than one of its traffic classes
+func ValidateTrafficClassPolicy(policy []TrafficClass) error {
+	for i, c := range policy {
+		if c.DSCP < 0 || c.DSCP > MaxDSCP {
+			return fmt.Errorf("DSCP must be between 0 and %d, got %d", MaxDSCP, c.DSCP)
+		}
+		r := c.LinkSelector.UIDRange
+		if r.Min > r.Max {
+			return fmt.Errorf("invalid UID range %d-%d", r.Min, r.Max)
+		}
+		for _, prev := range policy[:i] {
+			if p := prev.LinkSelector.UIDRange; r.Min <= p.Max && p.Min <= r.Max {
+				return fmt.Errorf("UID range %d-%d overlaps %d-%d", r.Min, r.Max, p.Min, p.Max)
+			}
+		}
+	}
+	return nil
+}
+
+// TrafficClassOf returns the DSCP class policy marks the traffic of the link uid with
+func TrafficClassOf(policy []TrafficClass, uid int) (int, bool) {
+	for _, c := range policy {
+		if r := c.LinkSelector.UIDRange; uid >= r.Min && uid <= r.Max {
+			return c.DSCP, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,94 @@
+// Package annotations reads wire settings from pod annotations, which act as defaults
+// for the links of a pod that don't set them in the topology spec.
+package annotations
+
+import (
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	TunnelType       = "meshnet.io/tunnel-type"
+	LatencyMs        = "meshnet.io/latency-ms"
+	JitterMs         = "meshnet.io/jitter-ms"
+	LossPercent      = "meshnet.io/loss-percent"
+	DuplicatePercent = "meshnet.io/duplicate-percent"
+	CorruptPercent   = "meshnet.io/corrupt-percent"
+
+	tunnelVxlan    = "vxlan"
+	tunnelVxlanGPE = "vxlan-gpe"
+)
+
+// validators of the supported annotations, any other annotation is ignored
+var validators = map[string]func(string) bool{
+	TunnelType: func(v string) bool {
+		return v == tunnelVxlan || v == tunnelVxlanGPE
+	},
+	LatencyMs:        isDuration,
+	JitterMs:         isDuration,
+	LossPercent:      isPercent,
+	DuplicatePercent: isPercent,
+	CorruptPercent:   isPercent,
+}
+
+func isDuration(v string) bool {
+	n, err := strconv.ParseInt(v, 10, 64)
+	return err == nil && n >= 0
+}
+
+func isPercent(v string) bool {
+	n, err := strconv.ParseFloat(v, 32)
+	return err == nil && n >= 0 && n <= 100
+}
+
+// Filter returns the supported annotations with valid values, logging the invalid ones
+func Filter(ann map[string]string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range ann {
+		valid, ok := validators[k]
+		if !ok {
+			continue
+		}
+		if !valid(v) {
+			log.Warnf("Ignoring invalid value %q of annotation %s", v, k)
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// ApplyDefaults sets the tunnel type and the egress impairments from the pod's annotations
+// on the links that don't set them, and returns the links with the defaults applied
+func ApplyDefaults(links []*mpb.Link, ann map[string]string) []*mpb.Link {
+	ann = Filter(ann)
+	if len(ann) == 0 {
+		return links
+	}
+	spec := &mpb.ImpairmentSpec{
+		LatencyMs:        int64(number(ann[LatencyMs])),
+		JitterMs:         int64(number(ann[JitterMs])),
+		LossPercent:      float32(number(ann[LossPercent])),
+		DuplicatePercent: float32(number(ann[DuplicatePercent])),
+		CorruptPercent:   float32(number(ann[CorruptPercent])),
+	}
+	for _, link := range links {
+		if ann[TunnelType] == tunnelVxlanGPE {
+			link.VxlanGpe = true
+		}
+		if impairment.IsEmpty(link.EgressImpairment) && !impairment.IsEmpty(spec) {
+			link.EgressImpairment = spec
+		}
+	}
+	return links
+}
+
+// number parses an annotation that has already been validated, missing ones being zero
+func number(v string) float64 {
+	n, _ := strconv.ParseFloat(v, 64)
+	return n
+}
@@ -0,0 +1,56 @@
+package annotations
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestFilter(t *testing.T) {
+	got := Filter(map[string]string{
+		TunnelType:          "vxlan-gpe",
+		LatencyMs:           "10",
+		JitterMs:            "-1",
+		LossPercent:         "101",
+		CorruptPercent:      "0.5",
+		"meshnet.io/src-ip": "10.0.0.1",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	})
+	want := map[string]string{
+		TunnelType:     "vxlan-gpe",
+		LatencyMs:      "10",
+		CorruptPercent: "0.5",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+
+	if got := Filter(map[string]string{TunnelType: "geneve"}); len(got) != 0 {
+		t.Errorf("Filter() accepted an unsupported tunnel type: %v", got)
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	own := &mpb.ImpairmentSpec{LatencyMs: 5}
+	links := ApplyDefaults([]*mpb.Link{
+		{Uid: 1},
+		{Uid: 2, EgressImpairment: own},
+	}, map[string]string{
+		TunnelType:  "vxlan-gpe",
+		LatencyMs:   "10",
+		LossPercent: "oops",
+	})
+
+	if !links[0].VxlanGpe || !links[1].VxlanGpe {
+		t.Errorf("tunnel type annotation was not applied")
+	}
+	if want := (&mpb.ImpairmentSpec{LatencyMs: 10}); !proto.Equal(links[0].EgressImpairment, want) {
+		t.Errorf("link 1 impairment = %v, want %v", links[0].EgressImpairment, want)
+	}
+	if links[1].EgressImpairment != own {
+		t.Errorf("link 2 impairment = %v, the link's own impairment must not be overridden", links[1].EgressImpairment)
+	}
+}
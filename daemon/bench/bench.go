@@ -0,0 +1,250 @@
+// Package bench measures the throughput of a link by sending synthetic frames from one of
+// its ends and counting them on the other. The frames use the IEEE local experimental
+// EtherType, so they're dropped by the pods' network stacks and never reach their apps,
+// although they do share the link's bandwidth with the live traffic while the test runs.
+package bench
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	etherType = 0x88b5
+
+	// Ethernet header, run ID, sequence number and send time
+	headerLen = 14
+	minFrame  = headerLen + 24
+	maxFrame  = 9000
+
+	// how long to wait for the frames still in flight when the sender is done
+	drainTime = 200 * time.Millisecond
+	// DefaultBufferSize is the receive buffer size of the packet socket counting the frames
+	DefaultBufferSize = 8 << 20
+	// latencies kept for the percentiles, the rest are only counted
+	maxSamples = 1 << 20
+)
+
+// Result of a benchmark run
+type Result struct {
+	Sent     uint64
+	Received uint64
+	Bytes    uint64
+	Duration time.Duration
+	// frames dropped by the receiving socket because its buffer was full
+	Drops uint64
+	P50   time.Duration
+	P99   time.Duration
+}
+
+// FramesPerSecond is the rate of frames received
+func (r *Result) FramesPerSecond() float64 {
+	return float64(r.Received) / r.Duration.Seconds()
+}
+
+// BytesPerSecond is the rate of bytes received
+func (r *Result) BytesPerSecond() float64 {
+	return float64(r.Bytes) / r.Duration.Seconds()
+}
+
+// LossPercent is the share of the frames sent that haven't been received
+func (r *Result) LossPercent() float64 {
+	if r.Sent == 0 || r.Received >= r.Sent {
+		return 0
+	}
+	return float64(r.Sent-r.Received) / float64(r.Sent) * 100
+}
+
+// Validate checks the frame size and the duration of a run
+func Validate(frameSize int, duration time.Duration) error {
+	if frameSize < minFrame || frameSize > maxFrame {
+		return fmt.Errorf("frame size must be between %d and %d bytes, got %d", minFrame, maxFrame, frameSize)
+	}
+	if duration <= 0 || duration > time.Minute {
+		return fmt.Errorf("duration must be between 0 and 1 minute, got %s", duration)
+	}
+	return nil
+}
+
+// Run sends frames of frameSize bytes out of txIntf in the txNs network namespace for the
+// given duration, as fast as possible, and counts the ones received on rxIntf in rxNs with
+// a receive buffer of bufSize bytes, or DefaultBufferSize if it's not positive.
+func Run(txNs, txIntf, rxNs, rxIntf string, frameSize int, duration time.Duration, bufSize int) (*Result, error) {
+	if err := Validate(frameSize, duration); err != nil {
+		return nil, err
+	}
+	// Sockets stay in the namespace they were created in
+	txFd, txIndex, err := openSocket(txNs, txIntf, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer unix.Close(txFd)
+	rxFd, _, err := openSocket(rxNs, rxIntf, htons(etherType))
+	if err != nil {
+		return nil, err
+	}
+	defer unix.Close(rxFd)
+	// A small receive buffer would make the receiver, rather than the link, the bottleneck
+	if bufSize <= 0 {
+		bufSize = DefaultBufferSize
+	}
+	if err := setBufferSize(rxFd, bufSize); err != nil {
+		return nil, err
+	}
+	tv := unix.NsecToTimeval(int64(drainTime))
+	if err := unix.SetsockoptTimeval(rxFd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return nil, err
+	}
+
+	runID := uint64(time.Now().UnixNano())
+	filter, err := runFilter(runID)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachFilter(rxFd, filter); err != nil {
+		return nil, err
+	}
+	received := make(chan *Result, 1)
+	go receive(rxFd, runID, received)
+
+	frame := newFrame(frameSize, runID)
+	addr := &unix.SockaddrLinklayer{Ifindex: txIndex, Protocol: htons(etherType)}
+	var sent uint64
+	start := time.Now()
+	for time.Since(start) < duration {
+		stamp(frame, sent, time.Now())
+		if err := unix.Sendto(txFd, frame, 0, addr); err != nil {
+			if err == unix.ENOBUFS || err == unix.EAGAIN {
+				continue
+			}
+			return nil, fmt.Errorf("failed to send frame: %s", err)
+		}
+		sent++
+	}
+	elapsed := time.Since(start)
+	result := <-received
+	result.Sent = sent
+	result.Duration = elapsed
+	return result, nil
+}
+
+// receive counts the frames of runID until none has been received for drainTime
+func receive(fd int, runID uint64, done chan<- *Result) {
+	result := &Result{}
+	var latencies []time.Duration
+	buf := make([]byte, maxFrame+headerLen)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			break
+		}
+		id, _, sentAt, ok := parseFrame(buf[:n])
+		if !ok || id != runID {
+			continue
+		}
+		result.Received++
+		result.Bytes += uint64(n)
+		if len(latencies) < maxSamples {
+			latencies = append(latencies, time.Since(sentAt))
+		}
+	}
+	// The kernel also counts the frames of the run it had no room for. Other frames are
+	// dropped by the socket filter before that, but the ones received before the filter
+	// was attached still have to be skipped above.
+	if stats, err := unix.GetsockoptTpacketStats(fd, unix.SOL_PACKET, unix.PACKET_STATISTICS); err == nil {
+		result.Drops = uint64(stats.Drops)
+	}
+	result.P50 = percentile(latencies, 50)
+	result.P99 = percentile(latencies, 99)
+	done <- result
+}
+
+// setBufferSize sets the receive buffer of fd, going over the rmem_max limit if allowed to
+func setBufferSize(fd, size int) error {
+	if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_RCVBUFFORCE, size); err == nil {
+		return nil
+	}
+	if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_RCVBUF, size); err != nil {
+		return fmt.Errorf("failed to set receive buffer size: %s", err)
+	}
+	return nil
+}
+
+func openSocket(nsName, intfName string, proto uint16) (int, int, error) {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	var fd, index int
+	err = netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		index = link.Attrs().Index
+		if fd, err = unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(proto)); err != nil {
+			return fmt.Errorf("failed to open packet socket: %s", err)
+		}
+		if err := unix.Bind(fd, &unix.SockaddrLinklayer{Ifindex: index, Protocol: proto}); err != nil {
+			unix.Close(fd)
+			return fmt.Errorf("failed to bind to %s: %s", intfName, err)
+		}
+		return nil
+	})
+	return fd, index, err
+}
+
+// newFrame builds a broadcast frame of size bytes, so that it's accepted by the receiving
+// end whatever its MAC address is
+func newFrame(size int, runID uint64) []byte {
+	frame := make([]byte, size)
+	for i := 0; i < 6; i++ {
+		frame[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(frame[12:], etherType)
+	binary.BigEndian.PutUint64(frame[headerLen:], runID)
+	return frame
+}
+
+func stamp(frame []byte, seq uint64, t time.Time) {
+	binary.BigEndian.PutUint64(frame[headerLen+8:], seq)
+	binary.BigEndian.PutUint64(frame[headerLen+16:], uint64(t.UnixNano()))
+}
+
+func parseFrame(frame []byte) (runID, seq uint64, sentAt time.Time, ok bool) {
+	if len(frame) < minFrame || binary.BigEndian.Uint16(frame[12:]) != etherType {
+		return 0, 0, time.Time{}, false
+	}
+	runID = binary.BigEndian.Uint64(frame[headerLen:])
+	seq = binary.BigEndian.Uint64(frame[headerLen+8:])
+	sentAt = time.Unix(0, int64(binary.BigEndian.Uint64(frame[headerLen+16:])))
+	return runID, seq, sentAt, true
+}
+
+// percentile returns the p-th percentile of the samples using the nearest-rank method
+func percentile(samples []time.Duration, p int) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	rank := (p*len(samples) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return samples[rank-1]
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,175 @@
+package bench
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/net/bpf"
+)
+
+func TestFrame(t *testing.T) {
+	frame := newFrame(minFrame, 42)
+	now := time.Now()
+	stamp(frame, 7, now)
+
+	runID, seq, sentAt, ok := parseFrame(frame)
+	if !ok || runID != 42 || seq != 7 || !sentAt.Equal(time.Unix(0, now.UnixNano())) {
+		t.Errorf("parseFrame() = %d, %d, %v, %t", runID, seq, sentAt, ok)
+	}
+	if _, _, _, ok := parseFrame(frame[:minFrame-1]); ok {
+		t.Errorf("parseFrame() accepted a truncated frame")
+	}
+	frame[12] = 0x08
+	if _, _, _, ok := parseFrame(frame); ok {
+		t.Errorf("parseFrame() accepted a frame with another EtherType")
+	}
+}
+
+func TestRunFilter(t *testing.T) {
+	raw, err := runFilter(0x0102030405060708)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := make([]bpf.Instruction, len(raw))
+	for i, r := range raw {
+		prog[i] = r.Disassemble()
+	}
+	vm, err := bpf.NewVM(prog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherType := newFrame(minFrame, 0x0102030405060708)
+	otherType[12] = 0x08
+	tests := []struct {
+		frame  []byte
+		accept bool
+	}{
+		{frame: newFrame(minFrame, 0x0102030405060708), accept: true},
+		{frame: newFrame(maxFrame, 0x0102030405060708), accept: true},
+		{frame: newFrame(minFrame, 0x0102030405060709), accept: false},
+		{frame: newFrame(minFrame, 0x1102030405060708), accept: false},
+		{frame: otherType, accept: false},
+		{frame: otherType[:10], accept: false},
+	}
+	for i, tt := range tests {
+		n, err := vm.Run(tt.frame)
+		if err != nil {
+			t.Fatalf("#%d test failed: %v", i, err)
+		}
+		if accepted := n >= len(tt.frame) && n > 0; accepted != tt.accept {
+			t.Errorf("#%d test failed: filter returned %d for a %d bytes frame, want accepted %t", i, n, len(tt.frame), tt.accept)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 100; i > 0; i-- {
+		samples = append(samples, time.Duration(i)*time.Microsecond)
+	}
+	tests := []struct {
+		p    int
+		want time.Duration
+	}{
+		{p: 50, want: 50 * time.Microsecond},
+		{p: 99, want: 99 * time.Microsecond},
+		{p: 100, want: 100 * time.Microsecond},
+	}
+	for _, tt := range tests {
+		if got := percentile(samples, tt.p); got != tt.want {
+			t.Errorf("percentile(%d) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile() of no samples = %v, want 0", got)
+	}
+}
+
+func TestResult(t *testing.T) {
+	r := &Result{Sent: 200, Received: 150, Bytes: 15000, Duration: 2 * time.Second}
+	if r.FramesPerSecond() != 75 || r.BytesPerSecond() != 7500 || r.LossPercent() != 25 {
+		t.Errorf("got %v fps, %v Bps and %v%% loss", r.FramesPerSecond(), r.BytesPerSecond(), r.LossPercent())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		frameSize int
+		duration  time.Duration
+		valid     bool
+	}{
+		{frameSize: 64, duration: time.Second, valid: true},
+		{frameSize: 10, duration: time.Second, valid: false},
+		{frameSize: 64, duration: 0, valid: false},
+		{frameSize: 64, duration: time.Hour, valid: false},
+	}
+	for i, tt := range tests {
+		err := Validate(tt.frameSize, tt.duration)
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+// vethPair connects eth1 in one new network namespace to eth2 in another one
+func vethPair(t *testing.T) (ns.NetNS, ns.NetNS) {
+	if os.Geteuid() != 0 {
+		t.Skip("root is required to create network namespaces")
+	}
+	var nss []ns.NetNS
+	for i := 0; i < 2; i++ {
+		netNs, err := testutils.NewNS()
+		if err != nil {
+			t.Skipf("failed to create a network namespace: %v", err)
+		}
+		t.Cleanup(func() {
+			netNs.Close()
+			testutils.UnmountNS(netNs)
+		})
+		nss = append(nss, netNs)
+	}
+	err := nss[0].Do(func(_ ns.NetNS) error {
+		veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"}
+		if err := netlink.LinkAdd(veth); err != nil {
+			return err
+		}
+		peer, err := netlink.LinkByName("eth2")
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetNsFd(peer, int(nss[1].Fd())); err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(veth)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = nss[1].Do(func(_ ns.NetNS) error {
+		peer, err := netlink.LinkByName("eth2")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(peer)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nss[0], nss[1]
+}
+
+func TestRunSmallBuffer(t *testing.T) {
+	tx, rx := vethPair(t)
+	r, err := Run(tx.Path(), "eth1", rx.Path(), "eth2", 1500, 200*time.Millisecond, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Drops == 0 || r.Received >= r.Sent {
+		t.Errorf("got %d drops and %d of %d frames received with a tiny buffer", r.Drops, r.Received, r.Sent)
+	}
+}
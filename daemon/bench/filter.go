@@ -0,0 +1,37 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+// runFilter returns a classic BPF program accepting only the frames of runID, so that the
+// kernel drops the rest of the link's traffic before it reaches the receive buffer
+func runFilter(runID uint64) ([]bpf.RawInstruction, error) {
+	return bpf.Assemble([]bpf.Instruction{
+		bpf.LoadAbsolute{Off: 12, Size: 2},
+		bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: etherType, SkipTrue: 5},
+		bpf.LoadAbsolute{Off: headerLen, Size: 4},
+		bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: uint32(runID >> 32), SkipTrue: 3},
+		bpf.LoadAbsolute{Off: headerLen + 4, Size: 4},
+		bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: uint32(runID), SkipTrue: 1},
+		bpf.RetConstant{Val: math.MaxUint32},
+		bpf.RetConstant{Val: 0},
+	})
+}
+
+// attachFilter makes the socket fd only receive the frames accepted by prog
+func attachFilter(fd int, prog []bpf.RawInstruction) error {
+	filter := make([]unix.SockFilter, len(prog))
+	for i, ins := range prog {
+		filter[i] = unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+	}
+	fprog := &unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, fprog); err != nil {
+		return fmt.Errorf("failed to attach the socket filter: %s", err)
+	}
+	return nil
+}
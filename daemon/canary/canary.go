@@ -0,0 +1,41 @@
+// Package canary selects the links of a topology that are active during a canary deployment.
+package canary
+
+import (
+	"math"
+	"sort"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// Count returns the number of links out of total that are active for fraction
+func Count(total int, fraction float64) int {
+	n := int(math.Ceil(float64(total) * fraction))
+	if n < 0 {
+		return 0
+	}
+	if n > total {
+		return total
+	}
+	return n
+}
+
+// Active returns the UIDs of the links that are active for fraction, i.e. the Count
+// links with the lowest UIDs. A nil state means that there's no canary in progress
+// and all links are active.
+func Active(links []*mpb.Link, state *mpb.CanaryState) map[int64]bool {
+	uids := make([]int64, 0, len(links))
+	for _, l := range links {
+		uids = append(uids, l.Uid)
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	if state != nil {
+		uids = uids[:Count(len(uids), state.Fraction)]
+	}
+
+	result := make(map[int64]bool, len(uids))
+	for _, uid := range uids {
+		result[uid] = true
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package canary
+
+import (
+	"reflect"
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		total    int
+		fraction float64
+		want     int
+	}{
+		{10, 0, 0},
+		{10, 0.1, 1},
+		{10, 0.25, 3},
+		{3, 0.5, 2},
+		{10, 1, 10},
+		{10, 1.5, 10},
+		{10, -0.5, 0},
+		{0, 0.5, 0},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.total, tt.fraction); got != tt.want {
+			t.Errorf("Count(%d, %v) = %d, want %d", tt.total, tt.fraction, got, tt.want)
+		}
+	}
+}
+
+func TestActive(t *testing.T) {
+	links := []*mpb.Link{{Uid: 4}, {Uid: 1}, {Uid: 3}, {Uid: 2}}
+	tests := []struct {
+		name  string
+		state *mpb.CanaryState
+		want  map[int64]bool
+	}{
+		{"no canary", nil, map[int64]bool{1: true, 2: true, 3: true, 4: true}},
+		{"half", &mpb.CanaryState{Fraction: 0.5}, map[int64]bool{1: true, 2: true}},
+		{"rolled back", &mpb.CanaryState{Fraction: 0}, map[int64]bool{}},
+	}
+	for _, tt := range tests {
+		if got := Active(links, tt.state); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Active() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
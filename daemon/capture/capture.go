@@ -0,0 +1,124 @@
+// Package capture captures the frames of the interfaces of the wires, and streams them in
+// the libpcap format, e.g. to Wireshark over PCAP-over-IP.
+package capture
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// DefaultSnaplen is the number of bytes of each frame captured by default
+	DefaultSnaplen = 65535
+
+	// how often a capture waiting for frames checks whether it has been stopped
+	readTimeout = 500 * time.Millisecond
+)
+
+// Socket is a packet socket receiving the frames sent and received by an interface
+type Socket struct {
+	fd int
+}
+
+// Open opens a packet socket on the interface intf of the nsName network namespace, or of
+// the current one if nsName is empty
+func Open(nsName, intf string) (*Socket, error) {
+	return open(nsName, intf, htons(unix.ETH_P_ALL))
+}
+
+// OpenInjector opens a packet socket that only sends frames out of the interface intf of the
+// nsName network namespace, or of the current one if nsName is empty
+func OpenInjector(nsName, intf string) (*Socket, error) {
+	// bound to no protocol, it doesn't receive any frame
+	return open(nsName, intf, 0)
+}
+
+func open(nsName, intf string, proto uint16) (*Socket, error) {
+	var s *Socket
+	open := func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intf)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intf, err)
+		}
+		fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(proto))
+		if err != nil {
+			return fmt.Errorf("failed to open packet socket: %s", err)
+		}
+		if err := unix.Bind(fd, &unix.SockaddrLinklayer{Ifindex: link.Attrs().Index, Protocol: proto}); err != nil {
+			unix.Close(fd)
+			return fmt.Errorf("failed to bind to %s: %s", intf, err)
+		}
+		tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+		if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+			unix.Close(fd)
+			return fmt.Errorf("failed to set the receive timeout: %s", err)
+		}
+		s = &Socket{fd: fd}
+		return nil
+	}
+	if nsName == "" {
+		err := open(nil)
+		return s, err
+	}
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+	err = netNs.Do(open)
+	return s, err
+}
+
+// Send sends the Ethernet frame data out of the interface of the socket
+func (s *Socket) Send(data []byte) error {
+	if _, err := unix.Write(s.fd, data); err != nil {
+		return fmt.Errorf("failed to send a frame of %d bytes: %s", len(data), err)
+	}
+	return nil
+}
+
+// Close closes the socket
+func (s *Socket) Close() error {
+	return unix.Close(s.fd)
+}
+
+// Capture calls fn with the frames of the socket matching filter, truncated to snaplen bytes,
+// along with the time they were received and their length, until ctx is done or fn fails
+func Capture(ctx context.Context, s *Socket, filter *Filter, snaplen int, fn func(t time.Time, data []byte, length int) error) error {
+	buf := make([]byte, snaplen)
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		length, _, err := unix.Recvfrom(s.fd, buf, unix.MSG_TRUNC)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read from the packet socket: %s", err)
+		}
+		data := buf[:min(length, snaplen)]
+		if !filter.Match(data) {
+			continue
+		}
+		if err := fn(time.Now(), data, length); err != nil {
+			return err
+		}
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,226 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+)
+
+// ethernet returns a frame of etherType with payload
+func ethernet(etherType uint16, payload []byte) []byte {
+	frame := make([]byte, 14, 14+len(payload))
+	binary.BigEndian.PutUint16(frame[12:], etherType)
+	return append(frame, payload...)
+}
+
+// ipv4 returns an IPv4 header of proto followed by the source and destination ports
+func ipv4(proto byte, src, dst uint16) []byte {
+	h := make([]byte, 24)
+	h[0] = 0x45
+	h[9] = proto
+	binary.BigEndian.PutUint16(h[20:], src)
+	binary.BigEndian.PutUint16(h[22:], dst)
+	return h
+}
+
+func TestFilter(t *testing.T) {
+	ssh := ethernet(etherTypeIPv4, ipv4(protoTCP, 40000, 22))
+	dns := ethernet(etherTypeIPv4, ipv4(protoUDP, 53, 40000))
+	ping := ethernet(etherTypeIPv4, ipv4(protoICMP, 0, 0))
+	arp := ethernet(etherTypeARP, make([]byte, 28))
+	v6 := ethernet(etherTypeIPv6, append(append(make([]byte, 6), protoICMPv6), make([]byte, 33)...))
+
+	tests := []struct {
+		filter string
+		want   []bool
+	}{
+		{filter: "", want: []bool{true, true, true, true, true}},
+		{filter: "tcp", want: []bool{true, false, false, false, false}},
+		{filter: "port 53", want: []bool{false, true, false, false, false}},
+		{filter: "udp and port 22", want: []bool{false, false, false, false, false}},
+		{filter: "arp or icmp6", want: []bool{false, false, false, true, true}},
+		{filter: "ip and icmp or tcp and port 22", want: []bool{true, false, true, false, false}},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) failed: %v", tt.filter, err)
+		}
+		for i, frame := range [][]byte{ssh, dns, ping, arp, v6} {
+			if got := f.Match(frame); got != tt.want[i] {
+				t.Errorf("filter %q matches frame %d = %v, want %v", tt.filter, i, got, tt.want[i])
+			}
+		}
+	}
+
+	for _, bad := range []string{"host 10.0.0.1", "port http", "tcp and"} {
+		if _, err := ParseFilter(bad); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestWritePacket(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteHeader(&b, 128); err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePacket(&b, time.Unix(10, 2000), []byte{1, 2, 3}, 60); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0xd4, 0xc3, 0xb2, 0xa1, 2, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 128, 0, 0, 0, 1, 0, 0, 0,
+		10, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 60, 0, 0, 0, 1, 2, 3,
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("pcap = % x, want % x", b.Bytes(), want)
+	}
+}
+
+func TestReadPCAP(t *testing.T) {
+	var written bytes.Buffer
+	if err := WriteHeader(&written, 128); err != nil {
+		t.Fatal(err)
+	}
+	for i, data := range [][]byte{{1, 2, 3}, {4, 5}} {
+		if err := WritePacket(&written, time.Unix(10, int64(i)*2000), data, 60); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// big endian with nanosecond timestamps
+	nano := []byte{
+		0xa1, 0xb2, 0x3c, 0x4d, 0, 2, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 128, 0, 0, 0, 1,
+		0, 0, 0, 10, 0, 0, 0, 5, 0, 0, 0, 1, 0, 0, 0, 1, 9,
+	}
+
+	tests := []struct {
+		desc string
+		pcap []byte
+		want []Record
+		err  bool
+	}{
+		{desc: "written", pcap: written.Bytes(), want: []Record{{Time: time.Unix(10, 0), Data: []byte{1, 2, 3}}, {Time: time.Unix(10, 2000), Data: []byte{4, 5}}}},
+		{desc: "nanoseconds", pcap: nano, want: []Record{{Time: time.Unix(10, 5), Data: []byte{9}}}},
+		{desc: "truncated", pcap: written.Bytes()[:written.Len()-1], err: true},
+		{desc: "not a pcap", pcap: make([]byte, 24), err: true},
+	}
+	for _, tt := range tests {
+		got, err := ReadPCAP(bytes.NewReader(tt.pcap))
+		if (err != nil) != tt.err {
+			t.Errorf("%s: ReadPCAP() = %v, want an error: %t", tt.desc, err, tt.err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: ReadPCAP() = %v, want %v", tt.desc, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Time.Equal(tt.want[i].Time) || !bytes.Equal(got[i].Data, tt.want[i].Data) {
+				t.Errorf("%s: frame %d = %v, want %v", tt.desc, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+// vethNS returns a new netns with the veth pair eth1 and eth2, skipping the test if it can't
+// be created
+func vethNS(t *testing.T) ns.NetNS {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	t.Cleanup(func() {
+		netNs.Close()
+		testutils.UnmountNS(netNs)
+	})
+	err = netNs.Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"}); err != nil {
+			return err
+		}
+		for _, name := range []string{"eth1", "eth2"} {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				return err
+			}
+			if err := netlink.LinkSetUp(link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+	return netNs
+}
+
+func TestServe(t *testing.T) {
+	netNs := vethNS(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, lis, netNs.Path(), "eth1", "udp") }()
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	// the header is written once the client gets the frames
+	var h [24]byte
+	if _, err := io.ReadFull(conn, h[:]); err != nil {
+		t.Fatalf("failed to read the pcap header: %v", err)
+	}
+	if magic, lt := binary.LittleEndian.Uint32(h[0:]), binary.LittleEndian.Uint32(h[20:]); magic != pcapMagic || lt != linkTypeEthernet {
+		t.Errorf("pcap header = % x, want the magic number %#x and Ethernet frames", h, pcapMagic)
+	}
+
+	injector, err := OpenInjector(netNs.Path(), "eth2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer injector.Close()
+	dns := ethernet(etherTypeIPv4, ipv4(protoUDP, 40000, 53))
+	for _, frame := range [][]byte{ethernet(etherTypeARP, make([]byte, 28)), dns} {
+		if err := injector.Send(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// only the UDP frame matches the filter
+	var rh [16]byte
+	if _, err := io.ReadFull(conn, rh[:]); err != nil {
+		t.Fatalf("failed to read the header of the captured frame: %v", err)
+	}
+	caplen, length := binary.LittleEndian.Uint32(rh[8:]), binary.LittleEndian.Uint32(rh[12:])
+	if caplen != uint32(len(dns)) || length != uint32(len(dns)) {
+		t.Fatalf("captured %d bytes of a frame of %d, want %d of %d", caplen, length, len(dns), len(dns))
+	}
+	data := make([]byte, caplen)
+	if _, err := io.ReadFull(conn, data); err != nil {
+		t.Fatalf("failed to read the captured frame: %v", err)
+	}
+	if !bytes.Equal(data, dns) {
+		t.Errorf("captured % x, want % x", data, dns)
+	}
+	if at := time.Unix(int64(binary.LittleEndian.Uint32(rh[0:])), 0); time.Since(at) > time.Minute {
+		t.Errorf("frame captured at %v", at)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Serve() = %v once stopped", err)
+	}
+}
@@ -0,0 +1,148 @@
+package capture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeARP  = 0x0806
+	etherTypeVLAN = 0x8100
+	etherTypeIPv6 = 0x86dd
+
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+)
+
+// Filter selects the captured frames. It's a subset of the pcap filter syntax: the
+// primitives arp, ip, ip6, icmp, icmp6, tcp, udp and port <n>, combined with and and or,
+// and binding tighter than or. The empty filter selects every frame.
+type Filter struct {
+	// frames match if they match all the primitives of any of the terms
+	terms [][]primitive
+}
+
+type primitive func(frame) bool
+
+// frame is the decoded headers of a captured frame
+type frame struct {
+	etherType uint16
+	proto     int
+	// transport ports, when the frame is TCP or UDP
+	src, dst int
+	hasPorts bool
+}
+
+// ParseFilter parses the expression of a filter
+func ParseFilter(expr string) (*Filter, error) {
+	f := &Filter{}
+	if strings.TrimSpace(expr) == "" {
+		return f, nil
+	}
+	for _, term := range strings.Split(expr, " or ") {
+		var prims []primitive
+		for _, p := range strings.Split(term, " and ") {
+			prim, err := parsePrimitive(strings.Fields(p))
+			if err != nil {
+				return nil, err
+			}
+			prims = append(prims, prim)
+		}
+		f.terms = append(f.terms, prims)
+	}
+	return f, nil
+}
+
+func parsePrimitive(words []string) (primitive, error) {
+	if len(words) == 2 && words[0] == "port" {
+		port, err := strconv.ParseUint(words[1], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", words[1])
+		}
+		return func(f frame) bool { return f.hasPorts && (f.src == int(port) || f.dst == int(port)) }, nil
+	}
+	if len(words) != 1 {
+		return nil, fmt.Errorf("unsupported filter %q", strings.Join(words, " "))
+	}
+	switch words[0] {
+	case "arp":
+		return func(f frame) bool { return f.etherType == etherTypeARP }, nil
+	case "ip":
+		return func(f frame) bool { return f.etherType == etherTypeIPv4 }, nil
+	case "ip6":
+		return func(f frame) bool { return f.etherType == etherTypeIPv6 }, nil
+	case "icmp":
+		return func(f frame) bool { return f.etherType == etherTypeIPv4 && f.proto == protoICMP }, nil
+	case "icmp6":
+		return func(f frame) bool { return f.etherType == etherTypeIPv6 && f.proto == protoICMPv6 }, nil
+	case "tcp":
+		return func(f frame) bool { return f.proto == protoTCP }, nil
+	case "udp":
+		return func(f frame) bool { return f.proto == protoUDP }, nil
+	}
+	return nil, fmt.Errorf("unsupported filter %q", words[0])
+}
+
+// Match tells whether the Ethernet frame data is selected by the filter
+func (f *Filter) Match(data []byte) bool {
+	if len(f.terms) == 0 {
+		return true
+	}
+	decoded := decode(data)
+	for _, term := range f.terms {
+		matches := true
+		for _, p := range term {
+			if !p(decoded) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// decode reads the headers of an Ethernet frame, as far as they're there
+func decode(data []byte) frame {
+	f := frame{proto: -1}
+	if len(data) < 14 {
+		return f
+	}
+	f.etherType = binary.BigEndian.Uint16(data[12:])
+	payload := data[14:]
+	if f.etherType == etherTypeVLAN && len(payload) >= 4 {
+		f.etherType = binary.BigEndian.Uint16(payload[2:])
+		payload = payload[4:]
+	}
+	var transport []byte
+	switch f.etherType {
+	case etherTypeIPv4:
+		if len(payload) < 20 {
+			return f
+		}
+		f.proto = int(payload[9])
+		if ihl := int(payload[0]&0x0f) * 4; len(payload) >= ihl {
+			transport = payload[ihl:]
+		}
+	case etherTypeIPv6:
+		if len(payload) < 40 {
+			return f
+		}
+		// extension headers aren't followed
+		f.proto = int(payload[6])
+		transport = payload[40:]
+	}
+	if (f.proto == protoTCP || f.proto == protoUDP) && len(transport) >= 4 {
+		f.src = int(binary.BigEndian.Uint16(transport[0:]))
+		f.dst = int(binary.BigEndian.Uint16(transport[2:]))
+		f.hasPorts = true
+	}
+	return f
+}
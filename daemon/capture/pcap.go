@@ -0,0 +1,103 @@
+package capture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	pcapMagic        = 0xa1b2c3d4
+	pcapMagicNano    = 0xa1b23c4d
+	linkTypeEthernet = 1
+)
+
+// Record is a frame read from a libpcap file
+type Record struct {
+	// time the frame was captured at
+	Time time.Time
+	// captured bytes of the frame
+	Data []byte
+}
+
+// WriteHeader writes the global header of a libpcap file of Ethernet frames of up to
+// snaplen bytes, with microsecond timestamps
+func WriteHeader(w io.Writer, snaplen int) error {
+	var h [24]byte
+	binary.LittleEndian.PutUint32(h[0:], pcapMagic)
+	binary.LittleEndian.PutUint16(h[4:], 2)
+	binary.LittleEndian.PutUint16(h[6:], 4)
+	// thiszone and sigfigs are always 0
+	binary.LittleEndian.PutUint32(h[16:], uint32(snaplen))
+	binary.LittleEndian.PutUint32(h[20:], linkTypeEthernet)
+	_, err := w.Write(h[:])
+	return err
+}
+
+// WritePacket writes the record of a frame captured at t, whose first len(data) bytes out of
+// length were captured
+func WritePacket(w io.Writer, t time.Time, data []byte, length int) error {
+	var h [16]byte
+	binary.LittleEndian.PutUint32(h[0:], uint32(t.Unix()))
+	binary.LittleEndian.PutUint32(h[4:], uint32(t.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(h[8:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(h[12:], uint32(length))
+	if _, err := w.Write(h[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// ReadPCAP reads the frames of a libpcap file of Ethernet frames, with microsecond or
+// nanosecond timestamps in either byte order
+func ReadPCAP(r io.Reader) ([]Record, error) {
+	var h [24]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		return nil, fmt.Errorf("failed to read the pcap header: %s", err)
+	}
+	var order binary.ByteOrder
+	var nano bool
+	for _, o := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch o.Uint32(h[0:]) {
+		case pcapMagic:
+			order = o
+		case pcapMagicNano:
+			order, nano = o, true
+		}
+		if order != nil {
+			break
+		}
+	}
+	if order == nil {
+		return nil, fmt.Errorf("not a pcap file, its magic number is %#x", binary.LittleEndian.Uint32(h[0:]))
+	}
+	if lt := order.Uint32(h[20:]); lt != linkTypeEthernet {
+		return nil, fmt.Errorf("unsupported link type %d, only Ethernet frames can be read", lt)
+	}
+	snaplen := order.Uint32(h[16:])
+
+	var records []Record
+	for {
+		var rh [16]byte
+		if _, err := io.ReadFull(r, rh[:]); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read the header of frame %d: %s", len(records)+1, err)
+		}
+		caplen := order.Uint32(rh[8:])
+		if caplen > snaplen && caplen > DefaultSnaplen {
+			return nil, fmt.Errorf("frame %d is %d bytes, longer than the snaplen of %d", len(records)+1, caplen, snaplen)
+		}
+		data := make([]byte, caplen)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("failed to read frame %d: %s", len(records)+1, err)
+		}
+		frac := time.Duration(order.Uint32(rh[4:]))
+		if !nano {
+			frac *= time.Microsecond
+		}
+		records = append(records, Record{Time: time.Unix(int64(order.Uint32(rh[0:])), int64(frac)), Data: data})
+	}
+}
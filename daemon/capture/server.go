@@ -0,0 +1,104 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// number of frames queued for a PCAP-over-IP client before its frames are dropped
+const clientQueue = 1024
+
+// PCAPoverIPServer captures the frames of the interface intf of the current network
+// namespace matching filter, and streams them to the clients of the TCP port until the
+// capture fails
+func PCAPoverIPServer(intf, filter string, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	return Serve(context.Background(), lis, "", intf, filter)
+}
+
+// Serve captures the frames of the interface intf of the nsName network namespace, the
+// current one if empty, matching filter, and streams them to the clients of lis until ctx is
+// done. Each client gets a libpcap header followed by the frames captured since it connected,
+// which is what Wireshark expects from a PCAP-over-IP server. Frames are dropped for the
+// clients that can't keep up. lis is closed when Serve returns.
+func Serve(ctx context.Context, lis net.Listener, nsName, intf, filter string) error {
+	defer lis.Close()
+	f, err := ParseFilter(filter)
+	if err != nil {
+		return err
+	}
+	s, err := Open(nsName, intf)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mu sync.Mutex
+	clients := make(map[chan []byte]bool)
+	go func() {
+		<-ctx.Done()
+		lis.Close()
+	}()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			log.Infof("PCAP-over-IP client %s connected to the capture of %s", conn.RemoteAddr(), intf)
+			ch := make(chan []byte, clientQueue)
+			mu.Lock()
+			clients[ch] = true
+			mu.Unlock()
+			go func() {
+				defer func() {
+					mu.Lock()
+					delete(clients, ch)
+					mu.Unlock()
+					conn.Close()
+				}()
+				if err := WriteHeader(conn, DefaultSnaplen); err != nil {
+					return
+				}
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case record := <-ch:
+						if _, err := conn.Write(record); err != nil {
+							log.Infof("PCAP-over-IP client %s of the capture of %s has gone: %s", conn.RemoteAddr(), intf, err)
+							return
+						}
+					}
+				}
+			}()
+		}
+	}()
+
+	return Capture(ctx, s, f, DefaultSnaplen, func(t time.Time, data []byte, length int) error {
+		var b bytes.Buffer
+		if err := WritePacket(&b, t, data, length); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for ch := range clients {
+			select {
+			case ch <- b.Bytes():
+			default:
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,264 @@
+// Package cilium registers the interfaces of meshnet links as endpoints of the Cilium
+// agent, when meshnet is chained after Cilium, so that Cilium's eBPF programs apply the
+// network policies of the pods to the traffic of their links too. The agent's REST API
+// is used directly on its unix socket.
+package cilium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+)
+
+// DefaultSocket is the unix socket the Cilium agent serves its API on
+const DefaultSocket = "/var/run/cilium/cilium.sock"
+
+const (
+	// Cilium allocates the IDs of its own endpoints below firstID
+	firstID = 4096
+	maxID   = 65535
+	// number of IDs tried for an endpoint, starting from the hash of its interface
+	maxProbes = 16
+)
+
+// EndpointID returns the first Cilium endpoint ID tried for the interface intf of the netns
+// netNs. The next ones are tried if it's taken by another endpoint.
+func EndpointID(netNs, intf string) int64 {
+	h := fnv.New32a()
+	h.Write([]byte(netNs + "/" + intf))
+	return firstID + int64(h.Sum32()%(maxID-firstID+1))
+}
+
+// probe returns the i-th ID tried for the interface intf of the netns netNs
+func probe(netNs, intf string, i int) int64 {
+	return firstID + (EndpointID(netNs, intf)-firstID+int64(i))%(maxID-firstID+1)
+}
+
+// Endpoint is the interface of a link of a pod
+type Endpoint struct {
+	KubeNs string
+	Pod    string
+	// ID of the pod's sandbox container, if known
+	ContainerID string
+	NetNs       string
+	Intf        string
+}
+
+// endpointChange is the EndpointChangeRequest of the Cilium API
+type endpointChange struct {
+	ID             int64       `json:"id"`
+	ContainerID    string      `json:"container-id,omitempty"`
+	InterfaceName  string      `json:"interface-name"`
+	InterfaceIndex int64       `json:"interface-index"`
+	Mac            string      `json:"mac,omitempty"`
+	Addressing     *addressing `json:"addressing,omitempty"`
+	K8sPodName     string      `json:"k8s-pod-name"`
+	K8sNamespace   string      `json:"k8s-namespace"`
+	State          string      `json:"state"`
+	SyncBuild      bool        `json:"sync-build"`
+}
+
+// addressing is the AddressPair of the Cilium API
+type addressing struct {
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+// endpointModel is the part of the Endpoint model of the Cilium API that tells who it
+// belongs to
+type endpointModel struct {
+	ID     int64 `json:"id"`
+	Status struct {
+		ExternalIdentifiers struct {
+			ContainerID  string `json:"container-id"`
+			PodName      string `json:"pod-name"`
+			K8sPodName   string `json:"k8s-pod-name"`
+			K8sNamespace string `json:"k8s-namespace"`
+		} `json:"external-identifiers"`
+		Networking struct {
+			InterfaceName string `json:"interface-name"`
+		} `json:"networking"`
+	} `json:"status"`
+}
+
+// ownedBy returns true if the endpoint is the one of the interface of ep. Cilium doesn't
+// register interfaces other than the primary one of a pod, which isn't a link.
+func (m *endpointModel) ownedBy(ep Endpoint) bool {
+	ids := m.Status.ExternalIdentifiers
+	samePod := (ids.K8sNamespace == ep.KubeNs && ids.K8sPodName == ep.Pod) || ids.PodName == ep.KubeNs+"/"+ep.Pod
+	return samePod && m.Status.Networking.InterfaceName == ep.Intf
+}
+
+// Client talks to the Cilium agent
+type Client struct {
+	http *http.Client
+}
+
+// NewClient returns a client of the agent serving on the unix socket
+func NewClient(socket string) *Client {
+	return &Client{http: &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}}}
+}
+
+// Register creates the endpoint of the interface of a link of a pod, replacing the endpoint
+// of an earlier interface of the same pod and name. IDs taken by the endpoints of other
+// interfaces are skipped.
+func (c *Client) Register(ctx context.Context, ep Endpoint) error {
+	change, err := readInterface(ep.NetNs, ep.Intf)
+	if err != nil {
+		return err
+	}
+	change.ContainerID = ep.ContainerID
+	change.K8sPodName = ep.Pod
+	change.K8sNamespace = ep.KubeNs
+	change.State = "waiting-for-identity"
+
+	for i := 0; i < maxProbes; i++ {
+		change.ID = probe(ep.NetNs, ep.Intf, i)
+		existing, err := c.get(ctx, change.ID)
+		if err != nil {
+			return fmt.Errorf("failed to register %s of pod %s with Cilium: %s", ep.Intf, ep.Pod, err)
+		}
+		if existing != nil {
+			if !existing.ownedBy(ep) {
+				continue
+			}
+			if err := c.delete(ctx, change.ID); err != nil {
+				return fmt.Errorf("failed to replace the Cilium endpoint of %s of pod %s: %s", ep.Intf, ep.Pod, err)
+			}
+		}
+		code, err := c.do(ctx, http.MethodPut, change.ID, change, nil)
+		if err != nil {
+			return fmt.Errorf("failed to register %s of pod %s with Cilium: %s", ep.Intf, ep.Pod, err)
+		}
+		switch code {
+		case http.StatusCreated:
+			return nil
+		case http.StatusConflict:
+			// taken in the meantime
+			continue
+		}
+		return fmt.Errorf("failed to register %s of pod %s with Cilium: endpoint %d: %s", ep.Intf, ep.Pod, change.ID, http.StatusText(code))
+	}
+	return fmt.Errorf("failed to register %s of pod %s with Cilium: no free endpoint ID", ep.Intf, ep.Pod)
+}
+
+// Deregister deletes the endpoint of the interface of a link of a pod, if it exists.
+// Endpoints of other interfaces are left alone.
+func (c *Client) Deregister(ctx context.Context, ep Endpoint) error {
+	for i := 0; i < maxProbes; i++ {
+		id := probe(ep.NetNs, ep.Intf, i)
+		existing, err := c.get(ctx, id)
+		if err == nil && existing != nil && existing.ownedBy(ep) {
+			err = c.delete(ctx, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to deregister %s from Cilium: %s", ep.Intf, err)
+		}
+	}
+	return nil
+}
+
+// get returns the endpoint with id, nil if it doesn't exist
+func (c *Client) get(ctx context.Context, id int64) (*endpointModel, error) {
+	var result endpointModel
+	code, err := c.do(ctx, http.MethodGet, id, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case http.StatusOK:
+		return &result, nil
+	case http.StatusNotFound:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("endpoint %d: %s", id, http.StatusText(code))
+}
+
+func (c *Client) delete(ctx context.Context, id int64) error {
+	code, err := c.do(ctx, http.MethodDelete, id, nil, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNotFound {
+		return fmt.Errorf("endpoint %d: %s", id, http.StatusText(code))
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method string, id int64, body, result interface{}) (int, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("http://cilium/v1/endpoint/%d", id), r)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if result != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return 0, fmt.Errorf("invalid endpoint %d: %s", id, err)
+		}
+	}
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// readInterface returns the index and the addresses of the interface intf of the netns netNs
+func readInterface(netNs, intf string) (*endpointChange, error) {
+	result := &endpointChange{InterfaceName: intf}
+	err := ns.WithNetNSPath(netNs, func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(intf)
+		if err != nil {
+			return err
+		}
+		result.InterfaceIndex = int64(l.Attrs().Index)
+		if mac := l.Attrs().HardwareAddr; len(mac) > 0 {
+			result.Mac = mac.String()
+		}
+		addrs, err := netlink.AddrList(l, netlink.FAMILY_ALL)
+		if err != nil {
+			return err
+		}
+		a := &addressing{}
+		for _, addr := range addrs {
+			switch {
+			case addr.IP.IsLinkLocalUnicast():
+			case addr.IP.To4() != nil && a.IPv4 == "":
+				a.IPv4 = addr.IP.String()
+			case addr.IP.To4() == nil && a.IPv6 == "":
+				a.IPv6 = addr.IP.String()
+			}
+		}
+		if *a != (addressing{}) {
+			result.Addressing = a
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", intf, err)
+	}
+	return result, nil
+}
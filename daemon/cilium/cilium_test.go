@@ -0,0 +1,141 @@
+package cilium
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEndpointID(t *testing.T) {
+	a := EndpointID("/var/run/netns/r1", "eth1")
+	tests := []struct {
+		ns, intf string
+		same     bool
+	}{
+		{"/var/run/netns/r1", "eth1", true},
+		{"/var/run/netns/r1", "eth2", false},
+		{"/var/run/netns/r2", "eth1", false},
+	}
+	for _, tt := range tests {
+		b := EndpointID(tt.ns, tt.intf)
+		if b < firstID || b > maxID {
+			t.Errorf("EndpointID(%s, %s) = %d, out of the range %d-%d", tt.ns, tt.intf, b, firstID, maxID)
+		}
+		if (a == b) != tt.same {
+			t.Errorf("EndpointID(%s, %s) = %d, want same as %d: %v", tt.ns, tt.intf, b, a, tt.same)
+		}
+	}
+}
+
+func TestProbe(t *testing.T) {
+	seen := make(map[int64]bool)
+	for i := 0; i < maxProbes; i++ {
+		id := probe("/var/run/netns/r1", "eth1", i)
+		if id < firstID || id > maxID || seen[id] {
+			t.Errorf("probe #%d = %d, out of the range %d-%d or repeated", i, id, firstID, maxID)
+		}
+		seen[id] = true
+	}
+	if probe("/var/run/netns/r1", "eth1", 0) != EndpointID("/var/run/netns/r1", "eth1") {
+		t.Errorf("the first probe isn't EndpointID()")
+	}
+}
+
+// fakeAgent serves the endpoint API on a unix socket, PUT fails with a conflict if the
+// endpoint exists
+func fakeAgent(t *testing.T) (string, map[int64]endpointChange) {
+	var mu sync.Mutex
+	endpoints := make(map[int64]endpointChange)
+	socket := filepath.Join(t.TempDir(), "cilium.sock")
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/v1/endpoint/"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ep, exists := endpoints[id]
+		switch {
+		case r.Method == http.MethodGet && exists:
+			var m endpointModel
+			m.ID = id
+			m.Status.ExternalIdentifiers.ContainerID = ep.ContainerID
+			m.Status.ExternalIdentifiers.K8sPodName = ep.K8sPodName
+			m.Status.ExternalIdentifiers.K8sNamespace = ep.K8sNamespace
+			m.Status.Networking.InterfaceName = ep.InterfaceName
+			json.NewEncoder(w).Encode(m)
+		case r.Method == http.MethodPut && exists:
+			w.WriteHeader(http.StatusConflict)
+		case r.Method == http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&ep); err != nil || ep.ID != id {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			endpoints[id] = ep
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && exists:
+			delete(endpoints, id)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})}
+	go srv.Serve(lis)
+	t.Cleanup(func() { srv.Close() })
+	return socket, endpoints
+}
+
+func TestRegister(t *testing.T) {
+	netNs := "/proc/self/ns/net"
+	if _, err := readInterface(netNs, "lo"); err != nil {
+		t.Skipf("can't enter the current netns: %v", err)
+	}
+	socket, endpoints := fakeAgent(t)
+	c := NewClient(socket)
+	ctx := context.Background()
+	ep := Endpoint{KubeNs: "default", Pod: "r1", ContainerID: "abc", NetNs: netNs, Intf: "lo"}
+
+	// the first ID of the interface is taken by the endpoint of another pod
+	first := EndpointID(netNs, "lo")
+	other := endpointChange{ID: first, InterfaceName: "lo", K8sPodName: "r2", K8sNamespace: "default"}
+	endpoints[first] = other
+
+	for i := 0; i < 2; i++ {
+		if err := c.Register(ctx, ep); err != nil {
+			t.Fatalf("#%d Register() failed: %v", i, err)
+		}
+	}
+	if len(endpoints) != 2 || endpoints[first] != other {
+		t.Fatalf("endpoints after Register() = %+v, want the other pod's and one more", endpoints)
+	}
+	got, ok := endpoints[probe(netNs, "lo", 1)]
+	if !ok {
+		t.Fatalf("the endpoint isn't registered with the next free ID")
+	}
+	if got.InterfaceName != "lo" || got.InterfaceIndex == 0 || got.K8sPodName != "r1" || got.ContainerID != "abc" ||
+		got.Addressing == nil || got.Addressing.IPv4 != "127.0.0.1" {
+		t.Errorf("registered endpoint = %+v", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.Deregister(ctx, ep); err != nil {
+			t.Errorf("#%d Deregister() failed: %v", i, err)
+		}
+	}
+	if len(endpoints) != 1 || endpoints[first] != other {
+		t.Errorf("endpoints after Deregister() = %+v, want only the other pod's", endpoints)
+	}
+	if err := c.Register(ctx, Endpoint{KubeNs: "default", Pod: "r1", NetNs: netNs, Intf: "eth99"}); err == nil {
+		t.Errorf("Register() of a missing interface didn't fail")
+	}
+}
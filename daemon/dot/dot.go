@@ -0,0 +1,237 @@
+// Package dot renders the topologies of a namespace as a graph in the DOT language of
+// Graphviz, e.g. to be drawn with dot -Tpng.
+package dot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+// colors of the wire states
+const (
+	ColorUp      = "green"
+	ColorDown    = "red"
+	ColorPending = "yellow"
+
+	// peer pod name used by macvlan links
+	localhost = "localhost"
+)
+
+// edge is a link, drawn once even though both of its pods have it
+type edge struct {
+	uid int
+	// the two ends, the first is the pod whose topology the link was first found in
+	pods, intfs [2]string
+	// impairments of the ends that have any
+	impairments []string
+	// a macvlan link, whose second end is the host of the pod
+	host bool
+}
+
+// Generate returns the graph of the topologies of ns. Pods are nodes labeled with their node
+// IP, and links are edges labeled with their interfaces, UID and impairments, colored by the
+// state of their wire: ColorUp when both pods are running, ColorPending when only one is
+// and ColorDown otherwise. Pods linked to more than once have an edge per link. Peers
+// without a topology are dashed, and macvlan links go to a node of their own for the host
+// of each pod. The output only depends on the topologies, not on their order.
+func Generate(ns string, topologies []topologyv1.Topology) string {
+	sorted := append([]topologyv1.Topology(nil), topologies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	pods := make(map[string]*topologyv1.Topology, len(sorted))
+	for i := range sorted {
+		pods[sorted[i].Name] = &sorted[i]
+	}
+
+	var edges []*edge
+	seen := make(map[string]*edge)
+	missing := make(map[string]bool)
+	for _, t := range sorted {
+		links := append([]topologyv1.Link(nil), t.Spec.Links...)
+		sort.SliceStable(links, func(i, j int) bool { return links[i].UID < links[j].UID })
+		for _, l := range links {
+			if l.PeerPod == "" {
+				continue
+			}
+			peer := l.PeerPod
+			if peer == localhost {
+				peer = hostNode(t.Name)
+			} else if pods[peer] == nil {
+				missing[peer] = true
+			}
+			key := edgeKey(l.UID, t.Name, peer)
+			e, ok := seen[key]
+			if !ok {
+				e = &edge{uid: l.UID, pods: [2]string{t.Name, peer}, intfs: [2]string{l.LocalIntf, l.PeerIntf}, host: l.PeerPod == localhost}
+				seen[key] = e
+				edges = append(edges, e)
+			}
+			if s := impairments(l.EgressImpairment); s != "" {
+				e.impairments = append(e.impairments, fmt.Sprintf("%s egress: %s", t.Name, s))
+			}
+			if s := impairments(l.IngressImpairment); s != "" {
+				e.impairments = append(e.impairments, fmt.Sprintf("%s ingress: %s", t.Name, s))
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "graph %s {\n", quote(ns))
+	b.WriteString("  node [shape=box];\n")
+	for _, t := range sorted {
+		label := t.Name
+		if t.Status.SrcIp != "" {
+			label += "\n" + t.Status.SrcIp
+		}
+		fmt.Fprintf(&b, "  %s [label=%s];\n", quote(t.Name), quote(label))
+	}
+	for _, name := range sortedKeys(missing) {
+		fmt.Fprintf(&b, "  %s [style=dashed];\n", quote(name))
+	}
+	for _, t := range sorted {
+		if !hasHostLink(t) {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=ellipse];\n", quote(hostNode(t.Name)), quote(localhost))
+	}
+	for _, e := range edges {
+		label := fmt.Sprintf("%s -- %s\nuid %d", e.intfs[0], e.intfs[1], e.uid)
+		for _, s := range e.impairments {
+			label += "\n" + s
+		}
+		fmt.Fprintf(&b, "  %s -- %s [label=%s, color=%s];\n", quote(e.pods[0]), quote(e.pods[1]), quote(label), color(pods, e))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Subgraph returns the topologies of the pods names and of their peers, where the peers
+// only keep their links to these pods, so that Generate draws the links of names only
+func Subgraph(names []string, topologies []topologyv1.Topology) []topologyv1.Topology {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+	peers := make(map[string]bool)
+	for _, t := range topologies {
+		if !selected[t.Name] {
+			continue
+		}
+		for _, l := range t.Spec.Links {
+			peers[l.PeerPod] = true
+		}
+	}
+	var result []topologyv1.Topology
+	for _, t := range topologies {
+		if selected[t.Name] {
+			result = append(result, t)
+			continue
+		}
+		if !peers[t.Name] {
+			continue
+		}
+		peer := *t.DeepCopy()
+		peer.Spec.Links = nil
+		for _, l := range t.Spec.Links {
+			if selected[l.PeerPod] {
+				peer.Spec.Links = append(peer.Spec.Links, l)
+			}
+		}
+		result = append(result, peer)
+	}
+	return result
+}
+
+// hostNode is the node of the host of pod, which its macvlan links go to
+func hostNode(pod string) string {
+	return pod + "/" + localhost
+}
+
+func hasHostLink(t topologyv1.Topology) bool {
+	for _, l := range t.Spec.Links {
+		if l.PeerPod == localhost {
+			return true
+		}
+	}
+	return false
+}
+
+// edgeKey identifies the link uid between pods a and b, whichever of them has it
+func edgeKey(uid int, a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return fmt.Sprintf("%d/%s/%s", uid, a, b)
+}
+
+// color returns the color of the state of the wire of e. The wire of a macvlan link only
+// needs its pod.
+func color(pods map[string]*topologyv1.Topology, e *edge) string {
+	alive := 0
+	for _, name := range e.pods {
+		if e.host && name == hostNode(e.pods[0]) {
+			// the host is there as long as the pod is
+			alive *= 2
+			continue
+		}
+		t := pods[name]
+		if t == nil {
+			// the wire can't be set up without the topology of the peer
+			return ColorDown
+		}
+		if t.Status.SrcIp != "" {
+			alive++
+		}
+	}
+	switch alive {
+	case 2:
+		return ColorUp
+	case 1:
+		return ColorPending
+	}
+	return ColorDown
+}
+
+// impairments describes the non-zero parameters of imp, empty if there are none
+func impairments(imp topologyv1.Impairment) string {
+	var parts []string
+	if imp.LatencyMs != 0 {
+		parts = append(parts, fmt.Sprintf("latency %dms", imp.LatencyMs))
+	}
+	if imp.JitterMs != 0 {
+		parts = append(parts, fmt.Sprintf("jitter %dms", imp.JitterMs))
+	}
+	if imp.LossPercent != 0 {
+		parts = append(parts, fmt.Sprintf("loss %g%%", imp.LossPercent))
+	}
+	if imp.DuplicatePercent != 0 {
+		parts = append(parts, fmt.Sprintf("duplicate %g%%", imp.DuplicatePercent))
+	}
+	if imp.CorruptPercent != 0 {
+		parts = append(parts, fmt.Sprintf("corrupt %g%%", imp.CorruptPercent))
+	}
+	if imp.ReorderPercent != 0 {
+		parts = append(parts, fmt.Sprintf("reorder %g%%", imp.ReorderPercent))
+	}
+	if imp.EcnEnabled {
+		parts = append(parts, "ECN")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// quote returns s as a DOT string, where \n is a line break
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
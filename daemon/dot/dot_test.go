@@ -0,0 +1,95 @@
+package dot
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func topology(name, srcIP string, links ...topologyv1.Link) topologyv1.Topology {
+	t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	t.Status.SrcIp = srcIP
+	t.Spec.Links = links
+	return t
+}
+
+func link(uid int, peer, local, remote string) topologyv1.Link {
+	return topologyv1.Link{UID: uid, PeerPod: peer, LocalIntf: local, PeerIntf: remote}
+}
+
+func TestGenerate(t *testing.T) {
+	impaired := link(2, "r2", "eth2", "eth2")
+	impaired.EgressImpairment = topologyv1.Impairment{LatencyMs: 10, LossPercent: 0.5}
+	// r1 and r2 are connected twice, r3 isn't running and r4 has no topology
+	topologies := []topologyv1.Topology{
+		topology("r3", "", link(3, "r2", "eth1", "eth3"), link(5, "r4", "eth2", "eth1")),
+		topology("r2", "10.0.0.2", link(1, "r1", "eth1", "eth1"), link(2, "r1", "eth2", "eth2"), link(3, "r3", "eth3", "eth1")),
+		topology("r1", "10.0.0.1", link(1, "r2", "eth1", "eth1"), impaired, link(4, "localhost", "eth3", "ens4")),
+	}
+	want := `graph "lab" {
+  node [shape=box];
+  "r1" [label="r1\n10.0.0.1"];
+  "r2" [label="r2\n10.0.0.2"];
+  "r3" [label="r3"];
+  "r4" [style=dashed];
+  "r1/localhost" [label="localhost", shape=ellipse];
+  "r1" -- "r2" [label="eth1 -- eth1\nuid 1", color=green];
+  "r1" -- "r2" [label="eth2 -- eth2\nuid 2\nr1 egress: latency 10ms, loss 0.5%", color=green];
+  "r1" -- "r1/localhost" [label="eth3 -- ens4\nuid 4", color=green];
+  "r2" -- "r3" [label="eth3 -- eth1\nuid 3", color=yellow];
+  "r3" -- "r4" [label="eth2 -- eth1\nuid 5", color=red];
+}
+`
+	if got := Generate("lab", topologies); got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{s: "r1", want: `"r1"`},
+		{s: "a\nb", want: `"a\nb"`},
+		{s: `say "hi" \o/`, want: `"say \"hi\" \\o/"`},
+	}
+	for i, tt := range tests {
+		if got := quote(tt.s); got != tt.want {
+			t.Errorf("#%d test failed: quote(%q) = %s, want %s", i, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestImpairments(t *testing.T) {
+	if got := impairments(topologyv1.Impairment{}); got != "" {
+		t.Errorf("impairments() of no impairment = %q", got)
+	}
+	got := impairments(topologyv1.Impairment{JitterMs: 2, DuplicatePercent: 1, CorruptPercent: 0.1})
+	if want := "jitter 2ms, duplicate 1%, corrupt 0.1%"; got != want {
+		t.Errorf("impairments() = %q, want %q", got, want)
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	topologies := []topologyv1.Topology{
+		topology("r1", "", link(1, "r2", "eth1", "eth1")),
+		topology("r2", "", link(1, "r1", "eth1", "eth1"), link(2, "r3", "eth2", "eth1")),
+		topology("r3", "", link(2, "r2", "eth1", "eth2"), link(3, "r4", "eth2", "eth1")),
+		topology("r4", "", link(3, "r3", "eth1", "eth2")),
+	}
+	got := Subgraph([]string{"r2"}, topologies)
+	want := map[string]int{"r1": 1, "r2": 2, "r3": 1}
+	if len(got) != len(want) {
+		t.Fatalf("Subgraph() returned %d topologies, want %d", len(got), len(want))
+	}
+	for _, topo := range got {
+		if len(topo.Spec.Links) != want[topo.Name] {
+			t.Errorf("Subgraph() topology %s has %d links, want %d", topo.Name, len(topo.Spec.Links), want[topo.Name])
+		}
+	}
+	if len(topologies[2].Spec.Links) != 2 {
+		t.Errorf("Subgraph() has changed the links of r3")
+	}
+}
@@ -0,0 +1,155 @@
+// Package ecmp installs multipath routes over parallel links of a pod. The links sharing
+// an ECMP group, which is a destination prefix, become the equal-cost next hops of the
+// route to that prefix in the pod's routing table.
+package ecmp
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// Validate checks that group is a valid destination prefix, an empty group meaning none
+func Validate(group string) error {
+	if group == "" {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(group); err != nil {
+		return fmt.Errorf("ECMP group must be a destination prefix: %s", err)
+	}
+	return nil
+}
+
+// Groups returns the sorted ECMP groups of links
+func Groups(links []*mpb.Link) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, l := range links {
+		if l.EcmpGroup != "" && !seen[l.EcmpGroup] {
+			seen[l.EcmpGroup] = true
+			result = append(result, l.EcmpGroup)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GroupOf returns the ECMP group of the link with the given UID, if any
+func GroupOf(links []*mpb.Link, uid int64) string {
+	for _, l := range links {
+		if l.Uid == uid {
+			return l.EcmpGroup
+		}
+	}
+	return ""
+}
+
+// Apply updates the multipath routes of the ECMP groups of links, and of the extra groups
+// that may have lost all of their links, in the nsName network namespace. A route is only
+// replaced once all the links of its group are up, in a single operation, so that traffic
+// keeps flowing over the previous next hops while a group is being changed. Links must be
+// removed from a group, and Apply called, before their interfaces are deleted, since the
+// kernel drops a whole multipath route when one of its devices goes away.
+func Apply(nsName string, links []*mpb.Link, extra ...string) error {
+	groups := Groups(links)
+	for _, g := range extra {
+		if g != "" {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		for _, group := range groups {
+			if err := applyGroup(group, links); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func applyGroup(group string, links []*mpb.Link) error {
+	_, dst, err := net.ParseCIDR(group)
+	if err != nil {
+		return fmt.Errorf("invalid ECMP group %q: %s", group, err)
+	}
+	if !hasLinks(group, links) {
+		if err := netlink.RouteDel(&netlink.Route{Dst: dst}); err != nil && err != unix.ESRCH {
+			return fmt.Errorf("failed to remove ECMP route to %s: %s", group, err)
+		}
+		return nil
+	}
+	hops, ready, err := nexthops(group, links, upIndex)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		log.Infof("Not all links of ECMP group %s are up yet, keeping its current route", group)
+		return nil
+	}
+	if err := netlink.RouteReplace(&netlink.Route{Dst: dst, MultiPath: hops}); err != nil {
+		return fmt.Errorf("failed to replace ECMP route to %s: %s", group, err)
+	}
+	return nil
+}
+
+// nexthops returns the next hops of the links in group, using index to find the index of
+// an interface that is up, and whether all of them are up
+func nexthops(group string, links []*mpb.Link, index func(string) (int, bool)) ([]*netlink.NexthopInfo, bool, error) {
+	var hops []*netlink.NexthopInfo
+	ready := true
+	for _, l := range links {
+		if l.EcmpGroup != group {
+			continue
+		}
+		idx, up := index(l.LocalIntf)
+		if !up {
+			ready = false
+			continue
+		}
+		hop := &netlink.NexthopInfo{LinkIndex: idx}
+		if l.PeerIp != "" {
+			ip, _, err := net.ParseCIDR(l.PeerIp)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid peer IP %s of link %d: %s", l.PeerIp, l.Uid, err)
+			}
+			hop.Gw = ip
+		}
+		hops = append(hops, hop)
+	}
+	return hops, ready, nil
+}
+
+func hasLinks(group string, links []*mpb.Link) bool {
+	for _, l := range links {
+		if l.EcmpGroup == group {
+			return true
+		}
+	}
+	return false
+}
+
+// upIndex returns the index of an interface of the current namespace if it's up
+func upIndex(name string) (int, bool) {
+	link, err := netlink.LinkByName(name)
+	if err != nil || link.Attrs().Flags&net.FlagUp == 0 {
+		return 0, false
+	}
+	return link.Attrs().Index, true
+}
@@ -0,0 +1,165 @@
+package ecmp
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const group = "192.168.0.0/24"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		group string
+		valid bool
+	}{
+		{group: "", valid: true},
+		{group: group, valid: true},
+		{group: "2001:db8::/64", valid: true},
+		{group: "spine", valid: false},
+		{group: "10.0.0.1", valid: false},
+	}
+	for i, tt := range tests {
+		err := Validate(tt.group)
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+func TestGroups(t *testing.T) {
+	links := []*mpb.Link{
+		{Uid: 1, EcmpGroup: group},
+		{Uid: 2},
+		{Uid: 3, EcmpGroup: "10.0.0.0/8"},
+		{Uid: 4, EcmpGroup: group},
+	}
+	want := []string{"10.0.0.0/8", group}
+	if got := Groups(links); !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+	if got := GroupOf(links, 4); got != group {
+		t.Errorf("GroupOf() = %q, want %q", got, group)
+	}
+}
+
+func TestNexthops(t *testing.T) {
+	links := []*mpb.Link{
+		{Uid: 1, LocalIntf: "eth1", PeerIp: "10.0.0.2/30", EcmpGroup: group},
+		{Uid: 2, LocalIntf: "eth2", PeerIp: "10.0.0.6/30", EcmpGroup: group},
+		{Uid: 3, LocalIntf: "eth3", PeerIp: "10.0.0.10/30"},
+	}
+	up := map[string]int{"eth1": 11, "eth2": 12, "eth3": 13}
+	index := func(name string) (int, bool) {
+		idx, ok := up[name]
+		return idx, ok
+	}
+
+	hops, ready, err := nexthops(group, links, index)
+	if err != nil || !ready {
+		t.Fatalf("nexthops() = %v, %v, want all links ready", ready, err)
+	}
+	if len(hops) != 2 || hops[0].LinkIndex != 11 || hops[1].Gw.String() != "10.0.0.6" {
+		t.Errorf("nexthops() returned %v", hops)
+	}
+
+	delete(up, "eth2")
+	if _, ready, _ := nexthops(group, links, index); ready {
+		t.Error("nexthops() is ready with a link down")
+	}
+}
+
+func TestApply(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("root is required to create network namespaces")
+	}
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+
+	links := []*mpb.Link{
+		{Uid: 1, LocalIntf: "eth1", PeerIp: "10.0.0.2/30", EcmpGroup: group},
+		{Uid: 2, LocalIntf: "eth2", PeerIp: "10.0.0.6/30", EcmpGroup: group},
+	}
+	err = netNs.Do(func(_ ns.NetNS) error {
+		for i, ip := range []string{"10.0.0.1/30", "10.0.0.5/30"} {
+			veth := &netlink.Veth{
+				LinkAttrs: netlink.LinkAttrs{Name: fmt.Sprintf("eth%d", i+1)},
+				PeerName:  fmt.Sprintf("peer%d", i+1),
+			}
+			if err := netlink.LinkAdd(veth); err != nil {
+				return err
+			}
+			addr, _ := netlink.ParseAddr(ip)
+			if err := netlink.AddrAdd(veth, addr); err != nil {
+				return err
+			}
+			for _, name := range []string{veth.Name, veth.PeerName} {
+				l, err := netlink.LinkByName(name)
+				if err != nil {
+					return err
+				}
+				if err := netlink.LinkSetUp(l); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// routes returns the number of next hops of the route to group, -1 if there's none
+	routes := func() int {
+		result := -1
+		netNs.Do(func(_ ns.NetNS) error {
+			list, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+			if err != nil {
+				return err
+			}
+			for _, r := range list {
+				if r.Dst == nil || r.Dst.String() != group {
+					continue
+				}
+				// the kernel stores a single next hop as a plain route
+				result = len(r.MultiPath)
+				if result == 0 {
+					result = 1
+				}
+			}
+			return nil
+		})
+		return result
+	}
+
+	if err := Apply(netNs.Path(), links); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if got := routes(); got != 2 {
+		t.Errorf("route has %d next hops, want 2", got)
+	}
+	// Removing a link from the group keeps the route over the other one
+	if err := Apply(netNs.Path(), links[:1], group); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if got := routes(); got != 1 {
+		t.Errorf("route has %d next hops, want 1", got)
+	}
+	if err := Apply(netNs.Path(), nil, group); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if got := routes(); got != -1 {
+		t.Errorf("route to %s hasn't been removed", group)
+	}
+}
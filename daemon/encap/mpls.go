@@ -0,0 +1,123 @@
+// Package encap configures label-based encapsulations of meshnet links using the
+// native MPLS support of the Linux kernel, which requires kernel 4.3 or later built
+// with CONFIG_MPLS_ROUTING and CONFIG_MPLS_IPTUNNEL (modules mpls_router and mpls_iptunnel).
+package encap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// labels 0-15 are reserved
+	minLabel = 16
+	maxLabel = 1<<20 - 1
+
+	platformLabels = "/proc/sys/net/mpls/platform_labels"
+)
+
+// Supported returns true if the kernel of this node supports MPLS routing
+func Supported() bool {
+	_, err := os.Stat(platformLabels)
+	return err == nil
+}
+
+// ValidateLabel checks that label can be used for a link, 0 meaning no label
+func ValidateLabel(label uint32) error {
+	if label != 0 && (label < minLabel || label > maxLabel) {
+		return fmt.Errorf("MPLS label must be between %d and %d, got %d", minLabel, maxLabel, label)
+	}
+	return nil
+}
+
+// ApplyMPLS pushes label on all traffic sent out of intfName to the link subnet of ip, and
+// pops it on the traffic received with that label, inside the nsName network namespace.
+// Both ends of a link must use the same label. A zero label does nothing.
+func ApplyMPLS(nsName, intfName, ip string, label uint32) error {
+	if label == 0 {
+		return nil
+	}
+	if err := ValidateLabel(label); err != nil {
+		return err
+	}
+	if !Supported() {
+		return fmt.Errorf("MPLS is not supported by the kernel, mpls_router and mpls_iptunnel modules are required")
+	}
+	_, subnet, err := net.ParseCIDR(ip)
+	if err != nil {
+		return fmt.Errorf("link IP is required to push MPLS labels: %s", err)
+	}
+
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		if err := raisePlatformLabels(label); err != nil {
+			return err
+		}
+		if err := writeSysctl(fmt.Sprintf("/proc/sys/net/mpls/conf/%s/input", intfName), "1"); err != nil {
+			return err
+		}
+
+		// Replacing the connected route of the link with one that pushes the label
+		if err := netlink.RouteReplace(&netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Dst:       subnet,
+			Scope:     netlink.SCOPE_LINK,
+			Encap:     &netlink.MPLSEncap{Labels: []int{int(label)}},
+		}); err != nil {
+			return fmt.Errorf("failed to add MPLS push route on %s: %s", intfName, err)
+		}
+
+		// Popping the label and handing the packet over to the local stack
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		dst := int(label)
+		if err := netlink.RouteReplace(&netlink.Route{
+			MPLSDst:   &dst,
+			LinkIndex: lo.Attrs().Index,
+		}); err != nil {
+			return fmt.Errorf("failed to add MPLS pop route for label %d: %s", label, err)
+		}
+		return nil
+	})
+}
+
+// raisePlatformLabels makes sure the label table of the namespace is large enough for label
+func raisePlatformLabels(label uint32) error {
+	data, err := ioutil.ReadFile(platformLabels)
+	if err != nil {
+		return err
+	}
+	current, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return err
+	}
+	if uint32(current) > label {
+		return nil
+	}
+	return writeSysctl(platformLabels, strconv.FormatUint(uint64(label)+1, 10))
+}
+
+func writeSysctl(path, value string) error {
+	if err := ioutil.WriteFile(path, []byte(value), 0644); err != nil {
+		return fmt.Errorf("failed to set %s: %s", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package encap
+
+import (
+	"os"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+)
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		label uint32
+		valid bool
+	}{
+		{label: 0, valid: true},
+		{label: 16, valid: true},
+		{label: 1048575, valid: true},
+		{label: 3, valid: false},
+		{label: 1048576, valid: false},
+	}
+	for i, tt := range tests {
+		err := ValidateLabel(tt.label)
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+func TestApplyMPLS(t *testing.T) {
+	if !Supported() {
+		t.Skip("kernel has no MPLS support")
+	}
+	if os.Geteuid() != 0 {
+		t.Skip("root is required to create network namespaces")
+	}
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+
+	err = netNs.Do(func(_ ns.NetNS) error {
+		dummy := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}}
+		if err := netlink.LinkAdd(dummy); err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(dummy)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyMPLS(netNs.Path(), "eth1", "10.0.0.1/30", 100); err != nil {
+		t.Fatalf("ApplyMPLS() failed: %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+// Package gtp sets up GTP-U tunnels between pods with the Linux GTP kernel module,
+// to emulate the N3/N9 interfaces of a 5G core.
+package gtp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"syscall"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/srv6"
+)
+
+const (
+	// Port is the UDP port of GTP-U, i.e. GTPv1 user plane
+	Port = 2152
+	// UDP port of GTPv0, which the kernel module requires a socket for as well
+	portV0 = 3386
+)
+
+// The kernel only references the UDP sockets of a GTP interface, they must be kept open
+// for as long as the interface is used
+var (
+	mu      sync.Mutex
+	tunnels = make(map[tunnelKey]*tunnel)
+)
+
+// tunnelKey identifies an interface by the inode of its netns and its index
+type tunnelKey struct {
+	netNs uint64
+	index int
+}
+
+type tunnel struct {
+	// path of the netns, if known, to release the sockets once it's gone
+	netNs string
+	conns []*net.UDPConn
+}
+
+func (t *tunnel) close() {
+	for _, c := range t.conns {
+		c.Close()
+	}
+}
+
+// Name returns the name of the interface created for teid
+func Name(teid uint32) string {
+	return fmt.Sprintf("gtp%d", teid)
+}
+
+// listen opens the UDP socket of port on ip, failing with a clear error if it's taken
+func listen(ip net.IP, port int) (*net.UDPConn, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: ip, Port: port})
+	if errors.Is(err, syscall.EADDRINUSE) {
+		return nil, fmt.Errorf(" MESHNETD: UDP port %d on %s is already in use, only one GTP-U tunnel can be set up per pod IP", port, ip)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(" MESHNETD: Error opening UDP port %d on %s: %s", port, ip, err)
+	}
+	return conn, nil
+}
+
+// CreateTunnel creates a GTP-U interface in the current netns sending the traffic of teid
+// from localIP to remoteIP, and returns its name. The session is only added by AddSession.
+func CreateTunnel(localIP, remoteIP net.IP, teid uint32) (string, error) {
+	if localIP.To4() == nil || remoteIP.To4() == nil {
+		return "", fmt.Errorf(" MESHNETD: GTP-U tunnel endpoints %s and %s must be IPv4 addresses", localIP, remoteIP)
+	}
+	name := Name(teid)
+	v1, err := listen(localIP, Port)
+	if err != nil {
+		return "", err
+	}
+	v0, err := listen(localIP, portV0)
+	if err != nil {
+		v1.Close()
+		return "", err
+	}
+	conns := []*net.UDPConn{v0, v1}
+	closeAll := func() {
+		for _, c := range conns {
+			c.Close()
+		}
+	}
+
+	var fds []int
+	for _, c := range conns {
+		f, err := c.File()
+		if err != nil {
+			closeAll()
+			return "", err
+		}
+		// the kernel takes its own reference to the socket, the duplicated fd isn't needed after LinkAdd
+		defer f.Close()
+		fds = append(fds, int(f.Fd()))
+	}
+	link := &netlink.GTP{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		FD0:       fds[0],
+		FD1:       fds[1],
+		// as an SGSN, sessions are looked up by the source of outgoing packets, the local link IP
+		Role: nl.GTP_ROLE_SGSN,
+	}
+	if err := netlink.LinkAdd(link); err != nil {
+		closeAll()
+		return "", fmt.Errorf(" MESHNETD: Error creating GTP-U interface %s, is the gtp kernel module loaded? %s", name, err)
+	}
+	if err := keep(name, conns); err != nil {
+		netlink.LinkDel(link)
+		closeAll()
+		return "", err
+	}
+	return name, nil
+}
+
+// currentNetNs returns the inode of the current netns
+func currentNetNs() (uint64, error) {
+	netNs, err := ns.GetCurrentNS()
+	if err != nil {
+		return 0, err
+	}
+	defer netNs.Close()
+	var st unix.Stat_t
+	if err := unix.Fstat(int(netNs.Fd()), &st); err != nil {
+		return 0, err
+	}
+	return st.Ino, nil
+}
+
+// keep stores the sockets of the interface name of the current netns
+func keep(name string, conns []*net.UDPConn) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return err
+	}
+	ino, err := currentNetNs()
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	tunnels[tunnelKey{ino, link.Attrs().Index}] = &tunnel{conns: conns}
+	return nil
+}
+
+// track records the path of the netns of the interface with index in the current netns
+func track(index int, path string) {
+	ino, err := currentNetNs()
+	if err != nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if t, ok := tunnels[tunnelKey{ino, index}]; ok {
+		t.netNs = path
+	}
+}
+
+// release closes the sockets of the interface with index of the current netns
+func release(index int) {
+	ino, err := currentNetNs()
+	if err != nil {
+		log.Warnf("Failed to release the sockets of GTP-U interface %d: %s", index, err)
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	key := tunnelKey{ino, index}
+	if t, ok := tunnels[key]; ok {
+		t.close()
+		delete(tunnels, key)
+	}
+}
+
+// Prune closes the sockets of the tunnels whose netns has been deleted, which they would
+// otherwise keep alive
+func Prune() {
+	mu.Lock()
+	defer mu.Unlock()
+	for key, t := range tunnels {
+		if t.netNs == "" {
+			continue
+		}
+		var st unix.Stat_t
+		if err := unix.Stat(t.netNs, &st); err == nil && st.Ino == key.netNs {
+			continue
+		}
+		log.Infof("Releasing the GTP-U sockets of deleted netns %s", t.netNs)
+		t.close()
+		delete(tunnels, key)
+	}
+}
+
+// AddSession tunnels the traffic from msIP, the local IP of the link, to remoteIP with teid
+func AddSession(link netlink.Link, msIP, remoteIP net.IP, teid uint32) error {
+	pdp := &netlink.PDP{
+		Version:     1,
+		PeerAddress: remoteIP,
+		MSAddress:   msIP,
+		ITEI:        teid,
+		OTEI:        teid,
+	}
+	if err := netlink.GTPPDPAdd(link, pdp); err != nil {
+		return fmt.Errorf(" MESHNETD: Error adding GTP-U session %d to %s: %s", teid, link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// CreateOrUpdate sets up a GTP-U link from the pod's primary IP to its peer at PeerVtep,
+// re-creating the interface if it exists already.
+func CreateOrUpdate(pod *mpb.RemotePod) error {
+	if pod.GtpTeid == 0 {
+		return fmt.Errorf(" MESHNETD: GTP-U link %s requires a TEID", pod.IntfName)
+	}
+	if pod.IntfIp == "" {
+		return fmt.Errorf(" MESHNETD: GTP-U link %s requires an IP address", pod.IntfName)
+	}
+	ipAddr, ipSubnet, err := net.ParseCIDR(pod.IntfIp)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error parsing CIDR %s: %s", pod.IntfIp, err)
+	}
+	remoteIP := net.ParseIP(pod.PeerVtep)
+	if remoteIP == nil {
+		return fmt.Errorf(" MESHNETD: Error parsing GTP-U peer %s", pod.PeerVtep)
+	}
+	localIP, err := srv6.PrimaryIP(pod.NetNs, netlink.FAMILY_V4)
+	if err != nil {
+		return err
+	}
+
+	podNs, err := ns.GetNS(pod.NetNs)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error opening netns %s: %s", pod.NetNs, err)
+	}
+	defer podNs.Close()
+
+	return podNs.Do(func(_ ns.NetNS) error {
+		if old, err := netlink.LinkByName(pod.IntfName); err == nil {
+			log.Infof("Re-creating GTP-U link %s", pod.IntfName)
+			if err := netlink.LinkDel(old); err != nil {
+				return err
+			}
+			release(old.Attrs().Index)
+		}
+		name, err := CreateTunnel(localIP, remoteIP, pod.GtpTeid)
+		if err != nil {
+			return err
+		}
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return err
+		}
+		track(link.Attrs().Index, pod.NetNs)
+		if err := netlink.LinkSetName(link, pod.IntfName); err != nil {
+			return fmt.Errorf(" MESHNETD: Error renaming %s to %s: %s", name, pod.IntfName, err)
+		}
+		if err := netlink.AddrReplace(link, &netlink.Addr{IPNet: &net.IPNet{IP: ipAddr, Mask: ipSubnet.Mask}}); err != nil {
+			return fmt.Errorf(" MESHNETD: Error adding address %s to %s: %s", pod.IntfIp, pod.IntfName, err)
+		}
+		if err := AddSession(link, ipAddr, remoteIP, pod.GtpTeid); err != nil {
+			return err
+		}
+		log.Infof("GTP-U link %s with TEID %d is up towards %s", pod.IntfName, pod.GtpTeid, remoteIP)
+		return netlink.LinkSetUp(link)
+	})
+}
@@ -0,0 +1,106 @@
+package gtp
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+var localhost = net.ParseIP("127.0.0.1")
+
+func newNS(t *testing.T) ns.NetNS {
+	if os.Geteuid() != 0 {
+		t.Skip("root is required to create network namespaces")
+	}
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		netNs.Close()
+		testutils.UnmountNS(netNs)
+	})
+	err = netNs.Do(func(_ ns.NetNS) error {
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(lo)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return netNs
+}
+
+func TestPortInUse(t *testing.T) {
+	netNs := newNS(t)
+	netNs.Do(func(_ ns.NetNS) error {
+		conn, err := listen(localhost, Port)
+		if err != nil {
+			t.Fatalf("listen() failed: %v", err)
+		}
+		defer conn.Close()
+		_, err = CreateTunnel(localhost, net.ParseIP("127.0.0.2"), 1)
+		if err == nil || !strings.Contains(err.Error(), "already in use") {
+			t.Errorf("CreateTunnel() = %v, want port 2152 in use", err)
+		}
+		return nil
+	})
+}
+
+func TestCreateTunnel(t *testing.T) {
+	netNs := newNS(t)
+	netNs.Do(func(_ ns.NetNS) error {
+		name, err := CreateTunnel(localhost, net.ParseIP("127.0.0.2"), 42)
+		if err != nil {
+			t.Skipf("GTP is not supported: %v", err)
+		}
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			t.Fatalf("interface %s hasn't been created: %v", name, err)
+		}
+		if err := AddSession(link, net.ParseIP("10.0.0.1"), net.ParseIP("127.0.0.2"), 42); err != nil {
+			t.Errorf("AddSession() failed: %v", err)
+		}
+		netlink.LinkDel(link)
+		release(link.Attrs().Index)
+		return nil
+	})
+}
+
+func TestPrune(t *testing.T) {
+	f, err := ioutil.TempFile("", "netns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	var st unix.Stat_t
+	if err := unix.Stat(f.Name(), &st); err != nil {
+		t.Fatal(err)
+	}
+
+	tunnels[tunnelKey{st.Ino, 1}] = &tunnel{netNs: f.Name()}
+	tunnels[tunnelKey{st.Ino + 1, 1}] = &tunnel{netNs: f.Name()}
+	tunnels[tunnelKey{st.Ino + 2, 1}] = &tunnel{}
+	defer func() { tunnels = make(map[tunnelKey]*tunnel) }()
+
+	Prune()
+	if _, ok := tunnels[tunnelKey{st.Ino, 1}]; !ok {
+		t.Error("tunnel of an existing netns has been pruned")
+	}
+	if _, ok := tunnels[tunnelKey{st.Ino + 1, 1}]; ok {
+		t.Error("tunnel of a re-created netns hasn't been pruned")
+	}
+	if _, ok := tunnels[tunnelKey{st.Ino + 2, 1}]; !ok {
+		t.Error("tunnel of an unknown netns has been pruned")
+	}
+}
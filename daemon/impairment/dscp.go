@@ -0,0 +1,294 @@
+package impairment
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// MaxDSCP is the largest DSCP class
+const MaxDSCP = 63
+
+// The version of the netlink library in use has neither flower filters nor pedit and csum
+// actions, so their messages are built here
+const (
+	tcaFlowerAct        = 3
+	tcaFlowerKeyEthType = 8
+	tcaPeditParms       = 2
+	tcaCsumParms        = 1
+	csumUpdateIPv4Hdr   = 1
+
+	// flower filters marking traffic have handle dscpHandle+DSCP
+	dscpHandle = 0xd5c00
+	dscpPrio   = 10
+)
+
+var clsactHandle = netlink.MakeHandle(0xffff, 0)
+
+// ApplyDSCP marks the IPv4 and IPv6 traffic sent out of the interface intfName of the nsName
+// network namespace with the DSCP class of class, keeping the ECN bits. Each IP version is
+// marked by a tc flower filter on the clsact egress hook of the interface, whose pedit action
+// rewrites the DS field of the header. A nil class removes the marking.
+func ApplyDSCP(nsName, intfName string, class *mpb.TrafficClass) error {
+	if class != nil && class.Dscp > MaxDSCP {
+		return fmt.Errorf("DSCP must be between 0 and %d, got %d", MaxDSCP, class.Dscp)
+	}
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		if err := removeDSCP(link); err != nil {
+			return err
+		}
+		if class == nil {
+			return nil
+		}
+		log.Infof("Marking the traffic sent out of %s with DSCP %d", intfName, class.Dscp)
+		if err := ensureClsact(link); err != nil {
+			return err
+		}
+		for _, proto := range []uint16{unix.ETH_P_IP, unix.ETH_P_IPV6} {
+			if err := addDSCPFilter(link.Attrs().Index, proto, class.Dscp); err != nil {
+				return fmt.Errorf("failed to mark the traffic of %s with DSCP %d: %s", intfName, class.Dscp, err)
+			}
+		}
+		return nil
+	})
+}
+
+// DSCPBytes returns the bytes marked by the DSCP filters of the interface intfName of the
+// nsName network namespace, by DSCP class. It's empty if the interface has no traffic class.
+func DSCPBytes(nsName, intfName string) (map[uint32]uint64, error) {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	result := map[uint32]uint64{}
+	err = netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		req := nl.NewNetlinkRequest(unix.RTM_GETTFILTER, unix.NLM_F_DUMP)
+		req.AddData(&nl.TcMsg{Family: nl.FAMILY_ALL, Ifindex: int32(link.Attrs().Index), Parent: netlink.HANDLE_MIN_EGRESS})
+		msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWTFILTER)
+		if err != nil {
+			// the interface has no clsact qdisc
+			return nil
+		}
+		for _, m := range msgs {
+			msg := nl.DeserializeTcMsg(m)
+			dscp, ok := dscpOf(msg.Handle)
+			if !ok {
+				continue
+			}
+			attrs, err := nl.ParseRouteAttr(m[msg.Len():])
+			if err != nil {
+				return err
+			}
+			bytes, err := parseFilterBytes(attrs)
+			if err != nil {
+				return err
+			}
+			result[dscp] += bytes
+		}
+		return nil
+	})
+	return result, err
+}
+
+func dscpOf(handle uint32) (uint32, bool) {
+	if handle < dscpHandle || handle > dscpHandle+MaxDSCP {
+		return 0, false
+	}
+	return handle - dscpHandle, true
+}
+
+// ensureClsact adds a clsact qdisc to link, replacing the ingress qdisc that older versions
+// redirected ingress traffic to the IFB with, since the two can't coexist
+func ensureClsact(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("failed to list the qdiscs of %s: %s", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if q.Type() == "clsact" {
+			return nil
+		}
+		if _, ok := q.(*netlink.Ingress); ok {
+			if err := netlink.QdiscDel(q); err != nil {
+				return fmt.Errorf("failed to remove ingress qdisc from %s: %s", link.Attrs().Name, err)
+			}
+		}
+	}
+	if err := netlink.QdiscAdd(&netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    clsactHandle,
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}); err != nil {
+		return fmt.Errorf("failed to add clsact qdisc to %s: %s", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// removeDSCP removes the DSCP filters of link
+func removeDSCP(link netlink.Link) error {
+	filters, err := netlink.FilterList(link, netlink.HANDLE_MIN_EGRESS)
+	if err != nil {
+		// the interface has no clsact qdisc
+		return nil
+	}
+	for _, f := range filters {
+		if _, ok := dscpOf(f.Attrs().Handle); !ok {
+			continue
+		}
+		if err := netlink.FilterDel(f); err != nil {
+			return fmt.Errorf("failed to remove DSCP filter from %s: %s", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+// addDSCPFilter adds a flower filter matching all the traffic of the ethertype proto sent
+// out of the interface with index and setting its DSCP class
+func addDSCPFilter(index int, proto uint16, dscp uint32) error {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWTFILTER, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(&nl.TcMsg{
+		Family:  nl.FAMILY_ALL,
+		Ifindex: int32(index),
+		Handle:  dscpHandle + dscp,
+		Parent:  netlink.HANDLE_MIN_EGRESS,
+		Info:    netlink.MakeHandle(dscpPrio, nl.Swap16(proto)),
+	})
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated("flower")))
+	options := nl.NewRtAttr(nl.TCA_OPTIONS, nil)
+	ethType := make([]byte, 2)
+	binary.BigEndian.PutUint16(ethType, proto)
+	options.AddRtAttr(tcaFlowerKeyEthType, ethType)
+
+	actions := options.AddRtAttr(tcaFlowerAct, nil)
+	pedit := actions.AddRtAttr(nl.TCA_ACT_TAB, nil)
+	pedit.AddRtAttr(nl.TCA_ACT_KIND, nl.ZeroTerminated("pedit"))
+	peditOpts := pedit.AddRtAttr(nl.TCA_ACT_OPTIONS, nil)
+	if proto == unix.ETH_P_IP {
+		peditOpts.AddRtAttr(tcaPeditParms, peditParms(netlink.TC_ACT_PIPE, dsfieldKey(dscp, false)))
+		// the header checksum covers the DS field
+		csum := actions.AddRtAttr(nl.TCA_ACT_TAB+1, nil)
+		csum.AddRtAttr(nl.TCA_ACT_KIND, nl.ZeroTerminated("csum"))
+		csumOpts := csum.AddRtAttr(nl.TCA_ACT_OPTIONS, nil)
+		csumOpts.AddRtAttr(tcaCsumParms, csumParms(csumUpdateIPv4Hdr))
+	} else {
+		peditOpts.AddRtAttr(tcaPeditParms, peditParms(netlink.TC_ACT_OK, dsfieldKey(dscp, true)))
+	}
+	req.AddData(options)
+	_, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+// peditKey is a struct tc_pedit_key, which rewrites the 32-bit word at off from the network
+// header as (word & mask) ^ val. mask and val are in network byte order.
+type peditKey struct {
+	mask, val, off uint32
+}
+
+// dsfieldKey sets the DSCP class of the first word of the IPv4 or IPv6 header, the top 6
+// bits of the TOS byte of IPv4 and of the traffic class of IPv6
+func dsfieldKey(dscp uint32, v6 bool) peditKey {
+	shift := uint32(18)
+	if v6 {
+		shift = 22
+	}
+	return peditKey{mask: ^(uint32(MaxDSCP) << shift), val: dscp << shift}
+}
+
+// peditParms serializes a struct tc_pedit_sel with key
+func peditParms(action netlink.TcAct, key peditKey) []byte {
+	gen := nl.TcGen{Action: int32(action)}
+	b := make([]byte, 0, nl.SizeofTcGen+4+24)
+	b = append(b, gen.Serialize()...)
+	// nkeys, flags and padding
+	b = append(b, 1, 0, 0, 0)
+	k := make([]byte, 24)
+	binary.BigEndian.PutUint32(k[0:4], key.mask)
+	binary.BigEndian.PutUint32(k[4:8], key.val)
+	nl.NativeEndian().PutUint32(k[8:12], key.off)
+	// at, offmask and shift are only used by offsets read from the packet
+	return append(b, k...)
+}
+
+// csumParms serializes a struct tc_csum
+func csumParms(flags uint32) []byte {
+	gen := nl.TcGen{Action: int32(netlink.TC_ACT_OK)}
+	b := append([]byte{}, gen.Serialize()...)
+	f := make([]byte, 4)
+	nl.NativeEndian().PutUint32(f, flags)
+	return append(b, f...)
+}
+
+// parseFilterBytes returns the bytes counted by the first action of a flower filter
+func parseFilterBytes(attrs []syscall.NetlinkRouteAttr) (uint64, error) {
+	for _, attr := range attrs {
+		if attr.Attr.Type != nl.TCA_OPTIONS {
+			continue
+		}
+		options, err := nl.ParseRouteAttr(attr.Value)
+		if err != nil {
+			return 0, err
+		}
+		for _, o := range options {
+			if o.Attr.Type != tcaFlowerAct {
+				continue
+			}
+			tables, err := nl.ParseRouteAttr(o.Value)
+			if err != nil || len(tables) == 0 {
+				return 0, err
+			}
+			return parseActionBytes(tables[0].Value)
+		}
+	}
+	return 0, nil
+}
+
+// parseActionBytes reads the bytes of the gnet_stats_basic of an action
+func parseActionBytes(data []byte) (uint64, error) {
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return 0, err
+	}
+	for _, attr := range attrs {
+		if attr.Attr.Type != nl.TCA_ACT_STATS {
+			continue
+		}
+		stats, err := nl.ParseRouteAttr(attr.Value)
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range stats {
+			// bytes and packets
+			if s.Attr.Type == nl.TCA_STATS_BASIC && len(s.Value) >= 8 {
+				return nl.NativeEndian().Uint64(s.Value[0:8]), nil
+			}
+		}
+	}
+	return 0, nil
+}
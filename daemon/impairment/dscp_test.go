@@ -0,0 +1,76 @@
+package impairment
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestDsfieldKey(t *testing.T) {
+	tests := []struct {
+		dscp uint32
+		v6   bool
+		want peditKey
+	}{
+		// EF in the TOS byte, keeping the ECN bits
+		{dscp: 46, want: peditKey{mask: 0xff03ffff, val: 0x00b80000}},
+		{dscp: 0, want: peditKey{mask: 0xff03ffff}},
+		// EF in the traffic class, after the version
+		{dscp: 46, v6: true, want: peditKey{mask: 0xf03fffff, val: 0x0b800000}},
+		{dscp: 63, v6: true, want: peditKey{mask: 0xf03fffff, val: 0x0fc00000}},
+	}
+	for _, tt := range tests {
+		if got := dsfieldKey(tt.dscp, tt.v6); got != tt.want {
+			t.Errorf("dsfieldKey(%d, %t) = %+v, want %+v", tt.dscp, tt.v6, got, tt.want)
+		}
+	}
+}
+
+func TestApplyDSCP(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ApplyDSCP(netNs.Path(), "eth1", &mpb.TrafficClass{Dscp: 64}); err == nil {
+		t.Errorf("ApplyDSCP() of DSCP 64 succeeded")
+	}
+	if got, err := DSCPBytes(netNs.Path(), "eth1"); err != nil || len(got) != 0 {
+		t.Errorf("DSCPBytes() without a traffic class = %v, %v", got, err)
+	}
+	if err := ApplyDSCP(netNs.Path(), "eth1", &mpb.TrafficClass{Dscp: 46}); err != nil {
+		t.Skipf("can't mark traffic: %v", err)
+	}
+	// the ingress impairment shares the clsact qdisc
+	if err := Apply(netNs.Path(), "eth1", nil, &mpb.ImpairmentSpec{LatencyMs: 100}); err != nil {
+		t.Fatalf("Apply() of an ingress impairment with a traffic class failed: %v", err)
+	}
+	if err := ApplyDSCP(netNs.Path(), "eth1", &mpb.TrafficClass{Dscp: 10}); err != nil {
+		t.Fatalf("ApplyDSCP() of another class failed: %v", err)
+	}
+	if got, err := DSCPBytes(netNs.Path(), "eth1"); err != nil || !reflect.DeepEqual(got, map[uint32]uint64{10: 0}) {
+		t.Errorf("DSCPBytes() = %v, %v, want no bytes of class 10", got, err)
+	}
+	if err := Apply(netNs.Path(), "eth1", nil, nil); err != nil {
+		t.Fatalf("Apply() removing the ingress impairment failed: %v", err)
+	}
+	if err := ApplyDSCP(netNs.Path(), "eth1", nil); err != nil {
+		t.Fatalf("ApplyDSCP() removing the traffic class failed: %v", err)
+	}
+	if got, err := DSCPBytes(netNs.Path(), "eth1"); err != nil || len(got) != 0 {
+		t.Errorf("DSCPBytes() after removing the traffic class = %v, %v", got, err)
+	}
+}
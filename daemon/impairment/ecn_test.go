@@ -0,0 +1,174 @@
+package impairment
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	ecnMask = 0x03
+	ecnECT0 = 0x02
+	ecnCE   = 0x03
+)
+
+func TestThresholdOf(t *testing.T) {
+	tests := []struct {
+		threshold float32
+		want      float32
+	}{
+		{threshold: 0, want: DefaultECNThresholdPercent},
+		{threshold: 5, want: 5},
+		{threshold: 3, want: 3},
+		{threshold: 50, want: 50},
+		{threshold: 0.5, want: 0.5},
+	}
+	for _, tt := range tests {
+		// the kernel reports the fixed target along with the interval
+		q := fqCodel(1, &mpb.ImpairmentSpec{EcnEnabled: true, EcnThresholdPercent: tt.threshold})
+		q.Target = codelTargetUs
+		if got := thresholdOf(q); got != tt.want {
+			t.Errorf("thresholdOf() with a threshold of %v = %v, want %v", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+// ipv4Frame returns a broadcast Ethernet frame with an IPv4 header whose ECN field is ecn
+func ipv4Frame(ecn byte) []byte {
+	frame := make([]byte, 14+20+8)
+	for i := 0; i < 6; i++ {
+		frame[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(frame[12:], unix.ETH_P_IP)
+	ip := frame[14:]
+	ip[0] = 0x45
+	ip[1] = ecn
+	binary.BigEndian.PutUint16(ip[2:], 28)
+	ip[8] = 64
+	ip[9] = unix.IPPROTO_UDP
+	copy(ip[12:], []byte{10, 0, 0, 1})
+	copy(ip[16:], []byte{10, 0, 0, 2})
+	return frame
+}
+
+// packetSocket opens a packet socket receiving the IPv4 frames of intf, returning it and the
+// index of intf
+func packetSocket(intf string) (int, int, error) {
+	link, err := netlink.LinkByName(intf)
+	if err != nil {
+		return 0, 0, err
+	}
+	proto := uint16(unix.ETH_P_IP<<8&0xff00 | unix.ETH_P_IP>>8)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(proto))
+	if err != nil {
+		return 0, 0, err
+	}
+	tv := unix.NsecToTimeval(time.Second.Nanoseconds())
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Ifindex: link.Attrs().Index, Protocol: proto}); err != nil {
+		unix.Close(fd)
+		return 0, 0, err
+	}
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return 0, 0, err
+	}
+	return fd, link.Attrs().Index, nil
+}
+
+func TestECN(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"}); err != nil {
+			return err
+		}
+		for _, name := range []string{"eth1", "eth2"} {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				return err
+			}
+			if err := netlink.LinkSetUp(link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &mpb.ImpairmentSpec{LatencyMs: 1, EcnEnabled: true, EcnThresholdPercent: 10}
+	if err := Apply(netNs.Path(), "eth1", spec, nil); err != nil {
+		t.Skipf("can't apply an impairment with ECN: %v", err)
+	}
+	egress, _, err := Read(netNs.Path(), "eth1")
+	if err != nil || !egress.EcnEnabled || egress.EcnThresholdPercent != 10 {
+		t.Errorf("Read() = %v, %v, want ECN with a threshold of 10%%", egress, err)
+	}
+
+	// ECN-capable packets sent through the qdiscs keep an ECT or CE codepoint
+	received, marked := 0, 0
+	err = netNs.Do(func(ns.NetNS) error {
+		tx, index, err := packetSocket("eth1")
+		if err != nil {
+			return err
+		}
+		defer unix.Close(tx)
+		rx, _, err := packetSocket("eth2")
+		if err != nil {
+			return err
+		}
+		defer unix.Close(rx)
+		const sent = 100
+		for i := 0; i < sent; i++ {
+			if err := unix.Sendto(tx, ipv4Frame(ecnECT0), 0, &unix.SockaddrLinklayer{Ifindex: index, Halen: 6}); err != nil {
+				return err
+			}
+		}
+		buf := make([]byte, 1500)
+		for received < sent {
+			n, _, err := unix.Recvfrom(rx, buf, 0)
+			if err != nil {
+				break
+			}
+			if n < 14+20 {
+				continue
+			}
+			switch ecn := buf[14+1] & ecnMask; ecn {
+			case ecnECT0:
+			case ecnCE:
+				marked++
+			default:
+				return fmt.Errorf("received a packet with ECN field %#x", ecn)
+			}
+			received++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received == 0 {
+		t.Errorf("no packet received through the ECN qdisc")
+	}
+	t.Logf("%d packets received, %d of them marked CE", received, marked)
+
+	if err := Apply(netNs.Path(), "eth1", &mpb.ImpairmentSpec{LatencyMs: 1}, nil); err != nil {
+		t.Fatalf("Apply() disabling ECN failed: %v", err)
+	}
+	if egress, _, err := Read(netNs.Path(), "eth1"); err != nil || egress.EcnEnabled {
+		t.Errorf("Read() after disabling ECN = %v, %v", egress, err)
+	}
+}
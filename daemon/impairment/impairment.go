@@ -0,0 +1,287 @@
+package impairment
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	ifbPrefix = "ifb-"
+	// maximum length of a Linux interface name
+	maxIntfName = 15
+
+	// DefaultECNThresholdPercent is the codel target as a percentage of its interval used
+	// when an ECN threshold isn't set, i.e. the codel defaults of 5ms and 100ms
+	DefaultECNThresholdPercent = 5
+	// target of the fq_codel qdiscs, which can't be changed, in microseconds
+	codelTargetUs = 5000
+)
+
+// Apply configures the impairments of a link inside the nsName network namespace.
+// Egress impairments are applied with a netem root qdisc on the link itself. Ingress
+// traffic is redirected to an IFB interface, which applies netem on its egress instead.
+// An empty spec removes any previously configured impairment in that direction.
+func Apply(nsName, intfName string, egress, ingress *mpb.ImpairmentSpec) error {
+	if err := Validate(egress); err != nil {
+		return err
+	}
+	if err := Validate(ingress); err != nil {
+		return err
+	}
+
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		if err := applyEgress(link, egress); err != nil {
+			return err
+		}
+		return applyIngress(link, ingress)
+	})
+}
+
+// Validate checks that the impairment values are within their allowed ranges
+func Validate(spec *mpb.ImpairmentSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if spec.LatencyMs < 0 || spec.JitterMs < 0 {
+		return fmt.Errorf("latency and jitter must not be negative")
+	}
+	for name, p := range map[string]float32{
+		"loss":      spec.LossPercent,
+		"duplicate": spec.DuplicatePercent,
+		"corrupt":   spec.CorruptPercent,
+	} {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("%s must be between 0 and 100 percent, got %v", name, p)
+		}
+	}
+	if spec.EcnThresholdPercent < 0 || spec.EcnThresholdPercent > 100 {
+		return fmt.Errorf("the ECN threshold must be between 0 and 100 percent, got %v", spec.EcnThresholdPercent)
+	}
+	for name, p := range map[string]float32{
+		"reorder":             spec.ReorderPercent,
+		"reorder correlation": spec.ReorderCorrelationPercent,
+	} {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("%s must be between 0 and 100 percent, got %v", name, p)
+		}
+	}
+	if spec.ReorderPercent == 0 && (spec.ReorderCorrelationPercent != 0 || spec.ReorderGap != 0) {
+		return fmt.Errorf("the reorder correlation and gap must be set with a reorder percentage")
+	}
+	// netem reorders packets by sending some of them without the delay of the others
+	if spec.ReorderPercent > 0 && spec.LatencyMs <= 0 {
+		return fmt.Errorf("reordering packets requires a latency")
+	}
+	return nil
+}
+
+// IsEmpty returns true if the spec has no impairments set
+func IsEmpty(spec *mpb.ImpairmentSpec) bool {
+	return spec == nil || (spec.LatencyMs == 0 &&
+		spec.JitterMs == 0 &&
+		spec.LossPercent == 0 &&
+		spec.DuplicatePercent == 0 &&
+		spec.CorruptPercent == 0 &&
+		spec.ReorderPercent == 0 &&
+		!spec.EcnEnabled)
+}
+
+// ECNThreshold returns the ECN threshold of spec, the default one when it isn't set
+func ECNThreshold(spec *mpb.ImpairmentSpec) float32 {
+	if spec.GetEcnThresholdPercent() == 0 {
+		return DefaultECNThresholdPercent
+	}
+	return spec.GetEcnThresholdPercent()
+}
+
+// ReorderGap returns the reorder gap of spec, 1 when it isn't set like netem does
+func ReorderGap(spec *mpb.ImpairmentSpec) uint32 {
+	if spec.GetReorderPercent() > 0 && spec.GetReorderGap() == 0 {
+		return 1
+	}
+	return spec.GetReorderGap()
+}
+
+// ifbName returns the IFB interface name for a link, hashing names that would be too long
+func ifbName(intfName string) string {
+	if len(ifbPrefix+intfName) <= maxIntfName {
+		return ifbPrefix + intfName
+	}
+	sum := sha1.Sum([]byte(intfName))
+	return ifbPrefix + hex.EncodeToString(sum[:])[:maxIntfName-len(ifbPrefix)]
+}
+
+func netem(linkIndex int, spec *mpb.ImpairmentSpec) *netlink.Netem {
+	return netlink.NewNetem(
+		netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		netlink.NetemQdiscAttrs{
+			Latency:     uint32(spec.LatencyMs * 1000),
+			Jitter:      uint32(spec.JitterMs * 1000),
+			Loss:        spec.LossPercent,
+			Duplicate:   spec.DuplicatePercent,
+			CorruptProb: spec.CorruptPercent,
+			ReorderProb: spec.ReorderPercent,
+			ReorderCorr: spec.ReorderCorrelationPercent,
+			Gap:         spec.ReorderGap,
+		},
+	)
+}
+
+// fqCodel returns the ECN-marking qdisc under the netem root qdisc of the link with index.
+// The codel interval is set so that the fixed target is the threshold percentage of it.
+func fqCodel(linkIndex int, spec *mpb.ImpairmentSpec) *netlink.FqCodel {
+	q := netlink.NewFqCodel(netlink.QdiscAttrs{
+		LinkIndex: linkIndex,
+		Handle:    netlink.MakeHandle(10, 0),
+		Parent:    netlink.MakeHandle(1, 1),
+	})
+	q.Interval = uint32(math.Round(codelTargetUs * 100 / float64(ECNThreshold(spec))))
+	return q
+}
+
+// applyECN adds, changes or removes the ECN-marking qdisc under the netem root qdisc of link
+func applyECN(link netlink.Link, spec *mpb.ImpairmentSpec) error {
+	if spec.GetEcnEnabled() {
+		if err := netlink.QdiscReplace(fqCodel(link.Attrs().Index, spec)); err != nil {
+			return fmt.Errorf("failed to enable ECN on %s: %s", link.Attrs().Name, err)
+		}
+		return nil
+	}
+	q, err := readFqCodel(link)
+	if err != nil || q == nil {
+		return err
+	}
+	if err := netlink.QdiscDel(q); err != nil {
+		return fmt.Errorf("failed to disable ECN on %s: %s", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+func applyEgress(link netlink.Link, spec *mpb.ImpairmentSpec) error {
+	if IsEmpty(spec) {
+		removeNetem(link)
+		return nil
+	}
+	log.Infof("Applying egress impairment %+v to %s", spec, link.Attrs().Name)
+	if err := netlink.QdiscReplace(netem(link.Attrs().Index, spec)); err != nil {
+		return fmt.Errorf("failed to apply egress impairment to %s: %s", link.Attrs().Name, err)
+	}
+	return applyECN(link, spec)
+}
+
+func applyIngress(link netlink.Link, spec *mpb.ImpairmentSpec) error {
+	name := ifbName(link.Attrs().Name)
+	if IsEmpty(spec) {
+		return removeIngress(link, name)
+	}
+	log.Infof("Applying ingress impairment %+v to %s via %s", spec, link.Attrs().Name, name)
+
+	// The IFB is created on first use and is kept for as long as the ingress impairment exists
+	ifb, err := netlink.LinkByName(name)
+	if err != nil {
+		if err := netlink.LinkAdd(&netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: name}}); err != nil {
+			return fmt.Errorf("failed to create IFB %s: %s", name, err)
+		}
+		if ifb, err = netlink.LinkByName(name); err != nil {
+			return err
+		}
+	}
+	if err := netlink.LinkSetUp(ifb); err != nil {
+		return fmt.Errorf("failed to bring up IFB %s: %s", name, err)
+	}
+
+	// The clsact qdisc is shared with the DSCP marking of the egress traffic
+	if err := ensureClsact(link); err != nil {
+		return err
+	}
+
+	// Matching all traffic and redirecting it to the egress of the IFB
+	if err := netlink.FilterReplace(redirectFilter(link, ifb.Attrs().Index)); err != nil {
+		return fmt.Errorf("failed to redirect %s to IFB %s: %s", link.Attrs().Name, name, err)
+	}
+
+	if err := netlink.QdiscReplace(netem(ifb.Attrs().Index, spec)); err != nil {
+		return fmt.Errorf("failed to apply ingress impairment to %s: %s", name, err)
+	}
+	return applyECN(ifb, spec)
+}
+
+// redirectFilter matches all the traffic received on link and redirects it to the egress of
+// the IFB with index
+func redirectFilter(link netlink.Link, index int) *netlink.U32 {
+	return &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_INGRESS,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{netlink.NewMirredAction(index)},
+	}
+}
+
+func removeNetem(link netlink.Link) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return
+	}
+	for _, q := range qdiscs {
+		if _, ok := q.(*netlink.Netem); ok && q.Attrs().Parent == netlink.HANDLE_ROOT {
+			if err := netlink.QdiscDel(q); err != nil {
+				log.Warnf("Failed to remove netem qdisc from %s: %s", link.Attrs().Name, err)
+			}
+		}
+	}
+}
+
+func removeIngress(link netlink.Link, name string) error {
+	ifb, err := netlink.LinkByName(name)
+	if err != nil {
+		// Nothing to clean up
+		return nil
+	}
+	log.Infof("Removing ingress impairment from %s", link.Attrs().Name)
+	qdiscs, err := netlink.QdiscList(link)
+	if err == nil {
+		for _, q := range qdiscs {
+			var err error
+			switch {
+			case q.Type() == "clsact":
+				err = netlink.FilterDel(redirectFilter(link, ifb.Attrs().Index))
+			case q.Type() == "ingress":
+				// set up by an older version
+				err = netlink.QdiscDel(q)
+			}
+			if err != nil {
+				log.Warnf("Failed to remove the redirection of %s to IFB %s: %s", link.Attrs().Name, name, err)
+			}
+		}
+	}
+	if err := netlink.LinkDel(ifb); err != nil {
+		return fmt.Errorf("failed to remove IFB %s: %s", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package impairment
+
+import (
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		spec  *mpb.ImpairmentSpec
+		valid bool
+	}{
+		{
+			spec:  nil,
+			valid: true,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LatencyMs: 10, JitterMs: 2, LossPercent: 0.1},
+			valid: true,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LatencyMs: -1},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LossPercent: 101},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{CorruptPercent: -0.5},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{EcnEnabled: true, EcnThresholdPercent: 20},
+			valid: true,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{EcnEnabled: true, EcnThresholdPercent: 120},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25, ReorderCorrelationPercent: 50, ReorderGap: 5},
+			valid: true,
+		},
+		{
+			// netem can't reorder packets without a delay
+			spec:  &mpb.ImpairmentSpec{ReorderPercent: 25},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 101},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LatencyMs: 10, ReorderCorrelationPercent: 50},
+			valid: false,
+		},
+		{
+			spec:  &mpb.ImpairmentSpec{LatencyMs: 10, ReorderGap: 5},
+			valid: false,
+		},
+	}
+	for i, tt := range tests {
+		err := Validate(tt.spec)
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+func TestIfbName(t *testing.T) {
+	tests := []struct {
+		intf     string
+		expected string
+	}{
+		{
+			intf:     "eth1",
+			expected: "ifb-eth1",
+		},
+		{
+			intf:     "Ethernet1_1",
+			expected: "ifb-Ethernet1_1",
+		},
+		{
+			intf:     "GigabitEthernet0/0/0/1",
+			expected: "ifb-350b1281375",
+		},
+	}
+	for i, tt := range tests {
+		result := ifbName(tt.intf)
+		if len(result) > maxIntfName {
+			t.Errorf("#%d test failed: %s is longer than %d", i, result, maxIntfName)
+		}
+		if result != tt.expected {
+			t.Errorf("#%d test failed: expected %s, got %s", i, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package impairment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// Patch changes the impairments of a link inside the nsName network namespace in place, so
+// that its traffic isn't interrupted: the existing netem qdiscs are changed rather than
+// replaced, and only the directions without one get a new qdisc, as with Apply. A nil spec
+// leaves its direction as it is, and an empty one removes its impairment.
+func Patch(nsName, intfName string, egress, ingress *mpb.ImpairmentSpec) error {
+	if err := Validate(egress); err != nil {
+		return err
+	}
+	if err := Validate(ingress); err != nil {
+		return err
+	}
+
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		switch {
+		case egress == nil:
+		case IsEmpty(egress):
+			removeNetem(link)
+		default:
+			if err := changeNetem(link, egress); err != nil {
+				return err
+			}
+		}
+		if ingress == nil {
+			return nil
+		}
+		ifb, err := netlink.LinkByName(ifbName(intfName))
+		if err != nil || IsEmpty(ingress) {
+			// without an IFB, the ingress traffic isn't redirected yet
+			return applyIngress(link, ingress)
+		}
+		return changeNetem(ifb, ingress)
+	})
+}
+
+// changeNetem changes the parameters of the netem root qdisc of link, adding the qdisc if
+// there is none, and its ECN marking
+func changeNetem(link netlink.Link, spec *mpb.ImpairmentSpec) error {
+	log.Infof("Changing the impairment of %s to %+v", link.Attrs().Name, spec)
+	err := netlink.QdiscChange(netem(link.Attrs().Index, spec))
+	if err == nil {
+		return applyECN(link, spec)
+	}
+	if !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.EINVAL) {
+		return fmt.Errorf("failed to change the impairment of %s: %s", link.Attrs().Name, err)
+	}
+	if err := netlink.QdiscAdd(netem(link.Attrs().Index, spec)); err != nil {
+		return fmt.Errorf("failed to apply impairment to %s: %s", link.Attrs().Name, err)
+	}
+	return applyECN(link, spec)
+}
+
+// Read returns the impairments of a link inside the nsName network namespace, as set in its
+// netem qdiscs and their ECN-marking fq_codel qdiscs. A direction without any is nil.
+func Read(nsName, intfName string) (egress, ingress *mpb.ImpairmentSpec, err error) {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	err = netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		if egress, err = readNetem(link); err != nil {
+			return err
+		}
+		ifb, err := netlink.LinkByName(ifbName(intfName))
+		if err != nil {
+			// no ingress impairment
+			return nil
+		}
+		ingress, err = readNetem(ifb)
+		return err
+	})
+	return egress, ingress, err
+}
+
+// readNetem returns the parameters of the netem root qdisc of link, nil if it has none
+func readNetem(link netlink.Link) (*mpb.ImpairmentSpec, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the qdiscs of %s: %s", link.Attrs().Name, err)
+	}
+	var spec *mpb.ImpairmentSpec
+	for _, q := range qdiscs {
+		if n, ok := q.(*netlink.Netem); ok && q.Attrs().Parent == netlink.HANDLE_ROOT {
+			spec = specOf(n)
+		}
+	}
+	if spec == nil {
+		return nil, nil
+	}
+	for _, q := range qdiscs {
+		if c, ok := q.(*netlink.FqCodel); ok && q.Attrs().Parent == netlink.MakeHandle(1, 1) && c.ECN != 0 {
+			spec.EcnEnabled = true
+			spec.EcnThresholdPercent = thresholdOf(c)
+		}
+	}
+	return spec, nil
+}
+
+// readFqCodel returns the fq_codel qdisc under the netem root qdisc of link, nil if it has none
+func readFqCodel(link netlink.Link) (*netlink.FqCodel, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the qdiscs of %s: %s", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if c, ok := q.(*netlink.FqCodel); ok && q.Attrs().Parent == netlink.MakeHandle(1, 1) {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// thresholdOf returns the codel target of an fq_codel qdisc as a percentage of its interval,
+// rounded like the netem percentages
+func thresholdOf(c *netlink.FqCodel) float32 {
+	target := c.Target
+	if target == 0 {
+		target = codelTargetUs
+	}
+	if c.Interval == 0 {
+		return DefaultECNThresholdPercent
+	}
+	return float32(math.Round(float64(target)/float64(c.Interval)*100*1e4) / 1e4)
+}
+
+// specOf converts the parameters of a netem qdisc, as read from the kernel, back to the
+// units of an ImpairmentSpec
+func specOf(n *netlink.Netem) *mpb.ImpairmentSpec {
+	ms := func(ticks uint32) int64 {
+		return int64(math.Round(float64(ticks) / netlink.TickInUsec() / 1000))
+	}
+	return &mpb.ImpairmentSpec{
+		LatencyMs:        ms(n.Latency),
+		JitterMs:         ms(n.Jitter),
+		LossPercent:      percentOf(n.Loss),
+		DuplicatePercent: percentOf(n.Duplicate),
+		CorruptPercent:   percentOf(n.CorruptProb),
+
+		ReorderPercent:            percentOf(n.ReorderProb),
+		ReorderCorrelationPercent: percentOf(n.ReorderCorr),
+		ReorderGap:                n.Gap,
+	}
+}
+
+// percentOf converts a netem probability, scaled to 32 bits, to a percentage rounded to
+// the precision it has been set with
+func percentOf(p uint32) float32 {
+	return float32(math.Round(float64(p)/math.MaxUint32*100*1e4) / 1e4)
+}
@@ -0,0 +1,137 @@
+package impairment
+
+import (
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/protobuf/proto"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestSpecOf(t *testing.T) {
+	tests := []*mpb.ImpairmentSpec{
+		{},
+		{LatencyMs: 10, JitterMs: 2},
+		{LatencyMs: 1500, LossPercent: 0.5},
+		{DuplicatePercent: 1, CorruptPercent: 0.01},
+		{LossPercent: 100},
+		{LatencyMs: 10, ReorderPercent: 25, ReorderCorrelationPercent: 50, ReorderGap: 5},
+		{LatencyMs: 100, ReorderPercent: 0.5, ReorderGap: 1},
+	}
+	for i, spec := range tests {
+		// the kernel returns the parameters as they have been set
+		if got := specOf(netem(1, spec)); !proto.Equal(got, spec) {
+			t.Errorf("#%d test failed: specOf() = %v, want %v", i, got, spec)
+		}
+	}
+}
+
+func TestReorderGap(t *testing.T) {
+	tests := []struct {
+		spec *mpb.ImpairmentSpec
+		want uint32
+	}{
+		{spec: nil, want: 0},
+		{spec: &mpb.ImpairmentSpec{LatencyMs: 10}, want: 0},
+		// netem reorders every packet when the gap isn't set
+		{spec: &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25}, want: 1},
+		{spec: &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25, ReorderGap: 5}, want: 5},
+	}
+	for i, tt := range tests {
+		if got := ReorderGap(tt.spec); got != tt.want {
+			t.Errorf("#%d test failed: ReorderGap() = %d, want %d", i, got, tt.want)
+		}
+		// the gap of 0 is the one set in the netem qdisc
+		if tt.spec != nil {
+			if got := specOf(netem(1, tt.spec)).ReorderGap; got != tt.want {
+				t.Errorf("#%d test failed: the gap of the netem qdisc is %d, want %d", i, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestPatch(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(netNs.Path(), "eth1", &mpb.ImpairmentSpec{LatencyMs: 10}, &mpb.ImpairmentSpec{LatencyMs: 5}); err != nil {
+		t.Skipf("can't apply an impairment: %v", err)
+	}
+	ifbIndex := func() int {
+		var index int
+		netNs.Do(func(ns.NetNS) error {
+			if ifb, err := netlink.LinkByName(ifbName("eth1")); err == nil {
+				index = ifb.Attrs().Index
+			}
+			return nil
+		})
+		return index
+	}
+	ifb := ifbIndex()
+
+	tests := []struct {
+		desc                    string
+		egress, ingress         *mpb.ImpairmentSpec
+		wantEgress, wantIngress *mpb.ImpairmentSpec
+	}{
+		{
+			desc:        "change of the egress only",
+			egress:      &mpb.ImpairmentSpec{LatencyMs: 20, LossPercent: 1},
+			wantEgress:  &mpb.ImpairmentSpec{LatencyMs: 20, LossPercent: 1},
+			wantIngress: &mpb.ImpairmentSpec{LatencyMs: 5},
+		},
+		{
+			desc:        "change of both directions",
+			egress:      &mpb.ImpairmentSpec{LatencyMs: 30},
+			ingress:     &mpb.ImpairmentSpec{LatencyMs: 15, JitterMs: 2},
+			wantEgress:  &mpb.ImpairmentSpec{LatencyMs: 30},
+			wantIngress: &mpb.ImpairmentSpec{LatencyMs: 15, JitterMs: 2},
+		},
+		{
+			desc:        "removal of the egress",
+			egress:      &mpb.ImpairmentSpec{},
+			wantIngress: &mpb.ImpairmentSpec{LatencyMs: 15, JitterMs: 2},
+		},
+		{
+			desc:        "egress added back",
+			egress:      &mpb.ImpairmentSpec{LatencyMs: 40},
+			wantEgress:  &mpb.ImpairmentSpec{LatencyMs: 40},
+			wantIngress: &mpb.ImpairmentSpec{LatencyMs: 15, JitterMs: 2},
+		},
+	}
+	for _, tt := range tests {
+		if err := Patch(netNs.Path(), "eth1", tt.egress, tt.ingress); err != nil {
+			t.Fatalf("%s: Patch() failed: %v", tt.desc, err)
+		}
+		egress, ingress, err := Read(netNs.Path(), "eth1")
+		if err != nil {
+			t.Fatalf("%s: Read() failed: %v", tt.desc, err)
+		}
+		if !proto.Equal(egress, tt.wantEgress) || !proto.Equal(ingress, tt.wantIngress) {
+			t.Errorf("%s: the netem qdiscs have %v and %v, want %v and %v", tt.desc, egress, ingress, tt.wantEgress, tt.wantIngress)
+		}
+		// the ingress traffic is still redirected to the same IFB
+		if got := ifbIndex(); got != ifb {
+			t.Errorf("%s: the IFB of eth1 is %d, want %d", tt.desc, got, ifb)
+		}
+	}
+
+	if err := Patch(netNs.Path(), "eth1", &mpb.ImpairmentSpec{LatencyMs: -1}, nil); err == nil {
+		t.Errorf("Patch() with a negative latency succeeded")
+	}
+	if err := Patch(netNs.Path(), "eth3", &mpb.ImpairmentSpec{LatencyMs: 1}, nil); err == nil {
+		t.Errorf("Patch() of a missing link succeeded")
+	}
+}
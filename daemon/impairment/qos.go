@@ -0,0 +1,270 @@
+package impairment
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	// MaxQueuePriority is the lowest priority of an egress queue, which the DSCP classes
+	// missing from a QoS map are queued with
+	MaxQueuePriority = 7
+	// DefaultQoSRateMbps is the bandwidth shared by the egress queues of a QoS map without a rate
+	DefaultQoSRateMbps = 1000
+	// the rates of HTB classes are 32-bit byte rates
+	maxQoSRateMbps = 34000
+
+	// the HTB qdisc of a QoS map has handle qosMajor:, its root class qosMajor:1 and the
+	// queue of a priority qosMajor:qosQueueMinor+priority
+	qosMajor      = 0x20
+	qosRootMinor  = 1
+	qosQueueMinor = 0x10
+)
+
+// ApplyQoS queues the traffic sent out of the interface intfName of the nsName network
+// namespace by its DSCP class, with a priority queue for each priority of qosMap. The queues
+// are the classes of an HTB qdisc sharing the rate of alloc, under the egress netem qdisc of
+// the interface if it has one. Each queue is guaranteed its percentage of the rate and can
+// borrow what the others don't use, the higher priorities first, and the packets of the DSCP
+// classes of qosMap are classified by u32 filters on the DS field of their IPv4 or IPv6 header.
+// Other packets are queued with MaxQueuePriority. An empty qosMap removes the queues.
+func ApplyQoS(nsName, intfName string, qosMap []*mpb.DscpQueueEntry, alloc *mpb.QueueAllocation) error {
+	shares, err := queueShares(qosMap, alloc)
+	if err != nil {
+		return err
+	}
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find link %s: %s", intfName, err)
+		}
+		// the queues and their filters are set up from scratch
+		if err := removeQoS(link); err != nil {
+			return err
+		}
+		if len(qosMap) == 0 {
+			return nil
+		}
+		parent, err := qosParent(link)
+		if err != nil {
+			return err
+		}
+		log.Infof("Queuing the traffic sent out of %s in %d priority queues", intfName, len(shares))
+		if err := addQueues(link, parent, qosRate(alloc), shares); err != nil {
+			return fmt.Errorf("failed to add the priority queues of %s: %s", intfName, err)
+		}
+		for _, f := range qosFilters(link.Attrs().Index, qosMap) {
+			if err := netlink.FilterAdd(f); err != nil {
+				return fmt.Errorf("failed to classify the traffic of %s: %s", intfName, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ValidateQoS checks the DSCP classes and the priorities of a QoS map and its bandwidth
+func ValidateQoS(qosMap []*mpb.DscpQueueEntry, alloc *mpb.QueueAllocation) error {
+	_, err := queueShares(qosMap, alloc)
+	return err
+}
+
+// queueShares returns the percentage of the rate of alloc guaranteed to the queue of each
+// priority of qosMap and alloc, and of MaxQueuePriority. The priorities alloc doesn't set a
+// percentage for share what's left equally.
+func queueShares(qosMap []*mpb.DscpQueueEntry, alloc *mpb.QueueAllocation) (map[uint32]float64, error) {
+	if len(qosMap) == 0 {
+		if len(alloc.GetQueues()) > 0 {
+			return nil, fmt.Errorf("the bandwidth of the egress queues is set without a QoS map")
+		}
+		return nil, nil
+	}
+	if alloc.GetRateMbps() < 0 || alloc.GetRateMbps() > maxQoSRateMbps {
+		return nil, fmt.Errorf("the QoS rate must be between 0 and %d Mbps, got %d", maxQoSRateMbps, alloc.GetRateMbps())
+	}
+	shares := map[uint32]float64{}
+	seen := map[uint32]bool{}
+	for _, e := range qosMap {
+		if e.Dscp > MaxDSCP {
+			return nil, fmt.Errorf("DSCP must be between 0 and %d, got %d", MaxDSCP, e.Dscp)
+		}
+		if e.Priority > MaxQueuePriority {
+			return nil, fmt.Errorf("queue priority must be between 0 and %d, got %d", MaxQueuePriority, e.Priority)
+		}
+		if seen[e.Dscp] {
+			return nil, fmt.Errorf("DSCP %d is mapped to more than one queue", e.Dscp)
+		}
+		seen[e.Dscp] = true
+		shares[e.Priority] = 0
+	}
+	shares[MaxQueuePriority] = 0
+
+	set := map[uint32]bool{}
+	total := float64(0)
+	for _, q := range alloc.GetQueues() {
+		if q.Priority > MaxQueuePriority {
+			return nil, fmt.Errorf("queue priority must be between 0 and %d, got %d", MaxQueuePriority, q.Priority)
+		}
+		if q.Percent <= 0 || q.Percent > 100 {
+			return nil, fmt.Errorf("the bandwidth of queue %d must be between 0 and 100 percent, got %v", q.Priority, q.Percent)
+		}
+		if set[q.Priority] {
+			return nil, fmt.Errorf("the bandwidth of queue %d is set more than once", q.Priority)
+		}
+		set[q.Priority] = true
+		shares[q.Priority] = float64(q.Percent)
+		total += float64(q.Percent)
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("the bandwidth of the egress queues adds up to %v percent", total)
+	}
+	if rest := len(shares) - len(set); rest > 0 {
+		if total >= 100 {
+			return nil, fmt.Errorf("the bandwidth of the egress queues leaves nothing for %d of them", rest)
+		}
+		for p := range shares {
+			if !set[p] {
+				shares[p] = (100 - total) / float64(rest)
+			}
+		}
+	}
+	return shares, nil
+}
+
+func qosRate(alloc *mpb.QueueAllocation) uint64 {
+	if alloc.GetRateMbps() == 0 {
+		return DefaultQoSRateMbps * 1000000
+	}
+	return uint64(alloc.GetRateMbps()) * 1000000
+}
+
+// qosParent returns the parent of the HTB qdisc of link: the root, or the netem root qdisc
+// applying egress impairments, which can't have the ECN qdisc under it as well
+func qosParent(link netlink.Link) (uint32, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the qdiscs of %s: %s", link.Attrs().Name, err)
+	}
+	parent := uint32(netlink.HANDLE_ROOT)
+	for _, q := range qdiscs {
+		switch q.(type) {
+		case *netlink.Netem:
+			if q.Attrs().Parent == netlink.HANDLE_ROOT {
+				parent = netlink.MakeHandle(1, 1)
+			}
+		case *netlink.FqCodel:
+			if q.Attrs().Parent == netlink.MakeHandle(1, 1) {
+				return 0, fmt.Errorf("%s can't have both ECN and priority queues on egress", link.Attrs().Name)
+			}
+		}
+	}
+	return parent, nil
+}
+
+// addQueues adds the HTB qdisc and classes of the queues of a QoS map under parent
+func addQueues(link netlink.Link, parent uint32, rate uint64, shares map[uint32]float64) error {
+	index := link.Attrs().Index
+	htb := netlink.NewHtb(netlink.QdiscAttrs{
+		LinkIndex: index,
+		Handle:    netlink.MakeHandle(qosMajor, 0),
+		Parent:    parent,
+	})
+	htb.Defcls = qosQueueMinor + MaxQueuePriority
+	if err := netlink.QdiscAdd(htb); err != nil {
+		return err
+	}
+	root := netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: index,
+		Handle:    netlink.MakeHandle(qosMajor, qosRootMinor),
+		Parent:    netlink.MakeHandle(qosMajor, 0),
+	}, netlink.HtbClassAttrs{Rate: rate, Ceil: rate})
+	if err := netlink.ClassAdd(root); err != nil {
+		return err
+	}
+	for _, c := range queueClasses(index, rate, shares) {
+		if err := netlink.ClassAdd(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueClasses returns the HTB classes of the queues of a QoS map, by priority. Each of them
+// may use the whole rate when the others are idle.
+func queueClasses(index int, rate uint64, shares map[uint32]float64) []*netlink.HtbClass {
+	var priorities []uint32
+	for p := range shares {
+		priorities = append(priorities, p)
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+	var classes []*netlink.HtbClass
+	for _, p := range priorities {
+		classes = append(classes, netlink.NewHtbClass(netlink.ClassAttrs{
+			LinkIndex: index,
+			Handle:    queueHandle(p),
+			Parent:    netlink.MakeHandle(qosMajor, qosRootMinor),
+		}, netlink.HtbClassAttrs{
+			Rate: uint64(float64(rate) * shares[p] / 100),
+			Ceil: rate,
+			Prio: p,
+		}))
+	}
+	return classes
+}
+
+func queueHandle(priority uint32) uint32 {
+	return netlink.MakeHandle(qosMajor, uint16(qosQueueMinor+priority))
+}
+
+// qosFilters returns the u32 filters classifying the IPv4 and IPv6 packets of the DSCP classes
+// of qosMap into their queue
+func qosFilters(index int, qosMap []*mpb.DscpQueueEntry) []*netlink.U32 {
+	var filters []*netlink.U32
+	for i, proto := range []uint16{unix.ETH_P_IP, unix.ETH_P_IPV6} {
+		for _, e := range qosMap {
+			key := dsfieldKey(e.Dscp, proto == unix.ETH_P_IPV6)
+			filters = append(filters, &netlink.U32{
+				FilterAttrs: netlink.FilterAttrs{
+					LinkIndex: index,
+					Parent:    netlink.MakeHandle(qosMajor, 0),
+					Priority:  uint16(i + 1),
+					Protocol:  proto,
+				},
+				ClassId: queueHandle(e.Priority),
+				Sel: &netlink.TcU32Sel{
+					Flags: netlink.TC_U32_TERMINAL,
+					Keys:  []netlink.TcU32Key{{Mask: ^key.mask, Val: key.val}},
+				},
+			})
+		}
+	}
+	return filters
+}
+
+// removeQoS removes the HTB qdisc of the QoS map of link, along with its classes and filters
+func removeQoS(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("failed to list the qdiscs of %s: %s", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if _, ok := q.(*netlink.Htb); ok && q.Attrs().Handle == netlink.MakeHandle(qosMajor, 0) {
+			if err := netlink.QdiscDel(q); err != nil {
+				return fmt.Errorf("failed to remove the priority queues of %s: %s", link.Attrs().Name, err)
+			}
+		}
+	}
+	return nil
+}
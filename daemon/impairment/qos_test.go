@@ -0,0 +1,196 @@
+package impairment
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestQueueShares(t *testing.T) {
+	ef := &mpb.DscpQueueEntry{Dscp: 46, Priority: 0}
+	af := &mpb.DscpQueueEntry{Dscp: 26, Priority: 3}
+	tests := []struct {
+		desc    string
+		qosMap  []*mpb.DscpQueueEntry
+		alloc   *mpb.QueueAllocation
+		want    map[uint32]float64
+		wantErr bool
+	}{
+		{desc: "no map"},
+		{desc: "equal shares", qosMap: []*mpb.DscpQueueEntry{ef, af},
+			want: map[uint32]float64{0: 100.0 / 3, 3: 100.0 / 3, 7: 100.0 / 3}},
+		{desc: "the rest shared", qosMap: []*mpb.DscpQueueEntry{ef, af},
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 50}}},
+			want:  map[uint32]float64{0: 50, 3: 25, 7: 25}},
+		{desc: "a queue without a class", qosMap: []*mpb.DscpQueueEntry{ef},
+			alloc: &mpb.QueueAllocation{RateMbps: 100, Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 20}, {Priority: 1, Percent: 20}}},
+			want:  map[uint32]float64{0: 20, 1: 20, 7: 60}},
+		{desc: "all set", qosMap: []*mpb.DscpQueueEntry{ef},
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 30}, {Priority: 7, Percent: 70}}},
+			want:  map[uint32]float64{0: 30, 7: 70}},
+		{desc: "DSCP too large", qosMap: []*mpb.DscpQueueEntry{{Dscp: 64}}, wantErr: true},
+		{desc: "priority too low", qosMap: []*mpb.DscpQueueEntry{{Dscp: 46, Priority: 8}}, wantErr: true},
+		{desc: "DSCP mapped twice", qosMap: []*mpb.DscpQueueEntry{ef, {Dscp: 46, Priority: 1}}, wantErr: true},
+		{desc: "bandwidth without a map",
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 50}}}, wantErr: true},
+		{desc: "over 100 percent", qosMap: []*mpb.DscpQueueEntry{ef},
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 60}, {Priority: 7, Percent: 60}}}, wantErr: true},
+		{desc: "nothing left", qosMap: []*mpb.DscpQueueEntry{ef, af},
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 100}}}, wantErr: true},
+		{desc: "no percentage", qosMap: []*mpb.DscpQueueEntry{ef},
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0}}}, wantErr: true},
+		{desc: "bandwidth set twice", qosMap: []*mpb.DscpQueueEntry{ef},
+			alloc: &mpb.QueueAllocation{Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 10}, {Priority: 0, Percent: 10}}}, wantErr: true},
+		{desc: "negative rate", qosMap: []*mpb.DscpQueueEntry{ef}, alloc: &mpb.QueueAllocation{RateMbps: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := queueShares(tt.qosMap, tt.alloc)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: queueShares() error = %v, wantErr %t", tt.desc, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: queueShares() = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestQosFilters(t *testing.T) {
+	filters := qosFilters(1, []*mpb.DscpQueueEntry{{Dscp: 46, Priority: 0}, {Dscp: 8, Priority: 5}})
+	tests := []struct {
+		proto uint16
+		key   netlink.TcU32Key
+		class uint32
+	}{
+		{proto: unix.ETH_P_IP, key: netlink.TcU32Key{Mask: 0x00fc0000, Val: 0x00b80000}, class: netlink.MakeHandle(0x20, 0x10)},
+		{proto: unix.ETH_P_IP, key: netlink.TcU32Key{Mask: 0x00fc0000, Val: 0x00200000}, class: netlink.MakeHandle(0x20, 0x15)},
+		{proto: unix.ETH_P_IPV6, key: netlink.TcU32Key{Mask: 0x0fc00000, Val: 0x0b800000}, class: netlink.MakeHandle(0x20, 0x10)},
+		{proto: unix.ETH_P_IPV6, key: netlink.TcU32Key{Mask: 0x0fc00000, Val: 0x02000000}, class: netlink.MakeHandle(0x20, 0x15)},
+	}
+	if len(filters) != len(tests) {
+		t.Fatalf("qosFilters() returned %d filters, want %d", len(filters), len(tests))
+	}
+	for i, tt := range tests {
+		f := filters[i]
+		if f.Protocol != tt.proto || f.ClassId != tt.class || !reflect.DeepEqual(f.Sel.Keys, []netlink.TcU32Key{tt.key}) {
+			t.Errorf("filter %d = %#x %s %+v, want %#x %s %+v", i, f.Protocol, netlink.HandleStr(f.ClassId), f.Sel.Keys,
+				tt.proto, netlink.HandleStr(tt.class), tt.key)
+		}
+	}
+}
+
+// qosFrame returns an Ethernet frame with an IPv4 header of DSCP class dscp whose payload
+// starts with the time it's sent at
+func qosFrame(dscp uint32, size int) []byte {
+	frame := ipv4Frame(byte(dscp << 2))
+	frame = append(frame, make([]byte, size-len(frame))...)
+	binary.BigEndian.PutUint16(frame[14+2:], uint16(size-14))
+	binary.BigEndian.PutUint64(frame[14+28:], uint64(time.Now().UnixNano()))
+	return frame
+}
+
+func TestQoSDelay(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"}); err != nil {
+			return err
+		}
+		for _, name := range []string{"eth1", "eth2"} {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				return err
+			}
+			if err := netlink.LinkSetUp(link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const high, low = 46, 8
+	qosMap := []*mpb.DscpQueueEntry{{Dscp: high, Priority: 0}, {Dscp: low, Priority: 6}}
+	alloc := &mpb.QueueAllocation{RateMbps: 10, Queues: []*mpb.QueueBandwidth{{Priority: 0, Percent: 10}}}
+	if err := ApplyQoS(netNs.Path(), "eth1", qosMap, alloc); err != nil {
+		t.Skipf("can't add priority queues: %v", err)
+	}
+
+	// a backlog of low priority frames builds up at 10Mbps, high priority frames sent behind
+	// it must overtake it
+	delays := map[uint32][]time.Duration{}
+	err = netNs.Do(func(ns.NetNS) error {
+		tx, index, err := packetSocket("eth1")
+		if err != nil {
+			return err
+		}
+		defer unix.Close(tx)
+		rx, _, err := packetSocket("eth2")
+		if err != nil {
+			return err
+		}
+		defer unix.Close(rx)
+		const backlog, probes = 300, 20
+		to := &unix.SockaddrLinklayer{Ifindex: index, Halen: 6}
+		for i := 0; i < backlog; i++ {
+			if err := unix.Sendto(tx, qosFrame(low, 1000), 0, to); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < probes; i++ {
+			if err := unix.Sendto(tx, qosFrame(high, 100), 0, to); err != nil {
+				return err
+			}
+		}
+		buf := make([]byte, 1500)
+		for received := 0; received < backlog+probes; received++ {
+			n, _, err := unix.Recvfrom(rx, buf, 0)
+			if err != nil {
+				break
+			}
+			if n < 14+28+8 {
+				continue
+			}
+			sent := time.Unix(0, int64(binary.BigEndian.Uint64(buf[14+28:])))
+			dscp := uint32(buf[14+1] >> 2)
+			delays[dscp] = append(delays[dscp], time.Since(sent))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delays[high]) == 0 || len(delays[low]) == 0 {
+		t.Fatalf("received %d high and %d low priority frames", len(delays[high]), len(delays[low]))
+	}
+	mean := func(ds []time.Duration) time.Duration {
+		var sum time.Duration
+		for _, d := range ds {
+			sum += d
+		}
+		return sum / time.Duration(len(ds))
+	}
+	h, l := mean(delays[high]), mean(delays[low])
+	if h >= l {
+		t.Errorf("the mean delay of DSCP %d is %s, not lower than the %s of DSCP %d", high, h, l, low)
+	}
+	t.Logf("mean delay of %s for DSCP %d and %s for DSCP %d", h, high, l, low)
+
+	if err := ApplyQoS(netNs.Path(), "eth1", nil, nil); err != nil {
+		t.Fatalf("ApplyQoS() removing the queues failed: %v", err)
+	}
+}
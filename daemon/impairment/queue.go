@@ -0,0 +1,87 @@
+package impairment
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// QueueStats are the counters of the queue of a netem qdisc
+type QueueStats struct {
+	// packets held by the qdisc
+	Depth uint32
+	// packets dropped because the queue was full
+	Drops uint32
+}
+
+// RxQueue returns the queue of packets received on a link that haven't been delivered yet
+// because of its ingress impairment, which is empty if the link has none.
+func RxQueue(nsName, intfName string) (QueueStats, error) {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return QueueStats{}, fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	var result QueueStats
+	err = netNs.Do(func(_ ns.NetNS) error {
+		ifb, err := netlink.LinkByName(ifbName(intfName))
+		if err != nil {
+			return nil
+		}
+		result, err = rootQueue(ifb.Attrs().Index)
+		return err
+	})
+	return result, err
+}
+
+// rootQueue reads the queue counters of the root qdisc of the link with index, which the
+// version of the netlink library in use doesn't expose
+func rootQueue(index int) (QueueStats, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_GETQDISC, unix.NLM_F_DUMP)
+	req.AddData(&nl.TcMsg{Family: nl.FAMILY_ALL, Ifindex: int32(index)})
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWQDISC)
+	if err != nil {
+		return QueueStats{}, fmt.Errorf("failed to list qdiscs: %s", err)
+	}
+	for _, m := range msgs {
+		msg := nl.DeserializeTcMsg(m)
+		if int(msg.Ifindex) != index || msg.Parent != netlink.HANDLE_ROOT {
+			continue
+		}
+		attrs, err := nl.ParseRouteAttr(m[msg.Len():])
+		if err != nil {
+			return QueueStats{}, err
+		}
+		return parseQueueStats(attrs)
+	}
+	return QueueStats{}, nil
+}
+
+// parseQueueStats reads the gnet_stats_queue of the TCA_STATS2 attribute of a qdisc
+func parseQueueStats(attrs []syscall.NetlinkRouteAttr) (QueueStats, error) {
+	for _, attr := range attrs {
+		if attr.Attr.Type != nl.TCA_STATS2 {
+			continue
+		}
+		stats, err := nl.ParseRouteAttr(attr.Value)
+		if err != nil {
+			return QueueStats{}, err
+		}
+		for _, s := range stats {
+			// qlen, backlog, drops, requeues and overlimits
+			if s.Attr.Type != nl.TCA_STATS_QUEUE || len(s.Value) < 12 {
+				continue
+			}
+			return QueueStats{
+				Depth: nl.NativeEndian().Uint32(s.Value[0:4]),
+				Drops: nl.NativeEndian().Uint32(s.Value[8:12]),
+			}, nil
+		}
+	}
+	return QueueStats{}, nil
+}
@@ -0,0 +1,69 @@
+package impairment
+
+import (
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestParseQueueStats(t *testing.T) {
+	queue := make([]byte, 20)
+	for i, v := range []uint32{7, 1400, 3, 0, 5} {
+		nl.NativeEndian().PutUint32(queue[i*4:], v)
+	}
+	stats2 := nl.NewRtAttr(nl.TCA_STATS2, nil)
+	stats2.AddRtAttr(nl.TCA_STATS_BASIC, make([]byte, 16))
+	stats2.AddRtAttr(nl.TCA_STATS_QUEUE, queue)
+	kind := nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated("netem"))
+
+	tests := []struct {
+		attrs []*nl.RtAttr
+		want  QueueStats
+	}{
+		{attrs: []*nl.RtAttr{kind, stats2}, want: QueueStats{Depth: 7, Drops: 3}},
+		{attrs: []*nl.RtAttr{kind}, want: QueueStats{}},
+	}
+	for i, tt := range tests {
+		var b []byte
+		for _, a := range tt.attrs {
+			b = append(b, a.Serialize()...)
+		}
+		attrs, err := nl.ParseRouteAttr(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := parseQueueStats(attrs); err != nil || got != tt.want {
+			t.Errorf("#%d test failed: parseQueueStats() = %+v, %v, want %+v", i, got, err, tt.want)
+		}
+	}
+}
+
+func TestRxQueue(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := RxQueue(netNs.Path(), "eth1"); err != nil || got != (QueueStats{}) {
+		t.Errorf("RxQueue() without an ingress impairment = %+v, %v", got, err)
+	}
+	if err := Apply(netNs.Path(), "eth1", nil, &mpb.ImpairmentSpec{LatencyMs: 100}); err != nil {
+		t.Skipf("can't apply an ingress impairment: %v", err)
+	}
+	if got, err := RxQueue(netNs.Path(), "eth1"); err != nil || got != (QueueStats{}) {
+		t.Errorf("RxQueue() of an idle link = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,113 @@
+// Package logging provides loggers for the hot paths of the daemon, where logging every
+// occurrence of a message would flood the log.
+package logging
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// number of keys above which the idle ones are forgotten
+	maxKeys = 1024
+	// how long a key has to be idle to be forgotten
+	idleTimeout = time.Minute
+)
+
+// RateLimitedLogger logs at most a number of messages per second for each key, e.g. the UID
+// of a wire and the kind of message. The first message logged after some have been dropped
+// tells how many.
+type RateLimitedLogger struct {
+	entry *log.Entry
+	limit rate.Limit
+
+	mu   sync.Mutex
+	keys map[string]*keyState
+}
+
+type keyState struct {
+	limiter    *rate.Limiter
+	suppressed int64
+	last       time.Time
+}
+
+// NewRateLimitedLogger returns a logger writing to entry at most perSecond messages per second
+// for each key, or every message if perSecond isn't positive
+func NewRateLimitedLogger(entry *log.Entry, perSecond float64) *RateLimitedLogger {
+	return &RateLimitedLogger{
+		entry: entry,
+		limit: rate.Limit(perSecond),
+		keys:  make(map[string]*keyState),
+	}
+}
+
+// allow returns whether a message of key can be logged and how many were dropped before it
+func (l *RateLimitedLogger) allow(key string, now time.Time) (bool, int64) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.keys[key]
+	if !ok {
+		if len(l.keys) >= maxKeys {
+			l.forgetIdle(now)
+		}
+		s = &keyState{limiter: rate.NewLimiter(l.limit, 1)}
+		l.keys[key] = s
+	}
+	s.last = now
+	if !s.limiter.AllowN(now, 1) {
+		s.suppressed++
+		return false, 0
+	}
+	suppressed := s.suppressed
+	s.suppressed = 0
+	return true, suppressed
+}
+
+// forgetIdle drops the keys that haven't been used for a while and have no dropped messages
+// to report. l.mu must be held.
+func (l *RateLimitedLogger) forgetIdle(now time.Time) {
+	for key, s := range l.keys {
+		if s.suppressed == 0 && now.Sub(s.last) > idleTimeout {
+			delete(l.keys, key)
+		}
+	}
+}
+
+// Logf logs a message of key at level, unless too many of them have been logged recently
+func (l *RateLimitedLogger) Logf(level log.Level, key, format string, args ...interface{}) {
+	if !l.entry.Logger.IsLevelEnabled(level) {
+		return
+	}
+	ok, suppressed := l.allow(key, time.Now())
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if suppressed > 0 {
+		msg = fmt.Sprintf("%s (suppressed %d similar messages)", msg, suppressed)
+	}
+	l.entry.Log(level, msg)
+}
+
+func (l *RateLimitedLogger) Debugf(key, format string, args ...interface{}) {
+	l.Logf(log.DebugLevel, key, format, args...)
+}
+
+func (l *RateLimitedLogger) Infof(key, format string, args ...interface{}) {
+	l.Logf(log.InfoLevel, key, format, args...)
+}
+
+func (l *RateLimitedLogger) Warnf(key, format string, args ...interface{}) {
+	l.Logf(log.WarnLevel, key, format, args...)
+}
+
+func (l *RateLimitedLogger) Errorf(key, format string, args ...interface{}) {
+	l.Logf(log.ErrorLevel, key, format, args...)
+}
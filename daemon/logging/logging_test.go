@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestRateLimitedLogger(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	l := NewRateLimitedLogger(log.NewEntry(logger), 1)
+
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for i := 0; i < 1000; i++ {
+		l.Warnf("wire 1", "failed to send frame %d", i)
+	}
+	if time.Now().After(deadline) {
+		t.Skip("1000 calls took more than 100ms")
+	}
+	if n := len(hook.AllEntries()); n != 1 {
+		t.Fatalf("1000 calls logged %d lines, want 1", n)
+	}
+
+	// other keys have their own budget
+	l.Warnf("wire 2", "failed to send frame")
+	if n := len(hook.AllEntries()); n != 2 {
+		t.Errorf("a message of another key logged %d lines in total, want 2", n)
+	}
+}
+
+func TestSuppressedCount(t *testing.T) {
+	l := NewRateLimitedLogger(log.NewEntry(log.New()), 1)
+	now := time.Now()
+	tests := []struct {
+		at         time.Duration
+		ok         bool
+		suppressed int64
+	}{
+		{at: 0, ok: true},
+		{at: 100 * time.Millisecond},
+		{at: 200 * time.Millisecond},
+		{at: 1100 * time.Millisecond, ok: true, suppressed: 2},
+		{at: 3000 * time.Millisecond, ok: true},
+	}
+	for i, tt := range tests {
+		ok, suppressed := l.allow("wire 1", now.Add(tt.at))
+		if ok != tt.ok || suppressed != tt.suppressed {
+			t.Errorf("#%d test failed: allow() = %v, %d, want %v, %d", i, ok, suppressed, tt.ok, tt.suppressed)
+		}
+	}
+
+	unlimited := NewRateLimitedLogger(log.NewEntry(log.New()), 0)
+	for i := 0; i < 10; i++ {
+		if ok, _ := unlimited.allow("wire 1", now); !ok {
+			t.Fatalf("unlimited logger dropped message %d", i)
+		}
+	}
+}
+
+func TestForgetIdle(t *testing.T) {
+	l := NewRateLimitedLogger(log.NewEntry(log.New()), 1)
+	now := time.Now()
+	l.allow("idle", now)
+	l.allow("suppressed", now)
+	l.allow("suppressed", now)
+	l.allow("recent", now.Add(idleTimeout))
+	l.forgetIdle(now.Add(idleTimeout + time.Second))
+	if _, ok := l.keys["idle"]; ok {
+		t.Errorf("idle key wasn't forgotten")
+	}
+	for _, key := range []string{"suppressed", "recent"} {
+		if _, ok := l.keys[key]; !ok {
+			t.Errorf("key %s was forgotten", key)
+		}
+	}
+}
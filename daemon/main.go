@@ -1,19 +1,49 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/networkop/meshnet-cni/daemon/bench"
+	"github.com/networkop/meshnet-cni/daemon/cilium"
 	"github.com/networkop/meshnet-cni/daemon/cni"
 	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/veth"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	defaultPort = 51111
+	defaultPort             = 51111
+	defaultKeepaliveTime    = 30 * time.Second
+	defaultKeepaliveTimeout = 10 * time.Second
+	defaultWireMaxRetryTime = 5 * time.Minute
+	defaultHealthAddr       = ":51112"
+	defaultRPCBurst         = 100
+	defaultHistoryLimit     = 10
+	defaultCanaryTimeout    = 30 * time.Minute
+	defaultAccountingPeriod = time.Minute
+	defaultGTPTEIDBase      = 10000
+	defaultOVSBridge        = "br-meshnet"
+	defaultHeartbeatTime    = 10 * time.Second
+	defaultHeartbeatTimeout = 5 * time.Second
+	defaultUnixSocket       = "/var/run/meshnet/daemon.sock"
+	defaultLogRateLimit     = 1
+	defaultShutdownTimeout  = 30 * time.Second
+	defaultWatchdogTimeout  = 60 * time.Second
+	defaultEvacuateTimeout  = 5 * time.Minute
+	defaultEventLogLimit    = 500
+	defaultRefDepth         = 5
+	defaultNodeStatusPeriod = 30 * time.Second
 )
 
+// version is set at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
 func main() {
 
 	if err := cni.Init(); err != nil {
@@ -23,6 +53,52 @@ func main() {
 	defer cni.Cleanup()
 
 	isDebug := flag.Bool("d", false, "enable degugging")
+	disableReflection := flag.Bool("disable-reflection", false, "disable gRPC server reflection")
+	keepaliveTime := flag.Duration("grpc-keepalive-time", defaultKeepaliveTime, "interval of gRPC keepalive pings, 0 to disable")
+	keepaliveTimeout := flag.Duration("grpc-keepalive-timeout", defaultKeepaliveTimeout, "time to wait for a gRPC keepalive ack")
+	keepaliveMinTime := flag.Duration("grpc-keepalive-min-time", meshnet.DefaultKeepaliveMinTime, "shortest interval of client keepalive pings accepted, at most the CNI plugin's 30s")
+	wireMaxRetryTime := flag.Duration("wire-max-retry-time", defaultWireMaxRetryTime, "how long to retry failed remote link updates for")
+	autoWireLabel := flag.String("auto-wire-label", "", "fully mesh pods sharing the value of this label, e.g. meshnet.io/group")
+	rpcRateLimit := flag.Float64("rpc-rate-limit", 0, "maximum rate of RPCs per second, 0 to disable")
+	rpcBurst := flag.Int("rpc-burst", defaultRPCBurst, "maximum burst of RPCs above the rate limit")
+	rpcRateLimitConfig := flag.String("rpc-rate-limit-config", "", "YAML file with per-method RPC rate limits")
+	packetBufferSize := flag.Int("packet-buffer-size", bench.DefaultBufferSize, "receive buffer size in bytes of the packet sockets used by link benchmarks")
+	eventLogLimit := flag.Int("event-log-limit", defaultEventLogLimit, "number of wire events kept in the event log of each topology, 0 to disable")
+	refDepth := flag.Int("topology-ref-depth", defaultRefDepth, "number of levels of references to other topologies that are resolved")
+	historyLimit := flag.Int("history-limit", defaultHistoryLimit, "number of topology revisions kept for rollbacks")
+	canaryTimeout := flag.Duration("canary-timeout", defaultCanaryTimeout, "how long a canary deployment can stay uncommitted before it's rolled back, 0 to disable")
+	resourceAutoscale := flag.Bool("resource-autoscale", false, "annotate the daemon's pod with CPU and memory recommendations based on the active wires")
+	accountingInterval := flag.Duration("traffic-accounting-interval", defaultAccountingPeriod, "how often the traffic of the wires is recorded in the meshnet-traffic-<namespace> ConfigMaps, 0 to disable")
+	selfTaintThreshold := flag.Float64("self-taint-threshold", 0, "fraction of wire set ups failed in the last 5 minutes above which the node is tainted with meshnet.io/not-ready:NoSchedule, e.g. 0.05, 0 to disable")
+	gtpTEIDBase := flag.Uint("gtp-teid-base", defaultGTPTEIDBase, "first TEID of GTP-U links, which get the TEID of their UID above it")
+	dataPlane := flag.String("data-plane", "veth", "backend of same-node links, veth or ovs")
+	netnsMode := flag.String("netns-mode", veth.ModeAuto, "how veth pairs are created: root creates them in the host netns, caller directly in the pods' netns, auto uses caller in a user namespace")
+	cniBackend := flag.String("cni-backend", "default", "CNI plugin meshnet is chained after, default or cilium to register the link interfaces as Cilium endpoints")
+	ciliumSocket := flag.String("cilium-socket", cilium.DefaultSocket, "unix socket of the Cilium agent API with -cni-backend=cilium")
+	ovsBridge := flag.String("ovs-bridge", defaultOVSBridge, "Open vSwitch bridge pods are connected to with -data-plane=ovs")
+	heartbeatInterval := flag.Duration("heartbeat-interval", defaultHeartbeatTime, "how often the daemons of the nodes of wire peers are sent a heartbeat, 0 to disable")
+	heartbeatTimeout := flag.Duration("heartbeat-timeout", defaultHeartbeatTimeout, "time to wait for a heartbeat answer before the wires to a node are considered down")
+	wireOrderTimeout := flag.Duration("wire-order-timeout", 0, "how long a remote link update waits for the links of the pod with a lower UID to be up, 0 to disable")
+	listenAddr := flag.String("listen-addr", "", "TCP address of the gRPC server, e.g. 0.0.0.0:51111, defaults to all addresses on $GRPC_PORT")
+	listenUnix := flag.String("listen-unix", defaultUnixSocket, "unix socket the gRPC server listens on as well, used by the CNI plugin, empty to disable")
+	wireGuard := flag.Bool("wireguard", false, "allow encrypted WireGuard links, publishing the node's public key in the meshnet-wg-<node IP> Secret")
+	wgKeyRotationHours := flag.Int("wg-key-rotation-hours", 0, "how often in hours the node's WireGuard key pair is re-generated, 0 to disable")
+	chaosProfiles := flag.Bool("chaos-profiles-enabled", false, "serve the chaos profile RPCs and apply the ChaosProfile objects to the wires of this node")
+	autoCreateNADs := flag.Bool("auto-create-nads", false, "create the Multus NetworkAttachmentDefinitions of the interfaces of the topologies when they are created or updated")
+	logRateLimit := flag.Float64("log-rate-limit", defaultLogRateLimit, "messages per second logged for each wire and kind of message by the wire set up paths, 0 for no limit")
+	maxActiveWires := flag.Int("max-active-wires", 0, "maximum number of wires of the pods of this node, pods whose wires would exceed it fail to start, 0 for no limit")
+	autoSetPathMTU := flag.Bool("auto-set-path-mtu", false, "set the MTUs found by path MTU discovery on the links and record them in their topologies")
+	verifyWiresOnCreate := flag.Bool("verify-wires-on-create", false, "check that both ends of a wire agree on its parameters after setting it up, and fail the wire if they don't")
+	podAliveTimeout := flag.Duration("pod-alive-timeout", 0, "how long the CNI plugin waits for the peers of a pod to be alive before setting up its wires, 0 not to wait")
+	watchdogTimeout := flag.Duration("wire-watchdog-timeout", defaultWatchdogTimeout, "how long the interface of a wire of this node may be missing before the wire is re-created, 0 to disable")
+	evacuationTimeout := flag.Duration("evacuation-timeout", defaultEvacuateTimeout, "how long EvacuateNode waits for the pods of this node to run on other nodes with their wires up, 0 for no limit")
+	shutdownTimeout := flag.Duration("shutdown-timeout", defaultShutdownTimeout, "time given to the RPCs in progress and the background tasks to finish when the daemon is stopped")
+	autoInjectRoutes := flag.Bool("auto-inject-routes", false, "inject the routes to the link subnets of their topologies into the pods of this node once all their wires are up")
+	driftDetectionInterval := flag.Duration("drift-detection-interval", 0, "how often the topologies are compared with the wires of this node and their drift recorded as events, 0 to disable")
+	convergenceSLA := flag.Int64("convergence-sla-ms", 0, "convergence of a namespace, from its first pod alive to its last wire up, in milliseconds beyond which an event is recorded, 0 to disable")
+	topologySource := flag.String("topology-source", "", "file:///path/to/topologies to read the topologies from <namespace>/<name>.yaml files instead of the K8s API and run without K8s, empty to use the K8s API")
+	nodeStatusInterval := flag.Duration("node-status-interval", defaultNodeStatusPeriod, "how often the NodeTopologyStatus of this node is written, 0 to disable")
+	healthAddr := flag.String("health-addr", defaultHealthAddr, "address of the HTTP /healthz endpoint, empty to disable")
 	grpcPort, err := strconv.Atoi(os.Getenv("GRPC_PORT"))
 	if err != nil || grpcPort == 0 {
 		grpcPort = defaultPort
@@ -33,18 +109,122 @@ func main() {
 		log.SetLevel(log.DebugLevel)
 		log.Debug("Verbose logging enabled")
 	}
+	if err := meshnet.ValidateCNIBackend(*cniBackend); err != nil {
+		log.Errorf("Invalid -cni-backend: %v", err)
+		os.Exit(1)
+	}
+	if err := meshnet.ValidateDataPlane(*dataPlane); err != nil {
+		log.Errorf("Invalid -data-plane: %v", err)
+		os.Exit(1)
+	}
+	if err := veth.ValidateMode(*netnsMode); err != nil {
+		log.Errorf("Invalid -netns-mode: %v", err)
+		os.Exit(1)
+	}
 
 	m, err := meshnet.New(meshnet.Config{
-		Port: grpcPort,
+		Port:              grpcPort,
+		DisableReflection: *disableReflection,
+		KeepaliveTime:     *keepaliveTime,
+		KeepaliveTimeout:  *keepaliveTimeout,
+		KeepaliveMinTime:  *keepaliveMinTime,
+		WireMaxRetryTime:  *wireMaxRetryTime,
+		AutoWireLabel:     *autoWireLabel,
+		RPCRateLimit: meshnet.RateLimit{
+			Rate:  *rpcRateLimit,
+			Burst: *rpcBurst,
+		},
+		RPCRateLimitConfig:        *rpcRateLimitConfig,
+		HistoryLimit:              *historyLimit,
+		PacketBufferSize:          *packetBufferSize,
+		CanaryTimeout:             *canaryTimeout,
+		ResourceAutoscale:         *resourceAutoscale,
+		TrafficAccountingInterval: *accountingInterval,
+		SelfTaintThreshold:        *selfTaintThreshold,
+		GTPTEIDBase:               uint32(*gtpTEIDBase),
+		DataPlane:                 *dataPlane,
+		NetnsMode:                 *netnsMode,
+		OVSBridge:                 *ovsBridge,
+		HeartbeatInterval:         *heartbeatInterval,
+		HeartbeatTimeout:          *heartbeatTimeout,
+		WireOrderTimeout:          *wireOrderTimeout,
+		ListenAddr:                *listenAddr,
+		ListenUnix:                *listenUnix,
+		WireGuard:                 *wireGuard,
+		WGKeyRotation:             time.Duration(*wgKeyRotationHours) * time.Hour,
+		ChaosProfiles:             *chaosProfiles,
+		AutoCreateNADs:            *autoCreateNADs,
+		LogRateLimit:              *logRateLimit,
+		WireWatchdogTimeout:       *watchdogTimeout,
+		EvacuationTimeout:         *evacuationTimeout,
+		CNIBackend:                *cniBackend,
+		CiliumSocket:              *ciliumSocket,
+		EventLogLimit:             *eventLogLimit,
+		TopologyRefDepth:          *refDepth,
+		MaxActiveWires:            *maxActiveWires,
+		AutoSetPathMTU:            *autoSetPathMTU,
+		VerifyWiresOnCreate:       *verifyWiresOnCreate,
+		PodAliveTimeout:           *podAliveTimeout,
+		NodeStatusInterval:        *nodeStatusInterval,
+		AutoInjectRoutes:          *autoInjectRoutes,
+		DriftDetectionInterval:    *driftDetectionInterval,
+		ConvergenceSLA:            time.Duration(*convergenceSLA) * time.Millisecond,
+		TopologySource:            *topologySource,
+		Version:                   version,
 	})
 	if err != nil {
 		log.Errorf("Failed to create meshnet: %v", err)
 		os.Exit(1)
 	}
+	if err := m.CountWires(context.Background()); err != nil {
+		log.Warnf("Failed to count the wires of this node: %v", err)
+	}
+	if err := m.RecoverTransactions(context.Background()); err != nil {
+		log.Warnf("Failed to roll back the interrupted wire transactions of this node: %v", err)
+	}
 	log.Info("Starting meshnet daemon...")
 
-	if err := m.Serve(); err != nil {
+	stopCh := make(chan struct{})
+	m.Go("topology-reconciler", stopCh, meshnet.NewTopologyReconciler(m).Run)
+	m.Go("wire-retry", stopCh, m.RetryFailedWires)
+	m.Go("wire-audit", stopCh, m.AuditWires)
+	m.Go("auto-wire", stopCh, m.AutoWire)
+	m.Go("canary-timeout", stopCh, m.CanaryTimeouts)
+	m.Go("resource-report", stopCh, m.ReportResources)
+	m.Go("traffic-accounting", stopCh, m.AccountTraffic)
+	m.Go("self-taint", stopCh, m.SelfTaint)
+	m.Go("gtp-prune", stopCh, m.PruneGTP)
+	m.Go("heartbeat", stopCh, m.Heartbeats)
+	m.Go("wireguard", stopCh, m.WireGuard)
+	m.Go("chaos-profiles", stopCh, m.ChaosProfiles)
+	m.Go("broadcast-groups", stopCh, m.BroadcastGroups)
+	m.Go("nads", stopCh, m.NADs)
+	m.Go("wire-watchdog", stopCh, m.WireWatchdog)
+	m.Go("node-status", stopCh, m.ReportNodeStatus)
+	m.Go("drift-detection", stopCh, m.DetectDrift)
+
+	if *healthAddr != "" {
+		go func() {
+			if err := m.ServeHealth(*healthAddr); err != nil {
+				log.Errorf("Health endpoint exited badly: %v", err)
+			}
+		}()
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.Serve()
+	}()
+
+	select {
+	case err := <-errCh:
 		log.Errorf("Daemon exited badly: %v", err)
 		os.Exit(1)
+	case sig := <-sigCh:
+		log.Infof("Received %s, shutting down", sig)
+		close(stopCh)
+		m.Shutdown(*shutdownTimeout)
 	}
 }
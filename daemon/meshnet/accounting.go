@@ -0,0 +1,327 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const trafficPrefix = "meshnet-traffic-"
+
+func trafficName(ns string) string {
+	return trafficPrefix + ns
+}
+
+// wireTraffic is the usage of a wire, stored as JSON under its UID in the traffic ConfigMap
+type wireTraffic struct {
+	Pod         string `json:"pod"`
+	PeerPod     string `json:"peer_pod"`
+	TxBytes     uint64 `json:"tx_bytes"`
+	RxBytes     uint64 `json:"rx_bytes"`
+	TxPackets   uint64 `json:"tx_packets"`
+	RxPackets   uint64 `json:"rx_packets"`
+	PeriodStart string `json:"period_start"`
+	PeriodEnd   string `json:"period_end"`
+}
+
+// counters are the interface counters of a wire end when it was last read
+type counters struct {
+	txBytes, rxBytes, txPackets, rxPackets uint64
+}
+
+func countersOf(s *netlink.LinkStatistics) counters {
+	return counters{s.TxBytes, s.RxBytes, s.TxPackets, s.RxPackets}
+}
+
+// sub returns the traffic since prev. Counters that went backwards have been reset
+// by the interface being re-created, so all of their value is new traffic.
+func (c counters) sub(prev counters) counters {
+	delta := func(cur, prev uint64) uint64 {
+		if cur < prev {
+			return cur
+		}
+		return cur - prev
+	}
+	return counters{
+		delta(c.txBytes, prev.txBytes),
+		delta(c.rxBytes, prev.rxBytes),
+		delta(c.txPackets, prev.txPackets),
+		delta(c.rxPackets, prev.rxPackets),
+	}
+}
+
+// accounts returns true if the wire is accounted for on pod's end. Each wire is only
+// accounted for once, on the end of the pod with the lowest name.
+func accounts(pod string, link *mpb.Link) bool {
+	return link.PeerPod == localhost || pod < link.PeerPod
+}
+
+// accountingSample is the traffic of a wire since the previous collection
+type accountingSample struct {
+	uid          int64
+	pod, peerPod string
+	traffic      counters
+}
+
+// AccountTraffic adds up the traffic of the wires of the pods on this node into the traffic
+// ConfigMap of their namespace, every TrafficAccountingInterval, until stopCh is closed.
+// Wires are only counted from the first time they're seen by the daemon, so traffic sent
+// while it's not running isn't accounted for.
+func (m *Meshnet) AccountTraffic(stopCh <-chan struct{}) {
+	interval := m.config.TrafficAccountingInterval
+	if interval <= 0 {
+		<-stopCh
+		return
+	}
+	last := make(map[string]counters)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			m.collectTraffic(context.Background(), last)
+		}
+	}
+}
+
+// collectTraffic reads the counters of the accounted wires and records their traffic since
+// the counters in last, which are then replaced with the current ones
+func (m *Meshnet) collectTraffic(ctx context.Context, last map[string]counters) {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed to list topologies: %s", err)
+		return
+	}
+	hostIP := os.Getenv("HOST_IP")
+	seen := make(map[string]bool)
+	samples := make(map[string][]accountingSample)
+	for _, t := range topologies.Items {
+		if t.Status.SrcIp != hostIP || t.Status.NetNs == "" {
+			continue
+		}
+		for _, l := range t.Spec.Links {
+			link := &mpb.Link{Uid: int64(l.UID), PeerPod: l.PeerPod, LocalIntf: l.LocalIntf}
+			if !accounts(t.Name, link) {
+				continue
+			}
+			stats, err := readLinkStats(t.Status.NetNs, link.LocalIntf)
+			if err != nil {
+				log.Debugf("Failed to read stats of link %d of pod %s: %s", link.Uid, t.Name, err)
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%d", t.Namespace, t.Name, link.Uid)
+			current := countersOf(stats)
+			prev, ok := last[key]
+			last[key] = current
+			seen[key] = true
+			if !ok {
+				continue
+			}
+			samples[t.Namespace] = append(samples[t.Namespace], accountingSample{
+				uid:     link.Uid,
+				pod:     t.Name,
+				peerPod: link.PeerPod,
+				traffic: current.sub(prev),
+			})
+		}
+	}
+	// Forgetting the wires that are gone, in case they come back with reset counters
+	for key := range last {
+		if !seen[key] {
+			delete(last, key)
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for ns, s := range samples {
+		if err := m.recordTraffic(ctx, ns, s, now); err != nil {
+			log.Errorf("Failed to record the traffic of namespace %s: %s", ns, err)
+		}
+	}
+}
+
+func (m *Meshnet) recordTraffic(ctx context.Context, ns string, samples []accountingSample, now string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cms := m.kClient.CoreV1().ConfigMaps(ns)
+		cm, err := cms.Get(ctx, trafficName(ns), metav1.GetOptions{})
+		create := apierrors.IsNotFound(err)
+		if create {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: trafficName(ns)}}
+		} else if err != nil {
+			return err
+		}
+		if err := addTraffic(cm, samples, now); err != nil {
+			return err
+		}
+		if create {
+			_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			return err
+		}
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// addTraffic adds the samples to the totals stored in cm, ending their period at now
+func addTraffic(cm *corev1.ConfigMap, samples []accountingSample, now string) error {
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	for _, s := range samples {
+		key := strconv.FormatInt(s.uid, 10)
+		w := wireTraffic{PeriodStart: now}
+		if data, ok := cm.Data[key]; ok {
+			if err := json.Unmarshal([]byte(data), &w); err != nil {
+				log.Warnf("Resetting invalid traffic record of wire %s: %s", key, err)
+				w = wireTraffic{PeriodStart: now}
+			}
+		}
+		w.Pod, w.PeerPod = s.pod, s.peerPod
+		w.TxBytes += s.traffic.txBytes
+		w.RxBytes += s.traffic.rxBytes
+		w.TxPackets += s.traffic.txPackets
+		w.RxPackets += s.traffic.rxPackets
+		w.PeriodEnd = now
+		data, err := json.Marshal(w)
+		if err != nil {
+			return err
+		}
+		cm.Data[key] = string(data)
+	}
+	return nil
+}
+
+// GetTrafficAccounting returns the traffic recorded for the wires of a namespace.
+func (m *Meshnet) GetTrafficAccounting(ctx context.Context, q *mpb.AccountingQuery) (*mpb.AccountingReport, error) {
+	start, end, err := parseRange(q.Start, q.End)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cm, err := m.kClient.CoreV1().ConfigMaps(q.KubeNs).Get(ctx, trafficName(q.KubeNs), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return &mpb.AccountingReport{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mpb.AccountingReport{Wires: filterTraffic(cm.Data, q.Pod, start, end)}, nil
+}
+
+// ResetTrafficAccounting moves the traffic recorded for the wires of a namespace to an
+// archive ConfigMap and starts counting from zero.
+func (m *Meshnet) ResetTrafficAccounting(ctx context.Context, q *mpb.AccountingQuery) (*mpb.AccountingReport, error) {
+	cms := m.kClient.CoreV1().ConfigMaps(q.KubeNs)
+	report := &mpb.AccountingReport{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := cms.Get(ctx, trafficName(q.KubeNs), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		archive := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("%s-%s", trafficName(q.KubeNs), time.Now().UTC().Format("20060102-150405")),
+			},
+			Data: cm.Data,
+		}
+		if _, err := cms.Create(ctx, archive, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		cm.Data = nil
+		if _, err := cms.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+			// The counters have changed in the meantime, the archive is re-created with them
+			if delErr := cms.Delete(ctx, archive.Name, metav1.DeleteOptions{}); delErr != nil {
+				log.Warnf("Failed to delete traffic archive %s: %s", archive.Name, delErr)
+			}
+			return err
+		}
+		report.Archive = archive.Name
+		report.Wires = filterTraffic(archive.Data, "", time.Time{}, time.Time{})
+		return nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"function": "ResetTrafficAccounting",
+		}).Errorf("Failed to reset the traffic accounting of namespace %s", q.KubeNs)
+		return nil, err
+	}
+	log.Infof("Traffic accounting of namespace %s has been reset, archived to %q", q.KubeNs, report.Archive)
+	return report, nil
+}
+
+func parseRange(start, end string) (time.Time, time.Time, error) {
+	var s, e time.Time
+	var err error
+	if start != "" {
+		if s, err = time.Parse(time.RFC3339, start); err != nil {
+			return s, e, fmt.Errorf("invalid start time: %s", err)
+		}
+	}
+	if end != "" {
+		if e, err = time.Parse(time.RFC3339, end); err != nil {
+			return s, e, fmt.Errorf("invalid end time: %s", err)
+		}
+	}
+	return s, e, nil
+}
+
+// filterTraffic returns the wires of pod, or all wires if it's empty, whose period overlaps
+// with the start to end range, where zero times are unbounded, sorted by UID
+func filterTraffic(data map[string]string, pod string, start, end time.Time) []*mpb.WireTraffic {
+	var result []*mpb.WireTraffic
+	for key, value := range data {
+		uid, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			continue
+		}
+		var w wireTraffic
+		if err := json.Unmarshal([]byte(value), &w); err != nil {
+			log.Warnf("Ignoring invalid traffic record of wire %s: %s", key, err)
+			continue
+		}
+		if pod != "" && w.Pod != pod && w.PeerPod != pod {
+			continue
+		}
+		periodStart, _ := time.Parse(time.RFC3339, w.PeriodStart)
+		periodEnd, _ := time.Parse(time.RFC3339, w.PeriodEnd)
+		if (!start.IsZero() && periodEnd.Before(start)) || (!end.IsZero() && periodStart.After(end)) {
+			continue
+		}
+		result = append(result, &mpb.WireTraffic{
+			Uid:         uid,
+			Pod:         w.Pod,
+			PeerPod:     w.PeerPod,
+			TxBytes:     w.TxBytes,
+			RxBytes:     w.RxBytes,
+			TxPackets:   w.TxPackets,
+			RxPackets:   w.RxPackets,
+			PeriodStart: w.PeriodStart,
+			PeriodEnd:   w.PeriodEnd,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Uid < result[j].Uid })
+	return result
+}
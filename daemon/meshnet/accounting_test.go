@@ -0,0 +1,71 @@
+package meshnet
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestCountersSub(t *testing.T) {
+	prev := counters{txBytes: 100, rxBytes: 200, txPackets: 1, rxPackets: 2}
+	if got, want := (counters{150, 260, 2, 4}).sub(prev), (counters{50, 60, 1, 2}); got != want {
+		t.Errorf("sub() = %+v, want %+v", got, want)
+	}
+	// the interface has been re-created
+	if got, want := (counters{10, 20, 1, 1}).sub(prev), (counters{10, 20, 0, 1}); got != want {
+		t.Errorf("sub() after reset = %+v, want %+v", got, want)
+	}
+}
+
+func TestAccounts(t *testing.T) {
+	if !accounts("r1", &mpb.Link{PeerPod: "r2"}) || accounts("r2", &mpb.Link{PeerPod: "r1"}) {
+		t.Error("wire must be accounted for on the end of the pod with the lowest name only")
+	}
+	if !accounts("r2", &mpb.Link{PeerPod: localhost}) {
+		t.Error("macvlan links must be accounted for")
+	}
+}
+
+func TestTrafficRecords(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	sample := accountingSample{uid: 1, pod: "r1", peerPod: "r2", traffic: counters{txBytes: 100, rxBytes: 50}}
+	if err := addTraffic(cm, []accountingSample{sample}, "2026-01-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	other := accountingSample{uid: 2, pod: "r2", peerPod: "r3", traffic: counters{txBytes: 1}}
+	if err := addTraffic(cm, []accountingSample{sample, other}, "2026-01-01T00:01:00Z"); err != nil {
+		t.Fatal(err)
+	}
+
+	all := filterTraffic(cm.Data, "", time.Time{}, time.Time{})
+	if len(all) != 2 {
+		t.Fatalf("filterTraffic() returned %d wires, want 2", len(all))
+	}
+	want := &mpb.WireTraffic{
+		Uid:         1,
+		Pod:         "r1",
+		PeerPod:     "r2",
+		TxBytes:     200,
+		RxBytes:     100,
+		PeriodStart: "2026-01-01T00:00:00Z",
+		PeriodEnd:   "2026-01-01T00:01:00Z",
+	}
+	if !proto.Equal(all[0], want) {
+		t.Errorf("filterTraffic() = %v, want %v", all[0], want)
+	}
+
+	if got := filterTraffic(cm.Data, "r3", time.Time{}, time.Time{}); len(got) != 1 || got[0].Uid != 2 {
+		t.Errorf("filterTraffic() by peer pod = %v", got)
+	}
+	after, _ := time.Parse(time.RFC3339, "2026-01-01T00:00:30Z")
+	if got := filterTraffic(cm.Data, "", after, time.Time{}); len(got) != 2 {
+		t.Errorf("filterTraffic() from %s returned %d wires, want 2", after, len(got))
+	}
+	if got := filterTraffic(cm.Data, "", time.Time{}, after); len(got) != 1 || got[0].Uid != 1 {
+		t.Errorf("filterTraffic() until %s = %v", after, got)
+	}
+}
@@ -0,0 +1,186 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	// maximum number of audit entries kept per topology, the oldest are evicted first
+	maxWireAudit = 100
+	// maximum number of audit entries waiting to be recorded, the newer ones are dropped
+	auditQueueSize = 256
+
+	howCreatedCNI       = "CNI_ADD"
+	howCreatedPeer      = "CNI_ADD_PEER"
+	howCreatedRemote    = "REMOTE_UPDATE"
+	howCreatedRetry     = "RETRY"
+	howCreatedPatchLink = "PATCH_LINK"
+
+	wireTypeVeth    = "veth"
+	wireTypeVxlan   = "vxlan"
+	wireTypeSRv6    = "srv6"
+	wireTypeMacvlan = "macvlan"
+	wireTypeSRIOV   = "sriov"
+	wireTypeGTP     = "gtp"
+	wireTypeOVS     = "ovs"
+	wireTypeWG      = "wireguard"
+)
+
+// AuditWire records how a wire was set up in the wire_audit status of the pod's topology.
+func (m *Meshnet) AuditWire(ctx context.Context, audit *mpb.WireAudit) (*mpb.BoolResponse, error) {
+	stamp(audit)
+	if audit.Queue {
+		if !m.queueAudit(audit) {
+			return &mpb.BoolResponse{Response: false}, status.Errorf(codes.ResourceExhausted, "%d audit entries are waiting to be recorded", auditQueueSize)
+		}
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+	if err := m.recordAudit(ctx, audit); err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+func (m *Meshnet) recordAudit(ctx context.Context, audit *mpb.WireAudit) error {
+	log.Infof("Recording wire %d of pod %s created by %s", audit.LinkUid, audit.Pod, audit.HowCreated)
+	up := m.order.up(audit.KubeNs, audit.Pod, audit.LinkUid)
+	if up && m.config.AutoInjectRoutes {
+		go m.autoInjectRoutes(audit.KubeNs, audit.Pod)
+	}
+
+	entry := map[string]interface{}{
+		"link_uid":    audit.LinkUid,
+		"how_created": audit.HowCreated,
+		"created_at":  audit.CreatedAt,
+		"node_ip":     audit.NodeIp,
+		"wire_type":   audit.WireType,
+	}
+
+	var topology string
+	var after *mpb.Link
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		result, err := m.getPod(ctx, audit.Pod, audit.KubeNs)
+		if err != nil {
+			return err
+		}
+		topology = result.GetName()
+		links, _, _ := unstructured.NestedSlice(result.Object, "spec", "links")
+		if parsed, err := parseLinks(links); err == nil {
+			after = linkByUID(parsed, audit.LinkUid)
+		}
+
+		entries, _, _ := unstructured.NestedSlice(result.Object, "status", "wire_audit")
+		entries = append(entries, entry)
+		if len(entries) > maxWireAudit {
+			entries = entries[len(entries)-maxWireAudit:]
+		}
+		if err := unstructured.SetNestedSlice(result.Object, entries, "status", "wire_audit"); err != nil {
+			return err
+		}
+		if setUpByPeer(audit.HowCreated) {
+			addWiresUp(result, 1)
+		}
+		if err := setInactive(result, []int64{audit.LinkUid}, false); err != nil {
+			return err
+		}
+		return m.updateStatus(ctx, result, audit.KubeNs)
+	})
+	if retryErr != nil {
+		log.WithFields(log.Fields{
+			"err":      retryErr,
+			"function": "AuditWire",
+		}).Errorf("Failed to record wire %d of pod %s", audit.LinkUid, audit.Pod)
+		return retryErr
+	}
+	m.recordEvent(ctx, audit.KubeNs, topology, mpb.TopologyEvent_CREATE, audit.LinkUid, nil, after,
+		"%s wire created by %s on %s", audit.WireType, audit.HowCreated, audit.NodeIp)
+	if up && m.config.ConvergenceSLA > 0 {
+		go m.autoMeasureConvergence(audit.KubeNs)
+	}
+	return nil
+}
+
+// setUpByPeer returns whether the wire of an audit entry has been set up by the CNI ADD of the
+// pod's peer, which came up after the pod and hasn't been counted in its wires_up yet
+func setUpByPeer(howCreated string) bool {
+	switch howCreated {
+	case howCreatedPeer, howCreatedRemote, howCreatedRetry:
+		return true
+	}
+	return false
+}
+
+// queueAudit queues an entry to be recorded by AuditWires, returning false if the queue is
+// full. The entry's timestamp is the time it was queued.
+func (m *Meshnet) queueAudit(audit *mpb.WireAudit) bool {
+	stamp(audit)
+	select {
+	case m.audits <- audit:
+		return true
+	default:
+		m.wireLog.Warnf("audit", "Dropping the audit of wire %d of pod %s, %d entries are waiting", audit.LinkUid, audit.Pod, auditQueueSize)
+		return false
+	}
+}
+
+// stamp sets the time and node of an audit entry if they're missing
+func stamp(audit *mpb.WireAudit) {
+	if audit.CreatedAt == "" {
+		audit.CreatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	if audit.NodeIp == "" {
+		audit.NodeIp = os.Getenv("HOST_IP")
+	}
+}
+
+// AuditWires records the queued audit entries one by one until stopCh is closed. Entries
+// that are still queued then are lost, like the ones that fail to be recorded.
+func (m *Meshnet) AuditWires(stopCh <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case audit := <-m.audits:
+			m.recordAudit(ctx, audit)
+		}
+	}
+}
+
+// auditRemote queues the entry of a wire set up by a remote update
+func (m *Meshnet) auditRemote(pod *mpb.RemotePod, howCreated string) {
+	if pod.PodName == "" {
+		return
+	}
+	wireType := wireTypeVxlan
+	switch pod.TunnelType {
+	case mpb.TunnelType_SRV6:
+		wireType = wireTypeSRv6
+	case mpb.TunnelType_GTP:
+		wireType = wireTypeGTP
+	case mpb.TunnelType_WIREGUARD:
+		wireType = wireTypeWG
+	}
+	m.queueAudit(&mpb.WireAudit{
+		Pod:        pod.PodName,
+		KubeNs:     pod.KubeNs,
+		LinkUid:    pod.Vni - vxlanBase,
+		HowCreated: howCreated,
+		WireType:   wireType,
+	})
+}
@@ -0,0 +1,39 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestAuditWireQueued(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	m.Go("wire-audit", stopCh, m.AuditWires)
+
+	resp, err := m.AuditWire(ctx, &mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: 1, HowCreated: "CNI_ADD", WireType: "veth", Queue: true})
+	if err != nil || !resp.Response {
+		t.Fatalf("AuditWire() = %v, %v", resp, err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, _, _ := unstructured.NestedSlice(meshnettest.Store(m).Object("default", "r1").Object, "status", "wire_audit")
+		if len(entries) == 1 {
+			if e := entries[0].(map[string]interface{}); e["how_created"] != "CNI_ADD" || e["created_at"] == "" {
+				t.Errorf("wire_audit of r1 = %v, want the queued entry", entries)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("wire_audit of r1 = %v, want the queued entry", entries)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
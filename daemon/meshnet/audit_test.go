@@ -0,0 +1,37 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestQueueAudit(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	m, err := NewWithClients(Config{DisableReflection: true}, fake.NewSimpleClientset(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < auditQueueSize; i++ {
+		if !m.queueAudit(&mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: int64(i)}) {
+			t.Fatalf("queueAudit() of entry %d failed, want it queued", i)
+		}
+	}
+	if m.queueAudit(&mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: auditQueueSize}) {
+		t.Errorf("queueAudit() succeeded with a full queue")
+	}
+	resp, err := m.AuditWire(context.Background(), &mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: 1, Queue: true})
+	if status.Code(err) != codes.ResourceExhausted || resp.Response {
+		t.Errorf("AuditWire() with a full queue = %v, %v, want %s", resp, err, codes.ResourceExhausted)
+	}
+
+	audit := <-m.audits
+	if audit.CreatedAt == "" || audit.NodeIp != "10.0.0.1" {
+		t.Errorf("queued entry %v, want it stamped with its time and node", audit)
+	}
+}
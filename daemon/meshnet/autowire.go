@@ -0,0 +1,323 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/networkop/meshnet-cni/daemon/annotations"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	// label holding the CIDR that link IPs are allocated from, with "_" in place of "/"
+	autoWireCIDRLabel = "meshnet.io/cidr"
+
+	// annotations holding the runtime status of auto-wired pods
+	srcIPAnnotation   = "meshnet.io/src-ip"
+	netNsAnnotation   = "meshnet.io/net-ns"
+	skippedAnnotation = "meshnet.io/skipped"
+
+	// keeps the VNI derived from a link UID within 24 bits
+	autoWireMaxUID = 1 << 23
+	// characters of a peer name kept in front of its hash, within maxIntfName
+	intfNamePrefix = maxIntfName - 9
+)
+
+// autoWirer builds full-mesh topologies for pods in the same namespace that share the
+// value of a label. The topologies are synthesized in memory from the current group
+// members, while the pods' runtime status is kept in their annotations, so that the
+// daemons on all nodes have the same view without creating any Topology CRs.
+type autoWirer struct {
+	label     string
+	kClient   kubernetes.Interface
+	factory   informers.SharedInformerFactory
+	podLister listerv1.PodLister
+}
+
+func newAutoWirer(kClient kubernetes.Interface, label string) *autoWirer {
+	factory := informers.NewSharedInformerFactoryWithOptions(kClient, 0,
+		informers.WithTweakListOptions(func(lo *metav1.ListOptions) {
+			lo.LabelSelector = label
+		}))
+	podInformer := factory.Core().V1().Pods()
+
+	a := &autoWirer{
+		label:     label,
+		kClient:   kClient,
+		factory:   factory,
+		podLister: podInformer.Lister(),
+	}
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: a.onAdd,
+	})
+	return a
+}
+
+// AutoWire runs the pod informer of the auto-wiring mode until stopCh is closed.
+func (m *Meshnet) AutoWire(stopCh <-chan struct{}) {
+	if m.autoWire == nil {
+		<-stopCh
+		return
+	}
+	log.Infof("Starting auto-wiring of pods with label %s", m.autoWire.label)
+	m.autoWire.factory.Start(stopCh)
+	m.autoWire.factory.WaitForCacheSync(stopCh)
+	<-stopCh
+}
+
+// onAdd only logs new group members, their links are set up by their own CNI ADD call,
+// since all the peers that are already up are seen as alive
+func (a *autoWirer) onAdd(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	log.Infof("Pod %s/%s has joined auto-wire group %s", pod.Namespace, pod.Name, pod.Labels[a.label])
+}
+
+// owns returns the pod if it's part of an auto-wire group
+func (a *autoWirer) owns(ctx context.Context, ns, name string) (*corev1.Pod, bool) {
+	if a == nil {
+		return nil, false
+	}
+	pod, err := a.kClient.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, false
+	}
+	if _, ok := pod.Labels[a.label]; !ok {
+		return nil, false
+	}
+	return pod, true
+}
+
+// members returns the sorted names of the pods in the same group as pod
+func (a *autoWirer) members(pod *corev1.Pod) ([]string, error) {
+	selector := labels.SelectorFromSet(labels.Set{a.label: pod.Labels[a.label]})
+	pods, err := a.podLister.Pods(pod.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{pod.Name}
+	for _, p := range pods {
+		if p.Name != pod.Name && p.DeletionTimestamp == nil {
+			result = append(result, p.Name)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (a *autoWirer) get(pod *corev1.Pod) (*mpb.Pod, error) {
+	members, err := a.members(pod)
+	if err != nil {
+		return nil, err
+	}
+	var cidr *net.IPNet
+	if v, ok := pod.Labels[autoWireCIDRLabel]; ok {
+		if _, cidr, err = net.ParseCIDR(strings.Replace(v, "_", "/", 1)); err != nil {
+			log.Errorf("Failed to parse %s label of pod %s: %s", autoWireCIDRLabel, pod.Name, err)
+		}
+	}
+
+	links, err := meshLinks(pod.Namespace, pod.Labels[a.label], pod.Name, members, cidr)
+	if err != nil {
+		return nil, err
+	}
+	return &mpb.Pod{
+		Name:        pod.Name,
+		SrcIp:       pod.Annotations[srcIPAnnotation],
+		NetNs:       pod.Annotations[netNsAnnotation],
+		KubeNs:      pod.Namespace,
+		Links:       links,
+		Annotations: annotations.Filter(pod.Annotations),
+	}, nil
+}
+
+// meshLinks returns the links of pod self towards all other members of its group. Each
+// interface is named after its peer, see intfName, so that members joining or leaving the
+// group don't rename the interfaces of the others.
+func meshLinks(ns, group, self string, members []string, cidr *net.IPNet) ([]*mpb.Link, error) {
+	if err := checkMeshUIDs(ns, group, members); err != nil {
+		return nil, err
+	}
+	i := sort.SearchStrings(members, self)
+	links := make([]*mpb.Link, 0, len(members))
+	intfs := make(map[string]string, len(members))
+	for j, peer := range members {
+		if j == i {
+			continue
+		}
+		intf := intfName(peer)
+		if other, ok := intfs[intf]; ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "peers %s and %s of pod %s would both be connected to interface %s", other, peer, self, intf)
+		}
+		intfs[intf] = peer
+		lo, hi := i, j
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		link := &mpb.Link{
+			PeerPod:   peer,
+			LocalIntf: intf,
+			PeerIntf:  intfName(self),
+			Uid:       linkUID(ns, group, members[lo], members[hi]),
+		}
+		if cidr != nil {
+			// Pairs are numbered so that a new member sorted last doesn't change the existing ones
+			pair := hi*(hi-1)/2 + lo
+			link.LocalIp = linkIP(cidr, pair, i == lo)
+			link.PeerIp = linkIP(cidr, pair, i != lo)
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// intfName returns the name of the interface towards peer: the name of the peer if it's a
+// valid interface name that isn't taken by the pod's own interfaces, or its first characters
+// followed by a hash of the whole name otherwise
+func intfName(peer string) string {
+	if len(peer) <= maxIntfName && peer != "lo" && peer != "eth0" {
+		return peer
+	}
+	h := fnv.New32a()
+	h.Write([]byte(peer))
+	prefix := peer
+	if len(prefix) > intfNamePrefix {
+		prefix = prefix[:intfNamePrefix]
+	}
+	return fmt.Sprintf("%s-%08x", prefix, h.Sum32())
+}
+
+// checkMeshUIDs fails if two links of the group have the same UID, and so the same VNI
+func checkMeshUIDs(ns, group string, members []string) error {
+	pairs := make(map[int64][2]string)
+	for i := range members {
+		for j := i + 1; j < len(members); j++ {
+			uid := linkUID(ns, group, members[i], members[j])
+			if other, ok := pairs[uid]; ok {
+				return status.Errorf(codes.FailedPrecondition, "links %s-%s and %s-%s of auto-wire group %s have the same UID %d, rename one of these pods",
+					other[0], other[1], members[i], members[j], group, uid)
+			}
+			pairs[uid] = [2]string{members[i], members[j]}
+		}
+	}
+	return nil
+}
+
+// linkUID derives the same UID on both ends of a link
+func linkUID(ns, group, a, b string) int64 {
+	h := fnv.New32a()
+	h.Write([]byte(strings.Join([]string{ns, group, a, b}, "/")))
+	return int64(h.Sum32() % autoWireMaxUID)
+}
+
+// linkIP returns the first or the second host address of the pair's /30 (or /126) within cidr
+func linkIP(cidr *net.IPNet, pair int, first bool) string {
+	ones, bits := cidr.Mask.Size()
+	prefix := bits - 2
+	if ones > prefix {
+		return ""
+	}
+	offset := int64(pair)*4 + 2
+	if first {
+		offset--
+	}
+	if big.NewInt(int64(pair+1)).Cmp(new(big.Int).Lsh(big.NewInt(1), uint(prefix-ones))) > 0 {
+		log.Errorf("CIDR %s is too small for link %d", cidr, pair)
+		return ""
+	}
+	base := cidr.IP.To4()
+	if base == nil {
+		base = cidr.IP.To16()
+	}
+	ip := new(big.Int).Add(new(big.Int).SetBytes(base), big.NewInt(offset)).Bytes()
+	// Restoring the leading zero bytes dropped by big.Int
+	result := make(net.IP, len(base))
+	copy(result[len(result)-len(ip):], ip)
+	return fmt.Sprintf("%s/%d", result, prefix)
+}
+
+// updateAnnotations applies fn to the pod's annotations and retries on conflicts
+func (a *autoWirer) updateAnnotations(ctx context.Context, ns, name string, fn func(map[string]string)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := a.kClient.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		fn(pod.Annotations)
+		_, err = a.kClient.CoreV1().Pods(ns).Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (a *autoWirer) setAlive(ctx context.Context, pod *mpb.Pod) error {
+	return a.updateAnnotations(ctx, pod.KubeNs, pod.Name, func(ann map[string]string) {
+		ann[srcIPAnnotation] = pod.SrcIp
+		ann[netNsAnnotation] = pod.NetNs
+	})
+}
+
+func (a *autoWirer) skip(ctx context.Context, skip *mpb.SkipQuery) error {
+	return a.updateAnnotations(ctx, skip.KubeNs, skip.Pod, func(ann map[string]string) {
+		ann[skippedAnnotation] = strings.Join(append(skippedList(ann), skip.Peer), ",")
+	})
+}
+
+func (a *autoWirer) skipReverse(ctx context.Context, skip *mpb.SkipQuery) error {
+	err := a.updateAnnotations(ctx, skip.KubeNs, skip.Peer, func(ann map[string]string) {
+		ann[skippedAnnotation] = strings.Join(append(skippedList(ann), skip.Pod), ",")
+	})
+	if err != nil {
+		return err
+	}
+	return a.updateAnnotations(ctx, skip.KubeNs, skip.Pod, func(ann map[string]string) {
+		var result []string
+		for _, p := range skippedList(ann) {
+			if p != skip.Peer {
+				result = append(result, p)
+			}
+		}
+		ann[skippedAnnotation] = strings.Join(result, ",")
+	})
+}
+
+func (a *autoWirer) isSkipped(ctx context.Context, skip *mpb.SkipQuery) (bool, error) {
+	peer, err := a.kClient.CoreV1().Pods(skip.KubeNs).Get(ctx, skip.Peer, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, p := range skippedList(peer.Annotations) {
+		if p == skip.Pod {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func skippedList(ann map[string]string) []string {
+	if ann[skippedAnnotation] == "" {
+		return nil
+	}
+	return strings.Split(ann[skippedAnnotation], ",")
+}
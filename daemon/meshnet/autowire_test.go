@@ -0,0 +1,144 @@
+package meshnet
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMeshLinks(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/24")
+	members := []string{"r1", "r2", "r3"}
+
+	r1, err := meshLinks("default", "lab", "r1", members, cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r3, err := meshLinks("default", "lab", "r3", members, cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r1) != 2 || len(r3) != 2 {
+		t.Fatalf("expected 2 links per pod, got %d and %d", len(r1), len(r3))
+	}
+
+	// r1's link towards r3 and r3's link towards r1 must match up
+	a, b := r1[1], r3[0]
+	if a.PeerPod != "r3" || b.PeerPod != "r1" {
+		t.Fatalf("unexpected peers %s and %s", a.PeerPod, b.PeerPod)
+	}
+	if a.LocalIntf != "r3" || a.PeerIntf != "r1" || b.LocalIntf != a.PeerIntf || b.PeerIntf != a.LocalIntf {
+		t.Errorf("interfaces don't match: %+v, %+v", a, b)
+	}
+	if a.Uid != b.Uid {
+		t.Errorf("UIDs don't match: %d, %d", a.Uid, b.Uid)
+	}
+	if a.LocalIp != "10.0.0.5/30" || a.PeerIp != "10.0.0.6/30" || b.LocalIp != a.PeerIp || b.PeerIp != a.LocalIp {
+		t.Errorf("IPs don't match: %+v, %+v", a, b)
+	}
+}
+
+func TestMeshLinksStable(t *testing.T) {
+	before, err := meshLinks("default", "lab", "r2", []string{"r2", "r3"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a member sorted first joins the group
+	after, err := meshLinks("default", "lab", "r2", []string{"r1", "r2", "r3"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 2 || after[1].PeerPod != "r3" || after[1].LocalIntf != before[0].LocalIntf || after[1].Uid != before[0].Uid {
+		t.Errorf("link towards r3 has changed from %v to %v", before[0], after)
+	}
+}
+
+func TestIntfName(t *testing.T) {
+	tests := []struct {
+		peer string
+		want string
+	}{
+		{peer: "r1", want: "r1"},
+		{peer: "spine-1.lab", want: "spine-1.lab"},
+		{peer: "very-long-pod-name", want: "very-l-" + fmt.Sprintf("%08x", fnv32("very-long-pod-name"))},
+	}
+	for _, tt := range tests {
+		if got := intfName(tt.peer); got != tt.want {
+			t.Errorf("intfName(%s) = %q, want %q", tt.peer, got, tt.want)
+		}
+	}
+	if intfName("eth0") == "eth0" || intfName("lo") == "lo" {
+		t.Error("intfName() has taken the name of the pod's own interfaces")
+	}
+}
+
+func TestMeshLinksCollisions(t *testing.T) {
+	// a peer named like the hashed name of another one
+	long := "very-long-pod-name"
+	members := []string{"r1", intfName(long), long}
+	if _, err := meshLinks("default", "lab", "r1", members, nil); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("meshLinks() with two peers on the same interface = %v, want FailedPrecondition", err)
+	}
+
+	// UIDs have 23 bits, so 200 members have colliding links
+	members = nil
+	for i := 0; i < 200; i++ {
+		members = append(members, fmt.Sprintf("r%03d", i))
+	}
+	if _, err := meshLinks("default", "lab", "r000", members, nil); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("meshLinks() with colliding UIDs = %v, want FailedPrecondition", err)
+	}
+	if _, err := meshLinks("default", "lab", "r000", members[:10], nil); err != nil {
+		t.Errorf("meshLinks() = %v, want no collisions between 10 members", err)
+	}
+}
+
+func TestLinkIP(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		pair     int
+		first    bool
+		expected string
+	}{
+		{
+			cidr:     "10.0.0.0/24",
+			pair:     0,
+			first:    true,
+			expected: "10.0.0.1/30",
+		},
+		{
+			cidr:     "10.0.0.0/24",
+			pair:     63,
+			first:    false,
+			expected: "10.0.0.254/30",
+		},
+		{
+			cidr:     "10.0.0.0/24",
+			pair:     64,
+			first:    true,
+			expected: "",
+		},
+		{
+			cidr:     "2001:db8::/64",
+			pair:     1,
+			first:    false,
+			expected: "2001:db8::6/126",
+		},
+	}
+	for i, tt := range tests {
+		_, cidr, _ := net.ParseCIDR(tt.cidr)
+		if result := linkIP(cidr, tt.pair, tt.first); result != tt.expected {
+			t.Errorf("#%d test failed: expected %q, got %q", i, tt.expected, result)
+		}
+	}
+}
+
+func fnv32(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
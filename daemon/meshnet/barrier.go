@@ -0,0 +1,96 @@
+package meshnet
+
+import (
+	"context"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// WaitForPodsAlive waits up to PodAliveTimeout for the pods of q to be alive, i.e. for the
+// CNI plugin to have called SetAlive for each of them, so that a pod can set up its wires
+// once its peers are up. It watches the topologies of the namespace instead of polling
+// them. With a zero PodAliveTimeout, it only tells whether the pods are alive already.
+func (m *Meshnet) WaitForPodsAlive(ctx context.Context, q *mpb.PodList) (*mpb.BoolResponse, error) {
+	pending := make(map[string]bool, len(q.Names))
+	for _, name := range q.Names {
+		pending[name] = true
+	}
+	if m.config.PodAliveTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.PodAliveTimeout)
+		defer cancel()
+	}
+
+	for {
+		// watching before listing, so that no change is missed in between
+		w, err := m.tClient.Topology(q.KubeNs).Watch(ctx, metav1.ListOptions{})
+		if err != nil {
+			return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_NONE, "failed to watch the topologies of namespace %s", q.KubeNs)
+		}
+		topologies, err := m.tClient.Topology(q.KubeNs).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			w.Stop()
+			return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of namespace %s", q.KubeNs)
+		}
+		for i := range topologies.Items {
+			markAlive(pending, &topologies.Items[i])
+		}
+		if len(pending) == 0 || m.config.PodAliveTimeout <= 0 {
+			w.Stop()
+			return &mpb.BoolResponse{Response: len(pending) == 0}, nil
+		}
+		alive := waitAlive(ctx, w.ResultChan(), pending)
+		w.Stop()
+		if alive {
+			return &mpb.BoolResponse{Response: true}, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return &mpb.BoolResponse{Response: false}, wireError(k8sCode(err), &mpb.WireError{},
+				"pods %v of namespace %s aren't alive: %s", pendingNames(pending), q.KubeNs, err)
+		}
+		log.Infof("The watch of the topologies of namespace %s has ended, restarting it", q.KubeNs)
+	}
+}
+
+// waitAlive removes the pods that become alive from pending, and returns true once there
+// are none left. It returns false if ctx is done or the watch ends first.
+func waitAlive(ctx context.Context, events <-chan watch.Event, pending map[string]bool) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			if t, ok := e.Object.(*topologyv1.Topology); ok && e.Type != watch.Deleted {
+				markAlive(pending, t)
+			}
+			if len(pending) == 0 {
+				return true
+			}
+		}
+	}
+}
+
+// markAlive removes the pod of t from pending if it's alive
+func markAlive(pending map[string]bool, t *topologyv1.Topology) {
+	if t.Status.SrcIp != "" {
+		delete(pending, t.Name)
+	}
+}
+
+func pendingNames(pending map[string]bool) []string {
+	var result []string
+	for name := range pending {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,32 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestWaitForPodsAlive(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	q := &mpb.PodList{Names: []string{"r1", "r2"}, KubeNs: "default"}
+	// without -pod-alive-timeout, it doesn't wait
+	if resp, err := m.WaitForPodsAlive(ctx, q); err != nil || resp.Response {
+		t.Errorf("WaitForPodsAlive() before the pods are alive = %v, %v, want false", resp, err)
+	}
+	for _, pod := range []string{"r1", "r2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", pod, err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/"+pod
+		if _, err := m.SetAlive(ctx, p); err != nil {
+			t.Fatalf("SetAlive(%s) failed: %v", pod, err)
+		}
+	}
+	if resp, err := m.WaitForPodsAlive(ctx, q); err != nil || !resp.Response {
+		t.Errorf("WaitForPodsAlive() = %v, %v, want true", resp, err)
+	}
+}
@@ -0,0 +1,43 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func aliveTopology(name, srcIP string) *topologyv1.Topology {
+	return &topologyv1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     topologyv1.TopologyStatus{SrcIp: srcIP},
+	}
+}
+
+func TestWaitAlive(t *testing.T) {
+	events := make(chan watch.Event, 4)
+	events <- watch.Event{Type: watch.Modified, Object: aliveTopology("r1", "10.0.0.1")}
+	events <- watch.Event{Type: watch.Modified, Object: aliveTopology("r2", "")}
+	events <- watch.Event{Type: watch.Deleted, Object: aliveTopology("r2", "10.0.0.2")}
+	events <- watch.Event{Type: watch.Added, Object: aliveTopology("r2", "10.0.0.2")}
+	pending := map[string]bool{"r1": true, "r2": true}
+	if !waitAlive(context.Background(), events, pending) {
+		t.Errorf("waitAlive() = false, want true once r1 and r2 are alive")
+	}
+
+	pending = map[string]bool{"r3": true}
+	close(events)
+	if waitAlive(context.Background(), events, pending) {
+		t.Errorf("waitAlive() after the end of the watch = true")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if waitAlive(ctx, make(chan watch.Event), pending) {
+		t.Errorf("waitAlive() after the timeout = true")
+	}
+}
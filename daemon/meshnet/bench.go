@@ -0,0 +1,62 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/bench"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// BenchmarkWire measures the throughput of a link by sending synthetic frames from the
+// pod's end and counting them at the peer's end. Both pods must be running on this node.
+func (m *Meshnet) BenchmarkWire(ctx context.Context, req *mpb.BenchmarkRequest) (*mpb.BenchmarkResult, error) {
+	duration := time.Duration(req.DurationMs) * time.Millisecond
+	if err := bench.Validate(int(req.FrameSize), duration); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: req.Pod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	link := linkByUID(pod.Links, req.LinkUid)
+	if link == nil {
+		return nil, fmt.Errorf("pod %s has no link %d", req.Pod, req.LinkUid)
+	}
+	if link.PeerPod == localhost {
+		return nil, status.Errorf(codes.FailedPrecondition, "link %d is a macvlan link with no peer pod", req.LinkUid)
+	}
+	peer, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	if pod.NetNs == "" || peer.NetNs == "" || pod.SrcIp != pod.NodeIp || peer.SrcIp != pod.NodeIp {
+		return nil, status.Errorf(codes.FailedPrecondition, "pods %s and %s must both be running on this node", req.Pod, link.PeerPod)
+	}
+
+	log.Infof("Benchmarking link %d of pod %s for %s", req.LinkUid, req.Pod, duration)
+	bufSize := int(req.RecvBufSizeBytes)
+	if bufSize <= 0 {
+		bufSize = m.config.PacketBufferSize
+	}
+	r, err := bench.Run(pod.NetNs, link.LocalIntf, peer.NetNs, link.PeerIntf, int(req.FrameSize), duration, bufSize)
+	if err != nil {
+		return nil, err
+	}
+	return &mpb.BenchmarkResult{
+		FramesSent:       r.Sent,
+		FramesReceived:   r.Received,
+		FramesPerSecond:  r.FramesPerSecond(),
+		BytesPerSecond:   r.BytesPerSecond(),
+		MaxThroughputBps: r.BytesPerSecond() * 8,
+		P50LatencyUs:     r.P50.Microseconds(),
+		P99LatencyUs:     r.P99.Microseconds(),
+		LossPercent:      r.LossPercent(),
+		SocketDrops:      r.Drops,
+	}, nil
+}
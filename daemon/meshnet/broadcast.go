@@ -0,0 +1,226 @@
+package meshnet
+
+import (
+	"context"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/vxlan"
+)
+
+const (
+	// how long to wait before watching the topologies again after the watch has failed
+	broadcastRetryInterval = 10 * time.Second
+
+	fanoutBestEffort     = "best_effort"
+	fanoutFailOnAnyError = "fail_on_any_error"
+)
+
+// broadcastGroup is the VTEPs the VXLAN end of a wire on this node floods frames to
+type broadcastGroup struct {
+	netNs, intf string
+	vteps       []string
+}
+
+// broadcastState keeps the VTEPs set on the VXLAN ends of this node, keyed by namespace,
+// pod and link UID
+type broadcastState struct {
+	mu      sync.Mutex
+	applied map[string][]string
+}
+
+func newBroadcastState() *broadcastState {
+	return &broadcastState{applied: make(map[string][]string)}
+}
+
+// broadcastGroups returns the broadcast groups of the VXLAN ends of the pods running on
+// hostIP. The pods with a link of the same UID form a broadcast domain, so the group of an
+// end is the nodes of all the other pods of its domain. If a link of the domain has fanout
+// peers, the domain is a fanout from its pod instead: the end of that pod floods to the nodes
+// of its fanout peers, and the other ends only reach the node of that pod.
+func broadcastGroups(topologies []topologyv1.Topology, hostIP string) map[string]broadcastGroup {
+	type domain struct {
+		ns  string
+		uid int
+	}
+	members := make(map[domain][]topologyv1.Topology)
+	roots := make(map[domain]topologyv1.Topology)
+	nodes := make(map[string]string)
+	for _, t := range topologies {
+		nodes[t.Namespace+"/"+t.Name] = t.Status.SrcIp
+		for _, l := range t.Spec.Links {
+			d := domain{t.Namespace, l.UID}
+			members[d] = append(members[d], t)
+			if _, ok := roots[d]; !ok && len(l.FanoutPeers) > 0 {
+				roots[d] = t
+			}
+		}
+	}
+
+	result := make(map[string]broadcastGroup)
+	for _, t := range topologies {
+		if t.Status.SrcIp != hostIP || t.Status.NetNs == "" {
+			continue
+		}
+		for _, l := range t.Spec.Links {
+			// only the links to another node are vxlans
+			peerNode := nodes[t.Namespace+"/"+l.PeerPod]
+			if l.PeerPod == localhost || peerNode == "" || peerNode == hostIP {
+				continue
+			}
+			d := domain{t.Namespace, l.UID}
+			vteps := make(map[string]bool)
+			root, fanout := roots[d]
+			switch {
+			case fanout && root.Name == t.Name:
+				for _, vtep := range fanoutVTEPs(l, t.Namespace, nodes, peerNode, hostIP) {
+					vteps[vtep] = true
+				}
+			case fanout:
+				if root.Status.SrcIp != "" && root.Status.SrcIp != hostIP {
+					vteps[root.Status.SrcIp] = true
+				}
+			default:
+				for _, member := range members[d] {
+					if member.Name == t.Name || member.Status.SrcIp == "" || member.Status.SrcIp == hostIP {
+						continue
+					}
+					vteps[member.Status.SrcIp] = true
+				}
+			}
+			group := broadcastGroup{netNs: t.Status.NetNs, intf: l.LocalIntf}
+			for vtep := range vteps {
+				group.vteps = append(group.vteps, vtep)
+			}
+			sort.Strings(group.vteps)
+			result[flapKey(t.Namespace, t.Name, int64(l.UID))] = group
+		}
+	}
+	return result
+}
+
+// fanoutVTEPs returns the nodes the end of a link with fanout peers floods frames to, those of
+// its peer and of the fanout peers running on other nodes. With fail_on_any_error, it only
+// floods to its peer until all the fanout peers are running.
+func fanoutVTEPs(l topologyv1.Link, ns string, nodes map[string]string, peerNode, hostIP string) []string {
+	vteps := []string{peerNode}
+	for _, peer := range l.FanoutPeers {
+		node := nodes[ns+"/"+peer]
+		if node == "" {
+			if l.FanoutMode == fanoutFailOnAnyError {
+				return []string{peerNode}
+			}
+			continue
+		}
+		if node != hostIP {
+			vteps = append(vteps, node)
+		}
+	}
+	return vteps
+}
+
+func sameVTEPs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// syncBroadcast sets the broadcast groups of the VXLAN ends of this node. Ends with a single
+// VTEP are point-to-point links, which are left alone unless they used to have a bigger group.
+func (m *Meshnet) syncBroadcast(ctx context.Context) error {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	groups := broadcastGroups(topologies.Items, os.Getenv("HOST_IP"))
+
+	m.broadcast.mu.Lock()
+	defer m.broadcast.mu.Unlock()
+	for key := range m.broadcast.applied {
+		if _, ok := groups[key]; !ok {
+			delete(m.broadcast.applied, key)
+		}
+	}
+	for key, g := range groups {
+		applied, ok := m.broadcast.applied[key]
+		if (!ok && len(g.vteps) < 2) || (ok && sameVTEPs(applied, g.vteps)) {
+			continue
+		}
+		var vteps []net.IP
+		for _, vtep := range g.vteps {
+			vteps = append(vteps, net.ParseIP(vtep))
+		}
+		if err := vxlan.SetBroadcastGroup(g.netNs, g.intf, vteps); err != nil {
+			m.wireLog.Warnf(key+"/broadcast", "Failed to set the broadcast group of link %s: %s", key, err)
+			continue
+		}
+		log.Infof("Link %s floods frames to %v", key, g.vteps)
+		if len(g.vteps) < 2 {
+			delete(m.broadcast.applied, key)
+		} else {
+			m.broadcast.applied[key] = g.vteps
+		}
+	}
+	return nil
+}
+
+// BroadcastGroups keeps the broadcast groups of the VXLAN ends of this node up to date with
+// the topologies until stopCh is closed.
+func (m *Meshnet) BroadcastGroups(stopCh <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		if err := m.syncBroadcast(ctx); err != nil {
+			log.Warnf("Failed to set the broadcast groups: %s", err)
+		}
+		w, err := m.tClient.Topology("").Watch(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Warnf("Failed to watch the topologies: %s", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(broadcastRetryInterval):
+			}
+			continue
+		}
+		m.watchBroadcast(ctx, w.ResultChan(), stopCh)
+		w.Stop()
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+	}
+}
+
+// watchBroadcast syncs the broadcast groups on every topology change, until the watch ends
+// or stopCh is closed
+func (m *Meshnet) watchBroadcast(ctx context.Context, events <-chan watch.Event, stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := m.syncBroadcast(ctx); err != nil {
+				log.Warnf("Failed to set the broadcast groups: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package meshnet
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func TestBroadcastGroups(t *testing.T) {
+	topology := func(name, node string, links map[int]string) topologyv1.Topology {
+		t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "lab"}}
+		t.Status.SrcIp = node
+		if node != "" {
+			t.Status.NetNs = "/var/run/netns/" + name
+		}
+		for uid, peer := range links {
+			t.Spec.Links = append(t.Spec.Links, topologyv1.Link{UID: uid, PeerPod: peer, LocalIntf: "eth1"})
+		}
+		return t
+	}
+	// r1, r2, r3 and r4 share the LAN 10, r1 and r5 have a point-to-point link 20
+	topologies := []topologyv1.Topology{
+		topology("r1", "10.0.0.1", map[int]string{10: "r2", 20: "r5"}),
+		topology("r2", "10.0.0.2", map[int]string{10: "r1"}),
+		topology("r3", "10.0.0.3", map[int]string{10: "r1"}),
+		topology("r4", "10.0.0.3", map[int]string{10: "r1"}),
+		topology("r5", "10.0.0.2", map[int]string{20: "r1"}),
+		topology("r6", "", map[int]string{10: "r1"}),
+		topology("r7", "10.0.0.1", map[int]string{10: "r1", 30: "localhost"}),
+	}
+	got := broadcastGroups(topologies, "10.0.0.1")
+	want := map[string][]string{
+		"lab/r1/10": {"10.0.0.2", "10.0.0.3"},
+		"lab/r1/20": {"10.0.0.2"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("broadcastGroups() = %v, want %v", got, want)
+	}
+	for key, vteps := range want {
+		if g, ok := got[key]; !ok || !sameVTEPs(g.vteps, vteps) || g.intf != "eth1" {
+			t.Errorf("broadcast group of %s = %+v, want %v", key, g, vteps)
+		}
+	}
+}
+
+func TestFanoutGroups(t *testing.T) {
+	topology := func(name, node string, link topologyv1.Link) topologyv1.Topology {
+		t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "lab"}}
+		t.Status.SrcIp = node
+		if node != "" {
+			t.Status.NetNs = "/var/run/netns/" + name
+		}
+		link.UID, link.LocalIntf = 40, "eth1"
+		t.Spec.Links = []topologyv1.Link{link}
+		return t
+	}
+	// r1 fans out to r3 and r4 besides r2, r5 only sends to r1
+	topologies := func(mode string) []topologyv1.Topology {
+		return []topologyv1.Topology{
+			topology("r1", "10.0.0.1", topologyv1.Link{PeerPod: "r2", FanoutPeers: []string{"r3", "r4"}, FanoutMode: mode}),
+			topology("r2", "10.0.0.2", topologyv1.Link{PeerPod: "r1"}),
+			topology("r3", "10.0.0.3", topologyv1.Link{PeerPod: "r1"}),
+			topology("r4", "", topologyv1.Link{PeerPod: "r1"}),
+			topology("r5", "10.0.0.4", topologyv1.Link{PeerPod: "r1"}),
+		}
+	}
+	tests := []struct {
+		desc   string
+		mode   string
+		hostIP string
+		key    string
+		want   []string
+	}{
+		{desc: "sender", mode: fanoutBestEffort, hostIP: "10.0.0.1", key: "lab/r1/40", want: []string{"10.0.0.2", "10.0.0.3"}},
+		{desc: "peer not running", mode: fanoutFailOnAnyError, hostIP: "10.0.0.1", key: "lab/r1/40", want: []string{"10.0.0.2"}},
+		{desc: "receiver", mode: fanoutBestEffort, hostIP: "10.0.0.3", key: "lab/r3/40", want: []string{"10.0.0.1"}},
+		{desc: "not a fanout peer", mode: fanoutBestEffort, hostIP: "10.0.0.4", key: "lab/r5/40", want: []string{"10.0.0.1"}},
+	}
+	for _, tt := range tests {
+		if g := broadcastGroups(topologies(tt.mode), tt.hostIP)[tt.key]; !sameVTEPs(g.vteps, tt.want) {
+			t.Errorf("%s: broadcast group of %s = %v, want %v", tt.desc, tt.key, g.vteps, tt.want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/util/retry"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/canary"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// canaryState reads the canary deployment in progress from a topology status, if any
+func canaryState(obj *unstructured.Unstructured) *mpb.CanaryState {
+	fraction, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "status", "canary_fraction")
+	if !found {
+		return nil
+	}
+	started, _, _ := unstructured.NestedString(obj.Object, "status", "canary_started")
+	return &mpb.CanaryState{Fraction: number(fraction), Started: started}
+}
+
+// CanaryActivate starts a canary deployment of a topology, setting up only the given
+// fraction of its links, taken by UID order, and tearing down the others.
+func (m *Meshnet) CanaryActivate(ctx context.Context, req *mpb.CanaryRequest) (*mpb.BoolResponse, error) {
+	if err := m.checkCanaryNode(ctx, req.Name, req.KubeNs); err != nil {
+		return m.canaryResponse("CanaryActivate", req.Name, err)
+	}
+	log.Infof("Activating %.0f%% of the links of topology %s", req.Fraction*100, req.Name)
+	err := m.setCanary(ctx, req.Name, req.KubeNs, req.Fraction, time.Now(), func(*mpb.CanaryState) error {
+		return nil
+	})
+	return m.canaryResponse("CanaryActivate", req.Name, err)
+}
+
+// CanaryExpand sets up more links of a canary deployment in progress.
+func (m *Meshnet) CanaryExpand(ctx context.Context, req *mpb.CanaryRequest) (*mpb.BoolResponse, error) {
+	if err := m.checkCanaryNode(ctx, req.Name, req.KubeNs); err != nil {
+		return m.canaryResponse("CanaryExpand", req.Name, err)
+	}
+	log.Infof("Expanding the canary deployment of topology %s to %.0f%%", req.Name, req.Fraction*100)
+	err := m.setCanary(ctx, req.Name, req.KubeNs, req.Fraction, time.Time{}, func(current *mpb.CanaryState) error {
+		if current == nil {
+			return fmt.Errorf("topology %s has no canary deployment in progress", req.Name)
+		}
+		if req.Fraction < current.Fraction {
+			return fmt.Errorf("fraction %v is lower than the current one %v", req.Fraction, current.Fraction)
+		}
+		return nil
+	})
+	return m.canaryResponse("CanaryExpand", req.Name, err)
+}
+
+// CanaryCommit sets up all the links of a topology and ends its canary deployment.
+func (m *Meshnet) CanaryCommit(ctx context.Context, req *mpb.CanaryRequest) (*mpb.BoolResponse, error) {
+	if err := m.checkCanaryNode(ctx, req.Name, req.KubeNs); err != nil {
+		return m.canaryResponse("CanaryCommit", req.Name, err)
+	}
+	log.Infof("Committing the canary deployment of topology %s", req.Name)
+	err := m.endCanary(ctx, req.Name, req.KubeNs)
+	if err == nil {
+		err = m.applyCanary(ctx, req.Name, req.KubeNs)
+	}
+	return m.canaryResponse("CanaryCommit", req.Name, err)
+}
+
+// checkCanaryNode fails with FailedPrecondition if the pod of topology name is running on
+// another node, whose daemon is the one that can set up and tear down its links, before the
+// canary state is changed
+func (m *Meshnet) checkCanaryNode(ctx context.Context, name, ns string) error {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: name, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	return canaryNode(pod)
+}
+
+// canaryNode fails with FailedPrecondition if pod is running on another node
+func canaryNode(pod *mpb.Pod) error {
+	if pod.NetNs != "" && pod.SrcIp != os.Getenv("HOST_IP") {
+		return status.Errorf(codes.FailedPrecondition, "pod %s is running on node %s, not on this node", pod.Name, pod.SrcIp)
+	}
+	return nil
+}
+
+func (m *Meshnet) canaryResponse(function, name string, err error) (*mpb.BoolResponse, error) {
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"function": function,
+		}).Errorf("Failed to update the canary deployment of topology %s", name)
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// setCanary records the canary state in the topology status, once check has accepted
+// the current one, and sets up or tears down the links accordingly. A zero started
+// time keeps the current one.
+func (m *Meshnet) setCanary(ctx context.Context, name, ns string, fraction float64, started time.Time, check func(*mpb.CanaryState) error) error {
+	if fraction < 0 || fraction > 1 {
+		return fmt.Errorf("fraction must be between 0 and 1, got %v", fraction)
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.getPod(ctx, name, ns)
+		if err != nil {
+			return err
+		}
+		if err := check(canaryState(obj)); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj.Object, fraction, "status", "canary_fraction"); err != nil {
+			return err
+		}
+		if !started.IsZero() {
+			if err := unstructured.SetNestedField(obj.Object, started.UTC().Format(time.RFC3339), "status", "canary_started"); err != nil {
+				return err
+			}
+		}
+		return m.updateStatus(ctx, obj, ns)
+	})
+	if err != nil {
+		return err
+	}
+	return m.applyCanary(ctx, name, ns)
+}
+
+func (m *Meshnet) endCanary(ctx context.Context, name, ns string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.getPod(ctx, name, ns)
+		if err != nil {
+			return err
+		}
+		unstructured.RemoveNestedField(obj.Object, "status", "canary_fraction")
+		unstructured.RemoveNestedField(obj.Object, "status", "canary_started")
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
+
+// applyCanary sets up the active links of a running pod that are missing and tears down the
+// local end of the inactive ones. Links of pods that aren't running are left to the CNI plugin.
+func (m *Meshnet) applyCanary(ctx context.Context, name, ns string) error {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: name, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	if err := canaryNode(pod); err != nil || pod.NetNs == "" {
+		return err
+	}
+	active := canary.Active(pod.Links, pod.Canary)
+	for _, link := range pod.Links {
+		exists := hasIntf(pod.NetNs, link.LocalIntf)
+		switch {
+		case active[link.Uid] && !exists:
+			if err := m.createWire(ctx, name, ns, link); err != nil {
+				return err
+			}
+		case !active[link.Uid] && exists:
+			if err := removeIntf(pod.NetNs, link, pod.OvsBridge); err != nil {
+				return fmt.Errorf("failed to remove interface %s of pod %s: %s", link.LocalIntf, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CanaryTimeouts rolls back the canary deployments of the pods on this node that haven't
+// been committed within timeout, by tearing down all of their links, until stopCh is closed.
+func (m *Meshnet) CanaryTimeouts(stopCh <-chan struct{}) {
+	timeout := m.config.CanaryTimeout
+	if timeout <= 0 {
+		<-stopCh
+		return
+	}
+	interval := timeout / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			m.expireCanaries(context.Background(), timeout)
+		}
+	}
+}
+
+func (m *Meshnet) expireCanaries(ctx context.Context, timeout time.Duration) {
+	topologies, err := m.localTopologies(ctx)
+	if err != nil {
+		log.Errorf("Failed to list the topologies of this node: %s", err)
+		return
+	}
+	for _, t := range topologies {
+		if t.Status.CanaryStarted == "" || !canaryExpired(t.Status.CanaryStarted, timeout, time.Now()) {
+			continue
+		}
+		log.Warnf("Canary deployment of topology %s/%s has timed out, rolling it back", t.Namespace, t.Name)
+		// The rolled back state is kept so that the links aren't set up again on a pod restart
+		err := m.setCanary(ctx, t.Name, t.Namespace, 0, time.Time{}, func(*mpb.CanaryState) error { return nil })
+		if err == nil {
+			err = m.clearCanaryStart(ctx, t.Name, t.Namespace)
+		}
+		if err != nil {
+			log.Errorf("Failed to roll back the canary deployment of topology %s/%s: %s", t.Namespace, t.Name, err)
+		}
+	}
+}
+
+// localTopologies returns the topologies of the pods running on this node. Only the namespaces
+// of the K8s pods of the node are listed, or all of them when NODE_NAME isn't set or there's no
+// K8s API.
+func (m *Meshnet) localTopologies(ctx context.Context) ([]topologyv1.Topology, error) {
+	namespaces := []string{metav1.NamespaceAll}
+	if node := os.Getenv("NODE_NAME"); node != "" && m.config.TopologySource == "" {
+		pods, err := m.kClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		namespaces = nil
+		for _, p := range pods.Items {
+			if !seen[p.Namespace] {
+				seen[p.Namespace] = true
+				namespaces = append(namespaces, p.Namespace)
+			}
+		}
+	}
+	hostIP := os.Getenv("HOST_IP")
+	var result []topologyv1.Topology
+	for _, ns := range namespaces {
+		topologies, err := m.tClient.Topology(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range topologies.Items {
+			if t.Status.SrcIp == hostIP {
+				result = append(result, t)
+			}
+		}
+	}
+	return result, nil
+}
+
+// clearCanaryStart stops a rolled back canary deployment from timing out again
+func (m *Meshnet) clearCanaryStart(ctx context.Context, name, ns string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.getPod(ctx, name, ns)
+		if err != nil {
+			return err
+		}
+		unstructured.RemoveNestedField(obj.Object, "status", "canary_started")
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
+
+func canaryExpired(started string, timeout time.Duration, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, started)
+	if err != nil {
+		log.Warnf("Invalid canary start time %q", started)
+		return false
+	}
+	return now.Sub(t) > timeout
+}
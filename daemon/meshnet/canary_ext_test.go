@@ -0,0 +1,39 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestCanaryActivate(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SrcIp, p.NetNs = "10.0.0.2", "/var/run/netns/r1"
+	if _, err := m.SetAlive(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &mpb.CanaryRequest{Name: "r1", KubeNs: "default", Fraction: 0.5}
+	if _, err := m.CanaryActivate(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CanaryActivate() of a pod of another node = %v, want FailedPrecondition", err)
+	}
+	r1 := meshnettest.Store(m).Object("default", "r1")
+	if _, found, _ := unstructured.NestedFieldNoCopy(r1.Object, "status", "canary_fraction"); found {
+		t.Error("CanaryActivate() has recorded the canary state of a pod of another node")
+	}
+	if _, err := m.CanaryCommit(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CanaryCommit() of a pod of another node = %v, want FailedPrecondition", err)
+	}
+}
@@ -0,0 +1,121 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// CaptureWire streams the frames of the end of a link in a pod, captured by the daemon of the
+// node the pod is running on, until the client cancels the call. With a pcap_over_ip_port,
+// that daemon serves them with PCAP-over-IP on this port instead, and the stream only has the
+// address of the server.
+func (m *Meshnet) CaptureWire(req *mpb.CaptureRequest, stream mpb.Local_CaptureWireServer) error {
+	if err := validateCapture(req); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	nodeIP, err := m.linkNode(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return err
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.captureLink(ctx, req, stream.Send)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, req.LinkUid)
+	if err != nil {
+		return err
+	}
+	remote, err := client.CaptureLink(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		p, err := remote.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(p); err != nil {
+			return err
+		}
+	}
+}
+
+// CaptureLink captures the frames of the end of a link in a pod running on this node
+func (m *Meshnet) CaptureLink(req *mpb.CaptureRequest, stream mpb.Remote_CaptureLinkServer) error {
+	if err := validateCapture(req); err != nil {
+		return err
+	}
+	return m.captureLink(stream.Context(), req, stream.Send)
+}
+
+func (m *Meshnet) captureLink(ctx context.Context, req *mpb.CaptureRequest, send func(*mpb.CapturedPacket) error) error {
+	pod, link, err := m.runningLink(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return err
+	}
+	captureError := func(err error) error {
+		return wireError(codes.Internal, &mpb.WireError{WireUid: req.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_PCAP_ERROR},
+			"failed to capture %s in pod %s: %s", link.LocalIntf, req.Pod, err)
+	}
+
+	if req.PcapOverIpPort != 0 {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", req.PcapOverIpPort))
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to listen on port %d: %s", req.PcapOverIpPort, err)
+		}
+		addr := net.JoinHostPort(os.Getenv("HOST_IP"), fmt.Sprint(req.PcapOverIpPort))
+		if err := send(&mpb.CapturedPacket{PcapOverIpAddress: addr}); err != nil {
+			lis.Close()
+			return err
+		}
+		log.Infof("Serving the capture of %s in pod %s with PCAP-over-IP on %s", link.LocalIntf, req.Pod, addr)
+		if err := capture.Serve(ctx, lis, pod.NetNs, link.LocalIntf, req.Filter); err != nil {
+			return captureError(err)
+		}
+		return nil
+	}
+
+	filter, err := capture.ParseFilter(req.Filter)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid capture filter: %s", err)
+	}
+	s, err := capture.Open(pod.NetNs, link.LocalIntf)
+	if err != nil {
+		return captureError(err)
+	}
+	defer s.Close()
+	log.Infof("Capturing %s in pod %s", link.LocalIntf, req.Pod)
+	var sendErr error
+	err = capture.Capture(ctx, s, filter, capture.DefaultSnaplen, func(t time.Time, data []byte, length int) error {
+		sendErr = send(&mpb.CapturedPacket{Timestamp: t.UnixNano(), Data: data, Length: int64(length)})
+		return sendErr
+	})
+	if err != nil && err != sendErr {
+		return captureError(err)
+	}
+	return err
+}
+
+func validateCapture(req *mpb.CaptureRequest) error {
+	if req.PcapOverIpPort < 0 || req.PcapOverIpPort > 65535 {
+		return status.Errorf(codes.InvalidArgument, "invalid PCAP-over-IP port %d", req.PcapOverIpPort)
+	}
+	if _, err := capture.ParseFilter(req.Filter); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid capture filter: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package meshnet_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestCaptureWire(t *testing.T) {
+	m := meshnettest.NewFakeMeshnet(lab())
+	for _, req := range []*mpb.CaptureRequest{
+		{Pod: "r1", KubeNs: "default", LinkUid: 1, Filter: "host 10.0.0.1"},
+		{Pod: "r1", KubeNs: "default", LinkUid: 1, PcapOverIpPort: 70000},
+	} {
+		if err := m.CaptureWire(req, nil); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CaptureWire(%v) = %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+// captureStream collects the frames of a capture until its context is done
+type captureStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	packets chan *mpb.CapturedPacket
+}
+
+func (s *captureStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *captureStream) Send(p *mpb.CapturedPacket) error {
+	select {
+	case s.packets <- p:
+	case <-s.ctx.Done():
+	}
+	return nil
+}
+
+func TestCaptureWireFrames(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	m := meshnettest.NewFakeMeshnet(lab())
+	netNs := runningPod(t, m, "r1", "eth1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &captureStream{ctx: ctx, packets: make(chan *mpb.CapturedPacket, 16)}
+	captured := make(chan error, 1)
+	go func() {
+		captured <- m.CaptureWire(&mpb.CaptureRequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Filter: "udp and port 7000"}, stream)
+	}()
+
+	injector, err := capture.OpenInjector(netNs.Path(), "peer1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer injector.Close()
+	frame := udpFrame(7000)
+	// the frames sent before the capture has started aren't captured
+	var got *mpb.CapturedPacket
+	for deadline := time.Now().Add(2 * time.Second); got == nil && time.Now().Before(deadline); {
+		for _, f := range [][]byte{udpFrame(7001), frame} {
+			if err := injector.Send(f); err != nil {
+				t.Fatal(err)
+			}
+		}
+		select {
+		case got = <-stream.packets:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	cancel()
+	if got == nil {
+		t.Fatalf("no frame captured on eth1")
+	}
+	if !bytes.Equal(got.Data, frame) || got.Length != int64(len(frame)) {
+		t.Errorf("captured % x of %d bytes, want % x of %d", got.Data, got.Length, frame, len(frame))
+	}
+	if at := time.Unix(0, got.Timestamp); time.Since(at) > time.Minute {
+		t.Errorf("frame captured at %v", at)
+	}
+	if err := <-captured; err != nil {
+		t.Errorf("CaptureWire() = %v once cancelled", err)
+	}
+}
@@ -0,0 +1,295 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const chaosSyncInterval = 10 * time.Second
+
+var chaosProfileResource = schema.GroupResource{Group: topologyv1.GroupName, Resource: "chaosprofiles"}
+
+// chaosWire is the end of a wire on this node impaired by a chaos profile
+type chaosWire struct {
+	// namespace and name of the profile
+	profile     string
+	netNs, intf string
+	impairment  *mpb.ImpairmentSpec
+	// the link's own impairments, the egress one is restored when the profile is removed
+	egress, ingress *mpb.ImpairmentSpec
+}
+
+// chaosState keeps the wire ends impaired by chaos profiles, keyed by namespace, pod and link UID
+type chaosState struct {
+	mu      sync.Mutex
+	applied map[string]*chaosWire
+}
+
+func newChaosState() *chaosState {
+	return &chaosState{applied: make(map[string]*chaosWire)}
+}
+
+func impairmentFromCR(i topologyv1.Impairment) *mpb.ImpairmentSpec {
+	spec := &mpb.ImpairmentSpec{
+		LatencyMs:        i.LatencyMs,
+		JitterMs:         i.JitterMs,
+		LossPercent:      i.LossPercent,
+		DuplicatePercent: i.DuplicatePercent,
+		CorruptPercent:   i.CorruptPercent,
+		EcnEnabled:       i.EcnEnabled,
+
+		EcnThresholdPercent: i.EcnThresholdPercent,
+
+		ReorderPercent:            i.ReorderPercent,
+		ReorderCorrelationPercent: i.ReorderCorrelationPercent,
+		ReorderGap:                i.ReorderGap,
+	}
+	if impairment.IsEmpty(spec) {
+		return nil
+	}
+	return spec
+}
+
+func impairmentToCR(spec *mpb.ImpairmentSpec) topologyv1.Impairment {
+	return topologyv1.Impairment{
+		LatencyMs:        spec.GetLatencyMs(),
+		JitterMs:         spec.GetJitterMs(),
+		LossPercent:      spec.GetLossPercent(),
+		DuplicatePercent: spec.GetDuplicatePercent(),
+		CorruptPercent:   spec.GetCorruptPercent(),
+		EcnEnabled:       spec.GetEcnEnabled(),
+
+		EcnThresholdPercent: spec.GetEcnThresholdPercent(),
+
+		ReorderPercent:            spec.GetReorderPercent(),
+		ReorderCorrelationPercent: spec.GetReorderCorrelationPercent(),
+		ReorderGap:                spec.GetReorderGap(),
+	}
+}
+
+// chaosMatch returns true if the profile impairs the wire between pods with labels a and b
+func chaosMatch(spec topologyv1.ChaosProfileSpec, a, b labels.Set) bool {
+	selector := labels.SelectorFromSet(spec.Selector)
+	matches := func(x, y labels.Set) bool {
+		return selector.Matches(x) && (len(spec.PeerSelector) == 0 || labels.SelectorFromSet(spec.PeerSelector).Matches(y))
+	}
+	return matches(a, b) || matches(b, a)
+}
+
+// chaosWires returns the ends of the wires of the pods running on hostIP that are impaired by
+// profiles, keyed by namespace, pod and link UID. podLabels has the labels of the pods keyed by
+// namespace and name. A wire matching several profiles gets the one whose name sorts first.
+func chaosWires(profiles []topologyv1.ChaosProfile, topologies []topologyv1.Topology, podLabels map[string]labels.Set, hostIP string) map[string]*chaosWire {
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].Namespace != profiles[j].Namespace {
+			return profiles[i].Namespace < profiles[j].Namespace
+		}
+		return profiles[i].Name < profiles[j].Name
+	})
+	result := make(map[string]*chaosWire)
+	for _, p := range profiles {
+		// an empty selector would impair every wire of the namespace
+		if len(p.Spec.Selector) == 0 {
+			log.Warnf("Ignoring chaos profile %s/%s without a selector", p.Namespace, p.Name)
+			continue
+		}
+		spec := impairmentFromCR(p.Spec.Impairment)
+		if spec == nil {
+			continue
+		}
+		for _, t := range topologies {
+			if t.Namespace != p.Namespace || t.Status.SrcIp != hostIP || t.Status.NetNs == "" {
+				continue
+			}
+			for _, l := range t.Spec.Links {
+				key := flapKey(t.Namespace, t.Name, int64(l.UID))
+				if _, ok := result[key]; ok {
+					continue
+				}
+				var peer labels.Set
+				if l.PeerPod != localhost {
+					peer = podLabels[t.Namespace+"/"+l.PeerPod]
+				}
+				if !chaosMatch(p.Spec, podLabels[t.Namespace+"/"+t.Name], peer) {
+					continue
+				}
+				result[key] = &chaosWire{
+					profile:    p.Namespace + "/" + p.Name,
+					netNs:      t.Status.NetNs,
+					intf:       l.LocalIntf,
+					impairment: spec,
+					egress:     impairmentFromCR(l.EgressImpairment),
+					ingress:    impairmentFromCR(l.IngressImpairment),
+				}
+			}
+		}
+	}
+	return result
+}
+
+// syncChaos applies the chaos profiles to the wires of this node and restores the wires
+// that are no longer impaired by any
+func (m *Meshnet) syncChaos(ctx context.Context) error {
+	profiles, err := m.tClient.ChaosProfile("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	podLabels := make(map[string]labels.Set)
+	listed := make(map[string]bool)
+	for _, p := range profiles.Items {
+		if listed[p.Namespace] {
+			continue
+		}
+		listed[p.Namespace] = true
+		pods, err := m.kClient.CoreV1().Pods(p.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods.Items {
+			podLabels[pod.Namespace+"/"+pod.Name] = labels.Set(pod.Labels)
+		}
+	}
+	want := chaosWires(profiles.Items, topologies.Items, podLabels, os.Getenv("HOST_IP"))
+
+	m.chaos.mu.Lock()
+	defer m.chaos.mu.Unlock()
+	for key, w := range want {
+		if cur, ok := m.chaos.applied[key]; ok && cur.profile == w.profile && cur.netNs == w.netNs && proto.Equal(cur.impairment, w.impairment) {
+			continue
+		}
+		if err := impairment.Apply(w.netNs, w.intf, w.impairment, w.ingress); err != nil {
+			m.wireLog.Warnf(key+"/chaos", "Failed to apply chaos profile %s to %s: %s", w.profile, key, err)
+			continue
+		}
+		log.Infof("Applied chaos profile %s to link %s", w.profile, key)
+		m.chaos.applied[key] = w
+	}
+	for key, w := range m.chaos.applied {
+		if _, ok := want[key]; ok {
+			continue
+		}
+		if _, err := os.Stat(w.netNs); err == nil {
+			if err := impairment.Apply(w.netNs, w.intf, w.egress, w.ingress); err != nil {
+				m.wireLog.Warnf(key+"/chaos", "Failed to remove chaos profile %s from %s: %s", w.profile, key, err)
+				continue
+			}
+			log.Infof("Removed chaos profile %s from link %s", w.profile, key)
+		}
+		delete(m.chaos.applied, key)
+	}
+	return nil
+}
+
+func (m *Meshnet) chaosEnabled() error {
+	if !m.config.ChaosProfiles {
+		return status.Error(codes.FailedPrecondition, "chaos profiles are disabled, restart meshnetd with -chaos-profiles-enabled")
+	}
+	return nil
+}
+
+// ApplyChaosProfile stores a chaos profile, which every daemon applies to the matching wires
+// of its node. The wires of this node are impaired before it returns.
+func (m *Meshnet) ApplyChaosProfile(ctx context.Context, p *mpb.ChaosProfile) (*mpb.BoolResponse, error) {
+	if err := m.chaosEnabled(); err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	if p.Name == "" || len(p.Selector) == 0 {
+		return &mpb.BoolResponse{Response: false}, status.Error(codes.InvalidArgument, "a chaos profile needs a name and a selector")
+	}
+	if impairment.IsEmpty(p.Impairment) {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.InvalidArgument, "chaos profile %s has no impairment", p.Name)
+	}
+	if err := impairment.Validate(p.Impairment); err != nil {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.InvalidArgument, "invalid impairment of chaos profile %s: %s", p.Name, err)
+	}
+	spec := topologyv1.ChaosProfileSpec{
+		Selector:     p.Selector,
+		PeerSelector: p.PeerSelector,
+		Impairment:   impairmentToCR(p.Impairment),
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		profiles := m.tClient.ChaosProfile(p.KubeNs)
+		current, err := profiles.Get(ctx, p.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = profiles.Create(ctx, &topologyv1.ChaosProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: p.KubeNs},
+				Spec:       spec,
+			})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(chaosProfileResource, p.Name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		current.Spec = spec
+		_, err = profiles.Update(ctx, current)
+		return err
+	})
+	if err != nil {
+		return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_NONE, "failed to store chaos profile %s", p.Name)
+	}
+	log.Infof("Stored chaos profile %s/%s", p.KubeNs, p.Name)
+	if err := m.syncChaos(ctx); err != nil {
+		log.Warnf("Failed to apply chaos profiles: %s", err)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// RemoveChaosProfile deletes a chaos profile, the wires of this node it impaired are restored
+// before it returns.
+func (m *Meshnet) RemoveChaosProfile(ctx context.Context, ref *mpb.ChaosProfileRef) (*mpb.BoolResponse, error) {
+	if err := m.chaosEnabled(); err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	if err := m.tClient.ChaosProfile(ref.KubeNs).Delete(ctx, ref.Name, metav1.DeleteOptions{}); err != nil {
+		return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_NONE, "failed to delete chaos profile %s", ref.Name)
+	}
+	log.Infof("Deleted chaos profile %s/%s", ref.KubeNs, ref.Name)
+	if err := m.syncChaos(ctx); err != nil {
+		log.Warnf("Failed to apply chaos profiles: %s", err)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// ChaosProfiles applies the chaos profiles to the wires of this node until stopCh is closed.
+func (m *Meshnet) ChaosProfiles(stopCh <-chan struct{}) {
+	if !m.config.ChaosProfiles {
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(chaosSyncInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.syncChaos(context.Background()); err != nil {
+			log.Warnf("Failed to apply chaos profiles: %s", err)
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestChaosProfile(t *testing.T) {
+	ctx := context.Background()
+	f := meshnettest.NewTopologies(lab()...)
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true, ChaosProfiles: true}, fake.NewSimpleClientset(), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := &mpb.ChaosProfile{
+		Name:         "as1-as2",
+		KubeNs:       "default",
+		Selector:     map[string]string{"as": "1"},
+		PeerSelector: map[string]string{"as": "2"},
+		Impairment:   &mpb.ImpairmentSpec{LatencyMs: 200, LossPercent: 5},
+	}
+	for _, latency := range []int64{200, 100} {
+		profile.Impairment.LatencyMs = latency
+		if resp, err := m.ApplyChaosProfile(ctx, profile); err != nil || !resp.Response {
+			t.Fatalf("ApplyChaosProfile() = %v, %v", resp, err)
+		}
+		stored, err := f.ChaosProfile("default").Get(ctx, "as1-as2", metav1.GetOptions{})
+		if err != nil || stored.Spec.Impairment.LatencyMs != latency || stored.Spec.PeerSelector["as"] != "2" {
+			t.Errorf("stored profile = %v, %v, want %dms", stored, err, latency)
+		}
+	}
+
+	for _, invalid := range []*mpb.ChaosProfile{
+		{Name: "no-selector", KubeNs: "default", Impairment: &mpb.ImpairmentSpec{LatencyMs: 1}},
+		{Name: "no-impairment", KubeNs: "default", Selector: map[string]string{"as": "1"}},
+		{Name: "bad-loss", KubeNs: "default", Selector: map[string]string{"as": "1"}, Impairment: &mpb.ImpairmentSpec{LossPercent: 101}},
+	} {
+		if _, err := m.ApplyChaosProfile(ctx, invalid); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ApplyChaosProfile(%s) = %v, want InvalidArgument", invalid.Name, err)
+		}
+	}
+
+	ref := &mpb.ChaosProfileRef{Name: "as1-as2", KubeNs: "default"}
+	if resp, err := m.RemoveChaosProfile(ctx, ref); err != nil || !resp.Response {
+		t.Fatalf("RemoveChaosProfile() = %v, %v", resp, err)
+	}
+	if _, err := m.RemoveChaosProfile(ctx, ref); status.Code(err) != codes.NotFound {
+		t.Errorf("RemoveChaosProfile() of a deleted profile = %v, want NotFound", err)
+	}
+
+	disabled := meshnettest.NewFakeMeshnet(lab())
+	if _, err := disabled.ApplyChaosProfile(ctx, profile); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ApplyChaosProfile() with chaos profiles disabled = %v, want FailedPrecondition", err)
+	}
+}
@@ -0,0 +1,93 @@
+package meshnet
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func TestChaosMatch(t *testing.T) {
+	as1, as2, as3 := labels.Set{"as": "1"}, labels.Set{"as": "2"}, labels.Set{"as": "3"}
+	between := topologyv1.ChaosProfileSpec{Selector: map[string]string{"as": "1"}, PeerSelector: map[string]string{"as": "2"}}
+	all := topologyv1.ChaosProfileSpec{Selector: map[string]string{"as": "1"}}
+	tests := []struct {
+		spec topologyv1.ChaosProfileSpec
+		a, b labels.Set
+		want bool
+	}{
+		{spec: between, a: as1, b: as2, want: true},
+		{spec: between, a: as2, b: as1, want: true},
+		{spec: between, a: as1, b: as3, want: false},
+		{spec: between, a: as1, b: nil, want: false},
+		{spec: all, a: as3, b: as1, want: true},
+		{spec: all, a: as1, b: nil, want: true},
+		{spec: all, a: as2, b: as3, want: false},
+	}
+	for i, tt := range tests {
+		if got := chaosMatch(tt.spec, tt.a, tt.b); got != tt.want {
+			t.Errorf("#%d test failed: chaosMatch(%v, %v) = %v, want %v", i, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestChaosWires(t *testing.T) {
+	topology := func(name, node string, peers ...string) topologyv1.Topology {
+		t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "lab"}}
+		t.Status.SrcIp, t.Status.NetNs = node, "/var/run/netns/"+name
+		for i, peer := range peers {
+			t.Spec.Links = append(t.Spec.Links, topologyv1.Link{UID: i + 1, PeerPod: peer, LocalIntf: "eth1"})
+		}
+		return t
+	}
+	profile := func(name string, latency int64, selector, peerSelector map[string]string) topologyv1.ChaosProfile {
+		return topologyv1.ChaosProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "lab"},
+			Spec: topologyv1.ChaosProfileSpec{
+				Selector:     selector,
+				PeerSelector: peerSelector,
+				Impairment:   topologyv1.Impairment{LatencyMs: latency},
+			},
+		}
+	}
+	topologies := []topologyv1.Topology{
+		topology("r1", "10.0.0.1", "r2", "r3"),
+		topology("r2", "10.0.0.2", "r1"),
+		topology("r3", "10.0.0.1", "r1"),
+	}
+	podLabels := map[string]labels.Set{
+		"lab/r1": {"as": "1"},
+		"lab/r2": {"as": "2"},
+		"lab/r3": {"as": "1"},
+	}
+	profiles := []topologyv1.ChaosProfile{
+		profile("b-all", 10, map[string]string{"as": "1"}, nil),
+		profile("a-between", 200, map[string]string{"as": "2"}, map[string]string{"as": "1"}),
+		profile("c-empty", 5, nil, nil),
+	}
+
+	got := chaosWires(profiles, topologies, podLabels, "10.0.0.1")
+	want := map[string]struct {
+		profile string
+		latency int64
+	}{
+		"lab/r1/1": {profile: "lab/a-between", latency: 200},
+		"lab/r1/2": {profile: "lab/b-all", latency: 10},
+		"lab/r3/1": {profile: "lab/b-all", latency: 10},
+	}
+	if len(got) != len(want) {
+		t.Errorf("chaosWires() impairs %d wires, want %d", len(got), len(want))
+	}
+	for key, w := range want {
+		g, ok := got[key]
+		if !ok {
+			t.Errorf("wire %s isn't impaired", key)
+			continue
+		}
+		if g.profile != w.profile || g.impairment.LatencyMs != w.latency {
+			t.Errorf("wire %s has profile %s with %dms, want %s with %dms", key, g.profile, g.impairment.LatencyMs, w.profile, w.latency)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/cilium"
+)
+
+const (
+	cniBackendDefault = "default"
+	cniBackendCilium  = "cilium"
+)
+
+// ValidateCNIBackend checks that backend is one of the supported CNI backends
+func ValidateCNIBackend(backend string) error {
+	switch backend {
+	case "", cniBackendDefault, cniBackendCilium:
+		return nil
+	}
+	return fmt.Errorf("unknown CNI backend %q, expected %q or %q", backend, cniBackendDefault, cniBackendCilium)
+}
+
+// ciliumSocket returns the socket of the Cilium agent link interfaces are registered with,
+// or an empty string if meshnet isn't chained after Cilium
+func (m *Meshnet) ciliumSocket() string {
+	if m.config.CNIBackend != cniBackendCilium {
+		return ""
+	}
+	return m.config.CiliumSocket
+}
+
+// registerEndpoint registers the interface intf of a pod with the Cilium agent, if any
+func (m *Meshnet) registerEndpoint(ctx context.Context, ns, pod, netNs, intf string) error {
+	socket := m.ciliumSocket()
+	if socket == "" {
+		return nil
+	}
+	// the sandbox of the pod is recorded in its status by the CNI plugin
+	var containerID string
+	if result, err := m.getPod(ctx, pod, ns); err == nil {
+		containerID, _, _ = unstructured.NestedString(result.Object, "status", "container_id")
+	} else {
+		log.Warnf("Failed to read the container ID of pod %s: %s", pod, err)
+	}
+	return cilium.NewClient(socket).Register(ctx, cilium.Endpoint{
+		KubeNs: ns, Pod: pod, ContainerID: containerID, NetNs: netNs, Intf: intf,
+	})
+}
+
+// deregisterEndpoint removes the interface intf of a pod from the Cilium agent, if any
+func (m *Meshnet) deregisterEndpoint(ctx context.Context, ns, pod, netNs, intf string) error {
+	socket := m.ciliumSocket()
+	if socket == "" {
+		return nil
+	}
+	return cilium.NewClient(socket).Deregister(ctx, cilium.Endpoint{KubeNs: ns, Pod: pod, NetNs: netNs, Intf: intf})
+}
@@ -0,0 +1,137 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// default number of levels of topology references that are resolved
+const defaultRefDepth = 5
+
+// topologyRef is a reference of a topology to another one, whose links it includes
+type topologyRef struct {
+	ns, name string
+}
+
+func (r topologyRef) String() string {
+	return r.ns + "/" + r.name
+}
+
+// refsOf returns the topologies referenced by the topology obj of the namespace ns
+func refsOf(obj *unstructured.Unstructured, ns string) ([]topologyRef, error) {
+	raw, _, err := unstructured.NestedSlice(obj.Object, "spec", "refs")
+	if err != nil {
+		return nil, err
+	}
+	var result []topologyRef
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid reference %v", r)
+		}
+		name, _, _ := unstructured.NestedString(m, "name")
+		if name == "" {
+			return nil, fmt.Errorf("reference without a name")
+		}
+		refNs, _, _ := unstructured.NestedString(m, "namespace")
+		if refNs == "" {
+			refNs = ns
+		}
+		result = append(result, topologyRef{ns: refNs, name: name})
+	}
+	return result, nil
+}
+
+func (m *Meshnet) refDepth() int {
+	if m.config.TopologyRefDepth > 0 {
+		return m.config.TopologyRefDepth
+	}
+	return defaultRefDepth
+}
+
+// composeLinks returns links along with the links of the topologies referenced by obj,
+// recursively. A topology referenced more than once is only included once, while
+// circular references, references deeper than the depth limit and links of different
+// topologies with the same UID are errors.
+func (m *Meshnet) composeLinks(ctx context.Context, obj *unstructured.Unstructured, ns string, links []*mpb.Link) ([]*mpb.Link, error) {
+	root := topologyRef{ns: ns, name: obj.GetName()}
+	owners := make(map[int64]topologyRef, len(links))
+	for _, l := range links {
+		owners[l.Uid] = root
+	}
+	c := &composition{
+		m:        m,
+		path:     map[topologyRef]bool{root: true},
+		included: map[topologyRef]bool{root: true},
+		owners:   owners,
+		links:    links,
+	}
+	if err := c.include(ctx, obj, root, 1); err != nil {
+		return nil, err
+	}
+	return c.links, nil
+}
+
+// composition is the state of the resolution of the references of a topology
+type composition struct {
+	m *Meshnet
+	// topologies being resolved, from the root to the current one
+	path map[topologyRef]bool
+	// topologies whose links have been included
+	included map[topologyRef]bool
+	// topology each link UID comes from
+	owners map[int64]topologyRef
+	links  []*mpb.Link
+}
+
+// include adds the links of the topologies referenced by obj, found at depth
+func (c *composition) include(ctx context.Context, obj *unstructured.Unstructured, from topologyRef, depth int) error {
+	refs, err := refsOf(obj, from.ns)
+	if err != nil {
+		return wireError(codes.InvalidArgument, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR}, "topology %s: %s", from, err)
+	}
+	for _, ref := range refs {
+		if c.path[ref] {
+			return wireError(codes.FailedPrecondition, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR}, "circular reference from topology %s to %s", from, ref)
+		}
+		if c.included[ref] {
+			continue
+		}
+		if depth > c.m.refDepth() {
+			return wireError(codes.FailedPrecondition, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR},
+				"reference from topology %s to %s is deeper than %d levels", from, ref, c.m.refDepth())
+		}
+		refObj, err := c.m.tClient.Topology(ref.ns).Unstructured(ctx, ref.name, metav1.GetOptions{})
+		if err != nil {
+			return k8sError(err, mpb.WireError_NONE, "failed to read topology %s referenced by %s", ref, from)
+		}
+		raw, _, _ := unstructured.NestedSlice(refObj.Object, "spec", "links")
+		links, err := parseLinks(raw)
+		if err != nil {
+			return wireError(codes.InvalidArgument, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR}, "topology %s: %s", ref, err)
+		}
+		for _, l := range links {
+			if owner, ok := c.owners[l.Uid]; ok {
+				return wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: l.Uid, Cause: mpb.WireError_K8S_API_ERROR},
+					"link UID %d of topology %s conflicts with topology %s", l.Uid, ref, owner)
+			}
+			c.owners[l.Uid] = ref
+			c.links = append(c.links, l)
+		}
+		c.included[ref] = true
+
+		c.path[ref] = true
+		err = c.include(ctx, refObj, ref, depth+1)
+		delete(c.path, ref)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestTopologyRefs(t *testing.T) {
+	ctx := context.Background()
+	withRefs := func(obj unstructured.Unstructured, refs ...string) unstructured.Unstructured {
+		var raw []interface{}
+		for _, r := range refs {
+			raw = append(raw, map[string]interface{}{"name": r})
+		}
+		if err := unstructured.SetNestedSlice(obj.Object, raw, "spec", "refs"); err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+	topologies := meshnettest.NewTopologies(
+		withRefs(meshnettest.Topology("default", "exp", []string{"r2"}, []int64{1}), "spine", "leaf"),
+		withRefs(meshnettest.Topology("default", "spine", []string{"leaf1", "leaf2"}, []int64{10, 11}), "leaf"),
+		meshnettest.Topology("default", "leaf", []string{"spine"}, []int64{20}),
+		withRefs(meshnettest.Topology("default", "a", []string{"r2"}, []int64{30}), "b"),
+		withRefs(meshnettest.Topology("default", "b", []string{"r2"}, []int64{31}), "a"),
+		withRefs(meshnettest.Topology("default", "clash", []string{"r2"}, []int64{10}), "spine"),
+		withRefs(meshnettest.Topology("default", "missing", []string{"r2"}, []int64{40}), "nope"),
+	)
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true, TopologyRefDepth: 2}, fake.NewSimpleClientset(), topologies)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "exp", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("Get() of a composed topology failed: %v", err)
+	}
+	var uids []int64
+	for _, l := range p.Links {
+		uids = append(uids, l.Uid)
+	}
+	if len(uids) != 4 || uids[0] != 1 || uids[1] != 10 || uids[2] != 11 || uids[3] != 20 {
+		t.Errorf("links of the composed topology = %v, want [1 10 11 20]", uids)
+	}
+
+	tests := []struct {
+		pod  string
+		code codes.Code
+	}{
+		{"a", codes.FailedPrecondition},
+		{"clash", codes.FailedPrecondition},
+		{"missing", codes.NotFound},
+	}
+	for _, tt := range tests {
+		if _, err := m.Get(ctx, &mpb.PodQuery{Name: tt.pod, KubeNs: "default"}); status.Code(err) != tt.code {
+			t.Errorf("Get(%s) = %v, want %s", tt.pod, err, tt.code)
+		}
+	}
+
+	shallow, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true, TopologyRefDepth: 1}, fake.NewSimpleClientset(), topologies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shallow.Get(ctx, &mpb.PodQuery{Name: "spine", KubeNs: "default"}); err != nil {
+		t.Errorf("Get(spine) with a depth of 1 failed: %v", err)
+	}
+	// exp references leaf through spine as well
+	if _, err := shallow.Get(ctx, &mpb.PodQuery{Name: "exp", KubeNs: "default"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Get(exp) with a depth of 1 = %v, want %s", err, codes.FailedPrecondition)
+	}
+}
@@ -0,0 +1,261 @@
+package meshnet
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const reasonConvergenceSLA = "ConvergenceSLAExceeded"
+
+// convergencePod is what the convergence of a namespace is measured from for one of its pods
+type convergencePod struct {
+	name string
+	// zero if the pod isn't alive
+	aliveAt time.Time
+	links   []*mpb.Link
+	audit   []topologyv1.WireAudit
+}
+
+// convergenceWire is a link between alive pods
+type convergenceWire struct {
+	uid  int64
+	pods []string
+	// when the last of its pods has been set alive, and when it has been audited up since,
+	// zero if it hasn't
+	start, up time.Time
+	upPod     string
+	wireType  string
+}
+
+// convergence measures the convergence of the pods of a namespace from the times they have
+// been set alive and the audit of their wires. Links to pods that aren't alive aren't
+// expected to be up, and audit entries older than the pods of a wire are ignored.
+func convergence(pods []convergencePod) *mpb.ConvergenceMetrics {
+	alive := make(map[string]*convergencePod)
+	var firstAlive time.Time
+	for i := range pods {
+		p := &pods[i]
+		if p.aliveAt.IsZero() {
+			continue
+		}
+		alive[p.name] = p
+		if firstAlive.IsZero() || p.aliveAt.Before(firstAlive) {
+			firstAlive = p.aliveAt
+		}
+	}
+	result := &mpb.ConvergenceMetrics{}
+	if firstAlive.IsZero() {
+		return result
+	}
+	result.FirstAlive = firstAlive.Format(time.RFC3339Nano)
+
+	wires := make(map[int64]*convergenceWire)
+	var uids []int64
+	for _, p := range pods {
+		if alive[p.name] == nil {
+			continue
+		}
+		for _, l := range p.links {
+			if l.PeerPod != localhost && alive[l.PeerPod] == nil {
+				continue
+			}
+			w := wires[l.Uid]
+			if w == nil {
+				w = &convergenceWire{uid: l.Uid}
+				wires[l.Uid] = w
+				uids = append(uids, l.Uid)
+			}
+			w.pods = append(w.pods, p.name)
+			if p.aliveAt.After(w.start) {
+				w.start = p.aliveAt
+			}
+		}
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+
+	// wires up by pod, in the order they have come up
+	byPod := make(map[string][]*convergenceWire)
+	byType := make(map[string]*mpb.WireTypeConvergence)
+	var last *convergenceWire
+	for _, uid := range uids {
+		w := wires[uid]
+		for _, name := range w.pods {
+			for _, a := range alive[name].audit {
+				if a.LinkUID != uid {
+					continue
+				}
+				t, err := time.Parse(time.RFC3339Nano, a.CreatedAt)
+				if err != nil || t.Before(w.start) || !t.After(w.up) {
+					continue
+				}
+				w.up, w.upPod, w.wireType = t, name, a.WireType
+			}
+		}
+		if w.up.IsZero() {
+			result.PendingWires++
+			continue
+		}
+		result.Wires++
+		for _, name := range w.pods {
+			byPod[name] = append(byPod[name], w)
+		}
+		tc := byType[w.wireType]
+		if tc == nil {
+			tc = &mpb.WireTypeConvergence{WireType: w.wireType}
+			byType[w.wireType] = tc
+			result.WireTypes = append(result.WireTypes, tc)
+		}
+		tc.Wires++
+		if setup := w.up.Sub(w.start).Milliseconds(); setup > tc.MaxSetupMs {
+			tc.MaxSetupMs = setup
+		}
+		if last == nil || w.up.After(last.up) {
+			last = w
+		}
+	}
+	sort.Slice(result.WireTypes, func(i, j int) bool { return result.WireTypes[i].WireType < result.WireTypes[j].WireType })
+	if last == nil {
+		return result
+	}
+	result.LastWireUp = last.up.Format(time.RFC3339Nano)
+	result.ConvergenceMs = last.up.Sub(firstAlive).Milliseconds()
+
+	for _, ws := range byPod {
+		sort.Slice(ws, func(i, j int) bool {
+			if !ws[i].up.Equal(ws[j].up) {
+				return ws[i].up.Before(ws[j].up)
+			}
+			return ws[i].uid < ws[j].uid
+		})
+	}
+	// walk back from the last wire up to the wire it has waited for, the one that has come up
+	// last before it in one of its pods
+	path := []*convergenceWire{last}
+	for w := last; ; {
+		var pred *convergenceWire
+		for _, name := range w.pods {
+			ws := byPod[name]
+			for i := range ws {
+				if ws[i] == w && i > 0 && (pred == nil || ws[i-1].up.After(pred.up)) {
+					pred = ws[i-1]
+				}
+			}
+		}
+		if pred == nil {
+			break
+		}
+		path = append([]*convergenceWire{pred}, path...)
+		w = pred
+	}
+	for i, w := range path {
+		from := w.start
+		if i > 0 && path[i-1].up.After(from) {
+			from = path[i-1].up
+		}
+		setup := w.up.Sub(from).Milliseconds()
+		result.CriticalPath = append(result.CriticalPath, &mpb.CriticalWire{
+			Uid:      w.uid,
+			Pod:      w.upPod,
+			WireType: w.wireType,
+			UpAt:     w.up.Format(time.RFC3339Nano),
+			SetupMs:  setup,
+		})
+		result.CriticalPathMs += setup
+	}
+	return result
+}
+
+// MeasureConvergenceTime measures the time from the first pod of a namespace set alive to the
+// last of its wires up, from the audit of the wires. Once the namespace has converged, i.e.
+// the wires between its alive pods are all up, the convergence and its critical path are
+// recorded in the status of its topologies, and an event if it exceeds ConvergenceSLA.
+func (m *Meshnet) MeasureConvergenceTime(ctx context.Context, q *mpb.TopologyQuery) (*mpb.ConvergenceMetrics, error) {
+	topologies, err := m.tClient.Topology(q.KubeNs).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of namespace %s", q.KubeNs)
+	}
+	var pods []convergencePod
+	var names []string
+	for _, t := range topologies.Items {
+		p := convergencePod{name: t.Name, audit: t.Status.WireAudit}
+		if t.Status.AliveAt == "" || t.Status.NetNs == "" {
+			pods = append(pods, p)
+			continue
+		}
+		if p.aliveAt, err = time.Parse(time.RFC3339Nano, t.Status.AliveAt); err != nil {
+			log.Warnf("Ignoring the invalid alive_at %q of pod %s: %s", t.Status.AliveAt, t.Name, err)
+			pods = append(pods, p)
+			continue
+		}
+		pod, err := m.Get(ctx, &mpb.PodQuery{Name: t.Name, KubeNs: q.KubeNs})
+		if err != nil {
+			return nil, err
+		}
+		p.links = pod.Links
+		pods = append(pods, p)
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no pod of namespace %s is alive", q.KubeNs)
+	}
+
+	result := convergence(pods)
+	if result.PendingWires > 0 || result.Wires == 0 {
+		return result, nil
+	}
+	var criticalPath []interface{}
+	for _, w := range result.CriticalPath {
+		criticalPath = append(criticalPath, w.Uid)
+	}
+	for _, name := range names {
+		if err := m.recordConvergence(ctx, q.KubeNs, name, result.ConvergenceMs, criticalPath); err != nil {
+			return nil, k8sError(err, mpb.WireError_UPDATE, "failed to record the convergence of pod %s", name)
+		}
+	}
+	if sla := m.config.ConvergenceSLA; sla > 0 && result.ConvergenceMs > sla.Milliseconds() {
+		result.SlaExceeded = true
+		last := result.CriticalPath[len(result.CriticalPath)-1]
+		log.Warnf("Namespace %s has converged in %dms, over the SLA of %dms", q.KubeNs, result.ConvergenceMs, sla.Milliseconds())
+		topologyEvent(m.dlq.recorder, q.KubeNs, last.Pod, corev1.EventTypeWarning, reasonConvergenceSLA,
+			"namespace %s has converged in %dms, over the SLA of %dms, with wire %d up last", q.KubeNs, result.ConvergenceMs, sla.Milliseconds(), last.Uid)
+	}
+	return result, nil
+}
+
+// recordConvergence sets the last convergence of the status of a topology
+func (m *Meshnet) recordConvergence(ctx context.Context, ns, name string, convergenceMs int64, criticalPath []interface{}) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.tClient.Topology(ns).Unstructured(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj.Object, convergenceMs, "status", "last_convergence_ms"); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedSlice(obj.Object, criticalPath, "status", "last_convergence_critical_path"); err != nil {
+			return err
+		}
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
+
+// autoMeasureConvergence measures the convergence of a namespace once a pod of this node has
+// all its wires up, so that exceeding ConvergenceSLA is recorded without waiting for a call
+// of MeasureConvergenceTime
+func (m *Meshnet) autoMeasureConvergence(ns string) {
+	if _, err := m.MeasureConvergenceTime(context.Background(), &mpb.TopologyQuery{KubeNs: ns}); err != nil {
+		log.Warnf("Failed to measure the convergence of namespace %s: %s", ns, err)
+	}
+}
@@ -0,0 +1,62 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestMeasureConvergenceTime(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+
+	if _, err := m.MeasureConvergenceTime(ctx, &mpb.TopologyQuery{KubeNs: "default"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("MeasureConvergenceTime() without alive pods = %v, want FailedPrecondition", err)
+	}
+	for _, pod := range []string{"r1", "r2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/"+pod
+		if _, err := m.SetAlive(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.AuditWire(ctx, &mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: 1, HowCreated: "CNI_ADD", WireType: "veth"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := m.MeasureConvergenceTime(ctx, &mpb.TopologyQuery{KubeNs: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Wires != 1 || got.PendingWires != 1 {
+		t.Errorf("MeasureConvergenceTime() = %d wires up and %d pending, want 1 and 1", got.Wires, got.PendingWires)
+	}
+
+	if _, err := m.AuditWire(ctx, &mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: 2, HowCreated: "CNI_ADD", WireType: "macvlan"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = m.MeasureConvergenceTime(ctx, &mpb.TopologyQuery{KubeNs: "default"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Wires != 2 || got.PendingWires != 0 || len(got.WireTypes) != 2 {
+		t.Errorf("MeasureConvergenceTime() = %v, want 2 wires of 2 types up", got)
+	}
+	for _, pod := range []string{"r1", "r2"} {
+		r := meshnettest.Store(m).Object("default", pod)
+		path, _, _ := unstructured.NestedSlice(r.Object, "status", "last_convergence_critical_path")
+		if len(path) != len(got.CriticalPath) || len(path) == 0 {
+			t.Errorf("last_convergence_critical_path of %s = %v, want %v", pod, path, got.CriticalPath)
+		}
+		if ms, ok, _ := unstructured.NestedInt64(r.Object, "status", "last_convergence_ms"); !ok || ms != got.ConvergenceMs {
+			t.Errorf("last_convergence_ms of %s = %d, want %d", pod, ms, got.ConvergenceMs)
+		}
+	}
+}
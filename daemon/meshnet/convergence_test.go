@@ -0,0 +1,84 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestConvergence(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+	audit := func(uid int64, ms int, wireType string) topologyv1.WireAudit {
+		return topologyv1.WireAudit{LinkUID: uid, CreatedAt: at(ms).Format(time.RFC3339Nano), WireType: wireType}
+	}
+	pods := func(auditB []topologyv1.WireAudit) []convergencePod {
+		return []convergencePod{
+			{name: "a", aliveAt: at(0), links: []*mpb.Link{{Uid: 1, PeerPod: "b"}, {Uid: 3, PeerPod: localhost}},
+				// the first one is older than the pods of the wire
+				audit: []topologyv1.WireAudit{audit(1, -1000, "veth"), audit(3, 150, "macvlan"), audit(1, 300, "veth")}},
+			{name: "b", aliveAt: at(100), links: []*mpb.Link{{Uid: 1, PeerPod: "a"}, {Uid: 2, PeerPod: "c"}}, audit: auditB},
+			{name: "c", aliveAt: at(200), links: []*mpb.Link{{Uid: 2, PeerPod: "b"}, {Uid: 4, PeerPod: "d"}},
+				audit: []topologyv1.WireAudit{audit(2, 450, "vxlan")}},
+			// not alive, link 4 isn't expected to be up
+			{name: "d", links: []*mpb.Link{{Uid: 4, PeerPod: "c"}}},
+		}
+	}
+
+	tests := []struct {
+		desc string
+		pods []convergencePod
+		want *mpb.ConvergenceMetrics
+	}{{
+		desc: "converged",
+		pods: pods([]topologyv1.WireAudit{audit(2, 500, "vxlan")}),
+		want: &mpb.ConvergenceMetrics{
+			ConvergenceMs: 500,
+			FirstAlive:    at(0).Format(time.RFC3339Nano),
+			LastWireUp:    at(500).Format(time.RFC3339Nano),
+			Wires:         3,
+			WireTypes: []*mpb.WireTypeConvergence{
+				{WireType: "macvlan", Wires: 1, MaxSetupMs: 150},
+				{WireType: "veth", Wires: 1, MaxSetupMs: 200},
+				{WireType: "vxlan", Wires: 1, MaxSetupMs: 300},
+			},
+			CriticalPath: []*mpb.CriticalWire{
+				{Uid: 3, Pod: "a", WireType: "macvlan", UpAt: at(150).Format(time.RFC3339Nano), SetupMs: 150},
+				{Uid: 1, Pod: "a", WireType: "veth", UpAt: at(300).Format(time.RFC3339Nano), SetupMs: 150},
+				{Uid: 2, Pod: "b", WireType: "vxlan", UpAt: at(500).Format(time.RFC3339Nano), SetupMs: 200},
+			},
+			CriticalPathMs: 500,
+		},
+	}, {
+		desc: "pending",
+		pods: []convergencePod{pods(nil)[0], pods(nil)[1], {name: "c", aliveAt: at(200), links: []*mpb.Link{{Uid: 2, PeerPod: "b"}}}},
+		want: &mpb.ConvergenceMetrics{
+			ConvergenceMs: 300,
+			FirstAlive:    at(0).Format(time.RFC3339Nano),
+			LastWireUp:    at(300).Format(time.RFC3339Nano),
+			Wires:         2,
+			PendingWires:  1,
+			WireTypes: []*mpb.WireTypeConvergence{
+				{WireType: "macvlan", Wires: 1, MaxSetupMs: 150},
+				{WireType: "veth", Wires: 1, MaxSetupMs: 200},
+			},
+			CriticalPath: []*mpb.CriticalWire{
+				{Uid: 3, Pod: "a", WireType: "macvlan", UpAt: at(150).Format(time.RFC3339Nano), SetupMs: 150},
+				{Uid: 1, Pod: "a", WireType: "veth", UpAt: at(300).Format(time.RFC3339Nano), SetupMs: 150},
+			},
+			CriticalPathMs: 300,
+		},
+	}, {
+		desc: "not alive",
+		pods: []convergencePod{{name: "d", links: []*mpb.Link{{Uid: 4, PeerPod: "c"}}}},
+		want: &mpb.ConvergenceMetrics{},
+	}}
+	for _, tt := range tests {
+		if got := convergence(tt.pods); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: convergence() = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package meshnet
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/wireguard"
+)
+
+// fields of the Secret of a secure link
+const (
+	privateKeyField    = "private_key"
+	peerPublicKeyField = "peer_public_key"
+	presharedKeyField  = "preshared_key"
+)
+
+// readCredentials reads and checks the keys of the Secret name. Neither the keys nor the
+// errors about them are logged, the errors only name the Secret and its field. The keys are
+// only passed to WireGuard on this node, never returned by an RPC.
+func (m *Meshnet) readCredentials(ctx context.Context, ns, name string) (*mpb.LinkCredentials, error) {
+	secret, err := m.kClient.CoreV1().Secrets(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read Secret %s", name)
+	}
+	creds := &mpb.LinkCredentials{
+		PrivateKey:    string(secret.Data[privateKeyField]),
+		PeerPublicKey: string(secret.Data[peerPublicKeyField]),
+		PresharedKey:  string(secret.Data[presharedKeyField]),
+	}
+	for _, f := range []struct {
+		name, key string
+		optional  bool
+	}{
+		{name: privateKeyField, key: creds.PrivateKey},
+		{name: peerPublicKeyField, key: creds.PeerPublicKey},
+		{name: presharedKeyField, key: creds.PresharedKey, optional: true},
+	} {
+		if f.optional && f.key == "" {
+			continue
+		}
+		if _, err := wireguard.ParseKey(f.key); err != nil {
+			return nil, wireError(codes.InvalidArgument, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR},
+				"field %s of Secret %s is not a WireGuard key", f.name, name)
+		}
+	}
+	return creds, nil
+}
+
+// fillLinkCredentials gives a WireGuard link set up by an update the keys of the link of the
+// local pod, if it has any. They're read here rather than sent by the caller.
+func (m *Meshnet) fillLinkCredentials(ctx context.Context, pod *mpb.RemotePod) error {
+	if pod.TunnelType != mpb.TunnelType_WIREGUARD || pod.Credentials != nil || pod.PodName == "" {
+		return nil
+	}
+	localPod, err := m.Get(ctx, &mpb.PodQuery{Name: pod.PodName, KubeNs: pod.KubeNs})
+	if err != nil {
+		return err
+	}
+	link := linkByUID(localPod.Links, pod.Vni-vxlanBase)
+	if link == nil || link.Secret == "" {
+		return nil
+	}
+	pod.Credentials, err = m.readCredentials(ctx, pod.KubeNs, link.Secret)
+	return err
+}
@@ -0,0 +1,142 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	deadLetterRetryInterval = 10 * time.Second
+
+	reasonWireSetupFailed    = "WireSetupFailed"
+	reasonWireSetupAbandoned = "WireSetupAbandoned"
+)
+
+type deadLetter struct {
+	pod      *mpb.RemotePod
+	since    time.Time
+	attempts int
+}
+
+// deadLetterQueue holds remote link updates that have failed and retries them until maxAge.
+// Every failure is recorded as a K8s event against the peer pod's topology.
+type deadLetterQueue struct {
+	mu       sync.Mutex
+	items    map[string]*deadLetter
+	maxAge   time.Duration
+	recorder record.EventRecorder
+}
+
+func newDeadLetterQueue(maxAge time.Duration, recorder record.EventRecorder) *deadLetterQueue {
+	return &deadLetterQueue{
+		items:    make(map[string]*deadLetter),
+		maxAge:   maxAge,
+		recorder: recorder,
+	}
+}
+
+func deadLetterKey(pod *mpb.RemotePod) string {
+	return fmt.Sprintf("%s/%s/%s", pod.KubeNs, pod.NetNs, pod.IntfName)
+}
+
+// add records a failed update, keeping the time of the first failure for the same link
+func (q *deadLetterQueue) add(pod *mpb.RemotePod, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := deadLetterKey(pod)
+	item, ok := q.items[key]
+	if !ok {
+		item = &deadLetter{since: time.Now()}
+		q.items[key] = item
+	}
+	item.pod = pod
+	item.attempts++
+
+	q.event(pod, corev1.EventTypeWarning, reasonWireSetupFailed,
+		"Failed to set up link %s with VNI %d towards %s (attempt %d): %v", pod.IntfName, pod.Vni, pod.PeerVtep, item.attempts, err)
+}
+
+// remove drops a link from the queue, e.g. when a newer update has succeeded
+func (q *deadLetterQueue) remove(pod *mpb.RemotePod) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.items, deadLetterKey(pod))
+}
+
+// pending returns the queued links, dropping the ones that have been retried for longer than maxAge
+func (q *deadLetterQueue) pending() []*mpb.RemotePod {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var result []*mpb.RemotePod
+	for key, item := range q.items {
+		if time.Since(item.since) > q.maxAge {
+			q.event(item.pod, corev1.EventTypeWarning, reasonWireSetupAbandoned,
+				"Giving up on link %s with VNI %d towards %s after %d attempts", item.pod.IntfName, item.pod.Vni, item.pod.PeerVtep, item.attempts)
+			delete(q.items, key)
+			continue
+		}
+		result = append(result, item.pod)
+	}
+	return result
+}
+
+func (q *deadLetterQueue) event(pod *mpb.RemotePod, eventType, reason, msgFmt string, args ...interface{}) {
+	topologyEvent(q.recorder, pod.KubeNs, pod.PodName, eventType, reason, msgFmt, args...)
+}
+
+// topologyEvent records an event against the topology of the pod name
+func topologyEvent(recorder record.EventRecorder, ns, name, eventType, reason, msgFmt string, args ...interface{}) {
+	if recorder == nil || name == "" {
+		return
+	}
+	ref := &corev1.ObjectReference{
+		APIVersion: topologyv1.SchemeGroupVersion.String(),
+		Kind:       "Topology",
+		Namespace:  ns,
+		Name:       name,
+	}
+	recorder.Eventf(ref, eventType, reason, msgFmt, args...)
+}
+
+// wireLogKey returns the key of the rate-limited messages of kind about the wire of pod
+func wireLogKey(pod *mpb.RemotePod, kind string) string {
+	return fmt.Sprintf("%s/%s/%d/%s", pod.KubeNs, pod.PodName, pod.Vni-vxlanBase, kind)
+}
+
+// RetryFailedWires periodically retries failed remote link updates until stopCh is closed.
+func (m *Meshnet) RetryFailedWires(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(deadLetterRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			for _, pod := range m.dlq.pending() {
+				m.wireLog.Infof(wireLogKey(pod, "retry"), "Retrying failed update of link %s in %s", pod.IntfName, pod.NetNs)
+				err := updateRemote(pod)
+				if err == nil {
+					err = m.registerEndpoint(context.Background(), pod.KubeNs, pod.PodName, pod.NetNs, pod.IntfName)
+				}
+				m.recordWire(err)
+				if err != nil {
+					m.dlq.add(pod, err)
+					continue
+				}
+				m.dlq.remove(pod)
+				m.recordEvent(context.Background(), pod.KubeNs, pod.PodName, mpb.TopologyEvent_RECOVER, pod.Vni-vxlanBase, nil, nil, "retried remote update succeeded")
+				m.auditRemote(pod, howCreatedRetry)
+			}
+		}
+	}
+}
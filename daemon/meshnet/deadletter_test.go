@@ -0,0 +1,133 @@
+package meshnet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	link := func(intf string) *mpb.RemotePod {
+		return &mpb.RemotePod{KubeNs: "default", PodName: "r1", NetNs: "/var/run/netns/r1", IntfName: intf, Vni: 5001, PeerVtep: "10.0.0.2"}
+	}
+	failed := errors.New("netlink failed")
+
+	tests := []struct {
+		desc string
+		// failures of each interface, in order
+		add []string
+		// interfaces whose update has succeeded since
+		remove []string
+		// interfaces whose first failure is older than the maximum age
+		expired []string
+		pending []string
+		// reasons of the recorded events, in order
+		events []string
+	}{
+		{
+			desc:    "enqueue",
+			add:     []string{"eth1"},
+			pending: []string{"eth1"},
+			events:  []string{reasonWireSetupFailed},
+		},
+		{
+			desc:    "retry of the same link",
+			add:     []string{"eth1", "eth1", "eth2"},
+			pending: []string{"eth1", "eth2"},
+			events:  []string{reasonWireSetupFailed, reasonWireSetupFailed, reasonWireSetupFailed},
+		},
+		{
+			desc:    "successful retry",
+			add:     []string{"eth1", "eth2"},
+			remove:  []string{"eth1"},
+			pending: []string{"eth2"},
+			events:  []string{reasonWireSetupFailed, reasonWireSetupFailed},
+		},
+		{
+			desc:    "drop after the maximum age",
+			add:     []string{"eth1", "eth2"},
+			expired: []string{"eth1"},
+			pending: []string{"eth2"},
+			events:  []string{reasonWireSetupFailed, reasonWireSetupFailed, reasonWireSetupAbandoned},
+		},
+	}
+	for _, tt := range tests {
+		recorder := record.NewFakeRecorder(10)
+		q := newDeadLetterQueue(time.Minute, recorder)
+		for _, intf := range tt.add {
+			q.add(link(intf), failed)
+		}
+		for _, intf := range tt.remove {
+			q.remove(link(intf))
+		}
+		for _, intf := range tt.expired {
+			q.items[deadLetterKey(link(intf))].since = time.Now().Add(-2 * time.Minute)
+		}
+
+		pending := make(map[string]bool)
+		for _, pod := range q.pending() {
+			pending[pod.IntfName] = true
+		}
+		if len(pending) != len(tt.pending) {
+			t.Errorf("%s: pending() = %v, want %v", tt.desc, pending, tt.pending)
+		}
+		for _, intf := range tt.pending {
+			if !pending[intf] {
+				t.Errorf("%s: %s isn't pending, want %v", tt.desc, intf, tt.pending)
+			}
+		}
+		for _, intf := range tt.expired {
+			if _, ok := q.items[deadLetterKey(link(intf))]; ok {
+				t.Errorf("%s: %s is still queued after the maximum age", tt.desc, intf)
+			}
+		}
+
+		close(recorder.Events)
+		var events []string
+		for e := range recorder.Events {
+			events = append(events, e)
+		}
+		if len(events) != len(tt.events) {
+			t.Fatalf("%s: events = %v, want reasons %v", tt.desc, events, tt.events)
+		}
+		for i, reason := range tt.events {
+			if !strings.Contains(events[i], " "+reason+" ") {
+				t.Errorf("%s: event %d = %q, want reason %s", tt.desc, i, events[i], reason)
+			}
+		}
+	}
+}
+
+func TestDeadLetterAttempts(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	q := newDeadLetterQueue(time.Minute, recorder)
+	pod := &mpb.RemotePod{KubeNs: "default", PodName: "r1", NetNs: "/var/run/netns/r1", IntfName: "eth1", Vni: 5001}
+	q.add(pod, errors.New("failed"))
+	since := q.items[deadLetterKey(pod)].since
+
+	// a newer update of the same link replaces the queued one
+	newer := &mpb.RemotePod{KubeNs: "default", PodName: "r1", NetNs: "/var/run/netns/r1", IntfName: "eth1", Vni: 5001, PeerVtep: "10.0.0.3"}
+	q.add(newer, errors.New("failed again"))
+	item := q.items[deadLetterKey(pod)]
+	if item.attempts != 2 || !item.since.Equal(since) || item.pod != newer {
+		t.Errorf("queued %v with %d attempts since %v, want the newer update with 2 attempts since the first failure %v", item.pod, item.attempts, item.since, since)
+	}
+	<-recorder.Events
+	if e := <-recorder.Events; !strings.Contains(e, "attempt 2") || !strings.Contains(e, "failed again") {
+		t.Errorf("event = %q, want the second attempt and its error", e)
+	}
+
+	// events need the name of the topology
+	q = newDeadLetterQueue(time.Minute, recorder)
+	q.add(&mpb.RemotePod{KubeNs: "default", IntfName: "eth1"}, errors.New("failed"))
+	select {
+	case e := <-recorder.Events:
+		t.Errorf("recorded %q for a link without its pod name", e)
+	default:
+	}
+}
@@ -0,0 +1,98 @@
+package meshnet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/annotations"
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// how long the cluster-wide link defaults are reused for
+const globalConfigTTL = 30 * time.Second
+
+// globalConfig caches the cluster-wide link defaults, so that every Get and dial doesn't
+// read them from K8s
+type globalConfig struct {
+	mu   sync.Mutex
+	at   time.Time
+	spec *topologyv1.MeshnetConfigSpec
+}
+
+// get returns the last defaults if they're younger than globalConfigTTL, or reads them again.
+// The last defaults are kept if that fails.
+func (c *globalConfig) get(now time.Time, read func() (*topologyv1.MeshnetConfigSpec, error)) *topologyv1.MeshnetConfigSpec {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.at.IsZero() && now.Sub(c.at) < globalConfigTTL {
+		return c.spec
+	}
+	spec, err := read()
+	if err != nil {
+		log.Warnf("Failed to read the global meshnet config: %s", err)
+		return c.spec
+	}
+	c.at, c.spec = now, spec
+	return spec
+}
+
+// globalDefaults returns the cluster-wide link defaults, or nil if there are none or they're
+// invalid. They're read at most every globalConfigTTL.
+func (m *Meshnet) globalDefaults(ctx context.Context) *topologyv1.MeshnetConfigSpec {
+	return m.globalConfig.get(time.Now(), func() (*topologyv1.MeshnetConfigSpec, error) {
+		cfg, err := m.tClient.GlobalConfig(ctx)
+		if err != nil || cfg == nil {
+			return nil, err
+		}
+		if err := cfg.Spec.Validate(); err != nil {
+			log.Warnf("Ignoring invalid global meshnet config: %s", err)
+			return nil, nil
+		}
+		return &cfg.Spec, nil
+	})
+}
+
+// applyDefaults fills in the settings that links don't set, from the pod's annotations
+// first and from the cluster-wide defaults next
+func (m *Meshnet) applyDefaults(ctx context.Context, links []*mpb.Link, ann map[string]string) []*mpb.Link {
+	links = annotations.ApplyDefaults(links, ann)
+	return applyGlobalDefaults(links, m.globalDefaults(ctx), ann)
+}
+
+func applyGlobalDefaults(links []*mpb.Link, spec *topologyv1.MeshnetConfigSpec, ann map[string]string) []*mpb.Link {
+	if spec == nil {
+		return links
+	}
+	// a tunnel type annotation, even vxlan, overrides the default
+	_, ownTunnel := ann[annotations.TunnelType]
+	for _, link := range links {
+		if spec.DefaultTunnelType == topologyv1.TunnelVxlanGPE && !ownTunnel {
+			link.VxlanGpe = true
+		}
+		if impairment.IsEmpty(link.EgressImpairment) && spec.DefaultLatencyMs > 0 {
+			link.EgressImpairment = &mpb.ImpairmentSpec{LatencyMs: spec.DefaultLatencyMs}
+		}
+	}
+	return links
+}
+
+// dial connects to the daemon at url, waiting for the connection for up to the
+// cluster-wide dial timeout if there's one
+func (m *Meshnet) dial(ctx context.Context, url string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if spec := m.globalDefaults(ctx); spec != nil {
+		if timeout, _ := spec.DialTimeout(); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+			opts = append(opts, grpc.WithBlock())
+		}
+	}
+	return grpc.DialContext(ctx, url, opts...)
+}
@@ -0,0 +1,101 @@
+package meshnet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/annotations"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestApplyGlobalDefaults(t *testing.T) {
+	spec := &topologyv1.MeshnetConfigSpec{
+		DefaultTunnelType: topologyv1.TunnelVxlanGPE,
+		DefaultLatencyMs:  20,
+	}
+	own := &mpb.ImpairmentSpec{LossPercent: 1}
+
+	tests := []struct {
+		desc string
+		link *mpb.Link
+		ann  map[string]string
+		want *mpb.Link
+	}{
+		{
+			desc: "defaults",
+			link: &mpb.Link{Uid: 1},
+			want: &mpb.Link{Uid: 1, VxlanGpe: true, EgressImpairment: &mpb.ImpairmentSpec{LatencyMs: 20}},
+		},
+		{
+			desc: "link impairment",
+			link: &mpb.Link{Uid: 2, EgressImpairment: own},
+			want: &mpb.Link{Uid: 2, VxlanGpe: true, EgressImpairment: own},
+		},
+		{
+			desc: "tunnel type annotation",
+			link: &mpb.Link{Uid: 3},
+			ann:  map[string]string{annotations.TunnelType: "vxlan"},
+			want: &mpb.Link{Uid: 3, EgressImpairment: &mpb.ImpairmentSpec{LatencyMs: 20}},
+		},
+	}
+	for _, tt := range tests {
+		got := applyGlobalDefaults([]*mpb.Link{tt.link}, spec, tt.ann)
+		if !proto.Equal(got[0], tt.want) {
+			t.Errorf("%s: applyGlobalDefaults() = %v, want %v", tt.desc, got[0], tt.want)
+		}
+	}
+
+	if got := applyGlobalDefaults([]*mpb.Link{{Uid: 4}}, nil, nil); !proto.Equal(got[0], &mpb.Link{Uid: 4}) {
+		t.Errorf("applyGlobalDefaults() without defaults = %v", got[0])
+	}
+}
+
+func TestValidateMeshnetConfig(t *testing.T) {
+	tests := []struct {
+		spec  topologyv1.MeshnetConfigSpec
+		valid bool
+	}{
+		{spec: topologyv1.MeshnetConfigSpec{}, valid: true},
+		{spec: topologyv1.MeshnetConfigSpec{DefaultTunnelType: "vxlan", DefaultGRPCDialTimeout: "5s"}, valid: true},
+		{spec: topologyv1.MeshnetConfigSpec{DefaultTunnelType: "geneve"}, valid: false},
+		{spec: topologyv1.MeshnetConfigSpec{DefaultLatencyMs: -1}, valid: false},
+		{spec: topologyv1.MeshnetConfigSpec{DefaultGRPCDialTimeout: "5"}, valid: false},
+		{spec: topologyv1.MeshnetConfigSpec{DefaultGRPCDialTimeout: "0s"}, valid: false},
+	}
+	for i, tt := range tests {
+		err := tt.spec.Validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+func TestGlobalConfig(t *testing.T) {
+	var c globalConfig
+	reads := 0
+	read := func(spec *topologyv1.MeshnetConfigSpec, err error) func() (*topologyv1.MeshnetConfigSpec, error) {
+		return func() (*topologyv1.MeshnetConfigSpec, error) {
+			reads++
+			return spec, err
+		}
+	}
+	first := &topologyv1.MeshnetConfigSpec{DefaultLatencyMs: 10}
+	second := &topologyv1.MeshnetConfigSpec{DefaultLatencyMs: 20}
+	now := time.Now()
+	if got := c.get(now, read(first, nil)); got != first || reads != 1 {
+		t.Fatalf("get() = %v after %d reads, want %v after 1", got, reads, first)
+	}
+	if got := c.get(now.Add(globalConfigTTL/2), read(second, nil)); got != first || reads != 1 {
+		t.Errorf("get() = %v after %d reads within the TTL, want %v after 1", got, reads, first)
+	}
+	if got := c.get(now.Add(globalConfigTTL), read(nil, errors.New("unreachable"))); got != first || reads != 2 {
+		t.Errorf("get() = %v after %d reads when reading fails, want the last defaults %v after 2", got, reads, first)
+	}
+	if got := c.get(now.Add(globalConfigTTL), read(nil, nil)); got != nil || reads != 3 {
+		t.Errorf("get() = %v after %d reads once the defaults are deleted, want nil after 3", got, reads)
+	}
+}
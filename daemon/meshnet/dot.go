@@ -0,0 +1,20 @@
+package meshnet
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/networkop/meshnet-cni/daemon/dot"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// ExportTopologyDOT returns the graph of the topologies of a namespace in the DOT language,
+// with the state of their wires
+func (m *Meshnet) ExportTopologyDOT(ctx context.Context, q *mpb.TopologyQuery) (*mpb.DOTGraph, error) {
+	topologies, err := m.tClient.Topology(q.KubeNs).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of namespace %s", q.KubeNs)
+	}
+	return &mpb.DOTGraph{Dot: dot.Generate(q.KubeNs, topologies.Items)}, nil
+}
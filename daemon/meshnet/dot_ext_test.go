@@ -0,0 +1,25 @@
+package meshnet_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestExportTopologyDOT(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	graph, err := m.ExportTopologyDOT(ctx, &mpb.TopologyQuery{KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("ExportTopologyDOT() failed: %v", err)
+	}
+	// the link between r1 and r2 is in both topologies, but drawn once
+	for edge, want := range map[string]int{`"r1" -- "r2"`: 1, `"r1" -- "r1/localhost"`: 1, "color=red": 2} {
+		if got := strings.Count(graph.Dot, edge); got != want {
+			t.Errorf("ExportTopologyDOT() has %d %s, want %d:\n%s", got, edge, want, graph.Dot)
+		}
+	}
+}
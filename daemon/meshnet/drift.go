@@ -0,0 +1,238 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const reasonTopologyDrift = "TopologyDrift"
+
+// wireObservation is what the data plane of this node has for the end of a link
+type wireObservation struct {
+	// the interface of the link exists
+	present bool
+	// impairments of the interface, only read when it's present
+	egress, ingress *mpb.ImpairmentSpec
+	// the impairments are changed by a chaos profile or a flap simulation, or couldn't be
+	// read, so they aren't compared
+	transient bool
+}
+
+// wireDrift compares the links of the pods running on hostIP with the wire table of the node,
+// keyed by namespace and pod, and with what observe finds for each link. pods must have all
+// the pods of the namespaces of the table checked, so that the links to peers that aren't
+// running, whose wires are left to them, aren't missing. Wires of the table are only checked
+// in namespace ns, all namespaces when empty.
+func wireDrift(ns string, pods []*mpb.Pod, table map[string]map[int64]bool, hostIP string, observe func(*mpb.Pod, *mpb.Link) wireObservation) []*mpb.TopologyDrift {
+	byKey := make(map[string]*mpb.Pod, len(pods))
+	for _, p := range pods {
+		byKey[p.KubeNs+"/"+p.Name] = p
+	}
+	var result []*mpb.TopologyDrift
+	add := func(kind mpb.TopologyDrift_Kind, remediation mpb.TopologyDrift_Remediation, kubeNs, pod string, uid int64, msgFmt string, args ...interface{}) {
+		result = append(result, &mpb.TopologyDrift{
+			Kind:        kind,
+			KubeNs:      kubeNs,
+			Pod:         pod,
+			LinkUid:     uid,
+			Message:     fmt.Sprintf(msgFmt, args...),
+			Remediation: remediation,
+		})
+	}
+
+	for _, p := range pods {
+		if p.SrcIp != hostIP || p.NetNs == "" {
+			continue
+		}
+		key := p.KubeNs + "/" + p.Name
+		for _, l := range p.Links {
+			if l.PeerPod != localhost {
+				if peer := byKey[p.KubeNs+"/"+l.PeerPod]; peer == nil || peer.SrcIp == "" {
+					continue
+				}
+			}
+			if !table[key][l.Uid] {
+				add(mpb.TopologyDrift_MISSING_WIRE, mpb.TopologyDrift_RECREATE_WIRE, p.KubeNs, p.Name, l.Uid,
+					"link %d of pod %s has no wire on this node", l.Uid, p.Name)
+				continue
+			}
+			o := observe(p, l)
+			if !o.present {
+				add(mpb.TopologyDrift_MISSING_WIRE, mpb.TopologyDrift_RECREATE_WIRE, p.KubeNs, p.Name, l.Uid,
+					"interface %s of link %d of pod %s is missing", l.LocalIntf, l.Uid, p.Name)
+				continue
+			}
+			if o.transient {
+				continue
+			}
+			for _, dir := range []struct {
+				name           string
+				want, observed *mpb.ImpairmentSpec
+			}{
+				{name: "egress", want: l.EgressImpairment, observed: o.egress},
+				{name: "ingress", want: l.IngressImpairment, observed: o.ingress},
+			} {
+				if !sameImpairment(dir.want, dir.observed) {
+					add(mpb.TopologyDrift_IMPAIRMENT_MISMATCH, mpb.TopologyDrift_REAPPLY_IMPAIRMENT, p.KubeNs, p.Name, l.Uid,
+						"%s impairment of %s in pod %s is %s, want %s", dir.name, l.LocalIntf, p.Name, describeImpairment(dir.observed), describeImpairment(dir.want))
+				}
+			}
+		}
+	}
+
+	for key, uids := range table {
+		kubeNs, name := splitKey(key)
+		if ns != "" && kubeNs != ns {
+			continue
+		}
+		p := byKey[key]
+		for uid := range uids {
+			if p != nil && p.SrcIp == hostIP && p.NetNs != "" && linkByUID(p.Links, uid) != nil {
+				continue
+			}
+			add(mpb.TopologyDrift_ORPHANED_WIRE, mpb.TopologyDrift_REMOVE_WIRE, kubeNs, name, uid,
+				"wire %d of pod %s on this node isn't a link of its running topology", uid, name)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.KubeNs != b.KubeNs {
+			return a.KubeNs < b.KubeNs
+		}
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		if a.LinkUid != b.LinkUid {
+			return a.LinkUid < b.LinkUid
+		}
+		return a.Message < b.Message
+	})
+	return result
+}
+
+// sameImpairment tells whether two impairments are the same, nil being no impairment, an
+// ECN threshold of 0 the default one and a reorder gap of 0 a gap of 1
+func sameImpairment(a, b *mpb.ImpairmentSpec) bool {
+	if impairment.IsEmpty(a) || impairment.IsEmpty(b) {
+		return impairment.IsEmpty(a) && impairment.IsEmpty(b)
+	}
+	a, b = proto.Clone(a).(*mpb.ImpairmentSpec), proto.Clone(b).(*mpb.ImpairmentSpec)
+	if a.EcnEnabled && b.EcnEnabled && impairment.ECNThreshold(a) == impairment.ECNThreshold(b) {
+		a.EcnThresholdPercent, b.EcnThresholdPercent = 0, 0
+	}
+	a.ReorderGap, b.ReorderGap = impairment.ReorderGap(a), impairment.ReorderGap(b)
+	return proto.Equal(a, b)
+}
+
+// describeImpairment formats an impairment for the messages of the drifts
+func describeImpairment(spec *mpb.ImpairmentSpec) string {
+	if impairment.IsEmpty(spec) {
+		return "none"
+	}
+	s := fmt.Sprintf("latency %dms, jitter %dms, loss %g%%, duplicate %g%%, corrupt %g%%",
+		spec.LatencyMs, spec.JitterMs, spec.LossPercent, spec.DuplicatePercent, spec.CorruptPercent)
+	if spec.EcnEnabled {
+		s += fmt.Sprintf(", ECN threshold %g%%", impairment.ECNThreshold(spec))
+	}
+	if spec.ReorderPercent > 0 {
+		s += fmt.Sprintf(", reorder %g%% %g%% gap %d", spec.ReorderPercent, spec.ReorderCorrelationPercent, impairment.ReorderGap(spec))
+	}
+	return s
+}
+
+// splitKey splits a namespace/pod key
+func splitKey(key string) (string, string) {
+	i := strings.Index(key, "/")
+	return key[:i], key[i+1:]
+}
+
+// DetectTopologyDrift compares the links of the topologies of a namespace, all of them if the
+// query has none, with the wires of this node: links without a wire or whose interface is
+// missing, wires that aren't links anymore and interfaces whose impairments aren't the ones
+// of their link. Wires being set up may be reported as missing.
+func (m *Meshnet) DetectTopologyDrift(ctx context.Context, q *mpb.TopologyQuery) (*mpb.DriftReport, error) {
+	drifts, err := m.detectDrift(ctx, q.KubeNs)
+	if err != nil {
+		return nil, err
+	}
+	return &mpb.DriftReport{Drifts: drifts}, nil
+}
+
+func (m *Meshnet) detectDrift(ctx context.Context, ns string) ([]*mpb.TopologyDrift, error) {
+	topologies, err := m.tClient.Topology(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of namespace %s", ns)
+	}
+	var pods []*mpb.Pod
+	for _, t := range topologies.Items {
+		pod, err := m.Get(ctx, &mpb.PodQuery{Name: t.Name, KubeNs: t.Namespace})
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+	return wireDrift(ns, pods, m.wires.snapshot(), os.Getenv("HOST_IP"), m.observeWire), nil
+}
+
+// observeWire reads the interface of the link of a pod running on this node
+func (m *Meshnet) observeWire(pod *mpb.Pod, link *mpb.Link) wireObservation {
+	o := wireObservation{present: linkExists(pod.NetNs, link.LocalIntf)}
+	if !o.present {
+		return o
+	}
+	key := flapKey(pod.KubeNs, pod.Name, link.Uid)
+	m.chaos.mu.Lock()
+	_, chaos := m.chaos.applied[key]
+	m.chaos.mu.Unlock()
+	if _, flapping := m.flaps.Load(key); chaos || flapping {
+		o.transient = true
+		return o
+	}
+	var err error
+	if o.egress, o.ingress, err = impairment.Read(pod.NetNs, link.LocalIntf); err != nil {
+		log.Debugf("Failed to read the impairment of %s in pod %s: %s", link.LocalIntf, pod.Name, err)
+		o.transient = true
+	}
+	return o
+}
+
+// DetectDrift records an event against the topologies that have drifted from the wires of
+// this node every DriftDetectionInterval, until stopCh is closed
+func (m *Meshnet) DetectDrift(stopCh <-chan struct{}) {
+	if m.config.DriftDetectionInterval <= 0 {
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(m.config.DriftDetectionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+		drifts, err := m.detectDrift(context.Background(), "")
+		if err != nil {
+			log.Warnf("Failed to detect topology drift: %s", err)
+			continue
+		}
+		for _, d := range drifts {
+			log.Warnf("Topology drift: %s", d.Message)
+			topologyEvent(m.dlq.recorder, d.KubeNs, d.Pod, corev1.EventTypeWarning, reasonTopologyDrift,
+				"%s, remediation: %s", d.Message, d.Remediation)
+		}
+	}
+}
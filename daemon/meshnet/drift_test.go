@@ -0,0 +1,84 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestWireDrift(t *testing.T) {
+	latency := &mpb.ImpairmentSpec{LatencyMs: 10}
+	pods := []*mpb.Pod{
+		{Name: "r1", KubeNs: "default", SrcIp: "10.0.0.1", NetNs: "/run/netns/r1", Links: []*mpb.Link{
+			{Uid: 1, PeerPod: "r2", LocalIntf: "eth1"},
+			{Uid: 2, PeerPod: "r2", LocalIntf: "eth2"},
+			{Uid: 3, PeerPod: "r3", LocalIntf: "eth3"},
+			{Uid: 4, PeerPod: "r2", LocalIntf: "eth4", EgressImpairment: latency},
+			{Uid: 5, PeerPod: localhost, LocalIntf: "eth5"},
+		}},
+		{Name: "r2", KubeNs: "default", SrcIp: "10.0.0.2", NetNs: "/run/netns/r2"},
+		// not running, the wire of link 3 is left to it
+		{Name: "r3", KubeNs: "default"},
+	}
+	table := map[string]map[int64]bool{
+		"default/r1": {1: true, 2: true, 4: true, 5: true, 9: true},
+		// has moved to another node
+		"default/r2": {1: true},
+		// other namespace
+		"lab/r1": {1: true},
+	}
+	observe := func(p *mpb.Pod, l *mpb.Link) wireObservation {
+		switch l.LocalIntf {
+		case "eth2":
+			return wireObservation{}
+		case "eth5":
+			return wireObservation{present: true, transient: true}
+		}
+		return wireObservation{present: true, egress: &mpb.ImpairmentSpec{LatencyMs: 20}}
+	}
+
+	type drift struct {
+		kind mpb.TopologyDrift_Kind
+		pod  string
+		uid  int64
+	}
+	var got []drift
+	for _, d := range wireDrift("default", pods, table, "10.0.0.1", observe) {
+		got = append(got, drift{kind: d.Kind, pod: d.Pod, uid: d.LinkUid})
+	}
+	want := []drift{
+		{kind: mpb.TopologyDrift_IMPAIRMENT_MISMATCH, pod: "r1", uid: 1},
+		{kind: mpb.TopologyDrift_MISSING_WIRE, pod: "r1", uid: 2},
+		{kind: mpb.TopologyDrift_IMPAIRMENT_MISMATCH, pod: "r1", uid: 4},
+		{kind: mpb.TopologyDrift_ORPHANED_WIRE, pod: "r1", uid: 9},
+		{kind: mpb.TopologyDrift_ORPHANED_WIRE, pod: "r2", uid: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wireDrift() = %v, want %v", got, want)
+	}
+}
+
+func TestSameImpairment(t *testing.T) {
+	tests := []struct {
+		a, b *mpb.ImpairmentSpec
+		want bool
+	}{
+		{a: nil, b: &mpb.ImpairmentSpec{}, want: true},
+		{a: nil, b: &mpb.ImpairmentSpec{LatencyMs: 1}, want: false},
+		{a: &mpb.ImpairmentSpec{LossPercent: 0.5}, b: &mpb.ImpairmentSpec{LossPercent: 0.5}, want: true},
+		{a: &mpb.ImpairmentSpec{LossPercent: 0.5}, b: &mpb.ImpairmentSpec{LossPercent: 1}, want: false},
+		// the default ECN threshold
+		{a: &mpb.ImpairmentSpec{EcnEnabled: true}, b: &mpb.ImpairmentSpec{EcnEnabled: true, EcnThresholdPercent: 5}, want: true},
+		{a: &mpb.ImpairmentSpec{EcnEnabled: true}, b: &mpb.ImpairmentSpec{EcnEnabled: true, EcnThresholdPercent: 10}, want: false},
+		{a: &mpb.ImpairmentSpec{EcnEnabled: true}, b: nil, want: false},
+		// the default reorder gap
+		{a: &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25}, b: &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25, ReorderGap: 1}, want: true},
+		{a: &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25}, b: &mpb.ImpairmentSpec{LatencyMs: 10, ReorderPercent: 25, ReorderGap: 5}, want: false},
+	}
+	for _, tt := range tests {
+		if got := sameImpairment(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameImpairment(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
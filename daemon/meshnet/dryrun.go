@@ -0,0 +1,134 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/veth"
+)
+
+// DryRunTopology reports the interfaces that the wires of the topologies of a namespace
+// would be made of, based on where their pods are running, without touching any of them
+func (m *Meshnet) DryRunTopology(ctx context.Context, q *mpb.TopologyQuery) (*mpb.DryRunReport, error) {
+	log.Infof("Dry-running the topologies of namespace %s", q.KubeNs)
+	topologies, err := m.tClient.Topology(q.KubeNs).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of %s", q.KubeNs)
+	}
+	var pods []*mpb.Pod
+	var warnings []string
+	for _, t := range topologies.Items {
+		pod, err := m.dryRunPod(ctx, q.KubeNs, t.Name)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("topology %s: %s", t.Name, err))
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	report := dryRun(pods, veth.Resolve(m.config.NetnsMode) == veth.ModeCaller)
+	report.Warnings = append(warnings, report.Warnings...)
+	return report, nil
+}
+
+// dryRunPod reads a topology like Get, but without allocating the IPs of its links
+func (m *Meshnet) dryRunPod(ctx context.Context, ns, name string) (*mpb.Pod, error) {
+	obj, err := m.tClient.Topology(ns).Unstructured(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	remoteLinks, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+	links, err := parseLinks(remoteLinks)
+	if err != nil {
+		return nil, err
+	}
+	if links, err = m.composeLinks(ctx, obj, ns, links); err != nil {
+		return nil, err
+	}
+	if err := m.applyLinkProfiles(ctx, ns, links); err != nil {
+		return nil, err
+	}
+	links = m.applyDefaults(ctx, links, m.podAnnotations(ctx, ns, name))
+	srcIP, _, _ := unstructured.NestedString(obj.Object, "status", "src_ip")
+	netNs, _, _ := unstructured.NestedString(obj.Object, "status", "net_ns")
+	return &mpb.Pod{Name: name, KubeNs: ns, SrcIp: srcIP, NetNs: netNs, Links: links}, nil
+}
+
+// dryRun predicts the wires of pods. The type of a wire depends on the nodes of its pods,
+// so wires with a pod that isn't running are only reported as a warning. Impairments are
+// flagged when the daemon runs rootless.
+func dryRun(pods []*mpb.Pod, rootless bool) *mpb.DryRunReport {
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	byName := make(map[string]*mpb.Pod, len(pods))
+	for _, p := range pods {
+		byName[p.Name] = p
+	}
+	report := &mpb.DryRunReport{}
+	warn := func(format string, args ...interface{}) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(format, args...))
+	}
+	seen := make(map[string]bool)
+	for _, pod := range pods {
+		uids := make(map[int64]int)
+		for _, link := range pod.Links {
+			if uids[link.Uid]++; uids[link.Uid] == 2 {
+				warn("pod %s has more than one link with uid %d", pod.Name, link.Uid)
+			}
+			if err := validateIntfName(link.LocalIntf); err != nil {
+				warn("link %d of pod %s: %s", link.Uid, pod.Name, err)
+			}
+			if rootless && !(impairment.IsEmpty(link.EgressImpairment) && impairment.IsEmpty(link.IngressImpairment)) {
+				warn("link %d of pod %s has impairments, which need root privileges the daemon doesn't have in netns mode %s", link.Uid, pod.Name, veth.ModeCaller)
+			}
+
+			if link.PeerPod == localhost {
+				if pod.NetNs != "" && link.SriovVfPciAddr == "" {
+					report.Macvlans = append(report.Macvlans, &mpb.DryRunMacvlan{Uid: link.Uid, Pod: pod.Name, Intf: link.LocalIntf, Parent: link.PeerIntf})
+				}
+				continue
+			}
+			peer, ok := byName[link.PeerPod]
+			if !ok {
+				warn("link %d of pod %s: peer pod %s has no topology", link.Uid, pod.Name, link.PeerPod)
+				continue
+			}
+			if linkByUID(peer.Links, link.Uid) == nil {
+				warn("link %d of pod %s: peer pod %s has no link %d", link.Uid, pod.Name, peer.Name, link.Uid)
+				continue
+			}
+
+			a, b := pod.Name, peer.Name
+			if b < a {
+				a, b = b, a
+			}
+			key := fmt.Sprintf("%d/%s/%s", link.Uid, a, b)
+			first := !seen[key]
+			seen[key] = true
+			switch {
+			case pod.NetNs == "" || peer.NetNs == "":
+				if first {
+					warn("wire %d between pods %s and %s: not all its pods are running, its type depends on their nodes", link.Uid, a, b)
+				}
+			case pod.SrcIp == peer.SrcIp:
+				if first {
+					report.VethPairs = append(report.VethPairs, &mpb.DryRunVeth{
+						Uid: link.Uid, Pod: pod.Name, Intf: link.LocalIntf, NetNs: pod.NetNs,
+						PeerPod: peer.Name, PeerIntf: link.PeerIntf, PeerNetNs: peer.NetNs,
+					})
+				}
+			default:
+				report.Vxlans = append(report.Vxlans, &mpb.DryRunVxlan{
+					Uid: link.Uid, Pod: pod.Name, Intf: link.LocalIntf, LocalVtep: pod.SrcIp,
+					RemoteVtep: peer.SrcIp, Vni: link.Uid + vxlanBase, VxlanGpe: link.VxlanGpe,
+				})
+			}
+		}
+	}
+	return report
+}
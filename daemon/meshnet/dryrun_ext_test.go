@@ -0,0 +1,36 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestDryRunTopology(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	for _, pod := range []string{"r1", "r2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", pod, err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/"+pod
+		if _, err := m.SetAlive(ctx, p); err != nil {
+			t.Fatalf("SetAlive(%s) failed: %v", pod, err)
+		}
+	}
+	before := meshnettest.Store(m).Object("default", "r1").GetResourceVersion()
+
+	report, err := m.DryRunTopology(ctx, &mpb.TopologyQuery{KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("DryRunTopology() failed: %v", err)
+	}
+	if len(report.VethPairs) != 1 || len(report.Macvlans) != 1 || len(report.Vxlans) != 0 || len(report.Warnings) != 0 {
+		t.Errorf("DryRunTopology() = %v, want a veth pair and a macvlan", report)
+	}
+	if after := meshnettest.Store(m).Object("default", "r1").GetResourceVersion(); after != before {
+		t.Errorf("DryRunTopology() has changed topology r1")
+	}
+}
@@ -0,0 +1,58 @@
+package meshnet
+
+import (
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestDryRun(t *testing.T) {
+	link := func(uid int64, peer, intf string) *mpb.Link {
+		return &mpb.Link{Uid: uid, PeerPod: peer, LocalIntf: intf, PeerIntf: intf}
+	}
+	pods := []*mpb.Pod{
+		{Name: "r2", SrcIp: "10.0.0.1", NetNs: "/run/netns/r2", Links: []*mpb.Link{link(1, "r1", "eth1"), link(2, "r3", "eth2")}},
+		{Name: "r1", SrcIp: "10.0.0.1", NetNs: "/run/netns/r1", Links: []*mpb.Link{
+			link(1, "r2", "eth1"),
+			link(3, localhost, "eth3"),
+			link(4, "r4", "ethernet-1-1-long"),
+			{Uid: 5, PeerPod: "r3", LocalIntf: "eth5", PeerIntf: "eth5", EgressImpairment: &mpb.ImpairmentSpec{LatencyMs: 10}},
+		}},
+		{Name: "r3", SrcIp: "10.0.0.2", NetNs: "/run/netns/r3", Links: []*mpb.Link{link(2, "r2", "eth2"), link(5, "r1", "eth5")}},
+		{Name: "r4", Links: []*mpb.Link{link(4, "r1", "eth1"), link(6, "r5", "eth2"), link(6, "r1", "eth3")}},
+	}
+
+	report := dryRun(pods, true)
+	if len(report.VethPairs) != 1 || report.VethPairs[0].Uid != 1 || report.VethPairs[0].Pod != "r1" {
+		t.Errorf("dryRun() veth pairs = %v, want link 1 between r1 and r2", report.VethPairs)
+	}
+	// one VXLAN per end of links 2 and 5
+	if len(report.Vxlans) != 4 {
+		t.Errorf("dryRun() vxlans = %v, want 4", report.Vxlans)
+	}
+	for _, vx := range report.Vxlans {
+		if vx.Vni != vx.Uid+vxlanBase || vx.LocalVtep == vx.RemoteVtep {
+			t.Errorf("dryRun() vxlan = %v", vx)
+		}
+	}
+	if len(report.Macvlans) != 1 || report.Macvlans[0].Parent != "eth3" {
+		t.Errorf("dryRun() macvlans = %v, want one on eth3", report.Macvlans)
+	}
+
+	want := []string{
+		"link 4 of pod r1: interface name \"ethernet-1-1-long\" is longer than 15 characters",
+		"wire 4 between pods r1 and r4: not all its pods are running, its type depends on their nodes",
+		"link 5 of pod r1 has impairments, which need root privileges the daemon doesn't have in netns mode caller",
+		"link 6 of pod r4: peer pod r5 has no topology",
+		"pod r4 has more than one link with uid 6",
+		"link 6 of pod r4: peer pod r1 has no link 6",
+	}
+	if len(report.Warnings) != len(want) {
+		t.Fatalf("dryRun() warnings = %q, want %q", report.Warnings, want)
+	}
+	for i := range want {
+		if report.Warnings[i] != want[i] {
+			t.Errorf("dryRun() warning #%d = %q, want %q", i, report.Warnings[i], want[i])
+		}
+	}
+}
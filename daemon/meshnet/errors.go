@@ -0,0 +1,80 @@
+package meshnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// wireError returns a status error with code whose details carry detail, so that clients can
+// tell why an RPC has failed without parsing its message
+func wireError(code codes.Code, detail *mpb.WireError, format string, args ...interface{}) error {
+	st := status.New(code, fmt.Sprintf(format, args...))
+	if withDetails, err := st.WithDetails(detail); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// k8sError converts err, returned by the K8s API while doing op, into a status error with a
+// K8S_API_ERROR detail. Errors that already have a status are returned as they are.
+func k8sError(err error, op mpb.WireError_Operation, format string, args ...interface{}) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	msg := fmt.Sprintf(format, args...)
+	return wireError(k8sCode(err), &mpb.WireError{Operation: op, Cause: mpb.WireError_K8S_API_ERROR}, "%s: %s", msg, err)
+}
+
+// retryOnConflictWithContext calls fn until it doesn't fail with a conflict, like
+// retry.RetryOnConflict, but gives up as soon as ctx is done, since the caller has then
+// stopped waiting for the result. Timeouts of single calls to the K8s API are retried as
+// long as ctx isn't done.
+func retryOnConflictWithContext(ctx context.Context, fn func() error) error {
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		return apierrors.IsConflict(err) || apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || errors.Is(err, context.DeadlineExceeded)
+	}, func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn()
+	})
+}
+
+// k8sCode maps errors of the K8s API to gRPC codes
+func k8sCode(err error) codes.Code {
+	switch {
+	case apierrors.IsNotFound(err):
+		return codes.NotFound
+	case apierrors.IsAlreadyExists(err):
+		return codes.AlreadyExists
+	case apierrors.IsConflict(err):
+		return codes.Aborted
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return codes.InvalidArgument
+	case apierrors.IsForbidden(err):
+		return codes.PermissionDenied
+	case apierrors.IsUnauthorized(err):
+		return codes.Unauthenticated
+	case apierrors.IsTooManyRequests(err):
+		return codes.ResourceExhausted
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case apierrors.IsInternalError(err):
+		return codes.Internal
+	}
+	// e.g. the API server can't be reached
+	return codes.Unavailable
+}
@@ -0,0 +1,97 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestK8sError(t *testing.T) {
+	topologies := schema.GroupResource{Group: "networkop.co.uk", Resource: "topologies"}
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: apierrors.NewNotFound(topologies, "r1"), code: codes.NotFound},
+		{err: apierrors.NewConflict(topologies, "r1", fmt.Errorf("changed")), code: codes.Aborted},
+		{err: apierrors.NewForbidden(topologies, "r1", fmt.Errorf("denied")), code: codes.PermissionDenied},
+		{err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{err: fmt.Errorf("connection refused"), code: codes.Unavailable},
+		// errors with a status are kept
+		{err: status.Error(codes.InvalidArgument, "bad link"), code: codes.InvalidArgument},
+	}
+	for i, tt := range tests {
+		err := k8sError(tt.err, mpb.WireError_UPDATE, "failed to update pod %s", "r1")
+		st, _ := status.FromError(err)
+		if st.Code() != tt.code {
+			t.Errorf("#%d test failed: code %s, want %s", i, st.Code(), tt.code)
+		}
+	}
+}
+
+func TestWireErrorDetails(t *testing.T) {
+	err := wireError(codes.Internal, &mpb.WireError{WireUid: 7, PeerIp: "10.0.0.2", Cause: mpb.WireError_NETLINK_ERROR}, "failed on link %d", 7)
+	st, _ := status.FromError(err)
+	if st.Code() != codes.Internal || st.Message() != "failed on link 7" {
+		t.Fatalf("wireError() = %v", err)
+	}
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("wireError() has %d details, want 1", len(details))
+	}
+	detail, ok := details[0].(*mpb.WireError)
+	if !ok || detail.WireUid != 7 || detail.Cause != mpb.WireError_NETLINK_ERROR {
+		t.Errorf("wireError() has detail %v", details[0])
+	}
+}
+
+func TestRetryOnConflictWithContext(t *testing.T) {
+	topologies := schema.GroupResource{Group: "networkop.co.uk", Resource: "topologies"}
+	conflict := apierrors.NewConflict(topologies, "r1", fmt.Errorf("changed"))
+	notFound := apierrors.NewNotFound(topologies, "r1")
+	tests := []struct {
+		name      string
+		cancelled bool
+		// errors returned by the successive calls, nil afterwards
+		errs []error
+		// cancel the context during the call with this index, -1 for never
+		cancelAt int
+		calls    int
+		want     error
+	}{
+		{name: "success", errs: nil, cancelAt: -1, calls: 1},
+		{name: "conflicts", errs: []error{conflict, conflict}, cancelAt: -1, calls: 3},
+		{name: "inner timeout", errs: []error{context.DeadlineExceeded}, cancelAt: -1, calls: 2},
+		{name: "other error", errs: []error{notFound}, cancelAt: -1, calls: 1, want: notFound},
+		{name: "cancelled before", cancelled: true, cancelAt: -1, calls: 0, want: context.Canceled},
+		{name: "cancelled during", errs: []error{conflict, conflict}, cancelAt: 0, calls: 1, want: conflict},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		if tt.cancelled {
+			cancel()
+		}
+		calls := 0
+		err := retryOnConflictWithContext(ctx, func() error {
+			defer func() { calls++ }()
+			if calls == tt.cancelAt {
+				cancel()
+			}
+			if calls < len(tt.errs) {
+				return tt.errs[calls]
+			}
+			return nil
+		})
+		cancel()
+		if err != tt.want || calls != tt.calls {
+			t.Errorf("%s: retryOnConflictWithContext() = %v after %d calls, want %v after %d", tt.name, err, calls, tt.want, tt.calls)
+		}
+	}
+}
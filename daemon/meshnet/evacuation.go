@@ -0,0 +1,127 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	stateEvacuating      = "EVACUATING"
+	reasonNodeEvacuating = "NodeEvacuating"
+	// how often the pods of an evacuated node are checked for running on other nodes
+	evacuationPollPeriod = time.Second
+)
+
+// EvacuateNode hands off the wires of this node before it's drained: the pods running on it are
+// marked EVACUATING in the state status of their topology, and the daemons of the nodes their
+// wires lead to are told that these wires are going down, as on shutdown. Unless wire loss is
+// accepted, it then waits for the pods to run on other nodes with all their wires up, for at
+// most EvacuationTimeout.
+func (m *Meshnet) EvacuateNode(ctx context.Context, req *mpb.EvacuationRequest) (*mpb.EvacuationResult, error) {
+	if m.config.EvacuationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.EvacuationTimeout)
+		defer cancel()
+	}
+	hostIP := os.Getenv("HOST_IP")
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list topologies")
+	}
+	var pods []topologyv1.Topology
+	for _, t := range topologies.Items {
+		if t.Status.SrcIp == hostIP && t.Status.NetNs != "" {
+			pods = append(pods, t)
+		}
+	}
+	log.Infof("Evacuating node %s, %d pods are running on it", hostIP, len(pods))
+	for _, t := range pods {
+		if err := m.setState(ctx, t.Namespace, t.Name, stateEvacuating); err != nil {
+			return nil, k8sError(err, mpb.WireError_UPDATE, "failed to mark pod %s as evacuating", t.Name)
+		}
+		topologyEvent(m.dlq.recorder, t.Namespace, t.Name, corev1.EventTypeWarning, reasonNodeEvacuating,
+			"Node %s is being evacuated, the wires of the pod are down until it runs on another node", hostIP)
+	}
+	notified := m.notifyPeers(ctx)
+
+	result, err := m.evacuationResult(ctx, pods, hostIP)
+	if err != nil {
+		return nil, err
+	}
+	result.NotifiedNodes = notified
+	for !req.AcceptWireLoss && result.LostWires > 0 {
+		select {
+		case <-ctx.Done():
+			result.TimedOut = true
+			log.Warnf("%d wires of node %s haven't been re-established on other nodes within %s", result.LostWires, hostIP, m.config.EvacuationTimeout)
+			return result, nil
+		case <-time.After(evacuationPollPeriod):
+		}
+		next, err := m.evacuationResult(ctx, pods, hostIP)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return nil, err
+		}
+		next.NotifiedNodes = notified
+		result = next
+	}
+	return result, nil
+}
+
+// evacuationResult reads where the pods that were running on the node hostIP are running now
+func (m *Meshnet) evacuationResult(ctx context.Context, pods []topologyv1.Topology, hostIP string) (*mpb.EvacuationResult, error) {
+	result := &mpb.EvacuationResult{}
+	for i := range pods {
+		t := &pods[i]
+		latest, err := m.tClient.Topology(t.Namespace).Get(ctx, t.Name, metav1.GetOptions{})
+		if err == nil {
+			t = latest
+		} else if !apierrors.IsNotFound(err) {
+			return nil, k8sError(err, mpb.WireError_NONE, "failed to read the topology of pod %s", t.Name)
+		}
+		p := evacuatedPod(t, hostIP)
+		if !p.Reestablished {
+			result.LostWires += p.Wires
+		}
+		result.Pods = append(result.Pods, p)
+	}
+	return result, nil
+}
+
+// evacuatedPod returns how far the pod of a topology is from having left the node hostIP
+func evacuatedPod(t *topologyv1.Topology, hostIP string) *mpb.EvacuatedPod {
+	p := &mpb.EvacuatedPod{Pod: t.Name, KubeNs: t.Namespace, Wires: int64(len(t.Spec.Links))}
+	if t.Status.SrcIp == "" || t.Status.SrcIp == hostIP {
+		return p
+	}
+	p.NodeIp = t.Status.SrcIp
+	p.Reestablished = t.Status.NetNs != "" && t.Status.WiresUp == t.Status.WiresTotal
+	return p
+}
+
+// setState sets the state status of a topology
+func (m *Meshnet) setState(ctx context.Context, ns, name, state string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.tClient.Topology(ns).Unstructured(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj.Object, state, "status", "state"); err != nil {
+			return err
+		}
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
@@ -0,0 +1,53 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestEvacuateNode(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	for _, pod := range []string{"r1", "r2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/"+pod
+		if _, err := m.SetAlive(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := m.EvacuateNode(ctx, &mpb.EvacuationRequest{AcceptWireLoss: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Pods) != 2 || result.LostWires != 3 || result.TimedOut {
+		t.Errorf("EvacuateNode() = %v, want r1 and r2 with 3 lost wires", result)
+	}
+	state, _, _ := unstructured.NestedString(meshnettest.Store(m).Object("default", "r1").Object, "status", "state")
+	if state != "EVACUATING" {
+		t.Errorf("state of r1 = %q, want EVACUATING", state)
+	}
+
+	// r1 is rescheduled on another node
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SrcIp = "10.0.0.2"
+	if _, err := m.SetAlive(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	state, _, _ = unstructured.NestedString(meshnettest.Store(m).Object("default", "r1").Object, "status", "state")
+	if state != "" {
+		t.Errorf("state of r1 on another node = %q, want none", state)
+	}
+}
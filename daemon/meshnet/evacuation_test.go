@@ -0,0 +1,46 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestEvacuatedPod(t *testing.T) {
+	topology := func(status topologyv1.TopologyStatus) *topologyv1.Topology {
+		return &topologyv1.Topology{
+			ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "lab"},
+			Spec:       topologyv1.TopologySpec{Links: []topologyv1.Link{{UID: 1}, {UID: 2}}},
+			Status:     status,
+		}
+	}
+	tests := []struct {
+		desc   string
+		status topologyv1.TopologyStatus
+		want   *mpb.EvacuatedPod
+	}{{
+		desc:   "still on the node",
+		status: topologyv1.TopologyStatus{SrcIp: "10.0.0.1", NetNs: "/var/run/netns/r1"},
+		want:   &mpb.EvacuatedPod{Pod: "r1", KubeNs: "lab", Wires: 2},
+	}, {
+		desc: "not rescheduled yet",
+		want: &mpb.EvacuatedPod{Pod: "r1", KubeNs: "lab", Wires: 2},
+	}, {
+		desc:   "wires down",
+		status: topologyv1.TopologyStatus{SrcIp: "10.0.0.2", NetNs: "/var/run/netns/r1", WiresUp: 1, WiresTotal: 2},
+		want:   &mpb.EvacuatedPod{Pod: "r1", KubeNs: "lab", Wires: 2, NodeIp: "10.0.0.2"},
+	}, {
+		desc:   "re-established",
+		status: topologyv1.TopologyStatus{SrcIp: "10.0.0.2", NetNs: "/var/run/netns/r1", WiresUp: 2, WiresTotal: 2},
+		want:   &mpb.EvacuatedPod{Pod: "r1", KubeNs: "lab", Wires: 2, NodeIp: "10.0.0.2", Reestablished: true},
+	}}
+	for _, tt := range tests {
+		if got := evacuatedPod(topology(tt.status), "10.0.0.1"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: evacuatedPod() = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
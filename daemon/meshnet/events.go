@@ -0,0 +1,195 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	eventsPrefix = "meshnet-events-"
+
+	wireStateUp     = "up"
+	wireStateFailed = "failed"
+)
+
+func eventsName(topology string) string {
+	return eventsPrefix + topology
+}
+
+// EventStore keeps the events of the wires of each topology, in the order they're appended
+type EventStore interface {
+	Append(ctx context.Context, ns, topology string, e *mpb.TopologyEvent) error
+	Events(ctx context.Context, ns, topology string) ([]*mpb.TopologyEvent, error)
+}
+
+// configMapEvents stores the events of a topology in a ConfigMap of its namespace, under
+// increasing sequence numbers like the revisions of its history. The oldest events are
+// evicted beyond limit.
+type configMapEvents struct {
+	kClient kubernetes.Interface
+	limit   int
+}
+
+// NewConfigMapEventStore returns an event store keeping the last limit events of each topology
+func NewConfigMapEventStore(kClient kubernetes.Interface, limit int) EventStore {
+	return &configMapEvents{kClient: kClient, limit: limit}
+}
+
+func (s *configMapEvents) Append(ctx context.Context, ns, topology string, e *mpb.TopologyEvent) error {
+	data, err := protojson.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cms := s.kClient.CoreV1().ConfigMaps(ns)
+		cm, err := cms.Get(ctx, eventsName(topology), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: eventsName(topology)}}
+			addRevision(cm, string(data), s.limit)
+			_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		addRevision(cm, string(data), s.limit)
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *configMapEvents) Events(ctx context.Context, ns, topology string) ([]*mpb.TopologyEvent, error) {
+	cm, err := s.kClient.CoreV1().ConfigMaps(ns).Get(ctx, eventsName(topology), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result []*mpb.TopologyEvent
+	for _, seq := range revisions(cm) {
+		e := &mpb.TopologyEvent{}
+		if err := protojson.Unmarshal([]byte(cm.Data[strconv.Itoa(seq)]), e); err != nil {
+			log.Warnf("Ignoring invalid event %d of topology %s: %s", seq, topology, err)
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// recordEvent appends an event of the wire uid to the event log of a topology, failures are
+// only logged
+func (m *Meshnet) recordEvent(ctx context.Context, ns, topology string, t mpb.TopologyEvent_Type, uid int64, before, after *mpb.Link, msgFmt string, args ...interface{}) {
+	if m.events == nil || topology == "" {
+		return
+	}
+	e := &mpb.TopologyEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Type:      t,
+		Actor:     os.Getenv("POD_NAME"),
+		WireUid:   uid,
+		Before:    before,
+		After:     after,
+		Message:   fmt.Sprintf(msgFmt, args...),
+	}
+	if err := m.events.Append(ctx, ns, topology, e); err != nil {
+		log.Warnf("Failed to record the %s event of link %d of topology %s: %s", t, uid, topology, err)
+	}
+}
+
+// ExportTopology returns a topology along with the events of its wires
+func (m *Meshnet) ExportTopology(ctx context.Context, q *mpb.PodQuery) (*mpb.TopologyExport, error) {
+	pod, err := m.Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	result := &mpb.TopologyExport{Pod: pod}
+	if m.events == nil {
+		return result, nil
+	}
+	if result.Events, err = m.events.Events(ctx, q.KubeNs, pod.Name); err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read the events of topology %s", pod.Name)
+	}
+	return result, nil
+}
+
+// ReplayTopology walks the event log of a topology forward up to a timestamp, and returns
+// the wires it had then, for post-mortem analysis of failures.
+func (m *Meshnet) ReplayTopology(ctx context.Context, r *mpb.ReplayRequest) (*mpb.ReplayResult, error) {
+	if m.events == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the event log is disabled")
+	}
+	var until time.Time
+	if r.Timestamp != "" {
+		var err error
+		if until, err = time.Parse(time.RFC3339Nano, r.Timestamp); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid timestamp %q: %s", r.Timestamp, err)
+		}
+	}
+	events, err := m.events.Events(ctx, r.KubeNs, r.Name)
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read the events of topology %s", r.Name)
+	}
+	return replay(events, until), nil
+}
+
+// replay infers the wires of a topology from its events up to until, or from all of
+// them if until is zero
+func replay(events []*mpb.TopologyEvent, until time.Time) *mpb.ReplayResult {
+	wires := make(map[int64]*mpb.ReplayedWire)
+	result := &mpb.ReplayResult{}
+	for _, e := range events {
+		at, err := time.Parse(time.RFC3339Nano, e.Timestamp)
+		if err != nil {
+			log.Warnf("Ignoring event of link %d with invalid timestamp %q", e.WireUid, e.Timestamp)
+			continue
+		}
+		if !until.IsZero() && at.After(until) {
+			break
+		}
+		result.Events++
+		w, ok := wires[e.WireUid]
+		if !ok {
+			w = &mpb.ReplayedWire{Uid: e.WireUid}
+		}
+		switch e.Type {
+		case mpb.TopologyEvent_DELETE:
+			delete(wires, e.WireUid)
+			continue
+		case mpb.TopologyEvent_CREATE, mpb.TopologyEvent_RECOVER:
+			w.State = wireStateUp
+		case mpb.TopologyEvent_FAIL:
+			w.State = wireStateFailed
+		}
+		if e.After != nil {
+			w.Link = e.After
+		}
+		w.Since = e.Timestamp
+		wires[e.WireUid] = w
+	}
+	for _, w := range wires {
+		result.Wires = append(result.Wires, w)
+	}
+	sort.Slice(result.Wires, func(i, j int) bool { return result.Wires[i].Uid < result.Wires[j].Uid })
+	return result
+}
@@ -0,0 +1,39 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestExportTopology(t *testing.T) {
+	ctx := context.Background()
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true, EventLogLimit: 10}, fake.NewSimpleClientset(), meshnettest.NewTopologies(lab()...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AuditWire(ctx, &mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: 1, HowCreated: "CNI_ADD", WireType: "veth"}); err != nil {
+		t.Fatal(err)
+	}
+	export, err := m.ExportTopology(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("ExportTopology() failed: %v", err)
+	}
+	if len(export.Events) != 1 || export.Events[0].Type != mpb.TopologyEvent_CREATE || export.Events[0].After.GetLocalIntf() != "eth1" {
+		t.Errorf("ExportTopology() events = %v, want the creation of eth1", export.Events)
+	}
+	replayed, err := m.ReplayTopology(ctx, &mpb.ReplayRequest{Name: "r1", KubeNs: "default"})
+	if err != nil || len(replayed.Wires) != 1 || replayed.Wires[0].Uid != 1 {
+		t.Errorf("ReplayTopology() = %v, %v, want wire 1", replayed, err)
+	}
+	if _, err := m.ReplayTopology(ctx, &mpb.ReplayRequest{Name: "r1", KubeNs: "default", Timestamp: "yesterday"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ReplayTopology() with an invalid timestamp = %v, want %s", err, codes.InvalidArgument)
+	}
+}
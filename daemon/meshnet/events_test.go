@@ -0,0 +1,78 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func event(at string, t mpb.TopologyEvent_Type, uid int64, after *mpb.Link) *mpb.TopologyEvent {
+	return &mpb.TopologyEvent{Timestamp: at, Type: t, WireUid: uid, After: after}
+}
+
+func TestReplay(t *testing.T) {
+	eth1 := &mpb.Link{Uid: 1, LocalIntf: "eth1", PeerPod: "r2"}
+	eth2 := &mpb.Link{Uid: 2, LocalIntf: "eth2", PeerPod: "r3"}
+	events := []*mpb.TopologyEvent{
+		event("2021-01-01T00:00:00Z", mpb.TopologyEvent_CREATE, 1, eth1),
+		event("2021-01-01T00:01:00Z", mpb.TopologyEvent_CREATE, 2, eth2),
+		event("2021-01-01T00:02:00Z", mpb.TopologyEvent_FAIL, 1, nil),
+		event("2021-01-01T00:03:00Z", mpb.TopologyEvent_DELETE, 2, nil),
+		event("2021-01-01T00:04:00Z", mpb.TopologyEvent_RECOVER, 1, nil),
+		event("2021-01-01T00:05:00Z", mpb.TopologyEvent_FAIL, 3, nil),
+	}
+	tests := []struct {
+		until  string
+		events int64
+		want   map[int64]string
+	}{
+		{"2020-12-31T00:00:00Z", 0, map[int64]string{}},
+		{"2021-01-01T00:01:30Z", 2, map[int64]string{1: wireStateUp, 2: wireStateUp}},
+		{"2021-01-01T00:02:00Z", 3, map[int64]string{1: wireStateFailed, 2: wireStateUp}},
+		{"2021-01-01T00:03:00Z", 4, map[int64]string{1: wireStateFailed}},
+		{"", 6, map[int64]string{1: wireStateUp, 3: wireStateFailed}},
+	}
+	for _, tt := range tests {
+		var until time.Time
+		if tt.until != "" {
+			until, _ = time.Parse(time.RFC3339, tt.until)
+		}
+		got := replay(events, until)
+		if got.Events != tt.events || len(got.Wires) != len(tt.want) {
+			t.Errorf("replay(%s) = %d events, %d wires, want %d, %d", tt.until, got.Events, len(got.Wires), tt.events, len(tt.want))
+			continue
+		}
+		for _, w := range got.Wires {
+			if w.State != tt.want[w.Uid] {
+				t.Errorf("replay(%s): state of wire %d = %q, want %q", tt.until, w.Uid, w.State, tt.want[w.Uid])
+			}
+		}
+	}
+	if got := replay(events, time.Time{}); got.Wires[0].Link != eth1 || got.Wires[1].Link != nil {
+		t.Errorf("replay() links = %v, %v, want eth1 and none", got.Wires[0].Link, got.Wires[1].Link)
+	}
+}
+
+func TestConfigMapEvents(t *testing.T) {
+	ctx := context.Background()
+	s := NewConfigMapEventStore(fake.NewSimpleClientset(), 2)
+	if events, err := s.Events(ctx, "default", "r1"); err != nil || len(events) != 0 {
+		t.Fatalf("Events() of an empty log = %v, %v", events, err)
+	}
+	for uid := int64(1); uid <= 3; uid++ {
+		if err := s.Append(ctx, "default", "r1", event("2021-01-01T00:00:00Z", mpb.TopologyEvent_CREATE, uid, nil)); err != nil {
+			t.Fatalf("Append() failed: %v", err)
+		}
+	}
+	events, err := s.Events(ctx, "default", "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].WireUid != 2 || events[1].WireUid != 3 {
+		t.Errorf("Events() = %v, want the events of wires 2 and 3", events)
+	}
+}
@@ -0,0 +1,75 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+var fanoutModes = map[mpb.FanoutWireDef_Mode]string{
+	mpb.FanoutWireDef_BEST_EFFORT:       fanoutBestEffort,
+	mpb.FanoutWireDef_FAIL_ON_ANY_ERROR: fanoutFailOnAnyError,
+}
+
+// AddFanoutWire makes the end of a link of a pod flood its frames to the nodes of other pods
+// of its broadcast domain, which must have a link with the same uid. They are recorded as the
+// fanout_peers of the link, which the daemons of the nodes of the domain apply to its VXLAN
+// ends. With FAIL_ON_ANY_ERROR, the peers must all be running.
+func (m *Meshnet) AddFanoutWire(ctx context.Context, def *mpb.FanoutWireDef) (*mpb.BoolResponse, error) {
+	if len(def.Peers) == 0 {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.InvalidArgument, "the fanout of link %d of pod %s has no peers", def.LinkUid, def.Pod)
+	}
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: def.Pod, KubeNs: def.KubeNs})
+	if err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	link := linkByUID(pod.Links, def.LinkUid)
+	if link == nil {
+		return &mpb.BoolResponse{Response: false}, wireError(codes.NotFound, &mpb.WireError{WireUid: def.LinkUid}, "pod %s has no link %d", def.Pod, def.LinkUid)
+	}
+	if link.PeerPod == localhost {
+		return &mpb.BoolResponse{Response: false}, wireError(codes.InvalidArgument, &mpb.WireError{WireUid: def.LinkUid},
+			"link %d of pod %s has no peer pod", def.LinkUid, def.Pod)
+	}
+	var peers []interface{}
+	for _, name := range def.Peers {
+		peer, err := m.Get(ctx, &mpb.PodQuery{Name: name, KubeNs: def.KubeNs})
+		if err != nil {
+			return &mpb.BoolResponse{Response: false}, err
+		}
+		if linkByUID(peer.Links, def.LinkUid) == nil {
+			return &mpb.BoolResponse{Response: false}, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: def.LinkUid},
+				"fanout peer %s has no link %d to receive the frames on", name, def.LinkUid)
+		}
+		if def.Mode == mpb.FanoutWireDef_FAIL_ON_ANY_ERROR && peer.SrcIp == "" {
+			return &mpb.BoolResponse{Response: false}, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: def.LinkUid},
+				"fanout peer %s is not running", name)
+		}
+		peers = append(peers, name)
+	}
+
+	err = m.patchLinks(ctx, def.Pod, def.KubeNs, def.LinkUid, func(idx int) ([]jsonPatch, error) {
+		if idx < 0 {
+			return nil, fmt.Errorf("link %d has been removed", def.LinkUid)
+		}
+		return []jsonPatch{
+			{Op: "test", Path: fmt.Sprintf("/spec/links/%d/uid", idx), Value: def.LinkUid},
+			{Op: "add", Path: fmt.Sprintf("/spec/links/%d/fanout_peers", idx), Value: peers},
+			{Op: "add", Path: fmt.Sprintf("/spec/links/%d/fanout_mode", idx), Value: fanoutModes[def.Mode]},
+		}, nil
+	})
+	if err != nil {
+		return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_UPDATE, "failed to set the fanout peers of link %d of pod %s", def.LinkUid, def.Pod)
+	}
+	log.Infof("Link %d of pod %s fans out to %v", def.LinkUid, def.Pod, def.Peers)
+	// the other nodes apply it when they see the topology change
+	if err := m.syncBroadcast(ctx); err != nil {
+		log.Warnf("Failed to set the broadcast groups: %s", err)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
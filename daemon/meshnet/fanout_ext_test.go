@@ -0,0 +1,39 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestAddFanoutWire(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	tests := []struct {
+		def  *mpb.FanoutWireDef
+		code codes.Code
+	}{
+		{def: &mpb.FanoutWireDef{Pod: "r1", KubeNs: "default", LinkUid: 1}, code: codes.InvalidArgument},
+		{def: &mpb.FanoutWireDef{Pod: "r1", KubeNs: "default", LinkUid: 2, Peers: []string{"r2"}}, code: codes.InvalidArgument},
+		{def: &mpb.FanoutWireDef{Pod: "r1", KubeNs: "default", LinkUid: 3, Peers: []string{"r2"}}, code: codes.NotFound},
+		// r2 isn't running
+		{def: &mpb.FanoutWireDef{Pod: "r1", KubeNs: "default", LinkUid: 1, Peers: []string{"r2"}, Mode: mpb.FanoutWireDef_FAIL_ON_ANY_ERROR}, code: codes.FailedPrecondition},
+		{def: &mpb.FanoutWireDef{Pod: "r1", KubeNs: "default", LinkUid: 1, Peers: []string{"r2"}}, code: codes.OK},
+	}
+	for _, tt := range tests {
+		if _, err := m.AddFanoutWire(ctx, tt.def); status.Code(err) != tt.code {
+			t.Errorf("AddFanoutWire(%v) = %v, want %s", tt.def, err, tt.code)
+		}
+	}
+	links, _, _ := unstructured.NestedSlice(meshnettest.Store(m).Object("default", "r1").Object, "spec", "links")
+	link := links[0].(map[string]interface{})
+	if peers, _, _ := unstructured.NestedStringSlice(link, "fanout_peers"); len(peers) != 1 || peers[0] != "r2" || link["fanout_mode"] != "best_effort" {
+		t.Errorf("link 1 of r1 = %v, want fanout_peers r2 and best_effort", link)
+	}
+}
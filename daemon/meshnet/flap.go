@@ -0,0 +1,167 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// flapper brings a link down and up in a loop until it's stopped
+type flapper struct {
+	up, down time.Duration
+	setUp    func(up bool) error
+	stop     chan struct{}
+	done     chan struct{}
+
+	mu    sync.Mutex
+	count int64
+	last  time.Time
+}
+
+func newFlapper(up, down time.Duration, setUp func(bool) error) *flapper {
+	return &flapper{
+		up:    up,
+		down:  down,
+		setUp: setUp,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// run flaps the link until stop is closed or the link can't be changed, always leaving it up
+func (f *flapper) run() error {
+	defer close(f.done)
+	for {
+		if !f.wait(f.up) {
+			return nil
+		}
+		if err := f.setUp(false); err != nil {
+			return err
+		}
+		f.mu.Lock()
+		f.count++
+		f.last = time.Now()
+		f.mu.Unlock()
+
+		stopped := !f.wait(f.down)
+		if err := f.setUp(true); err != nil || stopped {
+			return err
+		}
+	}
+}
+
+// wait returns false if the flapper has been stopped in the meantime
+func (f *flapper) wait(d time.Duration) bool {
+	select {
+	case <-f.stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func (f *flapper) stats() (int64, time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.count, f.last
+}
+
+func flapKey(ns, pod string, uid int64) string {
+	return fmt.Sprintf("%s/%s/%d", ns, pod, uid)
+}
+
+// StartFlapSimulation starts bringing a link of a pod on this node down and up.
+func (m *Meshnet) StartFlapSimulation(ctx context.Context, spec *mpb.FlapSpec) (*mpb.BoolResponse, error) {
+	if spec.UpDurationMs <= 0 || spec.DownDurationMs <= 0 {
+		return &mpb.BoolResponse{Response: false}, status.Error(codes.InvalidArgument, "up and down durations must be positive")
+	}
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: spec.Pod, KubeNs: spec.KubeNs})
+	if err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	// the netns of the pod can only be opened by the daemon of its node
+	if pod.NetNs == "" || pod.SrcIp != os.Getenv("HOST_IP") {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.FailedPrecondition, "pod %s is not running on this node", spec.Pod)
+	}
+	link := linkByUID(pod.Links, spec.LinkUid)
+	if link == nil {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.NotFound, "pod %s has no link %d", spec.Pod, spec.LinkUid)
+	}
+
+	key := flapKey(spec.KubeNs, spec.Pod, spec.LinkUid)
+	f := newFlapper(
+		time.Duration(spec.UpDurationMs)*time.Millisecond,
+		time.Duration(spec.DownDurationMs)*time.Millisecond,
+		func(up bool) error { return setLinkUp(pod.NetNs, link.LocalIntf, up) },
+	)
+	if _, loaded := m.flaps.LoadOrStore(key, f); loaded {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.AlreadyExists, "link %d of pod %s is already flapping", spec.LinkUid, spec.Pod)
+	}
+	log.Infof("Flapping link %d of pod %s, %dms up and %dms down", spec.LinkUid, spec.Pod, spec.UpDurationMs, spec.DownDurationMs)
+
+	go func() {
+		if err := f.run(); err != nil {
+			log.Errorf("Stopped flapping link %d of pod %s: %s", spec.LinkUid, spec.Pod, err)
+		}
+		if v, ok := m.flaps.Load(key); ok && v == f {
+			m.flaps.Delete(key)
+		}
+	}()
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// StopFlapSimulation stops flapping a link and waits for it to be brought back up.
+func (m *Meshnet) StopFlapSimulation(ctx context.Context, spec *mpb.FlapSpec) (*mpb.BoolResponse, error) {
+	v, ok := m.flaps.LoadAndDelete(flapKey(spec.KubeNs, spec.Pod, spec.LinkUid))
+	if !ok {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.NotFound, "link %d of pod %s is not flapping", spec.LinkUid, spec.Pod)
+	}
+	f := v.(*flapper)
+	close(f.stop)
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return &mpb.BoolResponse{Response: false}, ctx.Err()
+	}
+	count, _ := f.stats()
+	log.Infof("Stopped flapping link %d of pod %s after %d flaps", spec.LinkUid, spec.Pod, count)
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// flapStats returns the flap count and time of the last flap of a link, if it's flapping
+func (m *Meshnet) flapStats(ns, pod string, uid int64) (int64, time.Time, bool) {
+	v, ok := m.flaps.Load(flapKey(ns, pod, uid))
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	count, last := v.(*flapper).stats()
+	return count, last, true
+}
+
+func setLinkUp(nsName, intfName string, up bool) error {
+	podNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer podNs.Close()
+	return podNs.Do(func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return err
+		}
+		if up {
+			return netlink.LinkSetUp(l)
+		}
+		return netlink.LinkSetDown(l)
+	})
+}
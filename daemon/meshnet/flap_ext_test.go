@@ -0,0 +1,37 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestStartFlapSimulation(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	spec := &mpb.FlapSpec{Pod: "r1", KubeNs: "default", LinkUid: 1, UpDurationMs: 10, DownDurationMs: 10}
+
+	if _, err := m.StartFlapSimulation(ctx, spec); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("StartFlapSimulation() of a pod that isn't running = %v, want FailedPrecondition", err)
+	}
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SrcIp, p.NetNs = "10.0.0.2", "/var/run/netns/r1"
+	if _, err := m.SetAlive(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.StartFlapSimulation(ctx, spec); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("StartFlapSimulation() of a pod of another node = %v, want FailedPrecondition", err)
+	}
+	if _, err := m.StopFlapSimulation(ctx, spec); status.Code(err) != codes.NotFound {
+		t.Errorf("StopFlapSimulation() = %v after a refused start, want NotFound", err)
+	}
+}
@@ -0,0 +1,55 @@
+package meshnet
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlapper(t *testing.T) {
+	var mu sync.Mutex
+	var states []bool
+	f := newFlapper(time.Millisecond, time.Millisecond, func(up bool) error {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, up)
+		return nil
+	})
+	errCh := make(chan error)
+	go func() { errCh <- f.run() }()
+
+	for {
+		if count, _ := f.stats(); count >= 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(f.stop)
+	if err := <-errCh; err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, up := range states {
+		if up != (i%2 == 1) {
+			t.Fatalf("link states = %v, want alternating down and up", states)
+		}
+	}
+	if len(states)%2 != 0 {
+		t.Errorf("link states = %v, the link must be left up", states)
+	}
+	count, last := f.stats()
+	if int(count) != len(states)/2 || last.IsZero() {
+		t.Errorf("stats() = %d, %v after %d state changes", count, last, len(states))
+	}
+}
+
+func TestFlapperError(t *testing.T) {
+	want := errors.New("link not found")
+	f := newFlapper(time.Millisecond, time.Millisecond, func(up bool) error { return want })
+	if err := f.run(); err != want {
+		t.Errorf("run() = %v, want %v", err, want)
+	}
+}
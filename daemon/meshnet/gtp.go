@@ -0,0 +1,32 @@
+package meshnet
+
+import (
+	"time"
+
+	"github.com/networkop/meshnet-cni/daemon/gtp"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const gtpPruneInterval = time.Minute
+
+// allocateTEID gives GTP-U links without a TEID the one of their UID in the -gtp-teid-base range
+func (m *Meshnet) allocateTEID(pod *mpb.RemotePod) {
+	if pod.TunnelType != mpb.TunnelType_GTP || pod.GtpTeid != 0 {
+		return
+	}
+	pod.GtpTeid = m.config.GTPTEIDBase + uint32(pod.Vni-vxlanBase)
+}
+
+// PruneGTP releases the GTP-U tunnels of deleted pods until stopCh is closed.
+func (m *Meshnet) PruneGTP(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(gtpPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			gtp.Prune()
+		}
+	}
+}
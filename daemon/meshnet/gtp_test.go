@@ -0,0 +1,25 @@
+package meshnet
+
+import (
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestAllocateTEID(t *testing.T) {
+	m := &Meshnet{config: Config{GTPTEIDBase: 10000}}
+	tests := []struct {
+		pod  *mpb.RemotePod
+		want uint32
+	}{
+		{pod: &mpb.RemotePod{TunnelType: mpb.TunnelType_GTP, Vni: vxlanBase + 7}, want: 10007},
+		{pod: &mpb.RemotePod{TunnelType: mpb.TunnelType_GTP, Vni: vxlanBase + 7, GtpTeid: 3}, want: 3},
+		{pod: &mpb.RemotePod{Vni: vxlanBase + 7}, want: 0},
+	}
+	for i, tt := range tests {
+		m.allocateTEID(tt.pod)
+		if tt.pod.GtpTeid != tt.want {
+			t.Errorf("#%d test failed: TEID %d, want %d", i, tt.pod.GtpTeid, tt.want)
+		}
+	}
+}
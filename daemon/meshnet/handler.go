@@ -2,85 +2,175 @@ package meshnet
 
 import (
 	"context"
+	"fmt"
 	"os"
-
+	"time"
+
+	"github.com/networkop/meshnet-cni/daemon/annotations"
+	"github.com/networkop/meshnet-cni/daemon/encap"
+	"github.com/networkop/meshnet-cni/daemon/gtp"
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	"github.com/networkop/meshnet-cni/daemon/pmtu"
+	"github.com/networkop/meshnet-cni/daemon/srv6"
+	"github.com/networkop/meshnet-cni/daemon/veth"
 	"github.com/networkop/meshnet-cni/daemon/vxlan"
+	"github.com/networkop/meshnet-cni/daemon/wireguard"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/util/retry"
 
 	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
 )
 
 func (m *Meshnet) getPod(ctx context.Context, name, ns string) (*unstructured.Unstructured, error) {
 	log.Infof("Reading pod %s from K8s", name)
-	return m.tClient.Topology(ns).Unstructured(ctx, name, metav1.GetOptions{})
+	obj, err := m.tClient.Topology(ns).Unstructured(ctx, name, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		return obj, err
+	}
+	// name may be a K8s pod selected by the pod selector of a topology
+	topology, ok, selErr := m.selectedTopology(ctx, ns, name)
+	if selErr != nil {
+		return nil, selErr
+	}
+	if !ok {
+		return obj, err
+	}
+	return m.tClient.Topology(ns).Unstructured(ctx, topology, metav1.GetOptions{})
 }
 
 func (m *Meshnet) updateStatus(ctx context.Context, obj *unstructured.Unstructured, ns string) error {
 	log.Infof("Update pod status %s from K8s", obj.GetName())
-	_, err := m.tClient.Topology(ns).Update(ctx, obj, metav1.UpdateOptions{})
+	_, err := m.tClient.Topology(ns).UpdateStatus(ctx, obj, metav1.UpdateOptions{})
 	return err
 }
 
 func (m *Meshnet) Get(ctx context.Context, pod *mpb.PodQuery) (*mpb.Pod, error) {
 	log.Infof("Retrieving %s's metadata from K8s...", pod.Name)
 
+	if p, ok := m.autoWire.owns(ctx, pod.KubeNs, pod.Name); ok {
+		result, err := m.autoWire.get(p)
+		if err != nil {
+			return nil, k8sError(err, mpb.WireError_NONE, "failed to read auto-wired pod %s", pod.Name)
+		}
+		result.NodeIp = os.Getenv("HOST_IP")
+		result.OvsBridge = m.ovsBridge()
+		result.NetnsMode = veth.Resolve(m.config.NetnsMode)
+		result.CiliumSocket = m.ciliumSocket()
+		return result, nil
+	}
+
 	result, err := m.getPod(ctx, pod.Name, pod.KubeNs)
 	if err != nil {
 		log.Errorf("Failed to read pod %s from K8s", pod.Name)
-		return nil, err
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read pod %s", pod.Name)
 	}
 
 	remoteLinks, found, err := unstructured.NestedSlice(result.Object, "spec", "links")
 	if err != nil || !found || remoteLinks == nil {
 		log.Errorf("Could not find 'Link' array in pod's spec")
-		return nil, err
+		return nil, wireError(codes.NotFound, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR}, "topology of pod %s has no links", pod.Name)
 	}
 
-	links := make([]*mpb.Link, len(remoteLinks))
-	for i := range links {
-		remoteLink, ok := remoteLinks[i].(map[string]interface{})
-		if !ok {
-			log.Errorf("Unrecognised 'Link' structure")
-			return nil, err
-		}
-		newLink := &mpb.Link{}
-		newLink.PeerPod, _, _ = unstructured.NestedString(remoteLink, "peer_pod")
-		newLink.PeerIntf, _, _ = unstructured.NestedString(remoteLink, "peer_intf")
-		newLink.LocalIntf, _, _ = unstructured.NestedString(remoteLink, "local_intf")
-		newLink.LocalIp, _, _ = unstructured.NestedString(remoteLink, "local_ip")
-		newLink.PeerIp, _, _ = unstructured.NestedString(remoteLink, "peer_ip")
-		newLink.Uid, _, _ = unstructured.NestedInt64(remoteLink, "uid")
-		links[i] = newLink
+	links, err := parseLinks(remoteLinks)
+	if err != nil {
+		log.Errorf("Unrecognised 'Link' structure")
+		return nil, wireError(codes.InvalidArgument, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR}, "topology of pod %s: %s", pod.Name, err)
+	}
+	if links, err = m.composeLinks(ctx, result, pod.KubeNs, links); err != nil {
+		log.Errorf("Failed to resolve the references of the topology of pod %s: %v", pod.Name, err)
+		return nil, err
+	}
+	if err := m.applyLinkProfiles(ctx, pod.KubeNs, links); err != nil {
+		log.Errorf("Failed to resolve the link profiles of pod %s: %v", pod.Name, err)
+		return nil, err
+	}
+	if err := applyTrafficClasses(result, links); err != nil {
+		return nil, wireError(codes.InvalidArgument, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR}, "topology of pod %s: %s", pod.Name, err)
 	}
 
 	srcIP, _, _ := unstructured.NestedString(result.Object, "status", "src_ip")
 	netNs, _, _ := unstructured.NestedString(result.Object, "status", "net_ns")
+	containerID, _, _ := unstructured.NestedString(result.Object, "status", "container_id")
 	nodeIP := os.Getenv("HOST_IP")
+	ann := m.podAnnotations(ctx, pod.KubeNs, pod.Name)
+	links = m.applyDefaults(ctx, links, ann)
+	if err := m.fillIPs(ctx, pod.KubeNs, result.GetName(), links); err != nil {
+		log.Errorf("Failed to allocate link IPs of pod %s: %v", pod.Name, err)
+	}
 
+	// The peers of a pod selected by a pod selector refer to it by the name of its topology
 	return &mpb.Pod{
-		Name:   pod.Name,
-		SrcIp:  srcIP,
-		NetNs:  netNs,
-		KubeNs: pod.KubeNs,
-		Links:  links,
-		NodeIp: nodeIP,
+		Name:         result.GetName(),
+		SrcIp:        srcIP,
+		NetNs:        netNs,
+		KubeNs:       pod.KubeNs,
+		Links:        links,
+		NodeIp:       nodeIP,
+		Annotations:  ann,
+		Canary:       canaryState(result),
+		OvsBridge:    m.ovsBridge(),
+		NetnsMode:    veth.Resolve(m.config.NetnsMode),
+		CiliumSocket: m.ciliumSocket(),
+		ContainerId:  containerID,
 	}, nil
 }
 
+// podAnnotations returns the supported annotations of a K8s pod, which may not exist yet
+func (m *Meshnet) podAnnotations(ctx context.Context, ns, name string) map[string]string {
+	p, err := m.kClient.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Warnf("Failed to read annotations of pod %s: %s", name, err)
+		}
+		return nil
+	}
+	return annotations.Filter(p.Annotations)
+}
+
 func (m *Meshnet) SetAlive(ctx context.Context, pod *mpb.Pod) (*mpb.BoolResponse, error) {
 	log.Infof("Setting %s's SrcIp=%s and NetNs=%s", pod.Name, pod.SrcIp, pod.NetNs)
 
-	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	// The wires are reserved before the pod is marked alive, so that its CNI ADD fails
+	// if this node can't take them
+	if err := m.reserveWires(pod); err != nil {
+		log.Errorf("Refusing to set pod %s alive: %v", pod.Name, err)
+		return &mpb.BoolResponse{Response: false}, err
+	}
+
+	if _, ok := m.autoWire.owns(ctx, pod.KubeNs, pod.Name); ok {
+		if err := m.autoWire.setAlive(ctx, pod); err != nil {
+			m.releaseWires(pod)
+			return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_UPDATE, "failed to update the status of pod %s", pod.Name)
+		}
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+
+	// the peers are read once, the wires between them are counted as set up by the peer
+	// that comes up last
+	var alive map[string]bool
+	if pod.SrcIp != "" {
+		alive = m.alivePeers(ctx, pod)
+	}
+	var migratedFrom string
+	retryErr := retryOnConflictWithContext(ctx, func() error {
 		result, err := m.getPod(ctx, pod.Name, pod.KubeNs)
 		if err != nil {
 			log.Errorf("Failed to read pod %s from K8s", pod.Name)
 			return err
 		}
 
+		if migratedFrom, err = recordNode(result, pod.SrcIp); err != nil {
+			log.Errorf("Failed to update pod's last_src_ip")
+		}
+		if migratedFrom != "" {
+			// the pod has left the node being evacuated
+			unstructured.RemoveNestedField(result.Object, "status", "state")
+		}
+
 		if err = unstructured.SetNestedField(result.Object, pod.SrcIp, "status", "src_ip"); err != nil {
 			log.Errorf("Failed to update pod's src_ip")
 		}
@@ -89,6 +179,21 @@ func (m *Meshnet) SetAlive(ctx context.Context, pod *mpb.Pod) (*mpb.BoolResponse
 			log.Errorf("Failed to update pod's net_ns")
 		}
 
+		if pod.ContainerId == "" {
+			unstructured.RemoveNestedField(result.Object, "status", "container_id")
+		} else if err = unstructured.SetNestedField(result.Object, pod.ContainerId, "status", "container_id"); err != nil {
+			log.Errorf("Failed to update pod's container_id")
+		}
+
+		// the convergence of the namespace is measured from the time its pods are alive
+		if pod.NetNs == "" {
+			unstructured.RemoveNestedField(result.Object, "status", "alive_at")
+		} else if err = unstructured.SetNestedField(result.Object, time.Now().UTC().Format(time.RFC3339Nano), "status", "alive_at"); err != nil {
+			log.Errorf("Failed to update pod's alive_at")
+		}
+
+		setWireCount(result, alive)
+
 		return m.updateStatus(ctx, result, pod.KubeNs)
 	})
 
@@ -97,7 +202,15 @@ func (m *Meshnet) SetAlive(ctx context.Context, pod *mpb.Pod) (*mpb.BoolResponse
 			"err":      retryErr,
 			"function": "SetAlive",
 		}).Errorf("Failed to update pod %s alive status", pod.Name)
-		return &mpb.BoolResponse{Response: false}, retryErr
+		m.releaseWires(pod)
+		return &mpb.BoolResponse{Response: false}, k8sError(retryErr, mpb.WireError_UPDATE, "failed to update the status of pod %s", pod.Name)
+	}
+	m.orderWires(ctx, pod)
+	if pod.NetNs != "" {
+		m.labelPod(ctx, pod.KubeNs, pod.Name)
+	}
+	if migratedFrom != "" {
+		log.Infof("Pod %s has migrated from node %s to %s, its wires are re-established by CNI", pod.Name, migratedFrom, pod.SrcIp)
 	}
 
 	return &mpb.BoolResponse{Response: true}, nil
@@ -106,7 +219,14 @@ func (m *Meshnet) SetAlive(ctx context.Context, pod *mpb.Pod) (*mpb.BoolResponse
 func (m *Meshnet) Skip(ctx context.Context, skip *mpb.SkipQuery) (*mpb.BoolResponse, error) {
 	log.Infof("Skipping of pod %s by pod %s", skip.Peer, skip.Pod)
 
-	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	if _, ok := m.autoWire.owns(ctx, skip.KubeNs, skip.Pod); ok {
+		if err := m.autoWire.skip(ctx, skip); err != nil {
+			return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_UPDATE, "failed to skip pod %s by %s", skip.Peer, skip.Pod)
+		}
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+
+	retryErr := retryOnConflictWithContext(ctx, func() error {
 		result, err := m.getPod(ctx, skip.Pod, skip.KubeNs)
 		if err != nil {
 			log.Errorf("Failed to read pod %s from K8s", skip.Pod)
@@ -129,7 +249,7 @@ func (m *Meshnet) Skip(ctx context.Context, skip *mpb.SkipQuery) (*mpb.BoolRespo
 			"err":      retryErr,
 			"function": "Skip",
 		}).Errorf("Failed to update skip pod %s status", skip.Pod)
-		return &mpb.BoolResponse{Response: false}, retryErr
+		return &mpb.BoolResponse{Response: false}, k8sError(retryErr, mpb.WireError_UPDATE, "failed to skip pod %s by %s", skip.Peer, skip.Pod)
 	}
 
 	return &mpb.BoolResponse{Response: true}, nil
@@ -138,12 +258,19 @@ func (m *Meshnet) Skip(ctx context.Context, skip *mpb.SkipQuery) (*mpb.BoolRespo
 func (m *Meshnet) SkipReverse(ctx context.Context, skip *mpb.SkipQuery) (*mpb.BoolResponse, error) {
 	log.Infof("Reverse-skipping of pod %s by pod %s", skip.Peer, skip.Pod)
 
+	if _, ok := m.autoWire.owns(ctx, skip.KubeNs, skip.Pod); ok {
+		if err := m.autoWire.skipReverse(ctx, skip); err != nil {
+			return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_UPDATE, "failed to reverse-skip pod %s by %s", skip.Peer, skip.Pod)
+		}
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+
 	var podName string
-	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	retryErr := retryOnConflictWithContext(ctx, func() error {
 		// setting the value for peer pod
 		peerPod, err := m.getPod(ctx, skip.Peer, skip.KubeNs)
 		if err != nil {
-			log.Errorf("Failed to read pod %s from K8s", skip.Pod)
+			log.Errorf("Failed to read pod %s from K8s", skip.Peer)
 			return err
 		}
 		podName = peerPod.GetName()
@@ -158,6 +285,8 @@ func (m *Meshnet) SkipReverse(ctx context.Context, skip *mpb.SkipQuery) (*mpb.Bo
 			log.Errorf("Failed to updated reverse-skipped list for peer pod %s", peerPod.GetName())
 			return err
 		}
+		// the wire of this link is gone with this pod
+		addWiresUp(peerPod, -1)
 
 		// sending peer pod's updates to k8s
 		return m.updateStatus(ctx, peerPod, skip.KubeNs)
@@ -167,10 +296,10 @@ func (m *Meshnet) SkipReverse(ctx context.Context, skip *mpb.SkipQuery) (*mpb.Bo
 			"err":      retryErr,
 			"function": "SkipReverse",
 		}).Errorf("Failed to update peer pod %s skipreverse status", podName)
-		return &mpb.BoolResponse{Response: false}, retryErr
+		return &mpb.BoolResponse{Response: false}, k8sError(retryErr, mpb.WireError_UPDATE, "failed to update the skipped list of pod %s", skip.Peer)
 	}
 
-	retryErr = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	retryErr = retryOnConflictWithContext(ctx, func() error {
 		// setting the value for this pod
 		thisPod, err := m.getPod(ctx, skip.Pod, skip.KubeNs)
 		if err != nil {
@@ -217,36 +346,310 @@ func (m *Meshnet) SkipReverse(ctx context.Context, skip *mpb.SkipQuery) (*mpb.Bo
 			"err":      retryErr,
 			"function": "SkipReverse",
 		}).Error("Failed to update this pod skipreverse status")
-		return &mpb.BoolResponse{Response: false}, retryErr
+		return &mpb.BoolResponse{Response: false}, k8sError(retryErr, mpb.WireError_UPDATE, "failed to update the skipped list of pod %s", skip.Pod)
 	}
 
 	return &mpb.BoolResponse{Response: true}, nil
 }
 
+// IsSkipped checks whether skip.Peer has skipped skip.Pod, i.e. whether skip.Pod is in
+// the skipped list of skip.Peer's topology, which is what Skip sets when skip.Peer comes up
+// before skip.Pod.
 func (m *Meshnet) IsSkipped(ctx context.Context, skip *mpb.SkipQuery) (*mpb.BoolResponse, error) {
-	log.Infof("Checking if %s is skipped by %s", skip.Peer, skip.Pod)
+	log.Infof("Checking if %s is skipped by %s", skip.Pod, skip.Peer)
 
-	result, err := m.getPod(ctx, skip.Peer, skip.KubeNs)
+	if _, ok := m.autoWire.owns(ctx, skip.KubeNs, skip.Peer); ok {
+		isSkipped, err := m.autoWire.isSkipped(ctx, skip)
+		if err != nil {
+			return nil, k8sError(err, mpb.WireError_NONE, "failed to read auto-wired pod %s", skip.Peer)
+		}
+		return &mpb.BoolResponse{Response: isSkipped}, nil
+	}
+
+	peerPod, err := m.getPod(ctx, skip.Peer, skip.KubeNs)
 	if err != nil {
-		log.Errorf("Failed to read pod %s from K8s", skip.Pod)
-		return nil, err
+		log.Errorf("Failed to read pod %s from K8s", skip.Peer)
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read pod %s", skip.Peer)
 	}
 
-	skipped, _, _ := unstructured.NestedSlice(result.Object, "status", "skipped")
+	return &mpb.BoolResponse{Response: skippedBy(peerPod, skip.Pod)}, nil
+}
 
-	for _, peer := range skipped {
-		if skip.Pod == peer.(string) {
-			return &mpb.BoolResponse{Response: true}, nil
+// skippedBy returns true if pod is in the skipped list of the topology obj.
+// Entries that aren't strings, e.g. from a manually edited CR, are ignored.
+func skippedBy(obj *unstructured.Unstructured, pod string) bool {
+	skipped, _, _ := unstructured.NestedSlice(obj.Object, "status", "skipped")
+	for _, entry := range skipped {
+		name, ok := entry.(string)
+		if !ok {
+			log.Warnf("Ignoring invalid entry %v in the skipped list of pod %s", entry, obj.GetName())
+			continue
+		}
+		if name == pod {
+			return true
 		}
 	}
-
-	return &mpb.BoolResponse{Response: false}, nil
+	return false
 }
 
 func (m *Meshnet) Update(ctx context.Context, pod *mpb.RemotePod) (*mpb.BoolResponse, error) {
-	if err := vxlan.CreateOrUpdate(pod); err != nil {
-		log.Errorf("Failed to Update Vxlan")
+	if err := m.wires.reserve(pod.KubeNs, pod.PodName, []int64{pod.Vni - vxlanBase}); err != nil {
+		m.wireLog.Errorf(wireLogKey(pod, "update"), "Refusing remote link: %v", err)
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	m.allocateTEID(pod)
+	m.waitForPrerequisites(ctx, pod)
+	err := m.fillLinkCredentials(ctx, pod)
+	if err == nil {
+		err = m.fillWGPublicKey(ctx, pod)
+	}
+	if err == nil {
+		err = updateRemote(pod)
+	}
+	if err == nil {
+		err = m.registerEndpoint(ctx, pod.KubeNs, pod.PodName, pod.NetNs, pod.IntfName)
+	}
+	m.recordWire(err)
+	if err != nil {
+		m.wireLog.Errorf(wireLogKey(pod, "update"), "Failed to Update remote link: %v", err)
+		m.dlq.add(pod, err)
+		m.recordEvent(ctx, pod.KubeNs, pod.PodName, mpb.TopologyEvent_FAIL, pod.Vni-vxlanBase, nil, nil, "remote update failed: %v", err)
 		return &mpb.BoolResponse{Response: false}, nil
 	}
+	// A successful update supersedes any earlier failure of the same link
+	m.dlq.remove(pod)
+	if m.order.up(pod.KubeNs, pod.PodName, pod.Vni-vxlanBase) && m.config.AutoInjectRoutes {
+		go m.autoInjectRoutes(pod.KubeNs, pod.PodName)
+	}
+	if err := m.refreshECMP(ctx, pod.PodName, pod.KubeNs); err != nil {
+		log.Warnf("Failed to update the ECMP routes of pod %s: %s", pod.PodName, err)
+	}
+	m.auditRemote(pod, howCreatedRemote)
 	return &mpb.BoolResponse{Response: true}, nil
 }
+
+// UpdateVXLANNeighbors programs static FDB and ARP entries on a VXLAN link of this node
+func (m *Meshnet) UpdateVXLANNeighbors(ctx context.Context, u *mpb.VXLANNeighborUpdate) (*mpb.BoolResponse, error) {
+	if err := vxlan.UpdateNeighbors(u.NetNs, u.IntfName, u.Neighbors); err != nil {
+		log.Errorf("Failed to update the neighbors of %s: %v", u.IntfName, err)
+		return &mpb.BoolResponse{Response: false}, wireError(codes.Internal, &mpb.WireError{Operation: mpb.WireError_UPDATE, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to update the neighbors of %s: %s", u.IntfName, err)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// alivePeers returns whether each peer of a pod is alive, reading each of them once. Peers
+// that can't be read are left out, and count as down.
+func (m *Meshnet) alivePeers(ctx context.Context, pod *mpb.Pod) map[string]bool {
+	alive := make(map[string]bool)
+	for _, link := range pod.Links {
+		if link.PeerPod == localhost {
+			continue
+		}
+		if _, ok := alive[link.PeerPod]; ok {
+			continue
+		}
+		peer, err := m.getPod(ctx, link.PeerPod, pod.KubeNs)
+		if err != nil {
+			log.Debugf("Counting the wires of peer %s as down: %s", link.PeerPod, err)
+			continue
+		}
+		peerIP, _, _ := unstructured.NestedString(peer.Object, "status", "src_ip")
+		alive[link.PeerPod] = peerIP != ""
+	}
+	return alive
+}
+
+// wiresUp returns how many links of a pod have both ends alive, given whether each of its
+// peers is alive. Links to localhost only need the pod itself.
+func wiresUp(links []interface{}, alive map[string]bool) int64 {
+	var up int64
+	for _, l := range links {
+		link, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		peerName, _, _ := unstructured.NestedString(link, "peer_pod")
+		if peerName == localhost || alive[peerName] {
+			up++
+		}
+	}
+	return up
+}
+
+// setWireCount sets the number of links of a pod and how many of them have both ends alive
+func setWireCount(obj *unstructured.Unstructured, alive map[string]bool) {
+	links, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+	var up int64
+	if srcIP, _, _ := unstructured.NestedString(obj.Object, "status", "src_ip"); srcIP != "" {
+		up = wiresUp(links, alive)
+	}
+	if err := unstructured.SetNestedField(obj.Object, up, "status", "wires_up"); err != nil {
+		log.Errorf("Failed to update pod's wires_up")
+	}
+	if err := unstructured.SetNestedField(obj.Object, int64(len(links)), "status", "wires_total"); err != nil {
+		log.Errorf("Failed to update pod's wires_total")
+	}
+}
+
+// addWiresUp changes the number of wires up of a pod by delta as one of its wires is set up or
+// torn down by its peer, keeping it between 0 and the number of its links
+func addWiresUp(obj *unstructured.Unstructured, delta int64) {
+	links, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+	up, _, _ := unstructured.NestedInt64(obj.Object, "status", "wires_up")
+	up += delta
+	if up > int64(len(links)) {
+		up = int64(len(links))
+	}
+	if up < 0 {
+		up = 0
+	}
+	if err := unstructured.SetNestedField(obj.Object, up, "status", "wires_up"); err != nil {
+		log.Errorf("Failed to update pod's wires_up")
+	}
+}
+
+// updateRemote sets up the local end of a link to a pod on a remote node
+func updateRemote(pod *mpb.RemotePod) error {
+	switch pod.TunnelType {
+	case mpb.TunnelType_SRV6:
+		if err := srv6.CreateOrUpdate(pod); err != nil {
+			return fmt.Errorf("failed to Update SRv6: %v", err)
+		}
+	case mpb.TunnelType_GTP:
+		if err := gtp.CreateOrUpdate(pod); err != nil {
+			return fmt.Errorf("failed to Update GTP-U: %v", err)
+		}
+	case mpb.TunnelType_WIREGUARD:
+		if err := wireguard.CreateOrUpdate(pod); err != nil {
+			return fmt.Errorf("failed to Update WireGuard: %v", err)
+		}
+	default:
+		if err := vxlan.CreateOrUpdate(pod); err != nil {
+			return fmt.Errorf("failed to Update Vxlan: %v", err)
+		}
+	}
+	if err := impairment.Apply(pod.NetNs, pod.IntfName, pod.EgressImpairment, pod.IngressImpairment); err != nil {
+		return fmt.Errorf("failed to apply link impairments: %v", err)
+	}
+	if err := impairment.ApplyDSCP(pod.NetNs, pod.IntfName, pod.TrafficClass); err != nil {
+		return fmt.Errorf("failed to apply traffic class: %v", err)
+	}
+	if err := impairment.ApplyQoS(pod.NetNs, pod.IntfName, pod.EgressQosMap, pod.EgressQueueAllocation); err != nil {
+		return fmt.Errorf("failed to apply egress QoS map: %v", err)
+	}
+	if err := encap.ApplyMPLS(pod.NetNs, pod.IntfName, pod.IntfIp, pod.MplsLabel); err != nil {
+		return fmt.Errorf("failed to apply MPLS label: %v", err)
+	}
+	if err := pmtu.Apply(pod.NetNs, pod.IntfName, pod.Mtu); err != nil {
+		return fmt.Errorf("failed to apply MTU: %v", err)
+	}
+	return nil
+}
+
+// parseLinks converts the links of a topology spec
+func parseLinks(remoteLinks []interface{}) ([]*mpb.Link, error) {
+	links := make([]*mpb.Link, len(remoteLinks))
+	for i := range links {
+		remoteLink, ok := remoteLinks[i].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unrecognised link %v", remoteLinks[i])
+		}
+		newLink := &mpb.Link{}
+		newLink.PeerPod, _, _ = unstructured.NestedString(remoteLink, "peer_pod")
+		newLink.PeerIntf, _, _ = unstructured.NestedString(remoteLink, "peer_intf")
+		newLink.LocalIntf, _, _ = unstructured.NestedString(remoteLink, "local_intf")
+		newLink.LocalIp, _, _ = unstructured.NestedString(remoteLink, "local_ip")
+		newLink.PeerIp, _, _ = unstructured.NestedString(remoteLink, "peer_ip")
+		newLink.Uid, _, _ = unstructured.NestedInt64(remoteLink, "uid")
+		newLink.EgressImpairment = impairmentSpec(remoteLink, "egress_impairment")
+		newLink.IngressImpairment = impairmentSpec(remoteLink, "ingress_impairment")
+		newLink.VxlanGpe, _, _ = unstructured.NestedBool(remoteLink, "vxlan_gpe")
+		newLink.MplsLabel = uint32(number(remoteLink["mpls_label"]))
+		newLink.EcmpGroup, _, _ = unstructured.NestedString(remoteLink, "ecmp_group")
+		newLink.SriovVfPciAddr, _, _ = unstructured.NestedString(remoteLink, "sriov_vf_pci_addr")
+		newLink.LocalMac, _, _ = unstructured.NestedString(remoteLink, "local_mac")
+		newLink.PeerMac, _, _ = unstructured.NestedString(remoteLink, "peer_mac")
+		newLink.Mtu = int32(number(remoteLink["mtu"]))
+		newLink.Profile, _, _ = unstructured.NestedString(remoteLink, "profile_ref", "name")
+		newLink.Secret, _, _ = unstructured.NestedString(remoteLink, "secure_link", "secret_ref", "name")
+		newLink.Srv6Segments, _, _ = unstructured.NestedStringSlice(remoteLink, "srv6", "segments")
+		newLink.Srv6LocalSid, _, _ = unstructured.NestedString(remoteLink, "srv6", "local_sid")
+		newLink.EgressQosMap = qosMap(remoteLink)
+		newLink.EgressQueueAllocation = queueAllocation(remoteLink)
+		links[i] = newLink
+	}
+	return links, nil
+}
+
+// impairmentSpec reads an impairment from a link, returning nil if it's not set
+func impairmentSpec(link map[string]interface{}, field string) *mpb.ImpairmentSpec {
+	spec, found, err := unstructured.NestedMap(link, field)
+	if err != nil || !found {
+		return nil
+	}
+	return &mpb.ImpairmentSpec{
+		LatencyMs:        int64(number(spec["latency_ms"])),
+		JitterMs:         int64(number(spec["jitter_ms"])),
+		LossPercent:      float32(number(spec["loss_percent"])),
+		DuplicatePercent: float32(number(spec["duplicate_percent"])),
+		CorruptPercent:   float32(number(spec["corrupt_percent"])),
+		EcnEnabled:       spec["ecn_enabled"] == true,
+
+		EcnThresholdPercent: float32(number(spec["ecn_threshold_percent"])),
+
+		ReorderPercent:            float32(number(spec["reorder_percent"])),
+		ReorderCorrelationPercent: float32(number(spec["reorder_correlation_percent"])),
+		ReorderGap:                uint32(number(spec["reorder_gap"])),
+	}
+}
+
+// qosMap reads the egress QoS map of a link
+func qosMap(link map[string]interface{}) []*mpb.DscpQueueEntry {
+	entries, _, _ := unstructured.NestedSlice(link, "egress_qos_map")
+	var result []*mpb.DscpQueueEntry
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, &mpb.DscpQueueEntry{
+			Dscp:     uint32(number(entry["dscp"])),
+			Priority: uint32(number(entry["priority"])),
+		})
+	}
+	return result
+}
+
+// queueAllocation reads the bandwidth of the egress queues of a link, returning nil if it's
+// not set
+func queueAllocation(link map[string]interface{}) *mpb.QueueAllocation {
+	alloc, found, err := unstructured.NestedMap(link, "egress_queue_allocation")
+	if err != nil || !found {
+		return nil
+	}
+	result := &mpb.QueueAllocation{RateMbps: int64(number(alloc["rate_mbps"]))}
+	queues, _, _ := unstructured.NestedSlice(alloc, "queues")
+	for _, q := range queues {
+		queue, ok := q.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result.Queues = append(result.Queues, &mpb.QueueBandwidth{
+			Priority: uint32(number(queue["priority"])),
+			Percent:  float32(number(queue["percent"])),
+		})
+	}
+	return result
+}
+
+// number converts a JSON number, which may be decoded either as an int64 or a float64
+func number(v interface{}) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
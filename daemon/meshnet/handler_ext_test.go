@@ -0,0 +1,73 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestSetAlive(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+
+	for _, pod := range []string{"r1", "r2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", pod, err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/"+pod
+		if resp, err := m.SetAlive(ctx, p); err != nil || !resp.Response {
+			t.Fatalf("SetAlive(%s) = %v, %v", pod, resp, err)
+		}
+	}
+	meshnettest.AssertWireActive(t, m, 1)
+	meshnettest.AssertWireActive(t, m, 2)
+
+	// r2 has come up last, the wire of r1 to it is counted once r2 has set it up
+	wiresUp := func(pod string) int64 {
+		up, _, _ := unstructured.NestedInt64(meshnettest.Store(m).Object("default", pod).Object, "status", "wires_up")
+		return up
+	}
+	if r1, r2 := wiresUp("r1"), wiresUp("r2"); r1 != 1 || r2 != 1 {
+		t.Errorf("wires_up of r1 and r2 = %d and %d, want 1 and 1", r1, r2)
+	}
+	if _, err := m.AuditWire(ctx, &mpb.WireAudit{Pod: "r1", KubeNs: "default", LinkUid: 1, HowCreated: "CNI_ADD_PEER", WireType: "veth"}); err != nil {
+		t.Fatal(err)
+	}
+	if up := wiresUp("r1"); up != 2 {
+		t.Errorf("wires_up of r1 = %d once r2 has set up their wire, want 2", up)
+	}
+	// and uncounted when r2 is deleted
+	if _, err := m.SkipReverse(ctx, &mpb.SkipQuery{Pod: "r2", Peer: "r1", KubeNs: "default"}); err != nil {
+		t.Fatal(err)
+	}
+	if up := wiresUp("r1"); up != 1 {
+		t.Errorf("wires_up of r1 = %d once r2 is deleted, want 1", up)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+
+	if resp, err := m.Skip(ctx, &mpb.SkipQuery{Pod: "r1", Peer: "r2", KubeNs: "default"}); err != nil || !resp.Response {
+		t.Fatalf("Skip() = %v, %v", resp, err)
+	}
+	meshnettest.AssertSkipped(t, m, "r1", "r2")
+	if resp, err := m.IsSkipped(ctx, &mpb.SkipQuery{Pod: "r2", Peer: "r1", KubeNs: "default"}); err != nil || !resp.Response {
+		t.Errorf("IsSkipped() = %v, %v, want true", resp, err)
+	}
+
+	if resp, err := m.SkipReverse(ctx, &mpb.SkipQuery{Pod: "r2", Peer: "r1", KubeNs: "default"}); err != nil || !resp.Response {
+		t.Fatalf("SkipReverse() = %v, %v", resp, err)
+	}
+	meshnettest.AssertSkipped(t, m, "r1", "r2")
+
+	if _, err := m.Skip(ctx, &mpb.SkipQuery{Pod: "r3", Peer: "r1", KubeNs: "default"}); err == nil {
+		t.Errorf("Skip() of a missing pod didn't fail")
+	}
+}
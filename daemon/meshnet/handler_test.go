@@ -0,0 +1,146 @@
+package meshnet
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestSkippedBy(t *testing.T) {
+	tests := []struct {
+		desc    string
+		skipped []interface{}
+		pod     string
+		want    bool
+	}{
+		{
+			desc:    "skipped",
+			skipped: []interface{}{"r1", "r2"},
+			pod:     "r2",
+			want:    true,
+		},
+		{
+			desc:    "not skipped",
+			skipped: []interface{}{"r1"},
+			pod:     "r2",
+		},
+		{
+			desc: "no skipped list",
+			pod:  "r2",
+		},
+		{
+			desc:    "malformed entries",
+			skipped: []interface{}{int64(1), map[string]interface{}{"name": "r2"}, nil, "r2"},
+			pod:     "r2",
+			want:    true,
+		},
+		{
+			desc:    "only malformed entries",
+			skipped: []interface{}{int64(1), true},
+			pod:     "r2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tt.skipped != nil {
+				obj.Object["status"] = map[string]interface{}{"skipped": tt.skipped}
+			}
+			if got := skippedBy(obj, tt.pod); got != tt.want {
+				t.Errorf("skippedBy(%v, %q) = %v, want %v", tt.skipped, tt.pod, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSRv6Link(t *testing.T) {
+	link := &mpb.Link{
+		Uid: 1, PeerPod: "r2", LocalIntf: "eth1", PeerIntf: "eth1", LocalIp: "10.0.0.1/30",
+		Srv6Segments: []string{"fc00::1", "fc00::b"},
+		Srv6LocalSid: "fc00::a",
+	}
+	links, err := parseLinks([]interface{}{linkToMap(link)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(links[0], link) {
+		t.Errorf("parseLinks(linkToMap()) = %v, want %v", links[0], link)
+	}
+}
+
+func TestWiresUp(t *testing.T) {
+	link := func(peer string) interface{} {
+		return map[string]interface{}{"peer_pod": peer}
+	}
+	tests := []struct {
+		desc  string
+		links []interface{}
+		alive map[string]bool
+		want  int64
+	}{
+		{desc: "no links"},
+		{desc: "localhost links are up", links: []interface{}{link(localhost), link(localhost)}, want: 2},
+		{desc: "alive peer", links: []interface{}{link("r2")}, alive: map[string]bool{"r2": true}, want: 1},
+		{desc: "peer not alive", links: []interface{}{link("r2")}, alive: map[string]bool{"r2": false}},
+		{desc: "missing peer", links: []interface{}{link("r2"), link(localhost)}, alive: map[string]bool{}, want: 1},
+		{
+			desc:  "several links to the same peer",
+			links: []interface{}{link("r2"), link("r2"), link("r3")},
+			alive: map[string]bool{"r2": true, "r3": false},
+			want:  2,
+		},
+		{desc: "malformed link", links: []interface{}{"r2", link("r2")}, alive: map[string]bool{"r2": true}, want: 1},
+	}
+	for _, tt := range tests {
+		if got := wiresUp(tt.links, tt.alive); got != tt.want {
+			t.Errorf("%s: wiresUp() = %d, want %d", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestSetWireCount(t *testing.T) {
+	topology := func(srcIP string, up int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"links": []interface{}{
+				map[string]interface{}{"peer_pod": localhost},
+				map[string]interface{}{"peer_pod": "r2"},
+			}},
+			"status": map[string]interface{}{"src_ip": srcIP, "wires_up": up},
+		}}
+	}
+	count := func(obj *unstructured.Unstructured) (int64, int64) {
+		up, _, _ := unstructured.NestedInt64(obj.Object, "status", "wires_up")
+		total, _, _ := unstructured.NestedInt64(obj.Object, "status", "wires_total")
+		return up, total
+	}
+
+	obj := topology("10.0.0.1", 0)
+	setWireCount(obj, map[string]bool{"r2": true})
+	if up, total := count(obj); up != 2 || total != 2 {
+		t.Errorf("setWireCount() of an alive pod = %d/%d, want 2/2", up, total)
+	}
+	obj = topology("", 2)
+	setWireCount(obj, map[string]bool{"r2": true})
+	if up, total := count(obj); up != 0 || total != 2 {
+		t.Errorf("setWireCount() of a pod that isn't alive = %d/%d, want 0/2", up, total)
+	}
+
+	for _, tt := range []struct {
+		up, delta, want int64
+	}{
+		{up: 1, delta: 1, want: 2},
+		// a wire set up again isn't counted twice
+		{up: 2, delta: 1, want: 2},
+		{up: 1, delta: -1, want: 0},
+		{up: 0, delta: -1, want: 0},
+	} {
+		obj := topology("10.0.0.1", tt.up)
+		addWiresUp(obj, tt.delta)
+		if up, _ := count(obj); up != tt.want {
+			t.Errorf("addWiresUp(%d) to %d = %d, want %d", tt.delta, tt.up, up, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	healthCheckTimeout = 5 * time.Second
+	// how long the number of active wires is reused for
+	activeWiresTTL = 30 * time.Second
+)
+
+// tasks keeps track of the daemon's background goroutines
+type tasks struct {
+	mu      sync.Mutex
+	crashed map[string]string
+	running sync.WaitGroup
+}
+
+// Go runs fn in the background. The task is reported as crashed by HealthCheck
+// if it panics or returns before stopCh is closed.
+func (m *Meshnet) Go(name string, stopCh <-chan struct{}, fn func(<-chan struct{})) {
+	m.tasks.running.Add(1)
+	go func() {
+		defer m.tasks.running.Done()
+		defer func() {
+			reason := "exited"
+			if r := recover(); r != nil {
+				reason = fmt.Sprintf("panic: %v", r)
+			} else {
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+			}
+			log.Errorf("Background task %s has stopped: %s", name, reason)
+			m.tasks.mu.Lock()
+			m.tasks.crashed[name] = reason
+			m.tasks.mu.Unlock()
+		}()
+		fn(stopCh)
+	}()
+}
+
+func (m *Meshnet) crashedTasks() []string {
+	m.tasks.mu.Lock()
+	defer m.tasks.mu.Unlock()
+	var result []string
+	for name, reason := range m.tasks.crashed {
+		result = append(result, fmt.Sprintf("%s (%s)", name, reason))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// HealthCheck checks that the daemon can reach K8s and that its background tasks are running.
+// Either failing makes the daemon DEGRADED, which a restart doesn't fix.
+func (m *Meshnet) HealthCheck(ctx context.Context, _ *mpb.HealthRequest) (*mpb.HealthResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	resp := &mpb.HealthResponse{
+		Status:               mpb.HealthStatus_HEALTHY,
+		ActiveWires:          m.activeWires(ctx),
+		UnreachablePeers:     m.peers.unreachable(),
+		WireWatchdogRestarts: m.watchdog.total(),
+		ActiveWiresLimit:     int64(m.config.MaxActiveWires),
+		ActiveWiresCurrent:   int64(m.wires.active()),
+	}
+	var problems []string
+	if err := m.probeK8s(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to reach K8s API: %v", err))
+	}
+	if crashed := m.crashedTasks(); len(crashed) > 0 {
+		problems = append(problems, "stopped background tasks: "+strings.Join(crashed, ", "))
+	}
+	if len(problems) > 0 {
+		resp.Status = mpb.HealthStatus_DEGRADED
+		resp.Message = strings.Join(problems, "; ")
+	}
+	return resp, nil
+}
+
+// probeK8s reads the daemon's own namespace, or the API server version if it's unknown
+func (m *Meshnet) probeK8s(ctx context.Context) error {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		_, err := m.kClient.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
+		return err
+	}
+	_, err := m.kClient.Discovery().ServerVersion()
+	return err
+}
+
+// wireCount caches the number of wires that are up on this node, so that probes and resource
+// reports don't list the topologies of the whole cluster each time
+type wireCount struct {
+	mu    sync.Mutex
+	at    time.Time
+	value int64
+}
+
+// get returns the last count if it's younger than activeWiresTTL, or counts again. The last
+// count is kept if that fails.
+func (c *wireCount) get(now time.Time, count func() (int64, error)) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.at.IsZero() && now.Sub(c.at) < activeWiresTTL {
+		return c.value
+	}
+	value, err := count()
+	if err != nil {
+		log.Warnf("Failed to count the active wires: %v", err)
+		return c.value
+	}
+	c.at, c.value = now, value
+	return value
+}
+
+// activeWires sums up the wires that are up for all topology pods running on this node
+func (m *Meshnet) activeWires(ctx context.Context) int64 {
+	return m.activeWireCount.get(time.Now(), func() (int64, error) {
+		topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return 0, err
+		}
+		hostIP := os.Getenv("HOST_IP")
+		var result int64
+		for _, t := range topologies.Items {
+			if t.Status.SrcIp != "" && t.Status.SrcIp == hostIP {
+				result += t.Status.WiresUp
+			}
+		}
+		return result, nil
+	})
+}
+
+// ServeHealth serves the HealthCheck RPC as HTTP endpoints for kubelet probes: /healthz fails
+// only when the daemon isn't processing requests, /readyz when it isn't HEALTHY either.
+// The RPC is called over the daemon's gRPC server, to make sure it's processing requests.
+// The daemon's Prometheus metrics are served on /metrics.
+func (m *Meshnet) ServeHealth(addr string) error {
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", m.config.Port), grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := mpb.NewLocalClient(conn)
+	check := func(ctx context.Context) (*mpb.HealthResponse, error) {
+		return client.HealthCheck(ctx, &mpb.HealthRequest{})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", healthHandler(check, false))
+	mux.HandleFunc("/readyz", healthHandler(check, true))
+	mux.Handle("/metrics", promhttp.Handler())
+	log.Infof("Health endpoint has started on %s", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// healthHandler serves the result of check, failing if the daemon is UNHEALTHY, or not
+// HEALTHY with ready
+func healthHandler(check func(context.Context) (*mpb.HealthResponse, error), ready bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+
+		resp, err := check(ctx)
+		if err != nil {
+			resp = &mpb.HealthResponse{
+				Status:  mpb.HealthStatus_UNHEALTHY,
+				Message: fmt.Sprintf("gRPC server is not responding: %v", err),
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Status == mpb.HealthStatus_UNHEALTHY || (ready && resp.Status != mpb.HealthStatus_HEALTHY) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":       resp.Status.String(),
+			"active_wires": resp.ActiveWires,
+			"message":      resp.Message,
+		})
+	}
+}
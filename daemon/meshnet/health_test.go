@@ -0,0 +1,99 @@
+package meshnet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestHealthCheck(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "meshnet")
+	client := fake.NewSimpleClientset()
+	m, err := NewWithClients(Config{DisableReflection: true}, client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the topologies aren't listed while the count is fresh
+	m.activeWireCount = wireCount{at: time.Now(), value: 3}
+
+	resp, err := m.HealthCheck(context.Background(), &mpb.HealthRequest{})
+	if err != nil || resp.Status != mpb.HealthStatus_DEGRADED {
+		t.Fatalf("HealthCheck() = %v, %v without the namespace, want DEGRADED", resp, err)
+	}
+
+	client.PrependReactor("get", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+	resp, err = m.HealthCheck(context.Background(), &mpb.HealthRequest{})
+	if err != nil || resp.Status != mpb.HealthStatus_HEALTHY || resp.ActiveWires != 3 {
+		t.Errorf("HealthCheck() = %v, %v, want HEALTHY with 3 active wires", resp, err)
+	}
+
+	m.Go("crashing", make(chan struct{}), func(<-chan struct{}) {})
+	m.tasks.running.Wait()
+	resp, err = m.HealthCheck(context.Background(), &mpb.HealthRequest{})
+	if err != nil || resp.Status != mpb.HealthStatus_DEGRADED {
+		t.Errorf("HealthCheck() = %v, %v with a crashed task, want DEGRADED", resp, err)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		desc        string
+		status      mpb.HealthStatus
+		err         error
+		live, ready int
+	}{
+		{desc: "healthy", status: mpb.HealthStatus_HEALTHY, live: http.StatusOK, ready: http.StatusOK},
+		{desc: "K8s unreachable", status: mpb.HealthStatus_DEGRADED, live: http.StatusOK, ready: http.StatusServiceUnavailable},
+		{desc: "gRPC down", err: errors.New("unavailable"), live: http.StatusServiceUnavailable, ready: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		check := func(context.Context) (*mpb.HealthResponse, error) {
+			if tt.err != nil {
+				return nil, tt.err
+			}
+			return &mpb.HealthResponse{Status: tt.status}, nil
+		}
+		for ready, want := range map[bool]int{false: tt.live, true: tt.ready} {
+			rec := httptest.NewRecorder()
+			healthHandler(check, ready)(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Code != want {
+				t.Errorf("%s: ready=%v returned %d, want %d", tt.desc, ready, rec.Code, want)
+			}
+		}
+	}
+}
+
+func TestWireCount(t *testing.T) {
+	var c wireCount
+	calls := 0
+	count := func(n int64, err error) func() (int64, error) {
+		return func() (int64, error) {
+			calls++
+			return n, err
+		}
+	}
+	now := time.Now()
+	if got := c.get(now, count(2, nil)); got != 2 || calls != 1 {
+		t.Fatalf("get() = %d after %d counts, want 2 after 1", got, calls)
+	}
+	if got := c.get(now.Add(activeWiresTTL/2), count(5, nil)); got != 2 || calls != 1 {
+		t.Errorf("get() = %d after %d counts within the TTL, want 2 after 1", got, calls)
+	}
+	if got := c.get(now.Add(activeWiresTTL), count(0, errors.New("unreachable"))); got != 2 || calls != 2 {
+		t.Errorf("get() = %d after %d counts when counting fails, want the last count 2 after 2", got, calls)
+	}
+	if got := c.get(now.Add(activeWiresTTL), count(5, nil)); got != 5 || calls != 3 {
+		t.Errorf("get() = %d after %d counts, want 5 after 3", got, calls)
+	}
+}
@@ -0,0 +1,208 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	reasonPeerUnreachable       = "PeerUnreachable"
+	reasonWireRestored          = "WireRestored"
+	reasonWireNeedsIntervention = "WireNeedsIntervention"
+)
+
+// peerWire is a wire of a pod on this node whose peer is running on another node
+type peerWire struct {
+	ns, pod string
+	uid     int64
+}
+
+// wiresByPeer groups the wires of the pods running on hostIP by the node of their peer
+func wiresByPeer(topologies []topologyv1.Topology, hostIP string) map[string][]peerWire {
+	nodes := make(map[string]string)
+	for _, t := range topologies {
+		nodes[t.Namespace+"/"+t.Name] = t.Status.SrcIp
+	}
+	result := make(map[string][]peerWire)
+	for _, t := range topologies {
+		if t.Status.SrcIp != hostIP || t.Status.NetNs == "" {
+			continue
+		}
+		for _, l := range t.Spec.Links {
+			if l.PeerPod == localhost {
+				continue
+			}
+			node := nodes[t.Namespace+"/"+l.PeerPod]
+			if node == "" || node == hostIP {
+				continue
+			}
+			result[node] = append(result[node], peerWire{ns: t.Namespace, pod: t.Name, uid: int64(l.UID)})
+		}
+	}
+	return result
+}
+
+// peerTracker keeps the nodes whose daemon has stopped answering heartbeats
+type peerTracker struct {
+	mu   sync.Mutex
+	down map[string]time.Time
+}
+
+func newPeerTracker() *peerTracker {
+	return &peerTracker{down: make(map[string]time.Time)}
+}
+
+// setDown records whether the daemon of node is unreachable and returns true if it has changed
+func (p *peerTracker) setDown(node string, down bool, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, wasDown := p.down[node]
+	if down && !wasDown {
+		p.down[node] = now
+	}
+	if !down {
+		delete(p.down, node)
+	}
+	return down != wasDown
+}
+
+// retain forgets the nodes that no wire leads to anymore
+func (p *peerTracker) retain(nodes map[string][]peerWire) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for node := range p.down {
+		if _, ok := nodes[node]; !ok {
+			delete(p.down, node)
+		}
+	}
+}
+
+// unreachable returns the nodes which are down, with the time since when
+func (p *peerTracker) unreachable() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var result []string
+	for node, since := range p.down {
+		result = append(result, fmt.Sprintf("%s (since %s)", node, since.UTC().Format(time.RFC3339)))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Heartbeat answers the heartbeats of the daemons of other nodes.
+func (m *Meshnet) Heartbeat(ctx context.Context, req *mpb.HeartbeatRequest) (*mpb.HeartbeatResponse, error) {
+	log.Debugf("Received a heartbeat from %s", req.NodeIp)
+	return &mpb.HeartbeatResponse{NodeIp: os.Getenv("HOST_IP")}, nil
+}
+
+// Heartbeats sends a heartbeat to the daemon of every node the wires of this node lead to,
+// every HeartbeatInterval, until stopCh is closed. The wires to a node whose daemon doesn't
+// answer within HeartbeatTimeout are considered down, and re-created once it answers again.
+func (m *Meshnet) Heartbeats(stopCh <-chan struct{}) {
+	if m.config.HeartbeatInterval <= 0 {
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(m.config.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			m.checkPeers(context.Background())
+		}
+	}
+}
+
+func (m *Meshnet) checkPeers(ctx context.Context) {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed to list topologies: %s", err)
+		return
+	}
+	hostIP := os.Getenv("HOST_IP")
+	peers := wiresByPeer(topologies.Items, hostIP)
+	m.peers.retain(peers)
+
+	var wg sync.WaitGroup
+	for node, wires := range peers {
+		wg.Add(1)
+		go func(node string, wires []peerWire) {
+			defer wg.Done()
+			err := m.sendHeartbeat(ctx, node, hostIP)
+			if !m.peers.setDown(node, err != nil, time.Now()) {
+				return
+			}
+			if err != nil {
+				m.peerLost(node, wires, err)
+			} else {
+				m.peerRestored(ctx, node, wires)
+			}
+		}(node, wires)
+	}
+	wg.Wait()
+}
+
+func (m *Meshnet) sendHeartbeat(ctx context.Context, node, hostIP string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.config.HeartbeatTimeout)
+	defer cancel()
+	conn, err := m.peerConn(ctx, net.JoinHostPort(node, fmt.Sprint(m.config.Port)))
+	if err != nil {
+		return err
+	}
+	_, err = mpb.NewRemoteClient(conn).Heartbeat(ctx, &mpb.HeartbeatRequest{NodeIp: hostIP})
+	return err
+}
+
+// peerLost records the wires to node as down
+func (m *Meshnet) peerLost(node string, wires []peerWire, err error) {
+	log.Warnf("The daemon of node %s hasn't answered a heartbeat, %d wires are down: %s", node, len(wires), err)
+	for _, w := range wires {
+		topologyEvent(m.dlq.recorder, w.ns, w.pod, corev1.EventTypeWarning, reasonPeerUnreachable,
+			"Link %d is down, the daemon of node %s hasn't answered a heartbeat within %s: %v", w.uid, node, m.config.HeartbeatTimeout, err)
+		m.recordEvent(context.Background(), w.ns, w.pod, mpb.TopologyEvent_FAIL, w.uid, nil, nil, "the daemon of node %s is unreachable", node)
+	}
+}
+
+// peerRestored re-creates the wires to node, which may have changed while it was unreachable
+func (m *Meshnet) peerRestored(ctx context.Context, node string, wires []peerWire) {
+	log.Infof("The daemon of node %s is reachable again, re-validating %d wires", node, len(wires))
+	for _, w := range wires {
+		err := m.revalidate(ctx, w)
+		m.recordWire(err)
+		if err != nil {
+			topologyEvent(m.dlq.recorder, w.ns, w.pod, corev1.EventTypeWarning, reasonWireNeedsIntervention,
+				"Failed to restore link %d towards node %s, it has to be re-created: %v", w.uid, node, err)
+			continue
+		}
+		topologyEvent(m.dlq.recorder, w.ns, w.pod, corev1.EventTypeNormal, reasonWireRestored,
+			"Link %d towards node %s has been restored", w.uid, node)
+		m.recordEvent(ctx, w.ns, w.pod, mpb.TopologyEvent_RECOVER, w.uid, nil, nil, "the daemon of node %s is reachable again", node)
+	}
+}
+
+func (m *Meshnet) revalidate(ctx context.Context, w peerWire) error {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: w.pod, KubeNs: w.ns})
+	if err != nil {
+		return err
+	}
+	link := linkByUID(pod.Links, w.uid)
+	if link == nil {
+		// the link has been removed in the meantime
+		return nil
+	}
+	return m.createWire(ctx, w.pod, w.ns, link)
+}
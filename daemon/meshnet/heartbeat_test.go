@@ -0,0 +1,68 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func runningTopology(name, srcIP string, peers ...string) topologyv1.Topology {
+	t := topologyv1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     topologyv1.TopologyStatus{SrcIp: srcIP, NetNs: "/var/run/netns/" + name},
+	}
+	for i, p := range peers {
+		t.Spec.Links = append(t.Spec.Links, topologyv1.Link{PeerPod: p, UID: i + 1})
+	}
+	return t
+}
+
+func TestWiresByPeer(t *testing.T) {
+	topologies := []topologyv1.Topology{
+		runningTopology("r1", "10.0.0.1", "r2", "r3", "localhost", "r4"),
+		runningTopology("r2", "10.0.0.1", "r1"),
+		runningTopology("r3", "10.0.0.2", "r1", "r4"),
+		// not running
+		runningTopology("r4", "", "r1", "r3"),
+	}
+	got := wiresByPeer(topologies, "10.0.0.1")
+	want := map[string][]peerWire{
+		"10.0.0.2": {{ns: "default", pod: "r1", uid: 2}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wiresByPeer() = %v, want %v", got, want)
+	}
+}
+
+func TestPeerTracker(t *testing.T) {
+	p := newPeerTracker()
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		node    string
+		down    bool
+		changed bool
+	}{
+		{"10.0.0.2", false, false},
+		{"10.0.0.2", true, true},
+		{"10.0.0.2", true, false},
+		{"10.0.0.3", true, true},
+		{"10.0.0.2", false, true},
+	}
+	for i, tt := range tests {
+		if changed := p.setDown(tt.node, tt.down, now); changed != tt.changed {
+			t.Errorf("#%d test failed: changed = %v, want %v", i, changed, tt.changed)
+		}
+	}
+	want := []string{"10.0.0.3 (since 2021-01-01T00:00:00Z)"}
+	if got := p.unreachable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unreachable() = %v, want %v", got, want)
+	}
+	p.retain(map[string][]peerWire{"10.0.0.2": nil})
+	if got := p.unreachable(); len(got) != 0 {
+		t.Errorf("unreachable() after retain = %v, want none", got)
+	}
+}
@@ -0,0 +1,206 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	historyPrefix = "meshnet-history-"
+	// number of revisions kept per topology when no limit is configured
+	defaultHistoryLimit = 10
+)
+
+func historyName(topology string) string {
+	return historyPrefix + topology
+}
+
+// snapshot stores the current links of a topology as a new revision in its history ConfigMap
+func (m *Meshnet) snapshot(ctx context.Context, name, ns string) error {
+	obj, err := m.getPod(ctx, name, ns)
+	if err != nil {
+		return err
+	}
+	links, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+	data, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cms := m.kClient.CoreV1().ConfigMaps(ns)
+		cm, err := cms.Get(ctx, historyName(name), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: historyName(name)}}
+			rev := addRevision(cm, string(data), m.historyLimit())
+			log.Infof("Recorded revision %d of topology %s", rev, name)
+			_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		rev := addRevision(cm, string(data), m.historyLimit())
+		log.Infof("Recorded revision %d of topology %s", rev, name)
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (m *Meshnet) historyLimit() int {
+	if m.config.HistoryLimit > 0 {
+		return m.config.HistoryLimit
+	}
+	return defaultHistoryLimit
+}
+
+// revisions returns the sorted revision numbers of a history ConfigMap
+func revisions(cm *corev1.ConfigMap) []int {
+	var result []int
+	for k := range cm.Data {
+		if rev, err := strconv.Atoi(k); err == nil {
+			result = append(result, rev)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// addRevision stores spec under the next revision number and evicts the oldest
+// revisions beyond limit
+func addRevision(cm *corev1.ConfigMap, spec string, limit int) int {
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	revs := revisions(cm)
+	rev := 1
+	if len(revs) > 0 {
+		rev = revs[len(revs)-1] + 1
+	}
+	cm.Data[strconv.Itoa(rev)] = spec
+	revs = append(revs, rev)
+	for len(revs) > limit {
+		delete(cm.Data, strconv.Itoa(revs[0]))
+		revs = revs[1:]
+	}
+	return rev
+}
+
+// RollbackTopology restores the links of a topology from a revision in its history.
+// Links that differ from the revision are removed, added or updated one by one, so that
+// their wires (and the peers' topologies) are rebuilt the same way as with PatchLink.
+func (m *Meshnet) RollbackTopology(ctx context.Context, req *mpb.RollbackRequest) (*mpb.BoolResponse, error) {
+	log.Infof("Rolling back topology %s to revision %d", req.Name, req.Revision)
+
+	if err := m.rollback(ctx, req); err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"function": "RollbackTopology",
+		}).Errorf("Failed to roll back topology %s", req.Name)
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+func (m *Meshnet) rollback(ctx context.Context, req *mpb.RollbackRequest) error {
+	cm, err := m.kClient.CoreV1().ConfigMaps(req.KubeNs).Get(ctx, historyName(req.Name), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	spec, ok := cm.Data[strconv.FormatInt(req.Revision, 10)]
+	if !ok {
+		return fmt.Errorf("revision %d of topology %s not found", req.Revision, req.Name)
+	}
+	// Decoding integers as int64 rather than float64, as the unstructured helpers expect
+	var raw []interface{}
+	if err := utiljson.Unmarshal([]byte(spec), &raw); err != nil {
+		return fmt.Errorf("failed to parse revision %d: %v", req.Revision, err)
+	}
+	target, err := parseLinks(raw)
+	if err != nil {
+		return err
+	}
+
+	// Comparing the links as they're stored, without the defaults added by Get
+	obj, err := m.getPod(ctx, req.Name, req.KubeNs)
+	if err != nil {
+		return err
+	}
+	stored, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+	current, err := parseLinks(stored)
+	if err != nil {
+		return err
+	}
+	// The rollback itself can be rolled back
+	if err := m.snapshot(ctx, req.Name, req.KubeNs); err != nil {
+		return err
+	}
+
+	add, remove, update := diffLinks(current, target)
+	for _, link := range remove {
+		if err := m.removeLink(ctx, req.Name, req.KubeNs, link); err != nil {
+			return err
+		}
+	}
+	for _, link := range update {
+		if err := m.updateLink(ctx, req.Name, req.KubeNs, link); err != nil {
+			return err
+		}
+	}
+	for _, link := range add {
+		if err := m.addLink(ctx, req.Name, req.KubeNs, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffLinks matches links by UID and returns the changes that turn current into target.
+// Links whose wire has changed are replaced, while updateLink only re-applies impairments.
+func diffLinks(current, target []*mpb.Link) (add, remove, update []*mpb.Link) {
+	byUID := make(map[int64]*mpb.Link, len(current))
+	for _, l := range current {
+		byUID[l.Uid] = l
+	}
+	for _, t := range target {
+		c, ok := byUID[t.Uid]
+		delete(byUID, t.Uid)
+		switch {
+		case !ok:
+			add = append(add, t)
+		case !sameWire(c, t):
+			remove = append(remove, c)
+			add = append(add, t)
+		case !proto.Equal(c, t):
+			update = append(update, t)
+		}
+	}
+	for _, l := range current {
+		if _, ok := byUID[l.Uid]; ok {
+			remove = append(remove, l)
+		}
+	}
+	return add, remove, update
+}
+
+func sameWire(a, b *mpb.Link) bool {
+	return a.PeerPod == b.PeerPod && a.PeerIntf == b.PeerIntf && a.LocalIntf == b.LocalIntf &&
+		a.LocalIp == b.LocalIp && a.PeerIp == b.PeerIp && a.VxlanGpe == b.VxlanGpe
+}
@@ -0,0 +1,57 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestAddRevision(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	for i := 1; i <= 4; i++ {
+		if rev := addRevision(cm, "[]", 3); rev != i {
+			t.Fatalf("addRevision() = %d, want %d", rev, i)
+		}
+	}
+	if got, want := revisions(cm), []int{2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("revisions() = %v, want %v", got, want)
+	}
+
+	// Revision numbers keep growing after the oldest ones are evicted
+	cm.Data["10"] = "[]"
+	if rev := addRevision(cm, "[]", 3); rev != 11 {
+		t.Fatalf("addRevision() = %d, want 11", rev)
+	}
+	if got, want := revisions(cm), []int{4, 10, 11}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("revisions() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffLinks(t *testing.T) {
+	kept := &mpb.Link{Uid: 1, PeerPod: "r2", LocalIntf: "eth1", PeerIntf: "eth1"}
+	impaired := &mpb.Link{Uid: 2, PeerPod: "r3", LocalIntf: "eth2", PeerIntf: "eth1"}
+	moved := &mpb.Link{Uid: 3, PeerPod: "r4", LocalIntf: "eth3", PeerIntf: "eth1"}
+	removed := &mpb.Link{Uid: 4, PeerPod: "r5", LocalIntf: "eth4", PeerIntf: "eth1"}
+	added := &mpb.Link{Uid: 5, PeerPod: "r6", LocalIntf: "eth5", PeerIntf: "eth1"}
+
+	impairedTarget := &mpb.Link{Uid: 2, PeerPod: "r3", LocalIntf: "eth2", PeerIntf: "eth1",
+		EgressImpairment: &mpb.ImpairmentSpec{LatencyMs: 10}}
+	movedTarget := &mpb.Link{Uid: 3, PeerPod: "r7", LocalIntf: "eth3", PeerIntf: "eth1"}
+
+	add, remove, update := diffLinks(
+		[]*mpb.Link{kept, impaired, moved, removed},
+		[]*mpb.Link{kept, impairedTarget, movedTarget, added},
+	)
+	if want := []*mpb.Link{movedTarget, added}; !reflect.DeepEqual(add, want) {
+		t.Errorf("add = %v, want %v", add, want)
+	}
+	if want := []*mpb.Link{moved, removed}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("remove = %v, want %v", remove, want)
+	}
+	if want := []*mpb.Link{impairedTarget}; !reflect.DeepEqual(update, want) {
+		t.Errorf("update = %v, want %v", update, want)
+	}
+}
@@ -0,0 +1,156 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// PatchWireImpairment changes the impairments of the end of a link in a pod while its wire
+// is up, through the daemon of the node the pod is running on. The change isn't recorded in
+// the topology, so a re-created wire has the impairments of the topology again.
+func (m *Meshnet) PatchWireImpairment(ctx context.Context, p *mpb.ImpairmentPatch) (*mpb.BoolResponse, error) {
+	if err := validatePatch(p); err != nil {
+		return nil, err
+	}
+	nodeIP, err := m.linkNode(ctx, p.KubeNs, p.Pod, p.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.PatchLinkImpairment(ctx, p)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, p.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	return client.PatchLinkImpairment(ctx, p)
+}
+
+// GetWireImpairment returns the impairments of the end of a link in a pod, as set in the
+// kernel of the node the pod is running on
+func (m *Meshnet) GetWireImpairment(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.WireImpairment, error) {
+	nodeIP, err := m.linkNode(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.GetLinkImpairment(ctx, q)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetLinkImpairment(ctx, q)
+}
+
+// PatchLinkImpairment changes the impairments of the end of a link in a pod running on this
+// node, without re-creating its netem qdiscs
+func (m *Meshnet) PatchLinkImpairment(ctx context.Context, p *mpb.ImpairmentPatch) (*mpb.BoolResponse, error) {
+	if err := validatePatch(p); err != nil {
+		return nil, err
+	}
+	pod, link, err := m.runningLink(ctx, p.KubeNs, p.Pod, p.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	egress, ingress := p.Egress, p.Ingress
+	if p.Relative {
+		current, err := m.GetLinkImpairment(ctx, &mpb.LinkStatsQuery{Pod: p.Pod, KubeNs: p.KubeNs, LinkUid: p.LinkUid})
+		if err != nil {
+			return nil, err
+		}
+		egress, ingress = addImpairment(current.Egress, p.Egress), addImpairment(current.Ingress, p.Ingress)
+		for _, spec := range []*mpb.ImpairmentSpec{egress, ingress} {
+			if err := impairment.Validate(spec); err != nil {
+				return nil, wireError(codes.InvalidArgument, &mpb.WireError{Operation: mpb.WireError_UPDATE, WireUid: p.LinkUid},
+					"patched impairment of link %d of pod %s: %s", p.LinkUid, p.Pod, err)
+			}
+		}
+	}
+	if err := impairment.Patch(pod.NetNs, link.LocalIntf, egress, ingress); err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{Operation: mpb.WireError_UPDATE, WireUid: p.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to patch the impairment of %s in pod %s: %s", link.LocalIntf, p.Pod, err)
+	}
+	log.Infof("Patched the impairment of %s in pod %s", link.LocalIntf, p.Pod)
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// GetLinkImpairment returns the impairments of the end of a link in a pod running on this node
+func (m *Meshnet) GetLinkImpairment(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.WireImpairment, error) {
+	pod, link, err := m.runningLink(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	egress, ingress, err := impairment.Read(pod.NetNs, link.LocalIntf)
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{WireUid: q.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to read the impairment of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+	}
+	return &mpb.WireImpairment{Egress: egress, Ingress: ingress}, nil
+}
+
+// linkNode returns the IP of the node that the pod with the link uid is running on
+func (m *Meshnet) linkNode(ctx context.Context, ns, name string, uid int64) (string, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: name, KubeNs: ns})
+	if err != nil {
+		return "", err
+	}
+	if linkByUID(pod.Links, uid) == nil {
+		return "", wireError(codes.NotFound, &mpb.WireError{WireUid: uid}, "pod %s has no link %d", name, uid)
+	}
+	if pod.SrcIp == "" {
+		return "", wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: uid}, "pod %s is not running", name)
+	}
+	return pod.SrcIp, nil
+}
+
+// validatePatch checks the specs of an absolute patch, relative ones can only be checked
+// once they're added to the current impairments
+func validatePatch(p *mpb.ImpairmentPatch) error {
+	if p.Egress == nil && p.Ingress == nil {
+		return status.Errorf(codes.InvalidArgument, "the patch of link %d of pod %s changes nothing", p.LinkUid, p.Pod)
+	}
+	if p.Relative {
+		return nil
+	}
+	for _, spec := range []*mpb.ImpairmentSpec{p.Egress, p.Ingress} {
+		if err := impairment.Validate(spec); err != nil {
+			return wireError(codes.InvalidArgument, &mpb.WireError{Operation: mpb.WireError_UPDATE, WireUid: p.LinkUid},
+				"impairment of link %d of pod %s: %s", p.LinkUid, p.Pod, err)
+		}
+	}
+	return nil
+}
+
+// addImpairment returns current with delta added, nil if delta is nil. ECN can be enabled
+// but not disabled by a delta, and its reorder gap replaces the current one.
+func addImpairment(current, delta *mpb.ImpairmentSpec) *mpb.ImpairmentSpec {
+	if delta == nil {
+		return nil
+	}
+	result := &mpb.ImpairmentSpec{}
+	if current != nil {
+		result = proto.Clone(current).(*mpb.ImpairmentSpec)
+	}
+	result.LatencyMs += delta.LatencyMs
+	result.JitterMs += delta.JitterMs
+	result.LossPercent += delta.LossPercent
+	result.DuplicatePercent += delta.DuplicatePercent
+	result.CorruptPercent += delta.CorruptPercent
+	result.EcnEnabled = result.EcnEnabled || delta.EcnEnabled
+	result.EcnThresholdPercent += delta.EcnThresholdPercent
+	result.ReorderPercent += delta.ReorderPercent
+	result.ReorderCorrelationPercent += delta.ReorderCorrelationPercent
+	if delta.ReorderGap != 0 {
+		result.ReorderGap = delta.ReorderGap
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestPatchWireImpairment(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	tests := []struct {
+		patch *mpb.ImpairmentPatch
+		code  codes.Code
+	}{
+		{patch: &mpb.ImpairmentPatch{Pod: "r1", KubeNs: "default", LinkUid: 1, Egress: &mpb.ImpairmentSpec{LatencyMs: -1}}, code: codes.InvalidArgument},
+		{patch: &mpb.ImpairmentPatch{Pod: "r1", KubeNs: "default", LinkUid: 3, Egress: &mpb.ImpairmentSpec{LatencyMs: 10}}, code: codes.NotFound},
+		{patch: &mpb.ImpairmentPatch{Pod: "r1", KubeNs: "default", LinkUid: 1, Egress: &mpb.ImpairmentSpec{LatencyMs: 10}}, code: codes.FailedPrecondition},
+	}
+	for i, tt := range tests {
+		if _, err := m.PatchWireImpairment(ctx, tt.patch); status.Code(err) != tt.code {
+			t.Errorf("#%d test failed: PatchWireImpairment() = %v, want %s", i, err, tt.code)
+		}
+	}
+	if _, err := m.GetWireImpairment(ctx, &mpb.LinkStatsQuery{Pod: "r1", KubeNs: "default", LinkUid: 1}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetWireImpairment() of a pod that isn't running = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestPatchWireImpairmentNetem(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	netNs := runningPod(t, m, "r1", "eth1")
+	if err := impairment.Apply(netNs.Path(), "eth1", &mpb.ImpairmentSpec{LatencyMs: 10}, nil); err != nil {
+		t.Skipf("can't apply an impairment: %v", err)
+	}
+
+	query := &mpb.LinkStatsQuery{Pod: "r1", KubeNs: "default", LinkUid: 1}
+	tests := []struct {
+		desc            string
+		patch           *mpb.ImpairmentPatch
+		code            codes.Code
+		egress, ingress *mpb.ImpairmentSpec
+	}{
+		{
+			desc:   "absolute egress",
+			patch:  &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{LatencyMs: 20, LossPercent: 1}},
+			egress: &mpb.ImpairmentSpec{LatencyMs: 20, LossPercent: 1},
+		},
+		{
+			desc:   "relative egress",
+			patch:  &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{LatencyMs: 5, JitterMs: 1}, Relative: true},
+			egress: &mpb.ImpairmentSpec{LatencyMs: 25, JitterMs: 1, LossPercent: 1},
+		},
+		{
+			desc:   "relative egress below zero",
+			patch:  &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{LatencyMs: -30}, Relative: true},
+			code:   codes.InvalidArgument,
+			egress: &mpb.ImpairmentSpec{LatencyMs: 25, JitterMs: 1, LossPercent: 1},
+		},
+		{
+			desc:    "ingress added",
+			patch:   &mpb.ImpairmentPatch{Ingress: &mpb.ImpairmentSpec{LatencyMs: 5}},
+			egress:  &mpb.ImpairmentSpec{LatencyMs: 25, JitterMs: 1, LossPercent: 1},
+			ingress: &mpb.ImpairmentSpec{LatencyMs: 5},
+		},
+		{
+			desc:    "egress removed",
+			patch:   &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{}},
+			ingress: &mpb.ImpairmentSpec{LatencyMs: 5},
+		},
+	}
+	for _, tt := range tests {
+		tt.patch.Pod, tt.patch.KubeNs, tt.patch.LinkUid = "r1", "default", 1
+		if _, err := m.PatchWireImpairment(ctx, tt.patch); status.Code(err) != tt.code {
+			t.Fatalf("%s: PatchWireImpairment() = %v, want %s", tt.desc, err, tt.code)
+		}
+		got, err := m.GetWireImpairment(ctx, query)
+		if err != nil {
+			t.Fatalf("%s: GetWireImpairment() failed: %v", tt.desc, err)
+		}
+		if !proto.Equal(got.Egress, tt.egress) || !proto.Equal(got.Ingress, tt.ingress) {
+			t.Errorf("%s: the netem qdiscs of eth1 have %v and %v, want %v and %v", tt.desc, got.Egress, got.Ingress, tt.egress, tt.ingress)
+		}
+	}
+}
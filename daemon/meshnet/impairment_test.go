@@ -0,0 +1,54 @@
+package meshnet
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestAddImpairment(t *testing.T) {
+	tests := []struct {
+		current, delta, want *mpb.ImpairmentSpec
+	}{
+		{current: &mpb.ImpairmentSpec{LatencyMs: 10}, delta: nil, want: nil},
+		{current: nil, delta: &mpb.ImpairmentSpec{LatencyMs: 5}, want: &mpb.ImpairmentSpec{LatencyMs: 5}},
+		{
+			current: &mpb.ImpairmentSpec{LatencyMs: 10, LossPercent: 1},
+			delta:   &mpb.ImpairmentSpec{LatencyMs: -4, JitterMs: 2, LossPercent: 0.5},
+			want:    &mpb.ImpairmentSpec{LatencyMs: 6, JitterMs: 2, LossPercent: 1.5},
+		},
+	}
+	for i, tt := range tests {
+		current := proto.Clone(tt.current)
+		if got := addImpairment(tt.current, tt.delta); !proto.Equal(got, tt.want) {
+			t.Errorf("#%d test failed: addImpairment() = %v, want %v", i, got, tt.want)
+		}
+		if !proto.Equal(current, tt.current) {
+			t.Errorf("#%d test failed: addImpairment() has changed the current impairment", i)
+		}
+	}
+}
+
+func TestValidatePatch(t *testing.T) {
+	tests := []struct {
+		patch *mpb.ImpairmentPatch
+		code  codes.Code
+	}{
+		{patch: &mpb.ImpairmentPatch{}, code: codes.InvalidArgument},
+		{patch: &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{LatencyMs: 10}}, code: codes.OK},
+		{patch: &mpb.ImpairmentPatch{Ingress: &mpb.ImpairmentSpec{}}, code: codes.OK},
+		{patch: &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{LatencyMs: -10}}, code: codes.InvalidArgument},
+		{patch: &mpb.ImpairmentPatch{Ingress: &mpb.ImpairmentSpec{LossPercent: 101}}, code: codes.InvalidArgument},
+		// checked once added to the current impairment
+		{patch: &mpb.ImpairmentPatch{Egress: &mpb.ImpairmentSpec{LatencyMs: -10}, Relative: true}, code: codes.OK},
+	}
+	for i, tt := range tests {
+		if got := status.Code(validatePatch(tt.patch)); got != tt.code {
+			t.Errorf("#%d test failed: validatePatch() = %s, want %s", i, got, tt.code)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package meshnet
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/topotemplate"
+)
+
+// InstantiateTopology creates the topologies of a TopologyTemplate rendered with the parameters
+// of the request. Nothing is created unless the whole template renders. Topologies that already
+// exist are left as they are if they're an instance of the template with the same parameters,
+// and fail the instantiation otherwise.
+func (m *Meshnet) InstantiateTopology(ctx context.Context, req *mpb.InstantiationRequest) (*mpb.BoolResponse, error) {
+	tmpl, err := m.tClient.TopologyTemplate(req.KubeNs).Get(ctx, req.Template, metav1.GetOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read topology template %s", req.Template)
+	}
+	topologies, err := topotemplate.Render(tmpl, req.Parameters)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to render topology template %s: %s", req.Template, err)
+	}
+	for i := range topologies {
+		t := &topologies[i]
+		_, err := m.tClient.Topology(req.KubeNs).Create(ctx, t)
+		if apierrors.IsAlreadyExists(err) {
+			existing, getErr := m.tClient.Topology(req.KubeNs).Get(ctx, t.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return nil, k8sError(getErr, mpb.WireError_NONE, "failed to read topology %s", t.Name)
+			}
+			if !topotemplate.SameInstance(existing.Annotations, t.Annotations) {
+				return nil, status.Errorf(codes.AlreadyExists, "topology %s already exists and isn't an instance of template %s with these parameters", t.Name, req.Template)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, k8sError(err, mpb.WireError_NONE, "failed to create topology %s", t.Name)
+		}
+		log.Infof("Created topology %s/%s from template %s", req.KubeNs, t.Name, req.Template)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
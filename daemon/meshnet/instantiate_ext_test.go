@@ -0,0 +1,62 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestInstantiateTopology(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	meshnettest.Store(m).AddTemplate(&topologyv1.TopologyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "rack", Namespace: "default"},
+		Spec: topologyv1.TopologyTemplateSpec{Topologies: []topologyv1.TemplateTopology{
+			{Name: "{{ .prefix }}-spine", Links: []topologyv1.Link{{UID: 1, PeerPod: "{{ .prefix }}-leaf", LocalIntf: "eth1", PeerIntf: "eth1"}}},
+			{Name: "{{ .prefix }}-leaf", Links: []topologyv1.Link{{UID: 1, PeerPod: "{{ .prefix }}-spine", LocalIntf: "eth1", PeerIntf: "eth1"}}},
+		}},
+	})
+	instantiate := func(params map[string]string) error {
+		_, err := m.InstantiateTopology(ctx, &mpb.InstantiationRequest{Template: "rack", KubeNs: "default", Parameters: params})
+		return err
+	}
+
+	if err := instantiate(map[string]string{"prefix": "a"}); err != nil {
+		t.Fatalf("InstantiateTopology() failed: %v", err)
+	}
+	for _, name := range []string{"a-spine", "a-leaf"} {
+		obj := meshnettest.Store(m).Object("default", name)
+		if obj == nil {
+			t.Fatalf("InstantiateTopology() hasn't created topology %s", name)
+		}
+		if got := obj.GetAnnotations()["meshnet.io/template"]; got != "rack" {
+			t.Errorf("topology %s is annotated with template %q, want rack", name, got)
+		}
+	}
+	// instantiating again with the same parameters changes nothing
+	if err := instantiate(map[string]string{"prefix": "a"}); err != nil {
+		t.Errorf("InstantiateTopology() again failed: %v", err)
+	}
+	if err := instantiate(nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("InstantiateTopology() without parameters = %v, want InvalidArgument", err)
+	}
+	// r1 exists, and isn't an instance of the template
+	meshnettest.Store(m).AddTemplate(&topologyv1.TopologyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+		Spec:       topologyv1.TopologyTemplateSpec{Topologies: []topologyv1.TemplateTopology{{Name: "{{ .name }}"}}},
+	})
+	_, err := m.InstantiateTopology(ctx, &mpb.InstantiationRequest{Template: "single", KubeNs: "default", Parameters: map[string]string{"name": "r1"}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("InstantiateTopology() over r1 = %v, want AlreadyExists", err)
+	}
+	if _, err := m.InstantiateTopology(ctx, &mpb.InstantiationRequest{Template: "missing", KubeNs: "default"}); status.Code(err) != codes.NotFound {
+		t.Errorf("InstantiateTopology() of a missing template = %v, want NotFound", err)
+	}
+}
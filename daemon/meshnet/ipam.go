@@ -0,0 +1,277 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	ipamCIDRAnnotation = "meshnet.io/ipam-cidr"
+	ipamPrefix         = "meshnet-ipam-"
+	// key of the allocated addresses in the IPAM ConfigMap, one bit per address
+	ipamBitmapKey = "bitmap"
+	// every link gets a /31 out of the /24 of its namespace
+	ipamPoolSize = 256
+	ipamLinkSize = 2
+)
+
+func ipamName(ns string) string {
+	return ipamPrefix + ns
+}
+
+// ipamLink is the allocation of a link, released once none of its pods is using it
+type ipamLink struct {
+	Offset int      `json:"offset"`
+	Pods   []string `json:"pods"`
+}
+
+// ipamPool is the state of the IPAM pool of a namespace, as stored in its ConfigMap
+type ipamPool struct {
+	bitmap []byte
+	links  map[string]*ipamLink
+}
+
+func parsePool(cm *corev1.ConfigMap) (*ipamPool, error) {
+	p := &ipamPool{bitmap: make([]byte, ipamPoolSize/8), links: make(map[string]*ipamLink)}
+	for key, data := range cm.Data {
+		if key == ipamBitmapKey {
+			bitmap, err := base64.StdEncoding.DecodeString(data)
+			if err != nil || len(bitmap) != len(p.bitmap) {
+				return nil, fmt.Errorf("invalid IPAM bitmap in %s: %q", cm.Name, data)
+			}
+			p.bitmap = bitmap
+			continue
+		}
+		l := &ipamLink{}
+		if err := json.Unmarshal([]byte(data), l); err != nil {
+			return nil, fmt.Errorf("invalid IPAM allocation of link %s in %s: %s", key, cm.Name, err)
+		}
+		p.links[key] = l
+	}
+	return p, nil
+}
+
+func (p *ipamPool) encode(cm *corev1.ConfigMap) error {
+	cm.Data = map[string]string{ipamBitmapKey: base64.StdEncoding.EncodeToString(p.bitmap)}
+	for key, l := range p.links {
+		data, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		cm.Data[key] = string(data)
+	}
+	return nil
+}
+
+func (p *ipamPool) used(i int) bool {
+	return p.bitmap[i/8]&(1<<(i%8)) != 0
+}
+
+func (p *ipamPool) set(i int, used bool) {
+	if used {
+		p.bitmap[i/8] |= 1 << (i % 8)
+	} else {
+		p.bitmap[i/8] &^= 1 << (i % 8)
+	}
+}
+
+// allocate returns the offset of the addresses of the link uid used by pod, allocating them
+// if the link has none yet. It returns true if the pool has changed.
+func (p *ipamPool) allocate(uid int64, pod string) (int, bool, error) {
+	key := strconv.FormatInt(uid, 10)
+	if l, ok := p.links[key]; ok {
+		if contains(l.Pods, pod) {
+			return l.Offset, false, nil
+		}
+		l.Pods = append(l.Pods, pod)
+		return l.Offset, true, nil
+	}
+	for offset := 0; offset < ipamPoolSize; offset += ipamLinkSize {
+		if p.used(offset) {
+			continue
+		}
+		for i := offset; i < offset+ipamLinkSize; i++ {
+			p.set(i, true)
+		}
+		p.links[key] = &ipamLink{Offset: offset, Pods: []string{pod}}
+		return offset, true, nil
+	}
+	return 0, false, fmt.Errorf("IPAM pool is exhausted, it has room for %d links", ipamPoolSize/ipamLinkSize)
+}
+
+// release drops pod from the links it uses, freeing the addresses of those no pod uses
+// anymore. It returns false if pod had no allocation.
+func (p *ipamPool) release(pod string) bool {
+	changed := false
+	for key, l := range p.links {
+		var pods []string
+		for _, name := range l.Pods {
+			if name != pod {
+				pods = append(pods, name)
+			}
+		}
+		if len(pods) == len(l.Pods) {
+			continue
+		}
+		changed = true
+		l.Pods = pods
+		if len(pods) == 0 {
+			for i := l.Offset; i < l.Offset+ipamLinkSize; i++ {
+				p.set(i, false)
+			}
+			delete(p.links, key)
+		}
+	}
+	return changed
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPAMCIDR checks that cidr is an IPv4 /24
+func parseIPAMCIDR(cidr string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if ones, bits := ipNet.Mask.Size(); ipNet.IP.To4() == nil || ones != 24 || bits != 32 {
+		return nil, fmt.Errorf("IPAM pool %s must be an IPv4 /24", cidr)
+	}
+	return ipNet, nil
+}
+
+// linkAddrs returns the addresses of the ends of the link of pod and peer at offset of pool.
+// The pod with the lowest name gets the first one, so that both ends agree.
+func linkAddrs(pool *net.IPNet, offset int, pod, peer string) (string, string) {
+	addr := func(i int) string {
+		ip := make(net.IP, net.IPv4len)
+		copy(ip, pool.IP.To4())
+		ip[3] += byte(i)
+		return fmt.Sprintf("%s/31", ip)
+	}
+	first, second := addr(offset), addr(offset+1)
+	if peer != localhost && peer < pod {
+		return second, first
+	}
+	return first, second
+}
+
+// fillIPs allocates addresses for the links of pod which have no local or peer IP, if the
+// namespace has an IPAM pool
+func (m *Meshnet) fillIPs(ctx context.Context, ns, pod string, links []*mpb.Link) error {
+	var empty []*mpb.Link
+	for _, l := range links {
+		if l.SriovVfPciAddr == "" && (l.LocalIp == "" || l.PeerIp == "") {
+			empty = append(empty, l)
+		}
+	}
+	if len(empty) == 0 {
+		return nil
+	}
+	namespace, err := m.kClient.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cidr := namespace.Annotations[ipamCIDRAnnotation]
+	if cidr == "" {
+		return nil
+	}
+	pool, err := parseIPAMCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation of namespace %s: %s", ipamCIDRAnnotation, ns, err)
+	}
+
+	offsets := make(map[int64]int)
+	err = m.updatePool(ctx, ns, func(p *ipamPool) (bool, error) {
+		changed := false
+		for _, l := range empty {
+			offset, allocated, err := p.allocate(l.Uid, pod)
+			if err != nil {
+				return false, err
+			}
+			offsets[l.Uid] = offset
+			changed = changed || allocated
+		}
+		return changed, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, l := range empty {
+		local, peer := linkAddrs(pool, offsets[l.Uid], pod, l.PeerPod)
+		if l.LocalIp == "" {
+			l.LocalIp = local
+		}
+		if l.PeerIp == "" && l.PeerPod != localhost {
+			l.PeerIp = peer
+		}
+	}
+	return nil
+}
+
+// updatePool applies update to the IPAM pool of ns, retrying if it has changed in the meantime.
+// The ConfigMap is only written if update returns true.
+func (m *Meshnet) updatePool(ctx context.Context, ns string, update func(*ipamPool) (bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cms := m.kClient.CoreV1().ConfigMaps(ns)
+		cm, err := cms.Get(ctx, ipamName(ns), metav1.GetOptions{})
+		create := apierrors.IsNotFound(err)
+		if create {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ipamName(ns)}}
+		} else if err != nil {
+			return err
+		}
+		p, err := parsePool(cm)
+		if err != nil {
+			return err
+		}
+		changed, err := update(p)
+		if err != nil || !changed {
+			return err
+		}
+		if err := p.encode(cm); err != nil {
+			return err
+		}
+		if create {
+			_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			return err
+		}
+		// the update fails with a conflict if the pool has changed since it was read
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ReleaseIPAM releases the addresses allocated to the links of a pod, the addresses of a
+// link are freed once both of its pods have released them.
+func (m *Meshnet) ReleaseIPAM(ctx context.Context, pod *mpb.PodQuery) (*mpb.BoolResponse, error) {
+	err := m.updatePool(ctx, pod.KubeNs, func(p *ipamPool) (bool, error) {
+		return p.release(pod.Name), nil
+	})
+	if err != nil {
+		log.Errorf("Failed to release the addresses of pod %s: %v", pod.Name, err)
+		return &mpb.BoolResponse{Response: false}, k8sError(err, mpb.WireError_REMOVE, "failed to release the addresses of pod %s", pod.Name)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
@@ -0,0 +1,137 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestIPAMPool(t *testing.T) {
+	p, err := parsePool(&corev1.ConfigMap{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		uid     int64
+		pod     string
+		offset  int
+		changed bool
+	}{
+		{uid: 1, pod: "r1", offset: 0, changed: true},
+		{uid: 2, pod: "r1", offset: 2, changed: true},
+		{uid: 1, pod: "r2", offset: 0, changed: true},
+		{uid: 1, pod: "r2", offset: 0, changed: false},
+	}
+	for i, tt := range tests {
+		offset, changed, err := p.allocate(tt.uid, tt.pod)
+		if err != nil || offset != tt.offset || changed != tt.changed {
+			t.Errorf("#%d test failed: allocate() = %d, %v, %v, want %d, %v", i, offset, changed, err, tt.offset, tt.changed)
+		}
+	}
+
+	// the pool survives a round trip through its ConfigMap
+	cm := &corev1.ConfigMap{}
+	if err := p.encode(cm); err != nil {
+		t.Fatal(err)
+	}
+	if p, err = parsePool(cm); err != nil {
+		t.Fatal(err)
+	}
+
+	// link 2 is freed, link 1 is still used by r2
+	if !p.release("r1") {
+		t.Errorf("release() of r1 didn't change the pool")
+	}
+	if p.release("r1") {
+		t.Errorf("release() of r1 changed the pool twice")
+	}
+	if offset, _, _ := p.allocate(3, "r3"); offset != 2 {
+		t.Errorf("allocate() after release = %d, want the freed offset 2", offset)
+	}
+
+	full, _ := parsePool(&corev1.ConfigMap{})
+	for uid := int64(0); uid < ipamPoolSize/ipamLinkSize; uid++ {
+		full.allocate(uid, "r1")
+	}
+	if _, _, err := full.allocate(1000, "r1"); err == nil {
+		t.Errorf("allocate() from an exhausted pool didn't fail")
+	}
+}
+
+func TestLinkAddrs(t *testing.T) {
+	pool, err := parseIPAMCIDR("10.10.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		offset              int
+		pod, peerPod        string
+		wantLocal, wantPeer string
+	}{
+		{0, "r1", "r2", "10.10.0.0/31", "10.10.0.1/31"},
+		{0, "r2", "r1", "10.10.0.1/31", "10.10.0.0/31"},
+		{254, "r1", localhost, "10.10.0.254/31", "10.10.0.255/31"},
+	}
+	for _, tt := range tests {
+		local, peer := linkAddrs(pool, tt.offset, tt.pod, tt.peerPod)
+		if local != tt.wantLocal || peer != tt.wantPeer {
+			t.Errorf("linkAddrs(%d, %s, %s) = %s, %s, want %s, %s", tt.offset, tt.pod, tt.peerPod, local, peer, tt.wantLocal, tt.wantPeer)
+		}
+	}
+	for _, cidr := range []string{"10.10.0.0/16", "2001:db8::/120", "10.10.0.0"} {
+		if _, err := parseIPAMCIDR(cidr); err == nil {
+			t.Errorf("parseIPAMCIDR(%s) didn't fail", cidr)
+		}
+	}
+}
+
+func TestFillIPs(t *testing.T) {
+	ctx := context.Background()
+	m := &Meshnet{kClient: fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "lab",
+		Annotations: map[string]string{ipamCIDRAnnotation: "10.10.0.0/24"},
+	}})}
+
+	r1 := []*mpb.Link{
+		{Uid: 1, PeerPod: "r2"},
+		{Uid: 2, PeerPod: "r3", LocalIp: "192.168.0.1/24", PeerIp: "192.168.0.2/24"},
+	}
+	r2 := []*mpb.Link{{Uid: 1, PeerPod: "r1"}}
+	if err := m.fillIPs(ctx, "lab", "r1", r1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.fillIPs(ctx, "lab", "r2", r2); err != nil {
+		t.Fatal(err)
+	}
+	if r1[0].LocalIp != "10.10.0.0/31" || r1[0].PeerIp != r2[0].LocalIp || r2[0].PeerIp != r1[0].LocalIp {
+		t.Errorf("ends of link 1 don't match: r1 %s -> %s, r2 %s -> %s", r1[0].LocalIp, r1[0].PeerIp, r2[0].LocalIp, r2[0].PeerIp)
+	}
+	if r1[1].LocalIp != "192.168.0.1/24" {
+		t.Errorf("IP of link 2 has been overwritten with %s", r1[1].LocalIp)
+	}
+
+	for _, pod := range []string{"r1", "r2"} {
+		if _, err := m.ReleaseIPAM(ctx, &mpb.PodQuery{Name: pod, KubeNs: "lab"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cm, err := m.kClient.CoreV1().ConfigMaps("lab").Get(ctx, ipamName("lab"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := parsePool(cm); len(p.links) != 0 || p.used(0) {
+		t.Errorf("addresses of link 1 weren't released: %v", cm.Data)
+	}
+
+	// namespaces without a pool are left alone
+	other := []*mpb.Link{{Uid: 1, PeerPod: "r2"}}
+	m.kClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, metav1.CreateOptions{})
+	if err := m.fillIPs(ctx, "other", "r1", other); err != nil || other[0].LocalIp != "" {
+		t.Errorf("fillIPs() without a pool = %s, %v, want no IP", other[0].LocalIp, err)
+	}
+}
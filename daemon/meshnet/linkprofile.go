@@ -0,0 +1,67 @@
+package meshnet
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// linkProfile returns the spec of the LinkProfile name of the namespace ns
+func (m *Meshnet) linkProfile(ctx context.Context, ns, name string) (*topologyv1.LinkProfileSpec, error) {
+	p, err := m.tClient.LinkProfile(ns).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR},
+			"link profile %s/%s doesn't exist", ns, name)
+	}
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read link profile %s/%s", ns, name)
+	}
+	if err := p.Spec.Validate(); err != nil {
+		return nil, wireError(codes.InvalidArgument, &mpb.WireError{Cause: mpb.WireError_K8S_API_ERROR},
+			"link profile %s/%s: %s", ns, name, err)
+	}
+	return &p.Spec, nil
+}
+
+// applyLinkProfiles fills in the settings that links don't set from the LinkProfiles they
+// reference, which must exist in the namespace ns
+func (m *Meshnet) applyLinkProfiles(ctx context.Context, ns string, links []*mpb.Link) error {
+	profiles := make(map[string]*topologyv1.LinkProfileSpec)
+	for _, link := range links {
+		if link.Profile == "" {
+			continue
+		}
+		spec, ok := profiles[link.Profile]
+		if !ok {
+			var err error
+			if spec, err = m.linkProfile(ctx, ns, link.Profile); err != nil {
+				return err
+			}
+			profiles[link.Profile] = spec
+		}
+		mergeLinkProfile(link, spec)
+	}
+	return nil
+}
+
+// mergeLinkProfile sets the fields of link that are unset to the values of spec
+func mergeLinkProfile(link *mpb.Link, spec *topologyv1.LinkProfileSpec) {
+	if impairment.IsEmpty(link.EgressImpairment) {
+		link.EgressImpairment = impairmentFromCR(spec.EgressImpairment)
+	}
+	if impairment.IsEmpty(link.IngressImpairment) {
+		link.IngressImpairment = impairmentFromCR(spec.IngressImpairment)
+	}
+	if link.Mtu == 0 {
+		link.Mtu = int32(spec.MTU)
+	}
+	if spec.TunnelType == topologyv1.TunnelVxlanGPE {
+		link.VxlanGpe = true
+	}
+}
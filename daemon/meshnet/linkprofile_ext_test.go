@@ -0,0 +1,52 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestLinkProfile(t *testing.T) {
+	ctx := context.Background()
+	r1 := meshnettest.Topology("default", "r1", []string{"r2"}, []int64{1})
+	links, _, _ := unstructured.NestedSlice(r1.Object, "spec", "links")
+	links[0].(map[string]interface{})["profile_ref"] = map[string]interface{}{"name": "wan"}
+	unstructured.SetNestedSlice(r1.Object, links, "spec", "links")
+	m := meshnettest.NewFakeMeshnet([]unstructured.Unstructured{r1, meshnettest.Topology("default", "r2", []string{"r1"}, []int64{1})})
+
+	if _, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Get() with a missing profile = %v, want FailedPrecondition", err)
+	}
+	profile := &topologyv1.LinkProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "wan"},
+		Spec:       topologyv1.LinkProfileSpec{MTU: 1400, EgressImpairment: topologyv1.Impairment{LatencyMs: 30}},
+	}
+	if _, err := meshnettest.Store(m).LinkProfile("default").Create(ctx, profile); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if l := p.Links[0]; l.Profile != "wan" || l.Mtu != 1400 || l.EgressImpairment.GetLatencyMs() != 30 {
+		t.Errorf("Get() link = %v, want the values of profile wan", l)
+	}
+
+	_, err = m.PatchLink(ctx, &mpb.LinkPatch{
+		Pod:       "r1",
+		KubeNs:    "default",
+		Operation: mpb.LinkPatch_ADD,
+		Link:      &mpb.Link{Uid: 2, PeerPod: "r2", LocalIntf: "eth2", PeerIntf: "eth2", Profile: "lan"},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("PatchLink() with a missing profile = %v, want FailedPrecondition", err)
+	}
+}
@@ -0,0 +1,61 @@
+package meshnet
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestMergeLinkProfile(t *testing.T) {
+	spec := &topologyv1.LinkProfileSpec{
+		EgressImpairment: topologyv1.Impairment{LatencyMs: 10},
+		MTU:              9000,
+		TunnelType:       topologyv1.TunnelVxlanGPE,
+	}
+	own := &mpb.ImpairmentSpec{LossPercent: 1}
+
+	tests := []struct {
+		desc string
+		link *mpb.Link
+		want *mpb.Link
+	}{
+		{
+			desc: "profile values",
+			link: &mpb.Link{Uid: 1, Profile: "wan"},
+			want: &mpb.Link{Uid: 1, Profile: "wan", Mtu: 9000, VxlanGpe: true, EgressImpairment: &mpb.ImpairmentSpec{LatencyMs: 10}},
+		},
+		{
+			desc: "link values",
+			link: &mpb.Link{Uid: 2, Profile: "wan", Mtu: 1500, EgressImpairment: own},
+			want: &mpb.Link{Uid: 2, Profile: "wan", Mtu: 1500, VxlanGpe: true, EgressImpairment: own},
+		},
+	}
+	for _, tt := range tests {
+		mergeLinkProfile(tt.link, spec)
+		if !proto.Equal(tt.link, tt.want) {
+			t.Errorf("%s: mergeLinkProfile() = %v, want %v", tt.desc, tt.link, tt.want)
+		}
+	}
+}
+
+func TestValidateLinkProfile(t *testing.T) {
+	tests := []struct {
+		spec  topologyv1.LinkProfileSpec
+		valid bool
+	}{
+		{spec: topologyv1.LinkProfileSpec{}, valid: true},
+		{spec: topologyv1.LinkProfileSpec{MTU: 1400, TunnelType: "vxlan"}, valid: true},
+		{spec: topologyv1.LinkProfileSpec{TunnelType: "geneve"}, valid: false},
+		{spec: topologyv1.LinkProfileSpec{MTU: 60}, valid: false},
+		{spec: topologyv1.LinkProfileSpec{IngressImpairment: topologyv1.Impairment{LossPercent: 101}}, valid: false},
+	}
+	for i, tt := range tests {
+		err := tt.spec.Validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
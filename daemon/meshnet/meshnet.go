@@ -3,39 +3,173 @@ package meshnet
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
-	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
-
-	glogrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/homedir"
 
 	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
 
+	"github.com/networkop/meshnet-cni/daemon/logging"
 	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
 )
 
 type Config struct {
-	Port     int
-	GRPCOpts []grpc.ServerOption
+	Port              int
+	GRPCOpts          []grpc.ServerOption
+	DisableReflection bool
+	KeepaliveTime     time.Duration
+	KeepaliveTimeout  time.Duration
+	WireMaxRetryTime  time.Duration
+	// Shortest interval of client keepalive pings the server accepts, default if zero
+	KeepaliveMinTime time.Duration
+	// Pods sharing the value of this label are fully meshed, empty to disable
+	AutoWireLabel string
+	// Limit of unary RPCs without a per-method limit, zero rate to disable
+	RPCRateLimit RateLimit
+	// YAML file with per-method rate limits
+	RPCRateLimitConfig string
+	// Number of revisions kept in the history ConfigMap of each topology
+	HistoryLimit int
+	// Receive buffer size of the packet sockets used by BenchmarkWire
+	PacketBufferSize int
+	// How long a canary deployment can stay uncommitted, zero to disable the timeout
+	CanaryTimeout time.Duration
+	// Write resource recommendations as annotations of the daemon's own pod
+	ResourceAutoscale bool
+	// How often the traffic of the wires is accounted for, zero to disable
+	TrafficAccountingInterval time.Duration
+	// Fraction of failed wire set ups above which the node is tainted, zero to disable
+	SelfTaintThreshold float64
+	// First GTP-U TEID, links get the TEID of their UID above it
+	GTPTEIDBase uint32
+	// Backend of same-node links, "veth" or "ovs"
+	DataPlane string
+	// Open vSwitch bridge pods are connected to when DataPlane is "ovs"
+	OVSBridge string
+	// How veth pairs are created, "root", "caller" or "auto"
+	NetnsMode string
+	// How often the daemons of peer nodes are sent a heartbeat, zero to disable
+	HeartbeatInterval time.Duration
+	// How long to wait for the answer to a heartbeat
+	HeartbeatTimeout time.Duration
+	// How long a remote update waits for the links of the pod with a lower UID, zero to disable
+	WireOrderTimeout time.Duration
+	// TCP address of the gRPC server, all addresses on Port when empty
+	ListenAddr string
+	// Unix socket the gRPC server listens on as well, for the CNI plugin, empty to disable
+	ListenUnix string
+	// Allow WireGuard links and generate the node's WireGuard key pair
+	WireGuard bool
+	// How often the node's WireGuard key pair is re-generated, zero to disable
+	WGKeyRotation time.Duration
+	// Serve the chaos profile RPCs and apply the profiles to the wires of this node
+	ChaosProfiles bool
+	// Messages per second logged for each wire and kind of message by the hot paths, zero for no limit
+	LogRateLimit float64
+	// Create the Multus NetworkAttachmentDefinitions of the topologies when they change
+	AutoCreateNADs bool
+	// How long the interface of a wire of this node may be missing before the wire is re-created, zero to disable
+	WireWatchdogTimeout time.Duration
+	// How long EvacuateNode waits for the pods of this node to run on other nodes, zero for no limit
+	EvacuationTimeout time.Duration
+	// CNI plugin meshnet is chained after, "default" or "cilium"
+	CNIBackend string
+	// Unix socket of the Cilium agent with the "cilium" CNI backend
+	CiliumSocket string
+	// Number of events kept in the event log ConfigMap of each topology, zero to disable
+	EventLogLimit int
+	// Number of levels of references to other topologies that are resolved
+	TopologyRefDepth int
+	// Number of wires of the pods of this node, zero for no limit
+	MaxActiveWires int
+	// Set the MTUs found by DiscoverMTU on the links and record them in their topologies
+	AutoSetPathMTU bool
+	// Compare the parameters of both ends of the wires set up by this daemon
+	VerifyWiresOnCreate bool
+	// How long WaitForPodsAlive waits for the pods to be alive, zero not to wait
+	PodAliveTimeout time.Duration
+	// How often the NodeTopologyStatus of the node is written, zero to disable
+	NodeStatusInterval time.Duration
+	// Inject the routes of the topology into the pods of this node once all their wires are up
+	AutoInjectRoutes bool
+	// How often the topologies are compared with the wires of this node, zero to disable
+	DriftDetectionInterval time.Duration
+	// Convergence of a namespace beyond which an event is recorded when it's measured, zero to
+	// disable
+	ConvergenceSLA time.Duration
+	// file:// URL of a directory the topologies are read from instead of the K8s API, as
+	// <namespace>/<name>.yaml files, empty to use the K8s API. The daemon then runs without K8s.
+	TopologySource string
+	// Version of the daemon reported in the NodeTopologyStatus of the node
+	Version string
+	// Middleware of the gRPC server, run after the rate limiter
+	Interceptors []ServerOption
 }
 
 type Meshnet struct {
 	mpb.UnimplementedLocalServer
 	mpb.UnimplementedRemoteServer
-	config  Config
-	kClient kubernetes.Interface
-	tClient topologyclientv1.Interface
-	rCfg    *rest.Config
-	s       *grpc.Server
-	lis     net.Listener
+	config   Config
+	kClient  kubernetes.Interface
+	tClient  topologyclientv1.Interface
+	dClient  dynamic.Interface
+	rCfg     *rest.Config
+	s        *grpc.Server
+	lis      net.Listener
+	unixLis  net.Listener
+	health   *health.Server
+	dlq      *deadLetterQueue
+	autoWire *autoWirer
+	tasks    tasks
+	// results of the recent wire set ups, for SelfTaint
+	wireErrors *errorWindow
+	// active flap simulations, keyed by namespace, pod and link UID
+	flaps sync.Map
+	// nodes whose daemon doesn't answer heartbeats
+	peers *peerTracker
+	// wires of the local pods that are still being set up
+	order *wireOrder
+	// wires impaired by chaos profiles
+	chaos *chaosState
+	// broadcast groups of the VXLAN ends of this node
+	broadcast *broadcastState
+	// logger of the messages repeated for each wire
+	wireLog *logging.RateLimitedLogger
+	// connections to the daemons of peer nodes
+	conns *peerConns
+	// topologies the K8s pods have been resolved to by pod selector
+	selectors *selectorCache
+	// local wires whose interface is missing
+	watchdog *wireWatchdog
+	// events of the wires of the topologies, nil when disabled
+	events EventStore
+	// wires of the pods of this node, counted against MaxActiveWires
+	wires *wireTable
+	// wires that are up on this node, as last counted
+	activeWireCount wireCount
+	// cluster-wide link defaults, as last read
+	globalConfig globalConfig
+	// audit entries waiting to be recorded by AuditWires
+	audits chan *mpb.WireAudit
 }
 
 func restConfig() (*rest.Config, error) {
@@ -55,58 +189,192 @@ func restConfig() (*rest.Config, error) {
 	return rCfg, nil
 }
 
-func New(cfg Config) (*Meshnet, error) {
-	rCfg, err := restConfig()
+// topologyDir returns the directory of a file:// topology source
+func topologyDir(source string) (string, error) {
+	u, err := url.Parse(source)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("invalid topology source %q: %s", source, err)
+	}
+	if u.Scheme != "file" || u.Path == "" {
+		return "", fmt.Errorf("invalid topology source %q, want file:///path/to/topologies", source)
+	}
+	return u.Path, nil
+}
+
+func New(cfg Config) (*Meshnet, error) {
+	var rCfg *rest.Config
+	var kClient kubernetes.Interface
+	var tClient topologyclientv1.Interface
+	var dClient dynamic.Interface
+	if cfg.TopologySource != "" {
+		dir, err := topologyDir(cfg.TopologySource)
+		if err != nil {
+			return nil, err
+		}
+		if tClient, err = topologyclientv1.NewFileClientset(dir); err != nil {
+			return nil, err
+		}
+		log.Infof("Reading the topologies from %s, without K8s", dir)
+		// there are no pods, and the events are dropped
+		kClient = fake.NewSimpleClientset()
+	} else {
+		var err error
+		if rCfg, err = restConfig(); err != nil {
+			return nil, err
+		}
+		if kClient, err = kubernetes.NewForConfig(rCfg); err != nil {
+			return nil, err
+		}
+		if tClient, err = topologyclientv1.NewForConfig(rCfg); err != nil {
+			return nil, err
+		}
+		if dClient, err = dynamic.NewForConfig(rCfg); err != nil {
+			return nil, err
+		}
+	}
+	addr := cfg.ListenAddr
+	if addr == "" {
+		addr = fmt.Sprintf(":%d", cfg.Port)
 	}
-	kClient, err := kubernetes.NewForConfig(rCfg)
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, err
 	}
-	tClient, err := topologyclientv1.NewForConfig(rCfg)
+	var unixLis net.Listener
+	if cfg.ListenUnix != "" {
+		if unixLis, err = listenUnix(cfg.ListenUnix); err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("failed to listen on %s: %s", cfg.ListenUnix, err)
+		}
+	}
+	m, err := NewWithClients(cfg, kClient, tClient)
 	if err != nil {
+		lis.Close()
+		if unixLis != nil {
+			unixLis.Close()
+		}
 		return nil, err
 	}
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
+	m.rCfg = rCfg
+	m.dClient = dClient
+	m.lis = lis
+	m.unixLis = unixLis
+	return m, nil
+}
+
+// NewWithClients builds a daemon that uses the given K8s and topology clients. It doesn't
+// listen on anything, so it can only be served once its listeners are set by New.
+func NewWithClients(cfg Config, kClient kubernetes.Interface, tClient topologyclientv1.Interface) (*Meshnet, error) {
+	wireLog := logging.NewRateLimitedLogger(log.NewEntry(log.StandardLogger()), cfg.LogRateLimit)
+	limiter, err := newRateLimiter(cfg.RPCRateLimit, cfg.RPCRateLimitConfig, wireLog)
 	if err != nil {
 		return nil, err
 	}
+	grpcOpts := cfg.GRPCOpts
+	grpcOpts = append(grpcOpts, keepaliveOpts(cfg.KeepaliveTime, cfg.KeepaliveTimeout, cfg.KeepaliveMinTime)...)
+	serverOpts := append([]ServerOption{
+		WithGRPCOptions(grpcOpts...),
+		WithUnaryInterceptor(limiter.unaryInterceptor),
+	}, cfg.Interceptors...)
 	m := &Meshnet{
 		config:  cfg,
-		rCfg:    rCfg,
 		kClient: kClient,
 		tClient: tClient,
-		lis:     lis,
-		s:       newServerWithLogging(cfg.GRPCOpts...),
+		s:       NewMeshnetServer(serverOpts...),
+		health:  health.NewServer(),
+		dlq:     newDeadLetterQueue(cfg.WireMaxRetryTime, newEventRecorder(kClient)),
+		tasks:   tasks{crashed: make(map[string]string)},
+
+		wireErrors: newErrorWindow(wireErrorWindow),
+		peers:      newPeerTracker(),
+		order:      newWireOrder(),
+		chaos:      newChaosState(),
+		broadcast:  newBroadcastState(),
+		conns:      newPeerConns(),
+		selectors:  newSelectorCache(),
+		watchdog:   newWireWatchdog(),
+		wires:      newWireTable(cfg.MaxActiveWires),
+		wireLog:    wireLog,
+		audits:     make(chan *mpb.WireAudit, auditQueueSize),
+	}
+	if cfg.EventLogLimit > 0 {
+		m.events = NewConfigMapEventStore(kClient, cfg.EventLogLimit)
+	}
+	if cfg.AutoWireLabel != "" {
+		m.autoWire = newAutoWirer(kClient, cfg.AutoWireLabel)
 	}
 	mpb.RegisterLocalServer(m.s, m)
 	mpb.RegisterRemoteServer(m.s, m)
-	reflection.Register(m.s)
+	// The daemon is not serving until the reconciler has synced with K8s
+	m.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(m.s, m.health)
+	if !cfg.DisableReflection {
+		reflection.Register(m.s)
+	}
 	return m, nil
 }
 
 func (m *Meshnet) Serve() error {
-	log.Infof("GRPC server has started on port: %d", m.config.Port)
+	if m.unixLis != nil {
+		log.Infof("GRPC server has started on %s", m.config.ListenUnix)
+		go func() {
+			if err := m.s.Serve(m.unixLis); err != nil {
+				log.Errorf("GRPC server on %s has stopped: %v", m.config.ListenUnix, err)
+			}
+		}()
+	}
+	log.Infof("GRPC server has started on %s", m.lis.Addr())
 	return m.s.Serve(m.lis)
 }
 
 func (m *Meshnet) Stop() {
+	m.health.Shutdown()
 	m.s.Stop()
+	if m.conns != nil {
+		m.conns.closeAll()
+	}
+}
+
+// setReady updates the status reported by the gRPC health service
+func (m *Meshnet) setReady(ready bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	m.health.SetServingStatus("", status)
+}
+
+func newEventRecorder(kClient kubernetes.Interface) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kClient.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{
+		Component: "meshnetd",
+		Host:      os.Getenv("NODE_NAME"),
+	})
 }
 
-func newServerWithLogging(opts ...grpc.ServerOption) *grpc.Server {
-	lEntry := log.NewEntry(log.StandardLogger())
-	lOpts := []glogrus.Option{}
-	glogrus.ReplaceGrpcLogger(lEntry)
-	opts = append(opts,
-		grpc_middleware.WithUnaryServerChain(
-			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-			glogrus.UnaryServerInterceptor(lEntry, lOpts...),
-		),
-		grpc_middleware.WithStreamServerChain(
-			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
-			glogrus.StreamServerInterceptor(lEntry, lOpts...),
-		))
-	return grpc.NewServer(opts...)
+// DefaultKeepaliveMinTime is the shortest client keepalive interval accepted by default,
+// below the CNI plugin's 30 seconds
+const DefaultKeepaliveMinTime = 20 * time.Second
+
+// keepaliveOpts makes the server ping idle clients every t, unless t is zero, so that NAT and
+// firewall state is not dropped for long-lived streams. Client pings are accepted as often as
+// minTime whether or not the server pings, since the CNI plugin pings every 30 seconds.
+func keepaliveOpts(t, timeout, minTime time.Duration) []grpc.ServerOption {
+	if minTime <= 0 {
+		minTime = DefaultKeepaliveMinTime
+	}
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             minTime,
+			PermitWithoutStream: true,
+		}),
+	}
+	if t > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    t,
+			Timeout: timeout,
+		}))
+	}
+	return opts
 }
@@ -0,0 +1,87 @@
+package meshnet_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// peer pod name used by macvlan links
+const localhost = "localhost"
+
+func lab() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		meshnettest.Topology("default", "r1", []string{"r2", localhost}, []int64{1, 2}),
+		meshnettest.Topology("default", "r2", []string{"r1"}, []int64{1}),
+	}
+}
+
+// runningPod sets pod alive on this node, in a new netns with the veth pair of each of
+// intfs, whose other end is named peer<n> for the nth of them. The test is skipped if the
+// netns can't be created.
+func runningPod(t *testing.T, m *meshnet.Meshnet, pod string, intfs ...string) ns.NetNS {
+	t.Helper()
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	t.Cleanup(func() {
+		netNs.Close()
+		testutils.UnmountNS(netNs)
+	})
+	err = netNs.Do(func(ns.NetNS) error {
+		for i, intf := range intfs {
+			veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: intf}, PeerName: fmt.Sprintf("peer%d", i+1)}
+			if err := netlink.LinkAdd(veth); err != nil {
+				return err
+			}
+			for _, name := range []string{veth.Name, veth.PeerName} {
+				link, err := netlink.LinkByName(name)
+				if err != nil {
+					return err
+				}
+				if err := netlink.LinkSetUp(link); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+
+	ctx := context.Background()
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SrcIp, p.NetNs = os.Getenv("HOST_IP"), netNs.Path()
+	if _, err := m.SetAlive(ctx, p); err != nil {
+		t.Fatalf("SetAlive(%s) failed: %v", pod, err)
+	}
+	return netNs
+}
+
+// udpFrame returns a broadcast Ethernet frame of an IPv4 UDP datagram to port
+func udpFrame(port uint16) []byte {
+	frame := make([]byte, 14+20+8)
+	copy(frame, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	binary.BigEndian.PutUint16(frame[12:], 0x0800)
+	frame[14], frame[14+9] = 0x45, 17
+	binary.BigEndian.PutUint16(frame[14+2:], 20+8)
+	binary.BigEndian.PutUint16(frame[14+20+2:], port)
+	binary.BigEndian.PutUint16(frame[14+20+4:], 8)
+	return frame
+}
@@ -0,0 +1,100 @@
+package meshnettest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+var chaosProfileResource = schema.GroupResource{Group: topologyv1.GroupName, Resource: "chaosprofiles"}
+
+func (f *Topologies) ChaosProfile(namespace string) topologyclientv1.ChaosProfileInterface {
+	return &chaosProfiles{f: f, ns: namespace}
+}
+
+// chaosProfiles implements ChaosProfileInterface for a namespace of Topologies
+type chaosProfiles struct {
+	f  *Topologies
+	ns string
+}
+
+func (c *chaosProfiles) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.ChaosProfileList, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	var keys []string
+	for k, p := range c.f.profiles {
+		if c.ns == "" || p.Namespace == c.ns {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	result := &topologyv1.ChaosProfileList{}
+	for _, k := range keys {
+		result.Items = append(result.Items, *c.f.profiles[k].DeepCopy())
+	}
+	return result, nil
+}
+
+func (c *chaosProfiles) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.ChaosProfile, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	p, ok := c.f.profiles[key(c.ns, name)]
+	if !ok {
+		return nil, apierrors.NewNotFound(chaosProfileResource, name)
+	}
+	return p.DeepCopy(), nil
+}
+
+func (c *chaosProfiles) Create(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	if _, ok := c.f.profiles[key(c.ns, profile.Name)]; ok {
+		return nil, apierrors.NewAlreadyExists(chaosProfileResource, profile.Name)
+	}
+	return c.store(profile), nil
+}
+
+func (c *chaosProfiles) Update(ctx context.Context, profile *topologyv1.ChaosProfile) (*topologyv1.ChaosProfile, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	current, ok := c.f.profiles[key(c.ns, profile.Name)]
+	if !ok {
+		return nil, apierrors.NewNotFound(chaosProfileResource, profile.Name)
+	}
+	if err := c.f.conflict(c.ns, profile.Name); err != nil {
+		return nil, err
+	}
+	if profile.ResourceVersion != current.ResourceVersion {
+		return nil, apierrors.NewConflict(chaosProfileResource, profile.Name,
+			fmt.Errorf("the object has been modified, resource version %s, expected %s", profile.ResourceVersion, current.ResourceVersion))
+	}
+	return c.store(profile), nil
+}
+
+func (c *chaosProfiles) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	if _, ok := c.f.profiles[key(c.ns, name)]; !ok {
+		return apierrors.NewNotFound(chaosProfileResource, name)
+	}
+	delete(c.f.profiles, key(c.ns, name))
+	return nil
+}
+
+// store saves a copy of profile with a new resource version. c.f.mu must be held.
+func (c *chaosProfiles) store(profile *topologyv1.ChaosProfile) *topologyv1.ChaosProfile {
+	stored := profile.DeepCopy()
+	stored.Namespace = c.ns
+	c.f.version++
+	stored.ResourceVersion = strconv.Itoa(c.f.version)
+	c.f.profiles[key(c.ns, stored.Name)] = stored
+	return stored.DeepCopy()
+}
@@ -0,0 +1,100 @@
+package meshnettest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+var linkProfileResource = schema.GroupResource{Group: topologyv1.GroupName, Resource: "linkprofiles"}
+
+func (f *Topologies) LinkProfile(namespace string) topologyclientv1.LinkProfileInterface {
+	return &linkProfiles{f: f, ns: namespace}
+}
+
+// linkProfiles implements LinkProfileInterface for a namespace of Topologies
+type linkProfiles struct {
+	f  *Topologies
+	ns string
+}
+
+func (c *linkProfiles) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.LinkProfileList, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	var keys []string
+	for k, p := range c.f.linkProfiles {
+		if c.ns == "" || p.Namespace == c.ns {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	result := &topologyv1.LinkProfileList{}
+	for _, k := range keys {
+		result.Items = append(result.Items, *c.f.linkProfiles[k].DeepCopy())
+	}
+	return result, nil
+}
+
+func (c *linkProfiles) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.LinkProfile, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	p, ok := c.f.linkProfiles[key(c.ns, name)]
+	if !ok {
+		return nil, apierrors.NewNotFound(linkProfileResource, name)
+	}
+	return p.DeepCopy(), nil
+}
+
+func (c *linkProfiles) Create(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	if _, ok := c.f.linkProfiles[key(c.ns, profile.Name)]; ok {
+		return nil, apierrors.NewAlreadyExists(linkProfileResource, profile.Name)
+	}
+	return c.store(profile), nil
+}
+
+func (c *linkProfiles) Update(ctx context.Context, profile *topologyv1.LinkProfile) (*topologyv1.LinkProfile, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	current, ok := c.f.linkProfiles[key(c.ns, profile.Name)]
+	if !ok {
+		return nil, apierrors.NewNotFound(linkProfileResource, profile.Name)
+	}
+	if err := c.f.conflict(c.ns, profile.Name); err != nil {
+		return nil, err
+	}
+	if profile.ResourceVersion != current.ResourceVersion {
+		return nil, apierrors.NewConflict(linkProfileResource, profile.Name,
+			fmt.Errorf("the object has been modified, resource version %s, expected %s", profile.ResourceVersion, current.ResourceVersion))
+	}
+	return c.store(profile), nil
+}
+
+func (c *linkProfiles) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	if _, ok := c.f.linkProfiles[key(c.ns, name)]; !ok {
+		return apierrors.NewNotFound(linkProfileResource, name)
+	}
+	delete(c.f.linkProfiles, key(c.ns, name))
+	return nil
+}
+
+// store saves a copy of profile with a new resource version. c.f.mu must be held.
+func (c *linkProfiles) store(profile *topologyv1.LinkProfile) *topologyv1.LinkProfile {
+	stored := profile.DeepCopy()
+	stored.Namespace = c.ns
+	c.f.version++
+	stored.ResourceVersion = strconv.Itoa(c.f.version)
+	c.f.linkProfiles[key(c.ns, stored.Name)] = stored
+	return stored.DeepCopy()
+}
@@ -0,0 +1,114 @@
+// Package meshnettest runs the meshnet daemon's handlers against an in-memory K8s API, so that
+// they can be unit-tested without a cluster.
+package meshnettest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+)
+
+const localhost = "localhost"
+
+// stores of the daemons built by NewFakeMeshnet
+var stores sync.Map
+
+// NewFakeMeshnet returns a daemon backed by a fake clientset and an in-memory store of
+// initialTopologies, which simulates conflicts on writes.
+func NewFakeMeshnet(initialTopologies []unstructured.Unstructured) *meshnet.Meshnet {
+	f := NewTopologies(initialTopologies...)
+	f.Conflicts = true
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true}, fake.NewSimpleClientset(), f)
+	if err != nil {
+		panic(err)
+	}
+	stores.Store(m, f)
+	return m
+}
+
+// Store returns the topologies of a daemon built by NewFakeMeshnet
+func Store(m *meshnet.Meshnet) *Topologies {
+	f, ok := stores.Load(m)
+	if !ok {
+		panic("meshnettest: daemon wasn't built by NewFakeMeshnet")
+	}
+	return f.(*Topologies)
+}
+
+// Topology returns a topology object of pod with links to peers, the UIDs of the links
+// are given by uids
+func Topology(ns, pod string, peers []string, uids []int64) unstructured.Unstructured {
+	var links []interface{}
+	for i, peer := range peers {
+		links = append(links, map[string]interface{}{
+			"uid":        uids[i],
+			"peer_pod":   peer,
+			"local_intf": fmt.Sprintf("eth%d", i+1),
+			"peer_intf":  fmt.Sprintf("eth%d", i+1),
+		})
+	}
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networkop.co.uk/v1beta1",
+		"kind":       "Topology",
+		"metadata":   map[string]interface{}{"name": pod, "namespace": ns},
+		"spec":       map[string]interface{}{"links": links},
+	}}
+}
+
+// AssertWireActive checks that both ends of the link uid are alive, i.e. that every pod
+// with this link has a src_ip
+func AssertWireActive(t testing.TB, m *meshnet.Meshnet, uid int64) {
+	t.Helper()
+	ends := 0
+	for _, obj := range Store(m).Objects() {
+		links, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+		for _, l := range links {
+			link, ok := l.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if u, _, _ := unstructured.NestedInt64(link, "uid"); u != uid {
+				continue
+			}
+			ends++
+			if srcIP, _, _ := unstructured.NestedString(obj.Object, "status", "src_ip"); srcIP == "" {
+				t.Errorf("wire %d isn't active: pod %s/%s isn't alive", uid, obj.GetNamespace(), obj.GetName())
+			}
+			if peer, _, _ := unstructured.NestedString(link, "peer_pod"); peer == localhost {
+				ends++
+			}
+		}
+	}
+	if ends < 2 {
+		t.Errorf("wire %d isn't active: it has %d ends", uid, ends)
+	}
+}
+
+// AssertSkipped checks that peer is in the skipped list of pod, pod is looked up in all
+// namespaces
+func AssertSkipped(t testing.TB, m *meshnet.Meshnet, pod, peer string) {
+	t.Helper()
+	found := false
+	for _, obj := range Store(m).Objects() {
+		if obj.GetName() != pod {
+			continue
+		}
+		found = true
+		skipped, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "skipped")
+		for _, s := range skipped {
+			if s == peer {
+				return
+			}
+		}
+	}
+	if !found {
+		t.Errorf("pod %s doesn't exist", pod)
+		return
+	}
+	t.Errorf("pod %s hasn't skipped %s", pod, peer)
+}
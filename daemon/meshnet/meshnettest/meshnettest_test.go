@@ -0,0 +1,81 @@
+package meshnettest
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func lab() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		Topology("default", "r1", []string{"r2", localhost}, []int64{1, 2}),
+		Topology("default", "r2", []string{"r1"}, []int64{1}),
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	ctx := context.Background()
+	f := NewTopologies(lab()...)
+	f.Conflicts = true
+	client := f.Topology("default")
+
+	obj, err := client.Unstructured(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unstructured.SetNestedField(obj.Object, "10.0.0.1", "status", "src_ip")
+	if _, err := client.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err == nil {
+		t.Errorf("first UpdateStatus() didn't conflict")
+	}
+	if _, err := client.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		t.Errorf("second UpdateStatus() failed: %v", err)
+	}
+	// obj is stale now
+	f.Conflicts = false
+	if _, err := client.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err == nil {
+		t.Errorf("UpdateStatus() of a stale object didn't conflict")
+	}
+
+	topology, err := client.Get(ctx, "r1", metav1.GetOptions{})
+	if err != nil || topology.Status.SrcIp != "10.0.0.1" {
+		t.Errorf("Get() = %v, %v, want src_ip 10.0.0.1", topology, err)
+	}
+	if list, err := f.Topology("").List(ctx, metav1.ListOptions{}); err != nil || len(list.Items) != 2 {
+		t.Errorf("List() = %v, %v, want 2 topologies", list, err)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	client := NewTopologies(lab()...).Topology("default")
+
+	obj, err := client.Unstructured(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unstructured.SetNestedField(obj.Object, "10.0.0.1", "status", "src_ip")
+	unstructured.SetNestedSlice(obj.Object, nil, "spec", "links")
+	if _, err := client.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("UpdateStatus() failed: %v", err)
+	}
+	topology, err := client.Get(ctx, "r1", metav1.GetOptions{})
+	if err != nil || topology.Status.SrcIp != "10.0.0.1" || len(topology.Spec.Links) != 2 {
+		t.Errorf("after UpdateStatus(): %v, %v, want src_ip 10.0.0.1 and 2 links", topology, err)
+	}
+
+	obj, err = client.Unstructured(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unstructured.SetNestedField(obj.Object, "10.0.0.2", "status", "src_ip")
+	unstructured.SetNestedSlice(obj.Object, nil, "spec", "links")
+	if _, err := client.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	topology, err = client.Get(ctx, "r1", metav1.GetOptions{})
+	if err != nil || topology.Status.SrcIp != "10.0.0.1" || len(topology.Spec.Links) != 0 {
+		t.Errorf("after Update(): %v, %v, want src_ip 10.0.0.1 and no links", topology, err)
+	}
+}
@@ -0,0 +1,65 @@
+package meshnettest
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+var nodeStatusResource = schema.GroupResource{Group: topologyv1.GroupName, Resource: "nodetopologystatuses"}
+
+func (f *Topologies) NodeTopologyStatus() topologyclientv1.NodeTopologyStatusInterface {
+	return &nodeStatuses{f: f}
+}
+
+// nodeStatuses implements NodeTopologyStatusInterface for Topologies
+type nodeStatuses struct {
+	f *Topologies
+}
+
+func (c *nodeStatuses) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.NodeTopologyStatusList, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	var names []string
+	for name := range c.f.nodeStatuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := &topologyv1.NodeTopologyStatusList{}
+	for _, name := range names {
+		result.Items = append(result.Items, *c.f.nodeStatuses[name].DeepCopy())
+	}
+	return result, nil
+}
+
+func (c *nodeStatuses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.NodeTopologyStatus, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	s, ok := c.f.nodeStatuses[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(nodeStatusResource, name)
+	}
+	return s.DeepCopy(), nil
+}
+
+// Apply replaces the status of the object as a whole, as a server-side apply does when the
+// fields are all owned by the same manager
+func (c *nodeStatuses) Apply(ctx context.Context, status *topologyv1.NodeTopologyStatus, fieldManager string) (*topologyv1.NodeTopologyStatus, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	stored := status.DeepCopy()
+	if current, ok := c.f.nodeStatuses[status.Name]; ok {
+		stored.ObjectMeta = *current.ObjectMeta.DeepCopy()
+	}
+	c.f.version++
+	stored.ResourceVersion = strconv.Itoa(c.f.version)
+	c.f.nodeStatuses[stored.Name] = stored
+	return stored.DeepCopy(), nil
+}
@@ -0,0 +1,63 @@
+package meshnettest
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+var templateResource = schema.GroupResource{Group: topologyv1.GroupName, Resource: "topologytemplates"}
+
+func (f *Topologies) TopologyTemplate(namespace string) topologyclientv1.TopologyTemplateInterface {
+	return &templates{f: f, ns: namespace}
+}
+
+// AddTemplate stores a copy of tmpl
+func (f *Topologies) AddTemplate(tmpl *topologyv1.TopologyTemplate) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored := tmpl.DeepCopy()
+	f.version++
+	stored.ResourceVersion = strconv.Itoa(f.version)
+	f.templates[key(stored.Namespace, stored.Name)] = stored
+}
+
+// templates implements TopologyTemplateInterface for a namespace of Topologies
+type templates struct {
+	f  *Topologies
+	ns string
+}
+
+func (c *templates) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyTemplateList, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	var keys []string
+	for k, t := range c.f.templates {
+		if c.ns == "" || t.Namespace == c.ns {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	result := &topologyv1.TopologyTemplateList{}
+	for _, k := range keys {
+		result.Items = append(result.Items, *c.f.templates[k].DeepCopy())
+	}
+	return result, nil
+}
+
+func (c *templates) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.TopologyTemplate, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	t, ok := c.f.templates[key(c.ns, name)]
+	if !ok {
+		return nil, apierrors.NewNotFound(templateResource, name)
+	}
+	return t.DeepCopy(), nil
+}
@@ -0,0 +1,312 @@
+package meshnettest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+var topologyResource = schema.GroupResource{Group: topologyv1.GroupName, Resource: "topologies"}
+
+// Topologies is an in-memory implementation of the topology clientset. Like the API server,
+// it rejects updates of objects that have changed since they were read. When Conflicts is
+// set, the first attempt of every write of an object fails with a conflict and the second
+// one goes through, so that retries are exercised.
+type Topologies struct {
+	// Cluster-wide link defaults returned by GlobalConfig
+	Config *topologyv1.MeshnetConfig
+	// Quotas returned by NamespaceQuota, by namespace
+	Quotas    map[string]*topologyv1.MeshnetNamespaceQuota
+	Conflicts bool
+
+	mu           sync.Mutex
+	objects      map[string]*unstructured.Unstructured
+	attempts     map[string]int
+	profiles     map[string]*topologyv1.ChaosProfile
+	linkProfiles map[string]*topologyv1.LinkProfile
+	nodeStatuses map[string]*topologyv1.NodeTopologyStatus
+	templates    map[string]*topologyv1.TopologyTemplate
+	version      int
+	watchers     []*watcher
+}
+
+type watcher struct {
+	ns string
+	*watch.RaceFreeFakeWatcher
+}
+
+// NewTopologies returns a store holding copies of topologies
+func NewTopologies(topologies ...unstructured.Unstructured) *Topologies {
+	f := &Topologies{
+		objects:      make(map[string]*unstructured.Unstructured),
+		attempts:     make(map[string]int),
+		profiles:     make(map[string]*topologyv1.ChaosProfile),
+		linkProfiles: make(map[string]*topologyv1.LinkProfile),
+		nodeStatuses: make(map[string]*topologyv1.NodeTopologyStatus),
+		templates:    make(map[string]*topologyv1.TopologyTemplate),
+	}
+	for i := range topologies {
+		obj := topologies[i].DeepCopy()
+		f.version++
+		obj.SetResourceVersion(strconv.Itoa(f.version))
+		f.objects[key(obj.GetNamespace(), obj.GetName())] = obj
+	}
+	return f
+}
+
+func key(ns, name string) string {
+	return ns + "/" + name
+}
+
+// Object returns a copy of the topology ns/name, nil if there is none
+func (f *Topologies) Object(ns, name string) *unstructured.Unstructured {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if obj, ok := f.objects[key(ns, name)]; ok {
+		return obj.DeepCopy()
+	}
+	return nil
+}
+
+// Objects returns copies of all the topologies, sorted by namespace and name
+func (f *Topologies) Objects() []*unstructured.Unstructured {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var result []*unstructured.Unstructured
+	for _, k := range keys {
+		result = append(result, f.objects[k].DeepCopy())
+	}
+	return result
+}
+
+func (f *Topologies) Topology(namespace string) topologyclientv1.TopologyInterface {
+	return &topologies{f: f, ns: namespace}
+}
+
+func (f *Topologies) GlobalConfig(ctx context.Context) (*topologyv1.MeshnetConfig, error) {
+	return f.Config.DeepCopy(), nil
+}
+
+func (f *Topologies) NamespaceQuota(ctx context.Context, namespace string) (*topologyv1.MeshnetNamespaceQuota, error) {
+	return f.Quotas[namespace].DeepCopy(), nil
+}
+
+// conflict returns a conflict for the first attempt of a write of ns/name. f.mu must be held.
+func (f *Topologies) conflict(ns, name string) error {
+	if !f.Conflicts {
+		return nil
+	}
+	f.attempts[key(ns, name)]++
+	if f.attempts[key(ns, name)]%2 == 1 {
+		return apierrors.NewConflict(topologyResource, name, fmt.Errorf("simulated conflict"))
+	}
+	return nil
+}
+
+// store saves obj with a new resource version and notifies the watchers. f.mu must be held.
+func (f *Topologies) store(eventType watch.EventType, obj *unstructured.Unstructured) {
+	f.version++
+	obj.SetResourceVersion(strconv.Itoa(f.version))
+	k := key(obj.GetNamespace(), obj.GetName())
+	if eventType == watch.Deleted {
+		delete(f.objects, k)
+	} else {
+		f.objects[k] = obj
+	}
+	for _, w := range f.watchers {
+		if w.ns != "" && w.ns != obj.GetNamespace() {
+			continue
+		}
+		if t, err := toTyped(obj); err == nil && !w.IsStopped() {
+			w.Action(eventType, t)
+		}
+	}
+}
+
+func toTyped(obj *unstructured.Unstructured) (*topologyv1.Topology, error) {
+	result := &topologyv1.Topology{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// topologies implements TopologyInterface for a namespace of Topologies
+type topologies struct {
+	f  *Topologies
+	ns string
+}
+
+func (t *topologies) notFound(name string) error {
+	return apierrors.NewNotFound(topologyResource, name)
+}
+
+func (t *topologies) List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyList, error) {
+	result := &topologyv1.TopologyList{}
+	for _, obj := range t.f.Objects() {
+		if t.ns != "" && obj.GetNamespace() != t.ns {
+			continue
+		}
+		topology, err := toTyped(obj)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *topology)
+	}
+	return result, nil
+}
+
+func (t *topologies) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.Topology, error) {
+	obj := t.f.Object(t.ns, name)
+	if obj == nil {
+		return nil, t.notFound(name)
+	}
+	return toTyped(obj)
+}
+
+func (t *topologies) Create(ctx context.Context, topology *topologyv1.Topology) (*topologyv1.Topology, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(topology)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetNamespace(t.ns)
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	if _, ok := t.f.objects[key(t.ns, obj.GetName())]; ok {
+		return nil, apierrors.NewAlreadyExists(topologyResource, obj.GetName())
+	}
+	t.f.store(watch.Added, obj)
+	return toTyped(obj)
+}
+
+func (t *topologies) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	obj, ok := t.f.objects[key(t.ns, name)]
+	if !ok {
+		return t.notFound(name)
+	}
+	t.f.store(watch.Deleted, obj)
+	return nil
+}
+
+func (t *topologies) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	w := &watcher{ns: t.ns, RaceFreeFakeWatcher: watch.NewRaceFreeFake()}
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	t.f.watchers = append(t.f.watchers, w)
+	return w, nil
+}
+
+func (t *topologies) Unstructured(ctx context.Context, name string, opts metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	obj := t.f.Object(t.ns, name)
+	if obj == nil {
+		return nil, t.notFound(name)
+	}
+	return obj, nil
+}
+
+// Update updates everything but the status of a topology, as the API server does
+func (t *topologies) Update(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error) {
+	return t.update(obj, func(current *unstructured.Unstructured) *unstructured.Unstructured {
+		updated := obj.DeepCopy()
+		updated.SetNamespace(current.GetNamespace())
+		if status, ok := current.Object["status"]; ok {
+			updated.Object["status"] = runtime.DeepCopyJSONValue(status)
+		} else {
+			delete(updated.Object, "status")
+		}
+		return updated
+	})
+}
+
+// UpdateStatus only updates the status of a topology, as the status subresource does
+func (t *topologies) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, opts metav1.UpdateOptions) (*topologyv1.Topology, error) {
+	return t.update(obj, func(current *unstructured.Unstructured) *unstructured.Unstructured {
+		updated := current.DeepCopy()
+		if status, ok := obj.Object["status"]; ok {
+			updated.Object["status"] = runtime.DeepCopyJSONValue(status)
+		} else {
+			delete(updated.Object, "status")
+		}
+		return updated
+	})
+}
+
+// update stores the object built by merge from the current one, if obj isn't stale
+func (t *topologies) update(obj *unstructured.Unstructured, merge func(current *unstructured.Unstructured) *unstructured.Unstructured) (*topologyv1.Topology, error) {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	current, ok := t.f.objects[key(t.ns, obj.GetName())]
+	if !ok {
+		return nil, t.notFound(obj.GetName())
+	}
+	if err := t.f.conflict(t.ns, obj.GetName()); err != nil {
+		return nil, err
+	}
+	if obj.GetResourceVersion() != current.GetResourceVersion() {
+		return nil, apierrors.NewConflict(topologyResource, obj.GetName(),
+			fmt.Errorf("the object has been modified, resource version %s, expected %s", obj.GetResourceVersion(), current.GetResourceVersion()))
+	}
+	updated := merge(current)
+	t.f.store(watch.Modified, updated)
+	return toTyped(updated)
+}
+
+func (t *topologies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	current, ok := t.f.objects[key(t.ns, name)]
+	if !ok {
+		return nil, t.notFound(name)
+	}
+	if err := t.f.conflict(t.ns, name); err != nil {
+		return nil, err
+	}
+	doc, err := json.Marshal(current.Object)
+	if err != nil {
+		return nil, err
+	}
+	switch pt {
+	case types.JSONPatchType:
+		var patch jsonpatch.Patch
+		if patch, err = jsonpatch.DecodePatch(data); err == nil {
+			doc, err = patch.Apply(doc)
+		}
+	case types.MergePatchType:
+		doc, err = jsonpatch.MergePatch(doc, data)
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported patch type %s", pt))
+	}
+	if err != nil {
+		// a failed test operation is rejected as invalid by the API server
+		return nil, apierrors.NewInvalid(topologyv1.SchemeGroupVersion.WithKind("Topology").GroupKind(), name, nil)
+	}
+	patched := &unstructured.Unstructured{}
+	if err := patched.UnmarshalJSON(doc); err != nil {
+		return nil, err
+	}
+	t.f.store(watch.Modified, patched)
+	return patched.DeepCopy(), nil
+}
@@ -0,0 +1,30 @@
+package meshnet
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// recordNode sets the last_src_ip status of a pod starting on the node srcIP, counting a
+// migration in the migrations status if it used to run on another node. It returns the
+// previous node if the pod has migrated.
+func recordNode(obj *unstructured.Unstructured, srcIP string) (string, error) {
+	if srcIP == "" {
+		return "", nil
+	}
+	last, _, _ := unstructured.NestedString(obj.Object, "status", "last_src_ip")
+	if last == srcIP {
+		return "", nil
+	}
+	if err := unstructured.SetNestedField(obj.Object, srcIP, "status", "last_src_ip"); err != nil {
+		return "", err
+	}
+	if last == "" {
+		return "", nil
+	}
+	status, _, _ := unstructured.NestedMap(obj.Object, "status")
+	migrations := int64(number(status["migrations"]))
+	if err := unstructured.SetNestedField(obj.Object, migrations+1, "status", "migrations"); err != nil {
+		return "", err
+	}
+	return last, nil
+}
@@ -0,0 +1,43 @@
+package meshnet
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRecordNode(t *testing.T) {
+	tests := []struct {
+		status     map[string]interface{}
+		srcIP      string
+		from       string
+		migrations int64
+	}{
+		// first start
+		{status: map[string]interface{}{}, srcIP: "10.0.0.1"},
+		// restart on the same node
+		{status: map[string]interface{}{"last_src_ip": "10.0.0.1", "migrations": int64(1)}, srcIP: "10.0.0.1", migrations: 1},
+		// CNI DEL
+		{status: map[string]interface{}{"last_src_ip": "10.0.0.1"}, srcIP: ""},
+		{status: map[string]interface{}{"last_src_ip": "10.0.0.1"}, srcIP: "10.0.0.2", from: "10.0.0.1", migrations: 1},
+		{status: map[string]interface{}{"last_src_ip": "10.0.0.1", "migrations": int64(2)}, srcIP: "10.0.0.2", from: "10.0.0.1", migrations: 3},
+	}
+	for i, tt := range tests {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"status": tt.status}}
+		from, err := recordNode(obj, tt.srcIP)
+		if err != nil {
+			t.Fatalf("#%d test failed: %v", i, err)
+		}
+		if from != tt.from {
+			t.Errorf("#%d test failed: migrated from %q, want %q", i, from, tt.from)
+		}
+		migrations, _, _ := unstructured.NestedInt64(obj.Object, "status", "migrations")
+		if migrations != tt.migrations {
+			t.Errorf("#%d test failed: %d migrations, want %d", i, migrations, tt.migrations)
+		}
+		last, _, _ := unstructured.NestedString(obj.Object, "status", "last_src_ip")
+		if tt.srcIP != "" && last != tt.srcIP {
+			t.Errorf("#%d test failed: last_src_ip %q, want %q", i, last, tt.srcIP)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	minMTU = 68
+	maxMTU = 65535
+)
+
+// GetLinkMTU returns the MTU of the interface of a link of a pod running on this node.
+func (m *Meshnet) GetLinkMTU(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.LinkMTU, error) {
+	pod, link, err := m.runningLink(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	mtu, err := readLinkMTU(pod.NetNs, link.LocalIntf)
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{WireUid: q.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to read the MTU of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+	}
+	return &mpb.LinkMTU{Pod: q.Pod, Intf: link.LocalIntf, NodeIp: pod.SrcIp, Mtu: int32(mtu)}, nil
+}
+
+// SetLinkMTU sets the MTU of the interface of a link of a pod running on this node.
+func (m *Meshnet) SetLinkMTU(ctx context.Context, r *mpb.MTURequest) (*mpb.BoolResponse, error) {
+	if r.Mtu < minMTU || r.Mtu > maxMTU {
+		return nil, status.Errorf(codes.InvalidArgument, "MTU %d is out of the range %d-%d", r.Mtu, minMTU, maxMTU)
+	}
+	pod, link, err := m.runningLink(ctx, r.KubeNs, r.Pod, r.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLinkMTU(pod.NetNs, link.LocalIntf, int(r.Mtu)); err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{WireUid: r.LinkUid, PeerIp: link.PeerIp, Operation: mpb.WireError_UPDATE, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to set the MTU of %s in pod %s to %d: %s", link.LocalIntf, r.Pod, r.Mtu, err)
+	}
+	log.Infof("Set the MTU of %s in pod %s to %d", link.LocalIntf, r.Pod, r.Mtu)
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// GetInterfaceMTU returns the MTU of the interfaces of both ends of a link, asking the
+// daemons of the nodes the two pods are running on.
+func (m *Meshnet) GetInterfaceMTU(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.MTUResponse, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: q.Pod, KubeNs: q.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	link := linkByUID(pod.Links, q.LinkUid)
+	if link == nil {
+		return nil, wireError(codes.NotFound, &mpb.WireError{WireUid: q.LinkUid}, "pod %s has no link %d", q.Pod, q.LinkUid)
+	}
+
+	result := &mpb.MTUResponse{}
+	if result.Local, err = m.linkMTUFrom(ctx, pod.SrcIp, q); err != nil {
+		return nil, err
+	}
+	if link.PeerPod == localhost {
+		return result, nil
+	}
+	peer, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: q.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	if result.Remote, err = m.linkMTUFrom(ctx, peer.SrcIp, &mpb.LinkStatsQuery{Pod: link.PeerPod, KubeNs: q.KubeNs, LinkUid: q.LinkUid}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetInterfaceMTU sets the MTU of the interfaces of both ends of a link. If the remote
+// end can't be changed, the local end is set back to its previous MTU so that the two
+// ends don't disagree.
+func (m *Meshnet) SetInterfaceMTU(ctx context.Context, r *mpb.MTURequest) (*mpb.BoolResponse, error) {
+	if r.Mtu < minMTU || r.Mtu > maxMTU {
+		return nil, status.Errorf(codes.InvalidArgument, "MTU %d is out of the range %d-%d", r.Mtu, minMTU, maxMTU)
+	}
+	current, err := m.GetInterfaceMTU(ctx, &mpb.LinkStatsQuery{Pod: r.Pod, KubeNs: r.KubeNs, LinkUid: r.LinkUid})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.setLinkMTUOn(ctx, current.Local.NodeIp, r); err != nil {
+		return nil, err
+	}
+	if current.Remote == nil {
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+	remote := &mpb.MTURequest{Pod: current.Remote.Pod, KubeNs: r.KubeNs, LinkUid: r.LinkUid, Mtu: r.Mtu}
+	remoteErr := m.setLinkMTUOn(ctx, current.Remote.NodeIp, remote)
+	if remoteErr == nil {
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+
+	rollback := &mpb.MTURequest{Pod: r.Pod, KubeNs: r.KubeNs, LinkUid: r.LinkUid, Mtu: current.Local.Mtu}
+	if err := m.setLinkMTUOn(ctx, current.Local.NodeIp, rollback); err != nil {
+		log.Errorf("Failed to set the MTU of %s in pod %s back to %d: %v", current.Local.Intf, r.Pod, current.Local.Mtu, err)
+		return nil, wireError(codes.Aborted, &mpb.WireError{WireUid: r.LinkUid, PeerIp: current.Remote.NodeIp, Operation: mpb.WireError_UPDATE},
+			"failed to set the MTU of pod %s: %v, and to roll back the MTU of pod %s: %v", current.Remote.Pod, remoteErr, r.Pod, err)
+	}
+	return nil, wireError(codes.Aborted, &mpb.WireError{WireUid: r.LinkUid, PeerIp: current.Remote.NodeIp, Operation: mpb.WireError_UPDATE},
+		"failed to set the MTU of pod %s, the MTU of pod %s was rolled back to %d: %v", current.Remote.Pod, r.Pod, current.Local.Mtu, remoteErr)
+}
+
+// linkMTUFrom reads the MTU locally if nodeIP is this node, or from the daemon on nodeIP
+func (m *Meshnet) linkMTUFrom(ctx context.Context, nodeIP string, q *mpb.LinkStatsQuery) (*mpb.LinkMTU, error) {
+	if nodeIP == "" {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: q.LinkUid}, "pod %s is not running", q.Pod)
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.GetLinkMTU(ctx, q)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetLinkMTU(ctx, q)
+}
+
+// setLinkMTUOn sets the MTU locally if nodeIP is this node, or through the daemon on nodeIP
+func (m *Meshnet) setLinkMTUOn(ctx context.Context, nodeIP string, r *mpb.MTURequest) error {
+	if nodeIP == os.Getenv("HOST_IP") {
+		_, err := m.SetLinkMTU(ctx, r)
+		return err
+	}
+	client, err := m.remoteClient(ctx, nodeIP, r.LinkUid)
+	if err != nil {
+		return err
+	}
+	_, err = client.SetLinkMTU(ctx, r)
+	return err
+}
+
+// remoteClient returns a client of the daemon on nodeIP, for the wire uid
+func (m *Meshnet) remoteClient(ctx context.Context, nodeIP string, uid int64) (mpb.RemoteClient, error) {
+	conn, err := m.peerConn(ctx, net.JoinHostPort(nodeIP, fmt.Sprint(m.config.Port)))
+	if err != nil {
+		return nil, wireError(codes.Unavailable, &mpb.WireError{WireUid: uid, PeerIp: nodeIP, Cause: mpb.WireError_PEER_UNREACHABLE},
+			"failed to connect to the daemon on %s: %s", nodeIP, err)
+	}
+	return mpb.NewRemoteClient(conn), nil
+}
+
+// readLinkMTU reads the MTU of an interface in the netNs network namespace
+func readLinkMTU(netNs, intf string) (int, error) {
+	var mtu int
+	err := inNetNs(netNs, func() error {
+		l, err := netlink.LinkByName(intf)
+		if err != nil {
+			return err
+		}
+		mtu = l.Attrs().MTU
+		return nil
+	})
+	return mtu, err
+}
+
+// setLinkMTU sets the MTU of an interface in the netNs network namespace
+func setLinkMTU(netNs, intf string, mtu int) error {
+	return inNetNs(netNs, func() error {
+		l, err := netlink.LinkByName(intf)
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetMTU(l, mtu)
+	})
+}
+
+func inNetNs(netNs string, f func() error) error {
+	podNs, err := ns.GetNS(netNs)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", netNs, err)
+	}
+	defer podNs.Close()
+	return podNs.Do(func(_ ns.NetNS) error { return f() })
+}
@@ -0,0 +1,35 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestInterfaceMTU(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+
+	tests := []struct {
+		req  *mpb.MTURequest
+		want codes.Code
+	}{
+		{req: &mpb.MTURequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Mtu: 67}, want: codes.InvalidArgument},
+		{req: &mpb.MTURequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Mtu: 65536}, want: codes.InvalidArgument},
+		{req: &mpb.MTURequest{Pod: "r1", KubeNs: "default", LinkUid: 3, Mtu: 9000}, want: codes.NotFound},
+		{req: &mpb.MTURequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Mtu: 9000}, want: codes.FailedPrecondition},
+	}
+	for i, tt := range tests {
+		if _, err := m.SetInterfaceMTU(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("#%d test failed: SetInterfaceMTU() = %v, want %s", i, err, tt.want)
+		}
+	}
+	if _, err := m.GetInterfaceMTU(ctx, &mpb.LinkStatsQuery{Pod: "r1", KubeNs: "default", LinkUid: 1}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetInterfaceMTU() of a pod that isn't running = %v, want FailedPrecondition", err)
+	}
+}
@@ -0,0 +1,37 @@
+package meshnet
+
+import (
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+)
+
+func TestLinkMTU(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(_ ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1", MTU: 1500}, PeerName: "eth2"})
+	})
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+
+	if mtu, err := readLinkMTU(netNs.Path(), "eth1"); err != nil || mtu != 1500 {
+		t.Errorf("readLinkMTU() = %d, %v, want 1500", mtu, err)
+	}
+	if err := setLinkMTU(netNs.Path(), "eth1", 9000); err != nil {
+		t.Fatalf("setLinkMTU() failed: %v", err)
+	}
+	if mtu, err := readLinkMTU(netNs.Path(), "eth1"); err != nil || mtu != 9000 {
+		t.Errorf("readLinkMTU() after setLinkMTU() = %d, %v, want 9000", mtu, err)
+	}
+	if _, err := readLinkMTU(netNs.Path(), "eth3"); err == nil {
+		t.Errorf("readLinkMTU() of a missing interface didn't fail")
+	}
+}
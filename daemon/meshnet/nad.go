@@ -0,0 +1,153 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/networkop/meshnet-cni/daemon/nad"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const nadRetryInterval = 10 * time.Second
+
+// GenerateNADs returns the Multus NetworkAttachmentDefinitions of the interfaces of the
+// topologies of a namespace, and the networks annotation each pod needs to get them.
+func (m *Meshnet) GenerateNADs(ctx context.Context, q *mpb.TopologyQuery) (*mpb.NADBundle, error) {
+	log.Infof("Generating NetworkAttachmentDefinitions for namespace %s", q.KubeNs)
+
+	nads, annotations, err := m.generateNADs(ctx, q.KubeNs)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(nads)
+	if err != nil {
+		return nil, err
+	}
+	return &mpb.NADBundle{Nads: data, Count: int64(len(nads)), PodAnnotations: annotations}, nil
+}
+
+func (m *Meshnet) generateNADs(ctx context.Context, ns string) ([]nad.NetworkAttachmentDefinition, map[string]string, error) {
+	topologies, err := m.tClient.Topology(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	nads, annotations := nad.Generate(ns, topologies.Items)
+	return nads, annotations, nil
+}
+
+// applyNADs creates the NetworkAttachmentDefinitions of the topologies of ns, or updates
+// their config. NetworkAttachmentDefinitions of interfaces that are no longer used are
+// kept, since pods may still reference them.
+func (m *Meshnet) applyNADs(ctx context.Context, ns string) error {
+	nads, _, err := m.generateNADs(ctx, ns)
+	if err != nil {
+		return err
+	}
+	return m.createOrUpdateNADs(ctx, ns, nads)
+}
+
+func (m *Meshnet) createOrUpdateNADs(ctx context.Context, ns string, nads []nad.NetworkAttachmentDefinition) error {
+	client := m.dClient.Resource(nad.Resource).Namespace(ns)
+	for _, n := range nads {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&n)
+		if err != nil {
+			return err
+		}
+		want := &unstructured.Unstructured{Object: obj}
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			current, err := client.Get(ctx, n.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				_, err = client.Create(ctx, want, metav1.CreateOptions{})
+				if apierrors.IsAlreadyExists(err) {
+					return apierrors.NewConflict(nad.Resource.GroupResource(), n.Name, err)
+				}
+				return err
+			} else if err != nil {
+				return err
+			}
+			config, _, _ := unstructured.NestedString(current.Object, "spec", "config")
+			if config == n.Spec.Config {
+				return nil
+			}
+			if err := unstructured.SetNestedField(current.Object, n.Spec.Config, "spec", "config"); err != nil {
+				return err
+			}
+			_, err = client.Update(ctx, current, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NADs applies the NetworkAttachmentDefinitions of the topologies of a namespace whenever
+// one of them is created or updated, until stopCh is closed.
+func (m *Meshnet) NADs(stopCh <-chan struct{}) {
+	if !m.config.AutoCreateNADs {
+		<-stopCh
+		return
+	}
+	if m.dClient == nil {
+		log.Warnf("NetworkAttachmentDefinitions can't be created without a K8s API config")
+		<-stopCh
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		w, err := m.tClient.Topology("").Watch(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Warnf("Failed to watch the topologies: %s", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(nadRetryInterval):
+			}
+			continue
+		}
+		m.watchNADs(ctx, w.ResultChan(), stopCh)
+		w.Stop()
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+	}
+}
+
+// watchNADs applies the NetworkAttachmentDefinitions of the namespace of every added or
+// modified topology, until the watch ends or stopCh is closed
+func (m *Meshnet) watchNADs(ctx context.Context, events <-chan watch.Event, stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.Type != watch.Added && e.Type != watch.Modified {
+				continue
+			}
+			obj, err := meta.Accessor(e.Object)
+			if err != nil {
+				continue
+			}
+			if err := m.applyNADs(ctx, obj.GetNamespace()); err != nil {
+				log.Warnf("Failed to apply the NetworkAttachmentDefinitions of namespace %s: %s", obj.GetNamespace(), err)
+			}
+		}
+	}
+}
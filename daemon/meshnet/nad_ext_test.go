@@ -0,0 +1,31 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestGenerateNADs(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+
+	bundle, err := m.GenerateNADs(ctx, &mpb.TopologyQuery{KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("GenerateNADs() failed: %v", err)
+	}
+	if bundle.Count != 2 {
+		t.Errorf("GenerateNADs() count = %d, want 2", bundle.Count)
+	}
+	want := map[string]string{
+		"r1": "default/meshnet-eth1@eth1,default/meshnet-eth2@eth2",
+		"r2": "default/meshnet-eth1@eth1",
+	}
+	for pod, networks := range want {
+		if got := bundle.PodAnnotations[pod]; got != networks {
+			t.Errorf("networks annotation of %s = %q, want %q", pod, got, networks)
+		}
+	}
+}
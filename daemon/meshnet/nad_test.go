@@ -0,0 +1,55 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/nad"
+)
+
+func TestCreateOrUpdateNADs(t *testing.T) {
+	ctx := context.Background()
+	dClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{nad.Resource: "NetworkAttachmentDefinitionList"})
+	m := &Meshnet{dClient: dClient}
+
+	r1 := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: "r1"}}
+	r1.Spec.Links = []topologyv1.Link{{LocalIntf: "eth1"}, {LocalIntf: "eth2"}}
+	nads, _ := nad.Generate("lab", []topologyv1.Topology{r1})
+	for i := 0; i < 2; i++ {
+		if err := m.createOrUpdateNADs(ctx, "lab", nads); err != nil {
+			t.Fatalf("createOrUpdateNADs() failed: %v", err)
+		}
+	}
+
+	client := dClient.Resource(nad.Resource).Namespace("lab")
+	stale, err := client.Get(ctx, "meshnet-eth1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unstructured.SetNestedField(stale.Object, "{}", "spec", "config")
+	if _, err := client.Update(ctx, stale, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.createOrUpdateNADs(ctx, "lab", nads); err != nil {
+		t.Fatalf("createOrUpdateNADs() failed: %v", err)
+	}
+
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil || len(list.Items) != 2 {
+		t.Fatalf("List() = %v, %v, want 2 NetworkAttachmentDefinitions", list, err)
+	}
+	for i, item := range list.Items {
+		config, _, _ := unstructured.NestedString(item.Object, "spec", "config")
+		if config != nads[i].Spec.Config {
+			t.Errorf("config of %s = %s, want %s", item.GetName(), config, nads[i].Spec.Config)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const (
+	// field manager of the server-side applies of the NodeTopologyStatus objects
+	nodeStatusManager = "meshnet"
+	// number of errors reported in a NodeTopologyStatus
+	nodeStatusErrors = 5
+)
+
+// nodeInventory summarizes the topologies of the pods running on hostIP. The wires up are
+// counted by the type of the last audit entry of their link written on hostIP.
+func nodeInventory(topologies []topologyv1.Topology, hostIP string) topologyv1.NodeInventory {
+	inv := topologyv1.NodeInventory{NodeIP: hostIP, WiresByType: make(map[string]int64)}
+	namespaces := make(map[string]bool)
+	for _, t := range topologies {
+		if t.Status.SrcIp != hostIP || t.Status.NetNs == "" {
+			continue
+		}
+		namespaces[t.Namespace] = true
+		inv.ActiveWires += t.Status.WiresUp
+		types := make(map[int64]string)
+		for _, a := range t.Status.WireAudit {
+			if a.NodeIP == hostIP {
+				types[a.LinkUID] = a.WireType
+			}
+		}
+		for _, l := range t.Spec.Links {
+			if wireType, ok := types[int64(l.UID)]; ok {
+				inv.WiresByType[wireType]++
+			}
+		}
+	}
+	for ns := range namespaces {
+		inv.Namespaces = append(inv.Namespaces, ns)
+	}
+	sort.Strings(inv.Namespaces)
+	return inv
+}
+
+// ReportNodeStatus writes the NodeTopologyStatus of the daemon's node every
+// NodeStatusInterval, until stopCh is closed.
+func (m *Meshnet) ReportNodeStatus(stopCh <-chan struct{}) {
+	node := os.Getenv("NODE_NAME")
+	if m.config.NodeStatusInterval <= 0 || node == "" {
+		if m.config.NodeStatusInterval > 0 {
+			log.Warnf("NODE_NAME must be set to report the status of the node")
+		}
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(m.config.NodeStatusInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.reportNodeStatus(context.Background(), node); err != nil {
+			log.Warnf("Failed to report the status of node %s: %s", node, err)
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reportNodeStatus applies the current inventory of the node to its NodeTopologyStatus
+func (m *Meshnet) reportNodeStatus(ctx context.Context, node string) error {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	status := &topologyv1.NodeTopologyStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: node},
+		Status:     nodeInventory(topologies.Items, os.Getenv("HOST_IP")),
+	}
+	status.Status.DaemonVersion = m.config.Version
+	status.Status.LastHeartbeat = metav1.NewTime(now.UTC().Truncate(time.Second))
+	status.Status.TopErrors = m.wireErrors.top(now, nodeStatusErrors)
+	_, err = m.tClient.NodeTopologyStatus().Apply(ctx, status, nodeStatusManager)
+	return err
+}
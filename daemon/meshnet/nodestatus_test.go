@@ -0,0 +1,38 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func TestNodeInventory(t *testing.T) {
+	r1 := runningTopology("r1", "10.0.0.1", "r2", "localhost", "r3")
+	r1.Status.WiresUp = 2
+	r1.Status.WireAudit = []topologyv1.WireAudit{
+		{LinkUID: 1, NodeIP: "10.0.0.1", WireType: wireTypeVeth},
+		{LinkUID: 2, NodeIP: "10.0.0.1", WireType: wireTypeSRIOV},
+		// the last entry of a link wins
+		{LinkUID: 2, NodeIP: "10.0.0.1", WireType: wireTypeMacvlan},
+		// written by the node of the pod before it moved
+		{LinkUID: 3, NodeIP: "10.0.0.2", WireType: wireTypeVxlan},
+	}
+	r2 := runningTopology("r2", "10.0.0.1", "r1")
+	r2.Namespace = "lab"
+	r2.Status.WiresUp = 1
+	r2.Status.WireAudit = []topologyv1.WireAudit{{LinkUID: 1, NodeIP: "10.0.0.1", WireType: wireTypeVeth}}
+	r3 := runningTopology("r3", "10.0.0.2", "r1")
+	r3.Status.WiresUp = 1
+
+	got := nodeInventory([]topologyv1.Topology{r3, r2, r1}, "10.0.0.1")
+	want := topologyv1.NodeInventory{
+		NodeIP:      "10.0.0.1",
+		ActiveWires: 3,
+		WiresByType: map[string]int64{wireTypeVeth: 2, wireTypeMacvlan: 1},
+		Namespaces:  []string{"default", "lab"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nodeInventory() = %+v, want %+v", got, want)
+	}
+}
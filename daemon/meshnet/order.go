@@ -0,0 +1,143 @@
+package meshnet
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// wireOrder tracks the wires of the pods on this node that are still being set up, so that
+// a remote update can wait for the wires with a lower UID of the same pod
+type wireOrder struct {
+	mu sync.Mutex
+	// pending wires keyed by namespace and pod, their channel is closed once they're up
+	pods map[string]map[int64]chan struct{}
+}
+
+func newWireOrder() *wireOrder {
+	return &wireOrder{pods: make(map[string]map[int64]chan struct{})}
+}
+
+// reset starts tracking the wires uids of pod, which are all pending
+func (o *wireOrder) reset(ns, pod string, uids []int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	pending := make(map[int64]chan struct{})
+	for _, uid := range uids {
+		pending[uid] = make(chan struct{})
+	}
+	o.pods[ns+"/"+pod] = pending
+}
+
+// forget stops tracking the wires of pod, releasing anything waiting for them
+func (o *wireOrder) forget(ns, pod string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, ch := range o.pods[ns+"/"+pod] {
+		close(ch)
+	}
+	delete(o.pods, ns+"/"+pod)
+}
+
+// up marks the wire uid of pod as up. It tells whether all the tracked wires of pod are up.
+func (o *wireOrder) up(ns, pod string, uid int64) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	pending, ok := o.pods[ns+"/"+pod]
+	if ch, ok := pending[uid]; ok {
+		close(ch)
+		delete(pending, uid)
+	}
+	return ok && len(pending) == 0
+}
+
+// wait waits up to timeout for the wires uids of pod to be up. Wires that aren't pending
+// are considered up. It returns the wires that are still pending, in UID order.
+func (o *wireOrder) wait(ctx context.Context, ns, pod string, uids []int64, timeout time.Duration) []int64 {
+	o.mu.Lock()
+	waiting := make(map[int64]chan struct{})
+	for _, uid := range uids {
+		if ch, ok := o.pods[ns+"/"+pod][uid]; ok {
+			waiting[uid] = ch
+		}
+	}
+	o.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for uid, ch := range waiting {
+		select {
+		case <-ch:
+			delete(waiting, uid)
+		case <-timer.C:
+			return sortedUIDs(waiting)
+		case <-ctx.Done():
+			return sortedUIDs(waiting)
+		}
+	}
+	return nil
+}
+
+func sortedUIDs(m map[int64]chan struct{}) []int64 {
+	var result []int64
+	for uid := range m {
+		result = append(result, uid)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// prerequisites returns the UIDs of the links that have to be up before the link uid,
+// i.e. those of the same pod with a lower UID
+func prerequisites(links []*mpb.Link, uid int64) []int64 {
+	var result []int64
+	for _, l := range links {
+		if l.Uid < uid {
+			result = append(result, l.Uid)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// orderWires starts tracking the wires of a pod that has just come up. Links to peers that
+// aren't running are left out, since they're set up when the peer comes up.
+func (m *Meshnet) orderWires(ctx context.Context, pod *mpb.Pod) {
+	if pod.SrcIp == "" || pod.NetNs == "" {
+		m.order.forget(pod.KubeNs, pod.Name)
+		return
+	}
+	var uids []int64
+	for _, l := range pod.Links {
+		if l.PeerPod != localhost {
+			peer, err := m.getPod(ctx, l.PeerPod, pod.KubeNs)
+			if err != nil {
+				continue
+			}
+			if srcIP, _, _ := unstructured.NestedString(peer.Object, "status", "src_ip"); srcIP == "" {
+				continue
+			}
+		}
+		uids = append(uids, l.Uid)
+	}
+	log.Debugf("Tracking the set up of wires %v of pod %s", uids, pod.Name)
+	m.order.reset(pod.KubeNs, pod.Name, uids)
+}
+
+// waitForPrerequisites waits for the wires the remote update of pod depends on, proceeding
+// anyway once WireOrderTimeout has passed
+func (m *Meshnet) waitForPrerequisites(ctx context.Context, pod *mpb.RemotePod) {
+	if m.config.WireOrderTimeout <= 0 || len(pod.PrerequisiteUids) == 0 {
+		return
+	}
+	uid := pod.Vni - vxlanBase
+	if missing := m.order.wait(ctx, pod.KubeNs, pod.PodName, pod.PrerequisiteUids, m.config.WireOrderTimeout); len(missing) > 0 {
+		log.Warnf("Wires %v of pod %s aren't up after %s, setting up wire %d anyway", missing, pod.PodName, m.config.WireOrderTimeout, uid)
+	}
+}
@@ -0,0 +1,80 @@
+package meshnet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestPrerequisites(t *testing.T) {
+	links := []*mpb.Link{{Uid: 3}, {Uid: 1}, {Uid: 5}, {Uid: 2}}
+	tests := []struct {
+		uid  int64
+		want []int64
+	}{
+		{uid: 1, want: nil},
+		{uid: 3, want: []int64{1, 2}},
+		{uid: 6, want: []int64{1, 2, 3, 5}},
+	}
+	for _, tt := range tests {
+		if got := prerequisites(links, tt.uid); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("prerequisites(%d) = %v, want %v", tt.uid, got, tt.want)
+		}
+	}
+}
+
+func TestWireOrder(t *testing.T) {
+	ctx := context.Background()
+	o := newWireOrder()
+	o.reset("default", "r1", []int64{1, 2, 3})
+
+	// unknown pods and wires aren't waited for
+	if missing := o.wait(ctx, "default", "r2", []int64{1}, time.Second); missing != nil {
+		t.Errorf("wait() for an unknown pod = %v, want nothing missing", missing)
+	}
+	if missing := o.wait(ctx, "default", "r1", []int64{4}, time.Second); missing != nil {
+		t.Errorf("wait() for an unknown wire = %v, want nothing missing", missing)
+	}
+
+	if missing := o.wait(ctx, "default", "r1", []int64{1, 2}, 10*time.Millisecond); !reflect.DeepEqual(missing, []int64{1, 2}) {
+		t.Errorf("wait() before the wires are up = %v, want [1 2]", missing)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		o.up("default", "r1", 1)
+		o.up("default", "r1", 2)
+	}()
+	if missing := o.wait(ctx, "default", "r1", []int64{1, 2}, time.Second); missing != nil {
+		t.Errorf("wait() once the wires are up = %v, want nothing missing", missing)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		o.forget("default", "r1")
+	}()
+	if missing := o.wait(ctx, "default", "r1", []int64{3}, time.Second); missing != nil {
+		t.Errorf("wait() for a pod that has gone = %v, want nothing missing", missing)
+	}
+}
+
+func TestWireOrderAllUp(t *testing.T) {
+	o := newWireOrder()
+	o.reset("default", "r1", []int64{1, 2})
+	if o.up("default", "r2", 1) {
+		t.Errorf("up() of an unknown pod = true, want false")
+	}
+	if o.up("default", "r1", 1) {
+		t.Errorf("up() with wire 2 pending = true, want false")
+	}
+	if !o.up("default", "r1", 2) {
+		t.Errorf("up() of the last wire = false, want true")
+	}
+	// wires re-created later keep the pod up
+	if !o.up("default", "r1", 1) {
+		t.Errorf("up() once all the wires are up = false, want true")
+	}
+}
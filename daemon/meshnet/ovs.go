@@ -0,0 +1,35 @@
+package meshnet
+
+import (
+	"fmt"
+
+	"github.com/networkop/meshnet-cni/daemon/ovs"
+)
+
+const (
+	dataPlaneVeth = "veth"
+	dataPlaneOVS  = "ovs"
+)
+
+// ValidateDataPlane checks that dataPlane is one of the supported backends
+func ValidateDataPlane(dataPlane string) error {
+	switch dataPlane {
+	case "", dataPlaneVeth, dataPlaneOVS:
+		return nil
+	}
+	return fmt.Errorf("unknown data plane %q, expected %q or %q", dataPlane, dataPlaneVeth, dataPlaneOVS)
+}
+
+// ovsBridge returns the bridge pods are connected to, or an empty string if links are
+// plain veth pairs
+func (m *Meshnet) ovsBridge() string {
+	if m.config.DataPlane != dataPlaneOVS {
+		return ""
+	}
+	return m.config.OVSBridge
+}
+
+// ofPort returns the OpenFlow port number of the interface intf of the netns netNs
+func ofPort(netNs, intf string) (int64, error) {
+	return ovs.OFPort(ovs.PortName(netNs, intf))
+}
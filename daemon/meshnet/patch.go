@@ -0,0 +1,765 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	koko "github.com/redhat-nfvpe/koko/api"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/networkop/meshnet-cni/daemon/ecmp"
+	"github.com/networkop/meshnet-cni/daemon/encap"
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	"github.com/networkop/meshnet-cni/daemon/ovs"
+	"github.com/networkop/meshnet-cni/daemon/pmtu"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/sriov"
+	"github.com/networkop/meshnet-cni/daemon/srv6"
+	"github.com/networkop/meshnet-cni/daemon/veth"
+	"github.com/networkop/meshnet-cni/daemon/vxlan"
+	"github.com/networkop/meshnet-cni/daemon/wireguard"
+)
+
+const (
+	// must match the VNI offset and macvlan mode used by the CNI plugin
+	vxlanBase   = 5000
+	macvlanMode = netlink.MACVLAN_MODE_BRIDGE
+
+	// longest interface name Linux accepts, IFNAMSIZ without the terminating NUL
+	maxIntfName = 15
+)
+
+// jsonPatch is a single RFC 6902 operation. CRDs don't support strategic merge
+// patches, so links are patched by their index, guarded by a test of their UID.
+type jsonPatch struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchLink adds, removes or updates a single link of a pod without touching its other links.
+// The change is mirrored in the peer's topology, and the wire is set up or torn down right away
+// when the pods are already running.
+func (m *Meshnet) PatchLink(ctx context.Context, patch *mpb.LinkPatch) (*mpb.BoolResponse, error) {
+	if patch.Operation == mpb.LinkPatch_UNSPECIFIED {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.InvalidArgument, "operation is required")
+	}
+	link := patch.Link
+	if link == nil || link.Uid == 0 || link.PeerPod == "" {
+		return &mpb.BoolResponse{Response: false}, fmt.Errorf("link with uid and peer_pod is required")
+	}
+	if patch.Operation != mpb.LinkPatch_REMOVE {
+		for _, name := range []string{link.LocalIntf, link.PeerIntf} {
+			if err := validateIntfName(name); err != nil {
+				return &mpb.BoolResponse{Response: false}, status.Errorf(codes.InvalidArgument, "link %d: %s", link.Uid, err)
+			}
+		}
+		// links can't reference a profile that doesn't exist
+		if link.Profile != "" {
+			if _, err := m.linkProfile(ctx, patch.KubeNs, link.Profile); err != nil {
+				return &mpb.BoolResponse{Response: false}, err
+			}
+		}
+	}
+	if err := ecmp.Validate(link.EcmpGroup); err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	if err := impairment.ValidateQoS(link.EgressQosMap, link.EgressQueueAllocation); err != nil {
+		return &mpb.BoolResponse{Response: false}, status.Errorf(codes.InvalidArgument, "link %d: %s", link.Uid, err)
+	}
+	if link.SriovVfPciAddr != "" {
+		if err := sriov.ValidatePCIAddr(link.SriovVfPciAddr); err != nil {
+			return &mpb.BoolResponse{Response: false}, err
+		}
+		if link.PeerPod != localhost {
+			return &mpb.BoolResponse{Response: false}, fmt.Errorf("SR-IOV VF links must have %s as peer_pod", localhost)
+		}
+	}
+	log.Infof("Patching link %d of pod %s: %s", link.Uid, patch.Pod, patch.Operation)
+
+	if err := m.snapshot(ctx, patch.Pod, patch.KubeNs); err != nil {
+		log.Warnf("Failed to record the history of topology %s: %s", patch.Pod, err)
+	}
+
+	var err error
+	switch patch.Operation {
+	case mpb.LinkPatch_ADD:
+		err = m.addLink(ctx, patch.Pod, patch.KubeNs, link)
+	case mpb.LinkPatch_REMOVE:
+		err = m.removeLink(ctx, patch.Pod, patch.KubeNs, link)
+	case mpb.LinkPatch_UPDATE:
+		err = m.updateLink(ctx, patch.Pod, patch.KubeNs, link)
+	default:
+		err = fmt.Errorf("unsupported operation %s", patch.Operation)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"function": "PatchLink",
+		}).Errorf("Failed to patch link %d of pod %s", link.Uid, patch.Pod)
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+func (m *Meshnet) addLink(ctx context.Context, pod, ns string, link *mpb.Link) error {
+	// the wire counts against the quota of ns until it's in the topologies
+	release, err := m.reserveWire(ctx, ns, pod, link.PeerPod, link.Uid)
+	if err != nil {
+		return err
+	}
+	err = m.patchLinks(ctx, pod, ns, link.Uid, func(idx int) ([]jsonPatch, error) {
+		if idx >= 0 {
+			return nil, fmt.Errorf("link %d already exists", link.Uid)
+		}
+		return []jsonPatch{{Op: "add", Path: "/spec/links/-", Value: linkToMap(link)}}, nil
+	})
+	release()
+	if err != nil {
+		return err
+	}
+	if link.PeerPod != localhost {
+		if err := m.patchLinks(ctx, link.PeerPod, ns, link.Uid, func(idx int) ([]jsonPatch, error) {
+			if idx >= 0 {
+				return nil, nil
+			}
+			return []jsonPatch{{Op: "add", Path: "/spec/links/-", Value: linkToMap(reverseLink(pod, link))}}, nil
+		}); err != nil {
+			return err
+		}
+	}
+	err = m.createWire(ctx, pod, ns, link)
+	m.recordWire(err)
+	if err != nil {
+		return err
+	}
+	if err := m.refreshECMP(ctx, pod, ns); err != nil {
+		return err
+	}
+	if link.PeerPod == localhost {
+		return nil
+	}
+	return m.refreshECMP(ctx, link.PeerPod, ns)
+}
+
+func (m *Meshnet) removeLink(ctx context.Context, pod, ns string, link *mpb.Link) error {
+	remove := func(idx int) ([]jsonPatch, error) {
+		if idx < 0 {
+			return nil, nil
+		}
+		return []jsonPatch{
+			{Op: "test", Path: fmt.Sprintf("/spec/links/%d/uid", idx), Value: link.Uid},
+			{Op: "remove", Path: fmt.Sprintf("/spec/links/%d", idx)},
+		}, nil
+	}
+
+	localPod, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	if err := m.patchLinks(ctx, pod, ns, link.Uid, remove); err != nil {
+		return err
+	}
+	m.recordEvent(ctx, ns, localPod.Name, mpb.TopologyEvent_DELETE, link.Uid, linkByUID(localPod.Links, link.Uid), nil, "link removed")
+	m.wires.release(ns, pod, link.Uid)
+	var peerGroup string
+	if link.PeerPod != localhost {
+		var before *mpb.Link
+		if peerPod, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: ns}); err == nil {
+			peerGroup = ecmp.GroupOf(peerPod.Links, link.Uid)
+			before = linkByUID(peerPod.Links, link.Uid)
+		}
+		if err := m.patchLinks(ctx, link.PeerPod, ns, link.Uid, remove); err != nil {
+			return err
+		}
+		m.recordEvent(ctx, ns, link.PeerPod, mpb.TopologyEvent_DELETE, link.Uid, before, nil, "link removed by pod %s", localPod.Name)
+		m.wires.release(ns, link.PeerPod, link.Uid)
+	}
+
+	// ECMP routes must be moved off the link before its interfaces are removed
+	if err := m.refreshECMP(ctx, pod, ns, ecmp.GroupOf(localPod.Links, link.Uid)); err != nil {
+		return err
+	}
+	if link.PeerPod != localhost {
+		if err := m.refreshECMP(ctx, link.PeerPod, ns, peerGroup); err != nil {
+			return err
+		}
+	}
+
+	if localPod.NetNs != "" {
+		// The stored link tells whether the interface is a VF
+		if stored := linkByUID(localPod.Links, link.Uid); stored != nil {
+			link = stored
+		}
+		if err := removeIntf(localPod.NetNs, link, localPod.OvsBridge); err != nil {
+			log.Warnf("Failed to remove interface %s of pod %s: %s", link.LocalIntf, pod, err)
+		}
+		vxlan.Forget(ns, localPod.NetNs, link.LocalIntf, uint32(link.Uid+vxlanBase))
+		if err := m.deregisterEndpoint(ctx, ns, pod, localPod.NetNs, link.LocalIntf); err != nil {
+			log.Warnf("Failed to deregister interface %s of pod %s: %s", link.LocalIntf, pod, err)
+		}
+	}
+	if link.PeerPod == localhost {
+		return nil
+	}
+	// Neither pod needs to wait for the other one anymore
+	if err := m.unskip(ctx, pod, link.PeerPod, ns); err != nil {
+		return err
+	}
+	return m.unskip(ctx, link.PeerPod, pod, ns)
+}
+
+func (m *Meshnet) updateLink(ctx context.Context, pod, ns string, link *mpb.Link) error {
+	before, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	if err := m.patchLinks(ctx, pod, ns, link.Uid, func(idx int) ([]jsonPatch, error) {
+		if idx < 0 {
+			return nil, fmt.Errorf("link %d doesn't exist", link.Uid)
+		}
+		return []jsonPatch{
+			{Op: "test", Path: fmt.Sprintf("/spec/links/%d/uid", idx), Value: link.Uid},
+			{Op: "replace", Path: fmt.Sprintf("/spec/links/%d", idx), Value: linkToMap(link)},
+		}, nil
+	}); err != nil {
+		return err
+	}
+	var peer *mpb.Link
+	var peerBefore *mpb.Pod
+	if link.PeerPod != localhost {
+		if peerBefore, err = m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: ns}); err != nil {
+			return err
+		}
+		// The peer keeps the settings of its own end, like its impairments
+		peer = reverseLink(pod, link)
+		if err := m.patchStoredLink(ctx, link.PeerPod, ns, link.Uid, func(idx int, stored map[string]interface{}) ([]jsonPatch, error) {
+			if idx < 0 {
+				return nil, fmt.Errorf("link %d doesn't exist in the topology of pod %s", link.Uid, link.PeerPod)
+			}
+			return []jsonPatch{
+				{Op: "test", Path: fmt.Sprintf("/spec/links/%d/uid", idx), Value: link.Uid},
+				{Op: "replace", Path: fmt.Sprintf("/spec/links/%d", idx), Value: mirrorLink(stored, peer)},
+			}, nil
+		}); err != nil {
+			return err
+		}
+		if stored := linkByUID(peerBefore.Links, link.Uid); stored != nil {
+			merged := proto.Clone(stored).(*mpb.Link)
+			merged.PeerPod, merged.LocalIntf, merged.PeerIntf = peer.PeerPod, peer.LocalIntf, peer.PeerIntf
+			merged.LocalIp, merged.PeerIp = peer.LocalIp, peer.PeerIp
+			merged.VxlanGpe, merged.Mtu, merged.Profile = peer.VxlanGpe, peer.Mtu, peer.Profile
+			peer = merged
+		}
+	}
+
+	if err := m.configureUpdate(ctx, pod, ns, link, before); err != nil {
+		return err
+	}
+	if peer == nil {
+		return nil
+	}
+	return m.configureUpdate(ctx, link.PeerPod, ns, peer, peerBefore)
+}
+
+// configureUpdate applies an updated link to the end of pod if it's running on this node
+func (m *Meshnet) configureUpdate(ctx context.Context, pod, ns string, link *mpb.Link, before *mpb.Pod) error {
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil || p.NetNs == "" || p.SrcIp != os.Getenv("HOST_IP") {
+		return err
+	}
+	if err := m.applyLinkProfiles(ctx, ns, []*mpb.Link{link}); err != nil {
+		return err
+	}
+	link.TrafficClass = trafficClassOf(p, link.Uid)
+	m.applyDefaults(ctx, []*mpb.Link{link}, p.Annotations)
+	if err := configureEnd(p.NetNs, link); err != nil {
+		return err
+	}
+	// The link may have left its previous ECMP group
+	return m.refreshECMP(ctx, pod, ns, ecmp.GroupOf(before.Links, link.Uid))
+}
+
+// refreshECMP updates the ECMP routes of a pod if it's running on this node, including the
+// extra groups that may have lost their last link
+func (m *Meshnet) refreshECMP(ctx context.Context, pod, ns string, extra ...string) error {
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil || p.NetNs == "" || p.SrcIp != os.Getenv("HOST_IP") {
+		return err
+	}
+	return ecmp.Apply(p.NetNs, p.Links, extra...)
+}
+
+// patchLinks finds the index of the link with the given UID (or -1) and applies the
+// operations returned by fn, retrying if the links have been changed in the meantime
+func (m *Meshnet) patchLinks(ctx context.Context, pod, ns string, uid int64, fn func(int) ([]jsonPatch, error)) error {
+	return m.patchStoredLink(ctx, pod, ns, uid, func(idx int, _ map[string]interface{}) ([]jsonPatch, error) {
+		return fn(idx)
+	})
+}
+
+// patchStoredLink is patchLinks with the link as it's stored in the topology, nil if it doesn't exist
+func (m *Meshnet) patchStoredLink(ctx context.Context, pod, ns string, uid int64, fn func(int, map[string]interface{}) ([]jsonPatch, error)) error {
+	return retry.OnError(retry.DefaultRetry, isStale, func() error {
+		obj, err := m.getPod(ctx, pod, ns)
+		if err != nil {
+			return err
+		}
+		links, _, _ := unstructured.NestedSlice(obj.Object, "spec", "links")
+		idx := -1
+		var stored map[string]interface{}
+		for i, l := range links {
+			if lm, ok := l.(map[string]interface{}); ok {
+				if u, _, _ := unstructured.NestedInt64(lm, "uid"); u == uid {
+					idx, stored = i, lm
+				}
+			}
+		}
+
+		ops, err := fn(idx, stored)
+		if err != nil || len(ops) == 0 {
+			return err
+		}
+		data, err := json.Marshal(ops)
+		if err != nil {
+			return err
+		}
+		// A failed test operation means another patch has moved the link, so we try again
+		_, err = m.tClient.Topology(ns).Patch(ctx, pod, types.JSONPatchType, data, metav1.PatchOptions{})
+		return err
+	})
+}
+
+// isStale returns true if a patch has failed because the links have changed since they were read
+func isStale(err error) bool {
+	return apierrors.IsInvalid(err) || apierrors.IsConflict(err)
+}
+
+// unskip removes peer from the pod's skipped list
+func (m *Meshnet) unskip(ctx context.Context, pod, peer, ns string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.getPod(ctx, pod, ns)
+		if err != nil {
+			return err
+		}
+		skipped, _, _ := unstructured.NestedSlice(obj.Object, "status", "skipped")
+		newSkipped := make([]interface{}, 0, len(skipped))
+		for _, s := range skipped {
+			if s != peer {
+				newSkipped = append(newSkipped, s)
+			}
+		}
+		if len(newSkipped) == len(skipped) {
+			return nil
+		}
+		if err := unstructured.SetNestedSlice(obj.Object, newSkipped, "status", "skipped"); err != nil {
+			return err
+		}
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
+
+// createWire sets up the link if both of its ends are already running, otherwise
+// it's left to the CNI plugin of the pod that comes up last
+func (m *Meshnet) createWire(ctx context.Context, pod, ns string, link *mpb.Link) (err error) {
+	localPod, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	if localPod.NetNs == "" {
+		log.Infof("Pod %s is not running, link %d will be set up by CNI", pod, link.Uid)
+		return nil
+	}
+	if err := m.applyLinkProfiles(ctx, ns, []*mpb.Link{link}); err != nil {
+		return err
+	}
+	link.TrafficClass = trafficClassOf(localPod, link.Uid)
+	if err := m.wires.reserve(ns, pod, []int64{link.Uid}); err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = m.registerEndpoint(ctx, ns, pod, localPod.NetNs, link.LocalIntf)
+		}
+	}()
+	defer func() {
+		if err == nil && m.config.VerifyWiresOnCreate {
+			err = m.verifyCreatedWire(ctx, pod, ns, link.Uid)
+		}
+	}()
+	m.applyDefaults(ctx, []*mpb.Link{link}, localPod.Annotations)
+	myVeth, err := makeVeth(localPod.NetNs, link.LocalIntf, link.LocalIp)
+	if err != nil {
+		return err
+	}
+
+	if link.PeerPod == localhost && link.SriovVfPciAddr != "" {
+		if err := sriov.Attach(localPod.NetNs, link.LocalIntf, link.LocalIp, link.SriovVfPciAddr); err != nil {
+			return err
+		}
+		m.auditPatch(pod, ns, link.Uid, wireTypeSRIOV)
+		return configureEnd(localPod.NetNs, link)
+	}
+	if link.PeerPod == localhost {
+		if err := koko.MakeMacVLan(*myVeth, koko.MacVLan{ParentIF: link.PeerIntf, Mode: macvlanMode}); err != nil {
+			return err
+		}
+		m.auditPatch(pod, ns, link.Uid, wireTypeMacvlan)
+		return configureEnd(localPod.NetNs, link)
+	}
+
+	peerPod, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	if peerPod.SrcIp == "" || peerPod.NetNs == "" {
+		log.Infof("Peer pod %s is not running, link %d will be set up by CNI", link.PeerPod, link.Uid)
+		return nil
+	}
+	if peerPod.SrcIp == localPod.SrcIp {
+		if err := m.wires.reserve(ns, link.PeerPod, []int64{link.Uid}); err != nil {
+			return err
+		}
+	}
+
+	if peerPod.SrcIp == localPod.SrcIp && localPod.OvsBridge != "" {
+		if _, err := ovs.Attach(localPod.NetNs, link.LocalIntf, link.LocalIp, localPod.OvsBridge, link.Uid); err != nil {
+			return err
+		}
+		if _, err := ovs.Attach(peerPod.NetNs, link.PeerIntf, link.PeerIp, localPod.OvsBridge, link.Uid); err != nil {
+			return err
+		}
+		m.auditPatch(pod, ns, link.Uid, wireTypeOVS)
+		if err := encap.ApplyMPLS(peerPod.NetNs, link.PeerIntf, link.PeerIp, link.MplsLabel); err != nil {
+			return err
+		}
+		if err := pmtu.Apply(peerPod.NetNs, link.PeerIntf, link.Mtu); err != nil {
+			return err
+		}
+		if err := m.registerEndpoint(ctx, ns, link.PeerPod, peerPod.NetNs, link.PeerIntf); err != nil {
+			return err
+		}
+		return configureEnd(localPod.NetNs, link)
+	}
+	if peerPod.SrcIp == localPod.SrcIp {
+		peerVeth, err := makeVeth(peerPod.NetNs, link.PeerIntf, link.PeerIp)
+		if err != nil {
+			return err
+		}
+		if err := veth.Make(localPod.NetnsMode, *myVeth, *peerVeth); err != nil {
+			return err
+		}
+		m.auditPatch(pod, ns, link.Uid, wireTypeVeth)
+		if err := encap.ApplyMPLS(peerPod.NetNs, link.PeerIntf, link.PeerIp, link.MplsLabel); err != nil {
+			return err
+		}
+		if err := impairment.ApplyDSCP(peerPod.NetNs, link.PeerIntf, trafficClassOf(peerPod, link.Uid)); err != nil {
+			return err
+		}
+		qosMap, alloc := qosOf(peerPod, link.Uid)
+		if err := impairment.ApplyQoS(peerPod.NetNs, link.PeerIntf, qosMap, alloc); err != nil {
+			return err
+		}
+		if err := pmtu.Apply(peerPod.NetNs, link.PeerIntf, link.Mtu); err != nil {
+			return err
+		}
+		if err := m.registerEndpoint(ctx, ns, link.PeerPod, peerPod.NetNs, link.PeerIntf); err != nil {
+			return err
+		}
+		return configureEnd(localPod.NetNs, link)
+	}
+
+	local := &mpb.RemotePod{
+		NetNs:     localPod.NetNs,
+		IntfName:  link.LocalIntf,
+		IntfIp:    link.LocalIp,
+		PeerVtep:  peerPod.SrcIp,
+		Vni:       link.Uid + vxlanBase,
+		KubeNs:    ns,
+		PodName:   pod,
+		VxlanGpe:  link.VxlanGpe,
+		IntfMac:   link.LocalMac,
+		Neighbors: vxlan.Neighbors(link.PeerIp, link.PeerMac, peerPod.SrcIp),
+	}
+	wireType := wireTypeVxlan
+	var peerSRv6 *mpb.Link
+	if link.Secret != "" {
+		// a secure link is a WireGuard tunnel with the keys of its Secret, the peer's node
+		// reads the keys of its end itself
+		local.TunnelType = mpb.TunnelType_WIREGUARD
+		if local.Credentials, err = m.readCredentials(ctx, ns, link.Secret); err != nil {
+			return err
+		}
+		wireType = wireTypeWG
+		err = wireguard.CreateOrUpdate(local)
+	} else if len(link.Srv6Segments) > 0 {
+		if peerSRv6 = linkByUID(peerPod.Links, link.Uid); peerSRv6 == nil || len(peerSRv6.Srv6Segments) == 0 {
+			return status.Errorf(codes.InvalidArgument, "SRv6 link %d needs segments at both ends", link.Uid)
+		}
+		local.TunnelType = mpb.TunnelType_SRV6
+		local.Srv6Segments, local.Srv6LocalSid = link.Srv6Segments, link.Srv6LocalSid
+		wireType = wireTypeSRv6
+		err = srv6.CreateOrUpdate(local)
+	} else {
+		err = vxlan.CreateOrUpdate(local)
+	}
+	if err != nil {
+		return err
+	}
+	m.auditPatch(pod, ns, link.Uid, wireType)
+	if err := configureEnd(localPod.NetNs, link); err != nil {
+		return err
+	}
+
+	url := net.JoinHostPort(peerPod.SrcIp, fmt.Sprint(m.config.Port))
+	conn, err := m.peerConn(ctx, url)
+	if err != nil {
+		return err
+	}
+	qosMap, alloc := qosOf(peerPod, link.Uid)
+	remote := &mpb.RemotePod{
+		NetNs:     peerPod.NetNs,
+		IntfName:  link.PeerIntf,
+		IntfIp:    link.PeerIp,
+		PeerVtep:  localPod.SrcIp,
+		Vni:       link.Uid + vxlanBase,
+		KubeNs:    ns,
+		PodName:   link.PeerPod,
+		VxlanGpe:  link.VxlanGpe,
+		MplsLabel: link.MplsLabel,
+		IntfMac:   link.PeerMac,
+		Mtu:       link.Mtu,
+		Neighbors: vxlan.Neighbors(link.LocalIp, link.LocalMac, localPod.SrcIp),
+		// the wire is set up in the UID order of the peer's links
+		PrerequisiteUids: prerequisites(peerPod.Links, link.Uid),
+		TrafficClass:     trafficClassOf(peerPod, link.Uid),
+		TunnelType:       local.TunnelType,
+
+		EgressQosMap:          qosMap,
+		EgressQueueAllocation: alloc,
+	}
+	if peerSRv6 != nil {
+		remote.Srv6Segments, remote.Srv6LocalSid = peerSRv6.Srv6Segments, peerSRv6.Srv6LocalSid
+	}
+	ok, err := mpb.NewRemoteClient(conn).Update(ctx, remote)
+	if err != nil {
+		return err
+	}
+	if !ok.Response {
+		return fmt.Errorf("remote update of link %d on %s has failed", link.Uid, url)
+	}
+	return nil
+}
+
+// auditPatch queues the entry of a wire set up by PatchLink
+func (m *Meshnet) auditPatch(pod, ns string, uid int64, wireType string) {
+	m.queueAudit(&mpb.WireAudit{
+		Pod:        pod,
+		KubeNs:     ns,
+		LinkUid:    uid,
+		HowCreated: howCreatedPatchLink,
+		WireType:   wireType,
+	})
+}
+
+// removeIntf removes the local interface of a link, or gives it back to the host if it's
+// a VF. Removing one end of a veth pair removes both of them, ends connected to the OVS
+// bridge are removed from it as well.
+func removeIntf(netNs string, link *mpb.Link, bridge string) error {
+	if link.SriovVfPciAddr != "" {
+		return sriov.Detach(netNs, link.LocalIntf)
+	}
+	if bridge != "" {
+		// remote links are VXLAN interfaces, only same-node links have a bridge port
+		if _, err := netlink.LinkByName(ovs.PortName(netNs, link.LocalIntf)); err == nil {
+			return ovs.Detach(netNs, link.LocalIntf, bridge)
+		}
+	}
+	veth := koko.VEth{NsName: netNs, LinkName: link.LocalIntf}
+	return veth.RemoveVethLink()
+}
+
+// hasIntf returns whether the netNs network namespace has an interface called intf, as
+// koko.IsExistLinkInNS always returns false
+func hasIntf(netNs, intf string) bool {
+	return inNetNs(netNs, func() error {
+		_, err := netlink.LinkByName(intf)
+		return err
+	}) == nil
+}
+
+// validateIntfName checks that name is a valid Linux interface name, so that links fail
+// when they're added rather than when their wire is set up
+func validateIntfName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("interface name is required")
+	case len(name) > maxIntfName:
+		return fmt.Errorf("interface name %q is longer than %d characters", name, maxIntfName)
+	case name == "." || name == "..":
+		return fmt.Errorf("invalid interface name %q", name)
+	case strings.ContainsAny(name, "/: \t\n"):
+		return fmt.Errorf("interface name %q contains '/', ':' or whitespace", name)
+	}
+	return nil
+}
+
+// configureEnd applies the impairments, the traffic class, the QoS map, the MPLS label and the
+// MTU of the local end of a link
+func configureEnd(netNs string, link *mpb.Link) error {
+	if err := impairment.Apply(netNs, link.LocalIntf, link.EgressImpairment, link.IngressImpairment); err != nil {
+		return err
+	}
+	if err := impairment.ApplyDSCP(netNs, link.LocalIntf, link.TrafficClass); err != nil {
+		return err
+	}
+	if err := impairment.ApplyQoS(netNs, link.LocalIntf, link.EgressQosMap, link.EgressQueueAllocation); err != nil {
+		return err
+	}
+	if err := encap.ApplyMPLS(netNs, link.LocalIntf, link.LocalIp, link.MplsLabel); err != nil {
+		return err
+	}
+	return pmtu.Apply(netNs, link.LocalIntf, link.Mtu)
+}
+
+func makeVeth(netNs, linkName, ip string) (*koko.VEth, error) {
+	veth := &koko.VEth{NsName: netNs, LinkName: linkName}
+	if ip != "" {
+		ipAddr, ipSubnet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CIDR %s: %s", ip, err)
+		}
+		veth.IPAddr = []net.IPNet{{IP: ipAddr, Mask: ipSubnet.Mask}}
+	}
+	return veth, nil
+}
+
+// mirroredFields are the fields of a link that reverseLink sets, which both ends must agree on
+var mirroredFields = []string{"peer_pod", "local_intf", "peer_intf", "local_ip", "peer_ip", "vxlan_gpe", "mtu", "profile_ref"}
+
+// mirrorLink returns the stored link of the peer pod with the mirrored fields of peer
+func mirrorLink(stored map[string]interface{}, peer *mpb.Link) map[string]interface{} {
+	result := make(map[string]interface{}, len(stored))
+	for k, v := range stored {
+		result[k] = v
+	}
+	want := linkToMap(peer)
+	for _, field := range mirroredFields {
+		if v, ok := want[field]; ok {
+			result[field] = v
+		} else {
+			delete(result, field)
+		}
+	}
+	return result
+}
+
+// reverseLink returns the same link as seen from the peer pod
+func reverseLink(pod string, link *mpb.Link) *mpb.Link {
+	return &mpb.Link{
+		PeerPod:   pod,
+		LocalIntf: link.PeerIntf,
+		PeerIntf:  link.LocalIntf,
+		LocalIp:   link.PeerIp,
+		PeerIp:    link.LocalIp,
+		Uid:       link.Uid,
+		VxlanGpe:  link.VxlanGpe,
+		Mtu:       link.Mtu,
+		Profile:   link.Profile,
+	}
+}
+
+func linkToMap(link *mpb.Link) map[string]interface{} {
+	result := map[string]interface{}{
+		"uid":        link.Uid,
+		"peer_pod":   link.PeerPod,
+		"local_intf": link.LocalIntf,
+		"peer_intf":  link.PeerIntf,
+	}
+	if link.LocalIp != "" {
+		result["local_ip"] = link.LocalIp
+	}
+	if link.PeerIp != "" {
+		result["peer_ip"] = link.PeerIp
+	}
+	if link.VxlanGpe {
+		result["vxlan_gpe"] = true
+	}
+	if link.MplsLabel != 0 {
+		result["mpls_label"] = int64(link.MplsLabel)
+	}
+	if link.EcmpGroup != "" {
+		result["ecmp_group"] = link.EcmpGroup
+	}
+	if link.SriovVfPciAddr != "" {
+		result["sriov_vf_pci_addr"] = link.SriovVfPciAddr
+	}
+	if len(link.Srv6Segments) > 0 {
+		var segments []interface{}
+		for _, sid := range link.Srv6Segments {
+			segments = append(segments, sid)
+		}
+		path := map[string]interface{}{"segments": segments}
+		if link.Srv6LocalSid != "" {
+			path["local_sid"] = link.Srv6LocalSid
+		}
+		result["srv6"] = path
+	}
+	if link.Mtu != 0 {
+		result["mtu"] = int64(link.Mtu)
+	}
+	if link.Profile != "" {
+		result["profile_ref"] = map[string]interface{}{"name": link.Profile}
+	}
+	if !impairment.IsEmpty(link.EgressImpairment) {
+		result["egress_impairment"] = impairmentToMap(link.EgressImpairment)
+	}
+	if !impairment.IsEmpty(link.IngressImpairment) {
+		result["ingress_impairment"] = impairmentToMap(link.IngressImpairment)
+	}
+	if len(link.EgressQosMap) > 0 {
+		var entries []interface{}
+		for _, e := range link.EgressQosMap {
+			entries = append(entries, map[string]interface{}{"dscp": int64(e.Dscp), "priority": int64(e.Priority)})
+		}
+		result["egress_qos_map"] = entries
+	}
+	if alloc := link.EgressQueueAllocation; alloc != nil {
+		var queues []interface{}
+		for _, q := range alloc.Queues {
+			queues = append(queues, map[string]interface{}{"priority": int64(q.Priority), "percent": q.Percent})
+		}
+		result["egress_queue_allocation"] = map[string]interface{}{"rate_mbps": alloc.RateMbps, "queues": queues}
+	}
+	return result
+}
+
+func impairmentToMap(spec *mpb.ImpairmentSpec) map[string]interface{} {
+	return map[string]interface{}{
+		"latency_ms":        spec.LatencyMs,
+		"jitter_ms":         spec.JitterMs,
+		"loss_percent":      spec.LossPercent,
+		"duplicate_percent": spec.DuplicatePercent,
+		"corrupt_percent":   spec.CorruptPercent,
+		"ecn_enabled":       spec.EcnEnabled,
+		// the threshold is a float32 like the other percentages
+		"ecn_threshold_percent": spec.EcnThresholdPercent,
+
+		"reorder_percent":             spec.ReorderPercent,
+		"reorder_correlation_percent": spec.ReorderCorrelationPercent,
+		"reorder_gap":                 int64(spec.ReorderGap),
+	}
+}
@@ -0,0 +1,120 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestPatchLinkIntfNames(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	tests := []struct {
+		local, peer string
+		op          mpb.LinkPatch_Operation
+		code        codes.Code
+	}{
+		{local: "eth3", peer: "eth3", op: mpb.LinkPatch_ADD, code: codes.OK},
+		{local: "ethernet-1-1-long", peer: "eth4", op: mpb.LinkPatch_ADD, code: codes.InvalidArgument},
+		{local: "eth4", peer: "", op: mpb.LinkPatch_ADD, code: codes.InvalidArgument},
+		{local: "eth/4", peer: "eth4", op: mpb.LinkPatch_UPDATE, code: codes.InvalidArgument},
+		// links are removed by UID
+		{local: "", peer: "", op: mpb.LinkPatch_REMOVE, code: codes.OK},
+	}
+	for i, tt := range tests {
+		_, err := m.PatchLink(ctx, &mpb.LinkPatch{
+			Pod:       "r1",
+			KubeNs:    "default",
+			Operation: tt.op,
+			Link:      &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: tt.local, PeerIntf: tt.peer},
+		})
+		if status.Code(err) != tt.code {
+			t.Errorf("#%d test failed: PatchLink(%s, %q, %q) = %v, want %s", i, tt.op, tt.local, tt.peer, err, tt.code)
+		}
+	}
+}
+
+func TestPatchLinkOperations(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	link := func(pod string, uid int64) *mpb.Link {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", pod, err)
+		}
+		for _, l := range p.Links {
+			if l.Uid == uid {
+				return l
+			}
+		}
+		return nil
+	}
+	loss := &mpb.ImpairmentSpec{LossPercent: 5}
+	latency := &mpb.ImpairmentSpec{LatencyMs: 10}
+	tests := []struct {
+		desc string
+		pod  string
+		op   mpb.LinkPatch_Operation
+		link *mpb.Link
+		code codes.Code
+		// the link 3 of r1 and r2 after the patch, nil if it doesn't exist
+		r1, r2 *mpb.Link
+	}{{
+		desc: "unspecified",
+		pod:  "r1",
+		link: &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth3", PeerIntf: "eth3"},
+		code: codes.InvalidArgument,
+	}, {
+		desc: "add",
+		pod:  "r1",
+		op:   mpb.LinkPatch_ADD,
+		link: &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth3", PeerIntf: "eth4"},
+		r1:   &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth3", PeerIntf: "eth4"},
+		r2:   &mpb.Link{Uid: 3, PeerPod: "r1", LocalIntf: "eth4", PeerIntf: "eth3"},
+	}, {
+		desc: "update of the peer's impairment",
+		pod:  "r2",
+		op:   mpb.LinkPatch_UPDATE,
+		link: &mpb.Link{Uid: 3, PeerPod: "r1", LocalIntf: "eth4", PeerIntf: "eth3", EgressImpairment: latency},
+		r1:   &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth3", PeerIntf: "eth4"},
+		r2:   &mpb.Link{Uid: 3, PeerPod: "r1", LocalIntf: "eth4", PeerIntf: "eth3", EgressImpairment: latency},
+	}, {
+		desc: "update mirrored to the peer",
+		pod:  "r1",
+		op:   mpb.LinkPatch_UPDATE,
+		link: &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth5", PeerIntf: "eth4", Mtu: 1400, EgressImpairment: loss},
+		r1:   &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth5", PeerIntf: "eth4", Mtu: 1400, EgressImpairment: loss},
+		// r2 keeps its own impairment
+		r2: &mpb.Link{Uid: 3, PeerPod: "r1", LocalIntf: "eth4", PeerIntf: "eth5", Mtu: 1400, EgressImpairment: latency},
+	}, {
+		desc: "update of a missing link",
+		pod:  "r1",
+		op:   mpb.LinkPatch_UPDATE,
+		link: &mpb.Link{Uid: 4, PeerPod: "r2", LocalIntf: "eth6", PeerIntf: "eth6"},
+		code: codes.Unknown,
+		r1:   &mpb.Link{Uid: 3, PeerPod: "r2", LocalIntf: "eth5", PeerIntf: "eth4", Mtu: 1400, EgressImpairment: loss},
+		r2:   &mpb.Link{Uid: 3, PeerPod: "r1", LocalIntf: "eth4", PeerIntf: "eth5", Mtu: 1400, EgressImpairment: latency},
+	}, {
+		desc: "remove",
+		pod:  "r2",
+		op:   mpb.LinkPatch_REMOVE,
+		link: &mpb.Link{Uid: 3, PeerPod: "r1"},
+	}}
+	for _, tt := range tests {
+		_, err := m.PatchLink(ctx, &mpb.LinkPatch{Pod: tt.pod, KubeNs: "default", Operation: tt.op, Link: tt.link})
+		if status.Code(err) != tt.code {
+			t.Fatalf("%s: PatchLink() = %v, want %s", tt.desc, err, tt.code)
+		}
+		for pod, want := range map[string]*mpb.Link{"r1": tt.r1, "r2": tt.r2} {
+			if got := link(pod, 3); !proto.Equal(got, want) {
+				t.Errorf("%s: link 3 of %s = %v, want %v", tt.desc, pod, got, want)
+			}
+		}
+	}
+}
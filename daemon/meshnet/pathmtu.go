@@ -0,0 +1,161 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/pmtu"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// pathHop is a link of a path between two pods, leaving from the end of pod
+type pathHop struct {
+	pod  *mpb.Pod
+	link *mpb.Link
+}
+
+// DiscoverMTU finds the shortest path between two pods through the links of their
+// topologies, and probes the path MTU of each of its links from the node of the pod the
+// traffic leaves from. The smallest of them is the MTU of the path. With -auto-set-path-mtu,
+// the discovered MTUs are set on the links and recorded in their topologies.
+func (m *Meshnet) DiscoverMTU(ctx context.Context, q *mpb.PathQuery) (*mpb.MTUDiscoveryResult, error) {
+	if q.SrcPod == "" || q.DstPod == "" || q.SrcPod == q.DstPod {
+		return nil, status.Errorf(codes.InvalidArgument, "a path needs two different pods, got %q and %q", q.SrcPod, q.DstPod)
+	}
+	hops, err := m.findPath(ctx, q.KubeNs, q.SrcPod, q.DstPod)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mpb.MTUDiscoveryResult{}
+	for _, hop := range hops {
+		probe := &mpb.LinkStatsQuery{Pod: hop.pod.Name, KubeNs: q.KubeNs, LinkUid: hop.link.Uid}
+		lm, err := m.probeLinkMTUFrom(ctx, hop.pod.SrcIp, probe)
+		if err != nil {
+			return nil, err
+		}
+		result.Hops = append(result.Hops, &mpb.HopMTU{Pod: hop.pod.Name, LinkUid: hop.link.Uid, NodeIp: hop.pod.SrcIp, Mtu: lm.Mtu})
+		if result.PathMtu == 0 || lm.Mtu < result.PathMtu {
+			result.PathMtu = lm.Mtu
+			result.BottleneckUid = hop.link.Uid
+		}
+	}
+	log.Infof("Path MTU from pod %s to %s is %d, limited by link %d", q.SrcPod, q.DstPod, result.PathMtu, result.BottleneckUid)
+
+	if !m.config.AutoSetPathMTU {
+		return result, nil
+	}
+	for i, hop := range hops {
+		if err := m.setPathMTU(ctx, q.KubeNs, hop, result.Hops[i].Mtu); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ProbeLinkMTU probes the path MTU from the local end of a link of a pod running on this
+// node to the IP address of its peer end.
+func (m *Meshnet) ProbeLinkMTU(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.LinkMTU, error) {
+	pod, link, err := m.runningLink(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	peerIP, _, err := net.ParseCIDR(link.PeerIp)
+	if err != nil {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: q.LinkUid},
+			"link %d of pod %s has no peer IP address to probe", q.LinkUid, q.Pod)
+	}
+	max, err := readLinkMTU(pod.NetNs, link.LocalIntf)
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{WireUid: q.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to read the MTU of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+	}
+	mtu, err := pmtu.Discover(pod.NetNs, peerIP, max, pmtu.DefaultTimeout)
+	if err != nil {
+		return nil, wireError(codes.Unavailable, &mpb.WireError{WireUid: q.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_NETLINK_ERROR},
+			"link %d of pod %s: %s", q.LinkUid, q.Pod, err)
+	}
+	return &mpb.LinkMTU{Pod: q.Pod, Intf: link.LocalIntf, NodeIp: pod.SrcIp, Mtu: int32(mtu)}, nil
+}
+
+// probeLinkMTUFrom probes locally if nodeIP is this node, or through the daemon on nodeIP
+func (m *Meshnet) probeLinkMTUFrom(ctx context.Context, nodeIP string, q *mpb.LinkStatsQuery) (*mpb.LinkMTU, error) {
+	if nodeIP == "" {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: q.LinkUid}, "pod %s is not running", q.Pod)
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.ProbeLinkMTU(ctx, q)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	return client.ProbeLinkMTU(ctx, q)
+}
+
+// findPath returns the links of the shortest path from the pod src to dst. Pods without a
+// topology are dead ends.
+func (m *Meshnet) findPath(ctx context.Context, ns, src, dst string) ([]pathHop, error) {
+	via := map[string]pathHop{}
+	prev := map[string]string{}
+	seen := map[string]bool{src: true}
+	queue := []string{src}
+	for len(queue) > 0 && !seen[dst] {
+		name := queue[0]
+		queue = queue[1:]
+		pod, err := m.Get(ctx, &mpb.PodQuery{Name: name, KubeNs: ns})
+		if status.Code(err) == codes.NotFound && name != src {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range pod.Links {
+			if link.PeerPod == localhost || seen[link.PeerPod] {
+				continue
+			}
+			seen[link.PeerPod] = true
+			via[link.PeerPod] = pathHop{pod: pod, link: link}
+			prev[link.PeerPod] = name
+			queue = append(queue, link.PeerPod)
+		}
+	}
+	if !seen[dst] {
+		return nil, status.Errorf(codes.NotFound, "no path from pod %s to %s", src, dst)
+	}
+	var hops []pathHop
+	for name := dst; name != src; name = prev[name] {
+		hops = append([]pathHop{via[name]}, hops...)
+	}
+	return hops, nil
+}
+
+// setPathMTU records the MTU of the link of hop in the topologies of its two pods, and
+// sets the MTU of its interfaces
+func (m *Meshnet) setPathMTU(ctx context.Context, ns string, hop pathHop, mtu int32) error {
+	if hop.link.Mtu == mtu {
+		return nil
+	}
+	setMTU := func(idx int) ([]jsonPatch, error) {
+		if idx < 0 {
+			return nil, nil
+		}
+		return []jsonPatch{
+			{Op: "test", Path: fmt.Sprintf("/spec/links/%d/uid", idx), Value: hop.link.Uid},
+			{Op: "add", Path: fmt.Sprintf("/spec/links/%d/mtu", idx), Value: mtu},
+		}, nil
+	}
+	for _, pod := range []string{hop.pod.Name, hop.link.PeerPod} {
+		if err := m.patchLinks(ctx, pod, ns, hop.link.Uid, setMTU); err != nil {
+			return k8sError(err, mpb.WireError_UPDATE, "failed to record the MTU of link %d in topology %s", hop.link.Uid, pod)
+		}
+	}
+	_, err := m.SetInterfaceMTU(ctx, &mpb.MTURequest{Pod: hop.pod.Name, KubeNs: ns, LinkUid: hop.link.Uid, Mtu: mtu})
+	return err
+}
@@ -0,0 +1,41 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestDiscoverMTU(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet([]unstructured.Unstructured{
+		meshnettest.Topology("default", "r1", []string{"r2"}, []int64{1}),
+		meshnettest.Topology("default", "r2", []string{"r1", "r3", "gone"}, []int64{1, 2, 3}),
+		meshnettest.Topology("default", "r3", []string{"r2", localhost}, []int64{2, 4}),
+		meshnettest.Topology("default", "r4", []string{localhost}, []int64{5}),
+	})
+
+	tests := []struct {
+		src, dst string
+		code     codes.Code
+	}{
+		{src: "r1", dst: "r1", code: codes.InvalidArgument},
+		{src: "r1", dst: "", code: codes.InvalidArgument},
+		{src: "r1", dst: "r4", code: codes.NotFound},
+		{src: "nope", dst: "r1", code: codes.NotFound},
+		// the path is found, but its pods aren't running
+		{src: "r1", dst: "r3", code: codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		_, err := m.DiscoverMTU(ctx, &mpb.PathQuery{KubeNs: "default", SrcPod: tt.src, DstPod: tt.dst})
+		if status.Code(err) != tt.code {
+			t.Errorf("DiscoverMTU(%s, %s) = %v, want %s", tt.src, tt.dst, err, tt.code)
+		}
+	}
+}
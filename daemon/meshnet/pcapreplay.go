@@ -0,0 +1,200 @@
+package meshnet
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	defaultReplayKey = "pcap"
+	// a progress message is sent every replayProgressFrames frames, besides the end of each loop
+	replayProgressFrames = 100
+)
+
+// ReplayPCAP injects the frames of a pcap file into the end of a link in a pod, sending them out
+// of its interface on the node the pod is running on, with the gaps they were captured with
+// divided by replay_speed_factor. It returns once the file has been replayed loop_count times,
+// or when the call is cancelled for an endless replay, and fails if some frames couldn't be sent.
+func (m *Meshnet) ReplayPCAP(ctx context.Context, req *mpb.PCAPReplayRequest) (*mpb.BoolResponse, error) {
+	var last *mpb.ReplayProgress
+	err := m.replayPCAP(ctx, req, func(p *mpb.ReplayProgress) error {
+		last = p
+		return nil
+	})
+	if err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	if last != nil && last.Errors > 0 {
+		return &mpb.BoolResponse{Response: false}, wireError(codes.Internal, &mpb.WireError{WireUid: req.LinkUid, Cause: mpb.WireError_PCAP_ERROR},
+			"%d frames of the replay into link %d of pod %s have failed, the last one with: %s", last.Errors, req.LinkUid, req.Pod, last.LastError)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// StreamReplayPCAP replays a pcap file like ReplayPCAP, streaming the progress of the replay
+func (m *Meshnet) StreamReplayPCAP(req *mpb.PCAPReplayRequest, stream mpb.Local_StreamReplayPCAPServer) error {
+	return m.replayPCAP(stream.Context(), req, stream.Send)
+}
+
+// ReplayLink replays a pcap file into the end of a link in a pod running on this node
+func (m *Meshnet) ReplayLink(req *mpb.PCAPReplayRequest, stream mpb.Remote_ReplayLinkServer) error {
+	if err := validateReplay(req); err != nil {
+		return err
+	}
+	return m.replayLink(stream.Context(), req, stream.Send)
+}
+
+func (m *Meshnet) replayPCAP(ctx context.Context, req *mpb.PCAPReplayRequest, send func(*mpb.ReplayProgress) error) error {
+	if err := validateReplay(req); err != nil {
+		return err
+	}
+	if len(req.Pcap) == 0 {
+		data, err := m.replayFile(ctx, req)
+		if err != nil {
+			return err
+		}
+		// the daemon of the pod's node gets the file itself
+		req = proto.Clone(req).(*mpb.PCAPReplayRequest)
+		req.Pcap, req.ConfigMap, req.ConfigMapKey = data, "", ""
+	}
+	nodeIP, err := m.linkNode(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return err
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.replayLink(ctx, req, send)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, req.LinkUid)
+	if err != nil {
+		return err
+	}
+	remote, err := client.ReplayLink(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		p, err := remote.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := send(p); err != nil {
+			return err
+		}
+	}
+}
+
+// replayFile reads the pcap file of a replay from its ConfigMap
+func (m *Meshnet) replayFile(ctx context.Context, req *mpb.PCAPReplayRequest) ([]byte, error) {
+	cm, err := m.kClient.CoreV1().ConfigMaps(req.KubeNs).Get(ctx, req.ConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read ConfigMap %s", req.ConfigMap)
+	}
+	key := req.ConfigMapKey
+	if key == "" {
+		key = defaultReplayKey
+	}
+	if data, ok := cm.BinaryData[key]; ok {
+		return data, nil
+	}
+	if data, ok := cm.Data[key]; ok {
+		return []byte(data), nil
+	}
+	return nil, status.Errorf(codes.NotFound, "ConfigMap %s has no key %s", req.ConfigMap, key)
+}
+
+func (m *Meshnet) replayLink(ctx context.Context, req *mpb.PCAPReplayRequest, send func(*mpb.ReplayProgress) error) error {
+	pod, link, err := m.runningLink(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return err
+	}
+	records, err := capture.ReadPCAP(bytes.NewReader(req.Pcap))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid pcap file: %s", err)
+	}
+	if len(records) == 0 {
+		return status.Errorf(codes.InvalidArgument, "the pcap file has no frames")
+	}
+	s, err := capture.OpenInjector(pod.NetNs, link.LocalIntf)
+	if err != nil {
+		return wireError(codes.Internal, &mpb.WireError{WireUid: req.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_PCAP_ERROR},
+			"failed to open %s in pod %s: %s", link.LocalIntf, req.Pod, err)
+	}
+	defer s.Close()
+	log.Infof("Replaying %d frames into %s of pod %s", len(records), link.LocalIntf, req.Pod)
+	return replayFrames(ctx, records, req.ReplaySpeedFactor, req.LoopCount, s.Send, send)
+}
+
+// replayFrames injects records loops times, forever if loops is negative, keeping the gaps
+// between them divided by speed. It reports the progress every replayProgressFrames frames, at
+// the end of each loop and once done. Frames that fail to be injected are counted as errors.
+func replayFrames(ctx context.Context, records []capture.Record, speed float64, loops int64, inject func([]byte) error, report func(*mpb.ReplayProgress) error) error {
+	if speed == 0 {
+		speed = 1
+	}
+	if loops == 0 {
+		loops = 1
+	}
+	p := &mpb.ReplayProgress{}
+	for loops < 0 || p.Loops < loops {
+		start := time.Now()
+		for i, r := range records {
+			due := start.Add(time.Duration(float64(r.Time.Sub(records[0].Time)) / speed))
+			if wait := time.Until(due); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(wait):
+				}
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := inject(r.Data); err != nil {
+				p.Errors++
+				p.LastError = err.Error()
+			} else {
+				p.FramesSent++
+				p.BytesSent += int64(len(r.Data))
+			}
+			if (i+1)%replayProgressFrames == 0 && i+1 < len(records) {
+				if err := report(proto.Clone(p).(*mpb.ReplayProgress)); err != nil {
+					return err
+				}
+			}
+		}
+		p.Loops++
+		if loops < 0 || p.Loops < loops {
+			if err := report(proto.Clone(p).(*mpb.ReplayProgress)); err != nil {
+				return err
+			}
+		}
+	}
+	p.Done = true
+	return report(p)
+}
+
+func validateReplay(req *mpb.PCAPReplayRequest) error {
+	if req.ReplaySpeedFactor < 0 || math.IsNaN(req.ReplaySpeedFactor) || math.IsInf(req.ReplaySpeedFactor, 0) {
+		return status.Errorf(codes.InvalidArgument, "invalid replay speed factor %v", req.ReplaySpeedFactor)
+	}
+	if len(req.Pcap) == 0 && req.ConfigMap == "" {
+		return status.Errorf(codes.InvalidArgument, "the replay has no pcap file nor ConfigMap")
+	}
+	return nil
+}
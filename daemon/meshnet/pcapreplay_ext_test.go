@@ -0,0 +1,100 @@
+package meshnet_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestReplayPCAP(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		req  *mpb.PCAPReplayRequest
+		code codes.Code
+	}{
+		{req: &mpb.PCAPReplayRequest{Pod: "r1", KubeNs: "default", LinkUid: 1}, code: codes.InvalidArgument},
+		{req: &mpb.PCAPReplayRequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Pcap: []byte{1}, ReplaySpeedFactor: -1}, code: codes.InvalidArgument},
+		{req: &mpb.PCAPReplayRequest{Pod: "r1", KubeNs: "default", LinkUid: 1, ConfigMap: "capture"}, code: codes.NotFound},
+		// r1 isn't running
+		{req: &mpb.PCAPReplayRequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Pcap: []byte{1}}, code: codes.FailedPrecondition},
+	}
+	m := meshnettest.NewFakeMeshnet(lab())
+	for _, tt := range tests {
+		if _, err := m.ReplayPCAP(ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("ReplayPCAP(%v) = %v, want %s", tt.req, err, tt.code)
+		}
+	}
+}
+
+func TestReplayPCAPFrames(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	netNs := runningPod(t, m, "r1", "eth1")
+
+	var pcap bytes.Buffer
+	if err := capture.WriteHeader(&pcap, capture.DefaultSnaplen); err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Now()
+	var frames [][]byte
+	for i := 0; i < 3; i++ {
+		frame := udpFrame(7000 + uint16(i))
+		if err := capture.WritePacket(&pcap, t0.Add(time.Duration(i)*10*time.Millisecond), frame, len(frame)); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, frame)
+	}
+
+	// the replayed frames leave eth1 for the other end of its veth
+	s, err := capture.Open(netNs.Path(), "peer1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	start := time.Now()
+	resp, err := m.ReplayPCAP(ctx, &mpb.PCAPReplayRequest{Pod: "r1", KubeNs: "default", LinkUid: 1, Pcap: pcap.Bytes(), LoopCount: 2})
+	if err != nil || !resp.Response {
+		t.Fatalf("ReplayPCAP() = %v, %v", resp, err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("replay took %s, want the gaps of 20ms of each of the 2 loops", elapsed)
+	}
+
+	filter, err := capture.ParseFilter("udp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received [][]byte
+	errDone := errors.New("done")
+	captureCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	err = capture.Capture(captureCtx, s, filter, capture.DefaultSnaplen, func(_ time.Time, data []byte, _ int) error {
+		received = append(received, append([]byte(nil), data...))
+		if len(received) == 2*len(frames) {
+			return errDone
+		}
+		return nil
+	})
+	if err != nil && err != errDone {
+		t.Fatal(err)
+	}
+	want := append(frames, frames...)
+	if len(received) != len(want) {
+		t.Fatalf("received %d frames on the other end of eth1, want %d", len(received), len(want))
+	}
+	for i := range want {
+		if !bytes.Equal(received[i], want[i]) {
+			t.Errorf("frame %d = % x, want % x", i, received[i], want[i])
+		}
+	}
+}
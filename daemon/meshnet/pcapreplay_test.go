@@ -0,0 +1,64 @@
+package meshnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestReplayFrames(t *testing.T) {
+	t0 := time.Unix(10, 0)
+	records := []capture.Record{
+		{Time: t0, Data: []byte{1, 2, 3}},
+		{Time: t0.Add(20 * time.Millisecond), Data: []byte{4}},
+		{Time: t0.Add(40 * time.Millisecond), Data: []byte{5, 6}},
+	}
+	inject := func(data []byte) error {
+		if data[0] == 4 {
+			return errors.New("too big")
+		}
+		return nil
+	}
+
+	var reports []*mpb.ReplayProgress
+	start := time.Now()
+	// 40ms at twice the speed, twice
+	err := replayFrames(context.Background(), records, 2, 2, inject, func(p *mpb.ReplayProgress) error {
+		reports = append(reports, p)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("replay took %s, want at least 40ms", elapsed)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("reports = %v, want one per loop", reports)
+	}
+	last := reports[1]
+	if last.FramesSent != 4 || last.BytesSent != 10 || last.Errors != 2 || last.LastError != "too big" || last.Loops != 2 || !last.Done {
+		t.Errorf("last report = %v, want 4 frames of 10 bytes, 2 errors, 2 loops, done", last)
+	}
+	if reports[0].Loops != 1 || reports[0].Done {
+		t.Errorf("first report = %v, want 1 loop, not done", reports[0])
+	}
+
+	// endless until cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	reports = nil
+	if err := replayFrames(ctx, records, 10, -1, inject, func(p *mpb.ReplayProgress) error {
+		reports = append(reports, p)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) < 2 || reports[len(reports)-1].Done {
+		t.Errorf("endless replay reported %v, want several loops not done", reports)
+	}
+}
@@ -0,0 +1,55 @@
+package meshnet
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// peerConns holds one connection to the daemon of each peer node. The RPCs of all the
+// wires to a node are multiplexed on its connection as HTTP/2 streams, so wires can be
+// added and removed without dialing or closing anything.
+type peerConns struct {
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newPeerConns() *peerConns {
+	return &peerConns{conns: make(map[string]*grpc.ClientConn)}
+}
+
+// get returns the connection to url, dialing it if there's none or it was closed
+func (p *peerConns) get(ctx context.Context, url string, dial func(context.Context, string) (*grpc.ClientConn, error)) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if conn, ok := p.conns[url]; ok {
+		if conn.GetState() != connectivity.Shutdown {
+			return conn, nil
+		}
+		delete(p.conns, url)
+	}
+	conn, err := dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[url] = conn
+	return conn, nil
+}
+
+// closeAll closes the connections to all the peers
+func (p *peerConns) closeAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for url, conn := range p.conns {
+		conn.Close()
+		delete(p.conns, url)
+	}
+}
+
+// peerConn returns the shared connection to the daemon at url. It must not be closed
+// by the caller.
+func (m *Meshnet) peerConn(ctx context.Context, url string) (*grpc.ClientConn, error) {
+	return m.conns.get(ctx, url, m.dial)
+}
@@ -0,0 +1,50 @@
+package meshnet
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestPeerConns(t *testing.T) {
+	ctx := context.Background()
+	dials := 0
+	dial := func(ctx context.Context, url string) (*grpc.ClientConn, error) {
+		dials++
+		return grpc.DialContext(ctx, url, grpc.WithInsecure())
+	}
+	p := newPeerConns()
+
+	a, err := p.get(ctx, "10.0.0.1:51111", dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := p.get(ctx, "10.0.0.1:51111", dial); again != a || dials != 1 {
+		t.Errorf("second get() dialed again, %d dials", dials)
+	}
+	b, _ := p.get(ctx, "10.0.0.2:51111", dial)
+	if b == a || dials != 2 {
+		t.Errorf("get() of another peer = the first connection, %d dials", dials)
+	}
+
+	v6, err := p.get(ctx, net.JoinHostPort("::1", "51111"), dial)
+	if err != nil || v6.Target() != "[::1]:51111" || dials != 3 {
+		t.Errorf("get() of an IPv6 peer = %v, %v, %d dials, want [::1]:51111", v6.Target(), err, dials)
+	}
+
+	a.Close()
+	if again, _ := p.get(ctx, "10.0.0.1:51111", dial); again == a || dials != 4 {
+		t.Errorf("get() of a closed connection didn't dial again, %d dials", dials)
+	}
+
+	p.closeAll()
+	if state := b.GetState(); state != connectivity.Shutdown {
+		t.Errorf("state after closeAll() = %s, want %s", state, connectivity.Shutdown)
+	}
+	if len(p.conns) != 0 {
+		t.Errorf("%d connections left after closeAll()", len(p.conns))
+	}
+}
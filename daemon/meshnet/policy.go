@@ -0,0 +1,49 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/networkop/meshnet-cni/daemon/policy"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// GenerateNetworkPolicies returns the NetworkPolicies that keep apart the pods of a namespace
+// that aren't connected by topology links. They select the pods by the label set by
+// labelPod.
+func (m *Meshnet) GenerateNetworkPolicies(ctx context.Context, q *mpb.TopologyQuery) (*mpb.PolicyBundle, error) {
+	log.Infof("Generating network policies for namespace %s", q.KubeNs)
+
+	topologies, err := m.tClient.Topology(q.KubeNs).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	policies := policy.Generate(q.KubeNs, topologies.Items)
+	data, err := json.Marshal(policies)
+	if err != nil {
+		return nil, err
+	}
+	return &mpb.PolicyBundle{Policies: data, Count: int64(len(policies))}, nil
+}
+
+// labelPod sets the topology label of a pod, which the generated NetworkPolicies select it by.
+// It's a best effort, the pod's wires don't depend on it.
+func (m *Meshnet) labelPod(ctx context.Context, ns, name string) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{policy.TopologyLabel: name},
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, err = m.kClient.CoreV1().Pods(ns).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Warnf("Failed to label pod %s/%s with its topology: %s", ns, name, err)
+	}
+}
@@ -0,0 +1,34 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/policy"
+)
+
+func TestLabelPod(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "lab", Labels: map[string]string{"app": "srl"}},
+	})
+	m, err := NewWithClients(Config{DisableReflection: true}, client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.labelPod(ctx, "lab", "r1")
+	// pods that don't exist anymore are ignored
+	m.labelPod(ctx, "lab", "r2")
+
+	pod, err := client.CoreV1().Pods("lab").Get(ctx, "r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod.Labels[policy.TopologyLabel] != "r1" || pod.Labels["app"] != "srl" {
+		t.Errorf("labels of r1 = %v, want its own and %s=r1", pod.Labels, policy.TopologyLabel)
+	}
+}
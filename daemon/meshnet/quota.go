@@ -0,0 +1,160 @@
+package meshnet
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	quotaPrefix = "meshnet-quota-"
+	// how long a wire reserved by PatchLink counts against the quota without being in a topology
+	reservationTTL = time.Minute
+)
+
+// quotaName returns the name of the ConfigMap of the wires reserved in ns, which is kept in
+// the daemon's namespace, where tenants can't write
+func quotaName(ns string) string {
+	return quotaPrefix + ns
+}
+
+// GetNamespaceUsage returns the usage of the MeshnetNamespaceQuota of a namespace, with no
+// limits if it doesn't have one
+func (m *Meshnet) GetNamespaceUsage(ctx context.Context, q *mpb.NamespaceQuery) (*mpb.UsageReport, error) {
+	if q.KubeNs == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "namespace is required")
+	}
+	quota, err := m.tClient.NamespaceQuota(ctx, q.KubeNs)
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to get the quota of namespace %s", q.KubeNs)
+	}
+	var limits topologyv1.NamespaceQuotaSpec
+	if quota != nil {
+		limits = quota.Spec
+	}
+	topologies, err := m.tClient.Topology(q.KubeNs).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of namespace %s", q.KubeNs)
+	}
+	links := 0
+	for _, t := range topologies.Items {
+		if len(t.Spec.Links) > links {
+			links = len(t.Spec.Links)
+		}
+	}
+	var reserved map[string]string
+	cm, err := m.kClient.CoreV1().ConfigMaps(daemonNamespace()).Get(ctx, quotaName(q.KubeNs), metav1.GetOptions{})
+	if err == nil {
+		reserved = cm.Data
+	} else if !apierrors.IsNotFound(err) {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to read ConfigMap %s", quotaName(q.KubeNs))
+	}
+	wires := usedWires(topologyv1.WireKeys(topologies.Items), reserved, time.Now())
+	return &mpb.UsageReport{
+		KubeNs: q.KubeNs,
+		Usage: []*mpb.ResourceUsage{
+			{Resource: "topologies", Used: int64(len(topologies.Items)), Limit: limits.MaxTopologies},
+			{Resource: "links_per_topology", Used: int64(links), Limit: limits.MaxLinksPerTopology},
+			{Resource: "total_wires", Used: int64(len(wires)), Limit: limits.MaxTotalWires},
+		},
+	}, nil
+}
+
+// usedWires returns the wires of a namespace: those of its topologies, and those reserved
+// by PatchLink that haven't expired yet
+func usedWires(wires map[string]bool, reserved map[string]string, now time.Time) map[string]bool {
+	result := make(map[string]bool, len(wires)+len(reserved))
+	for key := range wires {
+		result[key] = true
+	}
+	for key, at := range reserved {
+		if t, err := time.Parse(time.RFC3339, at); err == nil && now.Sub(t) < reservationTTL {
+			result[key] = true
+		}
+	}
+	return result
+}
+
+// reserveWire counts the wire of link uid between pod and peer against the max_total_wires of
+// the quota of ns until release is called, failing with ResourceExhausted if ns would exceed
+// it. The wires of ns are counted from its topologies, wherever they were created, and the
+// reservations of the other PatchLink calls, which are serialized by their ConfigMap.
+func (m *Meshnet) reserveWire(ctx context.Context, ns, pod, peer string, uid int64) (release func(), err error) {
+	quota, err := m.tClient.NamespaceQuota(ctx, ns)
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to get the quota of namespace %s", ns)
+	}
+	if quota == nil || quota.Spec.MaxTotalWires == 0 {
+		return func() {}, nil
+	}
+	max := quota.Spec.MaxTotalWires
+	key := topologyv1.WireKey(pod, peer, int(uid))
+	err = m.updateReservations(ctx, ns, func(reserved map[string]string, now time.Time) error {
+		topologies, err := m.tClient.Topology(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		wires := usedWires(topologyv1.WireKeys(topologies.Items), reserved, now)
+		if !wires[key] && int64(len(wires))+1 > max {
+			return status.Errorf(codes.ResourceExhausted, "namespace %s has %d wires, its quota allows %d", ns, len(wires), max)
+		}
+		reserved[key] = now.UTC().Format(time.RFC3339)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		// the wire is counted from the topologies once it's in them
+		err := m.updateReservations(context.Background(), ns, func(reserved map[string]string, _ time.Time) error {
+			delete(reserved, key)
+			return nil
+		})
+		if err != nil {
+			log.Warnf("Failed to release the reservation of wire %s of namespace %s: %s", key, ns, err)
+		}
+	}, nil
+}
+
+// updateReservations applies update to the wires reserved in ns, stored in its quota
+// ConfigMap, retrying if they have changed in the meantime. Expired reservations are dropped.
+func (m *Meshnet) updateReservations(ctx context.Context, ns string, update func(reserved map[string]string, now time.Time) error) error {
+	cms := m.kClient.CoreV1().ConfigMaps(daemonNamespace())
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := cms.Get(ctx, quotaName(ns), metav1.GetOptions{})
+		create := apierrors.IsNotFound(err)
+		if create {
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: quotaName(ns)}}
+		} else if err != nil {
+			return err
+		}
+		now := time.Now()
+		reserved := make(map[string]string)
+		for key := range usedWires(nil, cm.Data, now) {
+			reserved[key] = cm.Data[key]
+		}
+		if err := update(reserved, now); err != nil {
+			return err
+		}
+		cm.Data = reserved
+		if create {
+			_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			return err
+		}
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
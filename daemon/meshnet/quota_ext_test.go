@@ -0,0 +1,98 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestNamespaceQuota(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "meshnet")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	wires := func() int64 {
+		t.Helper()
+		r, err := m.GetNamespaceUsage(ctx, &mpb.NamespaceQuery{KubeNs: "default"})
+		if err != nil {
+			t.Fatalf("GetNamespaceUsage() failed: %v", err)
+		}
+		for _, u := range r.Usage {
+			if u.Resource == "total_wires" {
+				return u.Used
+			}
+		}
+		t.Fatalf("GetNamespaceUsage() = %v, want the total_wires usage", r)
+		return 0
+	}
+	if _, err := m.GetNamespaceUsage(ctx, &mpb.NamespaceQuery{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetNamespaceUsage() without a namespace = %v, want InvalidArgument", err)
+	}
+	r, err := m.GetNamespaceUsage(ctx, &mpb.NamespaceQuery{KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("GetNamespaceUsage() failed: %v", err)
+	}
+	for _, u := range r.Usage {
+		if u.Used != 2 || u.Limit != 0 {
+			t.Errorf("GetNamespaceUsage() %s = %d of %d, want 2 without a limit", u.Resource, u.Used, u.Limit)
+		}
+	}
+
+	meshnettest.Store(m).Quotas = map[string]*topologyv1.MeshnetNamespaceQuota{
+		"default": {ObjectMeta: metav1.ObjectMeta{Name: "default"}, Spec: topologyv1.NamespaceQuotaSpec{MaxTotalWires: 3}},
+	}
+	tests := []struct {
+		op    mpb.LinkPatch_Operation
+		uid   int64
+		intf  string
+		code  codes.Code
+		wires int64
+	}{
+		{op: mpb.LinkPatch_ADD, uid: 3, intf: "eth3", code: codes.OK, wires: 3},
+		{op: mpb.LinkPatch_ADD, uid: 4, intf: "eth4", code: codes.ResourceExhausted, wires: 3},
+		{op: mpb.LinkPatch_REMOVE, uid: 3, code: codes.OK, wires: 2},
+		// removing a link twice doesn't release its wire twice
+		{op: mpb.LinkPatch_REMOVE, uid: 3, code: codes.OK, wires: 2},
+		{op: mpb.LinkPatch_ADD, uid: 4, intf: "eth4", code: codes.OK, wires: 3},
+	}
+	for i, tt := range tests {
+		_, err := m.PatchLink(ctx, &mpb.LinkPatch{
+			Pod:       "r1",
+			KubeNs:    "default",
+			Operation: tt.op,
+			Link:      &mpb.Link{Uid: tt.uid, PeerPod: "r2", LocalIntf: tt.intf, PeerIntf: tt.intf},
+		})
+		if status.Code(err) != tt.code {
+			t.Errorf("#%d test failed: PatchLink(%s, %d) = %v, want %s", i, tt.op, tt.uid, err, tt.code)
+		}
+		if got := wires(); got != tt.wires {
+			t.Errorf("#%d test failed: %d wires after PatchLink(%s, %d), want %d", i, got, tt.op, tt.uid, tt.wires)
+		}
+	}
+
+	// wires of topologies created through the API count as well
+	if _, err := meshnettest.Store(m).Topology("default").Create(ctx, &topologyv1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: "r3", Namespace: "default"},
+		Spec:       topologyv1.TopologySpec{Links: []topologyv1.Link{{UID: 9, PeerPod: "r1", LocalIntf: "eth9", PeerIntf: "eth9"}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := wires(); got != 4 {
+		t.Errorf("%d wires after creating a topology, want 4", got)
+	}
+	_, err = m.PatchLink(ctx, &mpb.LinkPatch{
+		Pod:       "r1",
+		KubeNs:    "default",
+		Operation: mpb.LinkPatch_ADD,
+		Link:      &mpb.Link{Uid: 5, PeerPod: "r2", LocalIntf: "eth5", PeerIntf: "eth5"},
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("PatchLink() above the quota = %v, want ResourceExhausted", err)
+	}
+}
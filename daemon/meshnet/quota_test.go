@@ -0,0 +1,22 @@
+package meshnet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsedWires(t *testing.T) {
+	now := time.Now()
+	wires := map[string]bool{"r1_r2_1": true}
+	reserved := map[string]string{
+		// the link of a reservation is already in the topology
+		"r1_r2_1": now.Format(time.RFC3339),
+		"r1_r3_2": now.Add(-reservationTTL / 2).Format(time.RFC3339),
+		"r1_r4_3": now.Add(-reservationTTL).Format(time.RFC3339),
+		"r1_r5_4": "invalid",
+	}
+	got := usedWires(wires, reserved, now)
+	if len(got) != 2 || !got["r1_r2_1"] || !got["r1_r3_2"] {
+		t.Errorf("usedWires() = %v, want r1_r2_1 and r1_r3_2", got)
+	}
+}
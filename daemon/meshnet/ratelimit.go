@@ -0,0 +1,114 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkop/meshnet-cni/daemon/logging"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// rateLimited counts the RPCs rejected by the rate limiter, by full method name
+var rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "meshnet_rpc_rate_limited_total",
+	Help: "Number of RPCs rejected by the daemon's rate limiter.",
+}, []string{"method"})
+
+func init() {
+	prometheus.MustRegister(rateLimited)
+}
+
+// Liveness probes must not fail just because the daemon is busy
+var unlimitedMethods = map[string]bool{
+	"/meshnet.v1beta1.Local/HealthCheck": true,
+	"/grpc.health.v1.Health/Check":       true,
+}
+
+// RateLimit is a token bucket of Rate requests per second with Burst tokens
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// rateLimitConfig is the format of the per-method rate limit file, keyed by full method
+// name, since the Local and Remote services have methods of the same name, e.g.
+//
+//	methods:
+//	  /meshnet.v1beta1.Local/SetAlive:
+//	    rate: 10
+//	    burst: 20
+type rateLimitConfig struct {
+	Methods map[string]RateLimit `json:"methods"`
+}
+
+// rateLimiter limits the rate of unary RPCs, using a per-method limit if there's one
+// and falling back to a limit shared by all other methods
+type rateLimiter struct {
+	global  *rate.Limiter
+	methods map[string]*rate.Limiter
+	// logger of the rejected calls, by method
+	log *logging.RateLimitedLogger
+}
+
+func newRateLimiter(global RateLimit, configFile string, logs *logging.RateLimitedLogger) (*rateLimiter, error) {
+	r := &rateLimiter{methods: make(map[string]*rate.Limiter), log: logs}
+	if global.Rate > 0 {
+		r.global = rate.NewLimiter(rate.Limit(global.Rate), global.Burst)
+	}
+	if configFile == "" {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit config: %v", err)
+	}
+	cfg := rateLimitConfig{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit config: %v", err)
+	}
+	for method, l := range cfg.Methods {
+		if !isFullMethod(method) {
+			return nil, fmt.Errorf("rate limit config: %q is not a full method name, e.g. /meshnet.v1beta1.Local/SetAlive", method)
+		}
+		log.Infof("Limiting %s to %v requests/s with a burst of %d", method, l.Rate, l.Burst)
+		r.methods[method] = rate.NewLimiter(rate.Limit(l.Rate), l.Burst)
+	}
+	return r, nil
+}
+
+// isFullMethod returns whether method is a full gRPC method name, /<package>.<service>/<method>
+func isFullMethod(method string) bool {
+	parts := strings.Split(method, "/")
+	return len(parts) == 3 && parts[0] == "" && parts[1] != "" && parts[2] != ""
+}
+
+func (r *rateLimiter) limiter(fullMethod string) *rate.Limiter {
+	if l, ok := r.methods[fullMethod]; ok {
+		return l
+	}
+	if unlimitedMethods[fullMethod] {
+		return nil
+	}
+	return r.global
+}
+
+// unaryInterceptor rejects the calls beyond their rate limit with ResourceExhausted and a
+// RATE_LIMITED detail, which the CNI plugin retries with a backoff
+func (r *rateLimiter) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if l := r.limiter(info.FullMethod); l != nil && !l.Allow() {
+		rateLimited.WithLabelValues(info.FullMethod).Inc()
+		r.log.Warnf(info.FullMethod, "Rate limit exceeded for %s", info.FullMethod)
+		return nil, wireError(codes.ResourceExhausted, &mpb.WireError{Cause: mpb.WireError_RATE_LIMITED}, "rate limit exceeded for %s", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,98 @@
+package meshnet
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/logging"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestRateLimiter(t *testing.T) {
+	f, err := ioutil.TempFile("", "ratelimit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("methods:\n  /meshnet.v1beta1.Local/Skip:\n    rate: 1\n    burst: 3\n")
+	f.Close()
+
+	r, err := newRateLimiter(RateLimit{Rate: 1, Burst: 1}, f.Name(), logging.NewRateLimitedLogger(log.NewEntry(log.StandardLogger()), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return true, nil
+	}
+
+	tests := []struct {
+		method  string
+		allowed int
+	}{
+		{
+			method:  "/meshnet.v1beta1.Local/SetAlive",
+			allowed: 1,
+		},
+		{
+			method:  "/meshnet.v1beta1.Local/Skip",
+			allowed: 3,
+		},
+		{
+			method:  "/meshnet.v1beta1.Local/HealthCheck",
+			allowed: 5,
+		},
+		{
+			// the limit of the Local method doesn't apply to the Remote one of the same name, which
+			// gets the global one, already used up by SetAlive
+			method:  "/meshnet.v1beta1.Remote/Skip",
+			allowed: 0,
+		},
+	}
+	for i, tt := range tests {
+		info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+		before := testutil.ToFloat64(rateLimited.WithLabelValues(tt.method))
+		for n := 0; n < 5; n++ {
+			_, err := r.unaryInterceptor(context.Background(), nil, info, handler)
+			if n < tt.allowed && err != nil {
+				t.Errorf("#%d test failed: request %d was rejected: %v", i, n, err)
+			}
+			if n >= tt.allowed && !isRateLimited(err) {
+				t.Errorf("#%d test failed: request %d was not rate limited: %v", i, n, err)
+			}
+		}
+		if got := testutil.ToFloat64(rateLimited.WithLabelValues(tt.method)) - before; got != float64(5-tt.allowed) {
+			t.Errorf("#%d test failed: %v rejections counted, want %d", i, got, 5-tt.allowed)
+		}
+	}
+}
+
+func TestRateLimitConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "ratelimit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("methods:\n  SetAlive:\n    rate: 1\n    burst: 3\n")
+	f.Close()
+	if _, err := newRateLimiter(RateLimit{}, f.Name(), nil); err == nil {
+		t.Error("newRateLimiter() accepted a method without its service")
+	}
+}
+
+// isRateLimited returns whether err is a rejection by the rate limiter
+func isRateLimited(err error) bool {
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted || len(st.Details()) != 1 {
+		return false
+	}
+	detail, ok := st.Details()[0].(*mpb.WireError)
+	return ok && detail.Cause == mpb.WireError_RATE_LIMITED
+}
@@ -0,0 +1,351 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	reconcileTimeout = 30 * time.Second
+	// peer pod name used by macvlan links
+	localhost = "localhost"
+
+	reasonPlacementViolated = "PlacementViolated"
+)
+
+// TopologyReconciler watches pod deletion events and cleans up the topology
+// status of deleted pods, in case the CNI DEL call never made it to the plugin.
+// It also checks that scheduled pods respect the placement of their topology.
+type TopologyReconciler struct {
+	m         *Meshnet
+	factory   informers.SharedInformerFactory
+	podLister listerv1.PodLister
+}
+
+// NewTopologyReconciler builds a reconciler for pods scheduled on this node.
+// When NODE_NAME is not set, pods from all nodes are watched.
+func NewTopologyReconciler(m *Meshnet) *TopologyReconciler {
+	var opts []informers.SharedInformerOption
+	if nodeName := os.Getenv("NODE_NAME"); nodeName != "" {
+		opts = append(opts, informers.WithTweakListOptions(func(lo *metav1.ListOptions) {
+			lo.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+		}))
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(m.kClient, 0, opts...)
+	podInformer := factory.Core().V1().Pods()
+
+	r := &TopologyReconciler{
+		m:         m,
+		factory:   factory,
+		podLister: podInformer.Lister(),
+	}
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.onAdd,
+		UpdateFunc: r.onUpdate,
+		DeleteFunc: r.onDelete,
+	})
+	return r
+}
+
+// Run starts the pod informer and blocks until stopCh is closed.
+func (r *TopologyReconciler) Run(stopCh <-chan struct{}) {
+	log.Info("Starting topology reconciler")
+	r.factory.Start(stopCh)
+	synced := true
+	for informer, ok := range r.factory.WaitForCacheSync(stopCh) {
+		if !ok {
+			log.Errorf("Failed to sync informer cache for %v", informer)
+			synced = false
+		}
+	}
+	r.m.setReady(synced)
+	if r.m.config.TopologySource != "" {
+		go r.watchTopologies(stopCh)
+	}
+	<-stopCh
+	r.m.setReady(false)
+	log.Info("Topology reconciler has stopped")
+}
+
+func (r *TopologyReconciler) onAdd(obj interface{}) {
+	if pod, ok := obj.(*corev1.Pod); ok && pod.Spec.NodeName != "" {
+		r.checkPlacement(pod)
+	}
+}
+
+func (r *TopologyReconciler) onUpdate(oldObj, newObj interface{}) {
+	old, ok := oldObj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	// Only pods that have just been scheduled are checked
+	if pod, ok := newObj.(*corev1.Pod); ok && old.Spec.NodeName == "" && pod.Spec.NodeName != "" {
+		r.checkPlacement(pod)
+	}
+}
+
+// checkPlacement records a warning event if pod has been scheduled on the same node as pods
+// it has anti-affinity with, e.g. because the meshnet webhook isn't deployed
+func (r *TopologyReconciler) checkPlacement(pod *corev1.Pod) {
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	topologies, err := r.m.tClient.Topology(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Debugf("Failed to list topologies of namespace %s: %v", pod.Namespace, err)
+		return
+	}
+	peers := topologyv1.AntiAffinePods(topologies.Items, pod.Name)
+	if colocated := r.colocated(pod, peers); len(colocated) > 0 {
+		log.Warnf("Pod %s/%s runs on node %s along with %v", pod.Namespace, pod.Name, pod.Spec.NodeName, colocated)
+		topologyEvent(r.m.dlq.recorder, pod.Namespace, pod.Name, corev1.EventTypeWarning, reasonPlacementViolated,
+			"Pod runs on node %s along with pods %v it has anti-affinity with", pod.Spec.NodeName, colocated)
+	}
+}
+
+// colocated returns the peers that run on the same node as pod
+func (r *TopologyReconciler) colocated(pod *corev1.Pod, peers []string) []string {
+	var result []string
+	for _, name := range peers {
+		peer, err := r.podLister.Pods(pod.Namespace).Get(name)
+		if err == nil && peer.Spec.NodeName == pod.Spec.NodeName {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func (r *TopologyReconciler) onDelete(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		// The informer may have missed the delete event during a re-list
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Warnf("Unrecognised object in pod delete event: %T", obj)
+			return
+		}
+		if pod, ok = tombstone.Obj.(*corev1.Pod); !ok {
+			log.Warnf("Unrecognised object in pod tombstone: %T", tombstone.Obj)
+			return
+		}
+	}
+
+	// A pod with the same name may have already been re-created, e.g. by a StatefulSet
+	if current, err := r.podLister.Pods(pod.Namespace).Get(pod.Name); err == nil && current.UID != pod.UID {
+		log.Infof("Pod %s/%s has been re-created, skipping clean-up", pod.Namespace, pod.Name)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	if err := r.cleanup(ctx, pod.Name, pod.Namespace); err != nil {
+		log.WithFields(log.Fields{
+			"err":      err,
+			"function": "onDelete",
+		}).Errorf("Failed to clean up topology of pod %s/%s", pod.Namespace, pod.Name)
+	}
+}
+
+// cleanup mirrors what the CNI plugin does on DEL: it tears down the wires of the pod, the far
+// end of those to pods of other nodes through their daemon, marks the pod as dead and its links
+// as inactive, and sets the reverse-skip flag on each of its peers.
+func (r *TopologyReconciler) cleanup(ctx context.Context, name, ns string) error {
+	localPod, err := r.m.Get(ctx, &mpb.PodQuery{Name: name, KubeNs: ns})
+	if err != nil || localPod == nil {
+		log.Infof("Pod %s/%s is not a topology pod, nothing to clean up", ns, name)
+		return nil
+	}
+
+	// The CNI DEL call has already done the work
+	if localPod.SrcIp == "" && localPod.NetNs == "" {
+		return nil
+	}
+	// The pod has migrated and is already running on another node
+	if hostIP := os.Getenv("HOST_IP"); hostIP != "" && localPod.SrcIp != "" && localPod.SrcIp != hostIP {
+		log.Infof("Pod %s/%s has moved to node %s, skipping clean-up", ns, name, localPod.SrcIp)
+		return nil
+	}
+	log.Infof("Reconciling topology of deleted pod %s/%s", ns, name)
+
+	// removing the VXLAN interfaces removes their FDB and neighbor entries as well
+	uids := make([]int64, 0, len(localPod.Links))
+	for _, link := range localPod.Links {
+		if err := r.m.rollbackWire(ctx, name, localPod, link); err != nil {
+			log.Warnf("Failed to tear down wire %d of deleted pod %s/%s: %v", link.Uid, ns, name, err)
+		}
+		uids = append(uids, link.Uid)
+	}
+	r.m.wires.forget(ns, name)
+
+	localPod.SrcIp = ""
+	localPod.NetNs = ""
+	if _, err := r.m.SetAlive(ctx, localPod); err != nil {
+		return err
+	}
+	if err := r.m.markInactive(ctx, name, ns, uids...); err != nil {
+		return err
+	}
+
+	for _, link := range localPod.Links {
+		if link.PeerPod == localhost {
+			continue
+		}
+		if _, err := r.m.SkipReverse(ctx, &mpb.SkipQuery{
+			Pod:    name,
+			Peer:   link.PeerPod,
+			KubeNs: ns,
+		}); err != nil {
+			return err
+		}
+		if err := r.m.markInactive(ctx, link.PeerPod, ns, link.Uid); err != nil {
+			log.Warnf("Failed to mark link %d of pod %s/%s inactive: %v", link.Uid, ns, link.PeerPod, err)
+		}
+	}
+	return nil
+}
+
+// markInactive adds uids to the inactive_links status of pod, the links whose wires have been
+// torn down by the reconciler, until they're set up again
+func (m *Meshnet) markInactive(ctx context.Context, pod, ns string, uids ...int64) error {
+	return retryOnConflictWithContext(ctx, func() error {
+		obj, err := m.getPod(ctx, pod, ns)
+		if err != nil {
+			return err
+		}
+		if err := setInactive(obj, uids, true); err != nil {
+			return err
+		}
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
+
+// setInactive adds uids to the inactive links of the topology obj, or removes them if
+// inactive is false
+func setInactive(obj *unstructured.Unstructured, uids []int64, inactive bool) error {
+	links, _, _ := unstructured.NestedSlice(obj.Object, "status", "inactive_links")
+	changed := make(map[int64]bool)
+	for _, uid := range uids {
+		changed[uid] = true
+	}
+	var result []interface{}
+	for _, v := range links {
+		if uid, ok := v.(int64); ok && !changed[uid] {
+			result = append(result, uid)
+		}
+	}
+	if inactive {
+		for _, uid := range uids {
+			result = append(result, uid)
+		}
+	}
+	if len(result) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "status", "inactive_links")
+		return nil
+	}
+	return unstructured.SetNestedSlice(obj.Object, result, "status", "inactive_links")
+}
+
+// watchTopologies reconciles the topologies of a file-based topology source when their files
+// change, since there are no pod events without K8s: the topology of a pod of this node whose
+// file is removed is cleaned up as if the pod had been deleted, and the topologies whose file
+// is changed are compared with the wires of this node.
+func (r *TopologyReconciler) watchTopologies(stopCh <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		w, err := r.m.tClient.Topology("").Watch(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Warnf("Failed to watch the topology files: %v", err)
+		} else {
+			r.reconcileTopologies(w, stopCh)
+			w.Stop()
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// reconcileTopologies handles the events of w until it ends or stopCh is closed
+func (r *TopologyReconciler) reconcileTopologies(w watch.Interface, stopCh <-chan struct{}) {
+	// specs of the topologies, the status writes of the daemon change their files as well
+	specs := make(map[string]topologyv1.TopologySpec)
+	for {
+		var e watch.Event
+		var ok bool
+		select {
+		case <-stopCh:
+			return
+		case e, ok = <-w.ResultChan():
+			if !ok {
+				return
+			}
+		}
+		t, isTopology := e.Object.(*topologyv1.Topology)
+		if !isTopology {
+			continue
+		}
+		key := t.Namespace + "/" + t.Name
+		last, known := specs[key]
+		if e.Type == watch.Deleted {
+			delete(specs, key)
+		} else {
+			specs[key] = t.Spec
+		}
+		if t.Status.NetNs == "" || t.Status.SrcIp != os.Getenv("HOST_IP") {
+			continue
+		}
+		if e.Type == watch.Modified && known && reflect.DeepEqual(last, t.Spec) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+		switch e.Type {
+		case watch.Deleted:
+			r.cleanupRemoved(ctx, t)
+		case watch.Modified:
+			drifts, err := r.m.detectDrift(ctx, t.Namespace)
+			if err != nil {
+				log.Warnf("Failed to compare topology %s/%s with the wires of this node: %v", t.Namespace, t.Name, err)
+			}
+			for _, d := range drifts {
+				if d.Pod == t.Name {
+					log.Warnf("Topology drift: %s", d.Message)
+				}
+			}
+		}
+		cancel()
+	}
+}
+
+// cleanupRemoved does what cleanup does for a pod whose topology has been removed: its wires
+// are forgotten and its peers are reverse-skipped
+func (r *TopologyReconciler) cleanupRemoved(ctx context.Context, t *topologyv1.Topology) {
+	log.Infof("Reconciling removed topology %s/%s", t.Namespace, t.Name)
+	r.m.wires.forget(t.Namespace, t.Name)
+	for _, link := range t.Spec.Links {
+		if link.PeerPod == localhost {
+			continue
+		}
+		if _, err := r.m.SkipReverse(ctx, &mpb.SkipQuery{Pod: t.Name, Peer: link.PeerPod, KubeNs: t.Namespace}); err != nil {
+			log.Warnf("Failed to reverse-skip pod %s by removed pod %s: %v", link.PeerPod, t.Name, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package meshnet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func TestColocated(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	pod := func(name, node string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: corev1.PodSpec{NodeName: node}}
+	}
+	for _, p := range []*corev1.Pod{pod("r1", "node1"), pod("r2", "node1"), pod("r3", "node2"), pod("r4", "")} {
+		indexer.Add(p)
+	}
+	r := &TopologyReconciler{podLister: listerv1.NewPodLister(indexer)}
+
+	got := r.colocated(pod("r1", "node1"), []string{"r2", "r3", "r4", "r5"})
+	if want := []string{"r2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("colocated() = %v, want %v", got, want)
+	}
+}
+
+func TestOnDelete(t *testing.T) {
+	const nodeIP = "10.0.0.1"
+	t.Setenv("HOST_IP", nodeIP)
+	deleted := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "default", UID: "uid-1"}}
+	recreated := deleted.DeepCopy()
+	recreated.UID = "uid-2"
+
+	tests := []struct {
+		desc    string
+		srcIP   string
+		netNs   string
+		current *corev1.Pod
+		obj     interface{}
+		cleaned bool
+	}{{
+		desc:    "deleted",
+		srcIP:   nodeIP,
+		netNs:   "/run/netns/deleted",
+		obj:     deleted,
+		cleaned: true,
+	}, {
+		desc:    "tombstone",
+		srcIP:   nodeIP,
+		netNs:   "/run/netns/deleted",
+		obj:     cache.DeletedFinalStateUnknown{Key: "default/r1", Obj: deleted},
+		cleaned: true,
+	}, {
+		desc:    "re-created",
+		srcIP:   nodeIP,
+		netNs:   "/run/netns/deleted",
+		current: recreated,
+		obj:     deleted,
+	}, {
+		desc:  "migrated",
+		srcIP: "10.0.0.2",
+		netNs: "/run/netns/moved",
+		obj:   deleted,
+	}, {
+		desc: "DEL already ran",
+		obj:  deleted,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r, tClient := newReconciler(t, tt.current,
+				reconciledTopology("r1", "r2", tt.srcIP, tt.netNs),
+				reconciledTopology("r2", "r1", nodeIP, "/run/netns/r2"))
+
+			r.onDelete(tt.obj)
+
+			ctx := context.Background()
+			r1, err := tClient.Topology("default").Get(ctx, "r1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r2, err := tClient.Topology("default").Get(ctx, "r2", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.cleaned {
+				if r1.Status.SrcIp != tt.srcIP || r1.Status.NetNs != tt.netNs || len(r1.Status.InactiveLinks) != 0 {
+					t.Errorf("status of r1 = %+v, want it unchanged", r1.Status)
+				}
+				if len(r2.Status.Skipped) != 0 || len(r2.Status.InactiveLinks) != 0 {
+					t.Errorf("status of r2 = %+v, want it unchanged", r2.Status)
+				}
+				return
+			}
+			if r1.Status.SrcIp != "" || r1.Status.NetNs != "" {
+				t.Errorf("r1 has src_ip %q and net_ns %q, want it dead", r1.Status.SrcIp, r1.Status.NetNs)
+			}
+			if want := []int64{1}; !reflect.DeepEqual(r1.Status.InactiveLinks, want) {
+				t.Errorf("inactive links of r1 = %v, want %v", r1.Status.InactiveLinks, want)
+			}
+			if want := []string{"r1"}; !reflect.DeepEqual(r2.Status.Skipped, want) {
+				t.Errorf("r2 skipped %v, want %v", r2.Status.Skipped, want)
+			}
+			if want := []int64{1}; !reflect.DeepEqual(r2.Status.InactiveLinks, want) {
+				t.Errorf("inactive links of r2 = %v, want %v", r2.Status.InactiveLinks, want)
+			}
+		})
+	}
+}
+
+// newReconciler returns a reconciler of a daemon reading topologies from files, current is
+// the pod in its cache if not nil
+func newReconciler(t *testing.T, current *corev1.Pod, topologies ...*topologyv1.Topology) (*TopologyReconciler, topologyclientv1.Interface) {
+	tClient, err := topologyclientv1.NewFileClientset(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, topology := range topologies {
+		if _, err := tClient.Topology(topology.Namespace).Create(context.Background(), topology); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := NewWithClients(Config{DisableReflection: true}, fake.NewSimpleClientset(), tClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if current != nil {
+		indexer.Add(current)
+	}
+	return &TopologyReconciler{m: m, podLister: listerv1.NewPodLister(indexer)}, tClient
+}
+
+// reconciledTopology returns the topology of pod with a link of UID 1 to peer
+func reconciledTopology(pod, peer, srcIP, netNs string) *topologyv1.Topology {
+	return &topologyv1.Topology{
+		ObjectMeta: metav1.ObjectMeta{Name: pod, Namespace: "default"},
+		Spec: topologyv1.TopologySpec{
+			Links: []topologyv1.Link{{LocalIntf: "eth1", PeerIntf: "eth1", PeerPod: peer, UID: 1}},
+		},
+		Status: topologyv1.TopologyStatus{SrcIp: srcIP, NetNs: netNs},
+	}
+}
+
+func TestSetInactive(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if err := setInactive(obj, []int64{1, 2}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := setInactive(obj, []int64{2, 3}, true); err != nil {
+		t.Fatal(err)
+	}
+	got, _, _ := unstructured.NestedSlice(obj.Object, "status", "inactive_links")
+	if want := []interface{}{int64(1), int64(2), int64(3)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inactive links = %v, want %v", got, want)
+	}
+
+	if err := setInactive(obj, []int64{1, 2, 3}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := unstructured.NestedSlice(obj.Object, "status", "inactive_links"); found {
+		t.Errorf("inactive links are still set once all links are active")
+	}
+}
+
+func TestCleanupRemovesWires(t *testing.T) {
+	const nodeIP = "10.0.0.1"
+	t.Setenv("HOST_IP", nodeIP)
+	var netNs []ns.NetNS
+	for i := 0; i < 2; i++ {
+		n, err := testutils.NewNS()
+		if err != nil {
+			t.Skipf("can't create a netns: %v", err)
+		}
+		t.Cleanup(func() {
+			n.Close()
+			testutils.UnmountNS(n)
+		})
+		netNs = append(netNs, n)
+	}
+	// the sandbox of r1 is still there, its eth1 is wired to eth1 of r2
+	err := netNs[0].Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "peer1"}); err != nil {
+			return err
+		}
+		peer, err := netlink.LinkByName("peer1")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetNsFd(peer, int(netNs[1].Fd()))
+	})
+	if err == nil {
+		err = netNs[1].Do(func(ns.NetNS) error {
+			peer, err := netlink.LinkByName("peer1")
+			if err != nil {
+				return err
+			}
+			return netlink.LinkSetName(peer, "eth1")
+		})
+	}
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+	r, _ := newReconciler(t, nil,
+		reconciledTopology("r1", "r2", nodeIP, netNs[0].Path()),
+		reconciledTopology("r2", "r1", nodeIP, netNs[1].Path()))
+
+	if err := r.cleanup(context.Background(), "r1", "default"); err != nil {
+		t.Fatalf("cleanup() = %v", err)
+	}
+	for i, n := range netNs {
+		if hasIntf(n.Path(), "eth1") {
+			t.Errorf("eth1 of r%d still exists after cleanup()", i+1)
+		}
+	}
+}
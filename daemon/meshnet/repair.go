@@ -0,0 +1,243 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/networkop/meshnet-cni/daemon/pmtu"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// pingAttempts is how many echo requests the connectivity check of a wire sends to its peer
+const pingAttempts = 3
+
+// wireHealth is the result of the checks of the end of a wire
+type wireHealth struct {
+	checks []*mpb.WireCheck
+	// the interface is missing, or exists but is down
+	missing, down bool
+}
+
+func (h wireHealth) healthy() bool {
+	for _, c := range h.checks {
+		if !c.Ok && !c.Skipped {
+			return false
+		}
+	}
+	return true
+}
+
+// nextRepair returns the repair of the failed checks of a wire, if any: a missing interface
+// is re-created along with the rest of the wire, a down one is set up, and a wire whose
+// parameters or connectivity checks fail is re-created
+func nextRepair(h wireHealth) (mpb.WireRepair_Action, bool) {
+	switch {
+	case h.missing:
+		return mpb.WireRepair_RECREATE_WIRE, true
+	case h.down:
+		return mpb.WireRepair_SET_UP, true
+	case !h.healthy():
+		return mpb.WireRepair_RECREATE_WIRE, true
+	}
+	return 0, false
+}
+
+// RepairWire checks the end of a link in a pod, on the node the pod is running on, and repairs
+// the wire if one of the checks fails: a down interface is set up, and a missing one, or a wire
+// whose ends disagree or whose peer doesn't answer pings, is re-created, including the end of
+// the peer. Each repair is performed once at most, and the checks are run again afterwards.
+func (m *Meshnet) RepairWire(ctx context.Context, req *mpb.WireRepairRequest) (*mpb.RepairResult, error) {
+	nodeIP, err := m.linkNode(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.repairLink(ctx, req)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, req.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	return client.RepairLink(ctx, req)
+}
+
+// RepairLink checks and repairs the end of a link in a pod running on this node
+func (m *Meshnet) RepairLink(ctx context.Context, req *mpb.WireRepairRequest) (*mpb.RepairResult, error) {
+	return m.repairLink(ctx, req)
+}
+
+func (m *Meshnet) repairLink(ctx context.Context, req *mpb.WireRepairRequest) (*mpb.RepairResult, error) {
+	pod, link, err := m.runningLink(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	h := m.checkWire(ctx, pod, link)
+	result := &mpb.RepairResult{Checks: h.checks, Healthy: h.healthy()}
+	if result.Healthy {
+		return result, nil
+	}
+	log.Warnf("Link %d of pod %s fails its checks: %s", req.LinkUid, req.Pod, failedChecks(h.checks))
+	m.recordEvent(ctx, req.KubeNs, req.Pod, mpb.TopologyEvent_FAIL, req.LinkUid, nil, nil, "%s", failedChecks(h.checks))
+
+	performed := make(map[mpb.WireRepair_Action]bool)
+	for {
+		action, ok := nextRepair(h)
+		if !ok || performed[action] {
+			break
+		}
+		performed[action] = true
+		repair := &mpb.WireRepair{Action: action}
+		if err := m.performRepair(ctx, pod, link, action); err != nil {
+			repair.Message = err.Error()
+			result.Repairs = append(result.Repairs, repair)
+			break
+		}
+		result.Repairs = append(result.Repairs, repair)
+		h = m.checkWire(ctx, pod, link)
+	}
+	result.ChecksAfter = h.checks
+	result.Healthy = h.healthy()
+
+	if !result.Healthy {
+		topologyEvent(m.dlq.recorder, req.KubeNs, req.Pod, corev1.EventTypeWarning, reasonWireNeedsIntervention,
+			"Failed to repair link %d: %s", req.LinkUid, failedChecks(h.checks))
+		return result, nil
+	}
+	m.recordEvent(ctx, req.KubeNs, req.Pod, mpb.TopologyEvent_RECOVER, req.LinkUid, nil, nil, "repaired by RepairWire")
+	topologyEvent(m.dlq.recorder, req.KubeNs, req.Pod, corev1.EventTypeNormal, reasonWireRepaired,
+		"Link %d has been repaired with %s", req.LinkUid, repairActions(result.Repairs))
+	return result, nil
+}
+
+// checkWire checks the interface of the end of a link in a pod running on this node, that
+// both ends of the wire agree on its parameters and that the peer answers pings
+func (m *Meshnet) checkWire(ctx context.Context, pod *mpb.Pod, link *mpb.Link) wireHealth {
+	var h wireHealth
+	intf := &mpb.WireCheck{Kind: mpb.WireCheck_INTERFACE}
+	err := inNetNs(pod.NetNs, func() error {
+		l, err := netlink.LinkByName(link.LocalIntf)
+		if err != nil {
+			return err
+		}
+		h.down = l.Attrs().Flags&net.FlagUp == 0
+		return nil
+	})
+	switch err.(type) {
+	case nil:
+		intf.Ok = !h.down
+		if h.down {
+			intf.Message = fmt.Sprintf("interface %s is down", link.LocalIntf)
+		}
+	case netlink.LinkNotFoundError:
+		h.missing = true
+		intf.Message = fmt.Sprintf("interface %s is missing", link.LocalIntf)
+	default:
+		intf.Message = fmt.Sprintf("failed to read interface %s: %s", link.LocalIntf, err)
+	}
+	h.checks = append(h.checks, intf)
+
+	params := &mpb.WireCheck{Kind: mpb.WireCheck_PARAMETERS}
+	var peer *mpb.Pod
+	if link.PeerPod != localhost {
+		peer, _ = m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: pod.KubeNs})
+	}
+	switch {
+	case link.PeerPod == localhost:
+		params.Skipped, params.Message = true, "the link has no peer pod"
+	case peer == nil || peer.SrcIp == "":
+		params.Skipped, params.Message = true, fmt.Sprintf("peer pod %s is not running", link.PeerPod)
+	default:
+		v, err := m.VerifyWire(ctx, &mpb.WireVerificationRequest{Pod: pod.Name, KubeNs: pod.KubeNs, LinkUid: link.Uid})
+		if err != nil {
+			params.Message = err.Error()
+			break
+		}
+		params.Ok = len(v.Mismatches) == 0
+		var fields []string
+		for _, mm := range v.Mismatches {
+			fields = append(fields, fmt.Sprintf("%s is %q and %q on the peer", mm.Field, mm.Local, mm.Peer))
+		}
+		params.Message = strings.Join(fields, ", ")
+	}
+	h.checks = append(h.checks, params)
+
+	conn := &mpb.WireCheck{Kind: mpb.WireCheck_CONNECTIVITY}
+	key := flapKey(pod.KubeNs, pod.Name, link.Uid)
+	m.chaos.mu.Lock()
+	_, chaos := m.chaos.applied[key]
+	m.chaos.mu.Unlock()
+	_, flapping := m.flaps.Load(key)
+	peerIP, _, ipErr := net.ParseCIDR(link.PeerIp)
+	switch {
+	case ipErr != nil:
+		conn.Skipped, conn.Message = true, "the link has no peer IP address"
+	case link.PeerPod != localhost && (peer == nil || peer.NetNs == ""):
+		conn.Skipped, conn.Message = true, fmt.Sprintf("peer pod %s is not running", link.PeerPod)
+	case chaos || flapping:
+		conn.Skipped, conn.Message = true, "the link is impaired by chaos or flapping"
+	case h.missing:
+		conn.Message = "the interface is missing"
+	default:
+		answered, err := pmtu.Ping(pod.NetNs, peerIP, pingAttempts, pmtu.DefaultTimeout)
+		switch {
+		case err != nil:
+			conn.Message = err.Error()
+		case !answered:
+			conn.Message = fmt.Sprintf("%s doesn't answer pings", peerIP)
+		default:
+			conn.Ok = true
+		}
+	}
+	h.checks = append(h.checks, conn)
+	return h
+}
+
+func (m *Meshnet) performRepair(ctx context.Context, pod *mpb.Pod, link *mpb.Link, action mpb.WireRepair_Action) error {
+	log.Infof("Repairing link %d of pod %s with %s", link.Uid, pod.Name, action)
+	switch action {
+	case mpb.WireRepair_SET_UP:
+		err := inNetNs(pod.NetNs, func() error {
+			l, err := netlink.LinkByName(link.LocalIntf)
+			if err != nil {
+				return err
+			}
+			return netlink.LinkSetUp(l)
+		})
+		if err != nil {
+			return wireError(codes.Internal, &mpb.WireError{WireUid: link.Uid, PeerIp: link.PeerIp, Operation: mpb.WireError_UPDATE, Cause: mpb.WireError_NETLINK_ERROR},
+				"failed to set up %s in pod %s: %s", link.LocalIntf, pod.Name, err)
+		}
+		return nil
+	default:
+		if err := m.removeEnd(ctx, pod.Name, pod, link); err != nil {
+			return err
+		}
+		return m.createWire(ctx, pod.Name, pod.KubeNs, link)
+	}
+}
+
+func failedChecks(checks []*mpb.WireCheck) string {
+	var failed []string
+	for _, c := range checks {
+		if !c.Ok && !c.Skipped {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Kind, c.Message))
+		}
+	}
+	return strings.Join(failed, "; ")
+}
+
+func repairActions(repairs []*mpb.WireRepair) string {
+	var actions []string
+	for _, r := range repairs {
+		actions = append(actions, r.Action.String())
+	}
+	return strings.Join(actions, ", ")
+}
@@ -0,0 +1,85 @@
+package meshnet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestRepairWire(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	tests := []struct {
+		uid  int64
+		code codes.Code
+	}{
+		// r1 isn't running
+		{uid: 1, code: codes.FailedPrecondition},
+		{uid: 3, code: codes.NotFound},
+	}
+	for _, tt := range tests {
+		_, err := m.RepairWire(ctx, &mpb.WireRepairRequest{Pod: "r1", KubeNs: "default", LinkUid: tt.uid})
+		if status.Code(err) != tt.code {
+			t.Errorf("RepairWire(%d) = %v, want %s", tt.uid, err, tt.code)
+		}
+	}
+}
+
+func TestRepairWireSetUp(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	netNs := runningPod(t, m, "r1", "eth1")
+	err := netNs.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName("eth1")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetDown(link)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &mpb.WireRepairRequest{Pod: "r1", KubeNs: "default", LinkUid: 1}
+	result, err := m.RepairWire(ctx, req)
+	if err != nil {
+		t.Fatalf("RepairWire() failed: %v", err)
+	}
+	if len(result.Repairs) != 1 || result.Repairs[0].Action != mpb.WireRepair_SET_UP || result.Repairs[0].Message != "" {
+		t.Errorf("RepairWire() repairs = %v, want a successful SET_UP", result.Repairs)
+	}
+	if len(result.Checks) == 0 || result.Checks[0].Kind != mpb.WireCheck_INTERFACE || result.Checks[0].Ok {
+		t.Errorf("RepairWire() checks = %v, want a failed interface check first", result.Checks)
+	}
+	if !result.Healthy || len(result.ChecksAfter) == 0 || !result.ChecksAfter[0].Ok {
+		t.Errorf("RepairWire() = %v, want a healthy wire after the repair", result)
+	}
+	err = netNs.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName("eth1")
+		if err != nil {
+			return err
+		}
+		if link.Attrs().Flags&net.FlagUp == 0 {
+			t.Errorf("eth1 is down after its repair")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a healthy wire isn't repaired
+	result, err = m.RepairWire(ctx, req)
+	if err != nil || !result.Healthy || len(result.Repairs) != 0 {
+		t.Errorf("RepairWire() of a healthy wire = %v, %v, want no repairs", result, err)
+	}
+}
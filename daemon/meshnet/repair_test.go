@@ -0,0 +1,29 @@
+package meshnet
+
+import (
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestNextRepair(t *testing.T) {
+	failed := []*mpb.WireCheck{{Kind: mpb.WireCheck_INTERFACE, Ok: true}, {Kind: mpb.WireCheck_CONNECTIVITY}}
+	skipped := []*mpb.WireCheck{{Kind: mpb.WireCheck_INTERFACE, Ok: true}, {Kind: mpb.WireCheck_CONNECTIVITY, Skipped: true}}
+	tests := []struct {
+		desc   string
+		health wireHealth
+		want   mpb.WireRepair_Action
+		repair bool
+	}{
+		{desc: "healthy", health: wireHealth{checks: skipped}},
+		{desc: "missing", health: wireHealth{checks: failed, missing: true}, want: mpb.WireRepair_RECREATE_WIRE, repair: true},
+		{desc: "down", health: wireHealth{checks: failed, down: true}, want: mpb.WireRepair_SET_UP, repair: true},
+		{desc: "unreachable", health: wireHealth{checks: failed}, want: mpb.WireRepair_RECREATE_WIRE, repair: true},
+	}
+	for _, tt := range tests {
+		got, repair := nextRepair(tt.health)
+		if got != tt.want || repair != tt.repair {
+			t.Errorf("%s: nextRepair() = %s, %t, want %s, %t", tt.desc, got, repair, tt.want, tt.repair)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	recommendedCPUAnnotation    = "meshnet.io/recommended-cpu"
+	recommendedMemoryAnnotation = "meshnet.io/recommended-memory"
+	resourceReportInterval      = 30 * time.Second
+
+	baseCPUMillicores    = 100
+	perWireCPUMillicores = 2
+	baseMemoryBytes      = 64 << 20
+	perWireMemoryBytes   = 512 << 10
+)
+
+// recommend returns the resources the daemon needs to serve wires active wires
+func recommend(wires int64) *mpb.ResourceRecommendation {
+	return &mpb.ResourceRecommendation{
+		ActiveWires:   wires,
+		CpuMillicores: baseCPUMillicores + wires*perWireCPUMillicores,
+		MemoryBytes:   baseMemoryBytes + wires*perWireMemoryBytes,
+	}
+}
+
+// GetResourceRecommendation returns the resources the daemon needs for the wires active on this node.
+func (m *Meshnet) GetResourceRecommendation(ctx context.Context, _ *mpb.Empty) (*mpb.ResourceRecommendation, error) {
+	return recommend(m.activeWires(ctx)), nil
+}
+
+// ReportResources writes the resource recommendation as annotations of the daemon's own pod,
+// for a VPA or an operator to pick up, until stopCh is closed.
+func (m *Meshnet) ReportResources(stopCh <-chan struct{}) {
+	if !m.config.ResourceAutoscale {
+		<-stopCh
+		return
+	}
+	name, ns := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if name == "" || ns == "" {
+		log.Warnf("POD_NAME and POD_NAMESPACE must be set to report resource recommendations")
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(resourceReportInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.reportResources(context.Background(), name, ns); err != nil {
+			log.Warnf("Failed to report resource recommendation: %s", err)
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Meshnet) reportResources(ctx context.Context, name, ns string) error {
+	r := recommend(m.activeWires(ctx))
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				recommendedCPUAnnotation:    fmt.Sprintf("%dm", r.CpuMillicores),
+				recommendedMemoryAnnotation: fmt.Sprint(r.MemoryBytes),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = m.kClient.CoreV1().Pods(ns).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
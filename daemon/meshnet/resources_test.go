@@ -0,0 +1,24 @@
+package meshnet
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		wires int64
+		want  *mpb.ResourceRecommendation
+	}{
+		{0, &mpb.ResourceRecommendation{CpuMillicores: 100, MemoryBytes: 64 << 20}},
+		{100, &mpb.ResourceRecommendation{ActiveWires: 100, CpuMillicores: 300, MemoryBytes: 114 << 20}},
+	}
+	for _, tt := range tests {
+		if got := recommend(tt.wires); !proto.Equal(got, tt.want) {
+			t.Errorf("recommend(%d) = %v, want %v", tt.wires, got, tt.want)
+		}
+	}
+}
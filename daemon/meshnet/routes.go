@@ -0,0 +1,85 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/routes"
+)
+
+// InjectRoutes adds routes to a pod for the link subnets of the topologies of its namespace
+// it isn't connected to, through the daemon of the node the pod is running on
+func (m *Meshnet) InjectRoutes(ctx context.Context, req *mpb.RouteInjectionRequest) (*mpb.BoolResponse, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: req.Pod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	if pod.SrcIp == "" || pod.NetNs == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "pod %s is not running", req.Pod)
+	}
+	if pod.SrcIp == os.Getenv("HOST_IP") {
+		return m.InjectPodRoutes(ctx, req)
+	}
+	client, err := m.remoteClient(ctx, pod.SrcIp, 0)
+	if err != nil {
+		return nil, err
+	}
+	return client.InjectPodRoutes(ctx, req)
+}
+
+// InjectPodRoutes adds the routes of a pod running on this node. Each route goes to the peer
+// end of the first link of the shortest path to its subnet, weighted by link UID. Routes
+// through wires that aren't up yet are skipped.
+func (m *Meshnet) InjectPodRoutes(ctx context.Context, req *mpb.RouteInjectionRequest) (*mpb.BoolResponse, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: req.Pod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	if pod.NetNs == "" || pod.SrcIp != os.Getenv("HOST_IP") {
+		return nil, status.Errorf(codes.FailedPrecondition, "pod %s is not running on this node", req.Pod)
+	}
+	pods, err := m.namespacePods(ctx, req.KubeNs)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := routes.Compute(req.Pod, pods)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to compute the routes of pod %s: %s", req.Pod, err)
+	}
+	n, err := routes.Apply(pod.NetNs, rs)
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{Cause: mpb.WireError_NETLINK_ERROR}, "failed to inject the routes of pod %s: %s", req.Pod, err)
+	}
+	log.Infof("Injected %d of %d routes into pod %s", n, len(rs), req.Pod)
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// autoInjectRoutes injects the routes of a pod of this node once all its wires are up
+func (m *Meshnet) autoInjectRoutes(ns, pod string) {
+	if _, err := m.InjectPodRoutes(context.Background(), &mpb.RouteInjectionRequest{Pod: pod, KubeNs: ns}); err != nil {
+		log.Warnf("Failed to inject the routes of pod %s: %s", pod, err)
+	}
+}
+
+// namespacePods returns the pods of the topologies of a namespace, with their links resolved
+func (m *Meshnet) namespacePods(ctx context.Context, ns string) ([]*mpb.Pod, error) {
+	topologies, err := m.tClient.Topology(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list the topologies of namespace %s", ns)
+	}
+	var pods []*mpb.Pod
+	for _, t := range topologies.Items {
+		pod, err := m.Get(ctx, &mpb.PodQuery{Name: t.Name, KubeNs: ns})
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
@@ -0,0 +1,88 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestInjectRoutes(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+
+	_, err := m.InjectRoutes(ctx, &mpb.RouteInjectionRequest{Pod: "r1", KubeNs: "default"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("InjectRoutes() of a pod that isn't running = %v, want FailedPrecondition", err)
+	}
+	_, err = m.InjectRoutes(ctx, &mpb.RouteInjectionRequest{Pod: "r9", KubeNs: "default"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("InjectRoutes() of an unknown pod = %v, want NotFound", err)
+	}
+}
+
+func TestInjectRoutesNetns(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	// addressed sets the local and peer IPs of the links of topology, in order
+	addressed := func(topology unstructured.Unstructured, ips ...[2]string) unstructured.Unstructured {
+		links, _, _ := unstructured.NestedSlice(topology.Object, "spec", "links")
+		for i, ip := range ips {
+			links[i].(map[string]interface{})["local_ip"] = ip[0]
+			links[i].(map[string]interface{})["peer_ip"] = ip[1]
+		}
+		if err := unstructured.SetNestedSlice(topology.Object, links, "spec", "links"); err != nil {
+			t.Fatal(err)
+		}
+		return topology
+	}
+	// r1 - r2 - r3
+	m := meshnettest.NewFakeMeshnet([]unstructured.Unstructured{
+		addressed(meshnettest.Topology("default", "r1", []string{"r2"}, []int64{1}), [2]string{"10.0.1.1/30", "10.0.1.2/30"}),
+		addressed(meshnettest.Topology("default", "r2", []string{"r1", "r3"}, []int64{1, 2}),
+			[2]string{"10.0.1.2/30", "10.0.1.1/30"}, [2]string{"10.0.2.1/30", "10.0.2.2/30"}),
+		addressed(meshnettest.Topology("default", "r3", []string{"r2"}, []int64{2}), [2]string{"10.0.2.2/30", "10.0.2.1/30"}),
+	})
+	netNs := runningPod(t, m, "r1", "eth1")
+	err := netNs.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName("eth1")
+		if err != nil {
+			return err
+		}
+		addr, _ := netlink.ParseAddr("10.0.1.1/30")
+		return netlink.AddrAdd(link, addr)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.InjectRoutes(ctx, &mpb.RouteInjectionRequest{Pod: "r1", KubeNs: "default"}); err != nil {
+		t.Fatalf("InjectRoutes() failed: %v", err)
+	}
+	var gw string
+	err = netNs.Do(func(ns.NetNS) error {
+		routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+		if err != nil {
+			return err
+		}
+		for _, r := range routes {
+			if r.Dst != nil && r.Dst.String() == "10.0.2.0/30" && r.Gw != nil {
+				gw = r.Gw.String()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gw != "10.0.1.2" {
+		t.Errorf("the route of r1 to 10.0.2.0/30 goes through %q, want 10.0.1.2", gw)
+	}
+}
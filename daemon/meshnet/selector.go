@@ -0,0 +1,113 @@
+package meshnet
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// selectedPod is the K8s pod a topology with a pod selector resolved to
+type selectedPod struct {
+	uid      types.UID
+	topology string
+}
+
+// selectorCache keeps the topologies that the K8s pods have been resolved to by pod
+// selector, for as long as the pods exist. A topology selects a single pod, so that there
+// is at most one entry per topology.
+type selectorCache struct {
+	mu   sync.Mutex
+	pods map[string]selectedPod
+}
+
+func newSelectorCache() *selectorCache {
+	return &selectorCache{pods: make(map[string]selectedPod)}
+}
+
+// get returns the topology the pod with uid has been resolved to
+func (c *selectorCache) get(ns, pod string, uid types.UID) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.pods[ns+"/"+pod]
+	if !ok || s.uid != uid {
+		return "", false
+	}
+	return s.topology, true
+}
+
+// set records the pod topology has been resolved to, replacing the pod it selected before
+func (c *selectorCache) set(ns, pod string, uid types.UID, topology string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, s := range c.pods {
+		if s.topology == topology && strings.HasPrefix(key, ns+"/") {
+			delete(c.pods, key)
+		}
+	}
+	c.pods[ns+"/"+pod] = selectedPod{uid: uid, topology: topology}
+}
+
+// selectedTopology returns the name of the topology whose pod selector matches the K8s
+// pod, and false if the pod doesn't exist or no topology selects it. It fails if more
+// than one topology selects the pod, or if the selector matches other pods too.
+func (m *Meshnet) selectedTopology(ctx context.Context, ns, pod string) (string, bool, error) {
+	p, err := m.kClient.CoreV1().Pods(ns).Get(ctx, pod, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if topology, ok := m.selectors.get(ns, pod, p.UID); ok {
+		return topology, true, nil
+	}
+
+	topologies, err := m.tClient.Topology(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", false, err
+	}
+	var matches []string
+	var selector labels.Selector
+	for _, t := range topologies.Items {
+		if t.Spec.PodSelector == nil {
+			continue
+		}
+		s, err := metav1.LabelSelectorAsSelector(t.Spec.PodSelector)
+		if err != nil {
+			log.Warnf("Ignoring the invalid pod selector of topology %s: %s", t.Name, err)
+			continue
+		}
+		if !s.Empty() && s.Matches(labels.Set(p.Labels)) {
+			matches = append(matches, t.Name)
+			selector = s
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+	default:
+		sort.Strings(matches)
+		return "", false, status.Errorf(codes.FailedPrecondition, "pod %s is selected by topologies %v", pod, matches)
+	}
+
+	pods, err := m.kClient.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", false, err
+	}
+	if n := len(pods.Items); n > 1 {
+		return "", false, status.Errorf(codes.FailedPrecondition, "pod selector of topology %s matches %d pods", matches[0], n)
+	}
+	log.Infof("Pod %s is selected by topology %s", pod, matches[0])
+	m.selectors.set(ns, pod, p.UID, matches[0])
+	return matches[0], true, nil
+}
@@ -0,0 +1,62 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestPodSelector(t *testing.T) {
+	ctx := context.Background()
+	r1 := meshnettest.Topology("default", "r1", []string{"r2", localhost}, []int64{1, 2})
+	selector := map[string]interface{}{"matchLabels": map[string]interface{}{"app": "r1"}}
+	if err := unstructured.SetNestedMap(r1.Object, selector, "spec", "pod_selector"); err != nil {
+		t.Fatal(err)
+	}
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "default", UID: types.UID(name), Labels: map[string]string{"app": "r1"},
+		}}
+	}
+	kClient := fake.NewSimpleClientset(pod("r1-5d4f"))
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true}, kClient,
+		meshnettest.NewTopologies(r1, meshnettest.Topology("default", "r2", []string{"r1"}, []int64{1})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1-5d4f", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("Get() of a selected pod failed: %v", err)
+	}
+	if p.Name != "r1" || len(p.Links) != 2 {
+		t.Errorf("Get() = %s with %d links, want r1 with 2 links", p.Name, len(p.Links))
+	}
+	if resp, err := m.Skip(ctx, &mpb.SkipQuery{Pod: "r1-5d4f", Peer: "r2", KubeNs: "default"}); err != nil || !resp.Response {
+		t.Fatalf("Skip() of a selected pod = %v, %v", resp, err)
+	}
+	if resp, err := m.IsSkipped(ctx, &mpb.SkipQuery{Pod: "r2", Peer: "r1", KubeNs: "default"}); err != nil || !resp.Response {
+		t.Errorf("IsSkipped() = %v, %v, want true", resp, err)
+	}
+	if _, err := m.Get(ctx, &mpb.PodQuery{Name: "r3", KubeNs: "default"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Get() of an unknown pod = %v, want %s", err, codes.NotFound)
+	}
+
+	if _, err := kClient.CoreV1().Pods("default").Create(ctx, pod("r1-8c2a"), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Get(ctx, &mpb.PodQuery{Name: "r1-8c2a", KubeNs: "default"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Get() of a pod matched by an ambiguous selector = %v, want %s", err, codes.FailedPrecondition)
+	}
+}
@@ -0,0 +1,150 @@
+package meshnet
+
+import (
+	"context"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	glogrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// ServerOption configures the gRPC server built by NewMeshnetServer
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	grpcOpts []grpc.ServerOption
+	unary    []grpc.UnaryServerInterceptor
+	stream   []grpc.StreamServerInterceptor
+}
+
+// WithUnaryInterceptor adds i to the unary RPCs, after the interceptors added before it
+func WithUnaryInterceptor(i grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.unary = append(o.unary, i)
+	}
+}
+
+// WithStreamInterceptor adds i to the streaming RPCs, after the interceptors added before it
+func WithStreamInterceptor(i grpc.StreamServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.stream = append(o.stream, i)
+	}
+}
+
+// WithGRPCOptions passes opts to grpc.NewServer. They must not set interceptors, which are
+// only added with WithUnaryInterceptor and WithStreamInterceptor.
+func WithGRPCOptions(opts ...grpc.ServerOption) ServerOption {
+	return func(o *serverOptions) {
+		o.grpcOpts = append(o.grpcOpts, opts...)
+	}
+}
+
+// NewMeshnetServer returns a gRPC server whose RPCs go through the tagging and logging
+// interceptors of the daemon, then through the interceptors of opts in their order. An
+// interceptor that panics is skipped: the panic is logged and the RPC goes on to the next
+// interceptor, as if the one that panicked had passed it on.
+func NewMeshnetServer(opts ...ServerOption) *grpc.Server {
+	lEntry := log.NewEntry(log.StandardLogger())
+	lOpts := []glogrus.Option{}
+	glogrus.ReplaceGrpcLogger(lEntry)
+	o := &serverOptions{
+		unary: []grpc.UnaryServerInterceptor{
+			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			glogrus.UnaryServerInterceptor(lEntry, lOpts...),
+		},
+		stream: []grpc.StreamServerInterceptor{
+			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			glogrus.StreamServerInterceptor(lEntry, lOpts...),
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	grpcOpts := append(o.grpcOpts,
+		grpc.UnaryInterceptor(o.unaryChain()),
+		grpc.StreamInterceptor(o.streamChain()))
+	return grpc.NewServer(grpcOpts...)
+}
+
+// unaryChain composes the unary interceptors, each isolated from the panics of the others
+func (o *serverOptions) unaryChain() grpc.UnaryServerInterceptor {
+	chain := make([]grpc.UnaryServerInterceptor, len(o.unary))
+	for i, interceptor := range o.unary {
+		chain[i] = isolateUnary(interceptor)
+	}
+	return grpc_middleware.ChainUnaryServer(chain...)
+}
+
+// streamChain composes the stream interceptors, each isolated from the panics of the others
+func (o *serverOptions) streamChain() grpc.StreamServerInterceptor {
+	chain := make([]grpc.StreamServerInterceptor, len(o.stream))
+	for i, interceptor := range o.stream {
+		chain[i] = isolateStream(interceptor)
+	}
+	return grpc_middleware.ChainStreamServer(chain...)
+}
+
+// isolateUnary recovers the panics of interceptor. If it panics before calling the rest of the
+// chain, the rest of the chain is called instead; if it panics after, the rest's result is
+// returned. Panics of the rest of the chain itself are left to the interceptors before it.
+func isolateUnary(interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		called, returned := false, false
+		var nextResp interface{}
+		var nextErr error
+		next := func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			nextResp, nextErr = handler(ctx, req)
+			returned = true
+			return nextResp, nextErr
+		}
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if called && !returned {
+				panic(r)
+			}
+			log.Errorf("Interceptor of %s panicked, skipping it: %v", info.FullMethod, r)
+			if called {
+				resp, err = nextResp, nextErr
+				return
+			}
+			resp, err = handler(ctx, req)
+		}()
+		return interceptor(ctx, req, info, next)
+	}
+}
+
+// isolateStream is isolateUnary for stream interceptors
+func isolateStream(interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		called, returned := false, false
+		var nextErr error
+		next := func(srv interface{}, ss grpc.ServerStream) error {
+			called = true
+			nextErr = handler(srv, ss)
+			returned = true
+			return nextErr
+		}
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if called && !returned {
+				panic(r)
+			}
+			log.Errorf("Interceptor of %s panicked, skipping it: %v", info.FullMethod, r)
+			if called {
+				err = nextErr
+				return
+			}
+			err = handler(srv, ss)
+		}()
+		return interceptor(srv, ss, info, next)
+	}
+}
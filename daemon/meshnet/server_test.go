@@ -0,0 +1,105 @@
+package meshnet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// recordUnary returns an interceptor appending name to calls, panicking before or after
+// passing the RPC on if panics is "before" or "after"
+func recordUnary(calls *[]string, name, panics string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		*calls = append(*calls, name)
+		if panics == "before" {
+			panic(name)
+		}
+		resp, err := handler(ctx, req)
+		if panics == "after" {
+			panic(name)
+		}
+		return resp, err
+	}
+}
+
+func TestUnaryChain(t *testing.T) {
+	tests := []struct {
+		panics []string
+		want   []string
+	}{
+		{
+			panics: []string{"", "", ""},
+			want:   []string{"a", "b", "c", "handler"},
+		},
+		{
+			panics: []string{"", "before", ""},
+			want:   []string{"a", "b", "c", "handler"},
+		},
+		{
+			panics: []string{"before", "after", "before"},
+			want:   []string{"a", "b", "c", "handler"},
+		},
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/meshnet.v1beta1.Local/Get"}
+	for i, tt := range tests {
+		var calls []string
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls = append(calls, "handler")
+			return true, nil
+		}
+		o := &serverOptions{}
+		for j, name := range []string{"a", "b", "c"} {
+			WithUnaryInterceptor(recordUnary(&calls, name, tt.panics[j]))(o)
+		}
+		resp, err := o.unaryChain()(context.Background(), nil, info, handler)
+		if err != nil || resp != true {
+			t.Errorf("#%d test failed: chain returned %v, %v", i, resp, err)
+		}
+		if !reflect.DeepEqual(calls, tt.want) {
+			t.Errorf("#%d test failed: calls = %v, want %v", i, calls, tt.want)
+		}
+	}
+}
+
+func TestUnaryChainHandlerPanic(t *testing.T) {
+	o := &serverOptions{}
+	WithUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(ctx, req)
+	})(o)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("handler")
+	}
+	defer func() {
+		if r := recover(); r != "handler" {
+			t.Errorf("panic of the handler = %v, want it passed on", r)
+		}
+	}()
+	o.unaryChain()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+}
+
+func TestStreamChain(t *testing.T) {
+	var calls []string
+	o := &serverOptions{}
+	for _, name := range []string{"a", "b"} {
+		name := name
+		WithStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name)
+			if name == "a" {
+				panic(name)
+			}
+			return handler(srv, ss)
+		})(o)
+	}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+	if err := o.streamChain()(nil, nil, &grpc.StreamServerInfo{}, handler); err != nil {
+		t.Errorf("chain returned %v", err)
+	}
+	if want := []string{"a", "b", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
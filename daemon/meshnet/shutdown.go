@@ -0,0 +1,116 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// NotifyShutdown marks the wires to the node of a daemon that is shutting down as down,
+// without waiting for its heartbeats to time out. They are re-validated once it answers
+// a heartbeat again.
+func (m *Meshnet) NotifyShutdown(ctx context.Context, n *mpb.ShutdownNotice) (*mpb.BoolResponse, error) {
+	log.Infof("The daemon of node %s is shutting down", n.NodeIp)
+	if !m.peers.setDown(n.NodeIp, true, time.Now()) {
+		return &mpb.BoolResponse{Response: true}, nil
+	}
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, k8sError(err, mpb.WireError_NONE, "failed to list topologies")
+	}
+	wires := wiresByPeer(topologies.Items, os.Getenv("HOST_IP"))[n.NodeIp]
+	log.Warnf("The daemon of node %s is shutting down, %d wires are down", n.NodeIp, len(wires))
+	for _, w := range wires {
+		topologyEvent(m.dlq.recorder, w.ns, w.pod, corev1.EventTypeWarning, reasonPeerUnreachable,
+			"Link %d is down, the daemon of node %s is shutting down", w.uid, n.NodeIp)
+		m.recordEvent(ctx, w.ns, w.pod, mpb.TopologyEvent_FAIL, w.uid, nil, nil, "the daemon of node %s is shutting down", n.NodeIp)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// Shutdown stops the daemon within timeout. It stops accepting RPCs, waits for the ones
+// in progress and for the background tasks, which must have been signaled to stop, and
+// tells the daemons of the nodes the wires of this node lead to that it's going away.
+// The RPCs still running when timeout expires are cancelled.
+func (m *Meshnet) Shutdown(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	m.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		m.s.GracefulStop()
+		close(stopped)
+	}()
+	if !m.waitTasks(ctx) {
+		log.Warnf("Background tasks haven't stopped within %s", timeout)
+	}
+	m.notifyPeers(ctx)
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Warnf("Cancelling the RPCs still running after %s", timeout)
+		m.s.Stop()
+	}
+	m.conns.closeAll()
+	log.Infof("Meshnet daemon has stopped")
+}
+
+// waitTasks waits for the background tasks to return, and returns false if ctx is done first
+func (m *Meshnet) waitTasks(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		m.tasks.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// notifyPeers sends a shutdown notice to the daemon of every node the wires of this node lead
+// to, and returns the nodes whose daemons have been notified
+func (m *Meshnet) notifyPeers(ctx context.Context) []string {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed to list topologies: %s", err)
+		return nil
+	}
+	hostIP := os.Getenv("HOST_IP")
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		notified []string
+	)
+	for node := range wiresByPeer(topologies.Items, hostIP) {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			client, err := m.remoteClient(ctx, node, 0)
+			if err == nil {
+				_, err = client.NotifyShutdown(ctx, &mpb.ShutdownNotice{NodeIp: hostIP})
+			}
+			if err != nil {
+				log.Warnf("Failed to notify the daemon of node %s of the shutdown: %s", node, err)
+				return
+			}
+			mu.Lock()
+			notified = append(notified, node)
+			mu.Unlock()
+		}(node)
+	}
+	wg.Wait()
+	sort.Strings(notified)
+	return notified
+}
@@ -0,0 +1,38 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestNotifyShutdown(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	for pod, node := range map[string]string{"r1": "10.0.0.1", "r2": "10.0.0.2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.SrcIp, p.NetNs = node, "/var/run/netns/"+pod
+		if _, err := m.SetAlive(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp, err := m.NotifyShutdown(ctx, &mpb.ShutdownNotice{NodeIp: "10.0.0.2"}); err != nil || !resp.Response {
+			t.Fatalf("NotifyShutdown() = %v, %v", resp, err)
+		}
+	}
+	health, err := m.HealthCheck(ctx, &mpb.HealthRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(health.UnreachablePeers) != 1 {
+		t.Errorf("unreachable peers = %v, want 10.0.0.2", health.UnreachablePeers)
+	}
+}
@@ -0,0 +1,33 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitTasks(t *testing.T) {
+	m := &Meshnet{tasks: tasks{crashed: make(map[string]string)}}
+	stopCh := make(chan struct{})
+	release := make(chan struct{})
+	m.Go("quick", stopCh, func(stopCh <-chan struct{}) { <-stopCh })
+	m.Go("slow", stopCh, func(stopCh <-chan struct{}) {
+		<-stopCh
+		<-release
+	})
+	close(stopCh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if m.waitTasks(ctx) {
+		t.Errorf("waitTasks() returned true with a task still running")
+	}
+
+	close(release)
+	if !m.waitTasks(context.Background()) {
+		t.Errorf("waitTasks() returned false once all the tasks have stopped")
+	}
+	if crashed := m.crashedTasks(); len(crashed) != 0 {
+		t.Errorf("crashed tasks = %v, want none", crashed)
+	}
+}
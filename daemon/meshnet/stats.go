@@ -0,0 +1,171 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// GetLinkStats returns the interface counters of a link of a pod running on this node.
+func (m *Meshnet) GetLinkStats(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.LinkStats, error) {
+	pod, link, err := m.runningLink(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &mpb.LinkStats{Pod: q.Pod, Intf: link.LocalIntf, NodeIp: pod.SrcIp}
+	s, err := readLinkStats(pod.NetNs, link.LocalIntf)
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{WireUid: q.LinkUid, PeerIp: link.PeerIp, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to read stats of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+	}
+	stats.RxPackets, stats.TxPackets = s.RxPackets, s.TxPackets
+	stats.RxBytes, stats.TxBytes = s.RxBytes, s.TxBytes
+	stats.RxErrors, stats.TxErrors = s.RxErrors, s.TxErrors
+	stats.RxDropped, stats.TxDropped = s.RxDropped, s.TxDropped
+	if pod.OvsBridge != "" && link.PeerPod != localhost {
+		if stats.Ofport, err = ofPort(pod.NetNs, link.LocalIntf); err != nil {
+			log.Warnf("Failed to read the OpenFlow port of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+		}
+	}
+	if rx, err := impairment.RxQueue(pod.NetNs, link.LocalIntf); err != nil {
+		log.Warnf("Failed to read the RX queue of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+	} else {
+		stats.RxQueueDepth, stats.BackpressureEventsTotal = uint64(rx.Depth), uint64(rx.Drops)
+	}
+	if link.TrafficClass != nil {
+		if stats.DscpTxBytes, err = impairment.DSCPBytes(pod.NetNs, link.LocalIntf); err != nil {
+			log.Warnf("Failed to read the DSCP counters of %s in pod %s: %s", link.LocalIntf, q.Pod, err)
+		}
+	}
+	if count, last, ok := m.flapStats(q.KubeNs, q.Pod, q.LinkUid); ok {
+		stats.FlapCount = count
+		if !last.IsZero() {
+			stats.LastFlap = last.UTC().Format(time.RFC3339)
+		}
+	}
+	return stats, nil
+}
+
+// GetAggregatedLinkStats returns the counters of both ends of a link, asking the daemons of
+// the nodes the two pods are running on. If only one end can be read, the result is partial
+// and the reason is in the warning.
+func (m *Meshnet) GetAggregatedLinkStats(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.AggregatedLinkStats, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: q.Pod, KubeNs: q.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	link := linkByUID(pod.Links, q.LinkUid)
+	if link == nil {
+		return nil, wireError(codes.NotFound, &mpb.WireError{WireUid: q.LinkUid}, "pod %s has no link %d", q.Pod, q.LinkUid)
+	}
+
+	local, localErr := m.linkStatsFrom(ctx, pod.SrcIp, q)
+	var remote *mpb.LinkStats
+	var remoteErr error
+	if link.PeerPod == localhost {
+		remoteErr = fmt.Errorf("link %d is a macvlan link with no remote end", q.LinkUid)
+	} else {
+		peer, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: q.KubeNs})
+		if err != nil {
+			remoteErr = err
+		} else {
+			remote, remoteErr = m.linkStatsFrom(ctx, peer.SrcIp, &mpb.LinkStatsQuery{
+				Pod:     link.PeerPod,
+				KubeNs:  q.KubeNs,
+				LinkUid: q.LinkUid,
+			})
+		}
+	}
+	return aggregateStats(local, remote, localErr, remoteErr)
+}
+
+// linkStatsFrom reads the stats locally if nodeIP is this node, or from the daemon on nodeIP
+func (m *Meshnet) linkStatsFrom(ctx context.Context, nodeIP string, q *mpb.LinkStatsQuery) (*mpb.LinkStats, error) {
+	if nodeIP == "" {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: q.LinkUid}, "pod %s is not running", q.Pod)
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.GetLinkStats(ctx, q)
+	}
+	url := net.JoinHostPort(nodeIP, fmt.Sprint(m.config.Port))
+	conn, err := m.peerConn(ctx, url)
+	if err != nil {
+		return nil, wireError(codes.Unavailable, &mpb.WireError{WireUid: q.LinkUid, PeerIp: nodeIP, Cause: mpb.WireError_PEER_UNREACHABLE},
+			"failed to connect to the daemon on %s: %s", nodeIP, err)
+	}
+	return mpb.NewRemoteClient(conn).GetLinkStats(ctx, q)
+}
+
+func aggregateStats(local, remote *mpb.LinkStats, localErr, remoteErr error) (*mpb.AggregatedLinkStats, error) {
+	if localErr != nil && remoteErr != nil {
+		return nil, fmt.Errorf("failed to read stats of both ends: %v; %v", localErr, remoteErr)
+	}
+	result := &mpb.AggregatedLinkStats{Local: local, Remote: remote}
+	switch {
+	case localErr != nil:
+		result.Warning = fmt.Sprintf("local end unavailable: %v", localErr)
+	case remoteErr != nil:
+		result.Warning = fmt.Sprintf("remote end unavailable: %v", remoteErr)
+	}
+	if result.Warning != "" {
+		log.Warnf("Returning partial link stats, %s", result.Warning)
+	}
+	return result, nil
+}
+
+// runningLink returns a running pod and its link uid
+func (m *Meshnet) runningLink(ctx context.Context, kubeNs, podName string, uid int64) (*mpb.Pod, *mpb.Link, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: podName, KubeNs: kubeNs})
+	if err != nil {
+		return nil, nil, err
+	}
+	link := linkByUID(pod.Links, uid)
+	if link == nil {
+		return nil, nil, wireError(codes.NotFound, &mpb.WireError{WireUid: uid}, "pod %s has no link %d", podName, uid)
+	}
+	if pod.NetNs == "" {
+		return nil, nil, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: uid, PeerIp: link.PeerIp}, "pod %s is not running", podName)
+	}
+	return pod, link, nil
+}
+
+func linkByUID(links []*mpb.Link, uid int64) *mpb.Link {
+	for _, l := range links {
+		if l.Uid == uid {
+			return l
+		}
+	}
+	return nil
+}
+
+// readLinkStats reads the counters of an interface in the netNs network namespace
+func readLinkStats(netNs, intf string) (*netlink.LinkStatistics, error) {
+	podNs, err := ns.GetNS(netNs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netns %s: %s", netNs, err)
+	}
+	defer podNs.Close()
+	result := &netlink.LinkStatistics{}
+	err = podNs.Do(func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(intf)
+		if err != nil {
+			return err
+		}
+		if s := l.Attrs().Statistics; s != nil {
+			result = s
+		}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,66 @@
+package meshnet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestAggregateStats(t *testing.T) {
+	local := &mpb.LinkStats{Pod: "r1", TxPackets: 10}
+	remote := &mpb.LinkStats{Pod: "r2", RxPackets: 9}
+	unreachable := errors.New("connection refused")
+
+	tests := []struct {
+		desc      string
+		local     *mpb.LinkStats
+		remote    *mpb.LinkStats
+		localErr  error
+		remoteErr error
+		warning   string
+		wantErr   bool
+	}{
+		{
+			desc:   "both ends",
+			local:  local,
+			remote: remote,
+		},
+		{
+			desc:      "remote unreachable",
+			local:     local,
+			remoteErr: unreachable,
+			warning:   "remote end unavailable",
+		},
+		{
+			desc:     "local unreachable",
+			remote:   remote,
+			localErr: unreachable,
+			warning:  "local end unavailable",
+		},
+		{
+			desc:      "both unreachable",
+			localErr:  unreachable,
+			remoteErr: unreachable,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := aggregateStats(tt.local, tt.remote, tt.localErr, tt.remoteErr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("aggregateStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Local != tt.local || got.Remote != tt.remote {
+				t.Errorf("aggregateStats() = %v, want local %v and remote %v", got, tt.local, tt.remote)
+			}
+			if !strings.HasPrefix(got.Warning, tt.warning) || (tt.warning == "") != (got.Warning == "") {
+				t.Errorf("aggregateStats() warning = %q, want %q", got.Warning, tt.warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package meshnet
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	defaultStatsInterval = time.Second
+	minStatsInterval     = 100 * time.Millisecond
+)
+
+// StreamLinkStats streams the stats of the links of a pod, read by the daemon of the node the
+// pod is running on, in a batch every interval_ms. In RATE mode, the counters are the increase
+// since the previous batch, the first one being sent after an interval. The stream ends when
+// the client cancels the call, or once the pod has none of the requested links left.
+func (m *Meshnet) StreamLinkStats(req *mpb.StatsStreamRequest, stream mpb.Local_StreamLinkStatsServer) error {
+	if err := validateStatsStream(req); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: req.Pod, KubeNs: req.KubeNs})
+	if err != nil {
+		return err
+	}
+	if pod.SrcIp == "" {
+		return status.Errorf(codes.FailedPrecondition, "pod %s is not running", req.Pod)
+	}
+	if pod.SrcIp == os.Getenv("HOST_IP") {
+		return m.streamLinkStats(ctx, req, stream.Send)
+	}
+	client, err := m.remoteClient(ctx, pod.SrcIp, 0)
+	if err != nil {
+		return err
+	}
+	remote, err := client.StreamPodLinkStats(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		b, err := remote.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(b); err != nil {
+			return err
+		}
+	}
+}
+
+// StreamPodLinkStats streams the stats of the links of a pod running on this node
+func (m *Meshnet) StreamPodLinkStats(req *mpb.StatsStreamRequest, stream mpb.Remote_StreamPodLinkStatsServer) error {
+	if err := validateStatsStream(req); err != nil {
+		return err
+	}
+	return m.streamLinkStats(stream.Context(), req, stream.Send)
+}
+
+func (m *Meshnet) streamLinkStats(ctx context.Context, req *mpb.StatsStreamRequest, send func(*mpb.StatsBatch) error) error {
+	interval := defaultStatsInterval
+	if req.IntervalMs != 0 {
+		interval = time.Duration(req.IntervalMs) * time.Millisecond
+	}
+	prev, err := m.streamedStats(ctx, req)
+	if err != nil || prev == nil {
+		return err
+	}
+	last := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			cur, err := m.streamedStats(ctx, req)
+			if err != nil || cur == nil {
+				return err
+			}
+			batch := &mpb.StatsBatch{Timestamp: now.UTC().Format(time.RFC3339), ElapsedMs: now.Sub(last).Milliseconds()}
+			for _, uid := range statsUIDs(cur) {
+				s := cur[uid]
+				if req.Mode == mpb.StatsStreamRequest_RATE {
+					p, ok := prev[uid]
+					if !ok {
+						// the link has been added since the previous batch
+						continue
+					}
+					s = statsDelta(p, s)
+				}
+				batch.Stats = append(batch.Stats, s)
+			}
+			prev, last = cur, now
+			if err := send(batch); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// streamedStats reads the stats of the requested links of a pod running on this node, by uid.
+// It returns nil if the pod has none of them left.
+func (m *Meshnet) streamedStats(ctx context.Context, req *mpb.StatsStreamRequest) (map[int64]*mpb.LinkStats, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: req.Pod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	if pod.NetNs == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "pod %s is not running", req.Pod)
+	}
+	wanted := make(map[int64]bool)
+	for _, uid := range req.LinkUids {
+		wanted[uid] = true
+	}
+	var result map[int64]*mpb.LinkStats
+	for _, link := range pod.Links {
+		if len(wanted) > 0 && !wanted[link.Uid] {
+			continue
+		}
+		if result == nil {
+			result = make(map[int64]*mpb.LinkStats)
+		}
+		s, err := m.GetLinkStats(ctx, &mpb.LinkStatsQuery{Pod: req.Pod, KubeNs: req.KubeNs, LinkUid: link.Uid})
+		if err != nil {
+			// e.g. the wire is being set up again, it's streamed once it's back
+			log.Debugf("Not streaming the stats of link %d of pod %s: %s", link.Uid, req.Pod, err)
+			continue
+		}
+		s.LinkUid = link.Uid
+		result[link.Uid] = s
+	}
+	return result, nil
+}
+
+// statsDelta returns the increase of the counters of a link from prev to cur, a counter that
+// went down, e.g. when its interface has been re-created, counting from 0. The other fields
+// are the current ones.
+func statsDelta(prev, cur *mpb.LinkStats) *mpb.LinkStats {
+	delta := func(p, c uint64) uint64 {
+		if c < p {
+			return c
+		}
+		return c - p
+	}
+	d := &mpb.LinkStats{
+		Pod:                     cur.Pod,
+		Intf:                    cur.Intf,
+		NodeIp:                  cur.NodeIp,
+		RxPackets:               delta(prev.RxPackets, cur.RxPackets),
+		TxPackets:               delta(prev.TxPackets, cur.TxPackets),
+		RxBytes:                 delta(prev.RxBytes, cur.RxBytes),
+		TxBytes:                 delta(prev.TxBytes, cur.TxBytes),
+		RxErrors:                delta(prev.RxErrors, cur.RxErrors),
+		TxErrors:                delta(prev.TxErrors, cur.TxErrors),
+		RxDropped:               delta(prev.RxDropped, cur.RxDropped),
+		TxDropped:               delta(prev.TxDropped, cur.TxDropped),
+		FlapCount:               int64(delta(uint64(prev.FlapCount), uint64(cur.FlapCount))),
+		LastFlap:                cur.LastFlap,
+		Ofport:                  cur.Ofport,
+		RxQueueDepth:            cur.RxQueueDepth,
+		BackpressureEventsTotal: delta(prev.BackpressureEventsTotal, cur.BackpressureEventsTotal),
+		LinkUid:                 cur.LinkUid,
+	}
+	for class, bytes := range cur.DscpTxBytes {
+		if d.DscpTxBytes == nil {
+			d.DscpTxBytes = make(map[uint32]uint64)
+		}
+		d.DscpTxBytes[class] = delta(prev.DscpTxBytes[class], bytes)
+	}
+	return d
+}
+
+func statsUIDs(stats map[int64]*mpb.LinkStats) []int64 {
+	uids := make([]int64, 0, len(stats))
+	for uid := range stats {
+		uids = append(uids, uid)
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return uids
+}
+
+func validateStatsStream(req *mpb.StatsStreamRequest) error {
+	if req.IntervalMs < 0 || (req.IntervalMs != 0 && time.Duration(req.IntervalMs)*time.Millisecond < minStatsInterval) {
+		return status.Errorf(codes.InvalidArgument, "invalid interval of %dms, it must be at least %s", req.IntervalMs, minStatsInterval)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package meshnet_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestStreamLinkStats(t *testing.T) {
+	m := meshnettest.NewFakeMeshnet(lab())
+	for _, req := range []*mpb.StatsStreamRequest{
+		{Pod: "r1", KubeNs: "default", IntervalMs: -1},
+		{Pod: "r1", KubeNs: "default", IntervalMs: 10},
+	} {
+		if err := m.StreamLinkStats(req, nil); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("StreamLinkStats(%v) = %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+// statsStream collects the batches of a stats stream until its context is done
+type statsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	batches chan *mpb.StatsBatch
+}
+
+func (s *statsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *statsStream) Send(b *mpb.StatsBatch) error {
+	select {
+	case s.batches <- b:
+	case <-s.ctx.Done():
+	}
+	return nil
+}
+
+func TestStreamLinkStatsRate(t *testing.T) {
+	t.Setenv("HOST_IP", "10.0.0.1")
+	m := meshnettest.NewFakeMeshnet(lab())
+	// link 2 is up too, but not streamed
+	netNs := runningPod(t, m, "r1", "eth1", "eth2")
+	// without IPv6, the frames of the test are the only ones on the veth
+	netNs.Do(func(ns.NetNS) error {
+		for _, intf := range []string{"eth1", "peer1"} {
+			os.WriteFile("/proc/sys/net/ipv6/conf/"+intf+"/disable_ipv6", []byte("1"), 0644)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &statsStream{ctx: ctx, batches: make(chan *mpb.StatsBatch, 16)}
+	streamed := make(chan error, 1)
+	go func() {
+		req := &mpb.StatsStreamRequest{Pod: "r1", KubeNs: "default", LinkUids: []int64{1}, Mode: mpb.StatsStreamRequest_RATE, IntervalMs: 100}
+		streamed <- m.StreamLinkStats(req, stream)
+	}()
+	next := func() *mpb.StatsBatch {
+		t.Helper()
+		select {
+		case b := <-stream.batches:
+			return b
+		case err := <-streamed:
+			t.Fatalf("StreamLinkStats() has returned %v", err)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no stats batch streamed")
+		}
+		return nil
+	}
+	next()
+
+	frame := udpFrame(7000)
+	for intf, n := range map[string]int{"peer1": 5, "eth1": 3} {
+		s, err := capture.OpenInjector(netNs.Path(), intf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < n; i++ {
+			if err := s.Send(frame); err != nil {
+				t.Fatal(err)
+			}
+		}
+		s.Close()
+	}
+
+	// the rates of the following batches add up to the frames sent
+	total := &mpb.LinkStats{}
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline) && (total.RxPackets < 5 || total.TxPackets < 3); {
+		b := next()
+		if b.ElapsedMs <= 0 {
+			t.Errorf("batch elapsed %dms, want the time since the previous one", b.ElapsedMs)
+		}
+		if len(b.Stats) != 1 || b.Stats[0].LinkUid != 1 || b.Stats[0].Intf != "eth1" {
+			t.Fatalf("batch stats = %v, want the stats of eth1 only", b.Stats)
+		}
+		s := b.Stats[0]
+		total.RxPackets, total.RxBytes = total.RxPackets+s.RxPackets, total.RxBytes+s.RxBytes
+		total.TxPackets, total.TxBytes = total.TxPackets+s.TxPackets, total.TxBytes+s.TxBytes
+	}
+	size := uint64(len(frame))
+	if total.RxPackets != 5 || total.RxBytes != 5*size || total.TxPackets != 3 || total.TxBytes != 3*size {
+		t.Errorf("streamed %d frames of %d bytes received and %d of %d sent, want 5 of %d and 3 of %d",
+			total.RxPackets, total.RxBytes, total.TxPackets, total.TxBytes, 5*size, 3*size)
+	}
+
+	cancel()
+	if err := <-streamed; err != nil {
+		t.Errorf("StreamLinkStats() = %v once cancelled", err)
+	}
+}
@@ -0,0 +1,30 @@
+package meshnet
+
+import (
+	"reflect"
+	"testing"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestStatsDelta(t *testing.T) {
+	prev := &mpb.LinkStats{Pod: "r1", RxPackets: 10, TxBytes: 1000, FlapCount: 1, RxQueueDepth: 4, DscpTxBytes: map[uint32]uint64{46: 100}}
+	tests := []struct {
+		desc string
+		cur  *mpb.LinkStats
+		want *mpb.LinkStats
+	}{{
+		desc: "increase",
+		cur:  &mpb.LinkStats{Pod: "r1", RxPackets: 15, TxBytes: 1500, FlapCount: 2, RxQueueDepth: 2, DscpTxBytes: map[uint32]uint64{46: 150, 10: 20}, LinkUid: 1},
+		want: &mpb.LinkStats{Pod: "r1", RxPackets: 5, TxBytes: 500, FlapCount: 1, RxQueueDepth: 2, DscpTxBytes: map[uint32]uint64{46: 50, 10: 20}, LinkUid: 1},
+	}, {
+		desc: "re-created interface",
+		cur:  &mpb.LinkStats{Pod: "r1", RxPackets: 3, TxBytes: 200, FlapCount: 1},
+		want: &mpb.LinkStats{Pod: "r1", RxPackets: 3, TxBytes: 200},
+	}}
+	for _, tt := range tests {
+		if got := statsDelta(prev, tt.cur); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: statsDelta() = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
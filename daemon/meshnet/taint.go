@@ -0,0 +1,201 @@
+package meshnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	notReadyTaint = "meshnet.io/not-ready"
+
+	wireErrorWindow   = 5 * time.Minute
+	selfTaintInterval = 30 * time.Second
+	// wire set ups of the window below which their error rate isn't significant
+	minWireSamples = 10
+)
+
+type wireResult struct {
+	at     time.Time
+	failed bool
+	// error of a failed set up
+	message string
+}
+
+// errorWindow keeps the results of the wire set ups of the last window
+type errorWindow struct {
+	mu      sync.Mutex
+	window  time.Duration
+	results []wireResult
+}
+
+func newErrorWindow(window time.Duration) *errorWindow {
+	return &errorWindow{window: window}
+}
+
+func (w *errorWindow) add(at time.Time, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r := wireResult{at: at, failed: err != nil}
+	if err != nil {
+		r.message = err.Error()
+	}
+	w.results = append(w.results, r)
+}
+
+// prune forgets the results older than the window ending at now. w.mu must be held.
+func (w *errorWindow) prune(now time.Time) {
+	i := 0
+	for i < len(w.results) && now.Sub(w.results[i].at) > w.window {
+		i++
+	}
+	w.results = w.results[i:]
+}
+
+// rate returns the fraction of failed wire set ups in the window ending at now, and their
+// number, forgetting the older ones
+func (w *errorWindow) rate(now time.Time) (float64, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.prune(now)
+	if len(w.results) == 0 {
+		return 0, 0
+	}
+	failed := 0
+	for _, r := range w.results {
+		if r.failed {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(w.results)), len(w.results)
+}
+
+// top returns the n most frequent errors in the window ending at now, most frequent first,
+// forgetting the older ones
+func (w *errorWindow) top(now time.Time, n int) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.prune(now)
+	counts := make(map[string]int)
+	for _, r := range w.results {
+		if r.failed {
+			counts[r.message]++
+		}
+	}
+	messages := make([]string, 0, len(counts))
+	for msg := range counts {
+		messages = append(messages, msg)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if counts[messages[i]] != counts[messages[j]] {
+			return counts[messages[i]] > counts[messages[j]]
+		}
+		return messages[i] < messages[j]
+	})
+	if len(messages) > n {
+		messages = messages[:n]
+	}
+	return messages
+}
+
+// recordWire counts the result of a wire set up towards the node's health
+func (m *Meshnet) recordWire(err error) {
+	m.wireErrors.add(time.Now(), err)
+}
+
+// withTaint returns taints with the not-ready taint added or removed, and whether they've changed
+func withTaint(taints []corev1.Taint, tainted bool) ([]corev1.Taint, bool) {
+	var result []corev1.Taint
+	found := false
+	for _, t := range taints {
+		if t.Key == notReadyTaint && t.Effect == corev1.TaintEffectNoSchedule {
+			found = true
+			continue
+		}
+		result = append(result, t)
+	}
+	if tainted {
+		result = append(result, corev1.Taint{Key: notReadyTaint, Effect: corev1.TaintEffectNoSchedule})
+	}
+	return result, found != tainted
+}
+
+// SelfTaint taints the daemon's node with meshnet.io/not-ready:NoSchedule while more than
+// SelfTaintThreshold of its wire set ups have failed in the last 5 minutes, so that no new
+// pods are scheduled there, until stopCh is closed.
+func (m *Meshnet) SelfTaint(stopCh <-chan struct{}) {
+	node := os.Getenv("NODE_NAME")
+	if m.config.SelfTaintThreshold <= 0 || node == "" {
+		if m.config.SelfTaintThreshold > 0 {
+			log.Warnf("NODE_NAME must be set to taint the node")
+		}
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(selfTaintInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := m.checkTaint(context.Background(), node); err != nil {
+				log.Warnf("Failed to update the taints of node %s: %s", node, err)
+			}
+		}
+	}
+}
+
+// checkTaint taints or untaints node according to the error rate of the recent wire set ups.
+// There's no taint with fewer than minWireSamples of them, so that a single failure on a quiet
+// node doesn't taint it, and a tainted node, which gets no new wires, is untainted once its
+// failures fall out of the window. The daemon can't taint its node while K8s is unreachable,
+// that's left to the readiness probe.
+func (m *Meshnet) checkTaint(ctx context.Context, node string) error {
+	reason := ""
+	if rate, n := m.wireErrors.rate(time.Now()); n >= minWireSamples && rate > m.config.SelfTaintThreshold {
+		reason = fmt.Sprintf("%.1f%% of %d wire set ups have failed in the last %s", rate*100, n, wireErrorWindow)
+	}
+	return m.setTaint(ctx, node, reason)
+}
+
+// setTaint adds the not-ready taint to node if reason isn't empty, or removes it otherwise
+func (m *Meshnet) setTaint(ctx context.Context, node, reason string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		n, err := m.kClient.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		taints, changed := withTaint(n.Spec.Taints, reason != "")
+		if !changed {
+			return nil
+		}
+		// Taints are replaced as a whole, the resource version guards against concurrent changes
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"resourceVersion": n.ResourceVersion},
+			"spec":     map[string]interface{}{"taints": taints},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := m.kClient.CoreV1().Nodes().Patch(ctx, node, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return err
+		}
+		if reason != "" {
+			log.Warnf("Node %s has been tainted with %s: %s", node, notReadyTaint, reason)
+		} else {
+			log.Infof("Taint %s has been removed from node %s", notReadyTaint, node)
+		}
+		return nil
+	})
+}
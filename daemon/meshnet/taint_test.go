@@ -0,0 +1,124 @@
+package meshnet
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestErrorWindow(t *testing.T) {
+	now := time.Now()
+	w := newErrorWindow(5 * time.Minute)
+	if got, n := w.rate(now); got != 0 || n != 0 {
+		t.Errorf("rate() = %v, %d without results, want 0, 0", got, n)
+	}
+	failed := errors.New("failed")
+	w.add(now.Add(-10*time.Minute), failed)
+	w.add(now.Add(-10*time.Minute), failed)
+	w.add(now.Add(-time.Minute), failed)
+	for i := 0; i < 3; i++ {
+		w.add(now.Add(-time.Minute), nil)
+	}
+	if got, n := w.rate(now); got != 0.25 || n != 4 {
+		t.Errorf("rate() = %v, %d, want 0.25, 4", got, n)
+	}
+	// the failure falls out of the window
+	if got, n := w.rate(now.Add(5 * time.Minute)); got != 0 || n != 0 {
+		t.Errorf("rate() = %v, %d after the window, want 0, 0", got, n)
+	}
+}
+
+func TestWithTaint(t *testing.T) {
+	other := corev1.Taint{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}
+	taints, changed := withTaint([]corev1.Taint{other}, true)
+	if !changed || len(taints) != 2 || taints[1].Key != notReadyTaint {
+		t.Fatalf("withTaint() = %v, %v, want the taint added", taints, changed)
+	}
+	if _, changed := withTaint(taints, true); changed {
+		t.Error("withTaint() has changed an already tainted node")
+	}
+	taints, changed = withTaint(taints, false)
+	if !changed || len(taints) != 1 || taints[0] != other {
+		t.Errorf("withTaint() = %v, %v, want the taint removed", taints, changed)
+	}
+}
+
+func TestCheckTaint(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	m := &Meshnet{
+		config:     Config{SelfTaintThreshold: 0.05},
+		kClient:    client,
+		wireErrors: newErrorWindow(wireErrorWindow),
+	}
+	tainted := func() bool {
+		n, err := client.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, taint := range n.Spec.Taints {
+			if taint.Key == notReadyTaint {
+				return true
+			}
+		}
+		return false
+	}
+
+	// a single failure isn't enough to taint a quiet node
+	m.recordWire(context.DeadlineExceeded)
+	if err := m.checkTaint(context.Background(), "node1"); err != nil {
+		t.Fatal(err)
+	}
+	if tainted() {
+		t.Error("node has been tainted with fewer than the minimum wire set ups")
+	}
+
+	m.wireErrors = newErrorWindow(wireErrorWindow)
+	for i := 0; i < 19; i++ {
+		m.recordWire(nil)
+	}
+	m.recordWire(context.DeadlineExceeded)
+	if err := m.checkTaint(context.Background(), "node1"); err != nil {
+		t.Fatal(err)
+	}
+	if tainted() {
+		t.Error("node has been tainted at the threshold")
+	}
+
+	m.recordWire(context.DeadlineExceeded)
+	if err := m.checkTaint(context.Background(), "node1"); err != nil {
+		t.Fatal(err)
+	}
+	if !tainted() {
+		t.Error("node hasn't been tainted above the threshold")
+	}
+
+	m.wireErrors = newErrorWindow(wireErrorWindow)
+	if err := m.checkTaint(context.Background(), "node1"); err != nil {
+		t.Fatal(err)
+	}
+	if tainted() {
+		t.Error("taint hasn't been removed after recovery")
+	}
+}
+
+func TestErrorWindowTop(t *testing.T) {
+	now := time.Now()
+	w := newErrorWindow(5 * time.Minute)
+	w.add(now.Add(-10*time.Minute), errors.New("old"))
+	for i, msg := range []string{"b", "a", "c", "b", "a", "b"} {
+		w.add(now.Add(-time.Duration(i)*time.Second), errors.New(msg))
+	}
+	w.add(now, nil)
+	if got, want := w.top(now, 2), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("top() = %v, want %v", got, want)
+	}
+	if got, want := w.top(now, 5), []string{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("top() = %v, want %v", got, want)
+	}
+}
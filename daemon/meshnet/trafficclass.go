@@ -0,0 +1,69 @@
+package meshnet
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// trafficClassPolicy reads the traffic class policy of the topology obj
+func trafficClassPolicy(obj *unstructured.Unstructured) ([]topologyv1.TrafficClass, error) {
+	raw, _, err := unstructured.NestedSlice(obj.Object, "spec", "traffic_class_policy")
+	if err != nil {
+		return nil, err
+	}
+	var policy []topologyv1.TrafficClass
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid traffic class %v", r)
+		}
+		uidRange, _, _ := unstructured.NestedMap(m, "link_selector", "uid_range")
+		policy = append(policy, topologyv1.TrafficClass{
+			LinkSelector: topologyv1.TrafficClassSelector{UIDRange: topologyv1.UIDRange{
+				Min: int(number(uidRange["min"])),
+				Max: int(number(uidRange["max"])),
+			}},
+			DSCP: int(number(m["dscp"])),
+		})
+	}
+	if err := topologyv1.ValidateTrafficClassPolicy(policy); err != nil {
+		return nil, fmt.Errorf("invalid traffic class policy: %s", err)
+	}
+	return policy, nil
+}
+
+// applyTrafficClasses sets the traffic class of the links selected by the traffic class
+// policy of the topology obj
+func applyTrafficClasses(obj *unstructured.Unstructured, links []*mpb.Link) error {
+	policy, err := trafficClassPolicy(obj)
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		if dscp, ok := topologyv1.TrafficClassOf(policy, int(l.Uid)); ok {
+			l.TrafficClass = &mpb.TrafficClass{Dscp: uint32(dscp)}
+		}
+	}
+	return nil
+}
+
+// trafficClassOf returns the traffic class of the link uid of pod, which the policy of its
+// topology has set
+func trafficClassOf(pod *mpb.Pod, uid int64) *mpb.TrafficClass {
+	if l := linkByUID(pod.Links, uid); l != nil {
+		return l.TrafficClass
+	}
+	return nil
+}
+
+// qosOf returns the egress QoS map of the link uid of pod and the bandwidth of its queues
+func qosOf(pod *mpb.Pod, uid int64) ([]*mpb.DscpQueueEntry, *mpb.QueueAllocation) {
+	if l := linkByUID(pod.Links, uid); l != nil {
+		return l.EgressQosMap, l.EgressQueueAllocation
+	}
+	return nil, nil
+}
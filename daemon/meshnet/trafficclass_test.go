@@ -0,0 +1,70 @@
+package meshnet
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func trafficClass(min, max, dscp int) topologyv1.TrafficClass {
+	return topologyv1.TrafficClass{
+		LinkSelector: topologyv1.TrafficClassSelector{UIDRange: topologyv1.UIDRange{Min: min, Max: max}},
+		DSCP:         dscp,
+	}
+}
+
+func TestValidateTrafficClassPolicy(t *testing.T) {
+	tests := []struct {
+		policy []topologyv1.TrafficClass
+		valid  bool
+	}{
+		{policy: nil, valid: true},
+		{policy: []topologyv1.TrafficClass{trafficClass(1, 10, 46), trafficClass(11, 20, 0)}, valid: true},
+		{policy: []topologyv1.TrafficClass{trafficClass(1, 1, 63)}, valid: true},
+		{policy: []topologyv1.TrafficClass{trafficClass(1, 10, 64)}, valid: false},
+		{policy: []topologyv1.TrafficClass{trafficClass(1, 10, -1)}, valid: false},
+		{policy: []topologyv1.TrafficClass{trafficClass(10, 1, 46)}, valid: false},
+		{policy: []topologyv1.TrafficClass{trafficClass(1, 10, 46), trafficClass(10, 20, 10)}, valid: false},
+		{policy: []topologyv1.TrafficClass{trafficClass(5, 6, 46), trafficClass(1, 10, 10)}, valid: false},
+	}
+	for i, tt := range tests {
+		err := topologyv1.ValidateTrafficClassPolicy(tt.policy)
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+func TestApplyTrafficClasses(t *testing.T) {
+	policy := []interface{}{
+		map[string]interface{}{
+			"link_selector": map[string]interface{}{"uid_range": map[string]interface{}{"min": int64(1), "max": int64(2)}},
+			"dscp":          int64(46),
+		},
+		map[string]interface{}{
+			"link_selector": map[string]interface{}{"uid_range": map[string]interface{}{"min": int64(3), "max": int64(3)}},
+			"dscp":          int64(0),
+		},
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"traffic_class_policy": policy},
+	}}
+	links := []*mpb.Link{{Uid: 1}, {Uid: 2}, {Uid: 3}, {Uid: 4}}
+	if err := applyTrafficClasses(obj, links); err != nil {
+		t.Fatalf("applyTrafficClasses() failed: %v", err)
+	}
+	want := map[int64]*mpb.TrafficClass{1: {Dscp: 46}, 2: {Dscp: 46}, 3: {Dscp: 0}, 4: nil}
+	for _, l := range links {
+		if got := l.TrafficClass; (got == nil) != (want[l.Uid] == nil) || (got != nil && got.Dscp != want[l.Uid].Dscp) {
+			t.Errorf("traffic class of link %d = %v, want %v", l.Uid, got, want[l.Uid])
+		}
+	}
+
+	policy[1].(map[string]interface{})["dscp"] = int64(64)
+	if err := applyTrafficClasses(obj, links); err == nil {
+		t.Errorf("applyTrafficClasses() of an invalid policy succeeded")
+	}
+}
@@ -0,0 +1,223 @@
+package meshnet
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/vxlan"
+)
+
+// errNotAttempted is the error of the links left out of a transaction that has already failed
+const errNotAttempted = "not attempted"
+
+// CreateTopologyWires sets up the wires of all the links of a pod running on this node, in
+// UID order, or none of them: once a wire fails, the ones already set up are removed in
+// reverse order. The wires set up so far are recorded in the status of the topology, so that
+// RecoverTransactions can remove them if the daemon restarts before the transaction is done.
+func (m *Meshnet) CreateTopologyWires(ctx context.Context, req *mpb.TopologyWireRequest) (*mpb.TransactionResult, error) {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: req.Pod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	if pod.NetNs == "" || pod.SrcIp != os.Getenv("HOST_IP") {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{Operation: mpb.WireError_ADD},
+			"pod %s is not running on this node", req.Pod)
+	}
+	id, err := transactionID()
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{Operation: mpb.WireError_ADD}, "failed to generate a transaction ID: %s", err)
+	}
+	tx := &topologyv1.WireTransaction{ID: id, NodeIP: pod.SrcIp}
+	if err := m.setTransaction(ctx, req.Pod, req.KubeNs, tx); err != nil {
+		return nil, k8sError(err, mpb.WireError_ADD, "failed to record transaction %s of pod %s", id, req.Pod)
+	}
+
+	links := append([]*mpb.Link(nil), pod.Links...)
+	sort.Slice(links, func(i, j int) bool { return links[i].Uid < links[j].Uid })
+	result := &mpb.TransactionResult{TransactionId: id}
+	failed := false
+	for _, link := range links {
+		st := &mpb.LinkTransactionStatus{Uid: link.Uid}
+		result.Links = append(result.Links, st)
+		if failed {
+			st.Error = errNotAttempted
+			continue
+		}
+		if err := m.createWire(ctx, req.Pod, req.KubeNs, link); err != nil {
+			log.Warnf("Transaction %s of pod %s failed to set up link %d: %s", id, req.Pod, link.Uid, err)
+			st.Error = err.Error()
+			failed = true
+			continue
+		}
+		st.Created = true
+		tx.Created = append(tx.Created, link.Uid)
+		if err := m.setTransaction(ctx, req.Pod, req.KubeNs, tx); err != nil {
+			// without a record, a restart couldn't roll the wire back
+			st.Error = err.Error()
+			failed = true
+		}
+	}
+
+	// the rollback must happen even if the caller has gone
+	bg := context.Background()
+	if failed {
+		rolledBack := m.rollbackWires(bg, req.Pod, req.KubeNs, tx.Created)
+		for _, st := range result.Links {
+			st.RolledBack = rolledBack[st.Uid]
+		}
+	} else {
+		result.Committed = true
+	}
+	if err := m.setTransaction(bg, req.Pod, req.KubeNs, nil); err != nil {
+		log.Warnf("Failed to clear transaction %s of pod %s: %s", id, req.Pod, err)
+	}
+	log.Infof("Transaction %s of pod %s set up %d of %d wires, committed: %t", id, req.Pod, len(tx.Created), len(links), result.Committed)
+	return result, nil
+}
+
+// RemoveWireEnd removes the end of a wire in a pod running on this node, when the wire is
+// rolled back by a transaction running on the node of the other end
+func (m *Meshnet) RemoveWireEnd(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.BoolResponse, error) {
+	pod, link, err := m.runningLink(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return &mpb.BoolResponse{Response: false}, err
+	}
+	if err := m.removeEnd(ctx, q.Pod, pod, link); err != nil {
+		return &mpb.BoolResponse{Response: false}, wireError(codes.Internal,
+			&mpb.WireError{Operation: mpb.WireError_REMOVE, WireUid: q.LinkUid, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to remove interface %s of pod %s: %s", link.LocalIntf, q.Pod, err)
+	}
+	return &mpb.BoolResponse{Response: true}, nil
+}
+
+// RecoverTransactions rolls back the transactions of this node that were in progress when
+// the daemon stopped
+func (m *Meshnet) RecoverTransactions(ctx context.Context) error {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, t := range topologies.Items {
+		tx := t.Status.WireTransaction
+		if tx == nil || tx.NodeIP != os.Getenv("HOST_IP") {
+			continue
+		}
+		log.Infof("Rolling back %d wires of interrupted transaction %s of pod %s", len(tx.Created), tx.ID, t.Name)
+		m.rollbackWires(ctx, t.Name, t.Namespace, tx.Created)
+		if err := m.setTransaction(ctx, t.Name, t.Namespace, nil); err != nil {
+			log.Warnf("Failed to clear transaction %s of pod %s: %s", tx.ID, t.Name, err)
+		}
+	}
+	return nil
+}
+
+// rollbackWires removes the wires uids of pod in reverse order, and returns the ones removed
+func (m *Meshnet) rollbackWires(ctx context.Context, pod, ns string, uids []int64) map[int64]bool {
+	removed := map[int64]bool{}
+	localPod, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil {
+		log.Warnf("Failed to roll back the wires of pod %s: %s", pod, err)
+		return removed
+	}
+	for i := len(uids) - 1; i >= 0; i-- {
+		link := linkByUID(localPod.Links, uids[i])
+		if link == nil || localPod.NetNs == "" {
+			// the link or the pod is gone, and its interface with it
+			removed[uids[i]] = true
+			continue
+		}
+		if err := m.rollbackWire(ctx, pod, localPod, link); err != nil {
+			log.Warnf("Failed to roll back link %d of pod %s: %s", link.Uid, pod, err)
+			continue
+		}
+		removed[link.Uid] = true
+	}
+	return removed
+}
+
+// rollbackWire removes both ends of the wire of link
+func (m *Meshnet) rollbackWire(ctx context.Context, name string, pod *mpb.Pod, link *mpb.Link) error {
+	if err := m.removeEnd(ctx, name, pod, link); err != nil {
+		return err
+	}
+	if link.PeerPod == localhost {
+		return nil
+	}
+	peer, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: pod.KubeNs})
+	if err != nil {
+		return err
+	}
+	if peer.NetNs == "" {
+		return nil
+	}
+	peerLink := linkByUID(peer.Links, link.Uid)
+	if peerLink == nil {
+		return nil
+	}
+	if peer.SrcIp == pod.SrcIp {
+		// the peer end of a veth is gone with the local one, but not an OVS port
+		return m.removeEnd(ctx, link.PeerPod, peer, peerLink)
+	}
+	client, err := m.remoteClient(ctx, peer.SrcIp, link.Uid)
+	if err != nil {
+		return err
+	}
+	_, err = client.RemoveWireEnd(ctx, &mpb.LinkStatsQuery{Pod: link.PeerPod, KubeNs: pod.KubeNs, LinkUid: link.Uid})
+	return err
+}
+
+// removeEnd removes the interface of link in pod if it exists, and releases its wire
+func (m *Meshnet) removeEnd(ctx context.Context, name string, pod *mpb.Pod, link *mpb.Link) error {
+	if hasIntf(pod.NetNs, link.LocalIntf) {
+		if err := removeIntf(pod.NetNs, link, pod.OvsBridge); err != nil {
+			return err
+		}
+	}
+	vxlan.Forget(pod.KubeNs, pod.NetNs, link.LocalIntf, uint32(link.Uid+vxlanBase))
+	if err := m.deregisterEndpoint(ctx, pod.KubeNs, name, pod.NetNs, link.LocalIntf); err != nil {
+		log.Warnf("Failed to deregister interface %s of pod %s: %s", link.LocalIntf, name, err)
+	}
+	m.wires.release(pod.KubeNs, name, link.Uid)
+	return nil
+}
+
+// setTransaction records tx in the status of the topology of pod, or clears it if tx is nil
+func (m *Meshnet) setTransaction(ctx context.Context, pod, ns string, tx *topologyv1.WireTransaction) error {
+	return retryOnConflictWithContext(ctx, func() error {
+		obj, err := m.getPod(ctx, pod, ns)
+		if err != nil {
+			return err
+		}
+		if tx == nil {
+			unstructured.RemoveNestedField(obj.Object, "status", "wire_transaction")
+			return m.updateStatus(ctx, obj, ns)
+		}
+		created := make([]interface{}, 0, len(tx.Created))
+		for _, uid := range tx.Created {
+			created = append(created, uid)
+		}
+		record := map[string]interface{}{"id": tx.ID, "node_ip": tx.NodeIP, "created": created}
+		if err := unstructured.SetNestedField(obj.Object, record, "status", "wire_transaction"); err != nil {
+			return err
+		}
+		return m.updateStatus(ctx, obj, ns)
+	})
+}
+
+func transactionID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
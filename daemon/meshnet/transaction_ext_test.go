@@ -0,0 +1,68 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestCreateTopologyWires(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("HOST_IP", "10.0.0.1")
+	m := meshnettest.NewFakeMeshnet(lab())
+	if _, err := m.CreateTopologyWires(ctx, &mpb.TopologyWireRequest{Pod: "r1", KubeNs: "default"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("CreateTopologyWires() of a pod that isn't running = %v, want FailedPrecondition", err)
+	}
+	for _, pod := range []string{"r1", "r2"} {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", pod, err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/"+pod
+		if _, err := m.SetAlive(ctx, p); err != nil {
+			t.Fatalf("SetAlive(%s) failed: %v", pod, err)
+		}
+	}
+
+	// the netns of the pods don't exist, so the first wire fails
+	result, err := m.CreateTopologyWires(ctx, &mpb.TopologyWireRequest{Pod: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("CreateTopologyWires() failed: %v", err)
+	}
+	if result.Committed || result.TransactionId == "" || len(result.Links) != 2 {
+		t.Fatalf("CreateTopologyWires() = %v, want an uncommitted transaction of 2 links", result)
+	}
+	if l := result.Links[0]; l.Uid != 1 || l.Created || l.Error == "" {
+		t.Errorf("CreateTopologyWires() link 1 = %v, want an error", l)
+	}
+	if l := result.Links[1]; l.Uid != 2 || l.Created || l.Error != "not attempted" {
+		t.Errorf("CreateTopologyWires() link 2 = %v, want it not attempted", l)
+	}
+	if _, found, _ := unstructured.NestedMap(meshnettest.Store(m).Object("default", "r1").Object, "status", "wire_transaction"); found {
+		t.Errorf("CreateTopologyWires() has left its transaction in the status of r1")
+	}
+}
+
+func TestRecoverTransactions(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("HOST_IP", "10.0.0.1")
+	topologies := lab()
+	tx := map[string]interface{}{"id": "1234", "node_ip": "10.0.0.1", "created": []interface{}{int64(1)}}
+	if err := unstructured.SetNestedField(topologies[0].Object, tx, "status", "wire_transaction"); err != nil {
+		t.Fatalf("SetNestedField() failed: %v", err)
+	}
+	m := meshnettest.NewFakeMeshnet(topologies)
+
+	if err := m.RecoverTransactions(ctx); err != nil {
+		t.Fatalf("RecoverTransactions() failed: %v", err)
+	}
+	if _, found, _ := unstructured.NestedMap(meshnettest.Store(m).Object("default", "r1").Object, "status", "wire_transaction"); found {
+		t.Errorf("RecoverTransactions() has left the transaction in the status of r1")
+	}
+}
@@ -0,0 +1,45 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestRemoveEnd(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(_ ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"})
+	})
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+	m, err := NewWithClients(Config{DisableReflection: true}, fake.NewSimpleClientset(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := &mpb.Pod{Name: "r1", KubeNs: "default", NetNs: netNs.Path()}
+	if err := m.removeEnd(context.Background(), "r1", pod, &mpb.Link{LocalIntf: "eth1", Uid: 1}); err != nil {
+		t.Fatalf("removeEnd() = %v", err)
+	}
+	if hasIntf(netNs.Path(), "eth1") || hasIntf(netNs.Path(), "eth2") {
+		t.Errorf("veth pair still exists after removeEnd()")
+	}
+	// the end of a pod that is gone is released all the same
+	pod.NetNs = "/run/netns/deleted"
+	if err := m.removeEnd(context.Background(), "r1", pod, &mpb.Link{LocalIntf: "eth1", Uid: 1}); err != nil {
+		t.Errorf("removeEnd() of a removed netns = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package meshnet
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// group allowed to use the daemon's unix socket, besides root
+	unixSocketGroup = "meshnet"
+	unixSocketMode  = 0660
+)
+
+// listenUnix listens on the unix socket path, replacing a socket left by an earlier daemon.
+// The socket is only accessible by root and the meshnet group, if it exists.
+func listenUnix(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if fi, err := os.Stat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and isn't a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, unixSocketMode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	group, err := user.LookupGroup(unixSocketGroup)
+	if err != nil {
+		log.Warnf("Group %s doesn't exist, %s is only accessible by root", unixSocketGroup, path)
+		return lis, nil
+	}
+	gid, err := strconv.Atoi(group.Gid)
+	if err == nil {
+		err = os.Chown(path, 0, gid)
+	}
+	if err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("failed to give %s to group %s: %s", path, unixSocketGroup, err)
+	}
+	return lis, nil
+}
@@ -0,0 +1,83 @@
+package meshnet
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meshnet", "daemon.sock")
+	// a socket left by an earlier daemon is replaced
+	for i := 0; i < 2; i++ {
+		lis, err := listenUnix(path)
+		if err != nil {
+			t.Fatalf("listenUnix() #%d failed: %v", i, err)
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != unixSocketMode {
+			t.Errorf("socket mode = %v, want %v", fi.Mode().Perm(), os.FileMode(unixSocketMode))
+		}
+		if i == 0 {
+			// leave the socket behind, as a crashed daemon would
+			lis.(interface{ SetUnlinkOnClose(bool) }).SetUnlinkOnClose(false)
+		}
+		lis.Close()
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0644)
+	if _, err := listenUnix(file); err == nil {
+		t.Errorf("listenUnix() over a regular file didn't fail")
+	}
+}
+
+func TestServeUnix(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "daemon.sock")
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unixLis, err := listenUnix(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Meshnet{
+		config:  Config{ListenUnix: path},
+		kClient: fake.NewSimpleClientset(),
+		lis:     lis,
+		unixLis: unixLis,
+		s:       grpc.NewServer(),
+		health:  health.NewServer(),
+		peers:   newPeerTracker(),
+	}
+	mpb.RegisterLocalServer(m.s, m)
+	mpb.RegisterRemoteServer(m.s, m)
+	go m.Serve()
+	defer m.Stop()
+
+	conn, err := grpc.Dial("unix://"+path, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := mpb.NewRemoteClient(conn).Heartbeat(ctx, &mpb.HeartbeatRequest{NodeIp: "10.0.0.1"}); err != nil {
+		t.Errorf("Heartbeat() over the unix socket failed: %v", err)
+	}
+	resp, err := mpb.NewLocalClient(conn).ReleaseIPAM(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil || !resp.Response {
+		t.Errorf("ReleaseIPAM() over the unix socket = %v, %v", resp, err)
+	}
+}
@@ -0,0 +1,156 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc/codes"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/vxlan"
+)
+
+// VerifyWire reads the parameters of both ends of a wire from the kernels of their nodes,
+// and returns the ones they don't agree on
+func (m *Meshnet) VerifyWire(ctx context.Context, req *mpb.WireVerificationRequest) (*mpb.WireVerificationResult, error) {
+	q := &mpb.LinkStatsQuery{Pod: req.Pod, KubeNs: req.KubeNs, LinkUid: req.LinkUid}
+	pod, link, err := m.runningLink(ctx, req.KubeNs, req.Pod, req.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	if link.PeerPod == localhost {
+		return nil, wireError(codes.InvalidArgument, &mpb.WireError{WireUid: req.LinkUid},
+			"link %d of pod %s has no peer pod", req.LinkUid, req.Pod)
+	}
+	local, err := m.wireParamsFrom(ctx, pod.SrcIp, q)
+	if err != nil {
+		return nil, err
+	}
+	peerPod, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: req.KubeNs})
+	if err != nil {
+		return nil, err
+	}
+	peer, err := m.wireParamsFrom(ctx, peerPod.SrcIp, &mpb.LinkStatsQuery{Pod: link.PeerPod, KubeNs: req.KubeNs, LinkUid: req.LinkUid})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mpb.WireVerificationResult{Local: local, Peer: peer, Mismatches: compareWireParams(local, peer)}
+	for _, mm := range result.Mismatches {
+		log.Errorf("Wire %d between pods %s and %s: %s is %q on %s's end and %q on %s's", req.LinkUid,
+			req.Pod, link.PeerPod, mm.Field, mm.Local, req.Pod, mm.Peer, link.PeerPod)
+	}
+	return result, nil
+}
+
+// GetWireParams returns the parameters of the end of a wire of a pod running on this node
+func (m *Meshnet) GetWireParams(ctx context.Context, q *mpb.LinkStatsQuery) (*mpb.WireParams, error) {
+	pod, link, err := m.runningLink(ctx, q.KubeNs, q.Pod, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	params := &mpb.WireParams{
+		Pod:      q.Pod,
+		KubeNs:   q.KubeNs,
+		LinkUid:  q.LinkUid,
+		NodeIp:   pod.SrcIp,
+		IntfName: link.LocalIntf,
+		PeerIntf: link.PeerIntf,
+	}
+	err = inNetNs(pod.NetNs, func() error {
+		l, err := netlink.LinkByName(link.LocalIntf)
+		if err != nil {
+			return err
+		}
+		params.WireType = l.Type()
+		params.Mtu = int32(l.Attrs().MTU)
+		if vx, ok := l.(*netlink.Vxlan); ok {
+			params.Vni = int64(vx.VxlanId)
+			params.RemoteVtep = vx.Group.String()
+			// the VNI and remote VTEP of VXLAN-GPE links are set by their route
+			if params.VxlanGpe = vxlan.IsGPE(vx); params.VxlanGpe {
+				vni, remote, err := vxlan.GPETunnel(vx)
+				if err != nil {
+					return err
+				}
+				params.Vni, params.RemoteVtep = int64(vni), remote.String()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, wireError(codes.Internal, &mpb.WireError{WireUid: q.LinkUid, Cause: mpb.WireError_NETLINK_ERROR},
+			"failed to read %s of pod %s: %s", link.LocalIntf, q.Pod, err)
+	}
+	return params, nil
+}
+
+// wireParamsFrom reads the end of a wire locally if nodeIP is this node, or through the
+// daemon on nodeIP
+func (m *Meshnet) wireParamsFrom(ctx context.Context, nodeIP string, q *mpb.LinkStatsQuery) (*mpb.WireParams, error) {
+	if nodeIP == "" {
+		return nil, wireError(codes.FailedPrecondition, &mpb.WireError{WireUid: q.LinkUid}, "pod %s is not running", q.Pod)
+	}
+	if nodeIP == os.Getenv("HOST_IP") {
+		return m.GetWireParams(ctx, q)
+	}
+	client, err := m.remoteClient(ctx, nodeIP, q.LinkUid)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetWireParams(ctx, q)
+}
+
+// compareWireParams returns the parameters on which the two ends of a wire disagree
+func compareWireParams(local, peer *mpb.WireParams) []*mpb.WireMismatch {
+	var result []*mpb.WireMismatch
+	check := func(field string, l, p interface{}) {
+		if ls, ps := fmt.Sprint(l), fmt.Sprint(p); ls != ps {
+			result = append(result, &mpb.WireMismatch{Field: field, Local: ls, Peer: ps})
+		}
+	}
+	check("link_uid", local.LinkUid, peer.LinkUid)
+	// each end's topology names the interface of the other end
+	check("peer_intf", local.PeerIntf, peer.IntfName)
+	check("intf_name", local.IntfName, peer.PeerIntf)
+	check("wire_type", local.WireType, peer.WireType)
+	check("vni", local.Vni, peer.Vni)
+	check("vxlan_gpe", local.VxlanGpe, peer.VxlanGpe)
+	check("mtu", local.Mtu, peer.Mtu)
+	if local.WireType == wireTypeVxlan {
+		check("remote_vtep", local.RemoteVtep, peer.NodeIp)
+		check("node_ip", local.NodeIp, peer.RemoteVtep)
+	}
+	return result
+}
+
+// verifyCreatedWire fails a wire that has just been set up if its two ends don't agree on
+// its parameters. Wires whose peer isn't running yet are set up, and verified, later.
+func (m *Meshnet) verifyCreatedWire(ctx context.Context, pod, ns string, uid int64) error {
+	local, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: ns})
+	if err != nil {
+		return err
+	}
+	link := linkByUID(local.Links, uid)
+	if link == nil || link.PeerPod == localhost {
+		return nil
+	}
+	if peer, err := m.Get(ctx, &mpb.PodQuery{Name: link.PeerPod, KubeNs: ns}); err != nil || peer.NetNs == "" {
+		return err
+	}
+	result, err := m.VerifyWire(ctx, &mpb.WireVerificationRequest{Pod: pod, KubeNs: ns, LinkUid: uid})
+	if err != nil {
+		return err
+	}
+	if len(result.Mismatches) == 0 {
+		return nil
+	}
+	mm := result.Mismatches[0]
+	m.recordEvent(ctx, ns, pod, mpb.TopologyEvent_FAIL, uid, nil, nil, "wire verification failed: %s is %q here and %q on the peer", mm.Field, mm.Local, mm.Peer)
+	return wireError(codes.FailedPrecondition, &mpb.WireError{Operation: mpb.WireError_ADD, WireUid: uid},
+		"the ends of wire %d disagree on %d parameters, e.g. %s is %q on pod %s and %q on pod %s",
+		uid, len(result.Mismatches), mm.Field, mm.Local, pod, mm.Peer, link.PeerPod)
+}
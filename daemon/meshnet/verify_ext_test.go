@@ -0,0 +1,43 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestVerifyWire(t *testing.T) {
+	ctx := context.Background()
+	m := meshnettest.NewFakeMeshnet(lab())
+	tests := []struct {
+		uid  int64
+		code codes.Code
+	}{
+		// r1 isn't running
+		{uid: 1, code: codes.FailedPrecondition},
+		{uid: 3, code: codes.NotFound},
+	}
+	for _, tt := range tests {
+		_, err := m.VerifyWire(ctx, &mpb.WireVerificationRequest{Pod: "r1", KubeNs: "default", LinkUid: tt.uid})
+		if status.Code(err) != tt.code {
+			t.Errorf("VerifyWire(%d) = %v, want %s", tt.uid, err, tt.code)
+		}
+	}
+
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	p.SrcIp, p.NetNs = "10.0.0.1", "/var/run/netns/r1"
+	if _, err := m.SetAlive(ctx, p); err != nil {
+		t.Fatalf("SetAlive() failed: %v", err)
+	}
+	if _, err := m.VerifyWire(ctx, &mpb.WireVerificationRequest{Pod: "r1", KubeNs: "default", LinkUid: 2}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("VerifyWire() of a macvlan link = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,37 @@
+package meshnet
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestCompareWireParams(t *testing.T) {
+	local := &mpb.WireParams{LinkUid: 1, NodeIp: "10.0.0.1", IntfName: "eth1", PeerIntf: "eth2", WireType: "vxlan", Vni: 5001, RemoteVtep: "10.0.0.2", Mtu: 1450}
+	peer := &mpb.WireParams{LinkUid: 1, NodeIp: "10.0.0.2", IntfName: "eth2", PeerIntf: "eth1", WireType: "vxlan", Vni: 5001, RemoteVtep: "10.0.0.1", Mtu: 1450}
+	if got := compareWireParams(local, peer); len(got) != 0 {
+		t.Errorf("compareWireParams() = %v, want no mismatches", got)
+	}
+
+	tests := []struct {
+		desc   string
+		change func(p *mpb.WireParams)
+		field  string
+	}{
+		{desc: "interface", change: func(p *mpb.WireParams) { p.IntfName = "eth3" }, field: "peer_intf"},
+		{desc: "VNI", change: func(p *mpb.WireParams) { p.Vni = 5002 }, field: "vni"},
+		{desc: "VTEP", change: func(p *mpb.WireParams) { p.RemoteVtep = "10.0.0.3" }, field: "node_ip"},
+		{desc: "MTU", change: func(p *mpb.WireParams) { p.Mtu = 1500 }, field: "mtu"},
+		{desc: "GPE", change: func(p *mpb.WireParams) { p.VxlanGpe = true }, field: "vxlan_gpe"},
+	}
+	for _, tt := range tests {
+		changed := proto.Clone(peer).(*mpb.WireParams)
+		tt.change(changed)
+		got := compareWireParams(local, changed)
+		if len(got) != 1 || got[0].Field != tt.field {
+			t.Errorf("%s: compareWireParams() = %v, want a mismatch of %s", tt.desc, got, tt.field)
+		}
+	}
+}
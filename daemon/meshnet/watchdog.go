@@ -0,0 +1,174 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const reasonWireRepaired = "WireRepaired"
+
+// localWire is a link of a pod running on this node
+type localWire struct {
+	ns, pod, netNs, intf string
+	uid                  int64
+}
+
+func (w localWire) key() string {
+	return fmt.Sprintf("%s/%s/%d", w.ns, w.pod, w.uid)
+}
+
+// localWires returns the links of the pods running on hostIP
+func localWires(topologies []topologyv1.Topology, hostIP string) []localWire {
+	var result []localWire
+	for _, t := range topologies {
+		if t.Status.SrcIp != hostIP || t.Status.NetNs == "" {
+			continue
+		}
+		for _, l := range t.Spec.Links {
+			result = append(result, localWire{ns: t.Namespace, pod: t.Name, netNs: t.Status.NetNs, intf: l.LocalIntf, uid: int64(l.UID)})
+		}
+	}
+	return result
+}
+
+// wireWatchdog keeps since when the interfaces of the local wires have been missing
+type wireWatchdog struct {
+	mu       sync.Mutex
+	missing  map[string]time.Time
+	restarts int64
+}
+
+func newWireWatchdog() *wireWatchdog {
+	return &wireWatchdog{missing: make(map[string]time.Time)}
+}
+
+// observe records whether the interface of the wire key is missing at now, and returns
+// true if it has been missing for longer than timeout
+func (d *wireWatchdog) observe(key string, missing bool, now time.Time, timeout time.Duration) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !missing {
+		delete(d.missing, key)
+		return false
+	}
+	since, ok := d.missing[key]
+	if !ok {
+		d.missing[key] = now
+		return false
+	}
+	return now.Sub(since) > timeout
+}
+
+// repaired forgets the wire key, which has just been re-created
+func (d *wireWatchdog) repaired(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.missing, key)
+	d.restarts++
+}
+
+// retain forgets the wires that aren't in wires anymore
+func (d *wireWatchdog) retain(wires []localWire) {
+	keys := make(map[string]bool, len(wires))
+	for _, w := range wires {
+		keys[w.key()] = true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key := range d.missing {
+		if !keys[key] {
+			delete(d.missing, key)
+		}
+	}
+}
+
+// total returns how many wires have been re-created
+func (d *wireWatchdog) total() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.restarts
+}
+
+// WireWatchdog periodically checks that the interfaces of the wires of the pods running
+// on this node still exist, and re-creates the wires whose interface has been missing for
+// longer than the watchdog timeout, e.g. after it has been deleted or renamed in the pod.
+func (m *Meshnet) WireWatchdog(stopCh <-chan struct{}) {
+	if m.config.WireWatchdogTimeout <= 0 {
+		<-stopCh
+		return
+	}
+	ticker := time.NewTicker(m.config.WireWatchdogTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			m.checkWires(context.Background())
+		}
+	}
+}
+
+func (m *Meshnet) checkWires(ctx context.Context) {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed to list topologies: %s", err)
+		return
+	}
+	wires := localWires(topologies.Items, os.Getenv("HOST_IP"))
+	m.watchdog.retain(wires)
+	for _, w := range wires {
+		missing := !linkExists(w.netNs, w.intf)
+		if !m.watchdog.observe(w.key(), missing, time.Now(), m.config.WireWatchdogTimeout) {
+			continue
+		}
+		log.Warnf("Interface %s of pod %s has been missing for more than %s, re-creating link %d", w.intf, w.pod, m.config.WireWatchdogTimeout, w.uid)
+		m.recordEvent(ctx, w.ns, w.pod, mpb.TopologyEvent_FAIL, w.uid, nil, nil, "interface %s is missing", w.intf)
+		err := m.repairWire(ctx, w)
+		m.recordWire(err)
+		if err != nil {
+			topologyEvent(m.dlq.recorder, w.ns, w.pod, corev1.EventTypeWarning, reasonWireNeedsIntervention,
+				"Failed to re-create link %d, whose interface %s is missing: %v", w.uid, w.intf, err)
+			continue
+		}
+		m.watchdog.repaired(w.key())
+		m.recordEvent(ctx, w.ns, w.pod, mpb.TopologyEvent_RECOVER, w.uid, nil, nil, "re-created by the wire watchdog")
+		topologyEvent(m.dlq.recorder, w.ns, w.pod, corev1.EventTypeNormal, reasonWireRepaired,
+			"Link %d has been re-created, its interface %s was missing", w.uid, w.intf)
+	}
+}
+
+func (m *Meshnet) repairWire(ctx context.Context, w localWire) error {
+	pod, err := m.Get(ctx, &mpb.PodQuery{Name: w.pod, KubeNs: w.ns})
+	if err != nil {
+		return err
+	}
+	link := linkByUID(pod.Links, w.uid)
+	if link == nil {
+		// the link has been removed in the meantime
+		return nil
+	}
+	return m.createWire(ctx, w.pod, w.ns, link)
+}
+
+// linkExists returns false only if the netNs network namespace exists and has no
+// interface called intf, so that the pods being torn down aren't repaired
+func linkExists(netNs, intf string) bool {
+	err := inNetNs(netNs, func() error {
+		_, err := netlink.LinkByName(intf)
+		return err
+	})
+	_, notFound := err.(netlink.LinkNotFoundError)
+	return !notFound
+}
@@ -0,0 +1,56 @@
+package meshnet
+
+import (
+	"testing"
+	"time"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func TestLocalWires(t *testing.T) {
+	topologies := []topologyv1.Topology{
+		runningTopology("r1", "10.0.0.1", "r2", "localhost"),
+		runningTopology("r2", "10.0.0.2", "r1"),
+		runningTopology("r3", "", "r1"),
+	}
+	got := localWires(topologies, "10.0.0.1")
+	if len(got) != 2 || got[0].key() != "default/r1/1" || got[1].key() != "default/r1/2" {
+		t.Errorf("localWires() = %v, want the 2 links of r1", got)
+	}
+}
+
+func TestWireWatchdog(t *testing.T) {
+	d := newWireWatchdog()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeout := time.Minute
+	tests := []struct {
+		key     string
+		missing bool
+		after   time.Duration
+		stuck   bool
+	}{
+		{"default/r1/1", false, 0, false},
+		{"default/r1/1", true, 0, false},
+		{"default/r1/1", true, 30 * time.Second, false},
+		{"default/r1/2", true, 90 * time.Second, false},
+		{"default/r1/1", true, 90 * time.Second, true},
+		// the interface is back, the timer starts over
+		{"default/r1/1", false, 100 * time.Second, false},
+		{"default/r1/1", true, 110 * time.Second, false},
+		{"default/r1/1", true, 150 * time.Second, false},
+	}
+	for i, tt := range tests {
+		if stuck := d.observe(tt.key, tt.missing, start.Add(tt.after), timeout); stuck != tt.stuck {
+			t.Errorf("#%d test failed: stuck = %v, want %v", i, stuck, tt.stuck)
+		}
+	}
+
+	d.repaired("default/r1/1")
+	if d.total() != 1 {
+		t.Errorf("total() = %d, want 1", d.total())
+	}
+	d.retain([]localWire{{ns: "default", pod: "r1", uid: 1}})
+	if _, ok := d.missing["default/r1/2"]; ok {
+		t.Errorf("retain() kept a removed wire")
+	}
+}
@@ -0,0 +1,149 @@
+package meshnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/wireguard"
+)
+
+const (
+	wgSecretPrefix   = "meshnet-wg-"
+	wgPublicKeyField = "public_key"
+	// namespace of the daemon's own objects when POD_NAMESPACE isn't set
+	defaultDaemonNamespace = "meshnet"
+	wgSyncInterval         = time.Minute
+)
+
+// wgSecretName returns the name of the Secret with the WireGuard public key of the node nodeIP
+func wgSecretName(nodeIP string) string {
+	return wgSecretPrefix + strings.ReplaceAll(nodeIP, ":", "-")
+}
+
+// daemonNamespace returns the namespace of the daemon's own objects, e.g. the key Secrets
+func daemonNamespace() string {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns
+	}
+	return defaultDaemonNamespace
+}
+
+// publishWGKey stores the public key of the node nodeIP in its Secret
+func (m *Meshnet) publishWGKey(ctx context.Context, ns, nodeIP string, key wireguard.Key) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secrets := m.kClient.CoreV1().Secrets(ns)
+		secret, err := secrets.Get(ctx, wgSecretName(nodeIP), metav1.GetOptions{})
+		create := apierrors.IsNotFound(err)
+		if create {
+			secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: wgSecretName(nodeIP)}}
+		} else if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[wgPublicKeyField] = []byte(key.String())
+		if create {
+			_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(corev1.Resource("secrets"), secret.Name, err)
+			}
+			return err
+		}
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// lookupWGKey reads the public key of the node nodeIP from its Secret
+func (m *Meshnet) lookupWGKey(ctx context.Context, ns, nodeIP string) (wireguard.Key, error) {
+	secret, err := m.kClient.CoreV1().Secrets(ns).Get(ctx, wgSecretName(nodeIP), metav1.GetOptions{})
+	if err != nil {
+		return wireguard.Key{}, err
+	}
+	return wireguard.ParseKey(string(secret.Data[wgPublicKeyField]))
+}
+
+// fillWGPublicKey gives WireGuard links without a public key the one of their peer's node,
+// unless they have keys of their own
+func (m *Meshnet) fillWGPublicKey(ctx context.Context, pod *mpb.RemotePod) error {
+	if pod.TunnelType != mpb.TunnelType_WIREGUARD || pod.Credentials != nil {
+		return nil
+	}
+	if !m.config.WireGuard {
+		return fmt.Errorf("WireGuard links are disabled, restart meshnetd with -wireguard")
+	}
+	if pod.WgPublicKey != "" {
+		return nil
+	}
+	key, err := m.lookupWGKey(ctx, daemonNamespace(), pod.PeerVtep)
+	if err != nil {
+		return fmt.Errorf("failed to look up the WireGuard key of node %s: %v", pod.PeerVtep, err)
+	}
+	pod.WgPublicKey = key.String()
+	return nil
+}
+
+// rotateWGKey generates a new key pair for the node and publishes its public key
+func (m *Meshnet) rotateWGKey(ctx context.Context, ns, nodeIP string) error {
+	key, err := wireguard.GenerateKey()
+	if err != nil {
+		return err
+	}
+	if err := wireguard.SetKey(key); err != nil {
+		log.Warnf("Failed to re-key some WireGuard links: %v", err)
+	}
+	return m.publishWGKey(ctx, ns, nodeIP, key.PublicKey())
+}
+
+// WireGuard generates the key pair of the node, re-generates it every WGKeyRotation and
+// updates the peers of the WireGuard links whose peer node has a new key, until stopCh is closed.
+func (m *Meshnet) WireGuard(stopCh <-chan struct{}) {
+	if !m.config.WireGuard {
+		<-stopCh
+		return
+	}
+	nodeIP, ns := os.Getenv("HOST_IP"), daemonNamespace()
+	if nodeIP == "" {
+		log.Warnf("HOST_IP must be set to publish the WireGuard key of the node")
+		<-stopCh
+		return
+	}
+	if err := m.rotateWGKey(context.Background(), ns, nodeIP); err != nil {
+		log.Errorf("Failed to set up the WireGuard key of the node: %v", err)
+	}
+
+	sync := time.NewTicker(wgSyncInterval)
+	defer sync.Stop()
+	var rotate <-chan time.Time
+	if m.config.WGKeyRotation > 0 {
+		ticker := time.NewTicker(m.config.WGKeyRotation)
+		defer ticker.Stop()
+		rotate = ticker.C
+	}
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-rotate:
+			log.Infof("Rotating the WireGuard key of the node")
+			if err := m.rotateWGKey(context.Background(), ns, nodeIP); err != nil {
+				log.Errorf("Failed to rotate the WireGuard key of the node: %v", err)
+			}
+		case <-sync.C:
+			wireguard.SyncPeers(func(peer string) (wireguard.Key, error) {
+				return m.lookupWGKey(context.Background(), ns, peer)
+			})
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package meshnet_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/wireguard"
+)
+
+func TestSecureLink(t *testing.T) {
+	ctx := context.Background()
+	keys := map[string]string{}
+	for _, name := range []string{"r1", "r2", "psk"} {
+		k, err := wireguard.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[name] = k.String()
+	}
+	secret := func(name string, data map[string]string) *corev1.Secret {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Data: map[string][]byte{}}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+	kClient := fake.NewSimpleClientset(
+		secret("r1-keys", map[string]string{"private_key": keys["r1"], "peer_public_key": keys["r2"], "preshared_key": keys["psk"]}),
+		secret("r2-keys", map[string]string{"private_key": keys["r2"], "peer_public_key": keys["r1"], "preshared_key": keys["psk"]}),
+		secret("bad-keys", map[string]string{"private_key": "not-a-key-" + keys["r1"], "peer_public_key": keys["r2"]}),
+	)
+	// secure gives the only link of topology the Secret name
+	secure := func(topology unstructured.Unstructured, name string) unstructured.Unstructured {
+		links, _, _ := unstructured.NestedSlice(topology.Object, "spec", "links")
+		links[0].(map[string]interface{})["secure_link"] = map[string]interface{}{"secret_ref": map[string]interface{}{"name": name}}
+		if err := unstructured.SetNestedSlice(topology.Object, links, "spec", "links"); err != nil {
+			t.Fatal(err)
+		}
+		return topology
+	}
+	topologies := []unstructured.Unstructured{
+		secure(meshnettest.Topology("default", "r1", []string{"r2"}, []int64{1}), "r1-keys"),
+		secure(meshnettest.Topology("default", "r2", []string{"r1"}, []int64{1}), "r2-keys"),
+		secure(meshnettest.Topology("default", "r3", []string{"r1"}, []int64{2}), "bad-keys"),
+		secure(meshnettest.Topology("default", "r4", []string{"r1"}, []int64{3}), "missing-keys"),
+	}
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true}, kClient, meshnettest.NewTopologies(topologies...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	logger := log.StandardLogger()
+	out, level := logger.Out, logger.Level
+	logger.SetOutput(&logs)
+	logger.SetLevel(log.DebugLevel)
+	defer func() {
+		logger.SetOutput(out)
+		logger.SetLevel(level)
+	}()
+
+	// the keys are never returned, only the name of their Secret
+	p, err := m.Get(ctx, &mpb.PodQuery{Name: "r1", KubeNs: "default"})
+	if err != nil {
+		t.Fatalf("Get(r1) failed: %v", err)
+	}
+	if p.Links[0].Secret != "r1-keys" {
+		t.Errorf("Get(r1) link secret = %q, want r1-keys", p.Links[0].Secret)
+	}
+	for name, key := range keys {
+		if strings.Contains(p.String(), key) {
+			t.Errorf("Get(r1) returned key %s", name)
+		}
+	}
+
+	// the netns doesn't exist, so the updates fail once the keys are passed to WireGuard
+	tests := []struct {
+		pod  string
+		uid  int64
+		want string
+	}{
+		{pod: "r2", uid: 1, want: keys["r2"]},
+		// the keys are checked before they're used
+		{pod: "r3", uid: 2},
+		{pod: "r4", uid: 3},
+	}
+	for _, tt := range tests {
+		remote := &mpb.RemotePod{
+			NetNs:      "/var/run/netns/" + tt.pod,
+			IntfName:   "eth1",
+			PeerVtep:   "192.0.2.1",
+			Vni:        5000 + tt.uid,
+			KubeNs:     "default",
+			PodName:    tt.pod,
+			TunnelType: mpb.TunnelType_WIREGUARD,
+		}
+		if resp, err := m.Update(ctx, remote); err != nil || resp.Response {
+			t.Errorf("Update(%s) = %v, %v, want false", tt.pod, resp, err)
+		}
+		if got := remote.Credentials.GetPrivateKey(); got != tt.want {
+			t.Errorf("Update(%s) passed the wrong private key to WireGuard", tt.pod)
+		}
+	}
+
+	if logs.Len() == 0 {
+		t.Fatalf("nothing was logged")
+	}
+	for name, key := range keys {
+		if strings.Contains(logs.String(), key) {
+			t.Errorf("key %s has been logged", name)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package meshnet
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/wireguard"
+)
+
+func TestWGSecretName(t *testing.T) {
+	tests := []struct {
+		nodeIP, want string
+	}{
+		{nodeIP: "192.0.2.1", want: "meshnet-wg-192.0.2.1"},
+		{nodeIP: "2001:db8::1", want: "meshnet-wg-2001-db8--1"},
+	}
+	for i, tt := range tests {
+		if got := wgSecretName(tt.nodeIP); got != tt.want {
+			t.Errorf("#%d test failed: wgSecretName(%s) = %s, want %s", i, tt.nodeIP, got, tt.want)
+		}
+	}
+}
+
+func TestWGPublicKey(t *testing.T) {
+	ctx := context.Background()
+	m := &Meshnet{kClient: fake.NewSimpleClientset(), config: Config{WireGuard: true}}
+	t.Setenv("POD_NAMESPACE", "meshnet")
+
+	pod := &mpb.RemotePod{TunnelType: mpb.TunnelType_WIREGUARD, PeerVtep: "192.0.2.1"}
+	if err := m.fillWGPublicKey(ctx, pod); err == nil {
+		t.Errorf("fillWGPublicKey() of an unpublished key didn't fail")
+	}
+
+	// the Secret is created, then updated when the key is rotated
+	for i := 0; i < 2; i++ {
+		priv, err := wireguard.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.publishWGKey(ctx, "meshnet", "192.0.2.1", priv.PublicKey()); err != nil {
+			t.Fatalf("publishWGKey() failed: %v", err)
+		}
+		pod.WgPublicKey = ""
+		if err := m.fillWGPublicKey(ctx, pod); err != nil || pod.WgPublicKey != priv.PublicKey().String() {
+			t.Errorf("#%d fillWGPublicKey() = %v, key %s, want %s", i, err, pod.WgPublicKey, priv.PublicKey())
+		}
+	}
+
+	// other tunnels are left alone, WireGuard ones fail when it's disabled
+	vxlan := &mpb.RemotePod{PeerVtep: "192.0.2.1"}
+	if err := m.fillWGPublicKey(ctx, vxlan); err != nil || vxlan.WgPublicKey != "" {
+		t.Errorf("fillWGPublicKey() of a vxlan = %v, key %q", err, vxlan.WgPublicKey)
+	}
+	m.config.WireGuard = false
+	if err := m.fillWGPublicKey(ctx, pod); err == nil {
+		t.Errorf("fillWGPublicKey() with WireGuard disabled didn't fail")
+	}
+}
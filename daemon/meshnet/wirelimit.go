@@ -0,0 +1,148 @@
+package meshnet
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// wireTable counts the wires of the pods running on this node, so that their number can
+// be limited. A pod's wires are reserved when it comes up, before they're set up.
+type wireTable struct {
+	mu    sync.Mutex
+	limit int
+	// wire UIDs keyed by namespace and pod
+	pods  map[string]map[int64]bool
+	count int
+}
+
+func newWireTable(limit int) *wireTable {
+	return &wireTable{limit: limit, pods: make(map[string]map[int64]bool)}
+}
+
+// reserve adds the wires uids of pod, or none of them if that would exceed the limit
+func (t *wireTable) reserve(ns, pod string, uids []int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := ns + "/" + pod
+	var added []int64
+	for _, uid := range uids {
+		if !t.pods[key][uid] {
+			added = append(added, uid)
+		}
+	}
+	if t.limit > 0 && t.count+len(added) > t.limit {
+		return wireError(codes.ResourceExhausted, &mpb.WireError{Operation: mpb.WireError_ADD},
+			"pod %s needs %d more wires, %d of the %d wires allowed on this node are in use", pod, len(added), t.count, t.limit)
+	}
+	if t.pods[key] == nil {
+		t.pods[key] = make(map[int64]bool)
+	}
+	for _, uid := range added {
+		t.pods[key][uid] = true
+	}
+	t.count += len(added)
+	return nil
+}
+
+// seed adds the wire uid of pod regardless of the limit
+func (t *wireTable) seed(ns, pod string, uid int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := ns + "/" + pod
+	if t.pods[key][uid] {
+		return
+	}
+	if t.pods[key] == nil {
+		t.pods[key] = make(map[int64]bool)
+	}
+	t.pods[key][uid] = true
+	t.count++
+}
+
+// release removes the wire uid of pod
+func (t *wireTable) release(ns, pod string, uid int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := ns + "/" + pod
+	if t.pods[key][uid] {
+		delete(t.pods[key], uid)
+		t.count--
+	}
+	if len(t.pods[key]) == 0 {
+		delete(t.pods, key)
+	}
+}
+
+// forget removes all the wires of pod
+func (t *wireTable) forget(ns, pod string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.count -= len(t.pods[ns+"/"+pod])
+	delete(t.pods, ns+"/"+pod)
+}
+
+// active returns the number of wires in the table
+func (t *wireTable) active() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.count
+}
+
+// snapshot returns a copy of the wire UIDs in the table, keyed by namespace and pod
+func (t *wireTable) snapshot() map[string]map[int64]bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make(map[string]map[int64]bool, len(t.pods))
+	for key, uids := range t.pods {
+		result[key] = make(map[int64]bool, len(uids))
+		for uid := range uids {
+			result[key][uid] = true
+		}
+	}
+	return result
+}
+
+// reserveWires reserves the wires of a pod coming up on this node, or releases them if the
+// pod is going down
+func (m *Meshnet) reserveWires(pod *mpb.Pod) error {
+	if pod.NetNs == "" {
+		m.wires.forget(pod.KubeNs, pod.Name)
+		return nil
+	}
+	uids := make([]int64, 0, len(pod.Links))
+	for _, l := range pod.Links {
+		uids = append(uids, l.Uid)
+	}
+	return m.wires.reserve(pod.KubeNs, pod.Name, uids)
+}
+
+// releaseWires undoes reserveWires after the pod failed to come up
+func (m *Meshnet) releaseWires(pod *mpb.Pod) {
+	if pod.NetNs != "" {
+		m.wires.forget(pod.KubeNs, pod.Name)
+	}
+}
+
+// CountWires adds the wires of the pods already running on this node to the wire table,
+// e.g. after a restart of the daemon, regardless of the limit
+func (m *Meshnet) CountWires(ctx context.Context) error {
+	topologies, err := m.tClient.Topology("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	wires := localWires(topologies.Items, os.Getenv("HOST_IP"))
+	for _, w := range wires {
+		m.wires.seed(w.ns, w.pod, w.uid)
+	}
+	if limit := m.wires.limit; limit > 0 && len(wires) > limit {
+		log.Warnf("%d wires are running on this node, more than the %d allowed", len(wires), limit)
+	}
+	return nil
+}
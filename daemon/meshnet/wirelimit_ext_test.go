@@ -0,0 +1,59 @@
+package meshnet_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/networkop/meshnet-cni/daemon/meshnet"
+	"github.com/networkop/meshnet-cni/daemon/meshnet/meshnettest"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestMaxActiveWires(t *testing.T) {
+	ctx := context.Background()
+	topologies := meshnettest.NewTopologies(lab()...)
+	m, err := meshnet.NewWithClients(meshnet.Config{DisableReflection: true, MaxActiveWires: 2}, fake.NewSimpleClientset(), topologies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alive := func(pod, netNs string) error {
+		p, err := m.Get(ctx, &mpb.PodQuery{Name: pod, KubeNs: "default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.SrcIp, p.NetNs = "10.0.0.1", netNs
+		_, err = m.SetAlive(ctx, p)
+		return err
+	}
+
+	if err := alive("r1", "/var/run/netns/r1"); err != nil {
+		t.Fatalf("SetAlive(r1) = %v", err)
+	}
+	if err := alive("r2", "/var/run/netns/r2"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("SetAlive(r2) over the limit = %v, want %s", err, codes.ResourceExhausted)
+	}
+	r2 := topologies.Object("default", "r2")
+	if netNs, _, _ := unstructured.NestedString(r2.Object, "status", "net_ns"); netNs != "" {
+		t.Errorf("net_ns of r2 = %q, want it not to be alive", netNs)
+	}
+
+	// the wires of r1 are released once it's down
+	if err := alive("r1", ""); err != nil {
+		t.Fatalf("SetAlive(r1) down = %v", err)
+	}
+	if err := alive("r2", "/var/run/netns/r2"); err != nil {
+		t.Errorf("SetAlive(r2) = %v", err)
+	}
+	health, err := m.HealthCheck(ctx, &mpb.HealthRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.ActiveWiresLimit != 2 || health.ActiveWiresCurrent != 1 {
+		t.Errorf("active wires = %d/%d, want 1/2", health.ActiveWiresCurrent, health.ActiveWiresLimit)
+	}
+}
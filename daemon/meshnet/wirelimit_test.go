@@ -0,0 +1,67 @@
+package meshnet
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWireTable(t *testing.T) {
+	w := newWireTable(3)
+	tests := []struct {
+		op     string
+		pod    string
+		uids   []int64
+		code   codes.Code
+		active int
+	}{
+		{"reserve", "r1", []int64{1, 2}, codes.OK, 2},
+		// reserving the same wires again is a no-op
+		{"reserve", "r1", []int64{1, 2}, codes.OK, 2},
+		// all or none of the wires of a pod are reserved
+		{"reserve", "r2", []int64{1, 3}, codes.ResourceExhausted, 2},
+		{"reserve", "r2", []int64{1}, codes.OK, 3},
+		{"reserve", "r3", []int64{4}, codes.ResourceExhausted, 3},
+		{"release", "r1", []int64{2}, codes.OK, 2},
+		{"reserve", "r3", []int64{4}, codes.OK, 3},
+		{"forget", "r1", nil, codes.OK, 2},
+		{"forget", "r1", nil, codes.OK, 2},
+	}
+	for i, tt := range tests {
+		var err error
+		switch tt.op {
+		case "reserve":
+			err = w.reserve("default", tt.pod, tt.uids)
+		case "release":
+			w.release("default", tt.pod, tt.uids[0])
+		case "forget":
+			w.forget("default", tt.pod)
+		}
+		if status.Code(err) != tt.code {
+			t.Errorf("#%d test failed: %s(%s, %v) = %v, want %s", i, tt.op, tt.pod, tt.uids, err, tt.code)
+		}
+		if w.active() != tt.active {
+			t.Errorf("#%d test failed: active() = %d, want %d", i, w.active(), tt.active)
+		}
+	}
+
+	// wires already running are counted regardless of the limit
+	w.seed("default", "r4", 1)
+	w.seed("default", "r4", 1)
+	if w.active() != 3 {
+		t.Errorf("active() = %d after seed, want 3", w.active())
+	}
+}
+
+func TestWireTableUnlimited(t *testing.T) {
+	w := newWireTable(0)
+	for uid := int64(1); uid <= 100; uid++ {
+		if err := w.reserve("default", "r1", []int64{uid}); err != nil {
+			t.Fatalf("reserve(%d) = %v, want no limit", uid, err)
+		}
+	}
+	if w.active() != 100 {
+		t.Errorf("active() = %d, want 100", w.active())
+	}
+}
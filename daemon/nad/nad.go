@@ -0,0 +1,115 @@
+// Package nad generates the Multus NetworkAttachmentDefinitions of the interfaces of
+// topology pods.
+package nad
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const (
+	// NetworksAnnotation lists the NetworkAttachmentDefinitions attached to a pod by Multus
+	NetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
+	// ManagedByLabel marks the NetworkAttachmentDefinitions generated by meshnet
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+
+	namePrefix = "meshnet-"
+	cniVersion = "0.3.1"
+)
+
+// Resource is the resource of the NetworkAttachmentDefinitions
+var Resource = schema.GroupVersionResource{
+	Group:    "k8s.cni.cncf.io",
+	Version:  "v1",
+	Resource: "network-attachment-definitions",
+}
+
+// NetworkAttachmentDefinition is a Multus NetworkAttachmentDefinition
+type NetworkAttachmentDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              Spec `json:"spec"`
+}
+
+// Spec holds the CNI configuration of a NetworkAttachmentDefinition
+type Spec struct {
+	// JSON-encoded CNI configuration
+	Config string `json:"config"`
+}
+
+// Name returns the name of the NetworkAttachmentDefinition of the interface intf
+func Name(intf string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, intf)
+	return namePrefix + strings.Trim(name, "-.")
+}
+
+// Generate returns a NetworkAttachmentDefinition for each interface name used by the
+// topologies in ns, sorted by name, and the Multus networks annotation of each pod. The
+// result only depends on the links, so applying it again doesn't change anything.
+func Generate(ns string, topologies []topologyv1.Topology) ([]NetworkAttachmentDefinition, map[string]string) {
+	intfs := make(map[string]bool)
+	annotations := make(map[string]string)
+	for _, t := range topologies {
+		var networks []string
+		seen := make(map[string]bool)
+		for _, l := range t.Spec.Links {
+			if l.LocalIntf == "" || seen[l.LocalIntf] {
+				continue
+			}
+			seen[l.LocalIntf] = true
+			intfs[l.LocalIntf] = true
+			networks = append(networks, fmt.Sprintf("%s/%s@%s", ns, Name(l.LocalIntf), l.LocalIntf))
+		}
+		if len(networks) > 0 {
+			sort.Strings(networks)
+			annotations[t.Name] = strings.Join(networks, ",")
+		}
+	}
+
+	byName := make(map[string]NetworkAttachmentDefinition, len(intfs))
+	for intf := range intfs {
+		name := Name(intf)
+		config, _ := json.Marshal(map[string]string{
+			"cniVersion": cniVersion,
+			"name":       name,
+			"type":       "meshnet",
+		})
+		byName[name] = NetworkAttachmentDefinition{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: Resource.GroupVersion().String(),
+				Kind:       "NetworkAttachmentDefinition",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{ManagedByLabel: "meshnet"},
+			},
+			Spec: Spec{Config: string(config)},
+		}
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	nads := make([]NetworkAttachmentDefinition, 0, len(names))
+	for _, name := range names {
+		nads = append(nads, byName[name])
+	}
+	return nads, annotations
+}
@@ -0,0 +1,72 @@
+package nad
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func topology(name string, intfs ...string) topologyv1.Topology {
+	t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, i := range intfs {
+		t.Spec.Links = append(t.Spec.Links, topologyv1.Link{LocalIntf: i})
+	}
+	return t
+}
+
+func TestName(t *testing.T) {
+	tests := map[string]string{
+		"eth1":        "meshnet-eth1",
+		"Ethernet1/1": "meshnet-ethernet1-1",
+		"ge-0/0/0.0":  "meshnet-ge-0-0-0.0",
+		"e1_":         "meshnet-e1",
+	}
+	for intf, want := range tests {
+		if got := Name(intf); got != want {
+			t.Errorf("Name(%q) = %q, want %q", intf, got, want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	topologies := []topologyv1.Topology{
+		topology("r2", "eth1", "eth2"),
+		topology("r1", "eth2", "eth1", "eth1"),
+		topology("r3"),
+	}
+	wantNames := []string{"meshnet-eth1", "meshnet-eth2"}
+	wantAnnotations := map[string]string{
+		"r1": "lab/meshnet-eth1@eth1,lab/meshnet-eth2@eth2",
+		"r2": "lab/meshnet-eth1@eth1,lab/meshnet-eth2@eth2",
+	}
+
+	nads, annotations := Generate("lab", topologies)
+	var names []string
+	for _, n := range nads {
+		names = append(names, n.Name)
+		if n.Namespace != "lab" {
+			t.Errorf("%s: namespace = %q, want lab", n.Name, n.Namespace)
+		}
+		var config map[string]string
+		if err := json.Unmarshal([]byte(n.Spec.Config), &config); err != nil {
+			t.Fatalf("%s: invalid config: %v", n.Name, err)
+		}
+		if config["type"] != "meshnet" || config["name"] != n.Name {
+			t.Errorf("%s: config = %v", n.Name, config)
+		}
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("Generate() names = %v, want %v", names, wantNames)
+	}
+	if !reflect.DeepEqual(annotations, wantAnnotations) {
+		t.Errorf("Generate() annotations = %v, want %v", annotations, wantAnnotations)
+	}
+
+	if again, _ := Generate("lab", topologies); !reflect.DeepEqual(again, nads) {
+		t.Errorf("Generate() is not deterministic")
+	}
+}
@@ -0,0 +1,238 @@
+// Package ovs connects pods to an Open vSwitch bridge instead of wiring them directly with
+// veth pairs. Every link end is a veth whose host end is an access port of the bridge, tagged
+// with the link UID, so that the two ends of a link are switched together and can be matched
+// by OpenFlow rules on their port numbers.
+package ovs
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+)
+
+// maxTag is the highest VLAN ID an access port can be tagged with
+const maxTag = 4094
+
+// PortName returns the name of the bridge port of the interface intfName of the netns nsName
+func PortName(nsName, intfName string) string {
+	h := fnv.New32a()
+	h.Write([]byte(nsName + "/" + intfName))
+	return fmt.Sprintf("mn%08x", h.Sum32())
+}
+
+// Tag returns the VLAN ID of the ports of the link uid
+func Tag(uid int64) (uint16, error) {
+	if uid < 1 || uid > maxTag {
+		return 0, fmt.Errorf("link UID %d can't be used as a VLAN tag, OVS links need UIDs between 1 and %d", uid, maxTag)
+	}
+	return uint16(uid), nil
+}
+
+// AddPort adds the interface portName to bridge as a trunk port, requesting the OpenFlow
+// port number ofPort unless it's 0.
+func AddPort(bridge, portName string, ofPort uint32) error {
+	return addPort(bridge, portName, ofPort, 0)
+}
+
+// AddAccessPort adds the interface portName to bridge as an access port of the VLAN tag
+func AddAccessPort(bridge, portName string, ofPort uint32, tag uint16) error {
+	return addPort(bridge, portName, ofPort, tag)
+}
+
+func addPort(bridge, portName string, ofPort uint32, tag uint16) error {
+	exists, err := portExists(portName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	iface := map[string]interface{}{"name": portName}
+	if ofPort > 0 {
+		iface["ofport_request"] = ofPort
+	}
+	port := map[string]interface{}{
+		"name":       portName,
+		"interfaces": []interface{}{"named-uuid", "iface"},
+	}
+	if tag > 0 {
+		port["tag"] = tag
+	}
+	results, err := transact(
+		op{"op": "insert", "table": "Interface", "row": iface, "uuid-name": "iface"},
+		op{"op": "insert", "table": "Port", "row": port, "uuid-name": "port"},
+		op{
+			"op":        "mutate",
+			"table":     "Bridge",
+			"where":     where(bridge),
+			"mutations": []interface{}{[]interface{}{"ports", "insert", set([]interface{}{"named-uuid", "port"})}},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add port %s to bridge %s: %s", portName, bridge, err)
+	}
+	// the inserted rows aren't referenced without the bridge and are garbage collected
+	if results[2].Count == 0 {
+		return fmt.Errorf("failed to add port %s: bridge %s doesn't exist", portName, bridge)
+	}
+	return nil
+}
+
+// DeletePort removes the port portName from bridge, along with its interface. Ports that
+// don't exist are ignored.
+func DeletePort(bridge, portName string) error {
+	uuid, err := portUUID(portName)
+	if err != nil {
+		return err
+	}
+	if uuid == nil {
+		return nil
+	}
+	_, err = transact(op{
+		"op":        "mutate",
+		"table":     "Bridge",
+		"where":     where(bridge),
+		"mutations": []interface{}{[]interface{}{"ports", "delete", set(uuid)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete port %s from bridge %s: %s", portName, bridge, err)
+	}
+	return nil
+}
+
+// OFPort returns the OpenFlow port number of the interface portName, or 0 if it has none yet
+func OFPort(portName string) (int64, error) {
+	results, err := transact(op{"op": "select", "table": "Interface", "where": where(portName), "columns": []string{"ofport"}})
+	if err != nil {
+		return 0, err
+	}
+	if len(results[0].Rows) == 0 {
+		return 0, fmt.Errorf("OVS interface %s doesn't exist", portName)
+	}
+	// an unassigned port number is an empty set
+	if n, ok := results[0].Rows[0]["ofport"].(float64); ok && n > 0 {
+		return int64(n), nil
+	}
+	return 0, nil
+}
+
+func portExists(portName string) (bool, error) {
+	uuid, err := portUUID(portName)
+	return uuid != nil, err
+}
+
+// portUUID returns the UUID of the port portName, as an OVSDB atom, or nil if it doesn't exist
+func portUUID(portName string) ([]interface{}, error) {
+	results, err := transact(op{"op": "select", "table": "Port", "where": where(portName), "columns": []string{"_uuid"}})
+	if err != nil {
+		return nil, err
+	}
+	if len(results[0].Rows) == 0 {
+		return nil, nil
+	}
+	uuid, ok := results[0].Rows[0]["_uuid"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected UUID of port %s: %v", portName, results[0].Rows[0]["_uuid"])
+	}
+	return uuid, nil
+}
+
+// set returns the OVSDB set of the atoms
+func set(atoms ...interface{}) []interface{} {
+	return []interface{}{"set", atoms}
+}
+
+// Attach creates the interface intfName of the netns nsName, with ip if it's set, as a veth
+// whose other end is an access port of bridge for the link uid. It returns the port's name.
+func Attach(nsName, intfName, ip, bridge string, uid int64) (string, error) {
+	tag, err := Tag(uid)
+	if err != nil {
+		return "", err
+	}
+	var ipNet *net.IPNet
+	if ip != "" {
+		ipAddr, subnet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse CIDR %s: %s", ip, err)
+		}
+		ipNet = &net.IPNet{IP: ipAddr, Mask: subnet.Mask}
+	}
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return "", fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	portName := PortName(nsName, intfName)
+	if _, err := netlink.LinkByName(portName); err == nil {
+		// the port is left from an earlier attempt, the veth is re-created
+		if err := deleteVeth(portName); err != nil {
+			return "", err
+		}
+	}
+	// the pod end is created with a temporary name, which may clash with other interfaces of the host
+	tmpName := "t" + portName
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: portName}, PeerName: tmpName}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return "", fmt.Errorf("failed to create veth %s: %s", portName, err)
+	}
+	podEnd, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return "", err
+	}
+	if err := netlink.LinkSetNsFd(podEnd, int(netNs.Fd())); err != nil {
+		netlink.LinkDel(veth)
+		return "", fmt.Errorf("failed to move %s to netns %s: %s", tmpName, nsName, err)
+	}
+	err = netNs.Do(func(_ ns.NetNS) error {
+		podEnd, err := netlink.LinkByName(tmpName)
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetName(podEnd, intfName); err != nil {
+			return fmt.Errorf("failed to rename %s to %s: %s", tmpName, intfName, err)
+		}
+		if ipNet != nil {
+			if err := netlink.AddrReplace(podEnd, &netlink.Addr{IPNet: ipNet}); err != nil {
+				return fmt.Errorf("failed to set IP %s on %s: %s", ip, intfName, err)
+			}
+		}
+		return netlink.LinkSetUp(podEnd)
+	})
+	if err != nil {
+		return "", err
+	}
+	hostEnd, err := netlink.LinkByName(portName)
+	if err != nil {
+		return "", err
+	}
+	if err := netlink.LinkSetUp(hostEnd); err != nil {
+		return "", err
+	}
+	if err := AddAccessPort(bridge, portName, 0, tag); err != nil {
+		return "", err
+	}
+	return portName, nil
+}
+
+// Detach removes the bridge port of the interface intfName of the netns nsName and the veth
+// behind it, if it still exists
+func Detach(nsName, intfName, bridge string) error {
+	portName := PortName(nsName, intfName)
+	if err := DeletePort(bridge, portName); err != nil {
+		return err
+	}
+	return deleteVeth(portName)
+}
+
+func deleteVeth(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		// the veth goes away with the pod's netns
+		return nil
+	}
+	return netlink.LinkDel(link)
+}
@@ -0,0 +1,218 @@
+package ovs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPortName(t *testing.T) {
+	a := PortName("/var/run/netns/r1", "eth1")
+	tests := []struct {
+		ns, intf string
+		same     bool
+	}{
+		{"/var/run/netns/r1", "eth1", true},
+		{"/var/run/netns/r1", "eth2", false},
+		{"/var/run/netns/r2", "eth1", false},
+	}
+	for _, tt := range tests {
+		b := PortName(tt.ns, tt.intf)
+		if len(b) > 15 {
+			t.Errorf("PortName(%s, %s) = %s, longer than an interface name", tt.ns, tt.intf, b)
+		}
+		if (a == b) != tt.same {
+			t.Errorf("PortName(%s, %s) = %s, want same as %s: %v", tt.ns, tt.intf, b, a, tt.same)
+		}
+	}
+}
+
+func TestTag(t *testing.T) {
+	tests := []struct {
+		uid     int64
+		want    uint16
+		wantErr bool
+	}{
+		{uid: 1, want: 1},
+		{uid: 4094, want: 4094},
+		{uid: 0, wantErr: true},
+		{uid: 4095, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Tag(tt.uid)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Tag(%d) error = %v, wantErr %v", tt.uid, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("Tag(%d) = %d, want %d", tt.uid, got, tt.want)
+		}
+	}
+}
+
+// fakeDB is an OVSDB server with just enough of the Open_vSwitch schema for this package
+type fakeDB struct {
+	mu      sync.Mutex
+	bridges map[string][]string
+	ports   map[string]map[string]interface{}
+	ifaces  map[string]map[string]interface{}
+	nextID  int
+}
+
+func newFakeDB(t *testing.T, bridges ...string) *fakeDB {
+	db := &fakeDB{
+		bridges: make(map[string][]string),
+		ports:   make(map[string]map[string]interface{}),
+		ifaces:  make(map[string]map[string]interface{}),
+	}
+	for _, b := range bridges {
+		db.bridges[b] = nil
+	}
+	sock := filepath.Join(t.TempDir(), "db.sock")
+	lis, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", sock, err)
+	}
+	old := Socket
+	Socket = sock
+	t.Cleanup(func() {
+		Socket = old
+		lis.Close()
+	})
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go db.serve(conn)
+		}
+	}()
+	return db
+}
+
+func (db *fakeDB) serve(conn net.Conn) {
+	defer conn.Close()
+	var req struct {
+		Params []json.RawMessage `json:"params"`
+		ID     interface{}       `json:"id"`
+	}
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+	db.mu.Lock()
+	var results []interface{}
+	for _, raw := range req.Params[1:] {
+		var o struct {
+			Op        string                 `json:"op"`
+			Table     string                 `json:"table"`
+			Where     [][]interface{}        `json:"where"`
+			Row       map[string]interface{} `json:"row"`
+			Mutations [][]interface{}        `json:"mutations"`
+		}
+		json.Unmarshal(raw, &o)
+		results = append(results, db.apply(o.Op, o.Table, o.Where, o.Row, o.Mutations))
+	}
+	db.mu.Unlock()
+	json.NewEncoder(conn).Encode(map[string]interface{}{"result": results, "error": nil, "id": req.ID})
+}
+
+func (db *fakeDB) apply(op, table string, cond [][]interface{}, row map[string]interface{}, mutations [][]interface{}) map[string]interface{} {
+	name := ""
+	if len(cond) > 0 {
+		name, _ = cond[0][2].(string)
+	}
+	rows := db.ports
+	if table == "Interface" {
+		rows = db.ifaces
+	}
+	switch op {
+	case "select":
+		if r, ok := rows[name]; ok {
+			return map[string]interface{}{"rows": []interface{}{r}}
+		}
+		return map[string]interface{}{"rows": []interface{}{}}
+	case "insert":
+		db.nextID++
+		row["_uuid"] = []interface{}{"uuid", fmt.Sprintf("uuid-%d", db.nextID)}
+		if table == "Interface" {
+			row["ofport"] = float64(db.nextID)
+		}
+		rows[row["name"].(string)] = row
+		return map[string]interface{}{"uuid": row["_uuid"]}
+	case "mutate":
+		ports, ok := db.bridges[name]
+		if !ok {
+			return map[string]interface{}{"count": 0}
+		}
+		switch mutations[0][1] {
+		case "insert":
+			// the port inserted in the same transaction has the highest UUID
+			ports = append(ports, fmt.Sprintf("uuid-%d", db.nextID))
+		case "delete":
+			// ["set", [["uuid", id]]]
+			uuid := mutations[0][2].([]interface{})[1].([]interface{})[0]
+			for port, r := range db.ports {
+				if fmt.Sprint(r["_uuid"]) == fmt.Sprint(uuid) {
+					delete(db.ports, port)
+					delete(db.ifaces, port)
+				}
+			}
+		}
+		db.bridges[name] = ports
+		return map[string]interface{}{"count": 1}
+	}
+	return map[string]interface{}{"error": "not supported", "details": op}
+}
+
+func TestAddDeletePort(t *testing.T) {
+	db := newFakeDB(t, "br-meshnet")
+
+	if err := AddAccessPort("br-meshnet", "mn0001", 0, 10); err != nil {
+		t.Fatalf("AddAccessPort() failed: %v", err)
+	}
+	if got := db.ports["mn0001"]["tag"]; got != float64(10) {
+		t.Errorf("tag of mn0001 = %v, want 10", got)
+	}
+	// adding it again is a no-op
+	if err := AddAccessPort("br-meshnet", "mn0001", 0, 10); err != nil {
+		t.Fatalf("AddAccessPort() of an existing port failed: %v", err)
+	}
+	if len(db.bridges["br-meshnet"]) != 1 {
+		t.Errorf("br-meshnet has ports %v, want 1 port", db.bridges["br-meshnet"])
+	}
+	n, err := OFPort("mn0001")
+	if err != nil || n == 0 {
+		t.Errorf("OFPort(mn0001) = %d, %v, want a port number", n, err)
+	}
+	if _, err := OFPort("mn0002"); err == nil {
+		t.Errorf("OFPort() of a missing interface didn't fail")
+	}
+
+	err = AddPort("br-missing", "mn0002", 5)
+	if err == nil || !strings.Contains(err.Error(), "doesn't exist") {
+		t.Errorf("AddPort() to a missing bridge error = %v, want it doesn't exist", err)
+	}
+
+	if err := DeletePort("br-meshnet", "mn0001"); err != nil {
+		t.Fatalf("DeletePort() failed: %v", err)
+	}
+	if _, ok := db.ports["mn0001"]; ok {
+		t.Errorf("port mn0001 wasn't deleted")
+	}
+	if err := DeletePort("br-meshnet", "mn0001"); err != nil {
+		t.Errorf("DeletePort() of a missing port failed: %v", err)
+	}
+}
+
+func TestTransactError(t *testing.T) {
+	old := Socket
+	Socket = filepath.Join(t.TempDir(), "missing.sock")
+	defer func() { Socket = old }()
+	if _, err := transact(op{"op": "select", "table": "Port"}); err == nil {
+		t.Errorf("transact() without a server didn't fail")
+	}
+}
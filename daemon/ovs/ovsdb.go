@@ -0,0 +1,99 @@
+package ovs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	database    = "Open_vSwitch"
+	dialTimeout = 5 * time.Second
+	rpcTimeout  = 10 * time.Second
+)
+
+// Socket is the unix socket of the local OVSDB server
+var Socket = "/var/run/openvswitch/db.sock"
+
+// op is an operation of an OVSDB transaction, as defined by RFC 7047
+type op map[string]interface{}
+
+// opResult is the result of an operation, only the fields used by this package are decoded
+type opResult struct {
+	Count   int                      `json:"count"`
+	Rows    []map[string]interface{} `json:"rows"`
+	Error   string                   `json:"error"`
+	Details string                   `json:"details"`
+}
+
+type rpcRequest struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+	ID     interface{}   `json:"id"`
+}
+
+type rpcResponse struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  interface{}     `json:"error"`
+	ID     interface{}     `json:"id"`
+}
+
+// where returns the condition matching the rows whose name is name
+func where(name string) [][]interface{} {
+	return [][]interface{}{{"name", "==", name}}
+}
+
+// transact runs ops in a single transaction of the Open_vSwitch database and returns
+// their results, failing if any of them has failed
+func transact(ops ...op) ([]opResult, error) {
+	conn, err := net.DialTimeout("unix", Socket, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to OVSDB at %s: %s", Socket, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(rpcTimeout))
+
+	params := []interface{}{database}
+	for _, o := range ops {
+		params = append(params, o)
+	}
+	enc, dec := json.NewEncoder(conn), json.NewDecoder(conn)
+	if err := enc.Encode(rpcRequest{Method: "transact", Params: params, ID: 1}); err != nil {
+		return nil, err
+	}
+	for {
+		var resp rpcResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, fmt.Errorf("failed to read OVSDB response: %s", err)
+		}
+		// the server may check that the connection is alive while it's processing the transaction
+		if resp.Method == "echo" {
+			var echo []interface{}
+			json.Unmarshal(resp.Params, &echo)
+			if err := enc.Encode(map[string]interface{}{"result": echo, "error": nil, "id": resp.ID}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.Error != nil {
+			return nil, fmt.Errorf("OVSDB transaction has failed: %v", resp.Error)
+		}
+		var results []opResult
+		if err := json.Unmarshal(resp.Result, &results); err != nil {
+			return nil, fmt.Errorf("failed to decode OVSDB response: %s", err)
+		}
+		// results has an extra entry for errors of the whole transaction, e.g. a constraint violation
+		for _, r := range results {
+			if r.Error != "" {
+				return nil, fmt.Errorf("OVSDB transaction has failed: %s: %s", r.Error, r.Details)
+			}
+		}
+		if len(results) < len(ops) {
+			return nil, fmt.Errorf("OVSDB has returned %d results for %d operations", len(results), len(ops))
+		}
+		return results, nil
+	}
+}
@@ -0,0 +1,229 @@
+// Package pmtu discovers the path MTU from a pod to an IP address, by sending it ICMP echo
+// requests of varying sizes with the Don't Fragment flag set and looking for the largest
+// one that's answered. It also sets the MTU of the interfaces of links.
+package pmtu
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// MinIPv4 and MinIPv6 are the smallest MTUs of IPv4 and IPv6 links
+	MinIPv4 = 68
+	MinIPv6 = 1280
+	// DefaultTimeout is how long a probe waits for its echo reply
+	DefaultTimeout = time.Second
+
+	ipv4HeaderLen = 20
+	ipv6HeaderLen = 40
+	icmpHeaderLen = 8
+
+	protocolICMP   = 1
+	protocolICMPv6 = 58
+)
+
+// Search returns the largest size between lo and hi for which probe succeeds, assuming it
+// succeeds for all the sizes below that one. It fails if probe fails for lo.
+func Search(lo, hi int, probe func(size int) (bool, error)) (int, error) {
+	if lo > hi {
+		return 0, fmt.Errorf("invalid range %d-%d", lo, hi)
+	}
+	ok, err := probe(lo)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("no answer to probes of %d bytes", lo)
+	}
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		ok, err := probe(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
+
+// Discover returns the path MTU from the netns netNs to dst, which is at most max
+func Discover(netNs string, dst net.IP, max int, timeout time.Duration) (int, error) {
+	min := MinIPv4
+	if dst.To4() == nil {
+		min = MinIPv6
+	}
+	var result int
+	err := ns.WithNetNSPath(netNs, func(_ ns.NetNS) error {
+		p, err := newProber(dst, timeout)
+		if err != nil {
+			return err
+		}
+		defer p.close()
+		result, err = Search(min, max, p.probe)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to discover the path MTU to %s: %s", dst, err)
+	}
+	return result, nil
+}
+
+// Ping returns true if dst answers one of attempts echo requests of the smallest size sent
+// from the netns netNs, each one waiting for its reply for timeout
+func Ping(netNs string, dst net.IP, attempts int, timeout time.Duration) (bool, error) {
+	size := MinIPv4
+	if dst.To4() == nil {
+		size = MinIPv6
+	}
+	var answered bool
+	err := ns.WithNetNSPath(netNs, func(_ ns.NetNS) error {
+		p, err := newProber(dst, timeout)
+		if err != nil {
+			return err
+		}
+		defer p.close()
+		for i := 0; i < attempts && !answered; i++ {
+			if answered, err = p.probe(size); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to ping %s: %s", dst, err)
+	}
+	return answered, nil
+}
+
+// Apply sets the MTU of the interface intf of the netns netNs, unless mtu is 0
+func Apply(netNs, intf string, mtu int32) error {
+	if mtu == 0 {
+		return nil
+	}
+	return ns.WithNetNSPath(netNs, func(_ ns.NetNS) error {
+		l, err := netlink.LinkByName(intf)
+		if err != nil {
+			return err
+		}
+		if l.Attrs().MTU == int(mtu) {
+			return nil
+		}
+		if err := netlink.LinkSetMTU(l, int(mtu)); err != nil {
+			return fmt.Errorf("failed to set the MTU of %s to %d: %s", intf, mtu, err)
+		}
+		return nil
+	})
+}
+
+// prober sends echo requests to dst on a raw ICMP socket, which never fragments them
+type prober struct {
+	fd      int
+	dst     net.IP
+	v6      bool
+	id, seq int
+	timeout time.Duration
+}
+
+func newProber(dst net.IP, timeout time.Duration) (*prober, error) {
+	p := &prober{dst: dst, v6: dst.To4() == nil, id: os.Getpid() & 0xffff, timeout: timeout}
+	family, proto, level, opt, value := unix.AF_INET, unix.IPPROTO_ICMP, unix.IPPROTO_IP, unix.IP_MTU_DISCOVER, unix.IP_PMTUDISC_DO
+	if p.v6 {
+		family, proto, level, opt, value = unix.AF_INET6, unix.IPPROTO_ICMPV6, unix.IPPROTO_IPV6, unix.IPV6_MTU_DISCOVER, unix.IPV6_PMTUDISC_DO
+	}
+	fd, err := unix.Socket(family, unix.SOCK_RAW|unix.SOCK_CLOEXEC, proto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open an ICMP socket: %s", err)
+	}
+	p.fd = fd
+	if err := unix.SetsockoptInt(fd, level, opt, value); err != nil {
+		p.close()
+		return nil, fmt.Errorf("failed to set the Don't Fragment flag: %s", err)
+	}
+	tv := unix.NsecToTimeval(timeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		p.close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *prober) close() {
+	unix.Close(p.fd)
+}
+
+// probe returns true if an echo request of size bytes, IP header included, is answered
+func (p *prober) probe(size int) (bool, error) {
+	p.seq = (p.seq + 1) & 0xffff
+	var (
+		msg   icmp.Message
+		sa    unix.Sockaddr
+		proto int
+	)
+	if p.v6 {
+		msg = icmp.Message{Type: ipv6.ICMPTypeEchoRequest, Body: &icmp.Echo{ID: p.id, Seq: p.seq, Data: make([]byte, size-ipv6HeaderLen-icmpHeaderLen)}}
+		sa6 := &unix.SockaddrInet6{}
+		copy(sa6.Addr[:], p.dst.To16())
+		sa, proto = sa6, protocolICMPv6
+	} else {
+		msg = icmp.Message{Type: ipv4.ICMPTypeEcho, Body: &icmp.Echo{ID: p.id, Seq: p.seq, Data: make([]byte, size-ipv4HeaderLen-icmpHeaderLen)}}
+		sa4 := &unix.SockaddrInet4{}
+		copy(sa4.Addr[:], p.dst.To4())
+		sa, proto = sa4, protocolICMP
+	}
+	// the kernel computes the checksum of ICMPv6 messages itself
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return false, err
+	}
+	if err := unix.Sendto(p.fd, b, 0, sa); err != nil {
+		if err == unix.EMSGSIZE {
+			// larger than the MTU of the interface, or than a path MTU the kernel has learnt
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to send a probe of %d bytes: %s", size, err)
+	}
+
+	buf := make([]byte, size+ipv6HeaderLen)
+	deadline := time.Now().Add(p.timeout)
+	for time.Now().Before(deadline) {
+		n, _, err := unix.Recvfrom(p.fd, buf, 0)
+		if err == unix.EINTR {
+			continue
+		}
+		if err == unix.EAGAIN || err == unix.EWOULDBLOCK {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to receive the answer to a probe: %s", err)
+		}
+		data := buf[:n]
+		if !p.v6 && n > 0 {
+			// raw IPv4 sockets receive the IP header as well
+			if hdrLen := int(data[0]&0x0f) * 4; hdrLen <= n {
+				data = data[hdrLen:]
+			}
+		}
+		reply, err := icmp.ParseMessage(proto, data)
+		if err != nil || (reply.Type != ipv4.ICMPTypeEchoReply && reply.Type != ipv6.ICMPTypeEchoReply) {
+			continue
+		}
+		if echo, ok := reply.Body.(*icmp.Echo); ok && echo.ID == p.id && echo.Seq == p.seq {
+			return true, nil
+		}
+	}
+	return false, nil
+}
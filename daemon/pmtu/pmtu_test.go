@@ -0,0 +1,119 @@
+package pmtu
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		lo, hi, mtu int
+		want        int
+		fail        bool
+	}{
+		{lo: 68, hi: 1500, mtu: 1500, want: 1500},
+		{lo: 68, hi: 1500, mtu: 1400, want: 1400},
+		{lo: 68, hi: 9000, mtu: 1450, want: 1450},
+		{lo: 68, hi: 1500, mtu: 68, want: 68},
+		{lo: 1280, hi: 1280, mtu: 1500, want: 1280},
+		// nothing gets through
+		{lo: 68, hi: 1500, mtu: 0, fail: true},
+		{lo: 1500, hi: 68, mtu: 1500, fail: true},
+	}
+	for i, tt := range tests {
+		probes := 0
+		got, err := Search(tt.lo, tt.hi, func(size int) (bool, error) {
+			probes++
+			return size <= tt.mtu, nil
+		})
+		if (err != nil) != tt.fail || got != tt.want {
+			t.Errorf("#%d test failed: Search(%d, %d) = %d, %v, want %d", i, tt.lo, tt.hi, got, err, tt.want)
+		}
+		if probes > 16 {
+			t.Errorf("#%d test failed: %d probes", i, probes)
+		}
+	}
+
+	if _, err := Search(68, 1500, func(int) (bool, error) { return false, errors.New("down") }); err == nil {
+		t.Errorf("Search() didn't return the error of the probe")
+	}
+}
+
+// TestDiscover probes through a veth pair whose ends have different MTUs, the larger
+// frames being dropped by the receiving end
+func TestDiscover(t *testing.T) {
+	src, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(src)
+	defer src.Close()
+	dst, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(dst)
+	defer dst.Close()
+
+	err = src.Do(func(_ ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1", MTU: 1500}, PeerName: "eth2"}); err != nil {
+			return err
+		}
+		peer, err := netlink.LinkByName("eth2")
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetNsFd(peer, int(dst.Fd())); err != nil {
+			return err
+		}
+		return up("eth1", "10.0.0.1/24")
+	})
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+	if err := dst.Do(func(_ ns.NetNS) error { return up("eth2", "10.0.0.2/24") }); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(dst.Path(), "eth2", 1400); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+
+	mtu, err := Discover(src.Path(), net.ParseIP("10.0.0.2"), 1500, 200*time.Millisecond)
+	if err != nil {
+		t.Skipf("can't probe: %v", err)
+	}
+	// veth lets frames with a VLAN tag's worth of extra bytes through
+	if mtu < 1400 || mtu > 1404 {
+		t.Errorf("Discover() = %d, want 1400", mtu)
+	}
+	if ok, err := Ping(src.Path(), net.ParseIP("10.0.0.2"), 1, 200*time.Millisecond); err != nil || !ok {
+		t.Errorf("Ping(10.0.0.2) = %v, %v, want an answer", ok, err)
+	}
+	if ok, err := Ping(src.Path(), net.ParseIP("10.0.0.3"), 2, 100*time.Millisecond); err != nil || ok {
+		t.Errorf("Ping(10.0.0.3) = %v, %v, want no answer", ok, err)
+	}
+}
+
+func up(intf, addr string) error {
+	l, err := netlink.LinkByName(intf)
+	if err != nil {
+		return err
+	}
+	ip, err := netlink.ParseAddr(addr)
+	if err != nil {
+		return err
+	}
+	if err := netlink.AddrAdd(l, ip); err != nil {
+		return err
+	}
+	if lo, err := netlink.LinkByName("lo"); err == nil {
+		netlink.LinkSetUp(lo)
+	}
+	return netlink.LinkSetUp(l)
+}
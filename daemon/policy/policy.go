@@ -0,0 +1,159 @@
+// Package policy generates Kubernetes NetworkPolicies that keep apart the pods of a topology
+// that aren't connected by its links.
+package policy
+
+import (
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const (
+	// TopologyLabel is set by meshnetd on each pod to the name of its topology, since
+	// NetworkPolicies can only select pods by their labels
+	TopologyLabel = "meshnet.io/topology"
+	// ManagedByLabel marks the NetworkPolicies generated by meshnet
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedBy is the value of ManagedByLabel of the generated NetworkPolicies
+	ManagedBy = "meshnet"
+	// namespaceLabel is set by K8s on each namespace to its name
+	namespaceLabel = "kubernetes.io/metadata.name"
+
+	namePrefix = "meshnet-"
+	// peer pod name used by macvlan links
+	localhost = "localhost"
+)
+
+// Generate returns a NetworkPolicy for each topology in ns, sorted by name. Each pod refuses
+// the traffic of the pods of the other topologies of ns that it isn't connected to, directly
+// or through other pods, so that intermediate pods of a multi-hop path can forward traffic.
+// NetworkPolicies only apply to the primary interface of the pods, not to their links, so
+// the traffic of the other pods and namespaces, e.g. the management of the topology, is
+// still accepted. The result only depends on the links, so applying it again doesn't change
+// anything.
+func Generate(ns string, topologies []topologyv1.Topology) []networkingv1.NetworkPolicy {
+	neighbours := make(map[string]map[string]bool)
+	add := func(a, b string) {
+		if neighbours[a] == nil {
+			neighbours[a] = make(map[string]bool)
+		}
+		neighbours[a][b] = true
+	}
+	var names []string
+	for _, t := range topologies {
+		names = append(names, t.Name)
+		add(t.Name, t.Name)
+		for _, l := range t.Spec.Links {
+			if l.PeerPod == localhost || l.PeerPod == "" {
+				continue
+			}
+			add(t.Name, l.PeerPod)
+			add(l.PeerPod, t.Name)
+		}
+	}
+	sort.Strings(names)
+
+	policies := make([]networkingv1.NetworkPolicy, 0, len(names))
+	for _, name := range names {
+		policy := networkingv1.NetworkPolicy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "networking.k8s.io/v1",
+				Kind:       "NetworkPolicy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      namePrefix + name,
+				Namespace: ns,
+				Labels:    map[string]string{ManagedByLabel: ManagedBy},
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{TopologyLabel: name},
+				},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}
+		denied := unreachable(name, names, neighbours)
+		if len(denied) == 0 {
+			// an empty rule accepts all the traffic
+			policy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{}}
+		} else {
+			// NotIn also selects the pods without the label
+			policy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      TopologyLabel,
+							Operator: metav1.LabelSelectorOpNotIn,
+							Values:   denied,
+						}},
+					},
+				}, {
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      namespaceLabel,
+							Operator: metav1.LabelSelectorOpNotIn,
+							Values:   []string{ns},
+						}},
+					},
+				}},
+			}}
+		}
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+// unreachable returns the sorted names of the pods that aren't connected to name
+func unreachable(name string, names []string, neighbours map[string]map[string]bool) []string {
+	connected := map[string]bool{name: true}
+	for _, n := range reachable(name, neighbours) {
+		connected[n] = true
+	}
+	var result []string
+	for _, n := range names {
+		if !connected[n] {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// reachable returns the sorted names of the pods connected to name, excluding itself
+func reachable(name string, neighbours map[string]map[string]bool) []string {
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	var result []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for n := range neighbours[current] {
+			if !seen[n] {
+				seen[n] = true
+				result = append(result, n)
+				queue = append(queue, n)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Stale returns the sorted names of the NetworkPolicies generated by meshnet among existing
+// that aren't in generated anymore, e.g. those of deleted topologies
+func Stale(existing, generated []networkingv1.NetworkPolicy) []string {
+	keep := make(map[string]bool, len(generated))
+	for _, p := range generated {
+		keep[p.Name] = true
+	}
+	var result []string
+	for _, p := range existing {
+		if p.Labels[ManagedByLabel] == ManagedBy && !keep[p.Name] {
+			result = append(result, p.Name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,95 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func topology(name string, peers ...string) topologyv1.Topology {
+	t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, p := range peers {
+		t.Spec.Links = append(t.Spec.Links, topologyv1.Link{PeerPod: p})
+	}
+	return t
+}
+
+func TestGenerate(t *testing.T) {
+	// r1 - r2 - r3 is a chain, r4 only has a macvlan link and r5 is on its own
+	topologies := []topologyv1.Topology{
+		topology("r3", "r2"),
+		topology("r1", "r2"),
+		topology("r2", "r1", "r3"),
+		topology("r4", "localhost"),
+		topology("r5"),
+	}
+	// the topology pods that each pod refuses
+	want := map[string][]string{
+		"meshnet-r1": {"r4", "r5"},
+		"meshnet-r2": {"r4", "r5"},
+		"meshnet-r3": {"r4", "r5"},
+		"meshnet-r4": {"r1", "r2", "r3", "r5"},
+		"meshnet-r5": {"r1", "r2", "r3", "r4"},
+	}
+
+	policies := Generate("lab", topologies)
+	if len(policies) != len(want) {
+		t.Fatalf("Generate() returned %d policies, want %d", len(policies), len(want))
+	}
+	for i, p := range policies {
+		if i > 0 && policies[i-1].Name >= p.Name {
+			t.Errorf("policies are not sorted: %s before %s", policies[i-1].Name, p.Name)
+		}
+		if p.Namespace != "lab" {
+			t.Errorf("%s: namespace = %q, want lab", p.Name, p.Namespace)
+		}
+		if len(p.Spec.Ingress) != 1 || len(p.Spec.Ingress[0].From) != 2 {
+			t.Fatalf("%s: ingress = %v, want a rule with the pods and the other namespaces", p.Name, p.Spec.Ingress)
+		}
+		pods, namespaces := p.Spec.Ingress[0].From[0].PodSelector, p.Spec.Ingress[0].From[1].NamespaceSelector
+		if got := pods.MatchExpressions[0]; got.Operator != metav1.LabelSelectorOpNotIn || !reflect.DeepEqual(got.Values, want[p.Name]) {
+			t.Errorf("%s: accepted pods = %v, want all but %v", p.Name, got, want[p.Name])
+		}
+		if got := namespaces.MatchExpressions[0]; got.Operator != metav1.LabelSelectorOpNotIn || !reflect.DeepEqual(got.Values, []string{"lab"}) {
+			t.Errorf("%s: accepted namespaces = %v, want all but lab", p.Name, got)
+		}
+	}
+
+	// a pod connected to all the others accepts all the traffic
+	all := Generate("lab", []topologyv1.Topology{topology("r1", "r2"), topology("r2", "r1")})
+	for _, p := range all {
+		if len(p.Spec.Ingress) != 1 || len(p.Spec.Ingress[0].From) != 0 {
+			t.Errorf("%s: ingress = %v, want a rule accepting all the traffic", p.Name, p.Spec.Ingress)
+		}
+	}
+
+	if again := Generate("lab", topologies); !reflect.DeepEqual(again, policies) {
+		t.Errorf("Generate() is not deterministic")
+	}
+}
+
+func TestStale(t *testing.T) {
+	policy := func(name string, managed bool) networkingv1.NetworkPolicy {
+		p := networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if managed {
+			p.Labels = map[string]string{ManagedByLabel: ManagedBy}
+		}
+		return p
+	}
+	existing := []networkingv1.NetworkPolicy{
+		policy("meshnet-r3", true),
+		policy("meshnet-r1", true),
+		policy("meshnet-r2", true),
+		// not generated by meshnet
+		policy("meshnet-custom", false),
+		policy("allow-dns", false),
+	}
+	generated := Generate("lab", []topologyv1.Topology{topology("r1")})
+	if got, want := Stale(existing, generated), []string{"meshnet-r2", "meshnet-r3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stale() = %v, want %v", got, want)
+	}
+}
@@ -20,375 +20,667 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Pod struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type TunnelType int32
 
-	Name   string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	SrcIp  string  `protobuf:"bytes,2,opt,name=src_ip,json=srcIp,proto3" json:"src_ip,omitempty"`
-	NetNs  string  `protobuf:"bytes,3,opt,name=net_ns,json=netNs,proto3" json:"net_ns,omitempty"`
-	KubeNs string  `protobuf:"bytes,4,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
-	Links  []*Link `protobuf:"bytes,5,rep,name=links,proto3" json:"links,omitempty"`
-	NodeIp string  `protobuf:"bytes,6,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
-}
+const (
+	TunnelType_VXLAN     TunnelType = 0
+	TunnelType_SRV6      TunnelType = 1
+	TunnelType_GTP       TunnelType = 2
+	TunnelType_WIREGUARD TunnelType = 3
+)
 
-func (x *Pod) Reset() {
-	*x = Pod{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for TunnelType.
+var (
+	TunnelType_name = map[int32]string{
+		0: "VXLAN",
+		1: "SRV6",
+		2: "GTP",
+		3: "WIREGUARD",
 	}
+	TunnelType_value = map[string]int32{
+		"VXLAN":     0,
+		"SRV6":      1,
+		"GTP":       2,
+		"WIREGUARD": 3,
+	}
+)
+
+func (x TunnelType) Enum() *TunnelType {
+	p := new(TunnelType)
+	*p = x
+	return p
 }
 
-func (x *Pod) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x TunnelType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*Pod) ProtoMessage() {}
+func (TunnelType) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[0].Descriptor()
+}
 
-func (x *Pod) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (TunnelType) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[0]
 }
 
-// Deprecated: Use Pod.ProtoReflect.Descriptor instead.
-func (*Pod) Descriptor() ([]byte, []int) {
+func (x TunnelType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TunnelType.Descriptor instead.
+func (TunnelType) EnumDescriptor() ([]byte, []int) {
 	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Pod) GetName() string {
-	if x != nil {
-		return x.Name
+type HealthStatus int32
+
+const (
+	HealthStatus_HEALTHY HealthStatus = 0
+	// the daemon can't reach K8s, or some background tasks have stopped and wires may not be retried or cleaned up
+	HealthStatus_DEGRADED HealthStatus = 1
+	// the daemon isn't processing requests
+	HealthStatus_UNHEALTHY HealthStatus = 2
+)
+
+// Enum value maps for HealthStatus.
+var (
+	HealthStatus_name = map[int32]string{
+		0: "HEALTHY",
+		1: "DEGRADED",
+		2: "UNHEALTHY",
 	}
-	return ""
+	HealthStatus_value = map[string]int32{
+		"HEALTHY":   0,
+		"DEGRADED":  1,
+		"UNHEALTHY": 2,
+	}
+)
+
+func (x HealthStatus) Enum() *HealthStatus {
+	p := new(HealthStatus)
+	*p = x
+	return p
 }
 
-func (x *Pod) GetSrcIp() string {
-	if x != nil {
-		return x.SrcIp
-	}
-	return ""
+func (x HealthStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Pod) GetNetNs() string {
-	if x != nil {
-		return x.NetNs
-	}
-	return ""
+func (HealthStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[1].Descriptor()
 }
 
-func (x *Pod) GetKubeNs() string {
-	if x != nil {
-		return x.KubeNs
-	}
-	return ""
+func (HealthStatus) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[1]
 }
 
-func (x *Pod) GetLinks() []*Link {
-	if x != nil {
-		return x.Links
-	}
-	return nil
+func (x HealthStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Pod) GetNodeIp() string {
-	if x != nil {
-		return x.NodeIp
+// Deprecated: Use HealthStatus.Descriptor instead.
+func (HealthStatus) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{1}
+}
+
+type FanoutWireDef_Mode int32
+
+const (
+	// flood to the peers that are running
+	FanoutWireDef_BEST_EFFORT FanoutWireDef_Mode = 0
+	// only flood to the peers once all of them are running
+	FanoutWireDef_FAIL_ON_ANY_ERROR FanoutWireDef_Mode = 1
+)
+
+// Enum value maps for FanoutWireDef_Mode.
+var (
+	FanoutWireDef_Mode_name = map[int32]string{
+		0: "BEST_EFFORT",
+		1: "FAIL_ON_ANY_ERROR",
 	}
-	return ""
+	FanoutWireDef_Mode_value = map[string]int32{
+		"BEST_EFFORT":       0,
+		"FAIL_ON_ANY_ERROR": 1,
+	}
+)
+
+func (x FanoutWireDef_Mode) Enum() *FanoutWireDef_Mode {
+	p := new(FanoutWireDef_Mode)
+	*p = x
+	return p
 }
 
-type Link struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x FanoutWireDef_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-	PeerPod   string `protobuf:"bytes,1,opt,name=peer_pod,json=peerPod,proto3" json:"peer_pod,omitempty"`
-	LocalIntf string `protobuf:"bytes,2,opt,name=local_intf,json=localIntf,proto3" json:"local_intf,omitempty"`
-	PeerIntf  string `protobuf:"bytes,3,opt,name=peer_intf,json=peerIntf,proto3" json:"peer_intf,omitempty"`
-	LocalIp   string `protobuf:"bytes,4,opt,name=local_ip,json=localIp,proto3" json:"local_ip,omitempty"`
-	PeerIp    string `protobuf:"bytes,5,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
-	Uid       int64  `protobuf:"varint,6,opt,name=uid,proto3" json:"uid,omitempty"`
+func (FanoutWireDef_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[2].Descriptor()
 }
 
-func (x *Link) Reset() {
-	*x = Link{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (FanoutWireDef_Mode) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[2]
 }
 
-func (x *Link) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x FanoutWireDef_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (*Link) ProtoMessage() {}
+// Deprecated: Use FanoutWireDef_Mode.Descriptor instead.
+func (FanoutWireDef_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{15, 0}
+}
 
-func (x *Link) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+type LinkPatch_Operation int32
+
+const (
+	// rejected, so that a patch without an operation doesn't add a link
+	LinkPatch_UNSPECIFIED LinkPatch_Operation = 0
+	LinkPatch_ADD         LinkPatch_Operation = 1
+	LinkPatch_REMOVE      LinkPatch_Operation = 2
+	LinkPatch_UPDATE      LinkPatch_Operation = 3
+)
+
+// Enum value maps for LinkPatch_Operation.
+var (
+	LinkPatch_Operation_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "ADD",
+		2: "REMOVE",
+		3: "UPDATE",
 	}
-	return mi.MessageOf(x)
+	LinkPatch_Operation_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"ADD":         1,
+		"REMOVE":      2,
+		"UPDATE":      3,
+	}
+)
+
+func (x LinkPatch_Operation) Enum() *LinkPatch_Operation {
+	p := new(LinkPatch_Operation)
+	*p = x
+	return p
 }
 
-// Deprecated: Use Link.ProtoReflect.Descriptor instead.
-func (*Link) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{1}
+func (x LinkPatch_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Link) GetPeerPod() string {
-	if x != nil {
-		return x.PeerPod
-	}
-	return ""
+func (LinkPatch_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[3].Descriptor()
 }
 
-func (x *Link) GetLocalIntf() string {
-	if x != nil {
-		return x.LocalIntf
-	}
-	return ""
+func (LinkPatch_Operation) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[3]
 }
 
-func (x *Link) GetPeerIntf() string {
-	if x != nil {
-		return x.PeerIntf
-	}
-	return ""
+func (x LinkPatch_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Link) GetLocalIp() string {
-	if x != nil {
-		return x.LocalIp
-	}
-	return ""
+// Deprecated: Use LinkPatch_Operation.Descriptor instead.
+func (LinkPatch_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{16, 0}
 }
 
-func (x *Link) GetPeerIp() string {
-	if x != nil {
-		return x.PeerIp
+type StatsStreamRequest_Mode int32
+
+const (
+	// the counters as read from the interfaces
+	StatsStreamRequest_CUMULATIVE StatsStreamRequest_Mode = 0
+	// the increase of the counters since the previous batch
+	StatsStreamRequest_RATE StatsStreamRequest_Mode = 1
+)
+
+// Enum value maps for StatsStreamRequest_Mode.
+var (
+	StatsStreamRequest_Mode_name = map[int32]string{
+		0: "CUMULATIVE",
+		1: "RATE",
 	}
-	return ""
+	StatsStreamRequest_Mode_value = map[string]int32{
+		"CUMULATIVE": 0,
+		"RATE":       1,
+	}
+)
+
+func (x StatsStreamRequest_Mode) Enum() *StatsStreamRequest_Mode {
+	p := new(StatsStreamRequest_Mode)
+	*p = x
+	return p
 }
 
-func (x *Link) GetUid() int64 {
-	if x != nil {
-		return x.Uid
-	}
-	return 0
+func (x StatsStreamRequest_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type PodQuery struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (StatsStreamRequest_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[4].Descriptor()
+}
 
-	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+func (StatsStreamRequest_Mode) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[4]
 }
 
-func (x *PodQuery) Reset() {
-	*x = PodQuery{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x StatsStreamRequest_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *PodQuery) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use StatsStreamRequest_Mode.Descriptor instead.
+func (StatsStreamRequest_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{29, 0}
 }
 
-func (*PodQuery) ProtoMessage() {}
+type WireCheck_Kind int32
 
-func (x *PodQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	// the interface exists and is up
+	WireCheck_INTERFACE WireCheck_Kind = 0
+	// both ends of the wire agree on its parameters, as checked by VerifyWire
+	WireCheck_PARAMETERS WireCheck_Kind = 1
+	// the IP address of the peer's end answers ICMP echo requests
+	WireCheck_CONNECTIVITY WireCheck_Kind = 2
+)
+
+// Enum value maps for WireCheck_Kind.
+var (
+	WireCheck_Kind_name = map[int32]string{
+		0: "INTERFACE",
+		1: "PARAMETERS",
+		2: "CONNECTIVITY",
 	}
-	return mi.MessageOf(x)
+	WireCheck_Kind_value = map[string]int32{
+		"INTERFACE":    0,
+		"PARAMETERS":   1,
+		"CONNECTIVITY": 2,
+	}
+)
+
+func (x WireCheck_Kind) Enum() *WireCheck_Kind {
+	p := new(WireCheck_Kind)
+	*p = x
+	return p
 }
 
-// Deprecated: Use PodQuery.ProtoReflect.Descriptor instead.
-func (*PodQuery) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{2}
+func (x WireCheck_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *PodQuery) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+func (WireCheck_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[5].Descriptor()
 }
 
-func (x *PodQuery) GetKubeNs() string {
-	if x != nil {
-		return x.KubeNs
-	}
-	return ""
+func (WireCheck_Kind) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[5]
 }
 
-type SkipQuery struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x WireCheck_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
 
-	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
-	Peer   string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
-	KubeNs string `protobuf:"bytes,3,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+// Deprecated: Use WireCheck_Kind.Descriptor instead.
+func (WireCheck_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{43, 0}
 }
 
-func (x *SkipQuery) Reset() {
-	*x = SkipQuery{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+type WireRepair_Action int32
+
+const (
+	// the interface was down and has been set up
+	WireRepair_SET_UP WireRepair_Action = 0
+	// the end of the wire has been removed and the wire set up again, along with the end
+	// of the peer
+	WireRepair_RECREATE_WIRE WireRepair_Action = 1
+)
+
+// Enum value maps for WireRepair_Action.
+var (
+	WireRepair_Action_name = map[int32]string{
+		0: "SET_UP",
+		1: "RECREATE_WIRE",
 	}
+	WireRepair_Action_value = map[string]int32{
+		"SET_UP":        0,
+		"RECREATE_WIRE": 1,
+	}
+)
+
+func (x WireRepair_Action) Enum() *WireRepair_Action {
+	p := new(WireRepair_Action)
+	*p = x
+	return p
 }
 
-func (x *SkipQuery) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x WireRepair_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*SkipQuery) ProtoMessage() {}
+func (WireRepair_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[6].Descriptor()
+}
 
-func (x *SkipQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (WireRepair_Action) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[6]
 }
 
-// Deprecated: Use SkipQuery.ProtoReflect.Descriptor instead.
-func (*SkipQuery) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{3}
+func (x WireRepair_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *SkipQuery) GetPod() string {
-	if x != nil {
-		return x.Pod
-	}
-	return ""
+// Deprecated: Use WireRepair_Action.Descriptor instead.
+func (WireRepair_Action) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{44, 0}
 }
 
-func (x *SkipQuery) GetPeer() string {
-	if x != nil {
-		return x.Peer
+type TopologyDrift_Kind int32
+
+const (
+	// link of the topology without a wire, or whose interface is missing
+	TopologyDrift_MISSING_WIRE TopologyDrift_Kind = 0
+	// wire of the node that isn't a link of the topology anymore
+	TopologyDrift_ORPHANED_WIRE TopologyDrift_Kind = 1
+	// impairments of the interface that aren't the ones of the link
+	TopologyDrift_IMPAIRMENT_MISMATCH TopologyDrift_Kind = 2
+)
+
+// Enum value maps for TopologyDrift_Kind.
+var (
+	TopologyDrift_Kind_name = map[int32]string{
+		0: "MISSING_WIRE",
+		1: "ORPHANED_WIRE",
+		2: "IMPAIRMENT_MISMATCH",
 	}
-	return ""
+	TopologyDrift_Kind_value = map[string]int32{
+		"MISSING_WIRE":        0,
+		"ORPHANED_WIRE":       1,
+		"IMPAIRMENT_MISMATCH": 2,
+	}
+)
+
+func (x TopologyDrift_Kind) Enum() *TopologyDrift_Kind {
+	p := new(TopologyDrift_Kind)
+	*p = x
+	return p
 }
 
-func (x *SkipQuery) GetKubeNs() string {
-	if x != nil {
-		return x.KubeNs
+func (x TopologyDrift_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopologyDrift_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[7].Descriptor()
+}
+
+func (TopologyDrift_Kind) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[7]
+}
+
+func (x TopologyDrift_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopologyDrift_Kind.Descriptor instead.
+func (TopologyDrift_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{66, 0}
+}
+
+type TopologyDrift_Remediation int32
+
+const (
+	TopologyDrift_RECREATE_WIRE      TopologyDrift_Remediation = 0
+	TopologyDrift_REMOVE_WIRE        TopologyDrift_Remediation = 1
+	TopologyDrift_REAPPLY_IMPAIRMENT TopologyDrift_Remediation = 2
+)
+
+// Enum value maps for TopologyDrift_Remediation.
+var (
+	TopologyDrift_Remediation_name = map[int32]string{
+		0: "RECREATE_WIRE",
+		1: "REMOVE_WIRE",
+		2: "REAPPLY_IMPAIRMENT",
 	}
-	return ""
+	TopologyDrift_Remediation_value = map[string]int32{
+		"RECREATE_WIRE":      0,
+		"REMOVE_WIRE":        1,
+		"REAPPLY_IMPAIRMENT": 2,
+	}
+)
+
+func (x TopologyDrift_Remediation) Enum() *TopologyDrift_Remediation {
+	p := new(TopologyDrift_Remediation)
+	*p = x
+	return p
 }
 
-type BoolResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x TopologyDrift_Remediation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-	Response bool `protobuf:"varint,1,opt,name=response,proto3" json:"response,omitempty"`
+func (TopologyDrift_Remediation) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[8].Descriptor()
 }
 
-func (x *BoolResponse) Reset() {
-	*x = BoolResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (TopologyDrift_Remediation) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[8]
+}
+
+func (x TopologyDrift_Remediation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopologyDrift_Remediation.Descriptor instead.
+func (TopologyDrift_Remediation) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{66, 1}
+}
+
+type WireError_Operation int32
+
+const (
+	WireError_NONE   WireError_Operation = 0
+	WireError_ADD    WireError_Operation = 1
+	WireError_REMOVE WireError_Operation = 2
+	WireError_UPDATE WireError_Operation = 3
+)
+
+// Enum value maps for WireError_Operation.
+var (
+	WireError_Operation_name = map[int32]string{
+		0: "NONE",
+		1: "ADD",
+		2: "REMOVE",
+		3: "UPDATE",
 	}
+	WireError_Operation_value = map[string]int32{
+		"NONE":   0,
+		"ADD":    1,
+		"REMOVE": 2,
+		"UPDATE": 3,
+	}
+)
+
+func (x WireError_Operation) Enum() *WireError_Operation {
+	p := new(WireError_Operation)
+	*p = x
+	return p
 }
 
-func (x *BoolResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x WireError_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*BoolResponse) ProtoMessage() {}
+func (WireError_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[9].Descriptor()
+}
 
-func (x *BoolResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (WireError_Operation) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[9]
+}
+
+func (x WireError_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WireError_Operation.Descriptor instead.
+func (WireError_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{82, 0}
+}
+
+type WireError_Cause int32
+
+const (
+	WireError_UNKNOWN          WireError_Cause = 0
+	WireError_K8S_API_ERROR    WireError_Cause = 1
+	WireError_NETLINK_ERROR    WireError_Cause = 2
+	WireError_PCAP_ERROR       WireError_Cause = 3
+	WireError_PEER_UNREACHABLE WireError_Cause = 4
+	// rejected by the daemon's rate limiter, the call can be retried later
+	WireError_RATE_LIMITED WireError_Cause = 5
+)
+
+// Enum value maps for WireError_Cause.
+var (
+	WireError_Cause_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "K8S_API_ERROR",
+		2: "NETLINK_ERROR",
+		3: "PCAP_ERROR",
+		4: "PEER_UNREACHABLE",
+		5: "RATE_LIMITED",
 	}
-	return mi.MessageOf(x)
+	WireError_Cause_value = map[string]int32{
+		"UNKNOWN":          0,
+		"K8S_API_ERROR":    1,
+		"NETLINK_ERROR":    2,
+		"PCAP_ERROR":       3,
+		"PEER_UNREACHABLE": 4,
+		"RATE_LIMITED":     5,
+	}
+)
+
+func (x WireError_Cause) Enum() *WireError_Cause {
+	p := new(WireError_Cause)
+	*p = x
+	return p
 }
 
-// Deprecated: Use BoolResponse.ProtoReflect.Descriptor instead.
-func (*BoolResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{4}
+func (x WireError_Cause) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *BoolResponse) GetResponse() bool {
-	if x != nil {
-		return x.Response
+func (WireError_Cause) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[10].Descriptor()
+}
+
+func (WireError_Cause) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[10]
+}
+
+func (x WireError_Cause) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WireError_Cause.Descriptor instead.
+func (WireError_Cause) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{82, 1}
+}
+
+type TopologyEvent_Type int32
+
+const (
+	TopologyEvent_CREATE  TopologyEvent_Type = 0
+	TopologyEvent_DELETE  TopologyEvent_Type = 1
+	TopologyEvent_FAIL    TopologyEvent_Type = 2
+	TopologyEvent_RECOVER TopologyEvent_Type = 3
+)
+
+// Enum value maps for TopologyEvent_Type.
+var (
+	TopologyEvent_Type_name = map[int32]string{
+		0: "CREATE",
+		1: "DELETE",
+		2: "FAIL",
+		3: "RECOVER",
 	}
-	return false
+	TopologyEvent_Type_value = map[string]int32{
+		"CREATE":  0,
+		"DELETE":  1,
+		"FAIL":    2,
+		"RECOVER": 3,
+	}
+)
+
+func (x TopologyEvent_Type) Enum() *TopologyEvent_Type {
+	p := new(TopologyEvent_Type)
+	*p = x
+	return p
 }
 
-type RemotePod struct {
+func (x TopologyEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopologyEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[11].Descriptor()
+}
+
+func (TopologyEvent_Type) Type() protoreflect.EnumType {
+	return &file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes[11]
+}
+
+func (x TopologyEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopologyEvent_Type.Descriptor instead.
+func (TopologyEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{83, 0}
+}
+
+type Pod struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetNs    string `protobuf:"bytes,1,opt,name=net_ns,json=netNs,proto3" json:"net_ns,omitempty"`
-	IntfName string `protobuf:"bytes,2,opt,name=intf_name,json=intfName,proto3" json:"intf_name,omitempty"`
-	IntfIp   string `protobuf:"bytes,3,opt,name=intf_ip,json=intfIp,proto3" json:"intf_ip,omitempty"`
-	PeerVtep string `protobuf:"bytes,4,opt,name=peer_vtep,json=peerVtep,proto3" json:"peer_vtep,omitempty"`
-	KubeNs   string `protobuf:"bytes,5,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
-	Vni      int64  `protobuf:"varint,6,opt,name=vni,proto3" json:"vni,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// IPv4 or IPv6 address of the pod's node, the VTEP of its VXLAN links
+	SrcIp  string  `protobuf:"bytes,2,opt,name=src_ip,json=srcIp,proto3" json:"src_ip,omitempty"`
+	NetNs  string  `protobuf:"bytes,3,opt,name=net_ns,json=netNs,proto3" json:"net_ns,omitempty"`
+	KubeNs string  `protobuf:"bytes,4,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Links  []*Link `protobuf:"bytes,5,rep,name=links,proto3" json:"links,omitempty"`
+	// IPv4 or IPv6 address of the node of the daemon
+	NodeIp string `protobuf:"bytes,6,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	// supported meshnet.io/* annotations of the K8s pod, used as defaults for its links
+	Annotations map[string]string `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// set while a canary deployment of the topology is in progress
+	Canary *CanaryState `protobuf:"bytes,8,opt,name=canary,proto3" json:"canary,omitempty"`
+	// OVS bridge that same-node links are switched by, empty when they're veth pairs
+	OvsBridge string `protobuf:"bytes,9,opt,name=ovs_bridge,json=ovsBridge,proto3" json:"ovs_bridge,omitempty"`
+	// how the veth pairs of same-node links are created, "root" or "caller"
+	NetnsMode string `protobuf:"bytes,10,opt,name=netns_mode,json=netnsMode,proto3" json:"netns_mode,omitempty"`
+	// socket of the Cilium agent the link interfaces are registered with, empty unless meshnet is chained after Cilium
+	CiliumSocket string `protobuf:"bytes,11,opt,name=cilium_socket,json=ciliumSocket,proto3" json:"cilium_socket,omitempty"`
+	// ID of the pod's sandbox container, set by the CNI plugin, the container-id of its Cilium endpoints
+	ContainerId string `protobuf:"bytes,12,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 }
 
-func (x *RemotePod) Reset() {
-	*x = RemotePod{}
+func (x *Pod) Reset() {
+	*x = Pod{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[5]
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemotePod) String() string {
+func (x *Pod) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemotePod) ProtoMessage() {}
+func (*Pod) ProtoMessage() {}
 
-func (x *RemotePod) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[5]
+func (x *Pod) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,195 +691,8550 @@ func (x *RemotePod) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemotePod.ProtoReflect.Descriptor instead.
-func (*RemotePod) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Pod.ProtoReflect.Descriptor instead.
+func (*Pod) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *RemotePod) GetNetNs() string {
+func (x *Pod) GetName() string {
 	if x != nil {
-		return x.NetNs
+		return x.Name
 	}
 	return ""
 }
 
-func (x *RemotePod) GetIntfName() string {
+func (x *Pod) GetSrcIp() string {
 	if x != nil {
-		return x.IntfName
+		return x.SrcIp
 	}
 	return ""
 }
 
-func (x *RemotePod) GetIntfIp() string {
+func (x *Pod) GetNetNs() string {
 	if x != nil {
-		return x.IntfIp
+		return x.NetNs
 	}
 	return ""
 }
 
-func (x *RemotePod) GetPeerVtep() string {
+func (x *Pod) GetKubeNs() string {
 	if x != nil {
-		return x.PeerVtep
+		return x.KubeNs
 	}
 	return ""
 }
 
-func (x *RemotePod) GetKubeNs() string {
+func (x *Pod) GetLinks() []*Link {
 	if x != nil {
-		return x.KubeNs
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Pod) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
 	}
 	return ""
 }
 
-func (x *RemotePod) GetVni() int64 {
+func (x *Pod) GetAnnotations() map[string]string {
 	if x != nil {
-		return x.Vni
+		return x.Annotations
 	}
-	return 0
+	return nil
 }
 
-var File_daemon_proto_meshnet_v1beta1_meshnet_proto protoreflect.FileDescriptor
+func (x *Pod) GetCanary() *CanaryState {
+	if x != nil {
+		return x.Canary
+	}
+	return nil
+}
 
-var file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDesc = []byte{
-	0x0a, 0x2a, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d,
-	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2f, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2f, 0x6d,
-	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6d, 0x65,
-	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x22, 0xa6, 0x01,
-	0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63,
-	0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x70,
-	0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x6e, 0x65, 0x74, 0x4e, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
-	0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
-	0x12, 0x2b, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
-	0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x17, 0x0a,
-	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x22, 0xa3, 0x01, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
-	0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x50, 0x6f, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f,
-	0x63, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65,
-	0x72, 0x5f, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65,
-	0x65, 0x72, 0x49, 0x6e, 0x74, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f,
-	0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x49,
-	0x70, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
-	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x08,
-	0x50, 0x6f, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07,
-	0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b,
-	0x75, 0x62, 0x65, 0x4e, 0x73, 0x22, 0x4a, 0x0a, 0x09, 0x53, 0x6b, 0x69, 0x70, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x70, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65,
-	0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e,
-	0x73, 0x22, 0x2a, 0x0a, 0x0c, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa0, 0x01,
-	0x0a, 0x09, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6e,
-	0x65, 0x74, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x65, 0x74,
-	0x4e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x17, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x66, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x69, 0x6e, 0x74, 0x66, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72,
-	0x5f, 0x76, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65, 0x65,
-	0x72, 0x56, 0x74, 0x65, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x10,
-	0x0a, 0x03, 0x76, 0x6e, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x76, 0x6e, 0x69,
-	0x32, 0xd5, 0x02, 0x0a, 0x05, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x36, 0x0a, 0x03, 0x47, 0x65,
-	0x74, 0x12, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
-	0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x14, 0x2e, 0x6d,
-	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50,
-	0x6f, 0x64, 0x12, 0x3f, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x14,
-	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
-	0x2e, 0x50, 0x6f, 0x64, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
-	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x53, 0x6b, 0x69, 0x70, 0x52, 0x65, 0x76, 0x65, 0x72,
-	0x73, 0x65, 0x12, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
-	0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d,
-	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
-	0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
-	0x04, 0x53, 0x6b, 0x69, 0x70, 0x12, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
-	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
-	0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x46, 0x0a, 0x09, 0x49, 0x73, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1a, 0x2e,
-	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
-	0x53, 0x6b, 0x69, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68,
-	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x4d, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x6d,
-	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x64, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
-	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x6f, 0x70, 0x2f,
-	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2f, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Pod) GetOvsBridge() string {
+	if x != nil {
+		return x.OvsBridge
+	}
+	return ""
 }
 
-var (
-	file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescOnce sync.Once
-	file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData = file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDesc
-)
+func (x *Pod) GetNetnsMode() string {
+	if x != nil {
+		return x.NetnsMode
+	}
+	return ""
+}
 
-func file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP() []byte {
-	file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescOnce.Do(func() {
-		file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData = protoimpl.X.CompressGZIP(file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData)
-	})
-	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData
+func (x *Pod) GetCiliumSocket() string {
+	if x != nil {
+		return x.CiliumSocket
+	}
+	return ""
 }
 
-var file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_daemon_proto_meshnet_v1beta1_meshnet_proto_goTypes = []interface{}{
-	(*Pod)(nil),          // 0: meshnet.v1beta1.Pod
-	(*Link)(nil),         // 1: meshnet.v1beta1.Link
-	(*PodQuery)(nil),     // 2: meshnet.v1beta1.PodQuery
-	(*SkipQuery)(nil),    // 3: meshnet.v1beta1.SkipQuery
-	(*BoolResponse)(nil), // 4: meshnet.v1beta1.BoolResponse
-	(*RemotePod)(nil),    // 5: meshnet.v1beta1.RemotePod
+func (x *Pod) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
 }
-var file_daemon_proto_meshnet_v1beta1_meshnet_proto_depIdxs = []int32{
-	1, // 0: meshnet.v1beta1.Pod.links:type_name -> meshnet.v1beta1.Link
-	2, // 1: meshnet.v1beta1.Local.Get:input_type -> meshnet.v1beta1.PodQuery
-	0, // 2: meshnet.v1beta1.Local.SetAlive:input_type -> meshnet.v1beta1.Pod
-	3, // 3: meshnet.v1beta1.Local.SkipReverse:input_type -> meshnet.v1beta1.SkipQuery
-	3, // 4: meshnet.v1beta1.Local.Skip:input_type -> meshnet.v1beta1.SkipQuery
-	3, // 5: meshnet.v1beta1.Local.IsSkipped:input_type -> meshnet.v1beta1.SkipQuery
-	5, // 6: meshnet.v1beta1.Remote.Update:input_type -> meshnet.v1beta1.RemotePod
-	0, // 7: meshnet.v1beta1.Local.Get:output_type -> meshnet.v1beta1.Pod
-	4, // 8: meshnet.v1beta1.Local.SetAlive:output_type -> meshnet.v1beta1.BoolResponse
-	4, // 9: meshnet.v1beta1.Local.SkipReverse:output_type -> meshnet.v1beta1.BoolResponse
-	4, // 10: meshnet.v1beta1.Local.Skip:output_type -> meshnet.v1beta1.BoolResponse
-	4, // 11: meshnet.v1beta1.Local.IsSkipped:output_type -> meshnet.v1beta1.BoolResponse
-	4, // 12: meshnet.v1beta1.Remote.Update:output_type -> meshnet.v1beta1.BoolResponse
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+type CanaryState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// share of the links, taken by UID order, that are set up
+	Fraction float64 `protobuf:"fixed64,1,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	// RFC3339 time the canary deployment has started at
+	Started string `protobuf:"bytes,2,opt,name=started,proto3" json:"started,omitempty"`
 }
 
-func init() { file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() }
-func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
-	if File_daemon_proto_meshnet_v1beta1_meshnet_proto != nil {
-		return
+func (x *CanaryState) Reset() {
+	*x = CanaryState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pod); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+}
+
+func (x *CanaryState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryState) ProtoMessage() {}
+
+func (x *CanaryState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryState.ProtoReflect.Descriptor instead.
+func (*CanaryState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CanaryState) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+func (x *CanaryState) GetStarted() string {
+	if x != nil {
+		return x.Started
+	}
+	return ""
+}
+
+type Link struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerPod   string `protobuf:"bytes,1,opt,name=peer_pod,json=peerPod,proto3" json:"peer_pod,omitempty"`
+	LocalIntf string `protobuf:"bytes,2,opt,name=local_intf,json=localIntf,proto3" json:"local_intf,omitempty"`
+	PeerIntf  string `protobuf:"bytes,3,opt,name=peer_intf,json=peerIntf,proto3" json:"peer_intf,omitempty"`
+	LocalIp   string `protobuf:"bytes,4,opt,name=local_ip,json=localIp,proto3" json:"local_ip,omitempty"`
+	PeerIp    string `protobuf:"bytes,5,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
+	Uid       int64  `protobuf:"varint,6,opt,name=uid,proto3" json:"uid,omitempty"`
+	// applied to the traffic sent out of the local interface
+	EgressImpairment *ImpairmentSpec `protobuf:"bytes,7,opt,name=egress_impairment,json=egressImpairment,proto3" json:"egress_impairment,omitempty"`
+	// applied to the traffic received on the local interface
+	IngressImpairment *ImpairmentSpec `protobuf:"bytes,8,opt,name=ingress_impairment,json=ingressImpairment,proto3" json:"ingress_impairment,omitempty"`
+	// use VXLAN-GPE when the peer is on another node
+	VxlanGpe bool `protobuf:"varint,9,opt,name=vxlan_gpe,json=vxlanGpe,proto3" json:"vxlan_gpe,omitempty"`
+	// MPLS label pushed on egress and popped on ingress, 0 for none
+	MplsLabel uint32 `protobuf:"varint,10,opt,name=mpls_label,json=mplsLabel,proto3" json:"mpls_label,omitempty"`
+	// destination prefix routed over all the links of the pod sharing it
+	EcmpGroup string `protobuf:"bytes,11,opt,name=ecmp_group,json=ecmpGroup,proto3" json:"ecmp_group,omitempty"`
+	// PCI address of an SR-IOV VF used as the local end, peer_pod must be localhost
+	SriovVfPciAddr string `protobuf:"bytes,12,opt,name=sriov_vf_pci_addr,json=sriovVfPciAddr,proto3" json:"sriov_vf_pci_addr,omitempty"`
+	// MAC addresses of the two ends, the peer's enables ARP suppression on VXLAN links
+	LocalMac string `protobuf:"bytes,13,opt,name=local_mac,json=localMac,proto3" json:"local_mac,omitempty"`
+	PeerMac  string `protobuf:"bytes,14,opt,name=peer_mac,json=peerMac,proto3" json:"peer_mac,omitempty"`
+	// MTU of the two ends, the interfaces' default when 0
+	Mtu int32 `protobuf:"varint,15,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	// LinkProfile of the pod's namespace whose values fill the fields the link doesn't set
+	Profile string `protobuf:"bytes,16,opt,name=profile,proto3" json:"profile,omitempty"`
+	// DSCP class the traffic sent out of the local interface is marked with, set by the
+	// traffic class policy of the topology
+	TrafficClass *TrafficClass `protobuf:"bytes,17,opt,name=traffic_class,json=trafficClass,proto3" json:"traffic_class,omitempty"`
+	// Secret of the pod's namespace holding the key material of the link, which is then a
+	// WireGuard tunnel when the peer is on another node. The keys are only read by the daemon
+	// setting up the tunnel, they're never logged nor returned.
+	Secret string `protobuf:"bytes,18,opt,name=secret,proto3" json:"secret,omitempty"`
+	// priority queue of the traffic sent out of the local interface, by DSCP class
+	EgressQosMap []*DscpQueueEntry `protobuf:"bytes,20,rep,name=egress_qos_map,json=egressQosMap,proto3" json:"egress_qos_map,omitempty"`
+	// bandwidth of the egress priority queues, used with egress_qos_map
+	EgressQueueAllocation *QueueAllocation `protobuf:"bytes,21,opt,name=egress_queue_allocation,json=egressQueueAllocation,proto3" json:"egress_queue_allocation,omitempty"`
+	// IPv6 segments to the peer, which make the link an SRv6 path when the peer is on another
+	// node. The last segment is the peer's srv6_local_sid.
+	Srv6Segments []string `protobuf:"bytes,22,rep,name=srv6_segments,json=srv6Segments,proto3" json:"srv6_segments,omitempty"`
+	// IPv6 SID of the local node terminating the peer's traffic of an SRv6 link
+	Srv6LocalSid string `protobuf:"bytes,23,opt,name=srv6_local_sid,json=srv6LocalSid,proto3" json:"srv6_local_sid,omitempty"`
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Link) GetPeerPod() string {
+	if x != nil {
+		return x.PeerPod
+	}
+	return ""
+}
+
+func (x *Link) GetLocalIntf() string {
+	if x != nil {
+		return x.LocalIntf
+	}
+	return ""
+}
+
+func (x *Link) GetPeerIntf() string {
+	if x != nil {
+		return x.PeerIntf
+	}
+	return ""
+}
+
+func (x *Link) GetLocalIp() string {
+	if x != nil {
+		return x.LocalIp
+	}
+	return ""
+}
+
+func (x *Link) GetPeerIp() string {
+	if x != nil {
+		return x.PeerIp
+	}
+	return ""
+}
+
+func (x *Link) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Link) GetEgressImpairment() *ImpairmentSpec {
+	if x != nil {
+		return x.EgressImpairment
+	}
+	return nil
+}
+
+func (x *Link) GetIngressImpairment() *ImpairmentSpec {
+	if x != nil {
+		return x.IngressImpairment
+	}
+	return nil
+}
+
+func (x *Link) GetVxlanGpe() bool {
+	if x != nil {
+		return x.VxlanGpe
+	}
+	return false
+}
+
+func (x *Link) GetMplsLabel() uint32 {
+	if x != nil {
+		return x.MplsLabel
+	}
+	return 0
+}
+
+func (x *Link) GetEcmpGroup() string {
+	if x != nil {
+		return x.EcmpGroup
+	}
+	return ""
+}
+
+func (x *Link) GetSriovVfPciAddr() string {
+	if x != nil {
+		return x.SriovVfPciAddr
+	}
+	return ""
+}
+
+func (x *Link) GetLocalMac() string {
+	if x != nil {
+		return x.LocalMac
+	}
+	return ""
+}
+
+func (x *Link) GetPeerMac() string {
+	if x != nil {
+		return x.PeerMac
+	}
+	return ""
+}
+
+func (x *Link) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *Link) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *Link) GetTrafficClass() *TrafficClass {
+	if x != nil {
+		return x.TrafficClass
+	}
+	return nil
+}
+
+func (x *Link) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Link) GetEgressQosMap() []*DscpQueueEntry {
+	if x != nil {
+		return x.EgressQosMap
+	}
+	return nil
+}
+
+func (x *Link) GetEgressQueueAllocation() *QueueAllocation {
+	if x != nil {
+		return x.EgressQueueAllocation
+	}
+	return nil
+}
+
+func (x *Link) GetSrv6Segments() []string {
+	if x != nil {
+		return x.Srv6Segments
+	}
+	return nil
+}
+
+func (x *Link) GetSrv6LocalSid() string {
+	if x != nil {
+		return x.Srv6LocalSid
+	}
+	return ""
+}
+
+// LinkCredentials are the WireGuard keys of one end of a link, in base64
+type LinkCredentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PrivateKey    string `protobuf:"bytes,1,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	PeerPublicKey string `protobuf:"bytes,2,opt,name=peer_public_key,json=peerPublicKey,proto3" json:"peer_public_key,omitempty"`
+	// optional
+	PresharedKey string `protobuf:"bytes,3,opt,name=preshared_key,json=presharedKey,proto3" json:"preshared_key,omitempty"`
+}
+
+func (x *LinkCredentials) Reset() {
+	*x = LinkCredentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkCredentials) ProtoMessage() {}
+
+func (x *LinkCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkCredentials.ProtoReflect.Descriptor instead.
+func (*LinkCredentials) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LinkCredentials) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *LinkCredentials) GetPeerPublicKey() string {
+	if x != nil {
+		return x.PeerPublicKey
+	}
+	return ""
+}
+
+func (x *LinkCredentials) GetPresharedKey() string {
+	if x != nil {
+		return x.PresharedKey
+	}
+	return ""
+}
+
+type TrafficClass struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dscp uint32 `protobuf:"varint,1,opt,name=dscp,proto3" json:"dscp,omitempty"`
+}
+
+func (x *TrafficClass) Reset() {
+	*x = TrafficClass{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrafficClass) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficClass) ProtoMessage() {}
+
+func (x *TrafficClass) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficClass.ProtoReflect.Descriptor instead.
+func (*TrafficClass) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TrafficClass) GetDscp() uint32 {
+	if x != nil {
+		return x.Dscp
+	}
+	return 0
+}
+
+// DscpQueueEntry classifies the packets of a DSCP class into the queue of a priority, from
+// 0, the highest, to 7
+type DscpQueueEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dscp     uint32 `protobuf:"varint,1,opt,name=dscp,proto3" json:"dscp,omitempty"`
+	Priority uint32 `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *DscpQueueEntry) Reset() {
+	*x = DscpQueueEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DscpQueueEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DscpQueueEntry) ProtoMessage() {}
+
+func (x *DscpQueueEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DscpQueueEntry.ProtoReflect.Descriptor instead.
+func (*DscpQueueEntry) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DscpQueueEntry) GetDscp() uint32 {
+	if x != nil {
+		return x.Dscp
+	}
+	return 0
+}
+
+func (x *DscpQueueEntry) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// QueueAllocation shares the egress bandwidth of a link between its priority queues
+type QueueAllocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// bandwidth shared by the queues, 1000 when 0
+	RateMbps int64             `protobuf:"varint,1,opt,name=rate_mbps,json=rateMbps,proto3" json:"rate_mbps,omitempty"`
+	Queues   []*QueueBandwidth `protobuf:"bytes,2,rep,name=queues,proto3" json:"queues,omitempty"`
+}
+
+func (x *QueueAllocation) Reset() {
+	*x = QueueAllocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueAllocation) ProtoMessage() {}
+
+func (x *QueueAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueAllocation.ProtoReflect.Descriptor instead.
+func (*QueueAllocation) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueueAllocation) GetRateMbps() int64 {
+	if x != nil {
+		return x.RateMbps
+	}
+	return 0
+}
+
+func (x *QueueAllocation) GetQueues() []*QueueBandwidth {
+	if x != nil {
+		return x.Queues
+	}
+	return nil
+}
+
+type QueueBandwidth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Priority uint32 `protobuf:"varint,1,opt,name=priority,proto3" json:"priority,omitempty"`
+	// percentage of rate_mbps the queue is guaranteed, the queues without one share the rest
+	Percent float32 `protobuf:"fixed32,2,opt,name=percent,proto3" json:"percent,omitempty"`
+}
+
+func (x *QueueBandwidth) Reset() {
+	*x = QueueBandwidth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueBandwidth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueBandwidth) ProtoMessage() {}
+
+func (x *QueueBandwidth) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueBandwidth.ProtoReflect.Descriptor instead.
+func (*QueueBandwidth) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QueueBandwidth) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *QueueBandwidth) GetPercent() float32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type ImpairmentSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LatencyMs        int64   `protobuf:"varint,1,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	JitterMs         int64   `protobuf:"varint,2,opt,name=jitter_ms,json=jitterMs,proto3" json:"jitter_ms,omitempty"`
+	LossPercent      float32 `protobuf:"fixed32,3,opt,name=loss_percent,json=lossPercent,proto3" json:"loss_percent,omitempty"`
+	DuplicatePercent float32 `protobuf:"fixed32,4,opt,name=duplicate_percent,json=duplicatePercent,proto3" json:"duplicate_percent,omitempty"`
+	CorruptPercent   float32 `protobuf:"fixed32,5,opt,name=corrupt_percent,json=corruptPercent,proto3" json:"corrupt_percent,omitempty"`
+	// mark congested packets with ECN through an fq_codel qdisc under netem
+	EcnEnabled bool `protobuf:"varint,6,opt,name=ecn_enabled,json=ecnEnabled,proto3" json:"ecn_enabled,omitempty"`
+	// codel target as a percentage of its interval, i.e. how long the queuing delay must stay
+	// above the 5ms target before packets are marked, 5% (100ms) when 0
+	EcnThresholdPercent float32 `protobuf:"fixed32,7,opt,name=ecn_threshold_percent,json=ecnThresholdPercent,proto3" json:"ecn_threshold_percent,omitempty"`
+	// percentage of packets sent right away rather than delayed by latency_ms, which must be
+	// set, correlated with the previous packet by reorder_correlation_percent
+	ReorderPercent            float32 `protobuf:"fixed32,8,opt,name=reorder_percent,json=reorderPercent,proto3" json:"reorder_percent,omitempty"`
+	ReorderCorrelationPercent float32 `protobuf:"fixed32,9,opt,name=reorder_correlation_percent,json=reorderCorrelationPercent,proto3" json:"reorder_correlation_percent,omitempty"`
+	// only every reorder_gap-th packet may be reordered, 1 when 0
+	ReorderGap uint32 `protobuf:"varint,10,opt,name=reorder_gap,json=reorderGap,proto3" json:"reorder_gap,omitempty"`
+}
+
+func (x *ImpairmentSpec) Reset() {
+	*x = ImpairmentSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImpairmentSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpairmentSpec) ProtoMessage() {}
+
+func (x *ImpairmentSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpairmentSpec.ProtoReflect.Descriptor instead.
+func (*ImpairmentSpec) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ImpairmentSpec) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetJitterMs() int64 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetLossPercent() float32 {
+	if x != nil {
+		return x.LossPercent
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetDuplicatePercent() float32 {
+	if x != nil {
+		return x.DuplicatePercent
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetCorruptPercent() float32 {
+	if x != nil {
+		return x.CorruptPercent
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetEcnEnabled() bool {
+	if x != nil {
+		return x.EcnEnabled
+	}
+	return false
+}
+
+func (x *ImpairmentSpec) GetEcnThresholdPercent() float32 {
+	if x != nil {
+		return x.EcnThresholdPercent
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetReorderPercent() float32 {
+	if x != nil {
+		return x.ReorderPercent
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetReorderCorrelationPercent() float32 {
+	if x != nil {
+		return x.ReorderCorrelationPercent
+	}
+	return 0
+}
+
+func (x *ImpairmentSpec) GetReorderGap() uint32 {
+	if x != nil {
+		return x.ReorderGap
+	}
+	return 0
+}
+
+type PodQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *PodQuery) Reset() {
+	*x = PodQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodQuery) ProtoMessage() {}
+
+func (x *PodQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodQuery.ProtoReflect.Descriptor instead.
+func (*PodQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PodQuery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PodQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+type SkipQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Peer   string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	KubeNs string `protobuf:"bytes,3,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *SkipQuery) Reset() {
+	*x = SkipQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SkipQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkipQuery) ProtoMessage() {}
+
+func (x *SkipQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkipQuery.ProtoReflect.Descriptor instead.
+func (*SkipQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SkipQuery) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *SkipQuery) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *SkipQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+type BoolResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Response bool `protobuf:"varint,1,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *BoolResponse) Reset() {
+	*x = BoolResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoolResponse) ProtoMessage() {}
+
+func (x *BoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoolResponse.ProtoReflect.Descriptor instead.
+func (*BoolResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BoolResponse) GetResponse() bool {
+	if x != nil {
+		return x.Response
+	}
+	return false
+}
+
+type RemotePod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetNs    string `protobuf:"bytes,1,opt,name=net_ns,json=netNs,proto3" json:"net_ns,omitempty"`
+	IntfName string `protobuf:"bytes,2,opt,name=intf_name,json=intfName,proto3" json:"intf_name,omitempty"`
+	IntfIp   string `protobuf:"bytes,3,opt,name=intf_ip,json=intfIp,proto3" json:"intf_ip,omitempty"`
+	// IPv4 or IPv6 address of the peer's node, the address family of the VXLAN underlay
+	PeerVtep   string     `protobuf:"bytes,4,opt,name=peer_vtep,json=peerVtep,proto3" json:"peer_vtep,omitempty"`
+	KubeNs     string     `protobuf:"bytes,5,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Vni        int64      `protobuf:"varint,6,opt,name=vni,proto3" json:"vni,omitempty"`
+	TunnelType TunnelType `protobuf:"varint,7,opt,name=tunnel_type,json=tunnelType,proto3,enum=meshnet.v1beta1.TunnelType" json:"tunnel_type,omitempty"`
+	// IPv6 segment list used to reach the peer, the last segment is the peer's SID
+	Srv6Segments []string `protobuf:"bytes,8,rep,name=srv6_segments,json=srv6Segments,proto3" json:"srv6_segments,omitempty"`
+	// IPv6 SID that terminates the peer's traffic towards this pod
+	Srv6LocalSid      string          `protobuf:"bytes,9,opt,name=srv6_local_sid,json=srv6LocalSid,proto3" json:"srv6_local_sid,omitempty"`
+	PodName           string          `protobuf:"bytes,10,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	EgressImpairment  *ImpairmentSpec `protobuf:"bytes,11,opt,name=egress_impairment,json=egressImpairment,proto3" json:"egress_impairment,omitempty"`
+	IngressImpairment *ImpairmentSpec `protobuf:"bytes,12,opt,name=ingress_impairment,json=ingressImpairment,proto3" json:"ingress_impairment,omitempty"`
+	// carry L3 payloads with VXLAN-GPE instead of Ethernet frames
+	VxlanGpe bool `protobuf:"varint,13,opt,name=vxlan_gpe,json=vxlanGpe,proto3" json:"vxlan_gpe,omitempty"`
+	// MPLS label of the link, 0 for none
+	MplsLabel uint32 `protobuf:"varint,14,opt,name=mpls_label,json=mplsLabel,proto3" json:"mpls_label,omitempty"`
+	// GTP-U tunnel endpoint ID, allocated from -gtp-teid-base when 0
+	GtpTeid uint32 `protobuf:"varint,15,opt,name=gtp_teid,json=gtpTeid,proto3" json:"gtp_teid,omitempty"`
+	// MAC address of the interface, random when empty
+	IntfMac string `protobuf:"bytes,16,opt,name=intf_mac,json=intfMac,proto3" json:"intf_mac,omitempty"`
+	// static FDB and ARP entries of a VXLAN link, which then answers ARP requests itself
+	Neighbors []*VXLANNeighbor `protobuf:"bytes,17,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+	// links of the pod that have to be up before this one is set up
+	PrerequisiteUids []int64 `protobuf:"varint,18,rep,packed,name=prerequisite_uids,json=prerequisiteUids,proto3" json:"prerequisite_uids,omitempty"`
+	// WireGuard public key of the peer's node, looked up in its meshnet-wg-<node IP> Secret when empty
+	WgPublicKey string `protobuf:"bytes,19,opt,name=wg_public_key,json=wgPublicKey,proto3" json:"wg_public_key,omitempty"`
+	// MTU of the interface, its default when 0
+	Mtu int32 `protobuf:"varint,20,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	// DSCP class the traffic sent out of the interface is marked with
+	TrafficClass *TrafficClass `protobuf:"bytes,21,opt,name=traffic_class,json=trafficClass,proto3" json:"traffic_class,omitempty"`
+	// keys of a WireGuard link used instead of the node's, never logged
+	Credentials *LinkCredentials `protobuf:"bytes,22,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	// priority queues of the traffic sent out of the interface, by DSCP class
+	EgressQosMap          []*DscpQueueEntry `protobuf:"bytes,23,rep,name=egress_qos_map,json=egressQosMap,proto3" json:"egress_qos_map,omitempty"`
+	EgressQueueAllocation *QueueAllocation  `protobuf:"bytes,24,opt,name=egress_queue_allocation,json=egressQueueAllocation,proto3" json:"egress_queue_allocation,omitempty"`
+}
+
+func (x *RemotePod) Reset() {
+	*x = RemotePod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemotePod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemotePod) ProtoMessage() {}
+
+func (x *RemotePod) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemotePod.ProtoReflect.Descriptor instead.
+func (*RemotePod) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemotePod) GetNetNs() string {
+	if x != nil {
+		return x.NetNs
+	}
+	return ""
+}
+
+func (x *RemotePod) GetIntfName() string {
+	if x != nil {
+		return x.IntfName
+	}
+	return ""
+}
+
+func (x *RemotePod) GetIntfIp() string {
+	if x != nil {
+		return x.IntfIp
+	}
+	return ""
+}
+
+func (x *RemotePod) GetPeerVtep() string {
+	if x != nil {
+		return x.PeerVtep
+	}
+	return ""
+}
+
+func (x *RemotePod) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *RemotePod) GetVni() int64 {
+	if x != nil {
+		return x.Vni
+	}
+	return 0
+}
+
+func (x *RemotePod) GetTunnelType() TunnelType {
+	if x != nil {
+		return x.TunnelType
+	}
+	return TunnelType_VXLAN
+}
+
+func (x *RemotePod) GetSrv6Segments() []string {
+	if x != nil {
+		return x.Srv6Segments
+	}
+	return nil
+}
+
+func (x *RemotePod) GetSrv6LocalSid() string {
+	if x != nil {
+		return x.Srv6LocalSid
+	}
+	return ""
+}
+
+func (x *RemotePod) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *RemotePod) GetEgressImpairment() *ImpairmentSpec {
+	if x != nil {
+		return x.EgressImpairment
+	}
+	return nil
+}
+
+func (x *RemotePod) GetIngressImpairment() *ImpairmentSpec {
+	if x != nil {
+		return x.IngressImpairment
+	}
+	return nil
+}
+
+func (x *RemotePod) GetVxlanGpe() bool {
+	if x != nil {
+		return x.VxlanGpe
+	}
+	return false
+}
+
+func (x *RemotePod) GetMplsLabel() uint32 {
+	if x != nil {
+		return x.MplsLabel
+	}
+	return 0
+}
+
+func (x *RemotePod) GetGtpTeid() uint32 {
+	if x != nil {
+		return x.GtpTeid
+	}
+	return 0
+}
+
+func (x *RemotePod) GetIntfMac() string {
+	if x != nil {
+		return x.IntfMac
+	}
+	return ""
+}
+
+func (x *RemotePod) GetNeighbors() []*VXLANNeighbor {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+func (x *RemotePod) GetPrerequisiteUids() []int64 {
+	if x != nil {
+		return x.PrerequisiteUids
+	}
+	return nil
+}
+
+func (x *RemotePod) GetWgPublicKey() string {
+	if x != nil {
+		return x.WgPublicKey
+	}
+	return ""
+}
+
+func (x *RemotePod) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *RemotePod) GetTrafficClass() *TrafficClass {
+	if x != nil {
+		return x.TrafficClass
+	}
+	return nil
+}
+
+func (x *RemotePod) GetCredentials() *LinkCredentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *RemotePod) GetEgressQosMap() []*DscpQueueEntry {
+	if x != nil {
+		return x.EgressQosMap
+	}
+	return nil
+}
+
+func (x *RemotePod) GetEgressQueueAllocation() *QueueAllocation {
+	if x != nil {
+		return x.EgressQueueAllocation
+	}
+	return nil
+}
+
+// VXLANNeighbor is a host reachable over a VXLAN interface
+type VXLANNeighbor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IP address, without a prefix length
+	Ip  string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Mac string `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	// VTEP the host is behind
+	VtepIp string `protobuf:"bytes,3,opt,name=vtep_ip,json=vtepIp,proto3" json:"vtep_ip,omitempty"`
+}
+
+func (x *VXLANNeighbor) Reset() {
+	*x = VXLANNeighbor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VXLANNeighbor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VXLANNeighbor) ProtoMessage() {}
+
+func (x *VXLANNeighbor) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VXLANNeighbor.ProtoReflect.Descriptor instead.
+func (*VXLANNeighbor) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VXLANNeighbor) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *VXLANNeighbor) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *VXLANNeighbor) GetVtepIp() string {
+	if x != nil {
+		return x.VtepIp
+	}
+	return ""
+}
+
+type VXLANNeighborUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetNs     string           `protobuf:"bytes,1,opt,name=net_ns,json=netNs,proto3" json:"net_ns,omitempty"`
+	IntfName  string           `protobuf:"bytes,2,opt,name=intf_name,json=intfName,proto3" json:"intf_name,omitempty"`
+	Neighbors []*VXLANNeighbor `protobuf:"bytes,3,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+}
+
+func (x *VXLANNeighborUpdate) Reset() {
+	*x = VXLANNeighborUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VXLANNeighborUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VXLANNeighborUpdate) ProtoMessage() {}
+
+func (x *VXLANNeighborUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VXLANNeighborUpdate.ProtoReflect.Descriptor instead.
+func (*VXLANNeighborUpdate) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VXLANNeighborUpdate) GetNetNs() string {
+	if x != nil {
+		return x.NetNs
+	}
+	return ""
+}
+
+func (x *VXLANNeighborUpdate) GetIntfName() string {
+	if x != nil {
+		return x.IntfName
+	}
+	return ""
+}
+
+func (x *VXLANNeighborUpdate) GetNeighbors() []*VXLANNeighbor {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+// FanoutWireDef makes the end of a link of a pod flood its frames to other pods of its
+// broadcast domain, the pods with a link of the same uid
+type FanoutWireDef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	// pods the frames are sent to, besides the peer of the link
+	Peers []string           `protobuf:"bytes,4,rep,name=peers,proto3" json:"peers,omitempty"`
+	Mode  FanoutWireDef_Mode `protobuf:"varint,5,opt,name=mode,proto3,enum=meshnet.v1beta1.FanoutWireDef_Mode" json:"mode,omitempty"`
+}
+
+func (x *FanoutWireDef) Reset() {
+	*x = FanoutWireDef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FanoutWireDef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FanoutWireDef) ProtoMessage() {}
+
+func (x *FanoutWireDef) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FanoutWireDef.ProtoReflect.Descriptor instead.
+func (*FanoutWireDef) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FanoutWireDef) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *FanoutWireDef) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *FanoutWireDef) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *FanoutWireDef) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *FanoutWireDef) GetMode() FanoutWireDef_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return FanoutWireDef_BEST_EFFORT
+}
+
+type LinkPatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation LinkPatch_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=meshnet.v1beta1.LinkPatch_Operation" json:"operation,omitempty"`
+	// name of the pod that the link belongs to
+	Pod    string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs string `protobuf:"bytes,3,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Link   *Link  `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"`
+}
+
+func (x *LinkPatch) Reset() {
+	*x = LinkPatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPatch) ProtoMessage() {}
+
+func (x *LinkPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPatch.ProtoReflect.Descriptor instead.
+func (*LinkPatch) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LinkPatch) GetOperation() LinkPatch_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return LinkPatch_UNSPECIFIED
+}
+
+func (x *LinkPatch) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *LinkPatch) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *LinkPatch) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type WireAudit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pod and namespace of the topology that the entry is recorded in
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	// which code path has set up the wire, e.g. CNI_ADD or REMOTE_UPDATE
+	HowCreated string `protobuf:"bytes,4,opt,name=how_created,json=howCreated,proto3" json:"how_created,omitempty"`
+	// RFC3339 timestamp, set by the daemon if empty
+	CreatedAt string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	NodeIp    string `protobuf:"bytes,6,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	// veth, vxlan, srv6 or macvlan
+	WireType string `protobuf:"bytes,7,opt,name=wire_type,json=wireType,proto3" json:"wire_type,omitempty"`
+	// the entry is recorded in the background, so that the call doesn't wait for K8s. It fails
+	// with RESOURCE_EXHAUSTED if too many entries are waiting.
+	Queue bool `protobuf:"varint,8,opt,name=queue,proto3" json:"queue,omitempty"`
+}
+
+func (x *WireAudit) Reset() {
+	*x = WireAudit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireAudit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireAudit) ProtoMessage() {}
+
+func (x *WireAudit) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireAudit.ProtoReflect.Descriptor instead.
+func (*WireAudit) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WireAudit) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *WireAudit) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *WireAudit) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *WireAudit) GetHowCreated() string {
+	if x != nil {
+		return x.HowCreated
+	}
+	return ""
+}
+
+func (x *WireAudit) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *WireAudit) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+func (x *WireAudit) GetWireType() string {
+	if x != nil {
+		return x.WireType
+	}
+	return ""
+}
+
+func (x *WireAudit) GetQueue() bool {
+	if x != nil {
+		return x.Queue
+	}
+	return false
+}
+
+type RollbackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the topology, i.e. of its pod
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KubeNs   string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Revision int64  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *RollbackRequest) Reset() {
+	*x = RollbackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RollbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRequest) ProtoMessage() {}
+
+func (x *RollbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRequest.ProtoReflect.Descriptor instead.
+func (*RollbackRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RollbackRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RollbackRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *RollbackRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type HealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{19}
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status HealthStatus `protobuf:"varint,1,opt,name=status,proto3,enum=meshnet.v1beta1.HealthStatus" json:"status,omitempty"`
+	// number of links of the pods on this node with both ends up
+	ActiveWires int64  `protobuf:"varint,2,opt,name=active_wires,json=activeWires,proto3" json:"active_wires,omitempty"`
+	Message     string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// nodes whose daemon hasn't answered the last heartbeat, their wires are considered down
+	UnreachablePeers []string `protobuf:"bytes,4,rep,name=unreachable_peers,json=unreachablePeers,proto3" json:"unreachable_peers,omitempty"`
+	// wires re-created by the watchdog because their interface was missing
+	WireWatchdogRestarts int64 `protobuf:"varint,5,opt,name=wire_watchdog_restarts,json=wireWatchdogRestarts,proto3" json:"wire_watchdog_restarts,omitempty"`
+	// maximum number of wires of the pods of this node, 0 for no limit
+	ActiveWiresLimit int64 `protobuf:"varint,6,opt,name=active_wires_limit,json=activeWiresLimit,proto3" json:"active_wires_limit,omitempty"`
+	// wires of the pods of this node counted against the limit
+	ActiveWiresCurrent int64 `protobuf:"varint,7,opt,name=active_wires_current,json=activeWiresCurrent,proto3" json:"active_wires_current,omitempty"`
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HealthResponse) GetStatus() HealthStatus {
+	if x != nil {
+		return x.Status
+	}
+	return HealthStatus_HEALTHY
+}
+
+func (x *HealthResponse) GetActiveWires() int64 {
+	if x != nil {
+		return x.ActiveWires
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetUnreachablePeers() []string {
+	if x != nil {
+		return x.UnreachablePeers
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetWireWatchdogRestarts() int64 {
+	if x != nil {
+		return x.WireWatchdogRestarts
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetActiveWiresLimit() int64 {
+	if x != nil {
+		return x.ActiveWiresLimit
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetActiveWiresCurrent() int64 {
+	if x != nil {
+		return x.ActiveWiresCurrent
+	}
+	return 0
+}
+
+type ShutdownNotice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IP of the node whose daemon is shutting down
+	NodeIp string `protobuf:"bytes,1,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+}
+
+func (x *ShutdownNotice) Reset() {
+	*x = ShutdownNotice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShutdownNotice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownNotice) ProtoMessage() {}
+
+func (x *ShutdownNotice) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownNotice.ProtoReflect.Descriptor instead.
+func (*ShutdownNotice) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ShutdownNotice) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+// EvacuationRequest hands the wires of this node off before it's drained
+type EvacuationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// don't wait for the pods to run on other nodes, their wires are lost until they do
+	AcceptWireLoss bool `protobuf:"varint,1,opt,name=accept_wire_loss,json=acceptWireLoss,proto3" json:"accept_wire_loss,omitempty"`
+}
+
+func (x *EvacuationRequest) Reset() {
+	*x = EvacuationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvacuationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvacuationRequest) ProtoMessage() {}
+
+func (x *EvacuationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvacuationRequest.ProtoReflect.Descriptor instead.
+func (*EvacuationRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EvacuationRequest) GetAcceptWireLoss() bool {
+	if x != nil {
+		return x.AcceptWireLoss
+	}
+	return false
+}
+
+type EvacuatedPod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Wires  int64  `protobuf:"varint,3,opt,name=wires,proto3" json:"wires,omitempty"`
+	// node the pod runs on after the evacuation, empty if it hasn't been rescheduled yet
+	NodeIp string `protobuf:"bytes,4,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	// the pod runs on another node with all its wires up
+	Reestablished bool `protobuf:"varint,5,opt,name=reestablished,proto3" json:"reestablished,omitempty"`
+}
+
+func (x *EvacuatedPod) Reset() {
+	*x = EvacuatedPod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvacuatedPod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvacuatedPod) ProtoMessage() {}
+
+func (x *EvacuatedPod) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvacuatedPod.ProtoReflect.Descriptor instead.
+func (*EvacuatedPod) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EvacuatedPod) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *EvacuatedPod) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *EvacuatedPod) GetWires() int64 {
+	if x != nil {
+		return x.Wires
+	}
+	return 0
+}
+
+func (x *EvacuatedPod) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+func (x *EvacuatedPod) GetReestablished() bool {
+	if x != nil {
+		return x.Reestablished
+	}
+	return false
+}
+
+type EvacuationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pods []*EvacuatedPod `protobuf:"bytes,1,rep,name=pods,proto3" json:"pods,omitempty"`
+	// nodes whose daemons have been notified that the wires to this node are going down
+	NotifiedNodes []string `protobuf:"bytes,2,rep,name=notified_nodes,json=notifiedNodes,proto3" json:"notified_nodes,omitempty"`
+	// wires of the pods that haven't been re-established on another node
+	LostWires int64 `protobuf:"varint,3,opt,name=lost_wires,json=lostWires,proto3" json:"lost_wires,omitempty"`
+	// the pods haven't all been re-established within the evacuation timeout
+	TimedOut bool `protobuf:"varint,4,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+}
+
+func (x *EvacuationResult) Reset() {
+	*x = EvacuationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvacuationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvacuationResult) ProtoMessage() {}
+
+func (x *EvacuationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvacuationResult.ProtoReflect.Descriptor instead.
+func (*EvacuationResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EvacuationResult) GetPods() []*EvacuatedPod {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+func (x *EvacuationResult) GetNotifiedNodes() []string {
+	if x != nil {
+		return x.NotifiedNodes
+	}
+	return nil
+}
+
+func (x *EvacuationResult) GetLostWires() int64 {
+	if x != nil {
+		return x.LostWires
+	}
+	return 0
+}
+
+func (x *EvacuationResult) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IP of the node sending the heartbeat
+	NodeIp string `protobuf:"bytes,1,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HeartbeatRequest) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IP of the node answering it
+	NodeIp string `protobuf:"bytes,1,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *HeartbeatResponse) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+type LinkStatsQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+}
+
+func (x *LinkStatsQuery) Reset() {
+	*x = LinkStatsQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkStatsQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStatsQuery) ProtoMessage() {}
+
+func (x *LinkStatsQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStatsQuery.ProtoReflect.Descriptor instead.
+func (*LinkStatsQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LinkStatsQuery) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *LinkStatsQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *LinkStatsQuery) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+// interface counters of one end of a link
+type LinkStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod       string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Intf      string `protobuf:"bytes,2,opt,name=intf,proto3" json:"intf,omitempty"`
+	NodeIp    string `protobuf:"bytes,3,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	RxPackets uint64 `protobuf:"varint,4,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
+	TxPackets uint64 `protobuf:"varint,5,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"`
+	RxBytes   uint64 `protobuf:"varint,6,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`
+	TxBytes   uint64 `protobuf:"varint,7,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	RxErrors  uint64 `protobuf:"varint,8,opt,name=rx_errors,json=rxErrors,proto3" json:"rx_errors,omitempty"`
+	TxErrors  uint64 `protobuf:"varint,9,opt,name=tx_errors,json=txErrors,proto3" json:"tx_errors,omitempty"`
+	RxDropped uint64 `protobuf:"varint,10,opt,name=rx_dropped,json=rxDropped,proto3" json:"rx_dropped,omitempty"`
+	TxDropped uint64 `protobuf:"varint,11,opt,name=tx_dropped,json=txDropped,proto3" json:"tx_dropped,omitempty"`
+	// number of times the link has been brought down by a flap simulation
+	FlapCount int64  `protobuf:"varint,12,opt,name=flap_count,json=flapCount,proto3" json:"flap_count,omitempty"`
+	LastFlap  string `protobuf:"bytes,13,opt,name=last_flap,json=lastFlap,proto3" json:"last_flap,omitempty"`
+	// OpenFlow port number of the link on the OVS bridge, 0 without the OVS data plane
+	Ofport int64 `protobuf:"varint,14,opt,name=ofport,proto3" json:"ofport,omitempty"`
+	// packets received on the link and held by its ingress impairment, 0 without one
+	RxQueueDepth uint64 `protobuf:"varint,15,opt,name=rx_queue_depth,json=rxQueueDepth,proto3" json:"rx_queue_depth,omitempty"`
+	// packets dropped because the queue of the ingress impairment was full
+	BackpressureEventsTotal uint64 `protobuf:"varint,16,opt,name=backpressure_events_total,json=backpressureEventsTotal,proto3" json:"backpressure_events_total,omitempty"`
+	// bytes sent out of the link and marked by its traffic class, by DSCP class
+	DscpTxBytes map[uint32]uint64 `protobuf:"bytes,17,rep,name=dscp_tx_bytes,json=dscpTxBytes,proto3" json:"dscp_tx_bytes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// uid of the link, only set in the batches of StreamLinkStats
+	LinkUid int64 `protobuf:"varint,18,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+}
+
+func (x *LinkStats) Reset() {
+	*x = LinkStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStats) ProtoMessage() {}
+
+func (x *LinkStats) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStats.ProtoReflect.Descriptor instead.
+func (*LinkStats) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LinkStats) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *LinkStats) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *LinkStats) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+func (x *LinkStats) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *LinkStats) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *LinkStats) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *LinkStats) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *LinkStats) GetRxErrors() uint64 {
+	if x != nil {
+		return x.RxErrors
+	}
+	return 0
+}
+
+func (x *LinkStats) GetTxErrors() uint64 {
+	if x != nil {
+		return x.TxErrors
+	}
+	return 0
+}
+
+func (x *LinkStats) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
+
+func (x *LinkStats) GetTxDropped() uint64 {
+	if x != nil {
+		return x.TxDropped
+	}
+	return 0
+}
+
+func (x *LinkStats) GetFlapCount() int64 {
+	if x != nil {
+		return x.FlapCount
+	}
+	return 0
+}
+
+func (x *LinkStats) GetLastFlap() string {
+	if x != nil {
+		return x.LastFlap
+	}
+	return ""
+}
+
+func (x *LinkStats) GetOfport() int64 {
+	if x != nil {
+		return x.Ofport
+	}
+	return 0
+}
+
+func (x *LinkStats) GetRxQueueDepth() uint64 {
+	if x != nil {
+		return x.RxQueueDepth
+	}
+	return 0
+}
+
+func (x *LinkStats) GetBackpressureEventsTotal() uint64 {
+	if x != nil {
+		return x.BackpressureEventsTotal
+	}
+	return 0
+}
+
+func (x *LinkStats) GetDscpTxBytes() map[uint32]uint64 {
+	if x != nil {
+		return x.DscpTxBytes
+	}
+	return nil
+}
+
+func (x *LinkStats) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+// StatsStreamRequest streams the stats of the links of a pod
+type StatsStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	// uids of the links to stream, all of them if empty
+	LinkUids []int64 `protobuf:"varint,3,rep,packed,name=link_uids,json=linkUids,proto3" json:"link_uids,omitempty"`
+	// time between two batches, 1000 if 0
+	IntervalMs int64                   `protobuf:"varint,4,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	Mode       StatsStreamRequest_Mode `protobuf:"varint,5,opt,name=mode,proto3,enum=meshnet.v1beta1.StatsStreamRequest_Mode" json:"mode,omitempty"`
+}
+
+func (x *StatsStreamRequest) Reset() {
+	*x = StatsStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsStreamRequest) ProtoMessage() {}
+
+func (x *StatsStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsStreamRequest.ProtoReflect.Descriptor instead.
+func (*StatsStreamRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StatsStreamRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *StatsStreamRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *StatsStreamRequest) GetLinkUids() []int64 {
+	if x != nil {
+		return x.LinkUids
+	}
+	return nil
+}
+
+func (x *StatsStreamRequest) GetIntervalMs() int64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *StatsStreamRequest) GetMode() StatsStreamRequest_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return StatsStreamRequest_CUMULATIVE
+}
+
+// StatsBatch has the stats of the streamed links read at the same time
+type StatsBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RFC3339 time the stats have been read at
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// time since the previous batch, the period of the deltas in RATE mode
+	ElapsedMs int64        `protobuf:"varint,2,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Stats     []*LinkStats `protobuf:"bytes,3,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *StatsBatch) Reset() {
+	*x = StatsBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsBatch) ProtoMessage() {}
+
+func (x *StatsBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsBatch.ProtoReflect.Descriptor instead.
+func (*StatsBatch) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StatsBatch) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *StatsBatch) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *StatsBatch) GetStats() []*LinkStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// FlapSpec brings a link of a pod on this node down and up in a loop
+type FlapSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod            string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs         string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid        int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	UpDurationMs   int64  `protobuf:"varint,4,opt,name=up_duration_ms,json=upDurationMs,proto3" json:"up_duration_ms,omitempty"`
+	DownDurationMs int64  `protobuf:"varint,5,opt,name=down_duration_ms,json=downDurationMs,proto3" json:"down_duration_ms,omitempty"`
+}
+
+func (x *FlapSpec) Reset() {
+	*x = FlapSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlapSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlapSpec) ProtoMessage() {}
+
+func (x *FlapSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlapSpec.ProtoReflect.Descriptor instead.
+func (*FlapSpec) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FlapSpec) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *FlapSpec) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *FlapSpec) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *FlapSpec) GetUpDurationMs() int64 {
+	if x != nil {
+		return x.UpDurationMs
+	}
+	return 0
+}
+
+func (x *FlapSpec) GetDownDurationMs() int64 {
+	if x != nil {
+		return x.DownDurationMs
+	}
+	return 0
+}
+
+type AggregatedLinkStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Local  *LinkStats `protobuf:"bytes,1,opt,name=local,proto3" json:"local,omitempty"`
+	Remote *LinkStats `protobuf:"bytes,2,opt,name=remote,proto3" json:"remote,omitempty"`
+	// set when the stats of one end could not be read
+	Warning string `protobuf:"bytes,3,opt,name=warning,proto3" json:"warning,omitempty"`
+}
+
+func (x *AggregatedLinkStats) Reset() {
+	*x = AggregatedLinkStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregatedLinkStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatedLinkStats) ProtoMessage() {}
+
+func (x *AggregatedLinkStats) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatedLinkStats.ProtoReflect.Descriptor instead.
+func (*AggregatedLinkStats) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AggregatedLinkStats) GetLocal() *LinkStats {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *AggregatedLinkStats) GetRemote() *LinkStats {
+	if x != nil {
+		return x.Remote
+	}
+	return nil
+}
+
+func (x *AggregatedLinkStats) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+// MTU of the interface of one end of a link
+type LinkMTU struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Intf   string `protobuf:"bytes,2,opt,name=intf,proto3" json:"intf,omitempty"`
+	NodeIp string `protobuf:"bytes,3,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	Mtu    int32  `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
+}
+
+func (x *LinkMTU) Reset() {
+	*x = LinkMTU{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkMTU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkMTU) ProtoMessage() {}
+
+func (x *LinkMTU) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkMTU.ProtoReflect.Descriptor instead.
+func (*LinkMTU) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LinkMTU) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *LinkMTU) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *LinkMTU) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+func (x *LinkMTU) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+type MTUResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Local *LinkMTU `protobuf:"bytes,1,opt,name=local,proto3" json:"local,omitempty"`
+	// unset for macvlan links
+	Remote *LinkMTU `protobuf:"bytes,2,opt,name=remote,proto3" json:"remote,omitempty"`
+}
+
+func (x *MTUResponse) Reset() {
+	*x = MTUResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MTUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MTUResponse) ProtoMessage() {}
+
+func (x *MTUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MTUResponse.ProtoReflect.Descriptor instead.
+func (*MTUResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MTUResponse) GetLocal() *LinkMTU {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *MTUResponse) GetRemote() *LinkMTU {
+	if x != nil {
+		return x.Remote
+	}
+	return nil
+}
+
+// ImpairmentPatch changes the impairments of the end of a link in a pod without re-creating
+// its wire. An unset spec leaves its direction as it is, and an empty one removes its
+// impairment.
+type ImpairmentPatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string          `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string          `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64           `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	Egress  *ImpairmentSpec `protobuf:"bytes,4,opt,name=egress,proto3" json:"egress,omitempty"`
+	Ingress *ImpairmentSpec `protobuf:"bytes,5,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	// the specs are added to the current impairments instead of replacing them
+	Relative bool `protobuf:"varint,6,opt,name=relative,proto3" json:"relative,omitempty"`
+}
+
+func (x *ImpairmentPatch) Reset() {
+	*x = ImpairmentPatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImpairmentPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpairmentPatch) ProtoMessage() {}
+
+func (x *ImpairmentPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpairmentPatch.ProtoReflect.Descriptor instead.
+func (*ImpairmentPatch) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ImpairmentPatch) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *ImpairmentPatch) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *ImpairmentPatch) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *ImpairmentPatch) GetEgress() *ImpairmentSpec {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *ImpairmentPatch) GetIngress() *ImpairmentSpec {
+	if x != nil {
+		return x.Ingress
+	}
+	return nil
+}
+
+func (x *ImpairmentPatch) GetRelative() bool {
+	if x != nil {
+		return x.Relative
+	}
+	return false
+}
+
+// impairments of the end of a link in a pod, read from its netem qdiscs
+type WireImpairment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unset when the direction has no impairment
+	Egress  *ImpairmentSpec `protobuf:"bytes,1,opt,name=egress,proto3" json:"egress,omitempty"`
+	Ingress *ImpairmentSpec `protobuf:"bytes,2,opt,name=ingress,proto3" json:"ingress,omitempty"`
+}
+
+func (x *WireImpairment) Reset() {
+	*x = WireImpairment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireImpairment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireImpairment) ProtoMessage() {}
+
+func (x *WireImpairment) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireImpairment.ProtoReflect.Descriptor instead.
+func (*WireImpairment) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WireImpairment) GetEgress() *ImpairmentSpec {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *WireImpairment) GetIngress() *ImpairmentSpec {
+	if x != nil {
+		return x.Ingress
+	}
+	return nil
+}
+
+type MTURequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	Mtu     int32  `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
+}
+
+func (x *MTURequest) Reset() {
+	*x = MTURequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MTURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MTURequest) ProtoMessage() {}
+
+func (x *MTURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MTURequest.ProtoReflect.Descriptor instead.
+func (*MTURequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *MTURequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *MTURequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *MTURequest) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *MTURequest) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+// path between two pods through the links of their topologies
+type PathQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KubeNs string `protobuf:"bytes,1,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	SrcPod string `protobuf:"bytes,2,opt,name=src_pod,json=srcPod,proto3" json:"src_pod,omitempty"`
+	DstPod string `protobuf:"bytes,3,opt,name=dst_pod,json=dstPod,proto3" json:"dst_pod,omitempty"`
+}
+
+func (x *PathQuery) Reset() {
+	*x = PathQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathQuery) ProtoMessage() {}
+
+func (x *PathQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathQuery.ProtoReflect.Descriptor instead.
+func (*PathQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PathQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *PathQuery) GetSrcPod() string {
+	if x != nil {
+		return x.SrcPod
+	}
+	return ""
+}
+
+func (x *PathQuery) GetDstPod() string {
+	if x != nil {
+		return x.DstPod
+	}
+	return ""
+}
+
+// path MTU of a link of a path, probed from the end of pod
+type HopMTU struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	LinkUid int64  `protobuf:"varint,2,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	NodeIp  string `protobuf:"bytes,3,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	Mtu     int32  `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
+}
+
+func (x *HopMTU) Reset() {
+	*x = HopMTU{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HopMTU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HopMTU) ProtoMessage() {}
+
+func (x *HopMTU) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HopMTU.ProtoReflect.Descriptor instead.
+func (*HopMTU) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *HopMTU) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *HopMTU) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *HopMTU) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+func (x *HopMTU) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+type MTUDiscoveryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// smallest MTU of the hops of the path
+	PathMtu int32 `protobuf:"varint,1,opt,name=path_mtu,json=pathMtu,proto3" json:"path_mtu,omitempty"`
+	// link whose MTU is the path MTU
+	BottleneckUid int64 `protobuf:"varint,2,opt,name=bottleneck_uid,json=bottleneckUid,proto3" json:"bottleneck_uid,omitempty"`
+	// links of the path from the source pod to the destination pod
+	Hops []*HopMTU `protobuf:"bytes,3,rep,name=hops,proto3" json:"hops,omitempty"`
+}
+
+func (x *MTUDiscoveryResult) Reset() {
+	*x = MTUDiscoveryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MTUDiscoveryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MTUDiscoveryResult) ProtoMessage() {}
+
+func (x *MTUDiscoveryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MTUDiscoveryResult.ProtoReflect.Descriptor instead.
+func (*MTUDiscoveryResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *MTUDiscoveryResult) GetPathMtu() int32 {
+	if x != nil {
+		return x.PathMtu
+	}
+	return 0
+}
+
+func (x *MTUDiscoveryResult) GetBottleneckUid() int64 {
+	if x != nil {
+		return x.BottleneckUid
+	}
+	return 0
+}
+
+func (x *MTUDiscoveryResult) GetHops() []*HopMTU {
+	if x != nil {
+		return x.Hops
+	}
+	return nil
+}
+
+type WireVerificationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+}
+
+func (x *WireVerificationRequest) Reset() {
+	*x = WireVerificationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireVerificationRequest) ProtoMessage() {}
+
+func (x *WireVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireVerificationRequest.ProtoReflect.Descriptor instead.
+func (*WireVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WireVerificationRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *WireVerificationRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *WireVerificationRequest) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+type WireRepairRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+}
+
+func (x *WireRepairRequest) Reset() {
+	*x = WireRepairRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireRepairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireRepairRequest) ProtoMessage() {}
+
+func (x *WireRepairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireRepairRequest.ProtoReflect.Descriptor instead.
+func (*WireRepairRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WireRepairRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *WireRepairRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *WireRepairRequest) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+// check of the end of a wire in a pod
+type WireCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind WireCheck_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=meshnet.v1beta1.WireCheck_Kind" json:"kind,omitempty"`
+	Ok   bool           `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	// the check doesn't apply to the wire, e.g. its peer isn't running
+	Skipped bool `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// why the check has failed or has been skipped
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *WireCheck) Reset() {
+	*x = WireCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireCheck) ProtoMessage() {}
+
+func (x *WireCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireCheck.ProtoReflect.Descriptor instead.
+func (*WireCheck) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WireCheck) GetKind() WireCheck_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return WireCheck_INTERFACE
+}
+
+func (x *WireCheck) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *WireCheck) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *WireCheck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type WireRepair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action  WireRepair_Action `protobuf:"varint,1,opt,name=action,proto3,enum=meshnet.v1beta1.WireRepair_Action" json:"action,omitempty"`
+	Message string            `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *WireRepair) Reset() {
+	*x = WireRepair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireRepair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireRepair) ProtoMessage() {}
+
+func (x *WireRepair) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireRepair.ProtoReflect.Descriptor instead.
+func (*WireRepair) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WireRepair) GetAction() WireRepair_Action {
+	if x != nil {
+		return x.Action
+	}
+	return WireRepair_SET_UP
+}
+
+func (x *WireRepair) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RepairResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Checks []*WireCheck `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"`
+	// repairs of the failed checks, in the order they have been performed
+	Repairs []*WireRepair `protobuf:"bytes,2,rep,name=repairs,proto3" json:"repairs,omitempty"`
+	// checks after the repairs, unset if there were none
+	ChecksAfter []*WireCheck `protobuf:"bytes,3,rep,name=checks_after,json=checksAfter,proto3" json:"checks_after,omitempty"`
+	// all the checks pass, after the repairs if any
+	Healthy bool `protobuf:"varint,4,opt,name=healthy,proto3" json:"healthy,omitempty"`
+}
+
+func (x *RepairResult) Reset() {
+	*x = RepairResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepairResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairResult) ProtoMessage() {}
+
+func (x *RepairResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairResult.ProtoReflect.Descriptor instead.
+func (*RepairResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RepairResult) GetChecks() []*WireCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *RepairResult) GetRepairs() []*WireRepair {
+	if x != nil {
+		return x.Repairs
+	}
+	return nil
+}
+
+func (x *RepairResult) GetChecksAfter() []*WireCheck {
+	if x != nil {
+		return x.ChecksAfter
+	}
+	return nil
+}
+
+func (x *RepairResult) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+// one end of a wire, as set up in the kernel of its node
+type WireParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod      string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs   string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid  int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	NodeIp   string `protobuf:"bytes,4,opt,name=node_ip,json=nodeIp,proto3" json:"node_ip,omitempty"`
+	IntfName string `protobuf:"bytes,5,opt,name=intf_name,json=intfName,proto3" json:"intf_name,omitempty"`
+	// interface of the other end, according to the pod's topology
+	PeerIntf string `protobuf:"bytes,6,opt,name=peer_intf,json=peerIntf,proto3" json:"peer_intf,omitempty"`
+	// netlink type of the interface, e.g. veth or vxlan
+	WireType string `protobuf:"bytes,7,opt,name=wire_type,json=wireType,proto3" json:"wire_type,omitempty"`
+	// unset unless wire_type is vxlan
+	Vni        int64  `protobuf:"varint,8,opt,name=vni,proto3" json:"vni,omitempty"`
+	RemoteVtep string `protobuf:"bytes,9,opt,name=remote_vtep,json=remoteVtep,proto3" json:"remote_vtep,omitempty"`
+	VxlanGpe   bool   `protobuf:"varint,10,opt,name=vxlan_gpe,json=vxlanGpe,proto3" json:"vxlan_gpe,omitempty"`
+	Mtu        int32  `protobuf:"varint,11,opt,name=mtu,proto3" json:"mtu,omitempty"`
+}
+
+func (x *WireParams) Reset() {
+	*x = WireParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireParams) ProtoMessage() {}
+
+func (x *WireParams) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireParams.ProtoReflect.Descriptor instead.
+func (*WireParams) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WireParams) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *WireParams) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *WireParams) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *WireParams) GetNodeIp() string {
+	if x != nil {
+		return x.NodeIp
+	}
+	return ""
+}
+
+func (x *WireParams) GetIntfName() string {
+	if x != nil {
+		return x.IntfName
+	}
+	return ""
+}
+
+func (x *WireParams) GetPeerIntf() string {
+	if x != nil {
+		return x.PeerIntf
+	}
+	return ""
+}
+
+func (x *WireParams) GetWireType() string {
+	if x != nil {
+		return x.WireType
+	}
+	return ""
+}
+
+func (x *WireParams) GetVni() int64 {
+	if x != nil {
+		return x.Vni
+	}
+	return 0
+}
+
+func (x *WireParams) GetRemoteVtep() string {
+	if x != nil {
+		return x.RemoteVtep
+	}
+	return ""
+}
+
+func (x *WireParams) GetVxlanGpe() bool {
+	if x != nil {
+		return x.VxlanGpe
+	}
+	return false
+}
+
+func (x *WireParams) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+type WireMismatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Local string `protobuf:"bytes,2,opt,name=local,proto3" json:"local,omitempty"`
+	Peer  string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+}
+
+func (x *WireMismatch) Reset() {
+	*x = WireMismatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireMismatch) ProtoMessage() {}
+
+func (x *WireMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireMismatch.ProtoReflect.Descriptor instead.
+func (*WireMismatch) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WireMismatch) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *WireMismatch) GetLocal() string {
+	if x != nil {
+		return x.Local
+	}
+	return ""
+}
+
+func (x *WireMismatch) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+type WireVerificationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Local *WireParams `protobuf:"bytes,1,opt,name=local,proto3" json:"local,omitempty"`
+	Peer  *WireParams `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// empty when both ends agree
+	Mismatches []*WireMismatch `protobuf:"bytes,3,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+}
+
+func (x *WireVerificationResult) Reset() {
+	*x = WireVerificationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireVerificationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireVerificationResult) ProtoMessage() {}
+
+func (x *WireVerificationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireVerificationResult.ProtoReflect.Descriptor instead.
+func (*WireVerificationResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WireVerificationResult) GetLocal() *WireParams {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *WireVerificationResult) GetPeer() *WireParams {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *WireVerificationResult) GetMismatches() []*WireMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+// PodList names pods of a namespace by the names of their topologies
+type PodList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names  []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	KubeNs string   `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *PodList) Reset() {
+	*x = PodList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodList) ProtoMessage() {}
+
+func (x *PodList) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodList.ProtoReflect.Descriptor instead.
+func (*PodList) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PodList) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *PodList) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+type TopologyQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KubeNs string `protobuf:"bytes,1,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *TopologyQuery) Reset() {
+	*x = TopologyQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyQuery) ProtoMessage() {}
+
+func (x *TopologyQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyQuery.ProtoReflect.Descriptor instead.
+func (*TopologyQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TopologyQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+// veth pair of a link between two pods of the same node
+type DryRunVeth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid       int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Pod       string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	Intf      string `protobuf:"bytes,3,opt,name=intf,proto3" json:"intf,omitempty"`
+	NetNs     string `protobuf:"bytes,4,opt,name=net_ns,json=netNs,proto3" json:"net_ns,omitempty"`
+	PeerPod   string `protobuf:"bytes,5,opt,name=peer_pod,json=peerPod,proto3" json:"peer_pod,omitempty"`
+	PeerIntf  string `protobuf:"bytes,6,opt,name=peer_intf,json=peerIntf,proto3" json:"peer_intf,omitempty"`
+	PeerNetNs string `protobuf:"bytes,7,opt,name=peer_net_ns,json=peerNetNs,proto3" json:"peer_net_ns,omitempty"`
+}
+
+func (x *DryRunVeth) Reset() {
+	*x = DryRunVeth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunVeth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunVeth) ProtoMessage() {}
+
+func (x *DryRunVeth) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunVeth.ProtoReflect.Descriptor instead.
+func (*DryRunVeth) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DryRunVeth) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *DryRunVeth) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *DryRunVeth) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *DryRunVeth) GetNetNs() string {
+	if x != nil {
+		return x.NetNs
+	}
+	return ""
+}
+
+func (x *DryRunVeth) GetPeerPod() string {
+	if x != nil {
+		return x.PeerPod
+	}
+	return ""
+}
+
+func (x *DryRunVeth) GetPeerIntf() string {
+	if x != nil {
+		return x.PeerIntf
+	}
+	return ""
+}
+
+func (x *DryRunVeth) GetPeerNetNs() string {
+	if x != nil {
+		return x.PeerNetNs
+	}
+	return ""
+}
+
+// VXLAN interface of one end of a link between pods of different nodes
+type DryRunVxlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid        int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Pod        string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	Intf       string `protobuf:"bytes,3,opt,name=intf,proto3" json:"intf,omitempty"`
+	LocalVtep  string `protobuf:"bytes,4,opt,name=local_vtep,json=localVtep,proto3" json:"local_vtep,omitempty"`
+	RemoteVtep string `protobuf:"bytes,5,opt,name=remote_vtep,json=remoteVtep,proto3" json:"remote_vtep,omitempty"`
+	Vni        int64  `protobuf:"varint,6,opt,name=vni,proto3" json:"vni,omitempty"`
+	VxlanGpe   bool   `protobuf:"varint,7,opt,name=vxlan_gpe,json=vxlanGpe,proto3" json:"vxlan_gpe,omitempty"`
+}
+
+func (x *DryRunVxlan) Reset() {
+	*x = DryRunVxlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunVxlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunVxlan) ProtoMessage() {}
+
+func (x *DryRunVxlan) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunVxlan.ProtoReflect.Descriptor instead.
+func (*DryRunVxlan) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DryRunVxlan) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *DryRunVxlan) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *DryRunVxlan) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *DryRunVxlan) GetLocalVtep() string {
+	if x != nil {
+		return x.LocalVtep
+	}
+	return ""
+}
+
+func (x *DryRunVxlan) GetRemoteVtep() string {
+	if x != nil {
+		return x.RemoteVtep
+	}
+	return ""
+}
+
+func (x *DryRunVxlan) GetVni() int64 {
+	if x != nil {
+		return x.Vni
+	}
+	return 0
+}
+
+func (x *DryRunVxlan) GetVxlanGpe() bool {
+	if x != nil {
+		return x.VxlanGpe
+	}
+	return false
+}
+
+// macvlan of a link to an interface of the node
+type DryRunMacvlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid    int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Pod    string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	Intf   string `protobuf:"bytes,3,opt,name=intf,proto3" json:"intf,omitempty"`
+	Parent string `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+}
+
+func (x *DryRunMacvlan) Reset() {
+	*x = DryRunMacvlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunMacvlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunMacvlan) ProtoMessage() {}
+
+func (x *DryRunMacvlan) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunMacvlan.ProtoReflect.Descriptor instead.
+func (*DryRunMacvlan) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DryRunMacvlan) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *DryRunMacvlan) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *DryRunMacvlan) GetIntf() string {
+	if x != nil {
+		return x.Intf
+	}
+	return ""
+}
+
+func (x *DryRunMacvlan) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+type DryRunReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VethPairs []*DryRunVeth    `protobuf:"bytes,1,rep,name=veth_pairs,json=vethPairs,proto3" json:"veth_pairs,omitempty"`
+	Vxlans    []*DryRunVxlan   `protobuf:"bytes,2,rep,name=vxlans,proto3" json:"vxlans,omitempty"`
+	Macvlans  []*DryRunMacvlan `protobuf:"bytes,3,rep,name=macvlans,proto3" json:"macvlans,omitempty"`
+	// problems that would make wires fail, or whose wires can't be predicted yet
+	Warnings []string `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (x *DryRunReport) Reset() {
+	*x = DryRunReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunReport) ProtoMessage() {}
+
+func (x *DryRunReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunReport.ProtoReflect.Descriptor instead.
+func (*DryRunReport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DryRunReport) GetVethPairs() []*DryRunVeth {
+	if x != nil {
+		return x.VethPairs
+	}
+	return nil
+}
+
+func (x *DryRunReport) GetVxlans() []*DryRunVxlan {
+	if x != nil {
+		return x.Vxlans
+	}
+	return nil
+}
+
+func (x *DryRunReport) GetMacvlans() []*DryRunMacvlan {
+	if x != nil {
+		return x.Macvlans
+	}
+	return nil
+}
+
+func (x *DryRunReport) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type TopologyWireRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *TopologyWireRequest) Reset() {
+	*x = TopologyWireRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyWireRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyWireRequest) ProtoMessage() {}
+
+func (x *TopologyWireRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyWireRequest.ProtoReflect.Descriptor instead.
+func (*TopologyWireRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TopologyWireRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *TopologyWireRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+type LinkTransactionStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid     int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Created bool  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	// the wire has been removed after another one failed
+	RolledBack bool `protobuf:"varint,3,opt,name=rolled_back,json=rolledBack,proto3" json:"rolled_back,omitempty"`
+	// why the wire failed, or why it wasn't attempted
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *LinkTransactionStatus) Reset() {
+	*x = LinkTransactionStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkTransactionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkTransactionStatus) ProtoMessage() {}
+
+func (x *LinkTransactionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkTransactionStatus.ProtoReflect.Descriptor instead.
+func (*LinkTransactionStatus) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *LinkTransactionStatus) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LinkTransactionStatus) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *LinkTransactionStatus) GetRolledBack() bool {
+	if x != nil {
+		return x.RolledBack
+	}
+	return false
+}
+
+func (x *LinkTransactionStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type TransactionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// all the wires have been set up, otherwise none of them are left
+	Committed bool                     `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	Links     []*LinkTransactionStatus `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+}
+
+func (x *TransactionResult) Reset() {
+	*x = TransactionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResult) ProtoMessage() {}
+
+func (x *TransactionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResult.ProtoReflect.Descriptor instead.
+func (*TransactionResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *TransactionResult) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *TransactionResult) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *TransactionResult) GetLinks() []*LinkTransactionStatus {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type PolicyBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// JSON-encoded list of NetworkPolicies
+	Policies []byte `protobuf:"bytes,1,opt,name=policies,proto3" json:"policies,omitempty"`
+	Count    int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *PolicyBundle) Reset() {
+	*x = PolicyBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyBundle) ProtoMessage() {}
+
+func (x *PolicyBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyBundle.ProtoReflect.Descriptor instead.
+func (*PolicyBundle) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PolicyBundle) GetPolicies() []byte {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *PolicyBundle) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type NADBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// JSON-encoded list of Multus NetworkAttachmentDefinitions
+	Nads  []byte `protobuf:"bytes,1,opt,name=nads,proto3" json:"nads,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// value of the k8s.v1.cni.cncf.io/networks annotation of each pod, by pod name
+	PodAnnotations map[string]string `protobuf:"bytes,3,rep,name=pod_annotations,json=podAnnotations,proto3" json:"pod_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *NADBundle) Reset() {
+	*x = NADBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NADBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NADBundle) ProtoMessage() {}
+
+func (x *NADBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NADBundle.ProtoReflect.Descriptor instead.
+func (*NADBundle) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *NADBundle) GetNads() []byte {
+	if x != nil {
+		return x.Nads
+	}
+	return nil
+}
+
+func (x *NADBundle) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NADBundle) GetPodAnnotations() map[string]string {
+	if x != nil {
+		return x.PodAnnotations
+	}
+	return nil
+}
+
+// DOTGraph is a graph of the topologies of a namespace in the DOT language of Graphviz
+type DOTGraph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dot string `protobuf:"bytes,1,opt,name=dot,proto3" json:"dot,omitempty"`
+}
+
+func (x *DOTGraph) Reset() {
+	*x = DOTGraph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DOTGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DOTGraph) ProtoMessage() {}
+
+func (x *DOTGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DOTGraph.ProtoReflect.Descriptor instead.
+func (*DOTGraph) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DOTGraph) GetDot() string {
+	if x != nil {
+		return x.Dot
+	}
+	return ""
+}
+
+// InstantiationRequest creates the topologies of a TopologyTemplate rendered with parameters
+type InstantiationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Template   string            `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	KubeNs     string            `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Parameters map[string]string `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *InstantiationRequest) Reset() {
+	*x = InstantiationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstantiationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstantiationRequest) ProtoMessage() {}
+
+func (x *InstantiationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstantiationRequest.ProtoReflect.Descriptor instead.
+func (*InstantiationRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *InstantiationRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *InstantiationRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *InstantiationRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// CaptureRequest captures the frames of the end of a link in a pod
+type CaptureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	// subset of the pcap filter syntax: arp, ip, ip6, icmp, icmp6, tcp, udp and port <n>,
+	// combined with and and or
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// TCP port of the node of the pod to serve the capture on with PCAP-over-IP rather than
+	// streaming the frames, 0 to stream them
+	PcapOverIpPort int32 `protobuf:"varint,5,opt,name=pcap_over_ip_port,json=pcapOverIpPort,proto3" json:"pcap_over_ip_port,omitempty"`
+}
+
+func (x *CaptureRequest) Reset() {
+	*x = CaptureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureRequest) ProtoMessage() {}
+
+func (x *CaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureRequest.ProtoReflect.Descriptor instead.
+func (*CaptureRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CaptureRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *CaptureRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *CaptureRequest) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *CaptureRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *CaptureRequest) GetPcapOverIpPort() int32 {
+	if x != nil {
+		return x.PcapOverIpPort
+	}
+	return 0
+}
+
+// PCAPReplayRequest injects the frames of a pcap file into the end of a link in a pod,
+// sending them out of its interface to the peer
+type PCAPReplayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs  string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	// contents of a libpcap file of Ethernet frames
+	Pcap []byte `protobuf:"bytes,4,opt,name=pcap,proto3" json:"pcap,omitempty"`
+	// ConfigMap of the pod's namespace with the pcap file, read if pcap is empty
+	ConfigMap string `protobuf:"bytes,5,opt,name=config_map,json=configMap,proto3" json:"config_map,omitempty"`
+	// key of the file in the binaryData of config_map, "pcap" if empty
+	ConfigMapKey string `protobuf:"bytes,6,opt,name=config_map_key,json=configMapKey,proto3" json:"config_map_key,omitempty"`
+	// the gaps between the frames of the file are divided by it, 1 if 0
+	ReplaySpeedFactor float64 `protobuf:"fixed64,7,opt,name=replay_speed_factor,json=replaySpeedFactor,proto3" json:"replay_speed_factor,omitempty"`
+	// number of times the file is replayed, 1 if 0, forever if negative
+	LoopCount int64 `protobuf:"varint,8,opt,name=loop_count,json=loopCount,proto3" json:"loop_count,omitempty"`
+}
+
+func (x *PCAPReplayRequest) Reset() {
+	*x = PCAPReplayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PCAPReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PCAPReplayRequest) ProtoMessage() {}
+
+func (x *PCAPReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PCAPReplayRequest.ProtoReflect.Descriptor instead.
+func (*PCAPReplayRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *PCAPReplayRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *PCAPReplayRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *PCAPReplayRequest) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *PCAPReplayRequest) GetPcap() []byte {
+	if x != nil {
+		return x.Pcap
+	}
+	return nil
+}
+
+func (x *PCAPReplayRequest) GetConfigMap() string {
+	if x != nil {
+		return x.ConfigMap
+	}
+	return ""
+}
+
+func (x *PCAPReplayRequest) GetConfigMapKey() string {
+	if x != nil {
+		return x.ConfigMapKey
+	}
+	return ""
+}
+
+func (x *PCAPReplayRequest) GetReplaySpeedFactor() float64 {
+	if x != nil {
+		return x.ReplaySpeedFactor
+	}
+	return 0
+}
+
+func (x *PCAPReplayRequest) GetLoopCount() int64 {
+	if x != nil {
+		return x.LoopCount
+	}
+	return 0
+}
+
+type ReplayProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FramesSent int64 `protobuf:"varint,1,opt,name=frames_sent,json=framesSent,proto3" json:"frames_sent,omitempty"`
+	BytesSent  int64 `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	// frames that have failed to be sent
+	Errors    int64  `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	LastError string `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// replays of the file done so far
+	Loops int64 `protobuf:"varint,5,opt,name=loops,proto3" json:"loops,omitempty"`
+	// set in the last message, once the replay is over
+	Done bool `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *ReplayProgress) Reset() {
+	*x = ReplayProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayProgress) ProtoMessage() {}
+
+func (x *ReplayProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayProgress.ProtoReflect.Descriptor instead.
+func (*ReplayProgress) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ReplayProgress) GetFramesSent() int64 {
+	if x != nil {
+		return x.FramesSent
+	}
+	return 0
+}
+
+func (x *ReplayProgress) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *ReplayProgress) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *ReplayProgress) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ReplayProgress) GetLoops() int64 {
+	if x != nil {
+		return x.Loops
+	}
+	return 0
+}
+
+func (x *ReplayProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type CapturedPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// time the frame was captured, in nanoseconds since the Unix epoch
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// captured bytes of the frame, up to 65535
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// length of the frame
+	Length int64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	// only set in the first message of a PCAP-over-IP capture, host:port of the server,
+	// which has no frames
+	PcapOverIpAddress string `protobuf:"bytes,4,opt,name=pcap_over_ip_address,json=pcapOverIpAddress,proto3" json:"pcap_over_ip_address,omitempty"`
+}
+
+func (x *CapturedPacket) Reset() {
+	*x = CapturedPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CapturedPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturedPacket) ProtoMessage() {}
+
+func (x *CapturedPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturedPacket.ProtoReflect.Descriptor instead.
+func (*CapturedPacket) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CapturedPacket) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *CapturedPacket) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CapturedPacket) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *CapturedPacket) GetPcapOverIpAddress() string {
+	if x != nil {
+		return x.PcapOverIpAddress
+	}
+	return ""
+}
+
+// TopologyDrift is a difference between the topology of a pod and the wires of its node
+type TopologyDrift struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind        TopologyDrift_Kind        `protobuf:"varint,1,opt,name=kind,proto3,enum=meshnet.v1beta1.TopologyDrift_Kind" json:"kind,omitempty"`
+	Pod         string                    `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	LinkUid     int64                     `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	Message     string                    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Remediation TopologyDrift_Remediation `protobuf:"varint,5,opt,name=remediation,proto3,enum=meshnet.v1beta1.TopologyDrift_Remediation" json:"remediation,omitempty"`
+	KubeNs      string                    `protobuf:"bytes,6,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *TopologyDrift) Reset() {
+	*x = TopologyDrift{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyDrift) ProtoMessage() {}
+
+func (x *TopologyDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyDrift.ProtoReflect.Descriptor instead.
+func (*TopologyDrift) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TopologyDrift) GetKind() TopologyDrift_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return TopologyDrift_MISSING_WIRE
+}
+
+func (x *TopologyDrift) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *TopologyDrift) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *TopologyDrift) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TopologyDrift) GetRemediation() TopologyDrift_Remediation {
+	if x != nil {
+		return x.Remediation
+	}
+	return TopologyDrift_RECREATE_WIRE
+}
+
+func (x *TopologyDrift) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+type DriftReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// drifts of the pods of the namespace running on the node of the daemon, all namespaces
+	// when the query has none
+	Drifts []*TopologyDrift `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"`
+}
+
+func (x *DriftReport) Reset() {
+	*x = DriftReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DriftReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftReport) ProtoMessage() {}
+
+func (x *DriftReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftReport.ProtoReflect.Descriptor instead.
+func (*DriftReport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DriftReport) GetDrifts() []*TopologyDrift {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+// wires of a type set up during the convergence of a topology
+type WireTypeConvergence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// veth, vxlan, srv6, macvlan, ...
+	WireType string `protobuf:"bytes,1,opt,name=wire_type,json=wireType,proto3" json:"wire_type,omitempty"`
+	Wires    int64  `protobuf:"varint,2,opt,name=wires,proto3" json:"wires,omitempty"`
+	// longest time a wire of the type has taken to come up once its pods were alive
+	MaxSetupMs int64 `protobuf:"varint,3,opt,name=max_setup_ms,json=maxSetupMs,proto3" json:"max_setup_ms,omitempty"`
+}
+
+func (x *WireTypeConvergence) Reset() {
+	*x = WireTypeConvergence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireTypeConvergence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireTypeConvergence) ProtoMessage() {}
+
+func (x *WireTypeConvergence) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireTypeConvergence.ProtoReflect.Descriptor instead.
+func (*WireTypeConvergence) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *WireTypeConvergence) GetWireType() string {
+	if x != nil {
+		return x.WireType
+	}
+	return ""
+}
+
+func (x *WireTypeConvergence) GetWires() int64 {
+	if x != nil {
+		return x.Wires
+	}
+	return 0
+}
+
+func (x *WireTypeConvergence) GetMaxSetupMs() int64 {
+	if x != nil {
+		return x.MaxSetupMs
+	}
+	return 0
+}
+
+// wire of the critical path of the convergence of a topology
+type CriticalWire struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// pod whose audit has recorded the wire up
+	Pod      string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	WireType string `protobuf:"bytes,3,opt,name=wire_type,json=wireType,proto3" json:"wire_type,omitempty"`
+	// RFC3339 time the wire has come up at
+	UpAt string `protobuf:"bytes,4,opt,name=up_at,json=upAt,proto3" json:"up_at,omitempty"`
+	// time from the wire before it on the path, or from its pods being alive for the first
+	// one, to the wire being up
+	SetupMs int64 `protobuf:"varint,5,opt,name=setup_ms,json=setupMs,proto3" json:"setup_ms,omitempty"`
+}
+
+func (x *CriticalWire) Reset() {
+	*x = CriticalWire{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CriticalWire) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CriticalWire) ProtoMessage() {}
+
+func (x *CriticalWire) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CriticalWire.ProtoReflect.Descriptor instead.
+func (*CriticalWire) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CriticalWire) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CriticalWire) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *CriticalWire) GetWireType() string {
+	if x != nil {
+		return x.WireType
+	}
+	return ""
+}
+
+func (x *CriticalWire) GetUpAt() string {
+	if x != nil {
+		return x.UpAt
+	}
+	return ""
+}
+
+func (x *CriticalWire) GetSetupMs() int64 {
+	if x != nil {
+		return x.SetupMs
+	}
+	return 0
+}
+
+type ConvergenceMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// time from the first pod of the namespace set alive to the last wire up
+	ConvergenceMs int64 `protobuf:"varint,1,opt,name=convergence_ms,json=convergenceMs,proto3" json:"convergence_ms,omitempty"`
+	// RFC3339 times of both
+	FirstAlive string `protobuf:"bytes,2,opt,name=first_alive,json=firstAlive,proto3" json:"first_alive,omitempty"`
+	LastWireUp string `protobuf:"bytes,3,opt,name=last_wire_up,json=lastWireUp,proto3" json:"last_wire_up,omitempty"`
+	// wires up and wires between alive pods not up yet, the topology has converged when there
+	// are none of the latter
+	Wires        int64                  `protobuf:"varint,4,opt,name=wires,proto3" json:"wires,omitempty"`
+	PendingWires int64                  `protobuf:"varint,5,opt,name=pending_wires,json=pendingWires,proto3" json:"pending_wires,omitempty"`
+	WireTypes    []*WireTypeConvergence `protobuf:"bytes,6,rep,name=wire_types,json=wireTypes,proto3" json:"wire_types,omitempty"`
+	// chain of wires, each one set up after the one before it in one of its pods, ending with
+	// the last wire up
+	CriticalPath   []*CriticalWire `protobuf:"bytes,7,rep,name=critical_path,json=criticalPath,proto3" json:"critical_path,omitempty"`
+	CriticalPathMs int64           `protobuf:"varint,8,opt,name=critical_path_ms,json=criticalPathMs,proto3" json:"critical_path_ms,omitempty"`
+	// the convergence has taken longer than the SLA of the daemon
+	SlaExceeded bool `protobuf:"varint,9,opt,name=sla_exceeded,json=slaExceeded,proto3" json:"sla_exceeded,omitempty"`
+}
+
+func (x *ConvergenceMetrics) Reset() {
+	*x = ConvergenceMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvergenceMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvergenceMetrics) ProtoMessage() {}
+
+func (x *ConvergenceMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvergenceMetrics.ProtoReflect.Descriptor instead.
+func (*ConvergenceMetrics) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ConvergenceMetrics) GetConvergenceMs() int64 {
+	if x != nil {
+		return x.ConvergenceMs
+	}
+	return 0
+}
+
+func (x *ConvergenceMetrics) GetFirstAlive() string {
+	if x != nil {
+		return x.FirstAlive
+	}
+	return ""
+}
+
+func (x *ConvergenceMetrics) GetLastWireUp() string {
+	if x != nil {
+		return x.LastWireUp
+	}
+	return ""
+}
+
+func (x *ConvergenceMetrics) GetWires() int64 {
+	if x != nil {
+		return x.Wires
+	}
+	return 0
+}
+
+func (x *ConvergenceMetrics) GetPendingWires() int64 {
+	if x != nil {
+		return x.PendingWires
+	}
+	return 0
+}
+
+func (x *ConvergenceMetrics) GetWireTypes() []*WireTypeConvergence {
+	if x != nil {
+		return x.WireTypes
+	}
+	return nil
+}
+
+func (x *ConvergenceMetrics) GetCriticalPath() []*CriticalWire {
+	if x != nil {
+		return x.CriticalPath
+	}
+	return nil
+}
+
+func (x *ConvergenceMetrics) GetCriticalPathMs() int64 {
+	if x != nil {
+		return x.CriticalPathMs
+	}
+	return 0
+}
+
+func (x *ConvergenceMetrics) GetSlaExceeded() bool {
+	if x != nil {
+		return x.SlaExceeded
+	}
+	return false
+}
+
+// RouteInjectionRequest adds routes to a pod for the link subnets of the topologies of its
+// namespace it isn't connected to
+type RouteInjectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *RouteInjectionRequest) Reset() {
+	*x = RouteInjectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteInjectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteInjectionRequest) ProtoMessage() {}
+
+func (x *RouteInjectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteInjectionRequest.ProtoReflect.Descriptor instead.
+func (*RouteInjectionRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RouteInjectionRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *RouteInjectionRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+// BenchmarkRequest sends synthetic frames over a link between two pods on this node
+type BenchmarkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod        string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	KubeNs     string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	LinkUid    int64  `protobuf:"varint,3,opt,name=link_uid,json=linkUid,proto3" json:"link_uid,omitempty"`
+	FrameSize  int64  `protobuf:"varint,4,opt,name=frame_size,json=frameSize,proto3" json:"frame_size,omitempty"`
+	DurationMs int64  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// receive buffer of the socket counting the frames, 0 for the daemon's default
+	RecvBufSizeBytes int64 `protobuf:"varint,6,opt,name=recv_buf_size_bytes,json=recvBufSizeBytes,proto3" json:"recv_buf_size_bytes,omitempty"`
+}
+
+func (x *BenchmarkRequest) Reset() {
+	*x = BenchmarkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BenchmarkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BenchmarkRequest) ProtoMessage() {}
+
+func (x *BenchmarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BenchmarkRequest.ProtoReflect.Descriptor instead.
+func (*BenchmarkRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *BenchmarkRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *BenchmarkRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *BenchmarkRequest) GetLinkUid() int64 {
+	if x != nil {
+		return x.LinkUid
+	}
+	return 0
+}
+
+func (x *BenchmarkRequest) GetFrameSize() int64 {
+	if x != nil {
+		return x.FrameSize
+	}
+	return 0
+}
+
+func (x *BenchmarkRequest) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *BenchmarkRequest) GetRecvBufSizeBytes() int64 {
+	if x != nil {
+		return x.RecvBufSizeBytes
+	}
+	return 0
+}
+
+type BenchmarkResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FramesSent      uint64  `protobuf:"varint,1,opt,name=frames_sent,json=framesSent,proto3" json:"frames_sent,omitempty"`
+	FramesReceived  uint64  `protobuf:"varint,2,opt,name=frames_received,json=framesReceived,proto3" json:"frames_received,omitempty"`
+	FramesPerSecond float64 `protobuf:"fixed64,3,opt,name=frames_per_second,json=framesPerSecond,proto3" json:"frames_per_second,omitempty"`
+	BytesPerSecond  float64 `protobuf:"fixed64,4,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// throughput at the receiving end in bits per second
+	MaxThroughputBps float64 `protobuf:"fixed64,5,opt,name=max_throughput_bps,json=maxThroughputBps,proto3" json:"max_throughput_bps,omitempty"`
+	P50LatencyUs     int64   `protobuf:"varint,6,opt,name=p50_latency_us,json=p50LatencyUs,proto3" json:"p50_latency_us,omitempty"`
+	P99LatencyUs     int64   `protobuf:"varint,7,opt,name=p99_latency_us,json=p99LatencyUs,proto3" json:"p99_latency_us,omitempty"`
+	LossPercent      float64 `protobuf:"fixed64,8,opt,name=loss_percent,json=lossPercent,proto3" json:"loss_percent,omitempty"`
+	// frames lost because the receive buffer was full, included in loss_percent
+	SocketDrops uint64 `protobuf:"varint,9,opt,name=socket_drops,json=socketDrops,proto3" json:"socket_drops,omitempty"`
+}
+
+func (x *BenchmarkResult) Reset() {
+	*x = BenchmarkResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BenchmarkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BenchmarkResult) ProtoMessage() {}
+
+func (x *BenchmarkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BenchmarkResult.ProtoReflect.Descriptor instead.
+func (*BenchmarkResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *BenchmarkResult) GetFramesSent() uint64 {
+	if x != nil {
+		return x.FramesSent
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetFramesReceived() uint64 {
+	if x != nil {
+		return x.FramesReceived
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetFramesPerSecond() float64 {
+	if x != nil {
+		return x.FramesPerSecond
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetMaxThroughputBps() float64 {
+	if x != nil {
+		return x.MaxThroughputBps
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetP50LatencyUs() int64 {
+	if x != nil {
+		return x.P50LatencyUs
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetP99LatencyUs() int64 {
+	if x != nil {
+		return x.P99LatencyUs
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetLossPercent() float64 {
+	if x != nil {
+		return x.LossPercent
+	}
+	return 0
+}
+
+func (x *BenchmarkResult) GetSocketDrops() uint64 {
+	if x != nil {
+		return x.SocketDrops
+	}
+	return 0
+}
+
+type CanaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the topology, i.e. of its pod
+	Name     string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KubeNs   string  `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Fraction float64 `protobuf:"fixed64,3,opt,name=fraction,proto3" json:"fraction,omitempty"`
+}
+
+func (x *CanaryRequest) Reset() {
+	*x = CanaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryRequest) ProtoMessage() {}
+
+func (x *CanaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryRequest.ProtoReflect.Descriptor instead.
+func (*CanaryRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CanaryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CanaryRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *CanaryRequest) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{75}
+}
+
+type ResourceRecommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActiveWires   int64 `protobuf:"varint,1,opt,name=active_wires,json=activeWires,proto3" json:"active_wires,omitempty"`
+	CpuMillicores int64 `protobuf:"varint,2,opt,name=cpu_millicores,json=cpuMillicores,proto3" json:"cpu_millicores,omitempty"`
+	MemoryBytes   int64 `protobuf:"varint,3,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+}
+
+func (x *ResourceRecommendation) Reset() {
+	*x = ResourceRecommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRecommendation) ProtoMessage() {}
+
+func (x *ResourceRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceRecommendation.ProtoReflect.Descriptor instead.
+func (*ResourceRecommendation) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ResourceRecommendation) GetActiveWires() int64 {
+	if x != nil {
+		return x.ActiveWires
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetCpuMillicores() int64 {
+	if x != nil {
+		return x.CpuMillicores
+	}
+	return 0
+}
+
+func (x *ResourceRecommendation) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+type AccountingQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KubeNs string `protobuf:"bytes,1,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	// only the wires of this pod, either end, if set
+	Pod string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	// only the wires accounted for during this RFC3339 time range, if set
+	Start string `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End   string `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *AccountingQuery) Reset() {
+	*x = AccountingQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountingQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountingQuery) ProtoMessage() {}
+
+func (x *AccountingQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountingQuery.ProtoReflect.Descriptor instead.
+func (*AccountingQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *AccountingQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *AccountingQuery) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *AccountingQuery) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *AccountingQuery) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type WireTraffic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// the end of the wire the counters are read from
+	Pod         string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	PeerPod     string `protobuf:"bytes,3,opt,name=peer_pod,json=peerPod,proto3" json:"peer_pod,omitempty"`
+	TxBytes     uint64 `protobuf:"varint,4,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	RxBytes     uint64 `protobuf:"varint,5,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`
+	TxPackets   uint64 `protobuf:"varint,6,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"`
+	RxPackets   uint64 `protobuf:"varint,7,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
+	PeriodStart string `protobuf:"bytes,8,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd   string `protobuf:"bytes,9,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+}
+
+func (x *WireTraffic) Reset() {
+	*x = WireTraffic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireTraffic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireTraffic) ProtoMessage() {}
+
+func (x *WireTraffic) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireTraffic.ProtoReflect.Descriptor instead.
+func (*WireTraffic) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *WireTraffic) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *WireTraffic) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *WireTraffic) GetPeerPod() string {
+	if x != nil {
+		return x.PeerPod
+	}
+	return ""
+}
+
+func (x *WireTraffic) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *WireTraffic) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *WireTraffic) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *WireTraffic) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *WireTraffic) GetPeriodStart() string {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return ""
+}
+
+func (x *WireTraffic) GetPeriodEnd() string {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return ""
+}
+
+type AccountingReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Wires []*WireTraffic `protobuf:"bytes,1,rep,name=wires,proto3" json:"wires,omitempty"`
+	// ConfigMap the counters have been archived to by a reset
+	Archive string `protobuf:"bytes,2,opt,name=archive,proto3" json:"archive,omitempty"`
+}
+
+func (x *AccountingReport) Reset() {
+	*x = AccountingReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountingReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountingReport) ProtoMessage() {}
+
+func (x *AccountingReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountingReport.ProtoReflect.Descriptor instead.
+func (*AccountingReport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *AccountingReport) GetWires() []*WireTraffic {
+	if x != nil {
+		return x.Wires
+	}
+	return nil
+}
+
+func (x *AccountingReport) GetArchive() string {
+	if x != nil {
+		return x.Archive
+	}
+	return ""
+}
+
+// ChaosProfile impairs the wires between the pods matching selector and the pods matching
+// peer_selector, or all the wires of the pods matching selector if peer_selector is empty
+type ChaosProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	// pod labels
+	Selector     map[string]string `protobuf:"bytes,3,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PeerSelector map[string]string `protobuf:"bytes,4,rep,name=peer_selector,json=peerSelector,proto3" json:"peer_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// applied to the traffic leaving either end of the wires
+	Impairment *ImpairmentSpec `protobuf:"bytes,5,opt,name=impairment,proto3" json:"impairment,omitempty"`
+}
+
+func (x *ChaosProfile) Reset() {
+	*x = ChaosProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChaosProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChaosProfile) ProtoMessage() {}
+
+func (x *ChaosProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChaosProfile.ProtoReflect.Descriptor instead.
+func (*ChaosProfile) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ChaosProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChaosProfile) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *ChaosProfile) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *ChaosProfile) GetPeerSelector() map[string]string {
+	if x != nil {
+		return x.PeerSelector
+	}
+	return nil
+}
+
+func (x *ChaosProfile) GetImpairment() *ImpairmentSpec {
+	if x != nil {
+		return x.Impairment
+	}
+	return nil
+}
+
+type ChaosProfileRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *ChaosProfileRef) Reset() {
+	*x = ChaosProfileRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChaosProfileRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChaosProfileRef) ProtoMessage() {}
+
+func (x *ChaosProfileRef) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChaosProfileRef.ProtoReflect.Descriptor instead.
+func (*ChaosProfileRef) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ChaosProfileRef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChaosProfileRef) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+// detail of the gRPC status of failed RPCs, telling why an operation on a pod or a wire has failed
+type WireError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0 when the error isn't about a single wire
+	WireUid   int64               `protobuf:"varint,1,opt,name=wire_uid,json=wireUid,proto3" json:"wire_uid,omitempty"`
+	PeerIp    string              `protobuf:"bytes,2,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
+	Operation WireError_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=meshnet.v1beta1.WireError_Operation" json:"operation,omitempty"`
+	Cause     WireError_Cause     `protobuf:"varint,4,opt,name=cause,proto3,enum=meshnet.v1beta1.WireError_Cause" json:"cause,omitempty"`
+}
+
+func (x *WireError) Reset() {
+	*x = WireError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WireError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireError) ProtoMessage() {}
+
+func (x *WireError) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireError.ProtoReflect.Descriptor instead.
+func (*WireError) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *WireError) GetWireUid() int64 {
+	if x != nil {
+		return x.WireUid
+	}
+	return 0
+}
+
+func (x *WireError) GetPeerIp() string {
+	if x != nil {
+		return x.PeerIp
+	}
+	return ""
+}
+
+func (x *WireError) GetOperation() WireError_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return WireError_NONE
+}
+
+func (x *WireError) GetCause() WireError_Cause {
+	if x != nil {
+		return x.Cause
+	}
+	return WireError_UNKNOWN
+}
+
+type TopologyEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RFC3339 time of the event, with nanoseconds
+	Timestamp string             `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Type      TopologyEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=meshnet.v1beta1.TopologyEvent_Type" json:"type,omitempty"`
+	// name of the daemon pod that recorded the event
+	Actor   string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	WireUid int64  `protobuf:"varint,4,opt,name=wire_uid,json=wireUid,proto3" json:"wire_uid,omitempty"`
+	// link before and after the event, unset when it didn't exist or hasn't changed
+	Before  *Link  `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	After   *Link  `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	Message string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *TopologyEvent) Reset() {
+	*x = TopologyEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyEvent) ProtoMessage() {}
+
+func (x *TopologyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyEvent.ProtoReflect.Descriptor instead.
+func (*TopologyEvent) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *TopologyEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *TopologyEvent) GetType() TopologyEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TopologyEvent_CREATE
+}
+
+func (x *TopologyEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TopologyEvent) GetWireUid() int64 {
+	if x != nil {
+		return x.WireUid
+	}
+	return 0
+}
+
+func (x *TopologyEvent) GetBefore() *Link {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *TopologyEvent) GetAfter() *Link {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *TopologyEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type TopologyExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod *Pod `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	// events of the wires of the topology, oldest first
+	Events []*TopologyEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *TopologyExport) Reset() {
+	*x = TopologyExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyExport) ProtoMessage() {}
+
+func (x *TopologyExport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyExport.ProtoReflect.Descriptor instead.
+func (*TopologyExport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *TopologyExport) GetPod() *Pod {
+	if x != nil {
+		return x.Pod
+	}
+	return nil
+}
+
+func (x *TopologyExport) GetEvents() []*TopologyEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ReplayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the topology, i.e. of its pod
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KubeNs string `protobuf:"bytes,2,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	// RFC3339 time the events are replayed up to, all of them when empty
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ReplayRequest) Reset() {
+	*x = ReplayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayRequest) ProtoMessage() {}
+
+func (x *ReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayRequest.ProtoReflect.Descriptor instead.
+func (*ReplayRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ReplayRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReplayRequest) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *ReplayRequest) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+type ReplayedWire struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// unset if the wire has failed before any of its creations was recorded
+	Link *Link `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	// "up" or "failed"
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// timestamp of the last event of the wire
+	Since string `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (x *ReplayedWire) Reset() {
+	*x = ReplayedWire{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayedWire) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayedWire) ProtoMessage() {}
+
+func (x *ReplayedWire) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayedWire.ProtoReflect.Descriptor instead.
+func (*ReplayedWire) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ReplayedWire) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ReplayedWire) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *ReplayedWire) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ReplayedWire) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+type ReplayResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// wires of the topology at the timestamp, by UID
+	Wires []*ReplayedWire `protobuf:"bytes,1,rep,name=wires,proto3" json:"wires,omitempty"`
+	// number of events replayed
+	Events int64 `protobuf:"varint,2,opt,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *ReplayResult) Reset() {
+	*x = ReplayResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayResult) ProtoMessage() {}
+
+func (x *ReplayResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayResult.ProtoReflect.Descriptor instead.
+func (*ReplayResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ReplayResult) GetWires() []*ReplayedWire {
+	if x != nil {
+		return x.Wires
+	}
+	return nil
+}
+
+func (x *ReplayResult) GetEvents() int64 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
+type NamespaceQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KubeNs string `protobuf:"bytes,1,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+}
+
+func (x *NamespaceQuery) Reset() {
+	*x = NamespaceQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamespaceQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceQuery) ProtoMessage() {}
+
+func (x *NamespaceQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceQuery.ProtoReflect.Descriptor instead.
+func (*NamespaceQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *NamespaceQuery) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+type ResourceUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "topologies", "links_per_topology" or "total_wires"
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// the most links of a topology for links_per_topology
+	Used int64 `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	// limit of the MeshnetNamespaceQuota of the namespace, 0 when unlimited
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ResourceUsage) Reset() {
+	*x = ResourceUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceUsage) ProtoMessage() {}
+
+func (x *ResourceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceUsage.ProtoReflect.Descriptor instead.
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ResourceUsage) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ResourceUsage) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// UsageReport is the usage of the MeshnetNamespaceQuota of a namespace
+type UsageReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KubeNs string           `protobuf:"bytes,1,opt,name=kube_ns,json=kubeNs,proto3" json:"kube_ns,omitempty"`
+	Usage  []*ResourceUsage `protobuf:"bytes,2,rep,name=usage,proto3" json:"usage,omitempty"`
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *UsageReport) GetKubeNs() string {
+	if x != nil {
+		return x.KubeNs
+	}
+	return ""
+}
+
+func (x *UsageReport) GetUsage() []*ResourceUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+var File_daemon_proto_meshnet_v1beta1_meshnet_proto protoreflect.FileDescriptor
+
+var file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2f, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2f, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x22, 0xeb, 0x03,
+	0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63,
+	0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x70,
+	0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6e, 0x65, 0x74, 0x4e, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x12, 0x2b, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x12, 0x47, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f,
+	0x64, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x34, 0x0a, 0x06, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x63,
+	0x61, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x76, 0x73, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x76, 0x73, 0x42, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x74, 0x6e, 0x73, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x74, 0x6e, 0x73, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x69, 0x6c, 0x69, 0x75, 0x6d, 0x5f, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x69, 0x6c, 0x69,
+	0x75, 0x6d, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x1a, 0x3e, 0x0a, 0x10, 0x41,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x0b, 0x43,
+	0x61, 0x6e, 0x61, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x22, 0xf9, 0x06, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x65,
+	0x72, 0x50, 0x6f, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x69, 0x6e,
+	0x74, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x49,
+	0x6e, 0x74, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x66,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x49, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x65, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65,
+	0x65, 0x72, 0x49, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x4c, 0x0a, 0x11, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x5f, 0x69, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70,
+	0x65, 0x63, 0x52, 0x10, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4e, 0x0a, 0x12, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f,
+	0x69, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65,
+	0x63, 0x52, 0x11, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x5f, 0x67, 0x70,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x47, 0x70,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x70, 0x6c, 0x73, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x70, 0x6c, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x63, 0x6d, 0x70, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x63, 0x6d, 0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x29, 0x0a, 0x11, 0x73, 0x72, 0x69, 0x6f, 0x76, 0x5f, 0x76, 0x66, 0x5f, 0x70, 0x63, 0x69, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x72, 0x69, 0x6f,
+	0x76, 0x56, 0x66, 0x50, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x4d, 0x61, 0x63, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x4d,
+	0x61, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x6d, 0x74, 0x75, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x42,
+	0x0a, 0x0d, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x65, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x5f, 0x71, 0x6f, 0x73, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x14, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x73, 0x63, 0x70, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0c, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x51, 0x6f, 0x73, 0x4d, 0x61,
+	0x70, 0x12, 0x58, 0x0a, 0x17, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x72, 0x76, 0x36, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x16, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x72, 0x76, 0x36, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x24, 0x0a, 0x0e, 0x73, 0x72, 0x76, 0x36, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x73,
+	0x69, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x72, 0x76, 0x36, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x53, 0x69, 0x64, 0x4a, 0x04, 0x08, 0x13, 0x10, 0x14, 0x22, 0x7f, 0x0a, 0x0f,
+	0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79,
+	0x12, 0x26, 0x0a, 0x0f, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x70, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x22, 0x0a,
+	0x0c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x73, 0x63, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x64, 0x73, 0x63,
+	0x70, 0x22, 0x40, 0x0a, 0x0e, 0x44, 0x73, 0x63, 0x70, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x73, 0x63, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x64, 0x73, 0x63, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x22, 0x67, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x75, 0x65, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6d,
+	0x62, 0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x61, 0x74, 0x65, 0x4d,
+	0x62, 0x70, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x52, 0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x0e,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x22, 0xa4, 0x03, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72,
+	0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6a, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x4d, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x6c, 0x6f, 0x73, 0x73, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x10, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0e, 0x63, 0x6f,
+	0x72, 0x72, 0x75, 0x70, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x65, 0x63, 0x6e, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x65, 0x63, 0x6e, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x32, 0x0a,
+	0x15, 0x65, 0x63, 0x6e, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x13, 0x65, 0x63,
+	0x6e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0e, 0x72, 0x65, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x1b, 0x72, 0x65,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x19, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x67, 0x61, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x47, 0x61, 0x70, 0x22, 0x37, 0x0a, 0x08, 0x50,
+	0x6f, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b,
+	0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75,
+	0x62, 0x65, 0x4e, 0x73, 0x22, 0x4a, 0x0a, 0x09, 0x53, 0x6b, 0x69, 0x70, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x70, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x22, 0x2a, 0x0a, 0x0c, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9e, 0x08, 0x0a,
+	0x09, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65,
+	0x74, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x4e,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x69, 0x6e, 0x74, 0x66, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x69, 0x6e, 0x74, 0x66, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f,
+	0x76, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72,
+	0x56, 0x74, 0x65, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x76, 0x6e, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x76, 0x6e, 0x69, 0x12,
+	0x3c, 0x0a, 0x0b, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x0a, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x72, 0x76, 0x36, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x72, 0x76, 0x36, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x72, 0x76, 0x36, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x73, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x72, 0x76, 0x36,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f, 0x64, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x4c, 0x0a, 0x11, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x6d,
+	0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52,
+	0x10, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x4e, 0x0a, 0x12, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x6d, 0x70,
+	0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x11,
+	0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x5f, 0x67, 0x70, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x47, 0x70, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x70, 0x6c, 0x73, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x6d, 0x70, 0x6c, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x19, 0x0a,
+	0x08, 0x67, 0x74, 0x70, 0x5f, 0x74, 0x65, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x67, 0x74, 0x70, 0x54, 0x65, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x66,
+	0x5f, 0x6d, 0x61, 0x63, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x74, 0x66,
+	0x4d, 0x61, 0x63, 0x12, 0x3c, 0x0a, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73,
+	0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x56, 0x58, 0x4c, 0x41, 0x4e, 0x4e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x52, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x5f, 0x75, 0x69, 0x64, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x03, 0x52, 0x10, 0x70, 0x72,
+	0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x55, 0x69, 0x64, 0x73, 0x12, 0x22,
+	0x0a, 0x0d, 0x77, 0x67, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x67, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x6d, 0x74, 0x75, 0x12, 0x42, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x5f,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x42, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52,
+	0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x45, 0x0a, 0x0e,
+	0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x71, 0x6f, 0x73, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x17,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x73, 0x63, 0x70, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x51, 0x6f, 0x73,
+	0x4d, 0x61, 0x70, 0x12, 0x58, 0x0a, 0x17, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a,
+	0x0d, 0x56, 0x58, 0x4c, 0x41, 0x4e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63,
+	0x12, 0x17, 0x0a, 0x07, 0x76, 0x74, 0x65, 0x70, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x74, 0x65, 0x70, 0x49, 0x70, 0x22, 0x87, 0x01, 0x0a, 0x13, 0x56, 0x58,
+	0x4c, 0x41, 0x4e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x4e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x66,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x66, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x56, 0x58, 0x4c, 0x41, 0x4e,
+	0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x52, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62,
+	0x6f, 0x72, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x0d, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x57, 0x69,
+	0x72, 0x65, 0x44, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x23, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x57, 0x69, 0x72, 0x65, 0x44, 0x65, 0x66, 0x2e,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x2e, 0x0a, 0x04, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x45, 0x53, 0x54, 0x5f, 0x45, 0x46, 0x46, 0x4f, 0x52,
+	0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x4f, 0x4e, 0x5f, 0x41,
+	0x4e, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x22, 0xe4, 0x01, 0x0a, 0x09, 0x4c,
+	0x69, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x42, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69,
+	0x6e, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x29, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x04, 0x6c, 0x69,
+	0x6e, 0x6b, 0x22, 0x3d, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x07, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4d,
+	0x4f, 0x56, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10,
+	0x03, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x57, 0x69, 0x72, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69,
+	0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69,
+	0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6f, 0x77, 0x5f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x6f, 0x77, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1b,
+	0x0a, 0x09, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x77, 0x69, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x22, 0x5a, 0x0a, 0x0f, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65,
+	0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x0f, 0x0a,
+	0x0d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc7,
+	0x02, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x77, 0x69, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x10, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x64, 0x6f, 0x67, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x14, 0x77, 0x69, 0x72, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x77, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x72, 0x65,
+	0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x5f, 0x77, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x72, 0x65,
+	0x73, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x0e, 0x53, 0x68, 0x75, 0x74,
+	0x64, 0x6f, 0x77, 0x6e, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x70, 0x22, 0x3d, 0x0a, 0x11, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x5f, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x57, 0x69, 0x72, 0x65, 0x4c, 0x6f,
+	0x73, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0c, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61, 0x74, 0x65, 0x64,
+	0x50, 0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x77, 0x69, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x77,
+	0x69, 0x72, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x12, 0x24, 0x0a,
+	0x0d, 0x72, 0x65, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x22, 0xa8, 0x01, 0x0a, 0x10, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61, 0x74,
+	0x65, 0x64, 0x50, 0x6f, 0x64, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x77, 0x69, 0x72, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x6f, 0x73, 0x74, 0x57, 0x69, 0x72, 0x65,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x22, 0x2b,
+	0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x22, 0x2c, 0x0a, 0x11, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x22, 0x56, 0x0a, 0x0e, 0x4c, 0x69, 0x6e,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69,
+	0x64, 0x22, 0x98, 0x05, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x74, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08,
+	0x72, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x78, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x78, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x74, 0x78, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x72, 0x78, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x78, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x74, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x6c,
+	0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x66, 0x6c, 0x61, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x66, 0x6c, 0x61, 0x70, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x46, 0x6c, 0x61, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x24,
+	0x0a, 0x0e, 0x72, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44,
+	0x65, 0x70, 0x74, 0x68, 0x12, 0x3a, 0x0a, 0x19, 0x62, 0x61, 0x63, 0x6b, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x75, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x75, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x4f, 0x0a, 0x0d, 0x64, 0x73, 0x63, 0x70, 0x5f, 0x74, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x2e, 0x44, 0x73, 0x63, 0x70, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x64, 0x73, 0x63, 0x70, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x1a, 0x3e, 0x0a, 0x10,
+	0x44, 0x73, 0x63, 0x70, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdd, 0x01, 0x0a,
+	0x12, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x12, 0x3c, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x20, 0x0a, 0x04, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x55, 0x4d, 0x55, 0x4c, 0x41, 0x54, 0x49, 0x56, 0x45,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x41, 0x54, 0x45, 0x10, 0x01, 0x22, 0x7b, 0x0a, 0x0a,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61, 0x70,
+	0x73, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c,
+	0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x08, 0x46, 0x6c,
+	0x61, 0x70, 0x53, 0x70, 0x65, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65,
+	0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e,
+	0x75, 0x70, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x75, 0x70, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x64, 0x6f,
+	0x77, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x22, 0x95, 0x01, 0x0a,
+	0x13, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x32, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61,
+	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x22, 0x5a, 0x0a, 0x07, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54, 0x55, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75,
+	0x22, 0x6f, 0x0a, 0x0b, 0x4d, 0x54, 0x55, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54, 0x55, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12,
+	0x30, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54, 0x55, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x22, 0xe7, 0x01, 0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74,
+	0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x37, 0x0a, 0x06, 0x65,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6d,
+	0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x65, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x39, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x0e,
+	0x57, 0x69, 0x72, 0x65, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x37,
+	0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52,
+	0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x39, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69,
+	0x72, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x22, 0x64, 0x0a, 0x0a, 0x4d, 0x54, 0x55, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
+	0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c,
+	0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c,
+	0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x22, 0x56, 0x0a, 0x09, 0x50, 0x61, 0x74, 0x68,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x73, 0x72, 0x63, 0x5f, 0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x72, 0x63, 0x50, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x73, 0x74, 0x5f, 0x70,
+	0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x73, 0x74, 0x50, 0x6f, 0x64,
+	0x22, 0x60, 0x0a, 0x06, 0x48, 0x6f, 0x70, 0x4d, 0x54, 0x55, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
+	0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x70,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d,
+	0x74, 0x75, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x4d, 0x54, 0x55, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x74,
+	0x68, 0x5f, 0x6d, 0x74, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x61, 0x74,
+	0x68, 0x4d, 0x74, 0x75, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x6e, 0x65,
+	0x63, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x6f,
+	0x74, 0x74, 0x6c, 0x65, 0x6e, 0x65, 0x63, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x68,
+	0x6f, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x48, 0x6f, 0x70, 0x4d,
+	0x54, 0x55, 0x52, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x22, 0x5f, 0x0a, 0x17, 0x57, 0x69, 0x72, 0x65,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x22, 0x59, 0x0a, 0x11, 0x57, 0x69, 0x72,
+	0x65, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e,
+	0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e,
+	0x6b, 0x55, 0x69, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x09, 0x57, 0x69, 0x72, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x12, 0x33, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x4b, 0x69, 0x6e,
+	0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x37, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x46, 0x41, 0x43, 0x45,
+	0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x45, 0x54, 0x45, 0x52, 0x53,
+	0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x56, 0x49,
+	0x54, 0x59, 0x10, 0x02, 0x22, 0x8b, 0x01, 0x0a, 0x0a, 0x57, 0x69, 0x72, 0x65, 0x52, 0x65, 0x70,
+	0x61, 0x69, 0x72, 0x12, 0x3a, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72,
+	0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x27, 0x0a, 0x06, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x45, 0x54, 0x5f, 0x55, 0x50, 0x10, 0x00, 0x12,
+	0x11, 0x0a, 0x0d, 0x52, 0x45, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x57, 0x49, 0x52, 0x45,
+	0x10, 0x01, 0x22, 0xd2, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x61, 0x69,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x52,
+	0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x07, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x3d,
+	0x0a, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x22, 0xa4, 0x02, 0x0a, 0x0a, 0x57, 0x69, 0x72, 0x65,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65,
+	0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e,
+	0x6f, 0x64, 0x65, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x66, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x66, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x66, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x66, 0x12,
+	0x1b, 0x0a, 0x09, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x77, 0x69, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x76, 0x6e, 0x69, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x76, 0x6e, 0x69, 0x12, 0x1f,
+	0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x76, 0x74, 0x65, 0x70, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x56, 0x74, 0x65, 0x70, 0x12,
+	0x1b, 0x0a, 0x09, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x5f, 0x67, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x47, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x74, 0x75, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x22, 0x4e,
+	0x0a, 0x0c, 0x57, 0x69, 0x72, 0x65, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0xbb,
+	0x01, 0x0a, 0x16, 0x57, 0x69, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x2f, 0x0a, 0x04,
+	0x70, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x3d, 0x0a,
+	0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x38, 0x0a, 0x07,
+	0x50, 0x6f, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x17, 0x0a,
+	0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x22, 0x28, 0x0a, 0x0d, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
+	0x67, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x22, 0xb3, 0x01, 0x0a, 0x0a, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x56, 0x65, 0x74, 0x68, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x70, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x65, 0x74, 0x5f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x4e, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x50, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65,
+	0x65, 0x72, 0x49, 0x6e, 0x74, 0x66, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x6e,
+	0x65, 0x74, 0x5f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x65, 0x65,
+	0x72, 0x4e, 0x65, 0x74, 0x4e, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x0b, 0x44, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x56, 0x78, 0x6c, 0x61, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e,
+	0x74, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x76, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x56, 0x74, 0x65, 0x70, 0x12, 0x1f, 0x0a,
+	0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x76, 0x74, 0x65, 0x70, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x56, 0x74, 0x65, 0x70, 0x12, 0x10,
+	0x0a, 0x03, 0x76, 0x6e, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x76, 0x6e, 0x69,
+	0x12, 0x1b, 0x0a, 0x09, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x5f, 0x67, 0x70, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x47, 0x70, 0x65, 0x22, 0x5f, 0x0a,
+	0x0d, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x4d, 0x61, 0x63, 0x76, 0x6c, 0x61, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
+	0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x69, 0x6e, 0x74, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0xd8,
+	0x01, 0x0a, 0x0c, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x3a, 0x0a, 0x0a, 0x76, 0x65, 0x74, 0x68, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x56, 0x65, 0x74, 0x68,
+	0x52, 0x09, 0x76, 0x65, 0x74, 0x68, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x76,
+	0x78, 0x6c, 0x61, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x56, 0x78, 0x6c, 0x61, 0x6e, 0x52, 0x06, 0x76, 0x78, 0x6c, 0x61, 0x6e,
+	0x73, 0x12, 0x3a, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x76, 0x6c, 0x61, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x4d, 0x61, 0x63, 0x76,
+	0x6c, 0x61, 0x6e, 0x52, 0x08, 0x6d, 0x61, 0x63, 0x76, 0x6c, 0x61, 0x6e, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x40, 0x0a, 0x13, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x57, 0x69, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
+	0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x22, 0x7a, 0x0a, 0x15, 0x4c,
+	0x69, 0x6e, 0x6b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x63,
+	0x6b, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x96, 0x01, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74,
+	0x65, 0x64, 0x12, 0x3c, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73,
+	0x22, 0x40, 0x0a, 0x0c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xd1, 0x01, 0x0a, 0x09, 0x4e, 0x41, 0x44, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x6e, 0x61, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x57, 0x0a, 0x0f, 0x70, 0x6f,
+	0x64, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4e, 0x41, 0x44, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e,
+	0x50, 0x6f, 0x64, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0e, 0x70, 0x6f, 0x64, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x1a, 0x41, 0x0a, 0x13, 0x50, 0x6f, 0x64, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1c, 0x0a, 0x08, 0x44, 0x4f, 0x54, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x64, 0x6f, 0x74, 0x22, 0xe1, 0x01, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62,
+	0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65,
+	0x4e, 0x73, 0x12, 0x55, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x99, 0x01, 0x0a, 0x0e, 0x43, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x11, 0x70, 0x63, 0x61,
+	0x70, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x63, 0x61, 0x70, 0x4f, 0x76, 0x65, 0x72, 0x49, 0x70,
+	0x50, 0x6f, 0x72, 0x74, 0x22, 0x81, 0x02, 0x0a, 0x11, 0x50, 0x43, 0x41, 0x50, 0x52, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b,
+	0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x63, 0x61, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x70, 0x63, 0x61, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6d,
+	0x61, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x4d, 0x61, 0x70, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6d, 0x61,
+	0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x70,
+	0x65, 0x65, 0x64, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x6f,
+	0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c,
+	0x6f, 0x6f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x6f, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6c, 0x6f, 0x6f, 0x70, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x22, 0x8b, 0x01, 0x0a,
+	0x0e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x14, 0x70, 0x63, 0x61,
+	0x70, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x63, 0x61, 0x70, 0x4f, 0x76, 0x65,
+	0x72, 0x49, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x87, 0x03, 0x0a, 0x0d, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x37, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f,
+	0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4c, 0x0a, 0x0b,
+	0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x44, 0x72, 0x69, 0x66,
+	0x74, 0x2e, 0x52, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72,
+	0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75,
+	0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62,
+	0x65, 0x4e, 0x73, 0x22, 0x44, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
+	0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x57, 0x49, 0x52, 0x45, 0x10, 0x00, 0x12, 0x11, 0x0a,
+	0x0d, 0x4f, 0x52, 0x50, 0x48, 0x41, 0x4e, 0x45, 0x44, 0x5f, 0x57, 0x49, 0x52, 0x45, 0x10, 0x01,
+	0x12, 0x17, 0x0a, 0x13, 0x49, 0x4d, 0x50, 0x41, 0x49, 0x52, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x4d,
+	0x49, 0x53, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x02, 0x22, 0x49, 0x0a, 0x0b, 0x52, 0x65, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45, 0x43, 0x52,
+	0x45, 0x41, 0x54, 0x45, 0x5f, 0x57, 0x49, 0x52, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52,
+	0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x57, 0x49, 0x52, 0x45, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12,
+	0x52, 0x45, 0x41, 0x50, 0x50, 0x4c, 0x59, 0x5f, 0x49, 0x4d, 0x50, 0x41, 0x49, 0x52, 0x4d, 0x45,
+	0x4e, 0x54, 0x10, 0x02, 0x22, 0x45, 0x0a, 0x0b, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x66, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x44, 0x72,
+	0x69, 0x66, 0x74, 0x52, 0x06, 0x64, 0x72, 0x69, 0x66, 0x74, 0x73, 0x22, 0x6a, 0x0a, 0x13, 0x57,
+	0x69, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x69, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x69, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x77, 0x69, 0x72, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x74,
+	0x75, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78,
+	0x53, 0x65, 0x74, 0x75, 0x70, 0x4d, 0x73, 0x22, 0x7f, 0x0a, 0x0c, 0x43, 0x72, 0x69, 0x74, 0x69,
+	0x63, 0x61, 0x6c, 0x57, 0x69, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x77,
+	0x69, 0x72, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x77, 0x69, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x75, 0x70, 0x5f, 0x61,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x70, 0x41, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x73, 0x65, 0x74, 0x75, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x73, 0x65, 0x74, 0x75, 0x70, 0x4d, 0x73, 0x22, 0x8f, 0x03, 0x0a, 0x12, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f,
+	0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x77, 0x69, 0x72, 0x65, 0x5f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x57, 0x69, 0x72, 0x65, 0x55, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x72,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x77, 0x69, 0x72, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x69, 0x72, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x57,
+	0x69, 0x72, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x0a, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09,
+	0x77, 0x69, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0d, 0x63, 0x72, 0x69,
+	0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x43, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x57, 0x69, 0x72, 0x65, 0x52,
+	0x0c, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a,
+	0x10, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x6d,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61,
+	0x6c, 0x50, 0x61, 0x74, 0x68, 0x4d, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6c, 0x61, 0x5f, 0x65,
+	0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73,
+	0x6c, 0x61, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x42, 0x0a, 0x15, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x22, 0xc7,
+	0x01, 0x0a, 0x10, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x6c, 0x69, 0x6e, 0x6b, 0x55, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x61,
+	0x6d, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x72, 0x65, 0x63,
+	0x76, 0x5f, 0x62, 0x75, 0x66, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x72, 0x65, 0x63, 0x76, 0x42, 0x75, 0x66, 0x53,
+	0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xf1, 0x02, 0x0a, 0x0f, 0x42, 0x65, 0x6e,
+	0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a,
+	0x0f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x12,
+	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x5f, 0x62,
+	0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x54, 0x68, 0x72,
+	0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x42, 0x70, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x35,
+	0x30, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x70, 0x35, 0x30, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x55, 0x73,
+	0x12, 0x24, 0x0a, 0x0e, 0x70, 0x39, 0x39, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x39, 0x39, 0x4c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x55, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6c, 0x6f,
+	0x73, 0x73, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x72, 0x6f, 0x70, 0x73, 0x22, 0x58, 0x0a, 0x0d,
+	0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x85, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x77, 0x69, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x72, 0x65, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x63, 0x70, 0x75, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x70, 0x75, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x63,
+	0x6f, 0x72, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x0f, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75,
+	0x62, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62,
+	0x65, 0x4e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x82, 0x02,
+	0x0a, 0x0b, 0x57, 0x69, 0x72, 0x65, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x50, 0x6f, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x74, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x78, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x65, 0x6e,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x45,
+	0x6e, 0x64, 0x22, 0x60, 0x0a, 0x10, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x77, 0x69, 0x72, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x54, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x52, 0x05, 0x77, 0x69, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x22, 0x99, 0x03, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62,
+	0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65,
+	0x4e, 0x73, 0x12, 0x47, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x54, 0x0a, 0x0d, 0x70,
+	0x65, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x3f, 0x0a, 0x0a, 0x69, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0a, 0x69, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65,
+	0x6e, 0x74, 0x1a, 0x3b, 0x0a, 0x0d, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x3f, 0x0a, 0x11, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x3e, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x22, 0xe7, 0x02, 0x0a, 0x09, 0x57, 0x69, 0x72, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x19,
+	0x0a, 0x08, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x77, 0x69, 0x72, 0x65, 0x55, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72,
+	0x49, 0x70, 0x12, 0x42, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x2e, 0x43, 0x61, 0x75, 0x73, 0x65, 0x52, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x22, 0x36,
+	0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x10, 0x03, 0x22, 0x72, 0x0a, 0x05, 0x43, 0x61, 0x75, 0x73, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d,
+	0x4b, 0x38, 0x53, 0x5f, 0x41, 0x50, 0x49, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x4e, 0x45, 0x54, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x43, 0x41, 0x50, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x52, 0x45, 0x41,
+	0x43, 0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x41, 0x54, 0x45,
+	0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10, 0x05, 0x22, 0xc4, 0x02, 0x0a, 0x0d, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x69, 0x72,
+	0x65, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x77, 0x69, 0x72,
+	0x65, 0x55, 0x69, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x06, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x35, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x41,
+	0x49, 0x4c, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x10,
+	0x03, 0x22, 0x70, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x5a, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65,
+	0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
+	0x77, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x57, 0x69, 0x72, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x29, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x5b, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6c,
+	0x61, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x77, 0x69, 0x72, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x64, 0x57, 0x69, 0x72, 0x65, 0x52, 0x05, 0x77, 0x69, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x29, 0x0a, 0x0e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73,
+	0x22, 0x55, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x75, 0x73, 0x65,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x5c, 0x0a, 0x0b, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x75, 0x62, 0x65, 0x5f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x75, 0x62, 0x65, 0x4e, 0x73, 0x12,
+	0x34, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x39, 0x0a, 0x0a, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x58, 0x4c, 0x41, 0x4e, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x53, 0x52, 0x56, 0x36, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x50, 0x10,
+	0x02, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x49, 0x52, 0x45, 0x47, 0x55, 0x41, 0x52, 0x44, 0x10, 0x03,
+	0x2a, 0x38, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x0b, 0x0a, 0x07, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10, 0x00, 0x12, 0x0c, 0x0a,
+	0x08, 0x44, 0x45, 0x47, 0x52, 0x41, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x55,
+	0x4e, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10, 0x02, 0x32, 0xfd, 0x1e, 0x0a, 0x05, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x36, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x12, 0x3f, 0x0a, 0x08,
+	0x53, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x1a, 0x1d,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a,
+	0x0b, 0x53, 0x6b, 0x69, 0x70, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x1a, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53,
+	0x6b, 0x69, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x04, 0x53, 0x6b, 0x69, 0x70, 0x12,
+	0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x49, 0x73,
+	0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
+	0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x57, 0x69, 0x72, 0x65, 0x12, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x1a, 0x24, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4c,
+	0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x4f, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x46, 0x6c, 0x61, 0x70, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x46, 0x6c, 0x61, 0x70, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x12, 0x53, 0x74, 0x6f,
+	0x70, 0x46, 0x6c, 0x61, 0x70, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x46, 0x6c, 0x61, 0x70, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x17, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x69, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x12, 0x4a, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4e,
+	0x41, 0x44, 0x73, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4e, 0x41, 0x44, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12,
+	0x54, 0x0a, 0x0d, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x57, 0x69, 0x72, 0x65,
+	0x12, 0x21, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x5b, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x21, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x5d, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x65, 0x74, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x21, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x47,
+	0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x50, 0x41, 0x4d, 0x12, 0x19, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x50, 0x6f, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x11, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43,
+	0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x1a, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x12, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x66, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x50, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x4d, 0x54, 0x55, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4d, 0x54, 0x55, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x4d, 0x54, 0x55, 0x12, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4d, 0x54, 0x55, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x12, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
+	0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x4f, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
+	0x67, 0x79, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x4e, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x4d, 0x54, 0x55,
+	0x12, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x23, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4d,
+	0x54, 0x55, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x5f, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x57, 0x69, 0x72, 0x65, 0x12,
+	0x28, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x0e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x5f, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x57, 0x69, 0x72, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x57, 0x69, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x4b, 0x0a, 0x10, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x50,
+	0x6f, 0x64, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x18, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x4c, 0x69,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4e, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x44, 0x4f, 0x54, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x4f, 0x54, 0x47, 0x72, 0x61, 0x70,
+	0x68, 0x12, 0x56, 0x0a, 0x13, 0x50, 0x61, 0x74, 0x63, 0x68, 0x57, 0x69, 0x72, 0x65, 0x49, 0x6d,
+	0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69,
+	0x72, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x57, 0x69, 0x72, 0x65, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
+	0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x5b, 0x0a, 0x13, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x65, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x25, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a,
+	0x0c, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x26, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x13, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x1e, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1c, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x44, 0x72,
+	0x69, 0x66, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x51, 0x0a, 0x0b, 0x43, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x57, 0x69, 0x72, 0x65, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x30, 0x01, 0x12, 0x5d, 0x0a, 0x16,
+	0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e,
+	0x63, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x23, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x4f, 0x0a, 0x0a, 0x52,
+	0x65, 0x70, 0x61, 0x69, 0x72, 0x57, 0x69, 0x72, 0x65, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65,
+	0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x55, 0x0a, 0x0f,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x23, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0c, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61, 0x74, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x63, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4e, 0x0a, 0x0d, 0x41, 0x64,
+	0x64, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x57, 0x69, 0x72, 0x65, 0x12, 0x1e, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x46, 0x61,
+	0x6e, 0x6f, 0x75, 0x74, 0x57, 0x69, 0x72, 0x65, 0x44, 0x65, 0x66, 0x1a, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x52, 0x65,
+	0x70, 0x6c, 0x61, 0x79, 0x50, 0x43, 0x41, 0x50, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x43, 0x41, 0x50, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x10, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x50, 0x43, 0x41, 0x50, 0x12,
+	0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x50, 0x43, 0x41, 0x50, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12, 0x52, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1c, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x32, 0xf7, 0x0a, 0x0a, 0x06, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x64, 0x1a, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1a, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69,
+	0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x5b, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x56, 0x58, 0x4c, 0x41, 0x4e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x12,
+	0x24, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x56, 0x58, 0x4c, 0x41, 0x4e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x12, 0x21, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0e, 0x4e, 0x6f, 0x74, 0x69,
+	0x66, 0x79, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x68, 0x75,
+	0x74, 0x64, 0x6f, 0x77, 0x6e, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x1a, 0x1d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54, 0x55, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x18, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x4d, 0x54, 0x55, 0x12, 0x48, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54,
+	0x55, 0x12, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x4d, 0x54, 0x55, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a,
+	0x0c, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54, 0x55, 0x12, 0x1f, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x18,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x54, 0x55, 0x12, 0x4d, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x57,
+	0x69, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1b, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x4f, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x57, 0x69, 0x72, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x13, 0x50, 0x61, 0x74, 0x63,
+	0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x20, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x63,
+	0x68, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x55, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x6d, 0x70, 0x61, 0x69,
+	0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e,
+	0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x49, 0x6d, 0x70,
+	0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x58, 0x0a, 0x0f, 0x49, 0x6e, 0x6a, 0x65, 0x63,
+	0x74, 0x50, 0x6f, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x51, 0x0a, 0x0b, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x4c, 0x69, 0x6e, 0x6b,
+	0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x30, 0x01, 0x12, 0x4f, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x4c, 0x69,
+	0x6e, 0x6b, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2e, 0x57, 0x69, 0x72, 0x65, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x58, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50,
+	0x6f, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
+	0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12,
+	0x53, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x22, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x50, 0x43, 0x41, 0x50, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x30, 0x01, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x6f, 0x70, 0x2f, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x2f, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescOnce sync.Once
+	file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData = file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDesc
+)
+
+func file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescGZIP() []byte {
+	file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescOnce.Do(func() {
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData = protoimpl.X.CompressGZIP(file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData)
+	})
+	return file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDescData
+}
+
+var file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes = make([]protoimpl.MessageInfo, 97)
+var file_daemon_proto_meshnet_v1beta1_meshnet_proto_goTypes = []interface{}{
+	(TunnelType)(0),                 // 0: meshnet.v1beta1.TunnelType
+	(HealthStatus)(0),               // 1: meshnet.v1beta1.HealthStatus
+	(FanoutWireDef_Mode)(0),         // 2: meshnet.v1beta1.FanoutWireDef.Mode
+	(LinkPatch_Operation)(0),        // 3: meshnet.v1beta1.LinkPatch.Operation
+	(StatsStreamRequest_Mode)(0),    // 4: meshnet.v1beta1.StatsStreamRequest.Mode
+	(WireCheck_Kind)(0),             // 5: meshnet.v1beta1.WireCheck.Kind
+	(WireRepair_Action)(0),          // 6: meshnet.v1beta1.WireRepair.Action
+	(TopologyDrift_Kind)(0),         // 7: meshnet.v1beta1.TopologyDrift.Kind
+	(TopologyDrift_Remediation)(0),  // 8: meshnet.v1beta1.TopologyDrift.Remediation
+	(WireError_Operation)(0),        // 9: meshnet.v1beta1.WireError.Operation
+	(WireError_Cause)(0),            // 10: meshnet.v1beta1.WireError.Cause
+	(TopologyEvent_Type)(0),         // 11: meshnet.v1beta1.TopologyEvent.Type
+	(*Pod)(nil),                     // 12: meshnet.v1beta1.Pod
+	(*CanaryState)(nil),             // 13: meshnet.v1beta1.CanaryState
+	(*Link)(nil),                    // 14: meshnet.v1beta1.Link
+	(*LinkCredentials)(nil),         // 15: meshnet.v1beta1.LinkCredentials
+	(*TrafficClass)(nil),            // 16: meshnet.v1beta1.TrafficClass
+	(*DscpQueueEntry)(nil),          // 17: meshnet.v1beta1.DscpQueueEntry
+	(*QueueAllocation)(nil),         // 18: meshnet.v1beta1.QueueAllocation
+	(*QueueBandwidth)(nil),          // 19: meshnet.v1beta1.QueueBandwidth
+	(*ImpairmentSpec)(nil),          // 20: meshnet.v1beta1.ImpairmentSpec
+	(*PodQuery)(nil),                // 21: meshnet.v1beta1.PodQuery
+	(*SkipQuery)(nil),               // 22: meshnet.v1beta1.SkipQuery
+	(*BoolResponse)(nil),            // 23: meshnet.v1beta1.BoolResponse
+	(*RemotePod)(nil),               // 24: meshnet.v1beta1.RemotePod
+	(*VXLANNeighbor)(nil),           // 25: meshnet.v1beta1.VXLANNeighbor
+	(*VXLANNeighborUpdate)(nil),     // 26: meshnet.v1beta1.VXLANNeighborUpdate
+	(*FanoutWireDef)(nil),           // 27: meshnet.v1beta1.FanoutWireDef
+	(*LinkPatch)(nil),               // 28: meshnet.v1beta1.LinkPatch
+	(*WireAudit)(nil),               // 29: meshnet.v1beta1.WireAudit
+	(*RollbackRequest)(nil),         // 30: meshnet.v1beta1.RollbackRequest
+	(*HealthRequest)(nil),           // 31: meshnet.v1beta1.HealthRequest
+	(*HealthResponse)(nil),          // 32: meshnet.v1beta1.HealthResponse
+	(*ShutdownNotice)(nil),          // 33: meshnet.v1beta1.ShutdownNotice
+	(*EvacuationRequest)(nil),       // 34: meshnet.v1beta1.EvacuationRequest
+	(*EvacuatedPod)(nil),            // 35: meshnet.v1beta1.EvacuatedPod
+	(*EvacuationResult)(nil),        // 36: meshnet.v1beta1.EvacuationResult
+	(*HeartbeatRequest)(nil),        // 37: meshnet.v1beta1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),       // 38: meshnet.v1beta1.HeartbeatResponse
+	(*LinkStatsQuery)(nil),          // 39: meshnet.v1beta1.LinkStatsQuery
+	(*LinkStats)(nil),               // 40: meshnet.v1beta1.LinkStats
+	(*StatsStreamRequest)(nil),      // 41: meshnet.v1beta1.StatsStreamRequest
+	(*StatsBatch)(nil),              // 42: meshnet.v1beta1.StatsBatch
+	(*FlapSpec)(nil),                // 43: meshnet.v1beta1.FlapSpec
+	(*AggregatedLinkStats)(nil),     // 44: meshnet.v1beta1.AggregatedLinkStats
+	(*LinkMTU)(nil),                 // 45: meshnet.v1beta1.LinkMTU
+	(*MTUResponse)(nil),             // 46: meshnet.v1beta1.MTUResponse
+	(*ImpairmentPatch)(nil),         // 47: meshnet.v1beta1.ImpairmentPatch
+	(*WireImpairment)(nil),          // 48: meshnet.v1beta1.WireImpairment
+	(*MTURequest)(nil),              // 49: meshnet.v1beta1.MTURequest
+	(*PathQuery)(nil),               // 50: meshnet.v1beta1.PathQuery
+	(*HopMTU)(nil),                  // 51: meshnet.v1beta1.HopMTU
+	(*MTUDiscoveryResult)(nil),      // 52: meshnet.v1beta1.MTUDiscoveryResult
+	(*WireVerificationRequest)(nil), // 53: meshnet.v1beta1.WireVerificationRequest
+	(*WireRepairRequest)(nil),       // 54: meshnet.v1beta1.WireRepairRequest
+	(*WireCheck)(nil),               // 55: meshnet.v1beta1.WireCheck
+	(*WireRepair)(nil),              // 56: meshnet.v1beta1.WireRepair
+	(*RepairResult)(nil),            // 57: meshnet.v1beta1.RepairResult
+	(*WireParams)(nil),              // 58: meshnet.v1beta1.WireParams
+	(*WireMismatch)(nil),            // 59: meshnet.v1beta1.WireMismatch
+	(*WireVerificationResult)(nil),  // 60: meshnet.v1beta1.WireVerificationResult
+	(*PodList)(nil),                 // 61: meshnet.v1beta1.PodList
+	(*TopologyQuery)(nil),           // 62: meshnet.v1beta1.TopologyQuery
+	(*DryRunVeth)(nil),              // 63: meshnet.v1beta1.DryRunVeth
+	(*DryRunVxlan)(nil),             // 64: meshnet.v1beta1.DryRunVxlan
+	(*DryRunMacvlan)(nil),           // 65: meshnet.v1beta1.DryRunMacvlan
+	(*DryRunReport)(nil),            // 66: meshnet.v1beta1.DryRunReport
+	(*TopologyWireRequest)(nil),     // 67: meshnet.v1beta1.TopologyWireRequest
+	(*LinkTransactionStatus)(nil),   // 68: meshnet.v1beta1.LinkTransactionStatus
+	(*TransactionResult)(nil),       // 69: meshnet.v1beta1.TransactionResult
+	(*PolicyBundle)(nil),            // 70: meshnet.v1beta1.PolicyBundle
+	(*NADBundle)(nil),               // 71: meshnet.v1beta1.NADBundle
+	(*DOTGraph)(nil),                // 72: meshnet.v1beta1.DOTGraph
+	(*InstantiationRequest)(nil),    // 73: meshnet.v1beta1.InstantiationRequest
+	(*CaptureRequest)(nil),          // 74: meshnet.v1beta1.CaptureRequest
+	(*PCAPReplayRequest)(nil),       // 75: meshnet.v1beta1.PCAPReplayRequest
+	(*ReplayProgress)(nil),          // 76: meshnet.v1beta1.ReplayProgress
+	(*CapturedPacket)(nil),          // 77: meshnet.v1beta1.CapturedPacket
+	(*TopologyDrift)(nil),           // 78: meshnet.v1beta1.TopologyDrift
+	(*DriftReport)(nil),             // 79: meshnet.v1beta1.DriftReport
+	(*WireTypeConvergence)(nil),     // 80: meshnet.v1beta1.WireTypeConvergence
+	(*CriticalWire)(nil),            // 81: meshnet.v1beta1.CriticalWire
+	(*ConvergenceMetrics)(nil),      // 82: meshnet.v1beta1.ConvergenceMetrics
+	(*RouteInjectionRequest)(nil),   // 83: meshnet.v1beta1.RouteInjectionRequest
+	(*BenchmarkRequest)(nil),        // 84: meshnet.v1beta1.BenchmarkRequest
+	(*BenchmarkResult)(nil),         // 85: meshnet.v1beta1.BenchmarkResult
+	(*CanaryRequest)(nil),           // 86: meshnet.v1beta1.CanaryRequest
+	(*Empty)(nil),                   // 87: meshnet.v1beta1.Empty
+	(*ResourceRecommendation)(nil),  // 88: meshnet.v1beta1.ResourceRecommendation
+	(*AccountingQuery)(nil),         // 89: meshnet.v1beta1.AccountingQuery
+	(*WireTraffic)(nil),             // 90: meshnet.v1beta1.WireTraffic
+	(*AccountingReport)(nil),        // 91: meshnet.v1beta1.AccountingReport
+	(*ChaosProfile)(nil),            // 92: meshnet.v1beta1.ChaosProfile
+	(*ChaosProfileRef)(nil),         // 93: meshnet.v1beta1.ChaosProfileRef
+	(*WireError)(nil),               // 94: meshnet.v1beta1.WireError
+	(*TopologyEvent)(nil),           // 95: meshnet.v1beta1.TopologyEvent
+	(*TopologyExport)(nil),          // 96: meshnet.v1beta1.TopologyExport
+	(*ReplayRequest)(nil),           // 97: meshnet.v1beta1.ReplayRequest
+	(*ReplayedWire)(nil),            // 98: meshnet.v1beta1.ReplayedWire
+	(*ReplayResult)(nil),            // 99: meshnet.v1beta1.ReplayResult
+	(*NamespaceQuery)(nil),          // 100: meshnet.v1beta1.NamespaceQuery
+	(*ResourceUsage)(nil),           // 101: meshnet.v1beta1.ResourceUsage
+	(*UsageReport)(nil),             // 102: meshnet.v1beta1.UsageReport
+	nil,                             // 103: meshnet.v1beta1.Pod.AnnotationsEntry
+	nil,                             // 104: meshnet.v1beta1.LinkStats.DscpTxBytesEntry
+	nil,                             // 105: meshnet.v1beta1.NADBundle.PodAnnotationsEntry
+	nil,                             // 106: meshnet.v1beta1.InstantiationRequest.ParametersEntry
+	nil,                             // 107: meshnet.v1beta1.ChaosProfile.SelectorEntry
+	nil,                             // 108: meshnet.v1beta1.ChaosProfile.PeerSelectorEntry
+}
+var file_daemon_proto_meshnet_v1beta1_meshnet_proto_depIdxs = []int32{
+	14,  // 0: meshnet.v1beta1.Pod.links:type_name -> meshnet.v1beta1.Link
+	103, // 1: meshnet.v1beta1.Pod.annotations:type_name -> meshnet.v1beta1.Pod.AnnotationsEntry
+	13,  // 2: meshnet.v1beta1.Pod.canary:type_name -> meshnet.v1beta1.CanaryState
+	20,  // 3: meshnet.v1beta1.Link.egress_impairment:type_name -> meshnet.v1beta1.ImpairmentSpec
+	20,  // 4: meshnet.v1beta1.Link.ingress_impairment:type_name -> meshnet.v1beta1.ImpairmentSpec
+	16,  // 5: meshnet.v1beta1.Link.traffic_class:type_name -> meshnet.v1beta1.TrafficClass
+	17,  // 6: meshnet.v1beta1.Link.egress_qos_map:type_name -> meshnet.v1beta1.DscpQueueEntry
+	18,  // 7: meshnet.v1beta1.Link.egress_queue_allocation:type_name -> meshnet.v1beta1.QueueAllocation
+	19,  // 8: meshnet.v1beta1.QueueAllocation.queues:type_name -> meshnet.v1beta1.QueueBandwidth
+	0,   // 9: meshnet.v1beta1.RemotePod.tunnel_type:type_name -> meshnet.v1beta1.TunnelType
+	20,  // 10: meshnet.v1beta1.RemotePod.egress_impairment:type_name -> meshnet.v1beta1.ImpairmentSpec
+	20,  // 11: meshnet.v1beta1.RemotePod.ingress_impairment:type_name -> meshnet.v1beta1.ImpairmentSpec
+	25,  // 12: meshnet.v1beta1.RemotePod.neighbors:type_name -> meshnet.v1beta1.VXLANNeighbor
+	16,  // 13: meshnet.v1beta1.RemotePod.traffic_class:type_name -> meshnet.v1beta1.TrafficClass
+	15,  // 14: meshnet.v1beta1.RemotePod.credentials:type_name -> meshnet.v1beta1.LinkCredentials
+	17,  // 15: meshnet.v1beta1.RemotePod.egress_qos_map:type_name -> meshnet.v1beta1.DscpQueueEntry
+	18,  // 16: meshnet.v1beta1.RemotePod.egress_queue_allocation:type_name -> meshnet.v1beta1.QueueAllocation
+	25,  // 17: meshnet.v1beta1.VXLANNeighborUpdate.neighbors:type_name -> meshnet.v1beta1.VXLANNeighbor
+	2,   // 18: meshnet.v1beta1.FanoutWireDef.mode:type_name -> meshnet.v1beta1.FanoutWireDef.Mode
+	3,   // 19: meshnet.v1beta1.LinkPatch.operation:type_name -> meshnet.v1beta1.LinkPatch.Operation
+	14,  // 20: meshnet.v1beta1.LinkPatch.link:type_name -> meshnet.v1beta1.Link
+	1,   // 21: meshnet.v1beta1.HealthResponse.status:type_name -> meshnet.v1beta1.HealthStatus
+	35,  // 22: meshnet.v1beta1.EvacuationResult.pods:type_name -> meshnet.v1beta1.EvacuatedPod
+	104, // 23: meshnet.v1beta1.LinkStats.dscp_tx_bytes:type_name -> meshnet.v1beta1.LinkStats.DscpTxBytesEntry
+	4,   // 24: meshnet.v1beta1.StatsStreamRequest.mode:type_name -> meshnet.v1beta1.StatsStreamRequest.Mode
+	40,  // 25: meshnet.v1beta1.StatsBatch.stats:type_name -> meshnet.v1beta1.LinkStats
+	40,  // 26: meshnet.v1beta1.AggregatedLinkStats.local:type_name -> meshnet.v1beta1.LinkStats
+	40,  // 27: meshnet.v1beta1.AggregatedLinkStats.remote:type_name -> meshnet.v1beta1.LinkStats
+	45,  // 28: meshnet.v1beta1.MTUResponse.local:type_name -> meshnet.v1beta1.LinkMTU
+	45,  // 29: meshnet.v1beta1.MTUResponse.remote:type_name -> meshnet.v1beta1.LinkMTU
+	20,  // 30: meshnet.v1beta1.ImpairmentPatch.egress:type_name -> meshnet.v1beta1.ImpairmentSpec
+	20,  // 31: meshnet.v1beta1.ImpairmentPatch.ingress:type_name -> meshnet.v1beta1.ImpairmentSpec
+	20,  // 32: meshnet.v1beta1.WireImpairment.egress:type_name -> meshnet.v1beta1.ImpairmentSpec
+	20,  // 33: meshnet.v1beta1.WireImpairment.ingress:type_name -> meshnet.v1beta1.ImpairmentSpec
+	51,  // 34: meshnet.v1beta1.MTUDiscoveryResult.hops:type_name -> meshnet.v1beta1.HopMTU
+	5,   // 35: meshnet.v1beta1.WireCheck.kind:type_name -> meshnet.v1beta1.WireCheck.Kind
+	6,   // 36: meshnet.v1beta1.WireRepair.action:type_name -> meshnet.v1beta1.WireRepair.Action
+	55,  // 37: meshnet.v1beta1.RepairResult.checks:type_name -> meshnet.v1beta1.WireCheck
+	56,  // 38: meshnet.v1beta1.RepairResult.repairs:type_name -> meshnet.v1beta1.WireRepair
+	55,  // 39: meshnet.v1beta1.RepairResult.checks_after:type_name -> meshnet.v1beta1.WireCheck
+	58,  // 40: meshnet.v1beta1.WireVerificationResult.local:type_name -> meshnet.v1beta1.WireParams
+	58,  // 41: meshnet.v1beta1.WireVerificationResult.peer:type_name -> meshnet.v1beta1.WireParams
+	59,  // 42: meshnet.v1beta1.WireVerificationResult.mismatches:type_name -> meshnet.v1beta1.WireMismatch
+	63,  // 43: meshnet.v1beta1.DryRunReport.veth_pairs:type_name -> meshnet.v1beta1.DryRunVeth
+	64,  // 44: meshnet.v1beta1.DryRunReport.vxlans:type_name -> meshnet.v1beta1.DryRunVxlan
+	65,  // 45: meshnet.v1beta1.DryRunReport.macvlans:type_name -> meshnet.v1beta1.DryRunMacvlan
+	68,  // 46: meshnet.v1beta1.TransactionResult.links:type_name -> meshnet.v1beta1.LinkTransactionStatus
+	105, // 47: meshnet.v1beta1.NADBundle.pod_annotations:type_name -> meshnet.v1beta1.NADBundle.PodAnnotationsEntry
+	106, // 48: meshnet.v1beta1.InstantiationRequest.parameters:type_name -> meshnet.v1beta1.InstantiationRequest.ParametersEntry
+	7,   // 49: meshnet.v1beta1.TopologyDrift.kind:type_name -> meshnet.v1beta1.TopologyDrift.Kind
+	8,   // 50: meshnet.v1beta1.TopologyDrift.remediation:type_name -> meshnet.v1beta1.TopologyDrift.Remediation
+	78,  // 51: meshnet.v1beta1.DriftReport.drifts:type_name -> meshnet.v1beta1.TopologyDrift
+	80,  // 52: meshnet.v1beta1.ConvergenceMetrics.wire_types:type_name -> meshnet.v1beta1.WireTypeConvergence
+	81,  // 53: meshnet.v1beta1.ConvergenceMetrics.critical_path:type_name -> meshnet.v1beta1.CriticalWire
+	90,  // 54: meshnet.v1beta1.AccountingReport.wires:type_name -> meshnet.v1beta1.WireTraffic
+	107, // 55: meshnet.v1beta1.ChaosProfile.selector:type_name -> meshnet.v1beta1.ChaosProfile.SelectorEntry
+	108, // 56: meshnet.v1beta1.ChaosProfile.peer_selector:type_name -> meshnet.v1beta1.ChaosProfile.PeerSelectorEntry
+	20,  // 57: meshnet.v1beta1.ChaosProfile.impairment:type_name -> meshnet.v1beta1.ImpairmentSpec
+	9,   // 58: meshnet.v1beta1.WireError.operation:type_name -> meshnet.v1beta1.WireError.Operation
+	10,  // 59: meshnet.v1beta1.WireError.cause:type_name -> meshnet.v1beta1.WireError.Cause
+	11,  // 60: meshnet.v1beta1.TopologyEvent.type:type_name -> meshnet.v1beta1.TopologyEvent.Type
+	14,  // 61: meshnet.v1beta1.TopologyEvent.before:type_name -> meshnet.v1beta1.Link
+	14,  // 62: meshnet.v1beta1.TopologyEvent.after:type_name -> meshnet.v1beta1.Link
+	12,  // 63: meshnet.v1beta1.TopologyExport.pod:type_name -> meshnet.v1beta1.Pod
+	95,  // 64: meshnet.v1beta1.TopologyExport.events:type_name -> meshnet.v1beta1.TopologyEvent
+	14,  // 65: meshnet.v1beta1.ReplayedWire.link:type_name -> meshnet.v1beta1.Link
+	98,  // 66: meshnet.v1beta1.ReplayResult.wires:type_name -> meshnet.v1beta1.ReplayedWire
+	101, // 67: meshnet.v1beta1.UsageReport.usage:type_name -> meshnet.v1beta1.ResourceUsage
+	21,  // 68: meshnet.v1beta1.Local.Get:input_type -> meshnet.v1beta1.PodQuery
+	12,  // 69: meshnet.v1beta1.Local.SetAlive:input_type -> meshnet.v1beta1.Pod
+	22,  // 70: meshnet.v1beta1.Local.SkipReverse:input_type -> meshnet.v1beta1.SkipQuery
+	22,  // 71: meshnet.v1beta1.Local.Skip:input_type -> meshnet.v1beta1.SkipQuery
+	22,  // 72: meshnet.v1beta1.Local.IsSkipped:input_type -> meshnet.v1beta1.SkipQuery
+	28,  // 73: meshnet.v1beta1.Local.PatchLink:input_type -> meshnet.v1beta1.LinkPatch
+	31,  // 74: meshnet.v1beta1.Local.HealthCheck:input_type -> meshnet.v1beta1.HealthRequest
+	29,  // 75: meshnet.v1beta1.Local.AuditWire:input_type -> meshnet.v1beta1.WireAudit
+	30,  // 76: meshnet.v1beta1.Local.RollbackTopology:input_type -> meshnet.v1beta1.RollbackRequest
+	39,  // 77: meshnet.v1beta1.Local.GetAggregatedLinkStats:input_type -> meshnet.v1beta1.LinkStatsQuery
+	43,  // 78: meshnet.v1beta1.Local.StartFlapSimulation:input_type -> meshnet.v1beta1.FlapSpec
+	43,  // 79: meshnet.v1beta1.Local.StopFlapSimulation:input_type -> meshnet.v1beta1.FlapSpec
+	62,  // 80: meshnet.v1beta1.Local.GenerateNetworkPolicies:input_type -> meshnet.v1beta1.TopologyQuery
+	62,  // 81: meshnet.v1beta1.Local.GenerateNADs:input_type -> meshnet.v1beta1.TopologyQuery
+	84,  // 82: meshnet.v1beta1.Local.BenchmarkWire:input_type -> meshnet.v1beta1.BenchmarkRequest
+	86,  // 83: meshnet.v1beta1.Local.CanaryActivate:input_type -> meshnet.v1beta1.CanaryRequest
+	86,  // 84: meshnet.v1beta1.Local.CanaryExpand:input_type -> meshnet.v1beta1.CanaryRequest
+	86,  // 85: meshnet.v1beta1.Local.CanaryCommit:input_type -> meshnet.v1beta1.CanaryRequest
+	87,  // 86: meshnet.v1beta1.Local.GetResourceRecommendation:input_type -> meshnet.v1beta1.Empty
+	89,  // 87: meshnet.v1beta1.Local.GetTrafficAccounting:input_type -> meshnet.v1beta1.AccountingQuery
+	89,  // 88: meshnet.v1beta1.Local.ResetTrafficAccounting:input_type -> meshnet.v1beta1.AccountingQuery
+	21,  // 89: meshnet.v1beta1.Local.ReleaseIPAM:input_type -> meshnet.v1beta1.PodQuery
+	92,  // 90: meshnet.v1beta1.Local.ApplyChaosProfile:input_type -> meshnet.v1beta1.ChaosProfile
+	93,  // 91: meshnet.v1beta1.Local.RemoveChaosProfile:input_type -> meshnet.v1beta1.ChaosProfileRef
+	39,  // 92: meshnet.v1beta1.Local.GetInterfaceMTU:input_type -> meshnet.v1beta1.LinkStatsQuery
+	49,  // 93: meshnet.v1beta1.Local.SetInterfaceMTU:input_type -> meshnet.v1beta1.MTURequest
+	21,  // 94: meshnet.v1beta1.Local.ExportTopology:input_type -> meshnet.v1beta1.PodQuery
+	97,  // 95: meshnet.v1beta1.Local.ReplayTopology:input_type -> meshnet.v1beta1.ReplayRequest
+	50,  // 96: meshnet.v1beta1.Local.DiscoverMTU:input_type -> meshnet.v1beta1.PathQuery
+	53,  // 97: meshnet.v1beta1.Local.VerifyWire:input_type -> meshnet.v1beta1.WireVerificationRequest
+	62,  // 98: meshnet.v1beta1.Local.DryRunTopology:input_type -> meshnet.v1beta1.TopologyQuery
+	67,  // 99: meshnet.v1beta1.Local.CreateTopologyWires:input_type -> meshnet.v1beta1.TopologyWireRequest
+	61,  // 100: meshnet.v1beta1.Local.WaitForPodsAlive:input_type -> meshnet.v1beta1.PodList
+	62,  // 101: meshnet.v1beta1.Local.ExportTopologyDOT:input_type -> meshnet.v1beta1.TopologyQuery
+	47,  // 102: meshnet.v1beta1.Local.PatchWireImpairment:input_type -> meshnet.v1beta1.ImpairmentPatch
+	39,  // 103: meshnet.v1beta1.Local.GetWireImpairment:input_type -> meshnet.v1beta1.LinkStatsQuery
+	73,  // 104: meshnet.v1beta1.Local.InstantiateTopology:input_type -> meshnet.v1beta1.InstantiationRequest
+	83,  // 105: meshnet.v1beta1.Local.InjectRoutes:input_type -> meshnet.v1beta1.RouteInjectionRequest
+	62,  // 106: meshnet.v1beta1.Local.DetectTopologyDrift:input_type -> meshnet.v1beta1.TopologyQuery
+	74,  // 107: meshnet.v1beta1.Local.CaptureWire:input_type -> meshnet.v1beta1.CaptureRequest
+	62,  // 108: meshnet.v1beta1.Local.MeasureConvergenceTime:input_type -> meshnet.v1beta1.TopologyQuery
+	54,  // 109: meshnet.v1beta1.Local.RepairWire:input_type -> meshnet.v1beta1.WireRepairRequest
+	41,  // 110: meshnet.v1beta1.Local.StreamLinkStats:input_type -> meshnet.v1beta1.StatsStreamRequest
+	34,  // 111: meshnet.v1beta1.Local.EvacuateNode:input_type -> meshnet.v1beta1.EvacuationRequest
+	27,  // 112: meshnet.v1beta1.Local.AddFanoutWire:input_type -> meshnet.v1beta1.FanoutWireDef
+	75,  // 113: meshnet.v1beta1.Local.ReplayPCAP:input_type -> meshnet.v1beta1.PCAPReplayRequest
+	75,  // 114: meshnet.v1beta1.Local.StreamReplayPCAP:input_type -> meshnet.v1beta1.PCAPReplayRequest
+	100, // 115: meshnet.v1beta1.Local.GetNamespaceUsage:input_type -> meshnet.v1beta1.NamespaceQuery
+	24,  // 116: meshnet.v1beta1.Remote.Update:input_type -> meshnet.v1beta1.RemotePod
+	39,  // 117: meshnet.v1beta1.Remote.GetLinkStats:input_type -> meshnet.v1beta1.LinkStatsQuery
+	26,  // 118: meshnet.v1beta1.Remote.UpdateVXLANNeighbors:input_type -> meshnet.v1beta1.VXLANNeighborUpdate
+	37,  // 119: meshnet.v1beta1.Remote.Heartbeat:input_type -> meshnet.v1beta1.HeartbeatRequest
+	33,  // 120: meshnet.v1beta1.Remote.NotifyShutdown:input_type -> meshnet.v1beta1.ShutdownNotice
+	39,  // 121: meshnet.v1beta1.Remote.GetLinkMTU:input_type -> meshnet.v1beta1.LinkStatsQuery
+	49,  // 122: meshnet.v1beta1.Remote.SetLinkMTU:input_type -> meshnet.v1beta1.MTURequest
+	39,  // 123: meshnet.v1beta1.Remote.ProbeLinkMTU:input_type -> meshnet.v1beta1.LinkStatsQuery
+	39,  // 124: meshnet.v1beta1.Remote.GetWireParams:input_type -> meshnet.v1beta1.LinkStatsQuery
+	39,  // 125: meshnet.v1beta1.Remote.RemoveWireEnd:input_type -> meshnet.v1beta1.LinkStatsQuery
+	47,  // 126: meshnet.v1beta1.Remote.PatchLinkImpairment:input_type -> meshnet.v1beta1.ImpairmentPatch
+	39,  // 127: meshnet.v1beta1.Remote.GetLinkImpairment:input_type -> meshnet.v1beta1.LinkStatsQuery
+	83,  // 128: meshnet.v1beta1.Remote.InjectPodRoutes:input_type -> meshnet.v1beta1.RouteInjectionRequest
+	74,  // 129: meshnet.v1beta1.Remote.CaptureLink:input_type -> meshnet.v1beta1.CaptureRequest
+	54,  // 130: meshnet.v1beta1.Remote.RepairLink:input_type -> meshnet.v1beta1.WireRepairRequest
+	41,  // 131: meshnet.v1beta1.Remote.StreamPodLinkStats:input_type -> meshnet.v1beta1.StatsStreamRequest
+	75,  // 132: meshnet.v1beta1.Remote.ReplayLink:input_type -> meshnet.v1beta1.PCAPReplayRequest
+	12,  // 133: meshnet.v1beta1.Local.Get:output_type -> meshnet.v1beta1.Pod
+	23,  // 134: meshnet.v1beta1.Local.SetAlive:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 135: meshnet.v1beta1.Local.SkipReverse:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 136: meshnet.v1beta1.Local.Skip:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 137: meshnet.v1beta1.Local.IsSkipped:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 138: meshnet.v1beta1.Local.PatchLink:output_type -> meshnet.v1beta1.BoolResponse
+	32,  // 139: meshnet.v1beta1.Local.HealthCheck:output_type -> meshnet.v1beta1.HealthResponse
+	23,  // 140: meshnet.v1beta1.Local.AuditWire:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 141: meshnet.v1beta1.Local.RollbackTopology:output_type -> meshnet.v1beta1.BoolResponse
+	44,  // 142: meshnet.v1beta1.Local.GetAggregatedLinkStats:output_type -> meshnet.v1beta1.AggregatedLinkStats
+	23,  // 143: meshnet.v1beta1.Local.StartFlapSimulation:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 144: meshnet.v1beta1.Local.StopFlapSimulation:output_type -> meshnet.v1beta1.BoolResponse
+	70,  // 145: meshnet.v1beta1.Local.GenerateNetworkPolicies:output_type -> meshnet.v1beta1.PolicyBundle
+	71,  // 146: meshnet.v1beta1.Local.GenerateNADs:output_type -> meshnet.v1beta1.NADBundle
+	85,  // 147: meshnet.v1beta1.Local.BenchmarkWire:output_type -> meshnet.v1beta1.BenchmarkResult
+	23,  // 148: meshnet.v1beta1.Local.CanaryActivate:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 149: meshnet.v1beta1.Local.CanaryExpand:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 150: meshnet.v1beta1.Local.CanaryCommit:output_type -> meshnet.v1beta1.BoolResponse
+	88,  // 151: meshnet.v1beta1.Local.GetResourceRecommendation:output_type -> meshnet.v1beta1.ResourceRecommendation
+	91,  // 152: meshnet.v1beta1.Local.GetTrafficAccounting:output_type -> meshnet.v1beta1.AccountingReport
+	91,  // 153: meshnet.v1beta1.Local.ResetTrafficAccounting:output_type -> meshnet.v1beta1.AccountingReport
+	23,  // 154: meshnet.v1beta1.Local.ReleaseIPAM:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 155: meshnet.v1beta1.Local.ApplyChaosProfile:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 156: meshnet.v1beta1.Local.RemoveChaosProfile:output_type -> meshnet.v1beta1.BoolResponse
+	46,  // 157: meshnet.v1beta1.Local.GetInterfaceMTU:output_type -> meshnet.v1beta1.MTUResponse
+	23,  // 158: meshnet.v1beta1.Local.SetInterfaceMTU:output_type -> meshnet.v1beta1.BoolResponse
+	96,  // 159: meshnet.v1beta1.Local.ExportTopology:output_type -> meshnet.v1beta1.TopologyExport
+	99,  // 160: meshnet.v1beta1.Local.ReplayTopology:output_type -> meshnet.v1beta1.ReplayResult
+	52,  // 161: meshnet.v1beta1.Local.DiscoverMTU:output_type -> meshnet.v1beta1.MTUDiscoveryResult
+	60,  // 162: meshnet.v1beta1.Local.VerifyWire:output_type -> meshnet.v1beta1.WireVerificationResult
+	66,  // 163: meshnet.v1beta1.Local.DryRunTopology:output_type -> meshnet.v1beta1.DryRunReport
+	69,  // 164: meshnet.v1beta1.Local.CreateTopologyWires:output_type -> meshnet.v1beta1.TransactionResult
+	23,  // 165: meshnet.v1beta1.Local.WaitForPodsAlive:output_type -> meshnet.v1beta1.BoolResponse
+	72,  // 166: meshnet.v1beta1.Local.ExportTopologyDOT:output_type -> meshnet.v1beta1.DOTGraph
+	23,  // 167: meshnet.v1beta1.Local.PatchWireImpairment:output_type -> meshnet.v1beta1.BoolResponse
+	48,  // 168: meshnet.v1beta1.Local.GetWireImpairment:output_type -> meshnet.v1beta1.WireImpairment
+	23,  // 169: meshnet.v1beta1.Local.InstantiateTopology:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 170: meshnet.v1beta1.Local.InjectRoutes:output_type -> meshnet.v1beta1.BoolResponse
+	79,  // 171: meshnet.v1beta1.Local.DetectTopologyDrift:output_type -> meshnet.v1beta1.DriftReport
+	77,  // 172: meshnet.v1beta1.Local.CaptureWire:output_type -> meshnet.v1beta1.CapturedPacket
+	82,  // 173: meshnet.v1beta1.Local.MeasureConvergenceTime:output_type -> meshnet.v1beta1.ConvergenceMetrics
+	57,  // 174: meshnet.v1beta1.Local.RepairWire:output_type -> meshnet.v1beta1.RepairResult
+	42,  // 175: meshnet.v1beta1.Local.StreamLinkStats:output_type -> meshnet.v1beta1.StatsBatch
+	36,  // 176: meshnet.v1beta1.Local.EvacuateNode:output_type -> meshnet.v1beta1.EvacuationResult
+	23,  // 177: meshnet.v1beta1.Local.AddFanoutWire:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 178: meshnet.v1beta1.Local.ReplayPCAP:output_type -> meshnet.v1beta1.BoolResponse
+	76,  // 179: meshnet.v1beta1.Local.StreamReplayPCAP:output_type -> meshnet.v1beta1.ReplayProgress
+	102, // 180: meshnet.v1beta1.Local.GetNamespaceUsage:output_type -> meshnet.v1beta1.UsageReport
+	23,  // 181: meshnet.v1beta1.Remote.Update:output_type -> meshnet.v1beta1.BoolResponse
+	40,  // 182: meshnet.v1beta1.Remote.GetLinkStats:output_type -> meshnet.v1beta1.LinkStats
+	23,  // 183: meshnet.v1beta1.Remote.UpdateVXLANNeighbors:output_type -> meshnet.v1beta1.BoolResponse
+	38,  // 184: meshnet.v1beta1.Remote.Heartbeat:output_type -> meshnet.v1beta1.HeartbeatResponse
+	23,  // 185: meshnet.v1beta1.Remote.NotifyShutdown:output_type -> meshnet.v1beta1.BoolResponse
+	45,  // 186: meshnet.v1beta1.Remote.GetLinkMTU:output_type -> meshnet.v1beta1.LinkMTU
+	23,  // 187: meshnet.v1beta1.Remote.SetLinkMTU:output_type -> meshnet.v1beta1.BoolResponse
+	45,  // 188: meshnet.v1beta1.Remote.ProbeLinkMTU:output_type -> meshnet.v1beta1.LinkMTU
+	58,  // 189: meshnet.v1beta1.Remote.GetWireParams:output_type -> meshnet.v1beta1.WireParams
+	23,  // 190: meshnet.v1beta1.Remote.RemoveWireEnd:output_type -> meshnet.v1beta1.BoolResponse
+	23,  // 191: meshnet.v1beta1.Remote.PatchLinkImpairment:output_type -> meshnet.v1beta1.BoolResponse
+	48,  // 192: meshnet.v1beta1.Remote.GetLinkImpairment:output_type -> meshnet.v1beta1.WireImpairment
+	23,  // 193: meshnet.v1beta1.Remote.InjectPodRoutes:output_type -> meshnet.v1beta1.BoolResponse
+	77,  // 194: meshnet.v1beta1.Remote.CaptureLink:output_type -> meshnet.v1beta1.CapturedPacket
+	57,  // 195: meshnet.v1beta1.Remote.RepairLink:output_type -> meshnet.v1beta1.RepairResult
+	42,  // 196: meshnet.v1beta1.Remote.StreamPodLinkStats:output_type -> meshnet.v1beta1.StatsBatch
+	76,  // 197: meshnet.v1beta1.Remote.ReplayLink:output_type -> meshnet.v1beta1.ReplayProgress
+	133, // [133:198] is the sub-list for method output_type
+	68,  // [68:133] is the sub-list for method input_type
+	68,  // [68:68] is the sub-list for extension type_name
+	68,  // [68:68] is the sub-list for extension extendee
+	0,   // [0:68] is the sub-list for field type_name
+}
+
+func init() { file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() }
+func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
+	if File_daemon_proto_meshnet_v1beta1_meshnet_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Link); i {
 			case 0:
 				return &v.state
@@ -599,8 +9246,728 @@ func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
 				return nil
 			}
 		}
-		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PodQuery); i {
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkCredentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrafficClass); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DscpQueueEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueAllocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueBandwidth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImpairmentSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PodQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SkipQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoolResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemotePod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VXLANNeighbor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VXLANNeighborUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanoutWireDef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkPatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireAudit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollbackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShutdownNotice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvacuationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvacuatedPod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvacuationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStatsQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlapSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregatedLinkStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkMTU); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MTUResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImpairmentPatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireImpairment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MTURequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HopMTU); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MTUDiscoveryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireVerificationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireRepairRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireRepair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepairResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireMismatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireVerificationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PodList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DryRunVeth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DryRunVxlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DryRunMacvlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DryRunReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyWireRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkTransactionStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyBundle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NADBundle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DOTGraph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstantiationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PCAPReplayRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -611,8 +9978,8 @@ func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
 				return nil
 			}
 		}
-		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SkipQuery); i {
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -623,8 +9990,8 @@ func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
 				return nil
 			}
 		}
-		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BoolResponse); i {
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CapturedPacket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -635,8 +10002,296 @@ func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
 				return nil
 			}
 		}
-		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemotePod); i {
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyDrift); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DriftReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireTypeConvergence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CriticalWire); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvergenceMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteInjectionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BenchmarkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BenchmarkResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceRecommendation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountingQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireTraffic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountingReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChaosProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChaosProfileRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayedWire); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamespaceQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageReport); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -653,13 +10308,14 @@ func file_daemon_proto_meshnet_v1beta1_meshnet_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_daemon_proto_meshnet_v1beta1_meshnet_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      12,
+			NumMessages:   97,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
 		GoTypes:           file_daemon_proto_meshnet_v1beta1_meshnet_proto_goTypes,
 		DependencyIndexes: file_daemon_proto_meshnet_v1beta1_meshnet_proto_depIdxs,
+		EnumInfos:         file_daemon_proto_meshnet_v1beta1_meshnet_proto_enumTypes,
 		MessageInfos:      file_daemon_proto_meshnet_v1beta1_meshnet_proto_msgTypes,
 	}.Build()
 	File_daemon_proto_meshnet_v1beta1_meshnet_proto = out.File
@@ -27,6 +27,52 @@ type LocalClient interface {
 	SkipReverse(ctx context.Context, in *SkipQuery, opts ...grpc.CallOption) (*BoolResponse, error)
 	Skip(ctx context.Context, in *SkipQuery, opts ...grpc.CallOption) (*BoolResponse, error)
 	IsSkipped(ctx context.Context, in *SkipQuery, opts ...grpc.CallOption) (*BoolResponse, error)
+	PatchLink(ctx context.Context, in *LinkPatch, opts ...grpc.CallOption) (*BoolResponse, error)
+	HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	AuditWire(ctx context.Context, in *WireAudit, opts ...grpc.CallOption) (*BoolResponse, error)
+	RollbackTopology(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetAggregatedLinkStats(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*AggregatedLinkStats, error)
+	StartFlapSimulation(ctx context.Context, in *FlapSpec, opts ...grpc.CallOption) (*BoolResponse, error)
+	// only the pod, kube_ns and link_uid of the FlapSpec are used
+	StopFlapSimulation(ctx context.Context, in *FlapSpec, opts ...grpc.CallOption) (*BoolResponse, error)
+	GenerateNetworkPolicies(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*PolicyBundle, error)
+	GenerateNADs(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*NADBundle, error)
+	BenchmarkWire(ctx context.Context, in *BenchmarkRequest, opts ...grpc.CallOption) (*BenchmarkResult, error)
+	CanaryActivate(ctx context.Context, in *CanaryRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	CanaryExpand(ctx context.Context, in *CanaryRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	// only the name and kube_ns of the CanaryRequest are used
+	CanaryCommit(ctx context.Context, in *CanaryRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetResourceRecommendation(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ResourceRecommendation, error)
+	GetTrafficAccounting(ctx context.Context, in *AccountingQuery, opts ...grpc.CallOption) (*AccountingReport, error)
+	// only the kube_ns of the AccountingQuery is used
+	ResetTrafficAccounting(ctx context.Context, in *AccountingQuery, opts ...grpc.CallOption) (*AccountingReport, error)
+	ReleaseIPAM(ctx context.Context, in *PodQuery, opts ...grpc.CallOption) (*BoolResponse, error)
+	ApplyChaosProfile(ctx context.Context, in *ChaosProfile, opts ...grpc.CallOption) (*BoolResponse, error)
+	RemoveChaosProfile(ctx context.Context, in *ChaosProfileRef, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetInterfaceMTU(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*MTUResponse, error)
+	SetInterfaceMTU(ctx context.Context, in *MTURequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	ExportTopology(ctx context.Context, in *PodQuery, opts ...grpc.CallOption) (*TopologyExport, error)
+	ReplayTopology(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResult, error)
+	DiscoverMTU(ctx context.Context, in *PathQuery, opts ...grpc.CallOption) (*MTUDiscoveryResult, error)
+	VerifyWire(ctx context.Context, in *WireVerificationRequest, opts ...grpc.CallOption) (*WireVerificationResult, error)
+	DryRunTopology(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*DryRunReport, error)
+	CreateTopologyWires(ctx context.Context, in *TopologyWireRequest, opts ...grpc.CallOption) (*TransactionResult, error)
+	WaitForPodsAlive(ctx context.Context, in *PodList, opts ...grpc.CallOption) (*BoolResponse, error)
+	ExportTopologyDOT(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*DOTGraph, error)
+	PatchWireImpairment(ctx context.Context, in *ImpairmentPatch, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetWireImpairment(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*WireImpairment, error)
+	InstantiateTopology(ctx context.Context, in *InstantiationRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	InjectRoutes(ctx context.Context, in *RouteInjectionRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	DetectTopologyDrift(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*DriftReport, error)
+	CaptureWire(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (Local_CaptureWireClient, error)
+	MeasureConvergenceTime(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*ConvergenceMetrics, error)
+	RepairWire(ctx context.Context, in *WireRepairRequest, opts ...grpc.CallOption) (*RepairResult, error)
+	StreamLinkStats(ctx context.Context, in *StatsStreamRequest, opts ...grpc.CallOption) (Local_StreamLinkStatsClient, error)
+	EvacuateNode(ctx context.Context, in *EvacuationRequest, opts ...grpc.CallOption) (*EvacuationResult, error)
+	AddFanoutWire(ctx context.Context, in *FanoutWireDef, opts ...grpc.CallOption) (*BoolResponse, error)
+	ReplayPCAP(ctx context.Context, in *PCAPReplayRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	StreamReplayPCAP(ctx context.Context, in *PCAPReplayRequest, opts ...grpc.CallOption) (Local_StreamReplayPCAPClient, error)
+	GetNamespaceUsage(ctx context.Context, in *NamespaceQuery, opts ...grpc.CallOption) (*UsageReport, error)
 }
 
 type localClient struct {
@@ -82,242 +128,2422 @@ func (c *localClient) IsSkipped(ctx context.Context, in *SkipQuery, opts ...grpc
 	return out, nil
 }
 
+func (c *localClient) PatchLink(ctx context.Context, in *LinkPatch, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/PatchLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/HealthCheck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) AuditWire(ctx context.Context, in *WireAudit, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/AuditWire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) RollbackTopology(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/RollbackTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GetAggregatedLinkStats(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*AggregatedLinkStats, error) {
+	out := new(AggregatedLinkStats)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GetAggregatedLinkStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) StartFlapSimulation(ctx context.Context, in *FlapSpec, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/StartFlapSimulation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) StopFlapSimulation(ctx context.Context, in *FlapSpec, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/StopFlapSimulation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GenerateNetworkPolicies(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*PolicyBundle, error) {
+	out := new(PolicyBundle)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GenerateNetworkPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GenerateNADs(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*NADBundle, error) {
+	out := new(NADBundle)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GenerateNADs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) BenchmarkWire(ctx context.Context, in *BenchmarkRequest, opts ...grpc.CallOption) (*BenchmarkResult, error) {
+	out := new(BenchmarkResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/BenchmarkWire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) CanaryActivate(ctx context.Context, in *CanaryRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/CanaryActivate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) CanaryExpand(ctx context.Context, in *CanaryRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/CanaryExpand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) CanaryCommit(ctx context.Context, in *CanaryRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/CanaryCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GetResourceRecommendation(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ResourceRecommendation, error) {
+	out := new(ResourceRecommendation)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GetResourceRecommendation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GetTrafficAccounting(ctx context.Context, in *AccountingQuery, opts ...grpc.CallOption) (*AccountingReport, error) {
+	out := new(AccountingReport)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GetTrafficAccounting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ResetTrafficAccounting(ctx context.Context, in *AccountingQuery, opts ...grpc.CallOption) (*AccountingReport, error) {
+	out := new(AccountingReport)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ResetTrafficAccounting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ReleaseIPAM(ctx context.Context, in *PodQuery, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ReleaseIPAM", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ApplyChaosProfile(ctx context.Context, in *ChaosProfile, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ApplyChaosProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) RemoveChaosProfile(ctx context.Context, in *ChaosProfileRef, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/RemoveChaosProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GetInterfaceMTU(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*MTUResponse, error) {
+	out := new(MTUResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GetInterfaceMTU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) SetInterfaceMTU(ctx context.Context, in *MTURequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/SetInterfaceMTU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ExportTopology(ctx context.Context, in *PodQuery, opts ...grpc.CallOption) (*TopologyExport, error) {
+	out := new(TopologyExport)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ExportTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ReplayTopology(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResult, error) {
+	out := new(ReplayResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ReplayTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) DiscoverMTU(ctx context.Context, in *PathQuery, opts ...grpc.CallOption) (*MTUDiscoveryResult, error) {
+	out := new(MTUDiscoveryResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/DiscoverMTU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) VerifyWire(ctx context.Context, in *WireVerificationRequest, opts ...grpc.CallOption) (*WireVerificationResult, error) {
+	out := new(WireVerificationResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/VerifyWire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) DryRunTopology(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*DryRunReport, error) {
+	out := new(DryRunReport)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/DryRunTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) CreateTopologyWires(ctx context.Context, in *TopologyWireRequest, opts ...grpc.CallOption) (*TransactionResult, error) {
+	out := new(TransactionResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/CreateTopologyWires", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) WaitForPodsAlive(ctx context.Context, in *PodList, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/WaitForPodsAlive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ExportTopologyDOT(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*DOTGraph, error) {
+	out := new(DOTGraph)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ExportTopologyDOT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) PatchWireImpairment(ctx context.Context, in *ImpairmentPatch, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/PatchWireImpairment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) GetWireImpairment(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*WireImpairment, error) {
+	out := new(WireImpairment)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GetWireImpairment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) InstantiateTopology(ctx context.Context, in *InstantiationRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/InstantiateTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) InjectRoutes(ctx context.Context, in *RouteInjectionRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/InjectRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) DetectTopologyDrift(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*DriftReport, error) {
+	out := new(DriftReport)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/DetectTopologyDrift", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) CaptureWire(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (Local_CaptureWireClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Local_ServiceDesc.Streams[0], "/meshnet.v1beta1.Local/CaptureWire", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &localCaptureWireClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Local_CaptureWireClient interface {
+	Recv() (*CapturedPacket, error)
+	grpc.ClientStream
+}
+
+type localCaptureWireClient struct {
+	grpc.ClientStream
+}
+
+func (x *localCaptureWireClient) Recv() (*CapturedPacket, error) {
+	m := new(CapturedPacket)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *localClient) MeasureConvergenceTime(ctx context.Context, in *TopologyQuery, opts ...grpc.CallOption) (*ConvergenceMetrics, error) {
+	out := new(ConvergenceMetrics)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/MeasureConvergenceTime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) RepairWire(ctx context.Context, in *WireRepairRequest, opts ...grpc.CallOption) (*RepairResult, error) {
+	out := new(RepairResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/RepairWire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) StreamLinkStats(ctx context.Context, in *StatsStreamRequest, opts ...grpc.CallOption) (Local_StreamLinkStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Local_ServiceDesc.Streams[1], "/meshnet.v1beta1.Local/StreamLinkStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &localStreamLinkStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Local_StreamLinkStatsClient interface {
+	Recv() (*StatsBatch, error)
+	grpc.ClientStream
+}
+
+type localStreamLinkStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *localStreamLinkStatsClient) Recv() (*StatsBatch, error) {
+	m := new(StatsBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *localClient) EvacuateNode(ctx context.Context, in *EvacuationRequest, opts ...grpc.CallOption) (*EvacuationResult, error) {
+	out := new(EvacuationResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/EvacuateNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) AddFanoutWire(ctx context.Context, in *FanoutWireDef, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/AddFanoutWire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) ReplayPCAP(ctx context.Context, in *PCAPReplayRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/ReplayPCAP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localClient) StreamReplayPCAP(ctx context.Context, in *PCAPReplayRequest, opts ...grpc.CallOption) (Local_StreamReplayPCAPClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Local_ServiceDesc.Streams[2], "/meshnet.v1beta1.Local/StreamReplayPCAP", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &localStreamReplayPCAPClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Local_StreamReplayPCAPClient interface {
+	Recv() (*ReplayProgress, error)
+	grpc.ClientStream
+}
+
+type localStreamReplayPCAPClient struct {
+	grpc.ClientStream
+}
+
+func (x *localStreamReplayPCAPClient) Recv() (*ReplayProgress, error) {
+	m := new(ReplayProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *localClient) GetNamespaceUsage(ctx context.Context, in *NamespaceQuery, opts ...grpc.CallOption) (*UsageReport, error) {
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Local/GetNamespaceUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LocalServer is the server API for Local service.
 // All implementations must embed UnimplementedLocalServer
 // for forward compatibility
-type LocalServer interface {
-	Get(context.Context, *PodQuery) (*Pod, error)
-	SetAlive(context.Context, *Pod) (*BoolResponse, error)
-	SkipReverse(context.Context, *SkipQuery) (*BoolResponse, error)
-	Skip(context.Context, *SkipQuery) (*BoolResponse, error)
-	IsSkipped(context.Context, *SkipQuery) (*BoolResponse, error)
-	mustEmbedUnimplementedLocalServer()
+type LocalServer interface {
+	Get(context.Context, *PodQuery) (*Pod, error)
+	SetAlive(context.Context, *Pod) (*BoolResponse, error)
+	SkipReverse(context.Context, *SkipQuery) (*BoolResponse, error)
+	Skip(context.Context, *SkipQuery) (*BoolResponse, error)
+	IsSkipped(context.Context, *SkipQuery) (*BoolResponse, error)
+	PatchLink(context.Context, *LinkPatch) (*BoolResponse, error)
+	HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error)
+	AuditWire(context.Context, *WireAudit) (*BoolResponse, error)
+	RollbackTopology(context.Context, *RollbackRequest) (*BoolResponse, error)
+	GetAggregatedLinkStats(context.Context, *LinkStatsQuery) (*AggregatedLinkStats, error)
+	StartFlapSimulation(context.Context, *FlapSpec) (*BoolResponse, error)
+	// only the pod, kube_ns and link_uid of the FlapSpec are used
+	StopFlapSimulation(context.Context, *FlapSpec) (*BoolResponse, error)
+	GenerateNetworkPolicies(context.Context, *TopologyQuery) (*PolicyBundle, error)
+	GenerateNADs(context.Context, *TopologyQuery) (*NADBundle, error)
+	BenchmarkWire(context.Context, *BenchmarkRequest) (*BenchmarkResult, error)
+	CanaryActivate(context.Context, *CanaryRequest) (*BoolResponse, error)
+	CanaryExpand(context.Context, *CanaryRequest) (*BoolResponse, error)
+	// only the name and kube_ns of the CanaryRequest are used
+	CanaryCommit(context.Context, *CanaryRequest) (*BoolResponse, error)
+	GetResourceRecommendation(context.Context, *Empty) (*ResourceRecommendation, error)
+	GetTrafficAccounting(context.Context, *AccountingQuery) (*AccountingReport, error)
+	// only the kube_ns of the AccountingQuery is used
+	ResetTrafficAccounting(context.Context, *AccountingQuery) (*AccountingReport, error)
+	ReleaseIPAM(context.Context, *PodQuery) (*BoolResponse, error)
+	ApplyChaosProfile(context.Context, *ChaosProfile) (*BoolResponse, error)
+	RemoveChaosProfile(context.Context, *ChaosProfileRef) (*BoolResponse, error)
+	GetInterfaceMTU(context.Context, *LinkStatsQuery) (*MTUResponse, error)
+	SetInterfaceMTU(context.Context, *MTURequest) (*BoolResponse, error)
+	ExportTopology(context.Context, *PodQuery) (*TopologyExport, error)
+	ReplayTopology(context.Context, *ReplayRequest) (*ReplayResult, error)
+	DiscoverMTU(context.Context, *PathQuery) (*MTUDiscoveryResult, error)
+	VerifyWire(context.Context, *WireVerificationRequest) (*WireVerificationResult, error)
+	DryRunTopology(context.Context, *TopologyQuery) (*DryRunReport, error)
+	CreateTopologyWires(context.Context, *TopologyWireRequest) (*TransactionResult, error)
+	WaitForPodsAlive(context.Context, *PodList) (*BoolResponse, error)
+	ExportTopologyDOT(context.Context, *TopologyQuery) (*DOTGraph, error)
+	PatchWireImpairment(context.Context, *ImpairmentPatch) (*BoolResponse, error)
+	GetWireImpairment(context.Context, *LinkStatsQuery) (*WireImpairment, error)
+	InstantiateTopology(context.Context, *InstantiationRequest) (*BoolResponse, error)
+	InjectRoutes(context.Context, *RouteInjectionRequest) (*BoolResponse, error)
+	DetectTopologyDrift(context.Context, *TopologyQuery) (*DriftReport, error)
+	CaptureWire(*CaptureRequest, Local_CaptureWireServer) error
+	MeasureConvergenceTime(context.Context, *TopologyQuery) (*ConvergenceMetrics, error)
+	RepairWire(context.Context, *WireRepairRequest) (*RepairResult, error)
+	StreamLinkStats(*StatsStreamRequest, Local_StreamLinkStatsServer) error
+	EvacuateNode(context.Context, *EvacuationRequest) (*EvacuationResult, error)
+	AddFanoutWire(context.Context, *FanoutWireDef) (*BoolResponse, error)
+	ReplayPCAP(context.Context, *PCAPReplayRequest) (*BoolResponse, error)
+	StreamReplayPCAP(*PCAPReplayRequest, Local_StreamReplayPCAPServer) error
+	GetNamespaceUsage(context.Context, *NamespaceQuery) (*UsageReport, error)
+	mustEmbedUnimplementedLocalServer()
+}
+
+// UnimplementedLocalServer must be embedded to have forward compatible implementations.
+type UnimplementedLocalServer struct {
+}
+
+func (UnimplementedLocalServer) Get(context.Context, *PodQuery) (*Pod, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedLocalServer) SetAlive(context.Context, *Pod) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAlive not implemented")
+}
+func (UnimplementedLocalServer) SkipReverse(context.Context, *SkipQuery) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SkipReverse not implemented")
+}
+func (UnimplementedLocalServer) Skip(context.Context, *SkipQuery) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Skip not implemented")
+}
+func (UnimplementedLocalServer) IsSkipped(context.Context, *SkipQuery) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsSkipped not implemented")
+}
+func (UnimplementedLocalServer) PatchLink(context.Context, *LinkPatch) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchLink not implemented")
+}
+func (UnimplementedLocalServer) HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedLocalServer) AuditWire(context.Context, *WireAudit) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditWire not implemented")
+}
+func (UnimplementedLocalServer) RollbackTopology(context.Context, *RollbackRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackTopology not implemented")
+}
+func (UnimplementedLocalServer) GetAggregatedLinkStats(context.Context, *LinkStatsQuery) (*AggregatedLinkStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregatedLinkStats not implemented")
+}
+func (UnimplementedLocalServer) StartFlapSimulation(context.Context, *FlapSpec) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartFlapSimulation not implemented")
+}
+func (UnimplementedLocalServer) StopFlapSimulation(context.Context, *FlapSpec) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopFlapSimulation not implemented")
+}
+func (UnimplementedLocalServer) GenerateNetworkPolicies(context.Context, *TopologyQuery) (*PolicyBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateNetworkPolicies not implemented")
+}
+func (UnimplementedLocalServer) GenerateNADs(context.Context, *TopologyQuery) (*NADBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateNADs not implemented")
+}
+func (UnimplementedLocalServer) BenchmarkWire(context.Context, *BenchmarkRequest) (*BenchmarkResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BenchmarkWire not implemented")
+}
+func (UnimplementedLocalServer) CanaryActivate(context.Context, *CanaryRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanaryActivate not implemented")
+}
+func (UnimplementedLocalServer) CanaryExpand(context.Context, *CanaryRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanaryExpand not implemented")
+}
+func (UnimplementedLocalServer) CanaryCommit(context.Context, *CanaryRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanaryCommit not implemented")
+}
+func (UnimplementedLocalServer) GetResourceRecommendation(context.Context, *Empty) (*ResourceRecommendation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceRecommendation not implemented")
+}
+func (UnimplementedLocalServer) GetTrafficAccounting(context.Context, *AccountingQuery) (*AccountingReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrafficAccounting not implemented")
+}
+func (UnimplementedLocalServer) ResetTrafficAccounting(context.Context, *AccountingQuery) (*AccountingReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetTrafficAccounting not implemented")
+}
+func (UnimplementedLocalServer) ReleaseIPAM(context.Context, *PodQuery) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseIPAM not implemented")
+}
+func (UnimplementedLocalServer) ApplyChaosProfile(context.Context, *ChaosProfile) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyChaosProfile not implemented")
+}
+func (UnimplementedLocalServer) RemoveChaosProfile(context.Context, *ChaosProfileRef) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChaosProfile not implemented")
+}
+func (UnimplementedLocalServer) GetInterfaceMTU(context.Context, *LinkStatsQuery) (*MTUResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInterfaceMTU not implemented")
+}
+func (UnimplementedLocalServer) SetInterfaceMTU(context.Context, *MTURequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInterfaceMTU not implemented")
+}
+func (UnimplementedLocalServer) ExportTopology(context.Context, *PodQuery) (*TopologyExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTopology not implemented")
+}
+func (UnimplementedLocalServer) ReplayTopology(context.Context, *ReplayRequest) (*ReplayResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayTopology not implemented")
+}
+func (UnimplementedLocalServer) DiscoverMTU(context.Context, *PathQuery) (*MTUDiscoveryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverMTU not implemented")
+}
+func (UnimplementedLocalServer) VerifyWire(context.Context, *WireVerificationRequest) (*WireVerificationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyWire not implemented")
+}
+func (UnimplementedLocalServer) DryRunTopology(context.Context, *TopologyQuery) (*DryRunReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunTopology not implemented")
+}
+func (UnimplementedLocalServer) CreateTopologyWires(context.Context, *TopologyWireRequest) (*TransactionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopologyWires not implemented")
+}
+func (UnimplementedLocalServer) WaitForPodsAlive(context.Context, *PodList) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaitForPodsAlive not implemented")
+}
+func (UnimplementedLocalServer) ExportTopologyDOT(context.Context, *TopologyQuery) (*DOTGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTopologyDOT not implemented")
+}
+func (UnimplementedLocalServer) PatchWireImpairment(context.Context, *ImpairmentPatch) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchWireImpairment not implemented")
+}
+func (UnimplementedLocalServer) GetWireImpairment(context.Context, *LinkStatsQuery) (*WireImpairment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWireImpairment not implemented")
+}
+func (UnimplementedLocalServer) InstantiateTopology(context.Context, *InstantiationRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstantiateTopology not implemented")
+}
+func (UnimplementedLocalServer) InjectRoutes(context.Context, *RouteInjectionRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectRoutes not implemented")
+}
+func (UnimplementedLocalServer) DetectTopologyDrift(context.Context, *TopologyQuery) (*DriftReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectTopologyDrift not implemented")
+}
+func (UnimplementedLocalServer) CaptureWire(*CaptureRequest, Local_CaptureWireServer) error {
+	return status.Errorf(codes.Unimplemented, "method CaptureWire not implemented")
+}
+func (UnimplementedLocalServer) MeasureConvergenceTime(context.Context, *TopologyQuery) (*ConvergenceMetrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MeasureConvergenceTime not implemented")
+}
+func (UnimplementedLocalServer) RepairWire(context.Context, *WireRepairRequest) (*RepairResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairWire not implemented")
+}
+func (UnimplementedLocalServer) StreamLinkStats(*StatsStreamRequest, Local_StreamLinkStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLinkStats not implemented")
+}
+func (UnimplementedLocalServer) EvacuateNode(context.Context, *EvacuationRequest) (*EvacuationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvacuateNode not implemented")
+}
+func (UnimplementedLocalServer) AddFanoutWire(context.Context, *FanoutWireDef) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFanoutWire not implemented")
+}
+func (UnimplementedLocalServer) ReplayPCAP(context.Context, *PCAPReplayRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayPCAP not implemented")
+}
+func (UnimplementedLocalServer) StreamReplayPCAP(*PCAPReplayRequest, Local_StreamReplayPCAPServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamReplayPCAP not implemented")
+}
+func (UnimplementedLocalServer) GetNamespaceUsage(context.Context, *NamespaceQuery) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNamespaceUsage not implemented")
+}
+func (UnimplementedLocalServer) mustEmbedUnimplementedLocalServer() {}
+
+// UnsafeLocalServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LocalServer will
+// result in compilation errors.
+type UnsafeLocalServer interface {
+	mustEmbedUnimplementedLocalServer()
+}
+
+func RegisterLocalServer(s grpc.ServiceRegistrar, srv LocalServer) {
+	s.RegisterService(&Local_ServiceDesc, srv)
+}
+
+func _Local_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).Get(ctx, req.(*PodQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_SetAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Pod)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).SetAlive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/SetAlive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).SetAlive(ctx, req.(*Pod))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_SkipReverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).SkipReverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/SkipReverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).SkipReverse(ctx, req.(*SkipQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_Skip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).Skip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/Skip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).Skip(ctx, req.(*SkipQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_IsSkipped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).IsSkipped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/IsSkipped",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).IsSkipped(ctx, req.(*SkipQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_PatchLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkPatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).PatchLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/PatchLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).PatchLink(ctx, req.(*LinkPatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/HealthCheck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).HealthCheck(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_AuditWire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WireAudit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).AuditWire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/AuditWire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).AuditWire(ctx, req.(*WireAudit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_RollbackTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).RollbackTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/RollbackTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).RollbackTopology(ctx, req.(*RollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GetAggregatedLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GetAggregatedLinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GetAggregatedLinkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GetAggregatedLinkStats(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_StartFlapSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlapSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).StartFlapSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/StartFlapSimulation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).StartFlapSimulation(ctx, req.(*FlapSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_StopFlapSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlapSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).StopFlapSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/StopFlapSimulation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).StopFlapSimulation(ctx, req.(*FlapSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GenerateNetworkPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GenerateNetworkPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GenerateNetworkPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GenerateNetworkPolicies(ctx, req.(*TopologyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GenerateNADs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GenerateNADs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GenerateNADs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GenerateNADs(ctx, req.(*TopologyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_BenchmarkWire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BenchmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).BenchmarkWire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/BenchmarkWire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).BenchmarkWire(ctx, req.(*BenchmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_CanaryActivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).CanaryActivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/CanaryActivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).CanaryActivate(ctx, req.(*CanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_CanaryExpand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).CanaryExpand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/CanaryExpand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).CanaryExpand(ctx, req.(*CanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_CanaryCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).CanaryCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/CanaryCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).CanaryCommit(ctx, req.(*CanaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GetResourceRecommendation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GetResourceRecommendation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GetResourceRecommendation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GetResourceRecommendation(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GetTrafficAccounting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountingQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GetTrafficAccounting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GetTrafficAccounting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GetTrafficAccounting(ctx, req.(*AccountingQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ResetTrafficAccounting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountingQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ResetTrafficAccounting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ResetTrafficAccounting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ResetTrafficAccounting(ctx, req.(*AccountingQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ReleaseIPAM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ReleaseIPAM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ReleaseIPAM",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ReleaseIPAM(ctx, req.(*PodQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ApplyChaosProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaosProfile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ApplyChaosProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ApplyChaosProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ApplyChaosProfile(ctx, req.(*ChaosProfile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_RemoveChaosProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaosProfileRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).RemoveChaosProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/RemoveChaosProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).RemoveChaosProfile(ctx, req.(*ChaosProfileRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GetInterfaceMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GetInterfaceMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GetInterfaceMTU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GetInterfaceMTU(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_SetInterfaceMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MTURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).SetInterfaceMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/SetInterfaceMTU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).SetInterfaceMTU(ctx, req.(*MTURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ExportTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ExportTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ExportTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ExportTopology(ctx, req.(*PodQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ReplayTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ReplayTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ReplayTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ReplayTopology(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_DiscoverMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).DiscoverMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/DiscoverMTU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).DiscoverMTU(ctx, req.(*PathQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_VerifyWire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WireVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).VerifyWire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/VerifyWire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).VerifyWire(ctx, req.(*WireVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_DryRunTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).DryRunTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/DryRunTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).DryRunTopology(ctx, req.(*TopologyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_CreateTopologyWires_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyWireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).CreateTopologyWires(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/CreateTopologyWires",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).CreateTopologyWires(ctx, req.(*TopologyWireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_WaitForPodsAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).WaitForPodsAlive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/WaitForPodsAlive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).WaitForPodsAlive(ctx, req.(*PodList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ExportTopologyDOT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ExportTopologyDOT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ExportTopologyDOT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ExportTopologyDOT(ctx, req.(*TopologyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_PatchWireImpairment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpairmentPatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).PatchWireImpairment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/PatchWireImpairment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).PatchWireImpairment(ctx, req.(*ImpairmentPatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_GetWireImpairment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GetWireImpairment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GetWireImpairment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GetWireImpairment(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_InstantiateTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).InstantiateTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/InstantiateTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).InstantiateTopology(ctx, req.(*InstantiationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_InjectRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteInjectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).InjectRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/InjectRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).InjectRoutes(ctx, req.(*RouteInjectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_DetectTopologyDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).DetectTopologyDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/DetectTopologyDrift",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).DetectTopologyDrift(ctx, req.(*TopologyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_CaptureWire_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CaptureRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LocalServer).CaptureWire(m, &localCaptureWireServer{stream})
+}
+
+type Local_CaptureWireServer interface {
+	Send(*CapturedPacket) error
+	grpc.ServerStream
+}
+
+type localCaptureWireServer struct {
+	grpc.ServerStream
+}
+
+func (x *localCaptureWireServer) Send(m *CapturedPacket) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Local_MeasureConvergenceTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).MeasureConvergenceTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/MeasureConvergenceTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).MeasureConvergenceTime(ctx, req.(*TopologyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_RepairWire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WireRepairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).RepairWire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/RepairWire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).RepairWire(ctx, req.(*WireRepairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_StreamLinkStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LocalServer).StreamLinkStats(m, &localStreamLinkStatsServer{stream})
+}
+
+type Local_StreamLinkStatsServer interface {
+	Send(*StatsBatch) error
+	grpc.ServerStream
+}
+
+type localStreamLinkStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *localStreamLinkStatsServer) Send(m *StatsBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Local_EvacuateNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvacuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).EvacuateNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/EvacuateNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).EvacuateNode(ctx, req.(*EvacuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_AddFanoutWire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FanoutWireDef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).AddFanoutWire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/AddFanoutWire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).AddFanoutWire(ctx, req.(*FanoutWireDef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_ReplayPCAP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PCAPReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).ReplayPCAP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/ReplayPCAP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).ReplayPCAP(ctx, req.(*PCAPReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Local_StreamReplayPCAP_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PCAPReplayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LocalServer).StreamReplayPCAP(m, &localStreamReplayPCAPServer{stream})
+}
+
+type Local_StreamReplayPCAPServer interface {
+	Send(*ReplayProgress) error
+	grpc.ServerStream
+}
+
+type localStreamReplayPCAPServer struct {
+	grpc.ServerStream
+}
+
+func (x *localStreamReplayPCAPServer) Send(m *ReplayProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Local_GetNamespaceUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NamespaceQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).GetNamespaceUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Local/GetNamespaceUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).GetNamespaceUsage(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Local_ServiceDesc is the grpc.ServiceDesc for Local service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Local_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "meshnet.v1beta1.Local",
+	HandlerType: (*LocalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Local_Get_Handler,
+		},
+		{
+			MethodName: "SetAlive",
+			Handler:    _Local_SetAlive_Handler,
+		},
+		{
+			MethodName: "SkipReverse",
+			Handler:    _Local_SkipReverse_Handler,
+		},
+		{
+			MethodName: "Skip",
+			Handler:    _Local_Skip_Handler,
+		},
+		{
+			MethodName: "IsSkipped",
+			Handler:    _Local_IsSkipped_Handler,
+		},
+		{
+			MethodName: "PatchLink",
+			Handler:    _Local_PatchLink_Handler,
+		},
+		{
+			MethodName: "HealthCheck",
+			Handler:    _Local_HealthCheck_Handler,
+		},
+		{
+			MethodName: "AuditWire",
+			Handler:    _Local_AuditWire_Handler,
+		},
+		{
+			MethodName: "RollbackTopology",
+			Handler:    _Local_RollbackTopology_Handler,
+		},
+		{
+			MethodName: "GetAggregatedLinkStats",
+			Handler:    _Local_GetAggregatedLinkStats_Handler,
+		},
+		{
+			MethodName: "StartFlapSimulation",
+			Handler:    _Local_StartFlapSimulation_Handler,
+		},
+		{
+			MethodName: "StopFlapSimulation",
+			Handler:    _Local_StopFlapSimulation_Handler,
+		},
+		{
+			MethodName: "GenerateNetworkPolicies",
+			Handler:    _Local_GenerateNetworkPolicies_Handler,
+		},
+		{
+			MethodName: "GenerateNADs",
+			Handler:    _Local_GenerateNADs_Handler,
+		},
+		{
+			MethodName: "BenchmarkWire",
+			Handler:    _Local_BenchmarkWire_Handler,
+		},
+		{
+			MethodName: "CanaryActivate",
+			Handler:    _Local_CanaryActivate_Handler,
+		},
+		{
+			MethodName: "CanaryExpand",
+			Handler:    _Local_CanaryExpand_Handler,
+		},
+		{
+			MethodName: "CanaryCommit",
+			Handler:    _Local_CanaryCommit_Handler,
+		},
+		{
+			MethodName: "GetResourceRecommendation",
+			Handler:    _Local_GetResourceRecommendation_Handler,
+		},
+		{
+			MethodName: "GetTrafficAccounting",
+			Handler:    _Local_GetTrafficAccounting_Handler,
+		},
+		{
+			MethodName: "ResetTrafficAccounting",
+			Handler:    _Local_ResetTrafficAccounting_Handler,
+		},
+		{
+			MethodName: "ReleaseIPAM",
+			Handler:    _Local_ReleaseIPAM_Handler,
+		},
+		{
+			MethodName: "ApplyChaosProfile",
+			Handler:    _Local_ApplyChaosProfile_Handler,
+		},
+		{
+			MethodName: "RemoveChaosProfile",
+			Handler:    _Local_RemoveChaosProfile_Handler,
+		},
+		{
+			MethodName: "GetInterfaceMTU",
+			Handler:    _Local_GetInterfaceMTU_Handler,
+		},
+		{
+			MethodName: "SetInterfaceMTU",
+			Handler:    _Local_SetInterfaceMTU_Handler,
+		},
+		{
+			MethodName: "ExportTopology",
+			Handler:    _Local_ExportTopology_Handler,
+		},
+		{
+			MethodName: "ReplayTopology",
+			Handler:    _Local_ReplayTopology_Handler,
+		},
+		{
+			MethodName: "DiscoverMTU",
+			Handler:    _Local_DiscoverMTU_Handler,
+		},
+		{
+			MethodName: "VerifyWire",
+			Handler:    _Local_VerifyWire_Handler,
+		},
+		{
+			MethodName: "DryRunTopology",
+			Handler:    _Local_DryRunTopology_Handler,
+		},
+		{
+			MethodName: "CreateTopologyWires",
+			Handler:    _Local_CreateTopologyWires_Handler,
+		},
+		{
+			MethodName: "WaitForPodsAlive",
+			Handler:    _Local_WaitForPodsAlive_Handler,
+		},
+		{
+			MethodName: "ExportTopologyDOT",
+			Handler:    _Local_ExportTopologyDOT_Handler,
+		},
+		{
+			MethodName: "PatchWireImpairment",
+			Handler:    _Local_PatchWireImpairment_Handler,
+		},
+		{
+			MethodName: "GetWireImpairment",
+			Handler:    _Local_GetWireImpairment_Handler,
+		},
+		{
+			MethodName: "InstantiateTopology",
+			Handler:    _Local_InstantiateTopology_Handler,
+		},
+		{
+			MethodName: "InjectRoutes",
+			Handler:    _Local_InjectRoutes_Handler,
+		},
+		{
+			MethodName: "DetectTopologyDrift",
+			Handler:    _Local_DetectTopologyDrift_Handler,
+		},
+		{
+			MethodName: "MeasureConvergenceTime",
+			Handler:    _Local_MeasureConvergenceTime_Handler,
+		},
+		{
+			MethodName: "RepairWire",
+			Handler:    _Local_RepairWire_Handler,
+		},
+		{
+			MethodName: "EvacuateNode",
+			Handler:    _Local_EvacuateNode_Handler,
+		},
+		{
+			MethodName: "AddFanoutWire",
+			Handler:    _Local_AddFanoutWire_Handler,
+		},
+		{
+			MethodName: "ReplayPCAP",
+			Handler:    _Local_ReplayPCAP_Handler,
+		},
+		{
+			MethodName: "GetNamespaceUsage",
+			Handler:    _Local_GetNamespaceUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CaptureWire",
+			Handler:       _Local_CaptureWire_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLinkStats",
+			Handler:       _Local_StreamLinkStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamReplayPCAP",
+			Handler:       _Local_StreamReplayPCAP_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "daemon/proto/meshnet/v1beta1/meshnet.proto",
+}
+
+// RemoteClient is the client API for Remote service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RemoteClient interface {
+	Update(ctx context.Context, in *RemotePod, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetLinkStats(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*LinkStats, error)
+	UpdateVXLANNeighbors(ctx context.Context, in *VXLANNeighborUpdate, opts ...grpc.CallOption) (*BoolResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	NotifyShutdown(ctx context.Context, in *ShutdownNotice, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetLinkMTU(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*LinkMTU, error)
+	SetLinkMTU(ctx context.Context, in *MTURequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	ProbeLinkMTU(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*LinkMTU, error)
+	GetWireParams(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*WireParams, error)
+	RemoveWireEnd(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*BoolResponse, error)
+	PatchLinkImpairment(ctx context.Context, in *ImpairmentPatch, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetLinkImpairment(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*WireImpairment, error)
+	InjectPodRoutes(ctx context.Context, in *RouteInjectionRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	CaptureLink(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (Remote_CaptureLinkClient, error)
+	RepairLink(ctx context.Context, in *WireRepairRequest, opts ...grpc.CallOption) (*RepairResult, error)
+	StreamPodLinkStats(ctx context.Context, in *StatsStreamRequest, opts ...grpc.CallOption) (Remote_StreamPodLinkStatsClient, error)
+	ReplayLink(ctx context.Context, in *PCAPReplayRequest, opts ...grpc.CallOption) (Remote_ReplayLinkClient, error)
+}
+
+type remoteClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemoteClient(cc grpc.ClientConnInterface) RemoteClient {
+	return &remoteClient{cc}
+}
+
+func (c *remoteClient) Update(ctx context.Context, in *RemotePod, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) GetLinkStats(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*LinkStats, error) {
+	out := new(LinkStats)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/GetLinkStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) UpdateVXLANNeighbors(ctx context.Context, in *VXLANNeighborUpdate, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/UpdateVXLANNeighbors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) NotifyShutdown(ctx context.Context, in *ShutdownNotice, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/NotifyShutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) GetLinkMTU(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*LinkMTU, error) {
+	out := new(LinkMTU)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/GetLinkMTU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) SetLinkMTU(ctx context.Context, in *MTURequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/SetLinkMTU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) ProbeLinkMTU(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*LinkMTU, error) {
+	out := new(LinkMTU)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/ProbeLinkMTU", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) GetWireParams(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*WireParams, error) {
+	out := new(WireParams)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/GetWireParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) RemoveWireEnd(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/RemoveWireEnd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) PatchLinkImpairment(ctx context.Context, in *ImpairmentPatch, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/PatchLinkImpairment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) GetLinkImpairment(ctx context.Context, in *LinkStatsQuery, opts ...grpc.CallOption) (*WireImpairment, error) {
+	out := new(WireImpairment)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/GetLinkImpairment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) InjectPodRoutes(ctx context.Context, in *RouteInjectionRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/InjectPodRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) CaptureLink(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (Remote_CaptureLinkClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Remote_ServiceDesc.Streams[0], "/meshnet.v1beta1.Remote/CaptureLink", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteCaptureLinkClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Remote_CaptureLinkClient interface {
+	Recv() (*CapturedPacket, error)
+	grpc.ClientStream
+}
+
+type remoteCaptureLinkClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteCaptureLinkClient) Recv() (*CapturedPacket, error) {
+	m := new(CapturedPacket)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteClient) RepairLink(ctx context.Context, in *WireRepairRequest, opts ...grpc.CallOption) (*RepairResult, error) {
+	out := new(RepairResult)
+	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/RepairLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) StreamPodLinkStats(ctx context.Context, in *StatsStreamRequest, opts ...grpc.CallOption) (Remote_StreamPodLinkStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Remote_ServiceDesc.Streams[1], "/meshnet.v1beta1.Remote/StreamPodLinkStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteStreamPodLinkStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Remote_StreamPodLinkStatsClient interface {
+	Recv() (*StatsBatch, error)
+	grpc.ClientStream
+}
+
+type remoteStreamPodLinkStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteStreamPodLinkStatsClient) Recv() (*StatsBatch, error) {
+	m := new(StatsBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteClient) ReplayLink(ctx context.Context, in *PCAPReplayRequest, opts ...grpc.CallOption) (Remote_ReplayLinkClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Remote_ServiceDesc.Streams[2], "/meshnet.v1beta1.Remote/ReplayLink", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteReplayLinkClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Remote_ReplayLinkClient interface {
+	Recv() (*ReplayProgress, error)
+	grpc.ClientStream
+}
+
+type remoteReplayLinkClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteReplayLinkClient) Recv() (*ReplayProgress, error) {
+	m := new(ReplayProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RemoteServer is the server API for Remote service.
+// All implementations must embed UnimplementedRemoteServer
+// for forward compatibility
+type RemoteServer interface {
+	Update(context.Context, *RemotePod) (*BoolResponse, error)
+	GetLinkStats(context.Context, *LinkStatsQuery) (*LinkStats, error)
+	UpdateVXLANNeighbors(context.Context, *VXLANNeighborUpdate) (*BoolResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	NotifyShutdown(context.Context, *ShutdownNotice) (*BoolResponse, error)
+	GetLinkMTU(context.Context, *LinkStatsQuery) (*LinkMTU, error)
+	SetLinkMTU(context.Context, *MTURequest) (*BoolResponse, error)
+	ProbeLinkMTU(context.Context, *LinkStatsQuery) (*LinkMTU, error)
+	GetWireParams(context.Context, *LinkStatsQuery) (*WireParams, error)
+	RemoveWireEnd(context.Context, *LinkStatsQuery) (*BoolResponse, error)
+	PatchLinkImpairment(context.Context, *ImpairmentPatch) (*BoolResponse, error)
+	GetLinkImpairment(context.Context, *LinkStatsQuery) (*WireImpairment, error)
+	InjectPodRoutes(context.Context, *RouteInjectionRequest) (*BoolResponse, error)
+	CaptureLink(*CaptureRequest, Remote_CaptureLinkServer) error
+	RepairLink(context.Context, *WireRepairRequest) (*RepairResult, error)
+	StreamPodLinkStats(*StatsStreamRequest, Remote_StreamPodLinkStatsServer) error
+	ReplayLink(*PCAPReplayRequest, Remote_ReplayLinkServer) error
+	mustEmbedUnimplementedRemoteServer()
 }
 
-// UnimplementedLocalServer must be embedded to have forward compatible implementations.
-type UnimplementedLocalServer struct {
+// UnimplementedRemoteServer must be embedded to have forward compatible implementations.
+type UnimplementedRemoteServer struct {
 }
 
-func (UnimplementedLocalServer) Get(context.Context, *PodQuery) (*Pod, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+func (UnimplementedRemoteServer) Update(context.Context, *RemotePod) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
-func (UnimplementedLocalServer) SetAlive(context.Context, *Pod) (*BoolResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetAlive not implemented")
+func (UnimplementedRemoteServer) GetLinkStats(context.Context, *LinkStatsQuery) (*LinkStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkStats not implemented")
 }
-func (UnimplementedLocalServer) SkipReverse(context.Context, *SkipQuery) (*BoolResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SkipReverse not implemented")
+func (UnimplementedRemoteServer) UpdateVXLANNeighbors(context.Context, *VXLANNeighborUpdate) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVXLANNeighbors not implemented")
+}
+func (UnimplementedRemoteServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedRemoteServer) NotifyShutdown(context.Context, *ShutdownNotice) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyShutdown not implemented")
+}
+func (UnimplementedRemoteServer) GetLinkMTU(context.Context, *LinkStatsQuery) (*LinkMTU, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkMTU not implemented")
+}
+func (UnimplementedRemoteServer) SetLinkMTU(context.Context, *MTURequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLinkMTU not implemented")
+}
+func (UnimplementedRemoteServer) ProbeLinkMTU(context.Context, *LinkStatsQuery) (*LinkMTU, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeLinkMTU not implemented")
+}
+func (UnimplementedRemoteServer) GetWireParams(context.Context, *LinkStatsQuery) (*WireParams, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWireParams not implemented")
+}
+func (UnimplementedRemoteServer) RemoveWireEnd(context.Context, *LinkStatsQuery) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWireEnd not implemented")
+}
+func (UnimplementedRemoteServer) PatchLinkImpairment(context.Context, *ImpairmentPatch) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchLinkImpairment not implemented")
+}
+func (UnimplementedRemoteServer) GetLinkImpairment(context.Context, *LinkStatsQuery) (*WireImpairment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkImpairment not implemented")
+}
+func (UnimplementedRemoteServer) InjectPodRoutes(context.Context, *RouteInjectionRequest) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectPodRoutes not implemented")
+}
+func (UnimplementedRemoteServer) CaptureLink(*CaptureRequest, Remote_CaptureLinkServer) error {
+	return status.Errorf(codes.Unimplemented, "method CaptureLink not implemented")
+}
+func (UnimplementedRemoteServer) RepairLink(context.Context, *WireRepairRequest) (*RepairResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairLink not implemented")
 }
-func (UnimplementedLocalServer) Skip(context.Context, *SkipQuery) (*BoolResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Skip not implemented")
+func (UnimplementedRemoteServer) StreamPodLinkStats(*StatsStreamRequest, Remote_StreamPodLinkStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPodLinkStats not implemented")
 }
-func (UnimplementedLocalServer) IsSkipped(context.Context, *SkipQuery) (*BoolResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsSkipped not implemented")
+func (UnimplementedRemoteServer) ReplayLink(*PCAPReplayRequest, Remote_ReplayLinkServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReplayLink not implemented")
 }
-func (UnimplementedLocalServer) mustEmbedUnimplementedLocalServer() {}
+func (UnimplementedRemoteServer) mustEmbedUnimplementedRemoteServer() {}
 
-// UnsafeLocalServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to LocalServer will
+// UnsafeRemoteServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemoteServer will
 // result in compilation errors.
-type UnsafeLocalServer interface {
-	mustEmbedUnimplementedLocalServer()
+type UnsafeRemoteServer interface {
+	mustEmbedUnimplementedRemoteServer()
 }
 
-func RegisterLocalServer(s grpc.ServiceRegistrar, srv LocalServer) {
-	s.RegisterService(&Local_ServiceDesc, srv)
+func RegisterRemoteServer(s grpc.ServiceRegistrar, srv RemoteServer) {
+	s.RegisterService(&Remote_ServiceDesc, srv)
 }
 
-func _Local_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PodQuery)
+func _Remote_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemotePod)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LocalServer).Get(ctx, in)
+		return srv.(RemoteServer).Update(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/meshnet.v1beta1.Local/Get",
+		FullMethod: "/meshnet.v1beta1.Remote/Update",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocalServer).Get(ctx, req.(*PodQuery))
+		return srv.(RemoteServer).Update(ctx, req.(*RemotePod))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Local_SetAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Pod)
+func _Remote_GetLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LocalServer).SetAlive(ctx, in)
+		return srv.(RemoteServer).GetLinkStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/meshnet.v1beta1.Local/SetAlive",
+		FullMethod: "/meshnet.v1beta1.Remote/GetLinkStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocalServer).SetAlive(ctx, req.(*Pod))
+		return srv.(RemoteServer).GetLinkStats(ctx, req.(*LinkStatsQuery))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Local_SkipReverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SkipQuery)
+func _Remote_UpdateVXLANNeighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VXLANNeighborUpdate)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LocalServer).SkipReverse(ctx, in)
+		return srv.(RemoteServer).UpdateVXLANNeighbors(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/meshnet.v1beta1.Local/SkipReverse",
+		FullMethod: "/meshnet.v1beta1.Remote/UpdateVXLANNeighbors",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocalServer).SkipReverse(ctx, req.(*SkipQuery))
+		return srv.(RemoteServer).UpdateVXLANNeighbors(ctx, req.(*VXLANNeighborUpdate))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Local_Skip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SkipQuery)
+func _Remote_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LocalServer).Skip(ctx, in)
+		return srv.(RemoteServer).Heartbeat(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/meshnet.v1beta1.Local/Skip",
+		FullMethod: "/meshnet.v1beta1.Remote/Heartbeat",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocalServer).Skip(ctx, req.(*SkipQuery))
+		return srv.(RemoteServer).Heartbeat(ctx, req.(*HeartbeatRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Local_IsSkipped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SkipQuery)
+func _Remote_NotifyShutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownNotice)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LocalServer).IsSkipped(ctx, in)
+		return srv.(RemoteServer).NotifyShutdown(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/meshnet.v1beta1.Local/IsSkipped",
+		FullMethod: "/meshnet.v1beta1.Remote/NotifyShutdown",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocalServer).IsSkipped(ctx, req.(*SkipQuery))
+		return srv.(RemoteServer).NotifyShutdown(ctx, req.(*ShutdownNotice))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Local_ServiceDesc is the grpc.ServiceDesc for Local service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Local_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "meshnet.v1beta1.Local",
-	HandlerType: (*LocalServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Get",
-			Handler:    _Local_Get_Handler,
-		},
-		{
-			MethodName: "SetAlive",
-			Handler:    _Local_SetAlive_Handler,
-		},
-		{
-			MethodName: "SkipReverse",
-			Handler:    _Local_SkipReverse_Handler,
-		},
-		{
-			MethodName: "Skip",
-			Handler:    _Local_Skip_Handler,
-		},
-		{
-			MethodName: "IsSkipped",
-			Handler:    _Local_IsSkipped_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "daemon/proto/meshnet/v1beta1/meshnet.proto",
+func _Remote_GetLinkMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).GetLinkMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/GetLinkMTU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).GetLinkMTU(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// RemoteClient is the client API for Remote service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type RemoteClient interface {
-	Update(ctx context.Context, in *RemotePod, opts ...grpc.CallOption) (*BoolResponse, error)
+func _Remote_SetLinkMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MTURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).SetLinkMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/SetLinkMTU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).SetLinkMTU(ctx, req.(*MTURequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type remoteClient struct {
-	cc grpc.ClientConnInterface
+func _Remote_ProbeLinkMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).ProbeLinkMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/ProbeLinkMTU",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).ProbeLinkMTU(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewRemoteClient(cc grpc.ClientConnInterface) RemoteClient {
-	return &remoteClient{cc}
+func _Remote_GetWireParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).GetWireParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/GetWireParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).GetWireParams(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *remoteClient) Update(ctx context.Context, in *RemotePod, opts ...grpc.CallOption) (*BoolResponse, error) {
-	out := new(BoolResponse)
-	err := c.cc.Invoke(ctx, "/meshnet.v1beta1.Remote/Update", in, out, opts...)
-	if err != nil {
+func _Remote_RemoveWireEnd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RemoteServer).RemoveWireEnd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/RemoveWireEnd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).RemoveWireEnd(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// RemoteServer is the server API for Remote service.
-// All implementations must embed UnimplementedRemoteServer
-// for forward compatibility
-type RemoteServer interface {
-	Update(context.Context, *RemotePod) (*BoolResponse, error)
-	mustEmbedUnimplementedRemoteServer()
+func _Remote_PatchLinkImpairment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpairmentPatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).PatchLinkImpairment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/PatchLinkImpairment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).PatchLinkImpairment(ctx, req.(*ImpairmentPatch))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedRemoteServer must be embedded to have forward compatible implementations.
-type UnimplementedRemoteServer struct {
+func _Remote_GetLinkImpairment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).GetLinkImpairment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/GetLinkImpairment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).GetLinkImpairment(ctx, req.(*LinkStatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedRemoteServer) Update(context.Context, *RemotePod) (*BoolResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+func _Remote_InjectPodRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteInjectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).InjectPodRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshnet.v1beta1.Remote/InjectPodRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).InjectPodRoutes(ctx, req.(*RouteInjectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRemoteServer) mustEmbedUnimplementedRemoteServer() {}
 
-// UnsafeRemoteServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to RemoteServer will
-// result in compilation errors.
-type UnsafeRemoteServer interface {
-	mustEmbedUnimplementedRemoteServer()
+func _Remote_CaptureLink_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CaptureRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteServer).CaptureLink(m, &remoteCaptureLinkServer{stream})
 }
 
-func RegisterRemoteServer(s grpc.ServiceRegistrar, srv RemoteServer) {
-	s.RegisterService(&Remote_ServiceDesc, srv)
+type Remote_CaptureLinkServer interface {
+	Send(*CapturedPacket) error
+	grpc.ServerStream
 }
 
-func _Remote_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemotePod)
+type remoteCaptureLinkServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteCaptureLinkServer) Send(m *CapturedPacket) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Remote_RepairLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WireRepairRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RemoteServer).Update(ctx, in)
+		return srv.(RemoteServer).RepairLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/meshnet.v1beta1.Remote/Update",
+		FullMethod: "/meshnet.v1beta1.Remote/RepairLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RemoteServer).Update(ctx, req.(*RemotePod))
+		return srv.(RemoteServer).RepairLink(ctx, req.(*WireRepairRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Remote_StreamPodLinkStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteServer).StreamPodLinkStats(m, &remoteStreamPodLinkStatsServer{stream})
+}
+
+type Remote_StreamPodLinkStatsServer interface {
+	Send(*StatsBatch) error
+	grpc.ServerStream
+}
+
+type remoteStreamPodLinkStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteStreamPodLinkStatsServer) Send(m *StatsBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Remote_ReplayLink_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PCAPReplayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteServer).ReplayLink(m, &remoteReplayLinkServer{stream})
+}
+
+type Remote_ReplayLinkServer interface {
+	Send(*ReplayProgress) error
+	grpc.ServerStream
+}
+
+type remoteReplayLinkServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteReplayLinkServer) Send(m *ReplayProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Remote_ServiceDesc is the grpc.ServiceDesc for Remote service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -329,7 +2555,75 @@ var Remote_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _Remote_Update_Handler,
 		},
+		{
+			MethodName: "GetLinkStats",
+			Handler:    _Remote_GetLinkStats_Handler,
+		},
+		{
+			MethodName: "UpdateVXLANNeighbors",
+			Handler:    _Remote_UpdateVXLANNeighbors_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Remote_Heartbeat_Handler,
+		},
+		{
+			MethodName: "NotifyShutdown",
+			Handler:    _Remote_NotifyShutdown_Handler,
+		},
+		{
+			MethodName: "GetLinkMTU",
+			Handler:    _Remote_GetLinkMTU_Handler,
+		},
+		{
+			MethodName: "SetLinkMTU",
+			Handler:    _Remote_SetLinkMTU_Handler,
+		},
+		{
+			MethodName: "ProbeLinkMTU",
+			Handler:    _Remote_ProbeLinkMTU_Handler,
+		},
+		{
+			MethodName: "GetWireParams",
+			Handler:    _Remote_GetWireParams_Handler,
+		},
+		{
+			MethodName: "RemoveWireEnd",
+			Handler:    _Remote_RemoveWireEnd_Handler,
+		},
+		{
+			MethodName: "PatchLinkImpairment",
+			Handler:    _Remote_PatchLinkImpairment_Handler,
+		},
+		{
+			MethodName: "GetLinkImpairment",
+			Handler:    _Remote_GetLinkImpairment_Handler,
+		},
+		{
+			MethodName: "InjectPodRoutes",
+			Handler:    _Remote_InjectPodRoutes_Handler,
+		},
+		{
+			MethodName: "RepairLink",
+			Handler:    _Remote_RepairLink_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CaptureLink",
+			Handler:       _Remote_CaptureLink_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPodLinkStats",
+			Handler:       _Remote_StreamPodLinkStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReplayLink",
+			Handler:       _Remote_ReplayLink_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon/proto/meshnet/v1beta1/meshnet.proto",
 }
@@ -0,0 +1,161 @@
+// Package routes computes and installs the routes of a pod to the subnets of the links of its
+// topology that it isn't connected to. They follow the shortest paths of the topology graph,
+// whose edges are the links with IPs, weighted by their UID.
+package routes
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// Route sends the traffic to Dst out of the interface Intf of a pod, to the peer end Gw of
+// its link
+type Route struct {
+	Dst  *net.IPNet
+	Intf string
+	Gw   net.IP
+}
+
+// edge is a link of a pod with IPs on both ends, to a peer with a topology
+type edge struct {
+	link *mpb.Link
+	peer string
+}
+
+// Compute returns the routes of the pod name, one of pods, to the subnets of the links of
+// pods that name isn't connected to. Each route goes through the first link of the shortest
+// path to the nearest pod with a link in the subnet. Subnets that can't be reached have no
+// route. The routes are sorted by destination.
+func Compute(name string, pods []*mpb.Pod) ([]Route, error) {
+	byName := make(map[string]*mpb.Pod, len(pods))
+	for _, p := range pods {
+		byName[p.Name] = p
+	}
+	if byName[name] == nil {
+		return nil, fmt.Errorf("pod %s has no topology", name)
+	}
+
+	// the pods with a link in each subnet
+	subnets := make(map[string][]string)
+	dsts := make(map[string]*net.IPNet)
+	edges := make(map[string][]edge)
+	for _, p := range pods {
+		for _, l := range p.Links {
+			if l.LocalIp == "" {
+				continue
+			}
+			_, dst, err := net.ParseCIDR(l.LocalIp)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP %s of link %d of pod %s: %s", l.LocalIp, l.Uid, p.Name, err)
+			}
+			subnets[dst.String()] = append(subnets[dst.String()], p.Name)
+			dsts[dst.String()] = dst
+			if l.PeerIp != "" && byName[l.PeerPod] != nil {
+				edges[p.Name] = append(edges[p.Name], edge{link: l, peer: l.PeerPod})
+			}
+		}
+	}
+
+	dist, first := shortestPaths(name, byName, edges)
+	var result []Route
+	for key, members := range subnets {
+		var nearest string
+		for _, member := range members {
+			if member == name {
+				// directly connected
+				nearest = ""
+				break
+			}
+			if _, ok := dist[member]; !ok {
+				continue
+			}
+			if nearest == "" || dist[member] < dist[nearest] || dist[member] == dist[nearest] && member < nearest {
+				nearest = member
+			}
+		}
+		if nearest == "" {
+			continue
+		}
+		hop := first[nearest]
+		gw, _, err := net.ParseCIDR(hop.PeerIp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer IP %s of link %d of pod %s: %s", hop.PeerIp, hop.Uid, name, err)
+		}
+		result = append(result, Route{Dst: dsts[key], Intf: hop.LocalIntf, Gw: gw})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Dst.String() < result[j].Dst.String() })
+	return result, nil
+}
+
+// shortestPaths runs Dijkstra's algorithm from src over edges weighted by their link UID. It
+// returns the distance of each reachable pod and the link of src its shortest path starts
+// with. Ties are broken by pod name, so that the paths don't depend on the order of pods.
+func shortestPaths(src string, pods map[string]*mpb.Pod, edges map[string][]edge) (map[string]int64, map[string]*mpb.Link) {
+	dist := map[string]int64{src: 0}
+	first := make(map[string]*mpb.Link)
+	done := make(map[string]bool)
+	for {
+		current := ""
+		for name, d := range dist {
+			if done[name] {
+				continue
+			}
+			if current == "" || d < dist[current] || d == dist[current] && name < current {
+				current = name
+			}
+		}
+		if current == "" {
+			return dist, first
+		}
+		done[current] = true
+		for _, e := range edges[current] {
+			if pods[e.peer] == nil || done[e.peer] {
+				continue
+			}
+			d := dist[current] + e.link.Uid
+			if old, ok := dist[e.peer]; ok && old <= d {
+				continue
+			}
+			dist[e.peer] = d
+			if current == src {
+				first[e.peer] = e.link
+			} else {
+				first[e.peer] = first[current]
+			}
+		}
+	}
+}
+
+// Apply replaces the routes in the nsName network namespace. Routes whose interface doesn't
+// exist yet are skipped. It returns the number of routes installed.
+func Apply(nsName string, routes []Route) (int, error) {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	installed := 0
+	err = netNs.Do(func(_ ns.NetNS) error {
+		for _, r := range routes {
+			link, err := netlink.LinkByName(r.Intf)
+			if err != nil {
+				log.Debugf("Skipping the route to %s, interface %s isn't there yet", r.Dst, r.Intf)
+				continue
+			}
+			if err := netlink.RouteReplace(&netlink.Route{LinkIndex: link.Attrs().Index, Dst: r.Dst, Gw: r.Gw}); err != nil {
+				return fmt.Errorf("failed to replace the route to %s via %s: %s", r.Dst, r.Gw, err)
+			}
+			installed++
+		}
+		return nil
+	})
+	return installed, err
+}
@@ -0,0 +1,174 @@
+package routes
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func link(uid int64, peer, intf, localIP, peerIP string) *mpb.Link {
+	return &mpb.Link{Uid: uid, PeerPod: peer, LocalIntf: intf, LocalIp: localIP, PeerIp: peerIP}
+}
+
+func TestCompute(t *testing.T) {
+	// r1 reaches r4 through r2 (1+2) rather than r3 (5+1)
+	pods := []*mpb.Pod{
+		{Name: "r1", Links: []*mpb.Link{
+			link(1, "r2", "eth1", "10.0.1.1/30", "10.0.1.2/30"),
+			link(5, "r3", "eth2", "10.0.5.1/30", "10.0.5.2/30"),
+		}},
+		{Name: "r2", Links: []*mpb.Link{
+			link(1, "r1", "eth1", "10.0.1.2/30", "10.0.1.1/30"),
+			link(2, "r4", "eth2", "10.0.2.1/30", "10.0.2.2/30"),
+		}},
+		{Name: "r3", Links: []*mpb.Link{
+			link(5, "r1", "eth1", "10.0.5.2/30", "10.0.5.1/30"),
+			link(6, "r4", "eth2", "10.0.6.1/30", "10.0.6.2/30"),
+		}},
+		{Name: "r4", Links: []*mpb.Link{
+			link(2, "r2", "eth1", "10.0.2.2/30", "10.0.2.1/30"),
+			link(6, "r3", "eth2", "10.0.6.2/30", "10.0.6.1/30"),
+			// no IP on the other end
+			link(7, "r5", "eth3", "10.0.7.1/30", ""),
+		}},
+		// unreachable
+		{Name: "r6", Links: []*mpb.Link{link(8, "r7", "eth1", "10.0.8.1/30", "10.0.8.2/30")}},
+	}
+
+	tests := []struct {
+		name string
+		pod  string
+		want map[string][2]string
+	}{
+		{
+			name: "first hop by shortest path",
+			pod:  "r1",
+			want: map[string][2]string{
+				"10.0.2.0/30": {"eth1", "10.0.1.2"},
+				// r4 (3) is nearer than r3 (5)
+				"10.0.6.0/30": {"eth1", "10.0.1.2"},
+				"10.0.7.0/30": {"eth1", "10.0.1.2"},
+			},
+		},
+		{
+			name: "ties broken by pod name",
+			pod:  "r3",
+			want: map[string][2]string{
+				"10.0.1.0/30": {"eth1", "10.0.5.1"},
+				// r2 and r4 are both 6 away
+				"10.0.2.0/30": {"eth1", "10.0.5.1"},
+				"10.0.7.0/30": {"eth2", "10.0.6.2"},
+			},
+		},
+		{
+			name: "no reachable subnets",
+			pod:  "r6",
+			want: map[string][2]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := Compute(tt.pod, pods)
+			if err != nil {
+				t.Fatalf("Compute() failed: %v", err)
+			}
+			got := make(map[string][2]string)
+			for _, r := range rs {
+				got[r.Dst.String()] = [2]string{r.Intf, r.Gw.String()}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Compute("r9", pods); err == nil {
+		t.Errorf("Compute() of an unknown pod succeeded")
+	}
+	bad := []*mpb.Pod{{Name: "r1", Links: []*mpb.Link{link(1, "r2", "eth1", "10.0.1.1", "")}}}
+	if _, err := Compute("r1", bad); err == nil {
+		t.Errorf("Compute() with an IP without prefix length succeeded")
+	}
+}
+
+func TestApply(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("can't create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+	err = netNs.Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth2"}); err != nil {
+			return err
+		}
+		link, err := netlink.LinkByName("eth1")
+		if err != nil {
+			return err
+		}
+		addr, _ := netlink.ParseAddr("10.0.1.1/30")
+		if err := netlink.AddrAdd(link, addr); err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(link)
+	})
+	if err != nil {
+		t.Skipf("can't create a veth pair: %v", err)
+	}
+
+	cidr := func(s string) *net.IPNet {
+		_, n, _ := net.ParseCIDR(s)
+		return n
+	}
+	rs := []Route{
+		{Dst: cidr("10.0.2.0/30"), Intf: "eth1", Gw: net.ParseIP("10.0.1.2")},
+		{Dst: cidr("10.0.6.0/30"), Intf: "eth1", Gw: net.ParseIP("10.0.1.2")},
+		// the wire isn't up yet
+		{Dst: cidr("10.0.5.0/30"), Intf: "eth3", Gw: net.ParseIP("10.0.5.2")},
+	}
+	// applying the routes again replaces them
+	for i := 0; i < 2; i++ {
+		if n, err := Apply(netNs.Path(), rs); err != nil || n != 2 {
+			t.Fatalf("Apply() = %d, %v, want 2 routes installed", n, err)
+		}
+	}
+
+	got := make(map[string]string)
+	err = netNs.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName("eth1")
+		if err != nil {
+			return err
+		}
+		routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+		if err != nil {
+			return err
+		}
+		for _, r := range routes {
+			if r.Gw == nil {
+				continue
+			}
+			if r.LinkIndex != link.Attrs().Index {
+				t.Errorf("the route to %s isn't through eth1", r.Dst)
+			}
+			got[r.Dst.String()] = r.Gw.String()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"10.0.2.0/30": "10.0.1.2", "10.0.6.0/30": "10.0.1.2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("the routes of the netns are %v, want %v", got, want)
+	}
+
+	if _, err := Apply(netNs.Path(), []Route{{Dst: cidr("10.0.9.0/30"), Intf: "eth1", Gw: net.ParseIP("10.0.9.2")}}); err == nil {
+		t.Errorf("Apply() of a route through an unreachable gateway succeeded")
+	}
+}
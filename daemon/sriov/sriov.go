@@ -0,0 +1,131 @@
+// Package sriov connects pods to SR-IOV virtual functions, moving the VF netdev into the
+// pod's network namespace instead of creating a macvlan on the physical interface.
+package sriov
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"regexp"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+)
+
+var pciAddr = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+
+// sysfs directory of the PCI devices, changed by tests
+var pciDevices = "/sys/bus/pci/devices"
+
+// ValidatePCIAddr checks that addr is a full PCI address, e.g. 0000:3b:02.1
+func ValidatePCIAddr(addr string) error {
+	if !pciAddr.MatchString(addr) {
+		return fmt.Errorf("invalid VF PCI address %q, expected e.g. 0000:3b:02.1", addr)
+	}
+	return nil
+}
+
+// netdevOf returns the name of the network interface of the PCI device addr
+func netdevOf(addr string) (string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(pciDevices, addr, "net"))
+	if err != nil {
+		return "", fmt.Errorf("failed to find the interface of VF %s, is it bound to a kernel driver? %s", addr, err)
+	}
+	if len(files) != 1 {
+		return "", fmt.Errorf("VF %s has %d interfaces, expected 1", addr, len(files))
+	}
+	return files[0].Name(), nil
+}
+
+// Attach moves the VF at the PCI address addr into the nsName network namespace, where it's
+// renamed to intfName, given ip, if any, and brought up. Its original name is kept as its
+// alias, so that Detach can restore it.
+func Attach(nsName, intfName, ip, addr string) error {
+	if err := ValidatePCIAddr(addr); err != nil {
+		return err
+	}
+	var ipNet *net.IPNet
+	if ip != "" {
+		ipAddr, subnet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return fmt.Errorf("failed to parse CIDR %s: %s", ip, err)
+		}
+		ipNet = &net.IPNet{IP: ipAddr, Mask: subnet.Mask}
+	}
+	name, err := netdevOf(addr)
+	if err != nil {
+		return err
+	}
+	vf, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to find VF interface %s: %s", name, err)
+	}
+
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	if err := netlink.LinkSetDown(vf); err != nil {
+		return fmt.Errorf("failed to bring VF interface %s down: %s", name, err)
+	}
+	if err := netlink.LinkSetAlias(vf, name); err != nil {
+		return fmt.Errorf("failed to save the name of VF interface %s: %s", name, err)
+	}
+	if err := netlink.LinkSetNsFd(vf, int(netNs.Fd())); err != nil {
+		return fmt.Errorf("failed to move VF interface %s to netns %s: %s", name, nsName, err)
+	}
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		vf, err := netlink.LinkByName(name)
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetName(vf, intfName); err != nil {
+			return fmt.Errorf("failed to rename VF interface %s to %s: %s", name, intfName, err)
+		}
+		if ipNet != nil {
+			if err := netlink.AddrReplace(vf, &netlink.Addr{IPNet: ipNet}); err != nil {
+				return fmt.Errorf("failed to set IP %s on %s: %s", ip, intfName, err)
+			}
+		}
+		return netlink.LinkSetUp(vf)
+	})
+}
+
+// Detach moves the VF interface intfName of the nsName network namespace back to the
+// current namespace, under its original name. The kernel would move it back when the
+// pod's namespace is deleted, but keeping the pod's name for it.
+func Detach(nsName, intfName string) error {
+	hostNs, err := ns.GetCurrentNS()
+	if err != nil {
+		return err
+	}
+	defer hostNs.Close()
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+
+	return netNs.Do(func(_ ns.NetNS) error {
+		vf, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf("failed to find VF interface %s: %s", intfName, err)
+		}
+		if err := netlink.LinkSetDown(vf); err != nil {
+			return err
+		}
+		if name := vf.Attrs().Alias; name != "" {
+			if err := netlink.LinkSetName(vf, name); err != nil {
+				return fmt.Errorf("failed to restore the name %s of VF interface %s: %s", name, intfName, err)
+			}
+		}
+		if err := netlink.LinkSetNsFd(vf, int(hostNs.Fd())); err != nil {
+			return fmt.Errorf("failed to move VF interface %s out of netns %s: %s", intfName, nsName, err)
+		}
+		return nil
+	})
+}
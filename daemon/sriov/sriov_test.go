@@ -0,0 +1,114 @@
+package sriov
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+)
+
+const addr = "0000:3b:02.1"
+
+func TestValidatePCIAddr(t *testing.T) {
+	tests := []struct {
+		addr  string
+		valid bool
+	}{
+		{addr: addr, valid: true},
+		{addr: "3b:02.1", valid: false},
+		{addr: "0000:3b:02.8", valid: false},
+		{addr: "0000:3B:02.1", valid: false},
+		{addr: "../../eth0", valid: false},
+	}
+	for i, tt := range tests {
+		err := ValidatePCIAddr(tt.addr)
+		if (err == nil) != tt.valid {
+			t.Errorf("#%d test failed: %v", i, err)
+		}
+	}
+}
+
+// fakeDevice makes the PCI device addr look like it has the interfaces names
+func fakeDevice(t *testing.T, names ...string) {
+	dir, err := ioutil.TempDir("", "sriov")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for _, name := range names {
+		if err := os.MkdirAll(filepath.Join(dir, addr, "net", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := pciDevices
+	pciDevices = dir
+	t.Cleanup(func() { pciDevices = old })
+}
+
+func TestNetdevOf(t *testing.T) {
+	fakeDevice(t, "ens1f0v1")
+	if got, err := netdevOf(addr); err != nil || got != "ens1f0v1" {
+		t.Errorf("netdevOf() = %q, %v, want ens1f0v1", got, err)
+	}
+	if _, err := netdevOf("0000:00:00.0"); err == nil {
+		t.Error("netdevOf() found an interface for a missing device")
+	}
+}
+
+func TestAttachDetach(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("root is required to create network namespaces")
+	}
+	hostNs, err := testutils.NewNS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testutils.UnmountNS(hostNs)
+	defer hostNs.Close()
+	podNs, err := testutils.NewNS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testutils.UnmountNS(podNs)
+	defer podNs.Close()
+
+	// A veth stands in for the VF, which is only a netdev once bound to its driver
+	fakeDevice(t, "vf1")
+	err = hostNs.Do(func(_ ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "vf1"}, PeerName: "pf1"}); err != nil {
+			return err
+		}
+		if err := Attach(podNs.Path(), "eth1", "10.0.0.1/24", addr); err != nil {
+			t.Fatalf("Attach() failed: %v", err)
+		}
+		if _, err := netlink.LinkByName("vf1"); err == nil {
+			t.Error("VF is still in the host's namespace")
+		}
+		podNs.Do(func(_ ns.NetNS) error {
+			l, err := netlink.LinkByName("eth1")
+			if err != nil {
+				t.Fatalf("VF hasn't been moved to the pod: %v", err)
+			}
+			addrs, _ := netlink.AddrList(l, netlink.FAMILY_V4)
+			if len(addrs) != 1 || addrs[0].IPNet.String() != "10.0.0.1/24" {
+				t.Errorf("VF has addresses %v, want 10.0.0.1/24", addrs)
+			}
+			return nil
+		})
+
+		if err := Detach(podNs.Path(), "eth1"); err != nil {
+			t.Fatalf("Detach() failed: %v", err)
+		}
+		if _, err := netlink.LinkByName("vf1"); err != nil {
+			t.Errorf("VF hasn't been given back to the host: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
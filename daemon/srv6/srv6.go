@@ -0,0 +1,208 @@
+package srv6
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// CreateOrUpdate sets up both directions of an SRv6 link for the pod.
+func CreateOrUpdate(pod *mpb.RemotePod) error {
+	segments, err := ParseSegments(pod.Srv6Segments)
+	if err != nil {
+		return err
+	}
+	if err := Encap(pod, segments); err != nil {
+		return err
+	}
+
+	// Return traffic is only terminated when the local SID is provided
+	if pod.Srv6LocalSid == "" {
+		return nil
+	}
+	sid := net.ParseIP(pod.Srv6LocalSid)
+	if sid == nil {
+		return fmt.Errorf(" MESHNETD: Error parsing SRv6 SID %s", pod.Srv6LocalSid)
+	}
+
+	// The inner packet carries the link IP, so the pod must be reached with the same family
+	family := netlink.FAMILY_V6
+	if ip, _, _ := net.ParseCIDR(pod.IntfIp); ip.To4() != nil {
+		family = netlink.FAMILY_V4
+	}
+	podIP, err := PrimaryIP(pod.NetNs, family)
+	if err != nil {
+		return err
+	}
+	return Decap(sid, podIP)
+}
+
+// ParseSegments converts a list of segments into IPv6 addresses.
+func ParseSegments(segments []string) ([]net.IP, error) {
+	result := make([]net.IP, 0, len(segments))
+	for _, s := range segments {
+		ip := net.ParseIP(s)
+		if ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf(" MESHNETD: SRv6 segment %q is not an IPv6 address", s)
+		}
+		result = append(result, ip)
+	}
+	return result, nil
+}
+
+// Encap sets up the transmit path of an SRv6 link inside the pod's network namespace.
+// The link interface is created as a dummy interface holding the link IP, while the
+// traffic towards the link subnet is encapsulated with the segment list and sent out
+// of the pod's default interface. Requires kernel >= 4.14 with CONFIG_IPV6_SEG6_LWTUNNEL.
+func Encap(pod *mpb.RemotePod, segments []net.IP) error {
+	if len(segments) == 0 {
+		return fmt.Errorf(" MESHNETD: SRv6 link %s requires at least one segment", pod.IntfName)
+	}
+	if pod.IntfIp == "" {
+		return fmt.Errorf(" MESHNETD: SRv6 link %s requires an IP address", pod.IntfName)
+	}
+	ipAddr, ipSubnet, err := net.ParseCIDR(pod.IntfIp)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error parsing CIDR %s: %s", pod.IntfIp, err)
+	}
+
+	podNs, err := ns.GetNS(pod.NetNs)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error opening netns %s: %s", pod.NetNs, err)
+	}
+	defer podNs.Close()
+
+	return podNs.Do(func(_ ns.NetNS) error {
+		link, err := ensureLink(pod.IntfName, &net.IPNet{IP: ipAddr, Mask: ipSubnet.Mask})
+		if err != nil {
+			return err
+		}
+
+		defaultLink, err := defaultLink()
+		if err != nil {
+			return err
+		}
+
+		route := &netlink.Route{
+			LinkIndex: defaultLink.Attrs().Index,
+			Dst:       ipSubnet,
+			Encap: &netlink.SEG6Encap{
+				Mode:     nl.SEG6_IPTUN_MODE_ENCAP,
+				Segments: segments,
+			},
+		}
+		log.Infof("Adding SRv6 route %s for link %s", route, link.Attrs().Name)
+		if err := netlink.RouteReplace(route); err != nil {
+			return fmt.Errorf(" MESHNETD: Error adding SRv6 encap route: %s", err)
+		}
+		return nil
+	})
+}
+
+// Decap installs a local SID on the node that strips the SRv6 header and cross-connects
+// the inner packet to the pod's primary IP, using End.DX4 or End.DX6 based on its family.
+func Decap(sid, podIP net.IP) error {
+	if sid.To4() != nil {
+		return fmt.Errorf(" MESHNETD: SRv6 SID %s is not an IPv6 address", sid)
+	}
+
+	encap := &netlink.SEG6LocalEncap{}
+	if podIP.To4() != nil {
+		encap.Action = nl.SEG6_LOCAL_ACTION_END_DX4
+		encap.InAddr = podIP.To4()
+		encap.Flags[nl.SEG6_LOCAL_NH4] = true
+	} else {
+		encap.Action = nl.SEG6_LOCAL_ACTION_END_DX6
+		encap.In6Addr = podIP
+		encap.Flags[nl.SEG6_LOCAL_NH6] = true
+	}
+	encap.Flags[nl.SEG6_LOCAL_ACTION] = true
+
+	// seg6local routes need an output device, the nexthop lookup decides the actual one
+	podRoutes, err := netlink.RouteGet(podIP)
+	if err != nil || len(podRoutes) < 1 {
+		return fmt.Errorf(" MESHNETD: Error looking up route to pod %s: %v", podIP, err)
+	}
+
+	route := &netlink.Route{
+		LinkIndex: podRoutes[0].LinkIndex,
+		Dst:       &net.IPNet{IP: sid, Mask: net.CIDRMask(128, 128)},
+		Encap:     encap,
+	}
+	log.Infof("Adding SRv6 local SID route %s", route)
+	if err := netlink.RouteReplace(route); err != nil {
+		return fmt.Errorf(" MESHNETD: Error adding SRv6 local SID route: %s", err)
+	}
+	return nil
+}
+
+// PrimaryIP returns the address of the pod's default interface that belongs to the same
+// address family as family, which is either netlink.FAMILY_V4 or netlink.FAMILY_V6.
+func PrimaryIP(nsName string, family int) (net.IP, error) {
+	podNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return nil, fmt.Errorf(" MESHNETD: Error opening netns %s: %s", nsName, err)
+	}
+	defer podNs.Close()
+
+	var result net.IP
+	err = podNs.Do(func(_ ns.NetNS) error {
+		link, err := defaultLink()
+		if err != nil {
+			return err
+		}
+		addrs, err := netlink.AddrList(link, family)
+		if err != nil {
+			return err
+		}
+		for _, addr := range addrs {
+			if addr.IP.IsGlobalUnicast() {
+				result = addr.IP
+				return nil
+			}
+		}
+		return fmt.Errorf(" MESHNETD: No global address found on %s", link.Attrs().Name)
+	})
+	return result, err
+}
+
+// ensureLink makes sure a dummy interface with the given address exists and is up
+func ensureLink(name string, addr *net.IPNet) (netlink.Link, error) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if err := netlink.LinkAdd(&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: name}}); err != nil {
+			return nil, fmt.Errorf(" MESHNETD: Error creating SRv6 link %s: %s", name, err)
+		}
+		if link, err = netlink.LinkByName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := netlink.AddrReplace(link, &netlink.Addr{IPNet: addr}); err != nil {
+		return nil, fmt.Errorf(" MESHNETD: Error adding address %s to %s: %s", addr, name, err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return nil, fmt.Errorf(" MESHNETD: Error bringing up %s: %s", name, err)
+	}
+	return link, nil
+}
+
+// defaultLink returns the interface of the default route in the current netns
+func defaultLink() (netlink.Link, error) {
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		if r.Dst == nil {
+			return netlink.LinkByIndex(r.LinkIndex)
+		}
+	}
+	return nil, fmt.Errorf(" MESHNETD: No default route found")
+}
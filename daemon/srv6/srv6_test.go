@@ -0,0 +1,193 @@
+package srv6
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestParseSegments(t *testing.T) {
+	tests := []struct {
+		segments []string
+		expected []net.IP
+		err      bool
+	}{
+		{
+			segments: []string{"fc00::1", "fc00::2"},
+			expected: []net.IP{net.ParseIP("fc00::1"), net.ParseIP("fc00::2")},
+		},
+		{
+			segments: []string{},
+			expected: []net.IP{},
+		},
+		{
+			segments: []string{"fc00::1", "1.1.1.1"},
+			err:      true,
+		},
+		{
+			segments: []string{"not-an-ip"},
+			err:      true,
+		},
+	}
+	for i, tt := range tests {
+		result, err := ParseSegments(tt.segments)
+		if (err != nil) != tt.err {
+			t.Errorf("#%d test failed: unexpected error %v", i, err)
+			continue
+		}
+		if len(result) != len(tt.expected) {
+			t.Errorf("#%d test failed: expected %v, got %v", i, tt.expected, result)
+			continue
+		}
+		for j := range result {
+			if !result[j].Equal(tt.expected[j]) {
+				t.Errorf("#%d test failed: expected %v, got %v", i, tt.expected, result)
+			}
+		}
+	}
+}
+
+// addVeth adds a veth pair, which unlike a dummy interface doesn't need a kernel module
+func addVeth(name string) (netlink.Link, error) {
+	if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: name}, PeerName: name + "-peer"}); err != nil {
+		return nil, err
+	}
+	return netlink.LinkByName(name)
+}
+
+// podNS returns a netns whose default route goes out of eth0, which has addr, via gw
+func podNS(t *testing.T, addr, gw string) ns.NetNS {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("failed to create a netns: %v", err)
+	}
+	t.Cleanup(func() {
+		netNs.Close()
+		testutils.UnmountNS(netNs)
+	})
+	err = netNs.Do(func(_ ns.NetNS) error {
+		eth0, err := addVeth("eth0")
+		if err != nil {
+			return err
+		}
+		a, err := netlink.ParseAddr(addr)
+		if err != nil {
+			return err
+		}
+		if err := netlink.AddrAdd(eth0, a); err != nil {
+			return err
+		}
+		if err := netlink.LinkSetUp(eth0); err != nil {
+			return err
+		}
+		return netlink.RouteAdd(&netlink.Route{LinkIndex: eth0.Attrs().Index, Gw: net.ParseIP(gw)})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return netNs
+}
+
+func TestEncap(t *testing.T) {
+	netNs := podNS(t, "10.0.0.5/24", "10.0.0.1")
+	// the link interface is kept if it exists
+	if err := netNs.Do(func(_ ns.NetNS) error {
+		_, err := addVeth("eth1")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	segments := []net.IP{net.ParseIP("fc00::1"), net.ParseIP("fc00::2")}
+	pod := &mpb.RemotePod{NetNs: netNs.Path(), IntfName: "eth1", IntfIp: "192.168.0.1/30"}
+	if err := Encap(pod, segments); err != nil {
+		t.Skipf("SRv6 encapsulation isn't supported: %v", err)
+	}
+	// setting up the link again keeps it
+	if err := Encap(pod, segments); err != nil {
+		t.Fatalf("Encap() failed again: %v", err)
+	}
+
+	err := netNs.Do(func(_ ns.NetNS) error {
+		eth1, err := netlink.LinkByName("eth1")
+		if err != nil {
+			return err
+		}
+		if eth1.Attrs().Flags&net.FlagUp == 0 {
+			t.Errorf("eth1 is down")
+		}
+		addrs, err := netlink.AddrList(eth1, netlink.FAMILY_V4)
+		if err != nil || len(addrs) != 1 || addrs[0].IPNet.String() != "192.168.0.1/30" {
+			t.Errorf("addresses of eth1 = %v, %v, want 192.168.0.1/30", addrs, err)
+		}
+		eth0, err := netlink.LinkByName("eth0")
+		if err != nil {
+			return err
+		}
+		_, dst, _ := net.ParseCIDR("192.168.0.0/30")
+		routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Dst: dst}, netlink.RT_FILTER_DST)
+		if err != nil {
+			return err
+		}
+		var encap *netlink.SEG6Encap
+		for _, r := range routes {
+			if e, ok := r.Encap.(*netlink.SEG6Encap); ok && r.LinkIndex == eth0.Attrs().Index {
+				encap = e
+			}
+		}
+		if encap == nil {
+			t.Fatalf("routes to %s = %v, want one encapsulated out of eth0", dst, routes)
+		}
+		if encap.Mode != nl.SEG6_IPTUN_MODE_ENCAP || len(encap.Segments) != len(segments) {
+			t.Fatalf("encap = %s, want the segments %v", encap, segments)
+		}
+		for i, s := range segments {
+			if !encap.Segments[i].Equal(s) {
+				t.Errorf("encap segments = %v, want %v", encap.Segments, segments)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Encap(&mpb.RemotePod{NetNs: netNs.Path(), IntfName: "eth2", IntfIp: "192.168.1.1/30"}, nil); err == nil {
+		t.Errorf("Encap() without segments succeeded")
+	}
+}
+
+func TestDecap(t *testing.T) {
+	node := podNS(t, "10.0.0.1/24", "10.0.0.254")
+	sid := net.ParseIP("fc00::a")
+	err := node.Do(func(_ ns.NetNS) error {
+		if err := Decap(sid, net.ParseIP("10.0.0.5")); err != nil {
+			t.Skipf("SRv6 local SIDs aren't supported: %v", err)
+		}
+		routes, err := netlink.RouteListFiltered(netlink.FAMILY_V6, &netlink.Route{
+			Dst: &net.IPNet{IP: sid, Mask: net.CIDRMask(128, 128)},
+		}, netlink.RT_FILTER_DST)
+		if err != nil {
+			return err
+		}
+		if len(routes) != 1 {
+			t.Fatalf("routes to %s = %v, want a local SID", sid, routes)
+		}
+		encap, ok := routes[0].Encap.(*netlink.SEG6LocalEncap)
+		if !ok || encap.Action != nl.SEG6_LOCAL_ACTION_END_DX4 || !encap.InAddr.Equal(net.ParseIP("10.0.0.5")) {
+			t.Errorf("local SID %s = %v, want End.DX4 to 10.0.0.5", sid, routes[0].Encap)
+		}
+		if err := Decap(net.ParseIP("10.0.0.9"), net.ParseIP("10.0.0.5")); err == nil {
+			t.Errorf("Decap() with an IPv4 SID succeeded")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
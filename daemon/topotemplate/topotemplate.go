@@ -0,0 +1,126 @@
+// Package topotemplate renders the topologies of a TopologyTemplate with the parameters of
+// an instantiation.
+package topotemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const (
+	// annotations of the instantiated topologies
+	TemplateAnnotation   = "meshnet.io/template"
+	ParametersAnnotation = "meshnet.io/template-parameters"
+
+	// longest interface name the kernel accepts
+	maxIntfName = 15
+)
+
+// Render returns the topologies of tmpl, with their names and the peer pods, interface names
+// and IPs of their links executed as Go templates with params. Every parameter used by the
+// templates must be given, rendered topology names must be valid object names, interface
+// names must fit in 15 characters and IPs must be CIDRs, e.g. 10.0.0.1/24. The topologies
+// are annotated with the name of tmpl and params, in the namespace of tmpl.
+func Render(tmpl *topologyv1.TopologyTemplate, params map[string]string) ([]topologyv1.Topology, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	var result []topologyv1.Topology
+	for i, tt := range tmpl.Spec.Topologies {
+		field := fmt.Sprintf("topologies[%d]", i)
+		name, err := execute(field+".name", tt.Name, params)
+		if err != nil {
+			return nil, err
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return nil, fmt.Errorf("%s.name: %q is not a valid name: %s", field, name, strings.Join(errs, ", "))
+		}
+		t := topologyv1.Topology{}
+		t.Name = name
+		t.Namespace = tmpl.Namespace
+		t.Annotations = map[string]string{
+			TemplateAnnotation:   tmpl.Name,
+			ParametersAnnotation: string(encoded),
+		}
+		for j, l := range tt.Links {
+			link, err := renderLink(fmt.Sprintf("%s.links[%d]", field, j), l, params)
+			if err != nil {
+				return nil, err
+			}
+			t.Spec.Links = append(t.Spec.Links, link)
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// SameInstance tells whether the annotations of two topologies make them instances of the
+// same template with the same parameters
+func SameInstance(a, b map[string]string) bool {
+	return a[TemplateAnnotation] == b[TemplateAnnotation] && a[ParametersAnnotation] == b[ParametersAnnotation]
+}
+
+// renderLink renders the peer pod, fanout peers, interface names and IPs of l
+func renderLink(field string, l topologyv1.Link, params map[string]string) (topologyv1.Link, error) {
+	var err error
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{name: "peer_pod", value: &l.PeerPod},
+		{name: "local_intf", value: &l.LocalIntf},
+		{name: "peer_intf", value: &l.PeerIntf},
+		{name: "local_ip", value: &l.LocalIP},
+		{name: "peer_ip", value: &l.PeerIP},
+	} {
+		if *f.value, err = execute(field+"."+f.name, *f.value, params); err != nil {
+			return l, err
+		}
+	}
+	if l.FanoutPeers != nil {
+		peers := make([]string, len(l.FanoutPeers))
+		for i, peer := range l.FanoutPeers {
+			if peers[i], err = execute(fmt.Sprintf("%s.fanout_peers[%d]", field, i), peer, params); err != nil {
+				return l, err
+			}
+		}
+		l.FanoutPeers = peers
+	}
+	for _, intf := range []string{l.LocalIntf, l.PeerIntf} {
+		if len(intf) > maxIntfName {
+			return l, fmt.Errorf("%s: interface name %q is longer than %d characters", field, intf, maxIntfName)
+		}
+	}
+	for _, ip := range []string{l.LocalIP, l.PeerIP} {
+		if ip == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(ip); err != nil {
+			return l, fmt.Errorf("%s: %q is not an IP with a prefix length", field, ip)
+		}
+	}
+	return l, nil
+}
+
+// execute runs text as a template named after its field with params, failing if it uses a
+// parameter that isn't in params
+func execute(field, text string, params map[string]string) (string, error) {
+	t, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, params); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
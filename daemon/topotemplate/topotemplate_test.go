@@ -0,0 +1,65 @@
+package topotemplate
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func rack(links ...topologyv1.Link) *topologyv1.TopologyTemplate {
+	return &topologyv1.TopologyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "rack", Namespace: "lab"},
+		Spec: topologyv1.TopologyTemplateSpec{Topologies: []topologyv1.TemplateTopology{
+			{Name: "{{ .podPrefix }}-spine-1", Links: links},
+		}},
+	}
+}
+
+func TestRender(t *testing.T) {
+	link := topologyv1.Link{
+		UID: 1, PeerPod: "{{ .podPrefix }}-leaf-1", LocalIntf: "eth{{ .port }}", PeerIntf: "eth1",
+		LocalIP: "10.{{ .rack }}.0.1/24", PeerIP: "10.{{ .rack }}.0.2/24", FanoutPeers: []string{"{{ .podPrefix }}-leaf-2"},
+	}
+	topologies, err := Render(rack(link), map[string]string{"podPrefix": "r1", "port": "2", "rack": "5"})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if len(topologies) != 1 {
+		t.Fatalf("Render() returned %d topologies, want 1", len(topologies))
+	}
+	got := topologies[0]
+	if got.Name != "r1-spine-1" || got.Namespace != "lab" {
+		t.Errorf("Render() topology = %s/%s, want lab/r1-spine-1", got.Namespace, got.Name)
+	}
+	want := topologyv1.Link{UID: 1, PeerPod: "r1-leaf-1", LocalIntf: "eth2", PeerIntf: "eth1", LocalIP: "10.5.0.1/24", PeerIP: "10.5.0.2/24", FanoutPeers: []string{"r1-leaf-2"}}
+	if len(got.Spec.Links) != 1 || !reflect.DeepEqual(got.Spec.Links[0], want) {
+		t.Errorf("Render() links = %+v, want %+v", got.Spec.Links, want)
+	}
+	if got.Annotations[TemplateAnnotation] != "rack" || got.Annotations[ParametersAnnotation] != `{"podPrefix":"r1","port":"2","rack":"5"}` {
+		t.Errorf("Render() annotations = %v", got.Annotations)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	params := map[string]string{"podPrefix": "r1", "long": "very-long-name", "ip": "10.0.0.1"}
+	tests := []struct {
+		desc string
+		link topologyv1.Link
+	}{
+		{desc: "missing parameter", link: topologyv1.Link{PeerPod: "{{ .rack }}-leaf", LocalIntf: "eth1", PeerIntf: "eth1"}},
+		{desc: "syntax error", link: topologyv1.Link{PeerPod: "{{ .podPrefix", LocalIntf: "eth1", PeerIntf: "eth1"}},
+		{desc: "long interface name", link: topologyv1.Link{PeerPod: "r2", LocalIntf: "eth-{{ .long }}", PeerIntf: "eth1"}},
+		{desc: "IP without prefix length", link: topologyv1.Link{PeerPod: "r2", LocalIntf: "eth1", PeerIntf: "eth1", LocalIP: "{{ .ip }}"}},
+	}
+	for _, tt := range tests {
+		if _, err := Render(rack(tt.link), params); err == nil {
+			t.Errorf("Render() with a %s has succeeded", tt.desc)
+		}
+	}
+	if _, err := Render(rack(), map[string]string{"podPrefix": "R_1"}); err == nil {
+		t.Error("Render() with an invalid topology name has succeeded")
+	}
+}
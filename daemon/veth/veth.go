@@ -0,0 +1,126 @@
+// Package veth creates the veth pairs of same-node links, either in the host network namespace
+// before moving their ends to the pods, or directly in the pods' namespaces for daemons that
+// can't use the host namespace, e.g. in rootless K8s.
+package veth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	koko "github.com/redhat-nfvpe/koko/api"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// ModeAuto selects ModeCaller when running in a user namespace and ModeRoot otherwise
+	ModeAuto = "auto"
+	// ModeRoot creates the pair in the host network namespace, then moves its ends
+	ModeRoot = "root"
+	// ModeCaller creates each end of the pair directly in its namespace
+	ModeCaller = "caller"
+)
+
+// uid map of the process, changed by tests
+var uidMap = "/proc/self/uid_map"
+
+// ValidateMode checks that mode is one of the veth creation modes
+func ValidateMode(mode string) error {
+	switch mode {
+	case ModeAuto, ModeRoot, ModeCaller:
+		return nil
+	}
+	return fmt.Errorf("unknown netns mode %q, expected %s, %s or %s", mode, ModeAuto, ModeRoot, ModeCaller)
+}
+
+// inUserNS returns true if the process runs in a user namespace other than the initial one,
+// whose uid map covers all the uids
+func inUserNS() bool {
+	data, err := ioutil.ReadFile(uidMap)
+	if err != nil {
+		return false
+	}
+	return strings.Join(strings.Fields(string(data)), " ") != "0 0 4294967295"
+}
+
+// Resolve returns the mode auto stands for, other modes are returned as is
+func Resolve(mode string) string {
+	if mode != ModeAuto && mode != "" {
+		return mode
+	}
+	if inUserNS() {
+		return ModeCaller
+	}
+	return ModeRoot
+}
+
+// Make creates a veth pair between a and b according to mode
+func Make(mode string, a, b koko.VEth) error {
+	if Resolve(mode) == ModeRoot {
+		return koko.MakeVeth(a, b)
+	}
+	return makeInNS(a, b)
+}
+
+// makeInNS creates the ends of the pair directly in their namespaces, so the pair never
+// exists in the namespace of the caller
+func makeInNS(a, b koko.VEth) error {
+	nsA, err := ns.GetNS(a.NsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", a.NsName, err)
+	}
+	defer nsA.Close()
+	nsB, err := ns.GetNS(b.NsName)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %s: %s", b.NsName, err)
+	}
+	defer nsB.Close()
+
+	pair := &netlink.Veth{
+		LinkAttrs:     netlink.LinkAttrs{Name: a.LinkName, Namespace: netlink.NsFd(nsA.Fd())},
+		PeerName:      b.LinkName,
+		PeerNamespace: netlink.NsFd(nsB.Fd()),
+	}
+	if err := netlink.LinkAdd(pair); err != nil {
+		return fmt.Errorf("failed to create veth pair %s/%s: %s", a.LinkName, b.LinkName, err)
+	}
+	if err := setUp(nsA, a); err != nil {
+		removeEnd(nsA, a.LinkName)
+		return err
+	}
+	if err := setUp(nsB, b); err != nil {
+		removeEnd(nsA, a.LinkName)
+		return err
+	}
+	return nil
+}
+
+// setUp gives the end of a pair in netNs its addresses and brings it up
+func setUp(netNs ns.NetNS, end koko.VEth) error {
+	return netNs.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName(end.LinkName)
+		if err != nil {
+			return fmt.Errorf("failed to find %s in netns %s: %s", end.LinkName, end.NsName, err)
+		}
+		for i := range end.IPAddr {
+			if err := netlink.AddrAdd(link, &netlink.Addr{IPNet: &end.IPAddr[i]}); err != nil {
+				return fmt.Errorf("failed to add %s to %s: %s", end.IPAddr[i].String(), end.LinkName, err)
+			}
+		}
+		if err := netlink.LinkSetUp(link); err != nil {
+			return fmt.Errorf("failed to bring %s up: %s", end.LinkName, err)
+		}
+		return nil
+	})
+}
+
+// removeEnd removes an end of a pair, which removes the other one as well
+func removeEnd(netNs ns.NetNS, name string) {
+	netNs.Do(func(ns.NetNS) error {
+		if link, err := netlink.LinkByName(name); err == nil {
+			netlink.LinkDel(link)
+		}
+		return nil
+	})
+}
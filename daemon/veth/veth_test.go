@@ -0,0 +1,100 @@
+package veth
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	koko "github.com/redhat-nfvpe/koko/api"
+	"github.com/vishvananda/netlink"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	defer func(orig string) { uidMap = orig }(uidMap)
+	tests := []struct {
+		mode, uidMap, want string
+	}{
+		{ModeAuto, "         0          0 4294967295\n", ModeRoot},
+		{ModeAuto, "         0       1000          1\n", ModeCaller},
+		{"", "         0       1000          1\n", ModeCaller},
+		{ModeRoot, "         0       1000          1\n", ModeRoot},
+		{ModeCaller, "         0          0 4294967295\n", ModeCaller},
+	}
+	for i, tt := range tests {
+		uidMap = filepath.Join(dir, "uid_map")
+		os.WriteFile(uidMap, []byte(tt.uidMap), 0644)
+		if got := Resolve(tt.mode); got != tt.want {
+			t.Errorf("#%d test failed: Resolve(%q) = %s, want %s", i, tt.mode, got, tt.want)
+		}
+	}
+	if err := ValidateMode("user"); err == nil {
+		t.Errorf("ValidateMode() of an unknown mode didn't fail")
+	}
+}
+
+func TestMakeInNS(t *testing.T) {
+	var namespaces []ns.NetNS
+	for i := 0; i < 3; i++ {
+		netNs, err := testutils.NewNS()
+		if err != nil {
+			t.Skipf("failed to create a netns: %v", err)
+		}
+		defer testutils.UnmountNS(netNs)
+		defer netNs.Close()
+		namespaces = append(namespaces, netNs)
+	}
+	caller, nsA, nsB := namespaces[0], namespaces[1], namespaces[2]
+
+	ip := net.IPNet{IP: net.IPv4(10, 0, 0, 1), Mask: net.CIDRMask(31, 32)}
+	a := koko.VEth{NsName: nsA.Path(), LinkName: "eth1", IPAddr: []net.IPNet{ip}}
+	b := koko.VEth{NsName: nsB.Path(), LinkName: "eth2"}
+	// the caller isn't in the host network namespace
+	if err := caller.Do(func(ns.NetNS) error { return Make(ModeCaller, a, b) }); err != nil {
+		t.Fatalf("Make() failed: %v", err)
+	}
+
+	check := func(netNs ns.NetNS, name string, wantAddrs int) {
+		netNs.Do(func(ns.NetNS) error {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				t.Errorf("%s wasn't created in %s: %v", name, netNs.Path(), err)
+				return nil
+			}
+			if link.Type() != "veth" || link.Attrs().Flags&net.FlagUp == 0 {
+				t.Errorf("%s is a %s with flags %v, want an up veth", name, link.Type(), link.Attrs().Flags)
+			}
+			addrs, _ := netlink.AddrList(link, netlink.FAMILY_V4)
+			if len(addrs) != wantAddrs {
+				t.Errorf("%s has addresses %v, want %d", name, addrs, wantAddrs)
+			}
+			return nil
+		})
+	}
+	check(nsA, "eth1", 1)
+	check(nsB, "eth2", 0)
+	caller.Do(func(ns.NetNS) error {
+		links, _ := netlink.LinkList()
+		for _, l := range links {
+			if l.Type() == "veth" {
+				t.Errorf("veth %s was left in the caller's netns", l.Attrs().Name)
+			}
+		}
+		return nil
+	})
+
+	// an end that can't be set up removes the pair
+	bad := koko.VEth{NsName: nsA.Path(), LinkName: "eth3", IPAddr: []net.IPNet{{IP: net.IP{10, 0, 0}}}}
+	if err := Make(ModeCaller, koko.VEth{NsName: nsB.Path(), LinkName: "eth4"}, bad); err == nil {
+		t.Errorf("Make() with an invalid address didn't fail")
+	}
+	nsB.Do(func(ns.NetNS) error {
+		if _, err := netlink.LinkByName("eth4"); err == nil {
+			t.Errorf("eth4 wasn't removed after a failure")
+		}
+		return nil
+	})
+}
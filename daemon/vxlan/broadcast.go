@@ -0,0 +1,179 @@
+package vxlan
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// the MAC address of the FDB entries used for broadcast, multicast and unknown unicast frames
+var floodMAC = net.HardwareAddr{0, 0, 0, 0, 0, 0}
+
+// end is a VXLAN interface set up by CreateOrUpdate
+type end struct {
+	netNs, intf string
+}
+
+// wire identifies the VXLAN interfaces of a link. Link UIDs, and so VNIs, are only
+// unique within a K8s namespace.
+type wire struct {
+	kubeNs string
+	vni    uint32
+}
+
+var (
+	mu sync.Mutex
+	// interfaces set up on this node, keyed by namespace and VNI
+	ends = make(map[wire]map[end]bool)
+)
+
+func track(kubeNs, netNs, intf string, vni uint32) {
+	mu.Lock()
+	defer mu.Unlock()
+	w := wire{kubeNs, vni}
+	if ends[w] == nil {
+		ends[w] = make(map[end]bool)
+	}
+	ends[w][end{netNs, intf}] = true
+}
+
+// Forget stops tracking the VXLAN interface intf of the netns netNs, with vni in the
+// K8s namespace kubeNs, once it has been removed
+func Forget(kubeNs, netNs, intf string, vni uint32) {
+	mu.Lock()
+	defer mu.Unlock()
+	w := wire{kubeNs, vni}
+	delete(ends[w], end{netNs, intf})
+	if len(ends[w]) == 0 {
+		delete(ends, w)
+	}
+}
+
+// AddBroadcastGroup makes the VXLAN interfaces with vni of the K8s namespace kubeNs set up
+// on this node send a unicast copy of every broadcast, multicast and unknown unicast frame
+// to each of vteps, i.e. head-end replication, so that the underlay doesn't need multicast
+// routing. VTEPs added by an earlier call that aren't in vteps anymore are removed.
+func AddBroadcastGroup(kubeNs string, vni uint32, vteps []net.IP) error {
+	mu.Lock()
+	w := wire{kubeNs, vni}
+	var targets []end
+	for e := range ends[w] {
+		if _, err := os.Stat(e.netNs); err != nil {
+			delete(ends[w], e)
+			continue
+		}
+		targets = append(targets, e)
+	}
+	mu.Unlock()
+
+	if len(targets) == 0 {
+		return fmt.Errorf(" MESHNETD: no VXLAN interface with VNI %d in namespace %s on this node", vni, kubeNs)
+	}
+	for _, e := range targets {
+		if err := SetBroadcastGroup(e.netNs, e.intf, vteps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetBroadcastGroup sets the VTEPs the VXLAN interface intfName of the netns nsName floods
+// frames to, in addition to its remote VTEP
+func SetBroadcastGroup(nsName, intfName string, vteps []net.IP) error {
+	want := make(map[string]net.IP)
+	for _, vtep := range vteps {
+		if vtep == nil {
+			return fmt.Errorf(" MESHNETD: invalid VTEP in the broadcast group of %s", intfName)
+		}
+		want[vtep.String()] = vtep
+	}
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error opening netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf(" MESHNETD: Error looking up link %s: %s", intfName, err)
+		}
+		vxlanLink, ok := link.(*netlink.Vxlan)
+		if !ok {
+			return fmt.Errorf(" MESHNETD: Link %s is a %s, not a vxlan", intfName, link.Type())
+		}
+		current, err := floodVTEPs(link)
+		if err != nil {
+			return err
+		}
+		for key, vtep := range want {
+			if current[key] {
+				continue
+			}
+			log.Infof("Adding VTEP %s to the broadcast group of %s", vtep, intfName)
+			if err := netlink.NeighAppend(floodEntry(link, vtep)); err != nil {
+				return fmt.Errorf(" MESHNETD: Error adding VTEP %s to the broadcast group of %s: %s", vtep, intfName, err)
+			}
+		}
+		for key := range current {
+			vtep := net.ParseIP(key)
+			// the entry of the remote VTEP is owned by the interface itself
+			if _, ok := want[key]; ok || vtep.Equal(vxlanLink.Group) {
+				continue
+			}
+			log.Infof("Removing VTEP %s from the broadcast group of %s", vtep, intfName)
+			if err := netlink.NeighDel(floodEntry(link, vtep)); err != nil {
+				return fmt.Errorf(" MESHNETD: Error removing VTEP %s from the broadcast group of %s: %s", vtep, intfName, err)
+			}
+		}
+		return nil
+	})
+}
+
+func floodEntry(link netlink.Link, vtep net.IP) *netlink.Neigh {
+	return &netlink.Neigh{
+		LinkIndex:    link.Attrs().Index,
+		Family:       unix.AF_BRIDGE,
+		Flags:        netlink.NTF_SELF,
+		State:        netlink.NUD_PERMANENT | netlink.NUD_NOARP,
+		IP:           vtep,
+		HardwareAddr: floodMAC,
+	}
+}
+
+// floodVTEPs returns the VTEPs link floods frames to, in the current netns
+func floodVTEPs(link netlink.Link) (map[string]bool, error) {
+	fdb, err := netlink.NeighList(link.Attrs().Index, unix.AF_BRIDGE)
+	if err != nil {
+		return nil, fmt.Errorf(" MESHNETD: Error listing the FDB of %s: %s", link.Attrs().Name, err)
+	}
+	result := make(map[string]bool)
+	for _, f := range fdb {
+		if f.IP != nil && f.HardwareAddr.String() == floodMAC.String() {
+			result[f.IP.String()] = true
+		}
+	}
+	return result, nil
+}
+
+// inBroadcastGroup returns true if the VXLAN interface link of the netns nsName floods
+// frames to vtep
+func inBroadcastGroup(nsName string, link netlink.Link, vtep net.IP) bool {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return false
+	}
+	defer netNs.Close()
+	found := false
+	netNs.Do(func(_ ns.NetNS) error {
+		vteps, err := floodVTEPs(link)
+		found = err == nil && vteps[vtep.String()]
+		return nil
+	})
+	return found
+}
@@ -0,0 +1,118 @@
+package vxlan
+
+import (
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+)
+
+func TestSetBroadcastGroup(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("failed to create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+
+	err = netNs.Do(func(_ ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Vxlan{
+			LinkAttrs: netlink.LinkAttrs{Name: "vx1"},
+			VxlanId:   5001,
+			Group:     net.IPv4(192, 168, 0, 2),
+			Port:      vxlanPort,
+		})
+	})
+	if err != nil {
+		t.Skipf("failed to create a vxlan: %v", err)
+	}
+	vteps := func(ips ...string) []net.IP {
+		var result []net.IP
+		for _, ip := range ips {
+			result = append(result, net.ParseIP(ip))
+		}
+		return result
+	}
+
+	tests := []struct {
+		vteps []net.IP
+		want  []string
+	}{
+		{vteps: vteps("192.168.0.2", "192.168.0.3", "192.168.0.4"), want: []string{"192.168.0.2", "192.168.0.3", "192.168.0.4"}},
+		// setting the same group again is a no-op
+		{vteps: vteps("192.168.0.2", "192.168.0.3", "192.168.0.4"), want: []string{"192.168.0.2", "192.168.0.3", "192.168.0.4"}},
+		{vteps: vteps("192.168.0.3"), want: []string{"192.168.0.2", "192.168.0.3"}},
+		// the remote VTEP of the interface is never removed
+		{vteps: nil, want: []string{"192.168.0.2"}},
+	}
+	for i, tt := range tests {
+		if err := SetBroadcastGroup(netNs.Path(), "vx1", tt.vteps); err != nil {
+			t.Fatalf("#%d SetBroadcastGroup() failed: %v", i, err)
+		}
+		var got []string
+		netNs.Do(func(_ ns.NetNS) error {
+			link, err := netlink.LinkByName("vx1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			flood, err := floodVTEPs(link)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for vtep := range flood {
+				got = append(got, vtep)
+			}
+			return nil
+		})
+		sort.Strings(got)
+		if len(got) != len(tt.want) {
+			t.Errorf("#%d test failed: broadcast group = %v, want %v", i, got, tt.want)
+			continue
+		}
+		for j := range got {
+			if got[j] != tt.want[j] {
+				t.Errorf("#%d test failed: broadcast group = %v, want %v", i, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if err := SetBroadcastGroup(netNs.Path(), "vx2", nil); err == nil {
+		t.Errorf("SetBroadcastGroup() of a missing link didn't fail")
+	}
+	if err := AddBroadcastGroup("lab", 9999, vteps("192.168.0.3")); err == nil {
+		t.Errorf("AddBroadcastGroup() of an unknown VNI didn't fail")
+	}
+	track("lab", netNs.Path(), "vx1", 5001)
+	if err := AddBroadcastGroup("lab", 5001, vteps("192.168.0.5")); err != nil {
+		t.Errorf("AddBroadcastGroup() failed: %v", err)
+	}
+}
+
+func TestTrackNamespaces(t *testing.T) {
+	track("a", "/var/run/netns/r1", "eth1", 6001)
+	track("b", "/var/run/netns/r2", "eth1", 6001)
+	defer Forget("b", "/var/run/netns/r2", "eth1", 6001)
+
+	mu.Lock()
+	a, b := len(ends[wire{"a", 6001}]), len(ends[wire{"b", 6001}])
+	mu.Unlock()
+	if a != 1 || b != 1 {
+		t.Fatalf("tracked ends of VNI 6001 = %d in a and %d in b, want 1 each", a, b)
+	}
+
+	Forget("a", "/var/run/netns/r1", "eth1", 6001)
+	mu.Lock()
+	_, inA := ends[wire{"a", 6001}]
+	b = len(ends[wire{"b", 6001}])
+	mu.Unlock()
+	if inA || b != 1 {
+		t.Errorf("after Forget() in a: tracked in a = %t, ends in b = %d, want false and 1", inA, b)
+	}
+	if err := AddBroadcastGroup("c", 6001, []net.IP{net.ParseIP("192.168.0.5")}); err == nil {
+		t.Errorf("AddBroadcastGroup() in a namespace without the VNI didn't fail")
+	}
+}
@@ -0,0 +1,331 @@
+package vxlan
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/redhat-nfvpe/koko/api"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// VXLAN-GPE interfaces are collect-metadata devices, which receive all VNIs of their UDP
+	// port, so each wire gets its own port: gpePortBase plus its VNI, the same on both nodes
+	gpePortBase = 10000
+	// not defined by the vendored netlink
+	iflaVxlanGPE = nl.IFLA_VXLAN_FLOWBASED + 2
+
+	// lwtunnel encapsulation of routes through collect-metadata devices
+	lwtunnelEncapIP  = 2
+	lwtunnelEncapIP6 = 4
+	lwtunnelIPID     = 1
+	lwtunnelIPDst    = 2
+)
+
+// gpePort returns the UDP port of the VXLAN-GPE interfaces of VNI vni
+func gpePort(vni int) (int, error) {
+	port := gpePortBase + vni
+	if vni <= 0 || port > 65535 {
+		return 0, fmt.Errorf("VNI %d is out of the range of VXLAN-GPE links", vni)
+	}
+	return port, nil
+}
+
+// IsGPE returns true if link, in the current network namespace, is a VXLAN-GPE interface
+func IsGPE(link *netlink.Vxlan) bool {
+	gpe, err := hasGPEFlag(link.Attrs().Index)
+	if err != nil {
+		log.Warnf("Failed to read the attributes of %s: %s", link.Attrs().Name, err)
+	}
+	return link.FlowBased && gpe
+}
+
+// GPETunnel returns the VNI and remote VTEP of the VXLAN-GPE interface link, in the current
+// network namespace, which are set by the route of its link subnet
+func GPETunnel(link *netlink.Vxlan) (int, net.IP, error) {
+	encaps, err := routeEncaps(link.Attrs().Index)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(encaps) == 0 {
+		return 0, nil, fmt.Errorf("%s has no VXLAN-GPE route", link.Attrs().Name)
+	}
+	return int(encaps[0].ID), encaps[0].Dst, nil
+}
+
+// createOrUpdateGPE makes sure a VXLAN-GPE interface with the given attributes exists.
+// GPE interfaces carry L3 payloads without an inner Ethernet header. The kernel only
+// supports them as collect-metadata devices, so the VNI and remote VTEP are set by an
+// encapsulating route of the link subnet rather than by the interface. They're created with a
+// raw netlink request, since neither koko nor netlink support the GPE flag.
+func createOrUpdateGPE(veth api.VEth, vxlan api.VxLan, local net.IP) error {
+	if len(veth.IPAddr) == 0 {
+		return fmt.Errorf(" MESHNETD: VXLAN-GPE link %s requires an IP address", veth.LinkName)
+	}
+	port, err := gpePort(vxlan.ID)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: %s", err)
+	}
+
+	link := getLinkFromNS(veth.NsName, veth.LinkName)
+	existing, ok := link.(*netlink.Vxlan)
+	if ok && existing.FlowBased && existing.Port == port && inNetNs(veth.NsName, func() bool { return IsGPE(existing) }) {
+		log.Infof("VXLAN-GPE link %s already exists", veth.LinkName)
+		return setGPERoute(veth, vxlan)
+	}
+	if link != nil {
+		log.Infof("Removing link %s before re-creating it as VXLAN-GPE", veth.LinkName)
+		if err := veth.RemoveVethLink(); err != nil {
+			return fmt.Errorf(" MESHNETD: Error when removing an old interface with koko: %s", err)
+		}
+	}
+
+	parent, err := netlink.LinkByName(vxlan.ParentIF)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error looking up VTEP interface %s: %s", vxlan.ParentIF, err)
+	}
+
+	// The interface is created in the host namespace, then moved and renamed by koko
+	tmpName := fmt.Sprintf("gpe%d", vxlan.ID)
+	log.Infof("Creating a VXLAN-GPE link: %v; inside the pod: %v", vxlan, veth)
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(nl.NewIfInfomsg(unix.AF_UNSPEC))
+	req.AddData(nl.NewRtAttr(unix.IFLA_IFNAME, nl.ZeroTerminated(tmpName)))
+	req.AddData(gpeLinkInfo(uint16(port), local, parent.Attrs().Index))
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return fmt.Errorf(" MESHNETD: Error when creating a VXLAN-GPE interface: %s", err)
+	}
+
+	gpeLink, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return err
+	}
+	if err := veth.SetVethLink(gpeLink); err != nil {
+		netlink.LinkDel(gpeLink)
+		return fmt.Errorf(" MESHNETD: Error when moving VXLAN-GPE interface to %s: %s", veth.NsName, err)
+	}
+	return setGPERoute(veth, vxlan)
+}
+
+// setGPERoute replaces the connected route of the link subnet of a VXLAN-GPE interface with
+// one encapsulating its traffic with the VNI and remote VTEP of the link
+func setGPERoute(veth api.VEth, vxlan api.VxLan) error {
+	netNs, err := ns.GetNS(veth.NsName)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error opening netns %s: %s", veth.NsName, err)
+	}
+	defer netNs.Close()
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(veth.LinkName)
+		if err != nil {
+			return err
+		}
+		for _, addr := range veth.IPAddr {
+			subnet := &net.IPNet{IP: addr.IP.Mask(addr.Mask), Mask: addr.Mask}
+			if err := netlink.RouteReplace(&netlink.Route{
+				LinkIndex: link.Attrs().Index,
+				Dst:       subnet,
+				Src:       addr.IP,
+				Scope:     netlink.SCOPE_LINK,
+				Encap:     &ipEncap{ID: uint64(vxlan.ID), Dst: vxlan.IPAddr},
+			}); err != nil {
+				return fmt.Errorf(" MESHNETD: Error adding the VXLAN-GPE route of %s: %s", subnet, err)
+			}
+		}
+		return nil
+	})
+}
+
+// inNetNs returns the result of f run in the network namespace nsName, or false if it can't
+// be entered
+func inNetNs(nsName string, f func() bool) bool {
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return false
+	}
+	defer netNs.Close()
+	result := false
+	netNs.Do(func(_ ns.NetNS) error {
+		result = f()
+		return nil
+	})
+	return result
+}
+
+// gpeLinkInfo builds the IFLA_LINKINFO attribute of a VXLAN-GPE interface. GPE requires
+// collect-metadata mode, which doesn't allow a VNI, a remote or learning.
+func gpeLinkInfo(port uint16, local net.IP, parentIndex int) *nl.RtAttr {
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated("vxlan"))
+
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	if parentIndex != 0 {
+		data.AddRtAttr(nl.IFLA_VXLAN_LINK, nl.Uint32Attr(uint32(parentIndex)))
+	}
+	if ip4 := local.To4(); ip4 != nil {
+		data.AddRtAttr(nl.IFLA_VXLAN_LOCAL, []byte(ip4))
+	} else if ip6 := local.To16(); ip6 != nil {
+		data.AddRtAttr(nl.IFLA_VXLAN_LOCAL6, []byte(ip6))
+	}
+	portAttr := make([]byte, 2)
+	binary.BigEndian.PutUint16(portAttr, port)
+	data.AddRtAttr(nl.IFLA_VXLAN_PORT, portAttr)
+	data.AddRtAttr(nl.IFLA_VXLAN_LEARNING, nl.Uint8Attr(0))
+	data.AddRtAttr(nl.IFLA_VXLAN_FLOWBASED, nl.Uint8Attr(1))
+	data.AddRtAttr(iflaVxlanGPE, []byte{})
+	return linkInfo
+}
+
+// hasGPEFlag returns true if the interface with index is a VXLAN-GPE one. The vendored
+// netlink doesn't decode the flag, so the interface is read with a raw netlink request.
+func hasGPEFlag(index int) (bool, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_GETLINK, unix.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	msg.Index = int32(index)
+	req.AddData(msg)
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWLINK)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range msgs {
+		if len(m) < unix.SizeofIfInfomsg {
+			continue
+		}
+		attrs, err := nl.ParseRouteAttr(m[unix.SizeofIfInfomsg:])
+		if err != nil {
+			return false, err
+		}
+		for _, a := range attrs {
+			if a.Attr.Type == unix.IFLA_LINKINFO {
+				return linkInfoHasGPE(a.Value)
+			}
+		}
+	}
+	return false, nil
+}
+
+// linkInfoHasGPE returns true if the IFLA_LINKINFO value info sets IFLA_VXLAN_GPE
+func linkInfoHasGPE(info []byte) (bool, error) {
+	infoAttrs, err := nl.ParseRouteAttr(info)
+	if err != nil {
+		return false, err
+	}
+	for _, i := range infoAttrs {
+		if i.Attr.Type != nl.IFLA_INFO_DATA {
+			continue
+		}
+		data, err := nl.ParseRouteAttr(i.Value)
+		if err != nil {
+			return false, err
+		}
+		for _, d := range data {
+			if d.Attr.Type == iflaVxlanGPE {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// routeEncaps returns the IP encapsulations of the routes through the interface with index.
+// The vendored netlink ignores them when listing routes, so they're read with a raw request.
+func routeEncaps(index int) ([]*ipEncap, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_GETROUTE, unix.NLM_F_DUMP)
+	req.AddData(nl.NewRtMsg())
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWROUTE)
+	if err != nil {
+		return nil, err
+	}
+	var result []*ipEncap
+	for _, m := range msgs {
+		msg := nl.DeserializeRtMsg(m)
+		attrs, err := nl.ParseRouteAttr(m[msg.Len():])
+		if err != nil {
+			return nil, err
+		}
+		var oif int
+		var encapType uint16
+		var encap []byte
+		for _, a := range attrs {
+			switch a.Attr.Type {
+			case unix.RTA_OIF:
+				oif = int(nl.NativeEndian().Uint32(a.Value))
+			case unix.RTA_ENCAP_TYPE:
+				encapType = nl.NativeEndian().Uint16(a.Value)
+			case unix.RTA_ENCAP:
+				encap = a.Value
+			}
+		}
+		if oif != index || (encapType != lwtunnelEncapIP && encapType != lwtunnelEncapIP6) {
+			continue
+		}
+		e := &ipEncap{}
+		if err := e.Decode(encap); err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// ipEncap is the LWTUNNEL_ENCAP_IP and LWTUNNEL_ENCAP_IP6 route encapsulation, which
+// isn't implemented by the vendored netlink. It sets the VNI and remote VTEP of the
+// packets sent through a collect-metadata VXLAN interface.
+type ipEncap struct {
+	ID  uint64
+	Dst net.IP
+}
+
+func (e *ipEncap) Type() int {
+	if e.Dst.To4() != nil {
+		return lwtunnelEncapIP
+	}
+	return lwtunnelEncapIP6
+}
+
+func (e *ipEncap) Decode(buf []byte) error {
+	attrs, err := nl.ParseRouteAttr(buf)
+	if err != nil {
+		return err
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case lwtunnelIPID:
+			if len(a.Value) != 8 {
+				return fmt.Errorf("invalid tunnel ID of length %d", len(a.Value))
+			}
+			e.ID = binary.BigEndian.Uint64(a.Value)
+		case lwtunnelIPDst:
+			e.Dst = net.IP(a.Value)
+		}
+	}
+	return nil
+}
+
+func (e *ipEncap) Encode() ([]byte, error) {
+	dst := e.Dst.To4()
+	if dst == nil {
+		dst = e.Dst.To16()
+	}
+	if dst == nil {
+		return nil, fmt.Errorf("invalid tunnel destination %s", e.Dst)
+	}
+	id := make([]byte, 8)
+	binary.BigEndian.PutUint64(id, e.ID)
+	buf := nl.NewRtAttr(lwtunnelIPID, id).Serialize()
+	return append(buf, nl.NewRtAttr(lwtunnelIPDst, []byte(dst)).Serialize()...), nil
+}
+
+func (e *ipEncap) String() string {
+	return fmt.Sprintf("id %d dst %s", e.ID, e.Dst)
+}
+
+func (e *ipEncap) Equal(x netlink.Encap) bool {
+	o, ok := x.(*ipEncap)
+	return ok && e.ID == o.ID && e.Dst.Equal(o.Dst)
+}
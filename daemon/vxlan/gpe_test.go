@@ -0,0 +1,209 @@
+package vxlan
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestGPELinkInfo(t *testing.T) {
+	attrs, err := nl.ParseRouteAttr(gpeLinkInfo(15001, net.ParseIP("2001:db8::1"), 2).Serialize())
+	if err != nil || len(attrs) != 1 || attrs[0].Attr.Type != unix.IFLA_LINKINFO {
+		t.Fatalf("failed to parse IFLA_LINKINFO: %v", err)
+	}
+	if gpe, err := linkInfoHasGPE(attrs[0].Value); err != nil || !gpe {
+		t.Errorf("IFLA_VXLAN_GPE is not set: %v", err)
+	}
+	info, err := nl.ParseRouteAttr(attrs[0].Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[uint16][]byte{}
+	for _, a := range info {
+		if a.Attr.Type != nl.IFLA_INFO_DATA {
+			continue
+		}
+		vxlanAttrs, err := nl.ParseRouteAttr(a.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range vxlanAttrs {
+			data[v.Attr.Type] = v.Value
+		}
+	}
+	if v, ok := data[nl.IFLA_VXLAN_FLOWBASED]; !ok || v[0] != 1 {
+		t.Errorf("IFLA_VXLAN_COLLECT_METADATA is not set")
+	}
+	if v, ok := data[nl.IFLA_VXLAN_LEARNING]; !ok || v[0] != 0 {
+		t.Errorf("learning is not turned off")
+	}
+	for _, attr := range []uint16{nl.IFLA_VXLAN_ID, nl.IFLA_VXLAN_GROUP, nl.IFLA_VXLAN_GROUP6} {
+		if _, ok := data[attr]; ok {
+			t.Errorf("attribute %d is not allowed in collect-metadata mode", attr)
+		}
+	}
+	if port := binary.BigEndian.Uint16(data[nl.IFLA_VXLAN_PORT]); port != 15001 {
+		t.Errorf("expected port 15001, got %d", port)
+	}
+	if src := net.IP(data[nl.IFLA_VXLAN_LOCAL6]); !src.Equal(net.ParseIP("2001:db8::1")) {
+		t.Errorf("expected local 2001:db8::1, got %s", src)
+	}
+}
+
+func TestGPEPort(t *testing.T) {
+	if port, err := gpePort(5001); err != nil || port != 15001 {
+		t.Errorf("gpePort(5001) = %d, %v, want 15001", port, err)
+	}
+	if _, err := gpePort(60000); err == nil {
+		t.Errorf("gpePort(60000) succeeded, want an error")
+	}
+}
+
+func TestIPEncap(t *testing.T) {
+	for _, e := range []*ipEncap{
+		{ID: 5001, Dst: net.ParseIP("192.0.2.1")},
+		{ID: 5002, Dst: net.ParseIP("2001:db8::2")},
+	} {
+		buf, err := e.Encode()
+		if err != nil {
+			t.Fatalf("Encode(%s) failed: %v", e, err)
+		}
+		got := &ipEncap{}
+		if err := got.Decode(buf); err != nil {
+			t.Fatalf("Decode(%s) failed: %v", e, err)
+		}
+		if !got.Equal(e) {
+			t.Errorf("decoded %s, want %s", got, e)
+		}
+		// the kernel reads the tunnel ID as a big endian 64-bit integer
+		if id := binary.BigEndian.Uint64(buf[4:12]); id != e.ID {
+			t.Errorf("encoded ID %d, want %d", id, e.ID)
+		}
+	}
+	if (&ipEncap{Dst: net.ParseIP("192.0.2.1")}).Type() != lwtunnelEncapIP ||
+		(&ipEncap{Dst: net.ParseIP("2001:db8::2")}).Type() != lwtunnelEncapIP6 {
+		t.Errorf("wrong encap type for the family of the remote VTEP")
+	}
+}
+
+// TestGPEWire sets up both ends of a VXLAN-GPE wire between two nodes connected by a veth
+// and sends a packet across it
+func TestGPEWire(t *testing.T) {
+	var netNss []ns.NetNS
+	for i := 0; i < 4; i++ {
+		n, err := testutils.NewNS()
+		if err != nil {
+			t.Skipf("failed to create a netns: %v", err)
+		}
+		defer testutils.UnmountNS(n)
+		defer n.Close()
+		netNss = append(netNss, n)
+	}
+	nodes, pods := netNss[:2], netNss[2:]
+	nodeIPs := []string{"192.0.2.1", "192.0.2.2"}
+	podIPs := []string{"10.0.0.1/24", "10.0.0.2/24"}
+
+	err := nodes[0].Do(func(_ ns.NetNS) error {
+		return netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "underlay"}, PeerName: "peer"})
+	})
+	if err != nil {
+		t.Skipf("failed to create a veth: %v", err)
+	}
+	err = nodes[0].Do(func(_ ns.NetNS) error {
+		peer, err := netlink.LinkByName("peer")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetNsFd(peer, int(nodes[1].Fd()))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range []string{"underlay", "peer"} {
+		err := nodes[i].Do(func(_ ns.NetNS) error {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				return err
+			}
+			addr, _ := netlink.ParseAddr(nodeIPs[i] + "/24")
+			if err := netlink.AddrAdd(link, addr); err != nil {
+				return err
+			}
+			return netlink.LinkSetUp(link)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := range nodes {
+		remote := &mpb.RemotePod{NetNs: pods[i].Path(), IntfName: "eth1", IntfIp: podIPs[i], PeerVtep: nodeIPs[1-i], Vni: 5001, VxlanGpe: true}
+		err := nodes[i].Do(func(_ ns.NetNS) error { return createOrUpdate(remote) })
+		if err != nil {
+			t.Fatalf("createOrUpdate on node %d failed: %v", i, err)
+		}
+		// setting up the same link again keeps the interface
+		index := getLinkFromNS(pods[i].Path(), "eth1").Attrs().Index
+		if err := nodes[i].Do(func(_ ns.NetNS) error { return createOrUpdate(remote) }); err != nil {
+			t.Fatalf("createOrUpdate on node %d failed again: %v", i, err)
+		}
+		vx, ok := getLinkFromNS(pods[i].Path(), "eth1").(*netlink.Vxlan)
+		if !ok || vx.Index != index {
+			t.Fatalf("eth1 of pod %d was re-created", i)
+		}
+		err = pods[i].Do(func(_ ns.NetNS) error {
+			if !vx.FlowBased || vx.Port != 15001 || !IsGPE(vx) {
+				t.Errorf("eth1 of pod %d is not an external VXLAN-GPE interface on port 15001: %+v", i, vx)
+			}
+			vni, dst, err := GPETunnel(vx)
+			if err != nil {
+				return err
+			}
+			if vni != 5001 || !dst.Equal(net.ParseIP(nodeIPs[1-i])) {
+				t.Errorf("eth1 of pod %d routes to VNI %d on %s, want 5001 on %s", i, vni, dst, nodeIPs[1-i])
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var conn *net.UDPConn
+	err = pods[1].Do(func(_ ns.NetNS) error {
+		var err error
+		conn, err = net.ListenUDP("udp4", &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 7000})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	err = pods[0].Do(func(_ ns.NetNS) error {
+		c, err := net.Dial("udp4", "10.0.0.2:7000")
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		_, err = c.Write([]byte("gpe"))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 16)
+	n, _, err := conn.ReadFromUDP(buf)
+	if err != nil || string(buf[:n]) != "gpe" {
+		t.Errorf("failed to receive a packet across the wire: %q, %v", buf[:n], err)
+	}
+}
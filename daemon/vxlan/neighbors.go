@@ -0,0 +1,160 @@
+package vxlan
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/redhat-nfvpe/koko/api"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// default UDP port of VXLAN, as used by koko
+const vxlanPort = 4789
+
+// Neighbors returns the neighbor of a link end with ip, which may have a prefix length, and
+// mac behind vtep, or nil if its MAC isn't known
+func Neighbors(ip, mac, vtep string) []*mpb.VXLANNeighbor {
+	if ip == "" || mac == "" || vtep == "" {
+		return nil
+	}
+	return []*mpb.VXLANNeighbor{{Ip: strings.Split(ip, "/")[0], Mac: mac, VtepIp: vtep}}
+}
+
+// createOrUpdateProxy makes sure a VXLAN interface answering ARP requests from its neighbor
+// table exists, with the MAC address mac if it's set, and programs its neighbors. Flooding
+// and learning are disabled, so that only the static FDB entries are used.
+func createOrUpdateProxy(veth api.VEth, vxlan api.VxLan, local net.IP, mac string, neighbors []*mpb.VXLANNeighbor) error {
+	var hwAddr net.HardwareAddr
+	if mac != "" {
+		var err error
+		if hwAddr, err = net.ParseMAC(mac); err != nil {
+			return fmt.Errorf(" MESHNETD: Error parsing MAC %s: %s", mac, err)
+		}
+	}
+
+	link := getLinkFromNS(veth.NsName, veth.LinkName)
+	if existing, ok := link.(*netlink.Vxlan); ok && existing.Proxy && !existing.Learning &&
+		existing.VxlanId == vxlan.ID && existing.Group.Equal(vxlan.IPAddr) &&
+		(hwAddr == nil || existing.HardwareAddr.String() == hwAddr.String()) {
+		log.Infof("VXLAN link %s with ARP suppression already exists", veth.LinkName)
+		return UpdateNeighbors(veth.NsName, veth.LinkName, neighbors)
+	}
+	if link != nil {
+		// the proxy and learning flags of a VXLAN interface can't be changed
+		log.Infof("Removing link %s before re-creating it with ARP suppression", veth.LinkName)
+		if err := veth.RemoveVethLink(); err != nil {
+			return fmt.Errorf(" MESHNETD: Error when removing an old interface with koko: %s", err)
+		}
+	}
+
+	parent, err := netlink.LinkByName(vxlan.ParentIF)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error looking up VTEP interface %s: %s", vxlan.ParentIF, err)
+	}
+	// The interface is created in the host namespace, then moved and renamed by koko
+	tmpName := fmt.Sprintf("vxp%d", vxlan.ID)
+	log.Infof("Creating a VXLAN link with ARP suppression: %v; inside the pod: %v", vxlan, veth)
+	proxyLink := &netlink.Vxlan{
+		LinkAttrs:    netlink.LinkAttrs{Name: tmpName, TxQLen: 1000, HardwareAddr: hwAddr},
+		VxlanId:      vxlan.ID,
+		VtepDevIndex: parent.Attrs().Index,
+		SrcAddr:      local,
+		Group:        vxlan.IPAddr,
+		Port:         vxlanPort,
+		Proxy:        true,
+	}
+	if err := netlink.LinkAdd(proxyLink); err != nil {
+		return fmt.Errorf(" MESHNETD: Error when creating a VXLAN interface with ARP suppression: %s", err)
+	}
+	created, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return err
+	}
+	if err := veth.SetVethLink(created); err != nil {
+		netlink.LinkDel(created)
+		return fmt.Errorf(" MESHNETD: Error when moving VXLAN interface to %s: %s", veth.NsName, err)
+	}
+	return UpdateNeighbors(veth.NsName, veth.LinkName, neighbors)
+}
+
+// UpdateNeighbors adds the static FDB and ARP entries of neighbors to the VXLAN interface
+// intfName of the netns nsName, replacing those of the same MAC and IP addresses.
+func UpdateNeighbors(nsName, intfName string, neighbors []*mpb.VXLANNeighbor) error {
+	type entry struct {
+		ip, vtep net.IP
+		mac      net.HardwareAddr
+	}
+	var entries []entry
+	for _, n := range neighbors {
+		ip, mac, vtep, err := parseNeighbor(n)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{ip, vtep, mac})
+	}
+
+	netNs, err := ns.GetNS(nsName)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error opening netns %s: %s", nsName, err)
+	}
+	defer netNs.Close()
+	return netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(intfName)
+		if err != nil {
+			return fmt.Errorf(" MESHNETD: Error looking up link %s: %s", intfName, err)
+		}
+		if _, ok := link.(*netlink.Vxlan); !ok {
+			return fmt.Errorf(" MESHNETD: Link %s is a %s, not a vxlan", intfName, link.Type())
+		}
+		for _, e := range entries {
+			fdb := &netlink.Neigh{
+				LinkIndex:    link.Attrs().Index,
+				Family:       unix.AF_BRIDGE,
+				Flags:        netlink.NTF_SELF,
+				State:        netlink.NUD_PERMANENT,
+				IP:           e.vtep,
+				HardwareAddr: e.mac,
+			}
+			if err := netlink.NeighSet(fdb); err != nil {
+				return fmt.Errorf(" MESHNETD: Error adding FDB entry %s via %s to %s: %s", e.mac, e.vtep, intfName, err)
+			}
+			family := netlink.FAMILY_V4
+			if e.ip.To4() == nil {
+				family = netlink.FAMILY_V6
+			}
+			arp := &netlink.Neigh{
+				LinkIndex:    link.Attrs().Index,
+				Family:       family,
+				State:        netlink.NUD_PERMANENT,
+				IP:           e.ip,
+				HardwareAddr: e.mac,
+			}
+			if err := netlink.NeighSet(arp); err != nil {
+				return fmt.Errorf(" MESHNETD: Error adding neighbor %s at %s to %s: %s", e.ip, e.mac, intfName, err)
+			}
+		}
+		return nil
+	})
+}
+
+func parseNeighbor(n *mpb.VXLANNeighbor) (net.IP, net.HardwareAddr, net.IP, error) {
+	ip := net.ParseIP(n.Ip)
+	if ip == nil {
+		return nil, nil, nil, fmt.Errorf(" MESHNETD: Error parsing neighbor IP %q", n.Ip)
+	}
+	mac, err := net.ParseMAC(n.Mac)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(" MESHNETD: Error parsing MAC of neighbor %s: %s", n.Ip, err)
+	}
+	vtep := net.ParseIP(n.VtepIp)
+	if vtep == nil {
+		return nil, nil, nil, fmt.Errorf(" MESHNETD: Error parsing VTEP %q of neighbor %s", n.VtepIp, n.Ip)
+	}
+	return ip, mac, vtep, nil
+}
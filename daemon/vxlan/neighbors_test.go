@@ -0,0 +1,114 @@
+package vxlan
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestNeighbors(t *testing.T) {
+	tests := []struct {
+		ip, mac, vtep string
+		want          *mpb.VXLANNeighbor
+	}{
+		{"10.0.0.1/24", "02:00:00:00:00:01", "192.168.0.1", &mpb.VXLANNeighbor{Ip: "10.0.0.1", Mac: "02:00:00:00:00:01", VtepIp: "192.168.0.1"}},
+		{"10.0.0.1", "02:00:00:00:00:01", "192.168.0.1", &mpb.VXLANNeighbor{Ip: "10.0.0.1", Mac: "02:00:00:00:00:01", VtepIp: "192.168.0.1"}},
+		{"10.0.0.1/24", "", "192.168.0.1", nil},
+		{"", "02:00:00:00:00:01", "192.168.0.1", nil},
+	}
+	for _, tt := range tests {
+		got := Neighbors(tt.ip, tt.mac, tt.vtep)
+		if tt.want == nil {
+			if got != nil {
+				t.Errorf("Neighbors(%q, %q, %q) = %v, want nil", tt.ip, tt.mac, tt.vtep, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0].Ip != tt.want.Ip || got[0].Mac != tt.want.Mac || got[0].VtepIp != tt.want.VtepIp {
+			t.Errorf("Neighbors(%q, %q, %q) = %v, want %v", tt.ip, tt.mac, tt.vtep, got, tt.want)
+		}
+	}
+}
+
+func TestParseNeighbor(t *testing.T) {
+	tests := []struct {
+		n       *mpb.VXLANNeighbor
+		wantErr bool
+	}{
+		{&mpb.VXLANNeighbor{Ip: "10.0.0.1", Mac: "02:00:00:00:00:01", VtepIp: "192.168.0.1"}, false},
+		{&mpb.VXLANNeighbor{Ip: "2001:db8::1", Mac: "02:00:00:00:00:01", VtepIp: "192.168.0.1"}, false},
+		{&mpb.VXLANNeighbor{Ip: "10.0.0.1/24", Mac: "02:00:00:00:00:01", VtepIp: "192.168.0.1"}, true},
+		{&mpb.VXLANNeighbor{Ip: "10.0.0.1", Mac: "02:00", VtepIp: "192.168.0.1"}, true},
+		{&mpb.VXLANNeighbor{Ip: "10.0.0.1", Mac: "02:00:00:00:00:01"}, true},
+	}
+	for _, tt := range tests {
+		if _, _, _, err := parseNeighbor(tt.n); (err != nil) != tt.wantErr {
+			t.Errorf("parseNeighbor(%v) error = %v, wantErr %v", tt.n, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUpdateNeighbors(t *testing.T) {
+	netNs, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("failed to create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(netNs)
+	defer netNs.Close()
+
+	err = netNs.Do(func(_ ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "veth1"}, PeerName: "veth2"}); err != nil {
+			return err
+		}
+		return netlink.LinkAdd(&netlink.Vxlan{
+			LinkAttrs: netlink.LinkAttrs{Name: "vx1"},
+			VxlanId:   5001,
+			Group:     net.IPv4(192, 168, 0, 2),
+			Port:      vxlanPort,
+			Proxy:     true,
+		})
+	})
+	if err != nil {
+		t.Skipf("failed to create links: %v", err)
+	}
+
+	neighbors := Neighbors("10.0.0.2/24", "02:00:00:00:00:02", "192.168.0.2")
+	if err := UpdateNeighbors(netNs.Path(), "veth1", neighbors); err == nil {
+		t.Errorf("UpdateNeighbors() of a veth didn't fail")
+	}
+	if err := UpdateNeighbors(netNs.Path(), "vx1", neighbors); err != nil {
+		t.Fatalf("UpdateNeighbors() failed: %v", err)
+	}
+	// updating them again replaces the entries
+	if err := UpdateNeighbors(netNs.Path(), "vx1", neighbors); err != nil {
+		t.Fatalf("UpdateNeighbors() of existing entries failed: %v", err)
+	}
+
+	netNs.Do(func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName("vx1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		arp, _ := netlink.NeighList(link.Attrs().Index, netlink.FAMILY_V4)
+		if len(arp) != 1 || !arp[0].IP.Equal(net.ParseIP("10.0.0.2")) || arp[0].HardwareAddr.String() != "02:00:00:00:00:02" {
+			t.Errorf("ARP entries of vx1 = %v, want 10.0.0.2 at 02:00:00:00:00:02", arp)
+		}
+		fdb, _ := netlink.NeighList(link.Attrs().Index, unix.AF_BRIDGE)
+		found := false
+		for _, f := range fdb {
+			if f.HardwareAddr.String() == "02:00:00:00:00:02" && f.IP.Equal(net.ParseIP("192.168.0.2")) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("FDB of vx1 = %v, want 02:00:00:00:00:02 via 192.168.0.2", fdb)
+		}
+		return nil
+	})
+}
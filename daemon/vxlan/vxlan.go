@@ -16,11 +16,20 @@ import (
 
 // CreateOrUpdate creates or updates the vxlan on the node.
 func CreateOrUpdate(v *mpb.RemotePod) error {
-	/// Looking up default interface
-	_, srcIntf, err := getSource()
+	if err := createOrUpdate(v); err != nil {
+		return err
+	}
+	track(v.KubeNs, v.NetNs, v.IntfName, uint32(v.Vni))
+	return nil
+}
+
+func createOrUpdate(v *mpb.RemotePod) error {
+	/// Looking up the interface towards the peer's VTEP
+	srcIP, srcIntf, err := getSource(v.PeerVtep)
 	if err != nil {
 		return err
 	}
+	local := underlayLocal(srcIP, v.PeerVtep)
 
 	// Creating koko Veth struct
 	veth := api.VEth{
@@ -49,6 +58,13 @@ func CreateOrUpdate(v *mpb.RemotePod) error {
 	}
 	log.Infof("Created koko vxlan struct %+v", vxlan)
 
+	if v.VxlanGpe {
+		return createOrUpdateGPE(veth, vxlan, local)
+	}
+	if len(v.Neighbors) > 0 || v.IntfMac != "" {
+		return createOrUpdateProxy(veth, vxlan, local, v.IntfMac, v.Neighbors)
+	}
+
 	// Try to read interface attributes from netlink
 	link := getLinkFromNS(veth.NsName, veth.LinkName)
 	log.Infof("Retrieved %s link from %s Netns: %+v", veth.LinkName, veth.NsName, link)
@@ -58,7 +74,9 @@ func CreateOrUpdate(v *mpb.RemotePod) error {
 	log.Infof("Is link %+v a VXLAN?: %s", vxlanLink, strconv.FormatBool(ok))
 	if ok { // the link we've found is a vxlan link
 
-		if !(vxlanLink.VxlanId == vxlan.ID && vxlanLink.Group.Equal(vxlan.IPAddr)) { // If Vxlan attrs are different
+		// A peer VTEP in the broadcast group of the link is already reached by it
+		sameGroup := vxlanLink.Group.Equal(vxlan.IPAddr) || inBroadcastGroup(veth.NsName, vxlanLink, vxlan.IPAddr)
+		if !(vxlanLink.VxlanId == vxlan.ID && sameGroup) { // If Vxlan attrs are different
 
 			// We remove the existing link and add a new one
 			log.Infof("Vxlan attrs are different: %d!=%d or %v!=%v", vxlanLink.VxlanId, vxlan.ID, vxlanLink.Group, vxlan.IPAddr)
@@ -66,7 +84,7 @@ func CreateOrUpdate(v *mpb.RemotePod) error {
 				return fmt.Errorf(" MESHNETD: Error when removing an old Vxlan interface with koko: %s", err)
 			}
 
-			if err = api.MakeVxLan(veth, vxlan); err != nil {
+			if err = makeVxLan(veth, vxlan, local); err != nil {
 				if strings.Contains(err.Error(), "file exists") {
 					log.Infof(" MESHNETD: Error when creating a Vxlan interface with koko, file exists")
 				} else {
@@ -88,7 +106,7 @@ func CreateOrUpdate(v *mpb.RemotePod) error {
 
 		// Then we simply create a new one
 		log.Infof("Creating a VXLAN link: %v; inside the pod: %v", vxlan, veth)
-		if err = api.MakeVxLan(veth, vxlan); err != nil {
+		if err = makeVxLan(veth, vxlan, local); err != nil {
 			if strings.Contains(err.Error(), "file exists") {
 				log.Warnf(" MESHNETD: Error when creating a Vxlan interface with koko, file exists")
 			} else {
@@ -101,6 +119,51 @@ func CreateOrUpdate(v *mpb.RemotePod) error {
 	return nil
 }
 
+// makeVxLan creates a VXLAN interface in the pod with koko, unless its underlay is IPv6.
+// koko doesn't set the local address, so those are created here with IFLA_VXLAN_LOCAL6.
+func makeVxLan(veth api.VEth, vxlan api.VxLan, local net.IP) error {
+	if local == nil {
+		return api.MakeVxLan(veth, vxlan)
+	}
+	parent, err := netlink.LinkByName(vxlan.ParentIF)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: Error looking up VTEP interface %s: %s", vxlan.ParentIF, err)
+	}
+	// The interface is created in the host namespace, then moved and renamed by koko
+	tmpName := fmt.Sprintf("vx6%d", vxlan.ID)
+	if err := netlink.LinkAdd(&netlink.Vxlan{
+		LinkAttrs:    netlink.LinkAttrs{Name: tmpName, TxQLen: 1000},
+		VxlanId:      vxlan.ID,
+		VtepDevIndex: parent.Attrs().Index,
+		SrcAddr:      local,
+		Group:        vxlan.IPAddr,
+		Port:         vxlanPort,
+		Learning:     true,
+		L2miss:       true,
+		L3miss:       true,
+	}); err != nil {
+		return fmt.Errorf(" MESHNETD: Error when creating a VXLAN interface over IPv6: %s", err)
+	}
+	created, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return err
+	}
+	if err := veth.SetVethLink(created); err != nil {
+		netlink.LinkDel(created)
+		return fmt.Errorf(" MESHNETD: Error when moving VXLAN interface to %s: %s", veth.NsName, err)
+	}
+	return nil
+}
+
+// underlayLocal returns the source address of the underlay towards peerVtep if it's an
+// IPv6 address, or nil for IPv4 underlays, whose source is left to the kernel
+func underlayLocal(srcIP, peerVtep string) net.IP {
+	if ip := net.ParseIP(peerVtep); ip == nil || ip.To4() != nil {
+		return nil
+	}
+	return net.ParseIP(srcIP)
+}
+
 // getLinkFromNS retrieves netlink.Link from NetNS
 func getLinkFromNS(nsName string, linkName string) netlink.Link {
 	// If namespace doesn't exist, do nothing and return empty result
@@ -123,12 +186,16 @@ func getLinkFromNS(nsName string, linkName string) netlink.Link {
 	return result
 }
 
-// Uses netlink to query the IP and LinkName of the interface with default route
-func getSource() (string, string, error) {
-	// Looking up a default route to get the intf and IP for vxlan
-	r, err := netlink.RouteGet(net.IPv4(1, 1, 1, 1))
-	if (err != nil) && len(r) < 1 {
-		return "", "", fmt.Errorf(" MESHNETD: Error getting default route: %s\n%+v", err, r)
+// Uses netlink to query the IP and LinkName of the interface of the route to peerVtep,
+// which may be an IPv4 or an IPv6 address
+func getSource(peerVtep string) (string, string, error) {
+	dst := net.ParseIP(peerVtep)
+	if dst == nil {
+		return "", "", fmt.Errorf(" MESHNETD: Invalid VTEP address %q", peerVtep)
+	}
+	r, err := netlink.RouteGet(dst)
+	if err != nil || len(r) < 1 {
+		return "", "", fmt.Errorf(" MESHNETD: Error getting the route to %s: %v", peerVtep, err)
 	}
 	srcIP := r[0].Src.String()
 
@@ -4,8 +4,12 @@ import (
 	"net"
 	"testing"
 
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
 	"github.com/redhat-nfvpe/koko/api"
 	"github.com/vishvananda/netlink"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
 )
 
 func TestVxlan(t *testing.T) {
@@ -47,6 +51,17 @@ func TestVxlan(t *testing.T) {
 			},
 			same: false,
 		},
+		{
+			expected: api.VxLan{
+				ID:     5001,
+				IPAddr: net.ParseIP("::1"),
+			},
+			found: &netlink.Vxlan{
+				VxlanId: 5001,
+				Group:   net.ParseIP("0:0:0:0:0:0:0:1"),
+			},
+			same: true,
+		},
 	}
 	for i, tt := range tests {
 		result := vxlanDifferent(tt.found, tt.expected)
@@ -55,3 +70,102 @@ func TestVxlan(t *testing.T) {
 		}
 	}
 }
+
+func TestGetSource(t *testing.T) {
+	tests := []struct {
+		vtep    string
+		wantErr bool
+	}{
+		{vtep: "127.0.0.1"},
+		{vtep: "::1"},
+		{vtep: "[::1]", wantErr: true},
+		{vtep: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, intf, err := getSource(tt.vtep)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("getSource(%q) didn't fail", tt.vtep)
+			}
+			continue
+		}
+		if err != nil {
+			t.Logf("getSource(%q) failed, the address may not be configured: %v", tt.vtep, err)
+			continue
+		}
+		if intf != "lo" {
+			t.Errorf("getSource(%q) = %s, want lo", tt.vtep, intf)
+		}
+	}
+}
+
+func TestUnderlayLocal(t *testing.T) {
+	tests := []struct {
+		src, vtep string
+		want      net.IP
+	}{
+		{src: "10.0.0.1", vtep: "10.0.0.2", want: nil},
+		{src: "2001:db8::1", vtep: "2001:db8::2", want: net.ParseIP("2001:db8::1")},
+		{src: "", vtep: "not an IP", want: nil},
+	}
+	for _, tt := range tests {
+		if got := underlayLocal(tt.src, tt.vtep); !got.Equal(tt.want) {
+			t.Errorf("underlayLocal(%q, %q) = %s, want %s", tt.src, tt.vtep, got, tt.want)
+		}
+	}
+}
+
+// TestCreateOrUpdateDualStack sets up a wire to an IPv4 VTEP and one to an IPv6 VTEP from
+// the same node, whose loopback has both address families
+func TestCreateOrUpdateDualStack(t *testing.T) {
+	node, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("failed to create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(node)
+	defer node.Close()
+	pod, err := testutils.NewNS()
+	if err != nil {
+		t.Skipf("failed to create a netns: %v", err)
+	}
+	defer testutils.UnmountNS(pod)
+	defer pod.Close()
+
+	err = node.Do(func(_ ns.NetNS) error {
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(lo)
+	})
+	if err != nil {
+		t.Skipf("failed to set up the loopback: %v", err)
+	}
+
+	tests := []struct {
+		intf, vtep string
+		vni        int64
+		local      net.IP
+	}{
+		{intf: "eth1", vtep: "127.0.0.1", vni: 5001},
+		{intf: "eth2", vtep: "::1", vni: 5002, local: net.ParseIP("::1")},
+	}
+	for _, tt := range tests {
+		err := node.Do(func(_ ns.NetNS) error {
+			return createOrUpdate(&mpb.RemotePod{NetNs: pod.Path(), IntfName: tt.intf, PeerVtep: tt.vtep, Vni: tt.vni})
+		})
+		if err != nil {
+			t.Fatalf("createOrUpdate(%s) failed: %v", tt.vtep, err)
+		}
+		vx, ok := getLinkFromNS(pod.Path(), tt.intf).(*netlink.Vxlan)
+		if !ok {
+			t.Fatalf("%s is not a VXLAN interface", tt.intf)
+		}
+		if int64(vx.VxlanId) != tt.vni || !vx.Group.Equal(net.ParseIP(tt.vtep)) {
+			t.Errorf("%s has VNI %d and remote %s, want %d and %s", tt.intf, vx.VxlanId, vx.Group, tt.vni, tt.vtep)
+		}
+		if tt.local != nil && !vx.SrcAddr.Equal(tt.local) {
+			t.Errorf("%s has local %s, want %s", tt.intf, vx.SrcAddr, tt.local)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package wireguard
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// generic netlink API of WireGuard, from include/uapi/linux/wireguard.h
+const (
+	genlName    = "wireguard"
+	genlVersion = 1
+
+	cmdSetDevice = 1
+
+	deviceIfname     = 2
+	devicePrivateKey = 3
+	deviceFlags      = 5
+	deviceListenPort = 6
+	devicePeers      = 8
+
+	deviceReplacePeers = 1
+
+	peerPublicKey         = 1
+	peerPresharedKey      = 2
+	peerFlags             = 3
+	peerEndpoint          = 4
+	peerKeepaliveInterval = 5
+	peerAllowedIPs        = 9
+
+	peerReplaceAllowedIPs = 2
+
+	allowedIPFamily   = 1
+	allowedIPAddr     = 2
+	allowedIPCIDRMask = 3
+)
+
+// device is the configuration of a WireGuard interface, nil fields are left unchanged
+type device struct {
+	privateKey *Key
+	listenPort int
+	// the only peer of the interface, replacing any other
+	peer *peer
+}
+
+// peer is the other end of a link, which accepts all the traffic of the link
+type peer struct {
+	publicKey Key
+	// nil for none
+	presharedKey *Key
+	endpoint     *net.UDPAddr
+}
+
+// configure applies dev to the WireGuard interface name of the current netns
+func configure(name string, dev *device) error {
+	family, err := netlink.GenlFamilyGet(genlName)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: WireGuard isn't supported by the kernel: %s", err)
+	}
+	req := nl.NewNetlinkRequest(int(family.ID), unix.NLM_F_ACK)
+	req.AddData(&nl.Genlmsg{Command: cmdSetDevice, Version: genlVersion})
+	for _, attr := range deviceAttrs(name, dev) {
+		req.AddData(attr)
+	}
+	if _, err := req.Execute(unix.NETLINK_GENERIC, 0); err != nil {
+		return fmt.Errorf(" MESHNETD: failed to configure WireGuard interface %s: %s", name, err)
+	}
+	return nil
+}
+
+// deviceAttrs builds the attributes of a WG_CMD_SET_DEVICE request
+func deviceAttrs(name string, dev *device) []*nl.RtAttr {
+	attrs := []*nl.RtAttr{nl.NewRtAttr(deviceIfname, nl.ZeroTerminated(name))}
+	if dev.privateKey != nil {
+		attrs = append(attrs, nl.NewRtAttr(devicePrivateKey, dev.privateKey[:]))
+	}
+	if dev.listenPort != 0 {
+		attrs = append(attrs, nl.NewRtAttr(deviceListenPort, nl.Uint16Attr(uint16(dev.listenPort))))
+	}
+	if dev.peer == nil {
+		return attrs
+	}
+	attrs = append(attrs, nl.NewRtAttr(deviceFlags, nl.Uint32Attr(deviceReplacePeers)))
+
+	peers := nl.NewRtAttr(devicePeers|int(nl.NLA_F_NESTED), nil)
+	p := peers.AddRtAttr(0|int(nl.NLA_F_NESTED), nil)
+	p.AddRtAttr(peerPublicKey, dev.peer.publicKey[:])
+	if dev.peer.presharedKey != nil {
+		p.AddRtAttr(peerPresharedKey, dev.peer.presharedKey[:])
+	}
+	p.AddRtAttr(peerFlags, nl.Uint32Attr(peerReplaceAllowedIPs))
+	if dev.peer.endpoint != nil {
+		p.AddRtAttr(peerEndpoint, sockaddr(dev.peer.endpoint))
+	}
+	p.AddRtAttr(peerKeepaliveInterval, nl.Uint16Attr(keepalive))
+	// the link is point-to-point, everything sent over it goes to the peer
+	allowed := p.AddRtAttr(peerAllowedIPs|int(nl.NLA_F_NESTED), nil)
+	for i, prefix := range []*net.IPNet{
+		{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, 32)},
+		{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)},
+	} {
+		ip := allowed.AddRtAttr(i|int(nl.NLA_F_NESTED), nil)
+		family := uint16(unix.AF_INET6)
+		if prefix.IP.To4() != nil {
+			family = unix.AF_INET
+		}
+		ones, _ := prefix.Mask.Size()
+		ip.AddRtAttr(allowedIPFamily, nl.Uint16Attr(family))
+		ip.AddRtAttr(allowedIPAddr, []byte(prefix.IP))
+		ip.AddRtAttr(allowedIPCIDRMask, nl.Uint8Attr(uint8(ones)))
+	}
+	return append(attrs, peers)
+}
+
+// sockaddr encodes addr as a struct sockaddr_in or sockaddr_in6
+func sockaddr(addr *net.UDPAddr) []byte {
+	if ip4 := addr.IP.To4(); ip4 != nil {
+		b := make([]byte, unix.SizeofSockaddrInet4)
+		nl.NativeEndian().PutUint16(b[0:2], unix.AF_INET)
+		binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+		copy(b[4:8], ip4)
+		return b
+	}
+	b := make([]byte, unix.SizeofSockaddrInet6)
+	nl.NativeEndian().PutUint16(b[0:2], unix.AF_INET6)
+	binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+	copy(b[8:24], addr.IP.To16())
+	return b
+}
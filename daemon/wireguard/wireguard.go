@@ -0,0 +1,315 @@
+// Package wireguard encrypts remote links with WireGuard interfaces. The interface of a link
+// is created in the host namespace, where its UDP socket stays, then moved into the pod.
+// The links of a node use the node's key pair, unless they have keys of their own.
+package wireguard
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	koko "github.com/redhat-nfvpe/koko/api"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/crypto/curve25519"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+const (
+	// the UDP port of a link is its VNI above PortBase, the same on both nodes
+	PortBase  = 40000
+	keepalive = 25
+	linkType  = "wireguard"
+)
+
+// Key is a Curve25519 private or public key
+type Key [32]byte
+
+// GenerateKey returns a new private key, clamped as wg(8) does
+func GenerateKey() (Key, error) {
+	var k Key
+	if _, err := rand.Read(k[:]); err != nil {
+		return Key{}, err
+	}
+	k[0] &= 248
+	k[31] = (k[31] & 127) | 64
+	return k, nil
+}
+
+// PublicKey returns the public key of the private key k
+func (k Key) PublicKey() Key {
+	var pub Key
+	curve25519.ScalarBaseMult((*[32]byte)(&pub), (*[32]byte)(&k))
+	return pub
+}
+
+// String returns k in base64, as used by wg(8)
+func (k Key) String() string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
+
+// ParseKey parses a base64 key
+func ParseKey(s string) (Key, error) {
+	var k Key
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) != len(k) {
+		// the key may be private, so it isn't part of the error
+		return k, fmt.Errorf("invalid WireGuard key")
+	}
+	copy(k[:], b)
+	return k, nil
+}
+
+// Port returns the UDP port of the link with vni
+func Port(vni int64) (int, error) {
+	port := PortBase + vni
+	if vni <= 0 || port > 65535 {
+		return 0, fmt.Errorf("VNI %d is out of the WireGuard port range", vni)
+	}
+	return int(port), nil
+}
+
+// tunnel is a WireGuard link set up on this node
+type tunnel struct {
+	netNs, intf string
+	peerVtep    string
+	port        int
+	peerKey     Key
+	// the tunnel has keys of its own, which neither SetKey nor SyncPeers change
+	linkKeys bool
+}
+
+var (
+	mu sync.Mutex
+	// the private key of the node, nil until SetKey
+	nodeKey *Key
+	// tunnels keyed by netns and interface
+	tunnels = make(map[string]*tunnel)
+)
+
+func tunnelKey(netNs, intf string) string {
+	return netNs + "/" + intf
+}
+
+// SetKey sets the private key of the node, re-keying the interfaces of the existing tunnels
+func SetKey(k Key) error {
+	mu.Lock()
+	defer mu.Unlock()
+	nodeKey = &k
+	var failed error
+	for _, t := range tunnels {
+		if t.linkKeys {
+			continue
+		}
+		err := inNS(t.netNs, func() error {
+			return configure(t.intf, &device{privateKey: &k})
+		})
+		if err != nil {
+			log.Errorf("Failed to re-key WireGuard interface %s in %s: %v", t.intf, t.netNs, err)
+			failed = err
+		}
+	}
+	return failed
+}
+
+// CreateOrUpdate sets up the WireGuard interface of a link to the pod behind pod.PeerVtep,
+// with the keys of pod.Credentials, or else with the key of the node and pod.WgPublicKey,
+// the public key of the peer's node
+func CreateOrUpdate(pod *mpb.RemotePod) error {
+	privateKey, p, err := linkKeys(pod)
+	if err != nil {
+		return err
+	}
+	port, err := Port(pod.Vni)
+	if err != nil {
+		return err
+	}
+	peerIP := net.ParseIP(pod.PeerVtep)
+	if peerIP == nil {
+		return fmt.Errorf(" MESHNETD: invalid peer VTEP %q", pod.PeerVtep)
+	}
+	veth := koko.VEth{NsName: pod.NetNs, LinkName: pod.IntfName}
+	if pod.IntfIp != "" {
+		ip, subnet, err := net.ParseCIDR(pod.IntfIp)
+		if err != nil {
+			return fmt.Errorf(" MESHNETD: Error parsing CIDR %s: %s", pod.IntfIp, err)
+		}
+		veth.IPAddr = []net.IPNet{{IP: ip, Mask: subnet.Mask}}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if privateKey == nil && nodeKey == nil {
+		return fmt.Errorf(" MESHNETD: the WireGuard key of the node hasn't been generated yet")
+	}
+	if privateKey == nil {
+		privateKey = nodeKey
+	}
+	p.endpoint = &net.UDPAddr{IP: peerIP, Port: port}
+	dev := &device{
+		privateKey: privateKey,
+		listenPort: port,
+		peer:       p,
+	}
+
+	existing, err := existingType(pod.NetNs, pod.IntfName)
+	if err != nil {
+		return err
+	}
+	switch existing {
+	case linkType:
+		// the socket of the interface is still bound to the port of the host namespace
+		log.Infof("Updating WireGuard link %s in %s", pod.IntfName, pod.NetNs)
+		if err := inNS(pod.NetNs, func() error { return configure(pod.IntfName, dev) }); err != nil {
+			return err
+		}
+	case "":
+		if err := create(veth, dev, fmt.Sprintf("wg%d", pod.Vni)); err != nil {
+			return err
+		}
+	default:
+		log.Infof("Removing %s link %s before re-creating it as WireGuard", existing, pod.IntfName)
+		if err := veth.RemoveVethLink(); err != nil {
+			return fmt.Errorf(" MESHNETD: Error when removing an old interface with koko: %s", err)
+		}
+		if err := create(veth, dev, fmt.Sprintf("wg%d", pod.Vni)); err != nil {
+			return err
+		}
+	}
+	tunnels[tunnelKey(pod.NetNs, pod.IntfName)] = &tunnel{
+		netNs:    pod.NetNs,
+		intf:     pod.IntfName,
+		peerVtep: pod.PeerVtep,
+		port:     port,
+		peerKey:  p.publicKey,
+		linkKeys: pod.Credentials != nil,
+	}
+	return nil
+}
+
+// linkKeys returns the private key and the peer of the link of pod. The private key is nil
+// when the link uses the key of the node. Errors don't contain the keys.
+func linkKeys(pod *mpb.RemotePod) (*Key, *peer, error) {
+	c := pod.Credentials
+	if c == nil {
+		peerKey, err := ParseKey(pod.WgPublicKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf(" MESHNETD: no public key of node %s: %s", pod.PeerVtep, err)
+		}
+		return nil, &peer{publicKey: peerKey}, nil
+	}
+	privateKey, err := ParseKey(c.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf(" MESHNETD: invalid private key of link %s: %s", pod.IntfName, err)
+	}
+	peerKey, err := ParseKey(c.PeerPublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf(" MESHNETD: invalid peer public key of link %s: %s", pod.IntfName, err)
+	}
+	p := &peer{publicKey: peerKey}
+	if c.PresharedKey != "" {
+		psk, err := ParseKey(c.PresharedKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf(" MESHNETD: invalid preshared key of link %s: %s", pod.IntfName, err)
+		}
+		p.presharedKey = &psk
+	}
+	return &privateKey, p, nil
+}
+
+// create creates the interface in the host namespace, where its socket is bound, then
+// moves it into the pod
+func create(veth koko.VEth, dev *device, tmpName string) error {
+	link := &netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Name: tmpName}, LinkType: linkType}
+	if err := netlink.LinkAdd(link); err != nil {
+		return fmt.Errorf(" MESHNETD: Error when creating a WireGuard interface, is the wireguard module loaded? %s", err)
+	}
+	created, err := netlink.LinkByName(tmpName)
+	if err != nil {
+		return err
+	}
+	if err := configure(tmpName, dev); err != nil {
+		netlink.LinkDel(created)
+		return err
+	}
+	if err := veth.SetVethLink(created); err != nil {
+		netlink.LinkDel(created)
+		return fmt.Errorf(" MESHNETD: Error when moving WireGuard interface to %s: %s", veth.NsName, err)
+	}
+	return nil
+}
+
+// existingType returns the type of the interface intf in netNs, empty if there is none
+func existingType(netNs, intf string) (string, error) {
+	var result string
+	err := inNS(netNs, func() error {
+		if link, err := netlink.LinkByName(intf); err == nil {
+			result = link.Type()
+		}
+		return nil
+	})
+	return result, err
+}
+
+func inNS(netNs string, fn func() error) error {
+	podNs, err := ns.GetNS(netNs)
+	if err != nil {
+		return fmt.Errorf(" MESHNETD: failed to open netns %s: %s", netNs, err)
+	}
+	defer podNs.Close()
+	return podNs.Do(func(ns.NetNS) error { return fn() })
+}
+
+// SyncPeers updates the peer keys of the tunnels whose peer node has a new key, as returned
+// by lookup, and forgets the tunnels of pods that are gone
+func SyncPeers(lookup func(nodeIP string) (Key, error)) {
+	mu.Lock()
+	vteps := make(map[string]bool)
+	for k, t := range tunnels {
+		if _, err := os.Stat(t.netNs); err != nil {
+			delete(tunnels, k)
+			continue
+		}
+		vteps[t.peerVtep] = true
+	}
+	mu.Unlock()
+
+	keys := make(map[string]Key)
+	for vtep := range vteps {
+		key, err := lookup(vtep)
+		if err != nil {
+			log.Warnf("Failed to look up the WireGuard key of node %s: %v", vtep, err)
+			continue
+		}
+		keys[vtep] = key
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, t := range tunnels {
+		if t.linkKeys {
+			continue
+		}
+		peerKey, ok := keys[t.peerVtep]
+		if !ok || peerKey == t.peerKey {
+			continue
+		}
+		log.Infof("Node %s has a new WireGuard key, updating %s in %s", t.peerVtep, t.intf, t.netNs)
+		err := inNS(t.netNs, func() error {
+			return configure(t.intf, &device{peer: &peer{
+				publicKey: peerKey,
+				endpoint:  &net.UDPAddr{IP: net.ParseIP(t.peerVtep), Port: t.port},
+			}})
+		})
+		if err != nil {
+			log.Errorf("Failed to update the peer of WireGuard interface %s: %v", t.intf, err)
+			continue
+		}
+		t.peerKey = peerKey
+	}
+}
@@ -0,0 +1,152 @@
+package wireguard
+
+import (
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+func TestKey(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := priv.PublicKey()
+	if pub == priv || pub == (Key{}) {
+		t.Errorf("PublicKey() = %s, want a key other than the private one", pub)
+	}
+	parsed, err := ParseKey(pub.String())
+	if err != nil || parsed != pub {
+		t.Errorf("ParseKey(%s) = %s, %v", pub, parsed, err)
+	}
+	for _, s := range []string{"", "not base64", "AAAA"} {
+		if _, err := ParseKey(s); err == nil {
+			t.Errorf("ParseKey(%q) didn't fail", s)
+		}
+	}
+
+	// Alice's key pair of RFC 7748, section 6.1
+	var alice Key
+	if _, err := hex.Decode(alice[:], []byte("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")); err != nil {
+		t.Fatal(err)
+	}
+	want := "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"
+	if got := alice.PublicKey(); hex.EncodeToString(got[:]) != want {
+		t.Errorf("PublicKey() = %x, want %s", got, want)
+	}
+}
+
+func TestPort(t *testing.T) {
+	tests := []struct {
+		vni  int64
+		want int
+		err  bool
+	}{
+		{vni: 5001, want: 45001},
+		{vni: 25535, want: 65535},
+		{vni: 25536, err: true},
+		{vni: 0, err: true},
+	}
+	for i, tt := range tests {
+		got, err := Port(tt.vni)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("#%d test failed: Port(%d) = %d, %v", i, tt.vni, got, err)
+		}
+	}
+}
+
+func TestSockaddr(t *testing.T) {
+	b := sockaddr(&net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 45001})
+	if len(b) != unix.SizeofSockaddrInet4 || b[2] != 0xaf || b[3] != 0xc9 || !net.IP(b[4:8]).Equal(net.ParseIP("192.0.2.1")) {
+		t.Errorf("sockaddr() of IPv4 = %v", b)
+	}
+	b = sockaddr(&net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 45001})
+	if len(b) != unix.SizeofSockaddrInet6 || !net.IP(b[8:24]).Equal(net.ParseIP("2001:db8::1")) {
+		t.Errorf("sockaddr() of IPv6 = %v", b)
+	}
+}
+
+func TestDeviceAttrs(t *testing.T) {
+	k := Key{1}
+	tests := []struct {
+		dev  *device
+		want int
+	}{
+		// interface name only
+		{dev: &device{}, want: 1},
+		{dev: &device{privateKey: &k}, want: 2},
+		// name, key, port, flags and peers
+		{dev: &device{privateKey: &k, listenPort: 45001, peer: &peer{publicKey: k}}, want: 5},
+	}
+	for i, tt := range tests {
+		if got := deviceAttrs("eth1", tt.dev); len(got) != tt.want {
+			t.Errorf("#%d test failed: deviceAttrs() has %d attributes, want %d", i, len(got), tt.want)
+		}
+	}
+}
+
+func TestCreateOrUpdateErrors(t *testing.T) {
+	k, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	nodeKey = nil
+	mu.Unlock()
+	tests := []*mpb.RemotePod{
+		{PeerVtep: "192.0.2.1", Vni: 5001},
+		{PeerVtep: "192.0.2.1", Vni: 0, WgPublicKey: k.PublicKey().String()},
+		{PeerVtep: "node1", Vni: 5001, WgPublicKey: k.PublicKey().String()},
+		{PeerVtep: "192.0.2.1", Vni: 5001, WgPublicKey: k.PublicKey().String(), IntfIp: "10.0.0.1"},
+		// the key of the node hasn't been set
+		{PeerVtep: "192.0.2.1", Vni: 5001, WgPublicKey: k.PublicKey().String()},
+	}
+	for i, pod := range tests {
+		if err := CreateOrUpdate(pod); err == nil {
+			t.Errorf("#%d test failed: CreateOrUpdate() didn't fail", i)
+		}
+	}
+}
+
+func TestLinkKeys(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := priv.PublicKey()
+	tests := []struct {
+		pod     *mpb.RemotePod
+		private bool
+		psk     bool
+		err     bool
+	}{
+		{pod: &mpb.RemotePod{WgPublicKey: pub.String()}},
+		{pod: &mpb.RemotePod{WgPublicKey: priv.String()[1:]}, err: true},
+		{pod: &mpb.RemotePod{Credentials: &mpb.LinkCredentials{PrivateKey: priv.String(), PeerPublicKey: pub.String()}}, private: true},
+		{pod: &mpb.RemotePod{Credentials: &mpb.LinkCredentials{PrivateKey: priv.String(), PeerPublicKey: pub.String(), PresharedKey: priv.String()}}, private: true, psk: true},
+		{pod: &mpb.RemotePod{Credentials: &mpb.LinkCredentials{PrivateKey: priv.String()[1:], PeerPublicKey: pub.String()}}, err: true},
+		{pod: &mpb.RemotePod{Credentials: &mpb.LinkCredentials{PrivateKey: priv.String(), PeerPublicKey: pub.String(), PresharedKey: priv.String()[1:]}}, err: true},
+	}
+	for i, tt := range tests {
+		private, p, err := linkKeys(tt.pod)
+		if err != nil {
+			if !tt.err {
+				t.Errorf("#%d test failed: linkKeys() = %v", i, err)
+			} else if strings.Contains(err.Error(), priv.String()[1:]) {
+				t.Errorf("#%d test failed: linkKeys() error contains the private key", i)
+			}
+			continue
+		}
+		if tt.err || (private != nil) != tt.private || (p.presharedKey != nil) != tt.psk || p.publicKey != pub {
+			t.Errorf("#%d test failed: linkKeys() returned the wrong keys", i)
+		}
+		if tt.private && *private != priv {
+			t.Errorf("#%d test failed: linkKeys() returned the wrong private key", i)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+)
+
+// maxPriority is the highest score an extender can give to a node
+const maxPriority = 10
+
+// extenderArgs, extenderFilterResult and hostPriority mirror the JSON
+// representation of the scheduler extender API from k8s.io/kube-scheduler
+type extenderArgs struct {
+	Pod       *corev1.Pod      `json:"pod"`
+	Nodes     *corev1.NodeList `json:"nodes,omitempty"`
+	NodeNames *[]string        `json:"nodenames,omitempty"`
+}
+
+type extenderFilterResult struct {
+	Nodes       *corev1.NodeList  `json:"nodes,omitempty"`
+	NodeNames   *[]string         `json:"nodenames,omitempty"`
+	FailedNodes map[string]string `json:"failedNodes,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+type hostPriority struct {
+	Host  string `json:"host"`
+	Score int64  `json:"score"`
+}
+
+type extender struct {
+	kClient         kubernetes.Interface
+	tClient         topologyclientv1.Interface
+	daemonNamespace string
+	daemonSelector  string
+}
+
+// filter removes nodes that don't run a meshnet daemon, since topology links can't be set up there
+func (e *extender) filter(ctx context.Context, args *extenderArgs) *extenderFilterResult {
+	result := &extenderFilterResult{
+		Nodes:       &corev1.NodeList{},
+		FailedNodes: map[string]string{},
+	}
+	if args.Nodes == nil {
+		result.Error = "extender requires full node objects, set nodeCacheCapable to false"
+		return result
+	}
+
+	daemons, err := e.kClient.CoreV1().Pods(e.daemonNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: e.daemonSelector,
+	})
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to list meshnet daemons: %v", err)
+		return result
+	}
+	ready := make(map[string]bool)
+	for _, daemon := range daemons.Items {
+		if daemon.Status.Phase == corev1.PodRunning {
+			ready[daemon.Spec.NodeName] = true
+		}
+	}
+
+	for _, node := range args.Nodes.Items {
+		if !ready[node.Name] {
+			result.FailedNodes[node.Name] = "meshnet daemon is not running"
+			continue
+		}
+		result.Nodes.Items = append(result.Nodes.Items, node)
+	}
+	return result
+}
+
+// prioritize scores nodes by the number of the pod's topology peers already running there
+func (e *extender) prioritize(ctx context.Context, args *extenderArgs) []hostPriority {
+	if args.Nodes == nil {
+		return nil
+	}
+	peerNodes := e.peerNodeIPs(ctx, args.Pod)
+
+	scores := make([]hostPriority, len(args.Nodes.Items))
+	var maxPeers int64
+	for i, node := range args.Nodes.Items {
+		scores[i].Host = node.Name
+		for _, addr := range node.Status.Addresses {
+			if addr.Type == corev1.NodeInternalIP {
+				scores[i].Score += peerNodes[addr.Address]
+			}
+		}
+		if scores[i].Score > maxPeers {
+			maxPeers = scores[i].Score
+		}
+	}
+
+	// Normalising the number of co-located peers to the extender priority range
+	if maxPeers > 0 {
+		for i := range scores {
+			scores[i].Score = scores[i].Score * maxPriority / maxPeers
+		}
+	}
+	return scores
+}
+
+// peerNodeIPs returns the number of the pod's peers alive on each node IP
+func (e *extender) peerNodeIPs(ctx context.Context, pod *corev1.Pod) map[string]int64 {
+	result := make(map[string]int64)
+	if pod == nil {
+		return result
+	}
+
+	topology, err := e.tClient.Topology(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Infof("Pod %s/%s is not a topology pod", pod.Namespace, pod.Name)
+		return result
+	}
+
+	for _, link := range topology.Spec.Links {
+		peer, err := e.tClient.Topology(pod.Namespace).Get(ctx, link.PeerPod, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		// src_ip is set to the node IP by the meshnet daemon when the peer is alive
+		if peer.Status.SrcIp != "" {
+			result[peer.Status.SrcIp]++
+		}
+	}
+	return result
+}
+
+func (e *extender) handleFilter(w http.ResponseWriter, r *http.Request) {
+	args := &extenderArgs{}
+	if err := json.NewDecoder(r.Body).Decode(args); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, e.filter(r.Context(), args))
+}
+
+func (e *extender) handlePrioritize(w http.ResponseWriter, r *http.Request) {
+	args := &extenderArgs{}
+	if err := json.NewDecoder(r.Body).Decode(args); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, e.prioritize(r.Context(), args))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("Failed to encode extender response: %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+// topologies is a topology clientset that only serves the Get of its topologies
+type topologies struct {
+	topologyclientv1.Interface
+	items []*topologyv1.Topology
+}
+
+func (t *topologies) Topology(namespace string) topologyclientv1.TopologyInterface {
+	return &topologyGetter{t: t, ns: namespace}
+}
+
+type topologyGetter struct {
+	topologyclientv1.TopologyInterface
+	t  *topologies
+	ns string
+}
+
+func (g *topologyGetter) Get(ctx context.Context, name string, opts metav1.GetOptions) (*topologyv1.Topology, error) {
+	for _, topology := range g.t.items {
+		if topology.Namespace == g.ns && topology.Name == name {
+			return topology, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Group: topologyv1.GroupName, Resource: "topologies"}, name)
+}
+
+// topology returns the topology of pod in namespace lab with a link of each of uids to
+// peers, alive on the node nodeIP unless it's empty
+func topology(pod, nodeIP string, peers []string, uids []int) *topologyv1.Topology {
+	t := &topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: pod, Namespace: "lab"}}
+	for i, peer := range peers {
+		t.Spec.Links = append(t.Spec.Links, topologyv1.Link{UID: uids[i], PeerPod: peer})
+	}
+	t.Status.SrcIp = nodeIP
+	return t
+}
+
+func daemon(name, node string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "meshnet", Labels: map[string]string{"name": "meshnet"}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func nodes(ips map[string]string, names ...string) *corev1.NodeList {
+	list := &corev1.NodeList{}
+	for _, name := range names {
+		node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if ip, ok := ips[name]; ok {
+			node.Status.Addresses = []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: name},
+				{Type: corev1.NodeInternalIP, Address: ip},
+			}
+		}
+		list.Items = append(list.Items, node)
+	}
+	return list
+}
+
+func nodeNames(list *corev1.NodeList) []string {
+	var result []string
+	for _, n := range list.Items {
+		result = append(result, n.Name)
+	}
+	return result
+}
+
+func TestFilter(t *testing.T) {
+	e := &extender{
+		kClient: fake.NewSimpleClientset(
+			daemon("meshnet-1", "n1", corev1.PodRunning),
+			daemon("meshnet-2", "n2", corev1.PodPending),
+			// a pod of another daemonset isn't a meshnet daemon
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "meshnet"},
+				Spec:       corev1.PodSpec{NodeName: "n3"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		),
+		daemonNamespace: "meshnet",
+		daemonSelector:  "name=meshnet",
+	}
+	tests := []struct {
+		desc   string
+		nodes  *corev1.NodeList
+		want   []string
+		failed map[string]string
+		err    bool
+	}{
+		{
+			desc:  "running daemon",
+			nodes: nodes(nil, "n1"),
+			want:  []string{"n1"},
+		},
+		{
+			desc:  "pending daemon and no daemon",
+			nodes: nodes(nil, "n1", "n2", "n3", "n4"),
+			want:  []string{"n1"},
+			failed: map[string]string{
+				"n2": "meshnet daemon is not running",
+				"n3": "meshnet daemon is not running",
+				"n4": "meshnet daemon is not running",
+			},
+		},
+		{
+			desc:  "node names only",
+			nodes: nil,
+			err:   true,
+		},
+	}
+	for _, tt := range tests {
+		result := e.filter(context.Background(), &extenderArgs{Pod: &corev1.Pod{}, Nodes: tt.nodes})
+		if (result.Error != "") != tt.err {
+			t.Errorf("%s: filter() error = %q, want an error: %t", tt.desc, result.Error, tt.err)
+			continue
+		}
+		if tt.err {
+			continue
+		}
+		if got := nodeNames(result.Nodes); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: filter() nodes = %v, want %v", tt.desc, got, tt.want)
+		}
+		if tt.failed == nil {
+			tt.failed = map[string]string{}
+		}
+		if !reflect.DeepEqual(result.FailedNodes, tt.failed) {
+			t.Errorf("%s: filter() failed nodes = %v, want %v", tt.desc, result.FailedNodes, tt.failed)
+		}
+	}
+}
+
+func TestPrioritize(t *testing.T) {
+	e := &extender{
+		tClient: &topologies{items: []*topologyv1.Topology{
+			topology("r1", "", []string{"r2", "r3", "r4", "r5"}, []int{1, 2, 3, 4}),
+			topology("r2", "10.0.0.1", []string{"r1"}, []int{1}),
+			topology("r3", "10.0.0.1", []string{"r1"}, []int{2}),
+			topology("r4", "10.0.0.2", []string{"r1"}, []int{3}),
+			// r5 isn't alive, and its node doesn't count
+			topology("r5", "", []string{"r1"}, []int{4}),
+			topology("r6", "", nil, nil),
+		}},
+	}
+	ips := map[string]string{"n1": "10.0.0.1", "n2": "10.0.0.2", "n3": "10.0.0.3"}
+	tests := []struct {
+		desc  string
+		pod   string
+		nodes *corev1.NodeList
+		want  []hostPriority
+	}{
+		{
+			desc:  "peers on two nodes",
+			pod:   "r1",
+			nodes: nodes(ips, "n1", "n2", "n3"),
+			want:  []hostPriority{{Host: "n1", Score: 10}, {Host: "n2", Score: 5}, {Host: "n3", Score: 0}},
+		},
+		{
+			desc:  "node without an internal IP",
+			pod:   "r1",
+			nodes: nodes(ips, "n2", "n4"),
+			want:  []hostPriority{{Host: "n2", Score: 10}, {Host: "n4", Score: 0}},
+		},
+		{
+			desc:  "no alive peer",
+			pod:   "r5",
+			nodes: nodes(ips, "n1", "n2"),
+			want:  []hostPriority{{Host: "n1", Score: 0}, {Host: "n2", Score: 0}},
+		},
+		{
+			desc:  "no links",
+			pod:   "r6",
+			nodes: nodes(ips, "n1"),
+			want:  []hostPriority{{Host: "n1", Score: 0}},
+		},
+		{
+			desc:  "not a topology pod",
+			pod:   "web",
+			nodes: nodes(ips, "n1"),
+			want:  []hostPriority{{Host: "n1", Score: 0}},
+		},
+		{
+			desc: "node names only",
+			pod:  "r1",
+		},
+	}
+	for _, tt := range tests {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: tt.pod, Namespace: "lab"}}
+		got := e.prioritize(context.Background(), &extenderArgs{Pod: pod, Nodes: tt.nodes})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: prioritize() = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
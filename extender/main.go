@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+)
+
+const (
+	defaultAddr        = ":8888"
+	defaultWebhookAddr = ":8443"
+)
+
+func restConfig() (*rest.Config, error) {
+	log.Infof("Trying in-cluster configuration")
+	rCfg, err := rest.InClusterConfig()
+	if err != nil {
+		kubecfg := filepath.Join(".kube", "config")
+		if home := homedir.HomeDir(); home != "" {
+			kubecfg = filepath.Join(home, kubecfg)
+		}
+		log.Infof("Falling back to kubeconfig: %q", kubecfg)
+		rCfg, err = clientcmd.BuildConfigFromFlags("", kubecfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rCfg, nil
+}
+
+func main() {
+	isDebug := flag.Bool("d", false, "enable degugging")
+	addr := flag.String("addr", defaultAddr, "address to serve the scheduler extender on")
+	daemonNamespace := flag.String("daemon-namespace", "meshnet", "namespace of the meshnet daemonset")
+	daemonSelector := flag.String("daemon-selector", "name=meshnet", "label selector of the meshnet daemon pods")
+	webhookAddr := flag.String("webhook-addr", defaultWebhookAddr, "address to serve the admission webhooks on")
+	tlsCert := flag.String("tls-cert", "", "TLS certificate of the admission webhooks, the webhooks are disabled without it")
+	tlsKey := flag.String("tls-key", "", "TLS key of the admission webhooks")
+	flag.Parse()
+	log.SetLevel(log.InfoLevel)
+	if *isDebug {
+		log.SetLevel(log.DebugLevel)
+		log.Debug("Verbose logging enabled")
+	}
+
+	rCfg, err := restConfig()
+	if err != nil {
+		log.Errorf("Failed to build K8s config: %v", err)
+		os.Exit(1)
+	}
+	kClient, err := kubernetes.NewForConfig(rCfg)
+	if err != nil {
+		log.Errorf("Failed to create K8s client: %v", err)
+		os.Exit(1)
+	}
+	tClient, err := topologyclientv1.NewForConfig(rCfg)
+	if err != nil {
+		log.Errorf("Failed to create topology client: %v", err)
+		os.Exit(1)
+	}
+
+	e := &extender{
+		kClient:         kClient,
+		tClient:         tClient,
+		daemonNamespace: *daemonNamespace,
+		daemonSelector:  *daemonSelector,
+	}
+	http.HandleFunc("/filter", e.handleFilter)
+	http.HandleFunc("/prioritize", e.handlePrioritize)
+
+	if *tlsCert != "" && *tlsKey != "" {
+		webhook := http.NewServeMux()
+		webhook.HandleFunc("/mutate", e.handleMutate)
+		webhook.HandleFunc("/validate", e.handleValidate)
+		go func() {
+			log.Infof("Admission webhooks have started on %s", *webhookAddr)
+			if err := http.ListenAndServeTLS(*webhookAddr, *tlsCert, *tlsKey, webhook); err != nil {
+				log.Errorf("Admission webhooks exited badly: %v", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	log.Infof("Scheduler extender has started on %s", *addr)
+	if err := http.ListenAndServe(*addr, nil); err != nil {
+		log.Errorf("Scheduler extender exited badly: %v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+// validate checks that a topology created or updated in its namespace keeps within the
+// MeshnetNamespaceQuota of the namespace, if it has one
+func (e *extender) validate(ctx context.Context, topology *topologyv1.Topology) error {
+	quota, err := e.tClient.NamespaceQuota(ctx, topology.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get the quota of namespace %s: %v", topology.Namespace, err)
+	}
+	if quota == nil {
+		return nil
+	}
+	topologies, err := e.tClient.Topology(topology.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list topologies: %v", err)
+	}
+	return topologyv1.CheckTopology(quota.Spec, topologies.Items, topology)
+}
+
+func (e *extender) handleValidate(w http.ResponseWriter, r *http.Request) {
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+	req := review.Request
+	resp := &admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+	review.Response = resp
+	review.Request = nil
+
+	topology := &topologyv1.Topology{}
+	if err := json.Unmarshal(req.Object.Raw, topology); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if topology.Name == "" {
+		topology.Name = req.Name
+	}
+	if topology.Namespace == "" {
+		topology.Namespace = req.Namespace
+	}
+
+	if err := e.validate(r.Context(), topology); err != nil {
+		log.Infof("Rejecting topology %s/%s: %v", topology.Namespace, topology.Name, err)
+		resp.Allowed = false
+		resp.Result = &metav1.Status{Message: err.Error(), Reason: metav1.StatusReasonForbidden, Code: http.StatusForbidden}
+	}
+	writeJSON(w, review)
+}
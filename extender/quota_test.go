@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func quotaTopology(name string, peers ...string) topologyv1.Topology {
+	t := topologyv1.Topology{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "lab"}}
+	for i, peer := range peers {
+		t.Spec.Links = append(t.Spec.Links, topologyv1.Link{UID: i + 1, PeerPod: peer})
+	}
+	return t
+}
+
+func TestCheckTopology(t *testing.T) {
+	existing := []topologyv1.Topology{quotaTopology("r1", "r2"), quotaTopology("r2", "r1")}
+	tests := []struct {
+		desc     string
+		quota    topologyv1.NamespaceQuotaSpec
+		topology topologyv1.Topology
+		wantErr  bool
+	}{
+		{desc: "no limits", topology: quotaTopology("r3", "r1", "r2")},
+		{desc: "links within the limit", quota: topologyv1.NamespaceQuotaSpec{MaxLinksPerTopology: 2}, topology: quotaTopology("r3", "r1", "r2")},
+		{desc: "too many links", quota: topologyv1.NamespaceQuotaSpec{MaxLinksPerTopology: 1}, topology: quotaTopology("r3", "r1", "r2"), wantErr: true},
+		{desc: "too many topologies", quota: topologyv1.NamespaceQuotaSpec{MaxTopologies: 2}, topology: quotaTopology("r3"), wantErr: true},
+		{desc: "update at the limit", quota: topologyv1.NamespaceQuotaSpec{MaxTopologies: 2}, topology: quotaTopology("r2", "r1")},
+		{desc: "too many wires", quota: topologyv1.NamespaceQuotaSpec{MaxTotalWires: 1}, topology: quotaTopology("r3", "r1"), wantErr: true},
+		{desc: "wires within the limit", quota: topologyv1.NamespaceQuotaSpec{MaxTotalWires: 2}, topology: quotaTopology("r3", "r1")},
+		// the link of r2 is the same wire as the one of r1
+		{desc: "update keeping the wires", quota: topologyv1.NamespaceQuotaSpec{MaxTotalWires: 1}, topology: quotaTopology("r2", "r1")},
+	}
+	for _, tt := range tests {
+		if err := topologyv1.CheckTopology(tt.quota, existing, &tt.topology); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckTopology() = %v, wantErr %t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWires(t *testing.T) {
+	topologies := []topologyv1.Topology{
+		quotaTopology("r1", "r2", "localhost"),
+		quotaTopology("r2", "r1"),
+		// a link only defined on one side is a wire as well
+		quotaTopology("r3", "r4"),
+	}
+	if got := topologyv1.Wires(topologies); got != 3 {
+		t.Errorf("Wires() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+const (
+	// label identifying topology pods in the anti-affinity rules of their peers
+	podLabel    = "meshnet.io/pod"
+	hostnameKey = "kubernetes.io/hostname"
+)
+
+// jsonPatch is a single RFC 6902 operation
+type jsonPatch struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// mutate adds hard anti-affinity rules to a pod that is a member of an anti-affinity group of
+// its topology. It fails if the cluster doesn't have enough nodes to satisfy them.
+func (e *extender) mutate(ctx context.Context, pod *corev1.Pod) ([]jsonPatch, error) {
+	topologies, err := e.tClient.Topology(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topologies: %v", err)
+	}
+	groups := topologyv1.AntiAffinityGroups(topologies.Items, pod.Name)
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	required := 0
+	for _, group := range groups {
+		if len(group) > required {
+			required = len(group)
+		}
+	}
+	nodes, err := e.kClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	schedulable := 0
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable {
+			schedulable++
+		}
+	}
+	peers := topologyv1.AntiAffinePods(topologies.Items, pod.Name)
+	if schedulable < required {
+		return nil, fmt.Errorf("anti-affinity of pod %s with %v needs %d nodes, the cluster has %d schedulable nodes",
+			pod.Name, peers, required, schedulable)
+	}
+	return antiAffinityPatch(pod, peers), nil
+}
+
+// antiAffinityPatch labels pod and keeps it away from the nodes running peers
+func antiAffinityPatch(pod *corev1.Pod, peers []string) []jsonPatch {
+	labels := make(map[string]string)
+	for k, v := range pod.Labels {
+		labels[k] = v
+	}
+	labels[podLabel] = pod.Name
+
+	affinity := &corev1.Affinity{}
+	if pod.Spec.Affinity != nil {
+		affinity = pod.Spec.Affinity.DeepCopy()
+	}
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      podLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   peers,
+				}},
+			},
+			TopologyKey: hostnameKey,
+		})
+
+	// add replaces the labels and the affinity if the pod already has them
+	return []jsonPatch{
+		{Op: "add", Path: "/metadata/labels", Value: labels},
+		{Op: "add", Path: "/spec/affinity", Value: affinity},
+	}
+}
+
+func (e *extender) handleMutate(w http.ResponseWriter, r *http.Request) {
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+	req := review.Request
+	resp := &admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+	review.Response = resp
+	review.Request = nil
+
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, pod); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if pod.Name == "" {
+		pod.Name = req.Name
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = req.Namespace
+	}
+
+	ops, err := e.mutate(r.Context(), pod)
+	if err != nil {
+		log.Infof("Rejecting pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		resp.Allowed = false
+		resp.Result = &metav1.Status{Message: err.Error(), Reason: metav1.StatusReasonForbidden, Code: http.StatusForbidden}
+		writeJSON(w, review)
+		return
+	}
+	if len(ops) > 0 {
+		patch, err := json.Marshal(ops)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		patchType := admissionv1.PatchTypeJSONPatch
+		resp.Patch = patch
+		resp.PatchType = &patchType
+		log.Infof("Adding anti-affinity rules to pod %s/%s", pod.Namespace, pod.Name)
+	}
+	writeJSON(w, review)
+}
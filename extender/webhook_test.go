@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+func TestAntiAffinePods(t *testing.T) {
+	topologies := []topologyv1.Topology{
+		{ObjectMeta: metav1.ObjectMeta{Name: "r1"}, Spec: topologyv1.TopologySpec{
+			Placement: &topologyv1.Placement{AntiAffinity: []string{"r2", "r3"}},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "r4"}, Spec: topologyv1.TopologySpec{
+			Placement: &topologyv1.Placement{AntiAffinity: []string{"r2"}},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "r5"}},
+	}
+	tests := []struct {
+		pod    string
+		groups int
+		want   []string
+	}{
+		{pod: "r1", groups: 1, want: []string{"r2", "r3"}},
+		{pod: "r2", groups: 2, want: []string{"r1", "r3", "r4"}},
+		{pod: "r5", groups: 0, want: nil},
+	}
+	for _, tt := range tests {
+		if got := topologyv1.AntiAffinityGroups(topologies, tt.pod); len(got) != tt.groups {
+			t.Errorf("AntiAffinityGroups(%s) = %v, want %d groups", tt.pod, got, tt.groups)
+		}
+		if got := topologyv1.AntiAffinePods(topologies, tt.pod); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("AntiAffinePods(%s) = %v, want %v", tt.pod, got, tt.want)
+		}
+	}
+}
+
+func TestAntiAffinityPatch(t *testing.T) {
+	existing := corev1.PodAffinityTerm{TopologyKey: "zone"}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1", Labels: map[string]string{"app": "lab"}},
+		Spec: corev1.PodSpec{Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{existing},
+		}}},
+	}
+	ops := antiAffinityPatch(pod, []string{"r2", "r3"})
+	if len(ops) != 2 {
+		t.Fatalf("antiAffinityPatch() = %v, want 2 operations", ops)
+	}
+	wantLabels := map[string]string{"app": "lab", podLabel: "r1"}
+	if !reflect.DeepEqual(ops[0].Value, wantLabels) {
+		t.Errorf("labels = %v, want %v", ops[0].Value, wantLabels)
+	}
+	terms := ops[1].Value.(*corev1.Affinity).PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 2 || !reflect.DeepEqual(terms[0], existing) {
+		t.Fatalf("anti-affinity terms = %v, want the existing one and a meshnet one", terms)
+	}
+	if values := terms[1].LabelSelector.MatchExpressions[0].Values; terms[1].TopologyKey != hostnameKey || !reflect.DeepEqual(values, []string{"r2", "r3"}) {
+		t.Errorf("meshnet anti-affinity term = %v", terms[1])
+	}
+	// the pod itself isn't modified
+	if len(pod.Labels) != 1 || len(pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Errorf("antiAffinityPatch() has modified the pod")
+	}
+}
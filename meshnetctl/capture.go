@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/capture"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// captureWire captures the frames of the link uid of pod through the daemon of its node,
+// either writing them to stdout in the libpcap format or, with -pcap-over-ip, printing the
+// address of the PCAP-over-IP server the daemon serves them on. It runs until interrupted.
+func captureWire(ctx context.Context, client topologyclientv1.Interface, opts options, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("capture needs a pod and a link UID")
+	}
+	pod := args[0]
+	uid, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid link UID %q", args[1])
+	}
+	topo, err := client.Topology(opts.namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read topology %s/%s: %v", opts.namespace, pod, err)
+	}
+	if topo.Status.SrcIp == "" {
+		return fmt.Errorf("pod %s/%s is not running", opts.namespace, pod)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	addr := net.JoinHostPort(topo.Status.SrcIp, fmt.Sprint(opts.daemonPort))
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the daemon on %s: %v", addr, err)
+	}
+	defer conn.Close()
+	stream, err := mpb.NewLocalClient(conn).CaptureWire(ctx, &mpb.CaptureRequest{
+		Pod:            pod,
+		KubeNs:         opts.namespace,
+		LinkUid:        uid,
+		Filter:         opts.filter,
+		PcapOverIpPort: int32(opts.pcapOverIP),
+	})
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	if opts.pcapOverIP == 0 {
+		if err := capture.WriteHeader(out, capture.DefaultSnaplen); err != nil {
+			return err
+		}
+	}
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if p.PcapOverIpAddress != "" {
+			fmt.Printf("Serving link %d of pod %s/%s with PCAP-over-IP on %s until interrupted, open it with:\n", uid, opts.namespace, pod, p.PcapOverIpAddress)
+			fmt.Printf("  wireshark -k -i TCP@%s\n", p.PcapOverIpAddress)
+			continue
+		}
+		if err := capture.WritePacket(out, time.Unix(0, p.Timestamp), p.Data, int(p.Length)); err != nil {
+			return err
+		}
+		// flush each frame so that the reader of a pipe sees it live
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// replayPCAP sends the frames of the pcap file -f out of the link uid of pod through the daemon
+// of its node, printing the progress of the replay. An endless replay runs until interrupted.
+func replayPCAP(ctx context.Context, client topologyclientv1.Interface, opts options, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("replay needs a pod and a link UID")
+	}
+	pod := args[0]
+	uid, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid link UID %q", args[1])
+	}
+	var data []byte
+	switch opts.file {
+	case "":
+		return fmt.Errorf("replay needs a pcap file, -f <file> or -f - for stdin")
+	case "-":
+		data, err = ioutil.ReadAll(os.Stdin)
+	default:
+		data, err = ioutil.ReadFile(opts.file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the pcap file: %v", err)
+	}
+	topo, err := client.Topology(opts.namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read topology %s/%s: %v", opts.namespace, pod, err)
+	}
+	if topo.Status.SrcIp == "" {
+		return fmt.Errorf("pod %s/%s is not running", opts.namespace, pod)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	addr := net.JoinHostPort(topo.Status.SrcIp, fmt.Sprint(opts.daemonPort))
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the daemon on %s: %v", addr, err)
+	}
+	defer conn.Close()
+	stream, err := mpb.NewLocalClient(conn).StreamReplayPCAP(ctx, &mpb.PCAPReplayRequest{
+		Pod:               pod,
+		KubeNs:            opts.namespace,
+		LinkUid:           uid,
+		Pcap:              data,
+		ReplaySpeedFactor: opts.speed,
+		LoopCount:         opts.loop,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("loops: %d, frames: %d, bytes: %d, errors: %d\n", p.Loops, p.FramesSent, p.BytesSent, p.Errors)
+		if p.LastError != "" {
+			fmt.Printf("  last error: %s\n", p.LastError)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+)
+
+// linkProfile runs the linkprofile subcommand named by the first of args
+func linkProfile(ctx context.Context, client topologyclientv1.Interface, opts options, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("linkprofile needs one of list, get, apply or delete")
+	}
+	profiles := client.LinkProfile(opts.namespace)
+	switch sub, names := args[0], args[1:]; sub {
+	case "list":
+		list, err := profiles.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list link profiles in %s: %v", opts.namespace, err)
+		}
+		return printLinkProfiles(opts, list.Items)
+	case "get":
+		var result []topologyv1.LinkProfile
+		err := forEachProfile(names, func(name string) error {
+			p, err := profiles.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to read link profile %s/%s: %v", opts.namespace, name, err)
+			}
+			result = append(result, *p)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return printLinkProfiles(opts, result)
+	case "apply":
+		return applyLinkProfiles(ctx, client, opts)
+	case "delete":
+		return forEachProfile(names, func(name string) error {
+			if err := profiles.Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+				return fmt.Errorf("failed to delete link profile %s/%s: %v", opts.namespace, name, err)
+			}
+			fmt.Printf("linkprofile %s/%s deleted\n", opts.namespace, name)
+			return nil
+		})
+	}
+	return fmt.Errorf("unknown linkprofile command %q", args[0])
+}
+
+func forEachProfile(names []string, fn func(string) error) error {
+	if len(names) == 0 {
+		return fmt.Errorf("link profile name is required")
+	}
+	for _, name := range names {
+		if err := fn(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyLinkProfiles creates the link profiles defined in a file, or updates them if they exist
+func applyLinkProfiles(ctx context.Context, client topologyclientv1.Interface, opts options) error {
+	if opts.file == "" {
+		return fmt.Errorf("-f is required")
+	}
+	f, err := os.Open(opts.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		p := &topologyv1.LinkProfile{}
+		if err := decoder.Decode(p); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to parse %s: %v", opts.file, err)
+		}
+		if p.Name == "" {
+			continue
+		}
+		if err := p.Spec.Validate(); err != nil {
+			return fmt.Errorf("invalid link profile %s: %v", p.Name, err)
+		}
+		ns := p.Namespace
+		if ns == "" {
+			ns = opts.namespace
+		}
+		profiles := client.LinkProfile(ns)
+		err := createOrUpdate(p, func() (metav1.Object, error) {
+			return profiles.Get(ctx, p.Name, metav1.GetOptions{})
+		}, func() error {
+			_, err := profiles.Create(ctx, p)
+			return err
+		}, func() error {
+			_, err := profiles.Update(ctx, p)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply link profile %s/%s: %v", ns, p.Name, err)
+		}
+		fmt.Printf("linkprofile %s/%s applied\n", ns, p.Name)
+	}
+}
+
+func printLinkProfiles(opts options, profiles []topologyv1.LinkProfile) error {
+	if opts.output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(profiles)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMTU\tTUNNEL_TYPE\tEGRESS_LATENCY_MS\tEGRESS_LOSS_PERCENT")
+	for _, p := range profiles {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%v\n", p.Name, p.Spec.MTU, p.Spec.TunnelType,
+			p.Spec.EgressImpairment.LatencyMs, p.Spec.EgressImpairment.LossPercent)
+	}
+	return w.Flush()
+}
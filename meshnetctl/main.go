@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	topologyv1 "github.com/networkop/meshnet-cni/api/types/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/policy"
+	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+)
+
+// label of the daemon pods
+const daemonSelector = "name=meshnet"
+
+const usage = `Usage: meshnetctl <command> [flags]
+
+Commands:
+  apply -f <topology.yaml>     create the topologies defined in a file, or update their
+                               links if they exist
+  delete <name> -n <ns>        delete a topology
+  status <name> -n <ns>        show the links of a topology and their status
+  policies -n <ns> [-apply]    generate NetworkPolicies that keep apart the topology pods
+                               that aren't connected, and optionally apply them, deleting
+                               the ones of the topologies that are gone
+  linkprofile list -n <ns>     list the link profiles of a namespace
+  linkprofile get <name>       show link profiles
+  linkprofile apply -f <file>  create or update the link profiles defined in a file
+  linkprofile delete <name>    delete a link profile
+  topology export-dot [<name>] print the graph of the topologies of a namespace, or of the
+                               links of the named ones, in the DOT language of Graphviz
+  topology instantiate <template> -p <key>=<value>...
+                               create the topologies of a topology template rendered with
+                               the parameters
+  capture <pod> <uid> [-filter <expr>] [-pcap-over-ip <port>]
+                               write the frames of a link of a pod to stdout as a pcap, e.g.
+                               | wireshark -k -i -, or serve them on a port of the pod's node
+                               to be opened in Wireshark
+  replay <pod> <uid> -f <file.pcap> [-speed <factor>] [-loop <n>]
+                               send the frames of a pcap file, - for stdin, out of a link
+                               of a pod at the pace they were captured, n times, forever
+                               if negative
+`
+
+type options struct {
+	namespace  string
+	output     string
+	file       string
+	apply      bool
+	params     parameters
+	filter     string
+	pcapOverIP int
+	speed      float64
+	loop       int64
+	daemonPort int
+	// namespace of the daemons, which serve the RPCs that don't depend on the node
+	daemonNamespace string
+}
+
+// parameters is a repeatable key=value flag
+type parameters map[string]string
+
+func (p parameters) String() string {
+	return fmt.Sprint(map[string]string(p))
+}
+
+func (p parameters) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("%q isn't a key=value parameter", value)
+	}
+	p[kv[0]] = kv[1]
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, args := os.Args[1], os.Args[2:]
+
+	opts := options{params: parameters{}}
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	fs.StringVar(&opts.namespace, "n", "default", "namespace of the topology")
+	fs.StringVar(&opts.output, "output", "table", "output format, either table or json")
+	fs.StringVar(&opts.file, "f", "", "file with topology or link profile definitions")
+	fs.BoolVar(&opts.apply, "apply", false, "apply the generated NetworkPolicies")
+	fs.Var(opts.params, "p", "key=value parameter of a topology template, can be repeated")
+	fs.StringVar(&opts.filter, "filter", "", "filter of the captured frames, e.g. \"tcp and port 179\"")
+	fs.IntVar(&opts.pcapOverIP, "pcap-over-ip", 0, "port of the node of the pod to serve the capture on with PCAP-over-IP")
+	fs.Float64Var(&opts.speed, "speed", 1, "the gaps between the replayed frames are divided by it")
+	fs.Int64Var(&opts.loop, "loop", 1, "number of times the pcap file is replayed, forever if negative")
+	fs.IntVar(&opts.daemonPort, "daemon-port", 51111, "gRPC port of the meshnet daemons")
+	fs.StringVar(&opts.daemonNamespace, "daemon-namespace", "meshnet", "namespace of the meshnet daemons")
+	// Positional arguments may come before the flags, e.g. status r1 -n lab
+	var positional []string
+	for len(args) > 0 {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) > 0 {
+			positional = append(positional, args[0])
+			args = args[1:]
+		}
+	}
+
+	rCfg, err := restConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read kubeconfig: %v\n", err)
+		os.Exit(1)
+	}
+	client, err := topologyclientv1.NewForConfig(rCfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create K8s client: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	switch cmd {
+	case "apply":
+		err = apply(ctx, client, opts)
+	case "delete":
+		err = forEach(positional, func(name string) error {
+			return remove(ctx, client, opts, name)
+		})
+	case "status":
+		err = forEach(positional, func(name string) error {
+			return status(ctx, client, opts, name)
+		})
+	case "policies":
+		var kClient kubernetes.Interface
+		if kClient, err = kubernetes.NewForConfig(rCfg); err == nil {
+			err = policies(ctx, kClient, opts)
+		}
+	case "linkprofile":
+		err = linkProfile(ctx, client, opts, positional)
+	case "topology":
+		err = topology(ctx, client, opts, positional)
+	case "capture":
+		err = captureWire(ctx, client, opts, positional)
+	case "replay":
+		err = replayPCAP(ctx, client, opts, positional)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func restConfig() (*rest.Config, error) {
+	kubecfg := os.Getenv("KUBECONFIG")
+	if kubecfg == "" {
+		kubecfg = filepath.Join(homedir.HomeDir(), ".kube", "config")
+	}
+	return clientcmd.BuildConfigFromFlags("", kubecfg)
+}
+
+func forEach(names []string, fn func(string) error) error {
+	if len(names) == 0 {
+		return fmt.Errorf("topology name is required")
+	}
+	for _, name := range names {
+		if err := fn(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apply(ctx context.Context, client topologyclientv1.Interface, opts options) error {
+	if opts.file == "" {
+		return fmt.Errorf("-f is required")
+	}
+	f, err := os.Open(opts.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		topo := &topologyv1.Topology{}
+		if err := decoder.Decode(topo); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to parse %s: %v", opts.file, err)
+		}
+		if topo.Name == "" {
+			continue
+		}
+		ns := topo.Namespace
+		if ns == "" {
+			ns = opts.namespace
+		}
+		topologies := client.Topology(ns)
+		err := createOrUpdate(topo, func() (metav1.Object, error) {
+			return topologies.Get(ctx, topo.Name, metav1.GetOptions{})
+		}, func() error {
+			_, err := topologies.Create(ctx, topo)
+			return err
+		}, func() error {
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(topo)
+			if err != nil {
+				return err
+			}
+			_, err = topologies.Update(ctx, &unstructured.Unstructured{Object: obj}, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply topology %s/%s: %v", ns, topo.Name, err)
+		}
+		fmt.Printf("topology %s/%s applied\n", ns, topo.Name)
+	}
+}
+
+// createOrUpdate creates obj if get doesn't find it, or updates it with the resource version
+// of the existing object otherwise
+func createOrUpdate(obj metav1.Object, get func() (metav1.Object, error), create, update func() error) error {
+	existing, err := get()
+	if apierrors.IsNotFound(err) {
+		return create()
+	} else if err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return update()
+}
+
+func remove(ctx context.Context, client topologyclientv1.Interface, opts options, name string) error {
+	if err := client.Topology(opts.namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete topology %s/%s: %v", opts.namespace, name, err)
+	}
+	fmt.Printf("topology %s/%s deleted\n", opts.namespace, name)
+	return nil
+}
+
+type linkStatus struct {
+	LocalIntf string `json:"local_intf"`
+	LocalIP   string `json:"local_ip,omitempty"`
+	PeerPod   string `json:"peer_pod"`
+	PeerIntf  string `json:"peer_intf"`
+	PeerAlive bool   `json:"peer_alive"`
+	Skipped   bool   `json:"skipped"`
+}
+
+type topologyStatus struct {
+	Name       string       `json:"name"`
+	Namespace  string       `json:"namespace"`
+	SrcIP      string       `json:"src_ip"`
+	NetNs      string       `json:"net_ns"`
+	WiresUp    int64        `json:"wires_up"`
+	WiresTotal int64        `json:"wires_total"`
+	Links      []linkStatus `json:"links"`
+}
+
+func status(ctx context.Context, client topologyclientv1.Interface, opts options, name string) error {
+	topo, err := client.Topology(opts.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read topology %s/%s: %v", opts.namespace, name, err)
+	}
+
+	result := topologyStatus{
+		Name:       topo.Name,
+		Namespace:  topo.Namespace,
+		SrcIP:      topo.Status.SrcIp,
+		NetNs:      topo.Status.NetNs,
+		WiresUp:    topo.Status.WiresUp,
+		WiresTotal: topo.Status.WiresTotal,
+	}
+	skipped := make(map[string]bool)
+	for _, s := range topo.Status.Skipped {
+		skipped[s] = true
+	}
+	for _, link := range topo.Spec.Links {
+		ls := linkStatus{
+			LocalIntf: link.LocalIntf,
+			LocalIP:   link.LocalIP,
+			PeerPod:   link.PeerPod,
+			PeerIntf:  link.PeerIntf,
+			Skipped:   skipped[link.PeerPod],
+		}
+		if peer, err := client.Topology(opts.namespace).Get(ctx, link.PeerPod, metav1.GetOptions{}); err == nil {
+			ls.PeerAlive = peer.Status.SrcIp != "" && peer.Status.NetNs != ""
+		}
+		result.Links = append(result.Links, ls)
+	}
+
+	if opts.output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	fmt.Printf("Topology: %s/%s\nSrc IP:   %s\nNetNs:    %s\nWires:    %d/%d\n\n",
+		result.Namespace, result.Name, result.SrcIP, result.NetNs, result.WiresUp, result.WiresTotal)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "LOCAL_INTF\tLOCAL_IP\tPEER_POD\tPEER_INTF\tPEER_ALIVE\tSKIPPED")
+	for _, l := range result.Links {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%t\n", l.LocalIntf, l.LocalIP, l.PeerPod, l.PeerIntf, l.PeerAlive, l.Skipped)
+	}
+	return w.Flush()
+}
+
+// policies prints the NetworkPolicies generated by a daemon for the topologies of a namespace
+// or, with -apply, creates or updates them and deletes the ones of the topologies that are gone
+func policies(ctx context.Context, kClient kubernetes.Interface, opts options) error {
+	addr, err := daemonAddr(ctx, kClient, opts)
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the daemon on %s: %v", addr, err)
+	}
+	defer conn.Close()
+	bundle, err := mpb.NewLocalClient(conn).GenerateNetworkPolicies(ctx, &mpb.TopologyQuery{KubeNs: opts.namespace})
+	if err != nil {
+		return fmt.Errorf("failed to generate the network policies of %s: %v", opts.namespace, err)
+	}
+	var result []networkingv1.NetworkPolicy
+	if err := json.Unmarshal(bundle.Policies, &result); err != nil {
+		return fmt.Errorf("failed to parse the network policies of %s: %v", opts.namespace, err)
+	}
+
+	if !opts.apply {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	nps := kClient.NetworkingV1().NetworkPolicies(opts.namespace)
+	for i := range result {
+		np := &result[i]
+		err := createOrUpdate(np, func() (metav1.Object, error) {
+			return nps.Get(ctx, np.Name, metav1.GetOptions{})
+		}, func() error {
+			_, err := nps.Create(ctx, np, metav1.CreateOptions{})
+			return err
+		}, func() error {
+			_, err := nps.Update(ctx, np, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply network policy %s/%s: %v", opts.namespace, np.Name, err)
+		}
+		fmt.Printf("networkpolicy %s/%s applied\n", opts.namespace, np.Name)
+	}
+
+	managed, err := nps.List(ctx, metav1.ListOptions{LabelSelector: policy.ManagedByLabel + "=" + policy.ManagedBy})
+	if err != nil {
+		return fmt.Errorf("failed to list the network policies of %s: %v", opts.namespace, err)
+	}
+	for _, name := range policy.Stale(managed.Items, result) {
+		if err := nps.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete network policy %s/%s: %v", opts.namespace, name, err)
+		}
+		fmt.Printf("networkpolicy %s/%s deleted\n", opts.namespace, name)
+	}
+	return nil
+}
+
+// daemonAddr returns the gRPC address of a running daemon, for the RPCs that any daemon serves
+func daemonAddr(ctx context.Context, kClient kubernetes.Interface, opts options) (string, error) {
+	pods, err := kClient.CoreV1().Pods(opts.daemonNamespace).List(ctx, metav1.ListOptions{LabelSelector: daemonSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list the daemons in %s: %v", opts.daemonNamespace, err)
+	}
+	for _, p := range pods.Items {
+		if p.Status.Phase == corev1.PodRunning && p.Status.PodIP != "" {
+			return net.JoinHostPort(p.Status.PodIP, fmt.Sprint(opts.daemonPort)), nil
+		}
+	}
+	return "", fmt.Errorf("no daemon is running in namespace %s", opts.daemonNamespace)
+}
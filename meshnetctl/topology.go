@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyclientv1 "github.com/networkop/meshnet-cni/api/clientset/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/dot"
+	"github.com/networkop/meshnet-cni/daemon/topotemplate"
+)
+
+// topology runs the topology subcommand named by the first of args
+func topology(ctx context.Context, client topologyclientv1.Interface, opts options, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("topology needs export-dot or instantiate")
+	}
+	switch sub, names := args[0], args[1:]; sub {
+	case "export-dot":
+		return exportDOT(ctx, client, opts, names)
+	case "instantiate":
+		if len(names) != 1 {
+			return fmt.Errorf("topology instantiate needs a template name")
+		}
+		return instantiate(ctx, client, opts, names[0])
+	}
+	return fmt.Errorf("unknown topology command %q", args[0])
+}
+
+// exportDOT prints the graph of the topologies of the namespace in the DOT language, or only
+// the links of the topologies names
+func exportDOT(ctx context.Context, client topologyclientv1.Interface, opts options, names []string) error {
+	list, err := client.Topology(opts.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list topologies in %s: %v", opts.namespace, err)
+	}
+	topologies := list.Items
+	if len(names) > 0 {
+		found := make(map[string]bool)
+		for _, t := range topologies {
+			found[t.Name] = true
+		}
+		for _, name := range names {
+			if !found[name] {
+				return fmt.Errorf("topology %s/%s not found", opts.namespace, name)
+			}
+		}
+		topologies = dot.Subgraph(names, topologies)
+	}
+	fmt.Print(dot.Generate(opts.namespace, topologies))
+	return nil
+}
+
+// instantiate creates the topologies of the template name rendered with the parameters. Like
+// apply, it leaves the topologies that already exist as they are.
+func instantiate(ctx context.Context, client topologyclientv1.Interface, opts options, name string) error {
+	tmpl, err := client.TopologyTemplate(opts.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read topology template %s/%s: %v", opts.namespace, name, err)
+	}
+	topologies, err := topotemplate.Render(tmpl, opts.params)
+	if err != nil {
+		return fmt.Errorf("failed to render topology template %s/%s: %v", opts.namespace, name, err)
+	}
+	for i := range topologies {
+		topo := &topologies[i]
+		_, err := client.Topology(opts.namespace).Create(ctx, topo)
+		if apierrors.IsAlreadyExists(err) {
+			existing, err := client.Topology(opts.namespace).Get(ctx, topo.Name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to read topology %s/%s: %v", opts.namespace, topo.Name, err)
+			}
+			if !topotemplate.SameInstance(existing.Annotations, topo.Annotations) {
+				return fmt.Errorf("topology %s/%s already exists and isn't an instance of template %s with these parameters", opts.namespace, topo.Name, name)
+			}
+			fmt.Printf("topology %s/%s unchanged\n", opts.namespace, topo.Name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create topology %s/%s: %v", opts.namespace, topo.Name, err)
+		}
+		fmt.Printf("topology %s/%s created\n", opts.namespace, topo.Name)
+	}
+	return nil
+}
@@ -7,7 +7,8 @@ import (
 	"net"
 	"os"
 	"runtime"
-	"strings"
+	"sort"
+	"time"
 
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
@@ -18,8 +19,24 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
 	"google.golang.org/grpc"
-
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkop/meshnet-cni/daemon/annotations"
+	"github.com/networkop/meshnet-cni/daemon/canary"
+	"github.com/networkop/meshnet-cni/daemon/cilium"
+	"github.com/networkop/meshnet-cni/daemon/ecmp"
+	"github.com/networkop/meshnet-cni/daemon/encap"
+	"github.com/networkop/meshnet-cni/daemon/impairment"
+	"github.com/networkop/meshnet-cni/daemon/ovs"
+	"github.com/networkop/meshnet-cni/daemon/pmtu"
 	mpb "github.com/networkop/meshnet-cni/daemon/proto/meshnet/v1beta1"
+	"github.com/networkop/meshnet-cni/daemon/sriov"
+	"github.com/networkop/meshnet-cni/daemon/srv6"
+	"github.com/networkop/meshnet-cni/daemon/veth"
+	"github.com/networkop/meshnet-cni/daemon/vxlan"
 )
 
 const (
@@ -27,9 +44,59 @@ const (
 	defaultPort = "51111"
 	localhost   = "localhost"
 	localDaemon = localhost + ":" + defaultPort
-	macvlanMode = netlink.MACVLAN_MODE_BRIDGE
+	// unix socket of the local daemon, preferred over localDaemon when it exists
+	daemonSocket = "/var/run/meshnet/daemon.sock"
+	macvlanMode  = netlink.MACVLAN_MODE_BRIDGE
+	// attempts of a call rejected by the rate limiter of a daemon, and the wait before the
+	// first retry, doubled for each of the next ones
+	rateLimitAttempts = 5
+	rateLimitBackoff  = 200 * time.Millisecond
 )
 
+var dialOpts = []grpc.DialOption{
+	grpc.WithInsecure(),
+	grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                30 * time.Second,
+		Timeout:             10 * time.Second,
+		PermitWithoutStream: true,
+	}),
+	grpc.WithUnaryInterceptor(retryRateLimited),
+}
+
+// retryRateLimited retries the calls rejected by the rate limiter of a daemon, backing off
+// exponentially, so that the pods started at once don't fail their CNI ADD
+func retryRateLimited(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	backoff := rateLimitBackoff
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if attempt == rateLimitAttempts || !isRateLimited(err) {
+			return err
+		}
+		log.Infof("%s has been rate limited, retrying in %s", method, backoff)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isRateLimited returns whether err is the rejection of a call by a daemon's rate limiter,
+// rather than e.g. an exhausted quota
+func isRateLimited(err error) bool {
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		return false
+	}
+	for _, d := range st.Details() {
+		if e, ok := d.(*mpb.WireError); ok && e.Cause == mpb.WireError_RATE_LIMITED {
+			return true
+		}
+	}
+	return false
+}
+
 type netConf struct {
 	types.NetConf
 	Delegate map[string]interface{} `json:"delegate"`
@@ -76,16 +143,27 @@ func loadConf(bytes []byte) (*netConf, *current.Result, error) {
 	return n, result, nil
 }
 
+// isChained returns true when meshnet runs after another plugin in a chain, in which case
+// the pod's default interface and routes are owned by that plugin and must be left untouched
+func isChained(n *netConf) bool {
+	return n.RawPrevResult != nil
+}
+
 // getVxlanSource uses netlink to get the iface reliably given an IP address.
 func getVxlanSource(nodeIP string) (string, string, error) {
 	if nodeIP == "" {
 		return "", "", fmt.Errorf("meshnetd provided no HOST_IP address: %s", nodeIP)
 	}
+	ip := net.ParseIP(nodeIP)
+	if ip == nil {
+		return "", "", fmt.Errorf("meshnetd provided an invalid HOST_IP address: %s", nodeIP)
+	}
 	ifaces, _ := net.Interfaces()
 	for _, i := range ifaces {
 		addrs, _ := i.Addrs()
 		for _, a := range addrs {
-			if strings.Contains(a.String(), nodeIP) {
+			// IPv6 addresses have several textual forms, and one address may be a prefix of another
+			if ipNet, ok := a.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
 				log.Infof("Found iface %s for address %s", i.Name, nodeIP)
 				return nodeIP, i.Name, nil
 			}
@@ -113,6 +191,131 @@ func makeVeth(netNS, linkName string, ip string) (*koko.VEth, error) {
 	return &veth, nil
 }
 
+// localDaemonAddr returns the address of the local daemon, its unix socket if it's listening on one
+func localDaemonAddr() string {
+	if fi, err := os.Stat(daemonSocket); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		return "unix://" + daemonSocket
+	}
+	return localDaemon
+}
+
+// findLink returns the pod's link with the given uid, or nil if there's none
+func findLink(pod *mpb.Pod, uid int64) *mpb.Link {
+	for _, link := range pod.Links {
+		if link.Uid == uid {
+			return link
+		}
+	}
+	return nil
+}
+
+// registerEndpoint registers the interface intf of pod as a Cilium endpoint when the daemon
+// of localPod has meshnet chained after Cilium
+func registerEndpoint(ctx context.Context, localPod, pod *mpb.Pod, intf string) error {
+	if localPod.CiliumSocket == "" {
+		return nil
+	}
+	log.Infof("Registering %s of pod %s with Cilium", intf, pod.Name)
+	if err := cilium.NewClient(localPod.CiliumSocket).Register(ctx, cilium.Endpoint{
+		KubeNs: localPod.KubeNs, Pod: pod.Name, ContainerID: pod.ContainerId, NetNs: pod.NetNs, Intf: intf,
+	}); err != nil {
+		log.Infof("Failed to register %s with Cilium: %s", intf, err)
+		return err
+	}
+	return nil
+}
+
+// configureEnd applies the impairments, the traffic class, the QoS map, the MPLS label and the
+// MTU of the end of a link in pod, whose interface is the local one of link, and registers it
+// with Cilium
+func configureEnd(ctx context.Context, localPod, pod *mpb.Pod, link *mpb.Link) error {
+	intf := link.LocalIntf
+	if err := impairment.Apply(pod.NetNs, intf, link.EgressImpairment, link.IngressImpairment); err != nil {
+		log.Infof("Failed to apply impairments to %s of pod %s: %s", intf, pod.Name, err)
+		return err
+	}
+	if err := impairment.ApplyDSCP(pod.NetNs, intf, link.TrafficClass); err != nil {
+		log.Infof("Failed to mark the traffic of %s of pod %s: %s", intf, pod.Name, err)
+		return err
+	}
+	if err := impairment.ApplyQoS(pod.NetNs, intf, link.EgressQosMap, link.EgressQueueAllocation); err != nil {
+		log.Infof("Failed to apply the QoS map of %s of pod %s: %s", intf, pod.Name, err)
+		return err
+	}
+	if err := encap.ApplyMPLS(pod.NetNs, intf, link.LocalIp, link.MplsLabel); err != nil {
+		log.Infof("Failed to apply MPLS label to %s of pod %s: %s", intf, pod.Name, err)
+		return err
+	}
+	if err := pmtu.Apply(pod.NetNs, intf, link.Mtu); err != nil {
+		log.Infof("Failed to set the MTU of %s of pod %s: %s", intf, pod.Name, err)
+		return err
+	}
+	return registerEndpoint(ctx, localPod, pod, intf)
+}
+
+// peerEnd returns the end of link in peerPod: its interface, IP, MPLS label and MTU are those of
+// link, and its impairments, traffic class and QoS map the ones of the peer's own link
+func peerEnd(peerPod *mpb.Pod, link *mpb.Link) *mpb.Link {
+	end := &mpb.Link{}
+	if peerLink := findLink(peerPod, link.Uid); peerLink != nil {
+		end = proto.Clone(peerLink).(*mpb.Link)
+	}
+	end.LocalIntf = link.PeerIntf
+	end.LocalIp = link.PeerIp
+	end.MplsLabel = link.MplsLabel
+	end.Mtu = link.Mtu
+	return end
+}
+
+// prerequisites returns the UIDs of the links of pod that are lower than uid
+func prerequisites(pod *mpb.Pod, uid int64) []int64 {
+	var result []int64
+	for _, link := range pod.Links {
+		if link.Uid < uid {
+			result = append(result, link.Uid)
+		}
+	}
+	return result
+}
+
+// waitForPeers waits for the peers of pod to be alive, for as long as the daemon's
+// -pod-alive-timeout allows
+func waitForPeers(ctx context.Context, client mpb.LocalClient, pod *mpb.Pod) {
+	seen := map[string]bool{}
+	var peers []string
+	for _, l := range pod.Links {
+		if l.PeerPod != localhost && !seen[l.PeerPod] {
+			seen[l.PeerPod] = true
+			peers = append(peers, l.PeerPod)
+		}
+	}
+	if len(peers) == 0 {
+		return
+	}
+	ok, err := client.WaitForPodsAlive(ctx, &mpb.PodList{Names: peers, KubeNs: pod.KubeNs})
+	if err != nil || !ok.Response {
+		log.Infof("Not all the peers of pod %s are alive: %v", pod.Name, err)
+	}
+}
+
+// auditWire records a new wire in the pod's topology status, created by the CNI ADD of the
+// pod itself or, with howCreated CNI_ADD_PEER, of its peer. It's only informational, so
+// failures are logged and don't fail the CNI call.
+func auditWire(ctx context.Context, client mpb.LocalClient, pod *mpb.Pod, uid int64, wireType, howCreated string) {
+	if _, err := client.AuditWire(ctx, &mpb.WireAudit{
+		Pod:        pod.Name,
+		KubeNs:     pod.KubeNs,
+		LinkUid:    uid,
+		HowCreated: howCreated,
+		NodeIp:     pod.SrcIp,
+		WireType:   wireType,
+		// CNI ADD doesn't wait for the entry to be written to K8s
+		Queue: true,
+	}); err != nil {
+		log.Infof("Failed to record wire %d: %s", uid, err)
+	}
+}
+
 // Creates koko.Vxlan from ParentIF, destination IP and VNI
 func makeVxlan(srcIntf string, peerIP string, idx int64) *koko.VxLan {
 	return &koko.VxLan{
@@ -132,6 +335,15 @@ func cmdAdd(args *skel.CmdArgs) error {
 	if err != nil {
 		return err
 	}
+	if isChained(n) {
+		log.Info("Running as a chained plugin, previous result is passed through")
+	} else {
+		log.Info("Running as a standalone plugin")
+	}
+	// Link addresses always come from the topology, so there's nothing to allocate
+	if n.IPAM.Type != "" {
+		log.Infof("Ignoring IPAM plugin %s, link IPs are set from the topology", n.IPAM.Type)
+	}
 
 	log.Info("Parsing CNI_ARGS environment variable")
 	cniArgs := k8sArgs{}
@@ -141,9 +353,10 @@ func cmdAdd(args *skel.CmdArgs) error {
 	log.Infof("Processing ADD POD in namespace %s", cniArgs.K8S_POD_NAMESPACE)
 
 	log.Infof("Attempting to connect to local meshnet daemon")
-	conn, err := grpc.Dial(localDaemon, grpc.WithInsecure())
+	daemonAddr := localDaemonAddr()
+	conn, err := grpc.Dial(daemonAddr, dialOpts...)
 	if err != nil {
-		log.Infof("Failed to connect to local meshnetd on %s", localDaemon)
+		log.Infof("Failed to connect to local meshnetd on %s", daemonAddr)
 		return err
 	}
 	defer conn.Close()
@@ -159,6 +372,8 @@ func cmdAdd(args *skel.CmdArgs) error {
 		log.Infof("Pod %s:%s was not a topology pod returning", string(cniArgs.K8S_POD_NAMESPACE), string(cniArgs.K8S_POD_NAME))
 		return types.PrintResult(result, n.CNIVersion)
 	}
+	// Pod annotations provide the defaults for the settings that links don't set
+	localPod.Links = annotations.ApplyDefaults(localPod.Links, localPod.Annotations)
 
 	// Finding the source IP and interface for VXLAN VTEP
 	srcIP, srcIntf, err := getVxlanSource(localPod.NodeIp)
@@ -170,6 +385,7 @@ func cmdAdd(args *skel.CmdArgs) error {
 	// Marking pod as "alive" by setting its srcIP and NetNS
 	localPod.NetNs = args.Netns
 	localPod.SrcIp = srcIP
+	localPod.ContainerId = args.ContainerID
 	log.Infof("Setting pod alive status on meshnet daemon")
 	ok, err := meshnetClient.SetAlive(ctx, localPod)
 	if err != nil || !ok.Response {
@@ -177,14 +393,43 @@ func cmdAdd(args *skel.CmdArgs) error {
 		return err
 	}
 
+	// Peers that aren't alive yet set up their wires to this pod when they come up
+	waitForPeers(ctx, meshnetClient, localPod)
+
+	// Only a fraction of the links is set up while a canary deployment is in progress
+	active := canary.Active(localPod.Links, localPod.Canary)
+
+	// Links are set up in UID order, a peer's daemon may wait for the ones with a lower UID
+	sort.SliceStable(localPod.Links, func(i, j int) bool { return localPod.Links[i].Uid < localPod.Links[j].Uid })
+
 	log.Info("Starting to traverse all links")
+	// The remote updates of all the links to a node share a connection to its daemon
+	remotes := make(map[string]*grpc.ClientConn)
 	for _, link := range localPod.Links { // Iterate over each link of the local pod
+		if !active[link.Uid] {
+			log.Infof("Skipping link %d outside of the canary deployment", link.Uid)
+			continue
+		}
 		// Build koko's veth struct for local intf
 		myVeth, err := makeVeth(args.Netns, link.LocalIntf, link.LocalIp)
 		if err != nil {
 			return err
 		}
 
+		// SR-IOV VFs connect to the physical network like macvlans, with a hardware datapath
+		if link.PeerPod == localhost && link.SriovVfPciAddr != "" {
+			log.Infof("Peer link is SR-IOV VF %s", link.SriovVfPciAddr)
+			if err = sriov.Attach(args.Netns, link.LocalIntf, link.LocalIp, link.SriovVfPciAddr); err != nil {
+				log.Infof("Failed to attach VF %s: %s", link.SriovVfPciAddr, err)
+				return err
+			}
+			auditWire(ctx, meshnetClient, localPod, link.Uid, "sriov", "CNI_ADD")
+			if err = configureEnd(ctx, localPod, localPod, link); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// First option is macvlan interface
 		if link.PeerPod == localhost {
 			log.Infof("Peer link is MacVlan")
@@ -197,6 +442,10 @@ func cmdAdd(args *skel.CmdArgs) error {
 				return err
 			}
 			log.Infof("macvlan interfacee %s@%s has been added", link.LocalIntf, link.PeerIntf)
+			auditWire(ctx, meshnetClient, localPod, link.Uid, "macvlan", "CNI_ADD")
+			if err = configureEnd(ctx, localPod, localPod, link); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -210,6 +459,11 @@ func cmdAdd(args *skel.CmdArgs) error {
 			log.Infof("Failed to retrieve peer pod %s:%s topology", string(cniArgs.K8S_POD_NAMESPACE), link.PeerPod)
 			return err
 		}
+		peerPod.Links = annotations.ApplyDefaults(peerPod.Links, peerPod.Annotations)
+		if !canary.Active(peerPod.Links, peerPod.Canary)[link.Uid] {
+			log.Infof("Skipping link %d outside of the canary deployment of peer pod %s", link.Uid, peerPod.Name)
+			continue
+		}
 
 		isAlive := peerPod.SrcIp != "" && peerPod.NetNs != ""
 		log.Infof("Is peer pod %s alive?: %t", peerPod.Name, isAlive)
@@ -225,70 +479,103 @@ func cmdAdd(args *skel.CmdArgs) error {
 					return err
 				}
 
-				// Checking if interfaces already exist
-				iExist, _ := koko.IsExistLinkInNS(myVeth.NsName, myVeth.LinkName)
-				pExist, _ := koko.IsExistLinkInNS(peerVeth.NsName, peerVeth.LinkName)
-
-				log.Infof("Does the link already exist? Local:%t, Peer:%t", iExist, pExist)
-				if iExist && pExist { // If both link exist, we don't need to do anything
-					log.Info("Both interfaces already exist in namespace")
-				} else if !iExist && pExist { // If only peer link exists, we need to destroy it first
-					log.Info("Only peer link exists, removing it first")
-					if err := peerVeth.RemoveVethLink(); err != nil {
-						log.Infof("Failed to remove a stale interface %s of my peer %s", peerVeth.LinkName, link.PeerPod)
-						return err
-					}
-					log.Infof("Adding the new veth link to both pods")
-					if err = koko.MakeVeth(*myVeth, *peerVeth); err != nil {
-						log.Infof("Error creating VEth pair after peer link remove: %s", err)
+				wireType := "veth"
+				if localPod.OvsBridge != "" {
+					// Each end is an access port of the bridge, so it's set up on its own
+					wireType = "ovs"
+					log.Infof("Connecting %s to OVS bridge %s", link.LocalIntf, localPod.OvsBridge)
+					if _, err = ovs.Attach(args.Netns, link.LocalIntf, link.LocalIp, localPod.OvsBridge, link.Uid); err != nil {
+						log.Infof("Failed to connect %s to OVS: %s", link.LocalIntf, err)
 						return err
 					}
-				} else if iExist && !pExist { // If only local link exists, we need to destroy it first
-					log.Infof("Only local link exists, removing it first")
-					if err := myVeth.RemoveVethLink(); err != nil {
-						log.Infof("Failed to remove a local stale VEth interface %s for pod %s", myVeth.LinkName, localPod.Name)
-						return err
-					}
-					log.Infof("Adding the new veth link to both pods")
-					if err = koko.MakeVeth(*myVeth, *peerVeth); err != nil {
-						log.Infof("Error creating VEth pair after local link remove: %s", err)
-						return err
-					}
-				} else { // if neither link exists, we have two options
-					log.Infof("Neither link exists. Checking if we've been skipped")
-					isSkipped, err := meshnetClient.IsSkipped(ctx, &mpb.SkipQuery{
-						Pod:    localPod.Name,
-						Peer:   peerPod.Name,
-						KubeNs: string(cniArgs.K8S_POD_NAMESPACE),
-					})
-					if err != nil {
-						log.Infof("Failed to read skipped status from our peer")
-						return err
+					// The peer's end is missing if it has skipped us
+					if pExist, _ := koko.IsExistLinkInNS(peerVeth.NsName, peerVeth.LinkName); !pExist {
+						log.Infof("Connecting peer %s to OVS bridge %s", link.PeerIntf, localPod.OvsBridge)
+						if _, err = ovs.Attach(peerPod.NetNs, link.PeerIntf, link.PeerIp, localPod.OvsBridge, link.Uid); err != nil {
+							log.Infof("Failed to connect peer %s to OVS: %s", link.PeerIntf, err)
+							return err
+						}
 					}
-					log.Infof("Have we been skipped by our peer %s? %t", peerPod.Name, isSkipped.Response)
-
-					// Comparing names to determine higher priority
-					higherPrio := localPod.Name > peerPod.Name
-					log.Infof("DO we have a higher priority? %t", higherPrio)
-
-					if isSkipped.Response || higherPrio { // If peer POD skipped us (booted before us) or we have a higher priority
-						log.Infof("Peer POD has skipped us or we have a higher priority")
-						if err = koko.MakeVeth(*myVeth, *peerVeth); err != nil {
-							log.Infof("Error when creating a new VEth pair with koko: %s", err)
-							log.Infof("MY VETH STRUCT: %+v", spew.Sdump(myVeth))
-							log.Infof("PEER STRUCT: %+v", spew.Sdump(peerVeth))
+				} else {
+					// Checking if interfaces already exist
+					iExist, _ := koko.IsExistLinkInNS(myVeth.NsName, myVeth.LinkName)
+					pExist, _ := koko.IsExistLinkInNS(peerVeth.NsName, peerVeth.LinkName)
+
+					log.Infof("Does the link already exist? Local:%t, Peer:%t", iExist, pExist)
+					if iExist && pExist { // If both link exist, we don't need to do anything
+						log.Info("Both interfaces already exist in namespace")
+					} else if !iExist && pExist { // If only peer link exists, we need to destroy it first
+						log.Info("Only peer link exists, removing it first")
+						if err := peerVeth.RemoveVethLink(); err != nil {
+							log.Infof("Failed to remove a stale interface %s of my peer %s", peerVeth.LinkName, link.PeerPod)
+							return err
+						}
+						log.Infof("Adding the new veth link to both pods")
+						if err = veth.Make(localPod.NetnsMode, *myVeth, *peerVeth); err != nil {
+							log.Infof("Error creating VEth pair after peer link remove: %s", err)
+							return err
+						}
+					} else if iExist && !pExist { // If only local link exists, we need to destroy it first
+						log.Infof("Only local link exists, removing it first")
+						if err := myVeth.RemoveVethLink(); err != nil {
+							log.Infof("Failed to remove a local stale VEth interface %s for pod %s", myVeth.LinkName, localPod.Name)
+							return err
+						}
+						log.Infof("Adding the new veth link to both pods")
+						if err = veth.Make(localPod.NetnsMode, *myVeth, *peerVeth); err != nil {
+							log.Infof("Error creating VEth pair after local link remove: %s", err)
+							return err
+						}
+					} else { // if neither link exists, we have two options
+						log.Infof("Neither link exists. Checking if we've been skipped")
+						isSkipped, err := meshnetClient.IsSkipped(ctx, &mpb.SkipQuery{
+							Pod:    localPod.Name,
+							Peer:   peerPod.Name,
+							KubeNs: string(cniArgs.K8S_POD_NAMESPACE),
+						})
+						if err != nil {
+							log.Infof("Failed to read skipped status from our peer")
 							return err
 						}
-					} else { // peerPod has higherPrio and hasn't skipped us
-						// In this case we do nothing, since the pod with a higher IP is supposed to connect veth pair
-						log.Infof("Doing nothing, expecting peer pod %s to connect veth pair", peerPod.Name)
-						continue
+						log.Infof("Have we been skipped by our peer %s? %t", peerPod.Name, isSkipped.Response)
+
+						// Comparing names to determine higher priority
+						higherPrio := localPod.Name > peerPod.Name
+						log.Infof("DO we have a higher priority? %t", higherPrio)
+
+						if isSkipped.Response || higherPrio { // If peer POD skipped us (booted before us) or we have a higher priority
+							log.Infof("Peer POD has skipped us or we have a higher priority")
+							if err = veth.Make(localPod.NetnsMode, *myVeth, *peerVeth); err != nil {
+								log.Infof("Error when creating a new VEth pair with koko: %s", err)
+								log.Infof("MY VETH STRUCT: %+v", spew.Sdump(myVeth))
+								log.Infof("PEER STRUCT: %+v", spew.Sdump(peerVeth))
+								return err
+							}
+						} else { // peerPod has higherPrio and hasn't skipped us
+							// In this case we do nothing, since the pod with a higher IP is supposed to connect veth pair
+							log.Infof("Doing nothing, expecting peer pod %s to connect veth pair", peerPod.Name)
+							continue
+						}
 					}
+
+				}
+
+				auditWire(ctx, meshnetClient, localPod, link.Uid, wireType, "CNI_ADD")
+				auditWire(ctx, meshnetClient, peerPod, link.Uid, wireType, "CNI_ADD_PEER")
+
+				// Both ends of a veth pair are configured here, since the peer's CNI call has already completed
+				if err = configureEnd(ctx, localPod, localPod, link); err != nil {
+					return err
+				}
+				if err = configureEnd(ctx, localPod, peerPod, peerEnd(peerPod, link)); err != nil {
+					return err
+				}
+				if err = ecmp.Apply(peerPod.NetNs, peerPod.Links); err != nil {
+					log.Infof("Failed to update ECMP routes of peer %s: %s", peerPod.Name, err)
+					return err
 				}
 			} else { // This means we're on different hosts
 				log.Infof("%s@%s and %s@%s are on different hosts", localPod.Name, localPod.SrcIp, peerPod.Name, peerPod.SrcIp)
-				// Creating koko's Vxlan struct
-				vxlan := makeVxlan(srcIntf, peerPod.SrcIp, link.Uid)
 				// Checking if interface already exists
 				iExist, _ := koko.IsExistLinkInNS(myVeth.NsName, myVeth.LinkName)
 				if iExist { // If VXLAN intf exists, we need to remove it first
@@ -298,28 +585,113 @@ func cmdAdd(args *skel.CmdArgs) error {
 						return err
 					}
 				}
-				if err = koko.MakeVxLan(*myVeth, *vxlan); err != nil {
-					log.Infof("Error when creating a Vxlan interface with koko: %s", err)
+				if link.Secret != "" {
+					// a secure link is a WireGuard tunnel with the keys of its Secret, which only
+					// the daemon reads, so it sets up the local end as well
+					var resp *mpb.BoolResponse
+					resp, err = mpb.NewRemoteClient(conn).Update(ctx, &mpb.RemotePod{
+						NetNs:      args.Netns,
+						IntfName:   link.LocalIntf,
+						IntfIp:     link.LocalIp,
+						PeerVtep:   peerPod.SrcIp,
+						Vni:        link.Uid + vxlanBase,
+						KubeNs:     string(cniArgs.K8S_POD_NAMESPACE),
+						PodName:    localPod.Name,
+						TunnelType: mpb.TunnelType_WIREGUARD,
+					})
+					if err == nil && !resp.Response {
+						err = fmt.Errorf("meshnetd failed to set up the WireGuard tunnel of link %d", link.Uid)
+					}
+				} else if len(link.Srv6Segments) > 0 {
+					// the traffic is encapsulated in the pod's netns and terminated on the node
+					if peerLink := findLink(peerPod, link.Uid); peerLink == nil || len(peerLink.Srv6Segments) == 0 {
+						err = fmt.Errorf("SRv6 link %d needs segments at both ends", link.Uid)
+					} else {
+						err = srv6.CreateOrUpdate(&mpb.RemotePod{
+							NetNs:        args.Netns,
+							IntfName:     link.LocalIntf,
+							IntfIp:       link.LocalIp,
+							Srv6Segments: link.Srv6Segments,
+							Srv6LocalSid: link.Srv6LocalSid,
+						})
+					}
+				} else if link.VxlanGpe || link.LocalMac != "" || link.PeerMac != "" {
+					// koko can't create GPE interfaces or set MACs and neighbors, so the daemon's implementation is used instead
+					err = vxlan.CreateOrUpdate(&mpb.RemotePod{
+						NetNs:     args.Netns,
+						IntfName:  link.LocalIntf,
+						IntfIp:    link.LocalIp,
+						PeerVtep:  peerPod.SrcIp,
+						Vni:       link.Uid + vxlanBase,
+						VxlanGpe:  link.VxlanGpe,
+						IntfMac:   link.LocalMac,
+						Neighbors: vxlan.Neighbors(link.PeerIp, link.PeerMac, peerPod.SrcIp),
+					})
+				} else {
+					err = koko.MakeVxLan(*myVeth, *makeVxlan(srcIntf, peerPod.SrcIp, link.Uid))
+				}
+				if err != nil {
+					log.Infof("Error when creating a Vxlan interface: %s", err)
+					return err
+				}
+				wireType := "vxlan"
+				if link.Secret != "" {
+					wireType = "wireguard"
+				} else if len(link.Srv6Segments) > 0 {
+					wireType = "srv6"
+				}
+				auditWire(ctx, meshnetClient, localPod, link.Uid, wireType, "CNI_ADD")
+				if err = configureEnd(ctx, localPod, localPod, link); err != nil {
 					return err
 				}
 
 				// Now we need to make an API call to update the remote VTEP to point to us
 				payload := &mpb.RemotePod{
-					NetNs:    peerPod.NetNs,
-					IntfName: link.PeerIntf,
-					IntfIp:   link.PeerIp,
-					PeerVtep: localPod.SrcIp,
-					Vni:      link.Uid + vxlanBase,
-					KubeNs:   string(cniArgs.K8S_POD_NAMESPACE),
+					NetNs:     peerPod.NetNs,
+					IntfName:  link.PeerIntf,
+					IntfIp:    link.PeerIp,
+					PeerVtep:  localPod.SrcIp,
+					Vni:       link.Uid + vxlanBase,
+					KubeNs:    string(cniArgs.K8S_POD_NAMESPACE),
+					PodName:   peerPod.Name,
+					VxlanGpe:  link.VxlanGpe,
+					MplsLabel: link.MplsLabel,
+					IntfMac:   link.PeerMac,
+					Mtu:       link.Mtu,
+					Neighbors: vxlan.Neighbors(link.LocalIp, link.LocalMac, localPod.SrcIp),
+					// The peer's daemon sets up the wires of the peer in UID order
+					PrerequisiteUids: prerequisites(peerPod, link.Uid),
+				}
+				// The remote daemon reads the keys of the peer's end of a secure link itself
+				if link.Secret != "" {
+					payload.TunnelType = mpb.TunnelType_WIREGUARD
+				}
+				// The remote daemon applies the impairments and the traffic class of the peer's end of the link
+				if peerLink := findLink(peerPod, link.Uid); peerLink != nil {
+					if len(link.Srv6Segments) > 0 {
+						payload.TunnelType = mpb.TunnelType_SRV6
+						payload.Srv6Segments = peerLink.Srv6Segments
+						payload.Srv6LocalSid = peerLink.Srv6LocalSid
+					}
+					payload.EgressImpairment = peerLink.EgressImpairment
+					payload.IngressImpairment = peerLink.IngressImpairment
+					payload.TrafficClass = peerLink.TrafficClass
+					payload.EgressQosMap = peerLink.EgressQosMap
+					payload.EgressQueueAllocation = peerLink.EgressQueueAllocation
 				}
 
-				url := fmt.Sprintf("%s:%s", peerPod.SrcIp, defaultPort)
+				url := net.JoinHostPort(peerPod.SrcIp, defaultPort)
 				log.Infof("Trying to do a remote update on %s", url)
 
-				remote, err := grpc.Dial(url, grpc.WithInsecure())
-				if err != nil {
-					log.Infof("Failed to dial remote gRPC url %s", url)
-					return err
+				remote, found := remotes[url]
+				if !found {
+					remote, err = grpc.Dial(url, dialOpts...)
+					if err != nil {
+						log.Infof("Failed to dial remote gRPC url %s", url)
+						return err
+					}
+					defer remote.Close()
+					remotes[url] = remote
 				}
 				remoteClient := mpb.NewRemoteClient(remote)
 				ok, err := remoteClient.Update(ctx, payload)
@@ -346,6 +718,12 @@ func cmdAdd(args *skel.CmdArgs) error {
 		}
 	}
 
+	// Groups with a link to a peer that isn't up yet are routed once the peer sets it up
+	if err := ecmp.Apply(args.Netns, localPod.Links); err != nil {
+		log.Infof("Failed to add ECMP routes: %s", err)
+		return err
+	}
+
 	return types.PrintResult(result, n.CNIVersion)
 }
 
@@ -366,9 +744,10 @@ func cmdDel(args *skel.CmdArgs) error {
 		return err
 	}
 
-	conn, err := grpc.Dial(localDaemon, grpc.WithInsecure())
+	daemonAddr := localDaemonAddr()
+	conn, err := grpc.Dial(daemonAddr, dialOpts...)
 	if err != nil {
-		log.Infof("Failed to connect to local meshnetd on %s", localDaemon)
+		log.Infof("Failed to connect to local meshnetd on %s", daemonAddr)
 		return err
 	}
 	defer conn.Close()
@@ -389,11 +768,35 @@ func cmdDel(args *skel.CmdArgs) error {
 	// By setting srcIP and NetNS to "" we're marking this POD as dead
 	localPod.NetNs = ""
 	localPod.SrcIp = ""
+	localPod.ContainerId = ""
 	meshnetClient.SetAlive(ctx, localPod)
 
 	log.Infof("Iterating over each link for clean-up")
 	for _, link := range localPod.Links { // Iterate over each link of the local pod
 
+		if link.SriovVfPciAddr != "" {
+			log.Infof("Returning VF %s to the host", link.SriovVfPciAddr)
+			if err := sriov.Detach(args.Netns, link.LocalIntf); err != nil {
+				log.Infof("Error returning VF %s: %s", link.SriovVfPciAddr, err)
+			}
+			continue
+		}
+
+		// The bridge port of an OVS link outlives the pod's end of the veth
+		if localPod.OvsBridge != "" && link.PeerPod != localhost {
+			if err := ovs.Detach(args.Netns, link.LocalIntf, localPod.OvsBridge); err != nil {
+				log.Infof("Error removing OVS port of %s: %s", link.LocalIntf, err)
+			}
+		}
+
+		if localPod.CiliumSocket != "" {
+			if err := cilium.NewClient(localPod.CiliumSocket).Deregister(ctx, cilium.Endpoint{
+				KubeNs: localPod.KubeNs, Pod: localPod.Name, NetNs: args.Netns, Intf: link.LocalIntf,
+			}); err != nil {
+				log.Infof("Error deregistering %s from Cilium: %s", link.LocalIntf, err)
+			}
+		}
+
 		// Creating koko's Veth struct for local intf
 		myVeth, err := makeVeth(args.Netns, link.LocalIntf, link.LocalIp)
 		if err != nil {
@@ -420,6 +823,14 @@ func cmdDel(args *skel.CmdArgs) error {
 			return err
 		}
 	}
+
+	// Releasing the link IPs allocated by the daemon, if the namespace has an IPAM pool
+	if _, err := meshnetClient.ReleaseIPAM(ctx, &mpb.PodQuery{
+		Name:   localPod.Name,
+		KubeNs: string(cniArgs.K8S_POD_NAMESPACE),
+	}); err != nil {
+		log.Infof("Failed to release the link IPs of pod %s: %s", localPod.Name, err)
+	}
 	return nil
 }
 